@@ -56,6 +56,12 @@ func main() {
 		controllers.NewStaticFilesController(app.HashFsAssets()),
 		controllers.NewGraphQLController(app),
 	)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go app.Outbox().Start(jobsCtx)
+	for _, job := range app.Jobs() {
+		go job.Start(jobsCtx)
+	}
 	options := &server.DefaultOptions{
 		Logger:        logger,
 		Configuration: conf,
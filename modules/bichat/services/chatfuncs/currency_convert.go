@@ -1,41 +1,41 @@
 package chatfuncs
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/llm/gpt-functions"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var SupportedCurrencies = []string{
-	"AUD", "AZN", "GBP",
-	"AMD", "BYN", "BGN",
-	"BRL", "HUF", "VND",
-	"HKD", "GEL", "DKK",
-	"AED", "USD", "EUR",
-	"EGP", "INR", "IDR",
-	"KZT", "CAD", "QAR",
-	"KGS", "CNY", "MDL",
-	"NZD", "NOK", "PLN",
-	"RON", "XDR", "SGD",
-	"TJS", "THB", "TRY",
-	"TMT", "UZS", "UAH",
-	"CZK", "SEK", "CHF",
-	"RSD", "ZAR", "KRW",
-	"JPY",
+func SupportedCurrencies() []string {
+	codes := make([]string, 0, len(currency.ValidCodes))
+	for _, c := range currency.ValidCodes {
+		codes = append(codes, string(c))
+	}
+	return codes
 }
 
-func NewCurrencyConvert() functions.ChatFunctionDefinition {
-	return currencyConvert{}
+func NewCurrencyConvert(currencyService *coreservices.CurrencyService, pool *pgxpool.Pool) functions.ChatFunctionDefinition {
+	return currencyConvert{currencyService: currencyService, pool: pool}
 }
 
-type currencyConvert struct{}
+type currencyConvert struct {
+	currencyService *coreservices.CurrencyService
+	pool            *pgxpool.Pool
+}
 
 func (c currencyConvert) Name() string {
 	return "currency_convert"
 }
 
 func (c currencyConvert) Description() string {
-	return "Converts currency"
+	return "Converts currency using the exchange rates stored in the system"
 }
 
 func (c currencyConvert) Arguments() map[string]interface{} {
@@ -48,14 +48,18 @@ func (c currencyConvert) Arguments() map[string]interface{} {
 			},
 			"from": map[string]interface{}{
 				"type":        "string",
-				"enum":        SupportedCurrencies,
+				"enum":        SupportedCurrencies(),
 				"description": "Currency to convert from.",
 			},
 			"to": map[string]interface{}{
 				"type":        "string",
-				"enum":        SupportedCurrencies,
+				"enum":        SupportedCurrencies(),
 				"description": "Currency to convert to.",
 			},
+			"date": map[string]interface{}{
+				"type":        "string",
+				"description": "Date of the rate in YYYY-MM-DD format. Defaults to today.",
+			},
 		},
 	}
 }
@@ -73,12 +77,30 @@ func (c currencyConvert) Execute(args map[string]interface{}) (string, error) {
 	if !ok {
 		return "", errors.New("to is required")
 	}
-	rate, err := GetExchangeRate(from, to)
+	fromCode, err := currency.NewCode(from)
+	if err != nil {
+		return "", err
+	}
+	toCode, err := currency.NewCode(to)
+	if err != nil {
+		return "", err
+	}
+	at := time.Now()
+	if date, ok := args["date"].(string); ok && date != "" {
+		at, err = time.Parse(time.DateOnly, date)
+		if err != nil {
+			return "", err
+		}
+	}
+	ctx := composables.WithPool(context.Background(), c.pool)
+	result, rate, err := c.currencyService.Convert(ctx, amount, fromCode, toCode, at)
 	if err != nil {
 		return "", err
 	}
 	jsonBytes, err := json.Marshal(map[string]interface{}{
-		"result": amount / rate,
+		"result":    result,
+		"rate":      rate.Rate,
+		"rate_date": rate.Date.Format(time.DateOnly),
 	})
 	if err != nil {
 		return "", err
@@ -1,13 +1,10 @@
 package chatfuncs
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/iota-uz/iota-sdk/pkg/llm/gpt-functions"
-	"log"
-	"net/http"
 
 	"gorm.io/gorm"
 )
@@ -22,39 +19,6 @@ var SupportedUnits = []string{
 	"kg/m3", "g/cm3", "mg/mm3", "t/km3", "lb/ft3", "oz/in3", "oz/yd3", "t/m3",
 }
 
-func GetExchangeRate(from string, to string) (float64, error) {
-	req, err := http.NewRequestWithContext(
-		context.Background(),
-		http.MethodGet,
-		"https://www.cbr-xml-daily.ru/latest.js",
-		nil,
-	)
-	if err != nil {
-		return 0, err
-	}
-	response, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return 0, err
-	}
-	defer func() {
-		if err := response.Body.Close(); err != nil {
-			log.Println(err)
-		}
-	}()
-	if response.StatusCode != http.StatusOK {
-		return 0, errors.New("failed to get exchange rates")
-	}
-	var responseData struct {
-		Rates map[string]float64 `json:"rates"`
-	}
-	if err := json.NewDecoder(response.Body).Decode(&responseData); err != nil {
-		return 0, err
-	}
-	exchangeRates := responseData.Rates
-	exchangeRates["RUB"] = 1
-	return exchangeRates[from] / exchangeRates[to], nil
-}
-
 func NewUnitConversion(db *gorm.DB) functions.ChatFunctionDefinition {
 	return &unitConversion{db: db}
 }
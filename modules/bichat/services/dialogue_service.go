@@ -11,6 +11,8 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/bichat/domain/entities/llm"
 	"github.com/iota-uz/iota-sdk/modules/bichat/domain/entities/prompt"
 	"github.com/iota-uz/iota-sdk/modules/bichat/infrastructure/llmproviders"
+	"github.com/iota-uz/iota-sdk/modules/bichat/services/chatfuncs"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
 
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	localComposables "github.com/iota-uz/iota-sdk/pkg/composables"
@@ -37,7 +39,10 @@ var (
 func NewDialogueService(repo dialogue.Repository, app application.Application) *DialogueService {
 	chatFuncs := functions.New()
 
-	// chatFuncs.Add(chatfuncs.NewCurrencyConvert())
+	chatFuncs.Add(chatfuncs.NewCurrencyConvert(
+		app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService),
+		app.DB(),
+	))
 	// chatFuncs.Add(chatfuncs.NewDoSQLQuery(app.DB))
 	chatFuncs.Add(NewSearchKnowledgeBase(app.Service(EmbeddingService{}).(*EmbeddingService)))
 	return &DialogueService{
@@ -0,0 +1,52 @@
+package exchangerate
+
+import (
+	"math"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+)
+
+// ExchangeRate is the price of one unit of Base expressed in Quote, effective from Date.
+type ExchangeRate struct {
+	ID        uint
+	Date      time.Time
+	Base      currency.Code
+	Quote     currency.Code
+	Rate      float64
+	CreatedAt time.Time
+}
+
+func New(date time.Time, base, quote currency.Code, rate float64) (*ExchangeRate, error) {
+	if !base.IsValid() || !quote.IsValid() || base == quote {
+		return nil, ErrInvalidPair
+	}
+	if rate <= 0 {
+		return nil, ErrInvalidRate
+	}
+	return &ExchangeRate{
+		ID:        0,
+		Date:      date,
+		Base:      base,
+		Quote:     quote,
+		Rate:      rate,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Inverse returns the rate of Quote expressed in Base.
+func (r *ExchangeRate) Inverse() *ExchangeRate {
+	return &ExchangeRate{
+		ID:        r.ID,
+		Date:      r.Date,
+		Base:      r.Quote,
+		Quote:     r.Base,
+		Rate:      1 / r.Rate,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// Convert converts an amount of Base into Quote rounded to the cent.
+func (r *ExchangeRate) Convert(amount float64) float64 {
+	return math.Round(amount*r.Rate*100) / 100
+}
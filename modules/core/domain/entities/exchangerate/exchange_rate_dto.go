@@ -0,0 +1,42 @@
+package exchangerate
+
+import (
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type CreateDTO struct {
+	Date  shared.DateOnly `validate:"required"`
+	Base  string          `validate:"required,len=3"`
+	Quote string          `validate:"required,len=3,nefield=Base"`
+	Rate  float64         `validate:"required,gt=0"`
+}
+
+func (d *CreateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *CreateDTO) ToEntity() (*ExchangeRate, error) {
+	base, err := currency.NewCode(d.Base)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := currency.NewCode(d.Quote)
+	if err != nil {
+		return nil, err
+	}
+	return New(time.Time(d.Date), base, quote, d.Rate)
+}
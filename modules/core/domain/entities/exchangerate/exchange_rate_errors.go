@@ -0,0 +1,47 @@
+package exchangerate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+var (
+	ErrInvalidPair = errors.New("invalid currency pair")
+	ErrInvalidRate = errors.New("exchange rate must be positive")
+)
+
+type ErrRateNotFound struct {
+	serrors.BaseError
+	Base  currency.Code
+	Quote currency.Code
+	Date  time.Time
+}
+
+func NewErrRateNotFound(base, quote currency.Code, date time.Time) *ErrRateNotFound {
+	return &ErrRateNotFound{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_EXCHANGE_RATE_NOT_FOUND",
+			Message: "no exchange rate of " + string(base) + "/" + string(quote) + " on " + date.Format(time.DateOnly),
+		},
+		Base:  base,
+		Quote: quote,
+		Date:  date,
+	}
+}
+
+func (e *ErrRateNotFound) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Base":  e.Base,
+			"Quote": e.Quote,
+			"Date":  e.Date.Format(time.DateOnly),
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package exchangerate
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func NewCreatedEvent(ctx context.Context, data CreateDTO) (*CreatedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Data:    data,
+	}, nil
+}
+
+type CreatedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Data    CreateDTO
+	Result  ExchangeRate
+}
@@ -0,0 +1,26 @@
+package exchangerate
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+)
+
+type FindParams struct {
+	Base   currency.Code
+	Quote  currency.Code
+	Limit  int
+	Offset int
+	SortBy []string
+}
+
+type Repository interface {
+	Count(ctx context.Context) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*ExchangeRate, error)
+	// GetLatest returns the most recent rate of the pair effective on or before at.
+	GetLatest(ctx context.Context, base, quote currency.Code, at time.Time) (*ExchangeRate, error)
+	// Upsert stores the rate, replacing the one recorded for the same pair and date.
+	Upsert(ctx context.Context, rate *ExchangeRate) error
+	Delete(ctx context.Context, id uint) error
+}
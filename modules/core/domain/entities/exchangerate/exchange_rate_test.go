@@ -0,0 +1,47 @@
+package exchangerate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  currency.Code
+		quote currency.Code
+		rate  float64
+		err   error
+	}{
+		{name: "valid", base: currency.UsdCode, quote: currency.EurCode, rate: 0.92},
+		{name: "same currency", base: currency.UsdCode, quote: currency.UsdCode, rate: 1, err: exchangerate.ErrInvalidPair},
+		{name: "unknown currency", base: "XXX", quote: currency.UsdCode, rate: 1, err: exchangerate.ErrInvalidPair},
+		{name: "zero rate", base: currency.UsdCode, quote: currency.EurCode, rate: 0, err: exchangerate.ErrInvalidRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := exchangerate.New(time.Now(), tt.base, tt.quote, tt.rate)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestExchangeRate_Inverse(t *testing.T) {
+	rate, err := exchangerate.New(time.Now(), currency.EurCode, currency.UsdCode, 1.25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inverse := rate.Inverse()
+	if inverse.Base != currency.UsdCode || inverse.Quote != currency.EurCode {
+		t.Errorf("expected USD/EUR, got %s/%s", inverse.Base, inverse.Quote)
+	}
+	if got := inverse.Convert(100); got != 80 {
+		t.Errorf("expected 80, got %v", got)
+	}
+}
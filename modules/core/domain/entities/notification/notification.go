@@ -0,0 +1,26 @@
+package notification
+
+import "time"
+
+type Notification struct {
+	ID        uint
+	UserID    uint
+	Type      string
+	Title     string
+	Body      string
+	Link      string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+func (n *Notification) MarkAsRead() {
+	if n.ReadAt != nil {
+		return
+	}
+	now := time.Now()
+	n.ReadAt = &now
+}
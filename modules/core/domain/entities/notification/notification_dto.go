@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SendDTO describes a notification to send. When UserIDs is empty the notification is
+// broadcast to every user allowed to receive the type.
+type SendDTO struct {
+	Type    string
+	UserIDs []uint
+	Data    map[string]interface{}
+	Link    string
+}
+
+// PreferencesDTO is the notification settings form of a user.
+type PreferencesDTO struct {
+	TelegramChatID string
+	// Channels chosen for each notification type, keyed by the type name
+	Channels map[string][]string
+}
+
+func (d *PreferencesDTO) ToEntities(userID uint, types []Type) ([]*Preference, *Settings, error) {
+	settings := &Settings{UserID: userID}
+	if chatID := strings.TrimSpace(d.TelegramChatID); chatID != "" {
+		v, err := strconv.ParseInt(chatID, 10, 64)
+		if err != nil {
+			return nil, nil, ErrInvalidTelegramChatID
+		}
+		settings.TelegramChatID = v
+	}
+	preferences := make([]*Preference, 0, len(types))
+	for _, t := range types {
+		channels := make([]Channel, 0, len(d.Channels[t.Name]))
+		for _, v := range d.Channels[t.Name] {
+			c := Channel(v)
+			if !slices.Contains(Channels, c) {
+				return nil, nil, ErrUnknownChannel
+			}
+			if !slices.Contains(channels, c) {
+				channels = append(channels, c)
+			}
+		}
+		preferences = append(preferences, &Preference{
+			UserID:   userID,
+			Type:     t.Name,
+			Channels: channels,
+		})
+	}
+	return preferences, settings, nil
+}
@@ -0,0 +1,9 @@
+package notification
+
+import "errors"
+
+var (
+	ErrUnknownType           = errors.New("unknown notification type")
+	ErrUnknownChannel        = errors.New("unknown notification channel")
+	ErrInvalidTelegramChatID = errors.New("invalid telegram chat id")
+)
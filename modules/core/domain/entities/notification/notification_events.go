@@ -0,0 +1,9 @@
+package notification
+
+import "context"
+
+// Sender delivers a notification through an external channel.
+type Sender interface {
+	Channel() Channel
+	Send(ctx context.Context, delivery Delivery) error
+}
@@ -0,0 +1,26 @@
+package notification
+
+import "context"
+
+type FindParams struct {
+	UserID uint
+	Unread bool
+	Limit  int
+	Offset int
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Notification, error)
+	GetByID(ctx context.Context, id uint) (*Notification, error)
+	Create(ctx context.Context, data *Notification) error
+	MarkAsRead(ctx context.Context, data *Notification) error
+	MarkAllAsRead(ctx context.Context, userID uint) error
+}
+
+type PreferenceRepository interface {
+	GetByUserID(ctx context.Context, userID uint) ([]*Preference, error)
+	Save(ctx context.Context, userID uint, preferences []*Preference) error
+	GetSettings(ctx context.Context, userID uint) (*Settings, error)
+	SaveSettings(ctx context.Context, settings *Settings) error
+}
@@ -0,0 +1,63 @@
+package notification_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+)
+
+func TestNotification_MarkAsRead(t *testing.T) {
+	n := &notification.Notification{}
+	if n.IsRead() {
+		t.Fatal("new notification should be unread")
+	}
+	n.MarkAsRead()
+	if !n.IsRead() {
+		t.Fatal("notification should be read")
+	}
+	readAt := *n.ReadAt
+	n.MarkAsRead()
+	if !n.ReadAt.Equal(readAt) {
+		t.Errorf("expected read time to stay %v, got %v", readAt, *n.ReadAt)
+	}
+}
+
+func TestPreferencesDTO_ToEntities(t *testing.T) {
+	types := []notification.Type{
+		{Name: "a", Defaults: []notification.Channel{notification.InApp}},
+		{Name: "b", Defaults: []notification.Channel{notification.InApp}},
+	}
+	dto := &notification.PreferencesDTO{
+		TelegramChatID: " -100123 ",
+		Channels: map[string][]string{
+			"a": {"email", "telegram", "email"},
+		},
+	}
+	preferences, settings, err := dto.ToEntities(1, types)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.TelegramChatID != -100123 {
+		t.Errorf("expected chat id -100123, got %d", settings.TelegramChatID)
+	}
+	if len(preferences) != 2 {
+		t.Fatalf("expected 2 preferences, got %d", len(preferences))
+	}
+	if len(preferences[0].Channels) != 2 || !preferences[0].Has(notification.Email) || preferences[0].Has(notification.InApp) {
+		t.Errorf("unexpected channels %v", preferences[0].Channels)
+	}
+	if len(preferences[1].Channels) != 0 {
+		t.Errorf("unchecked type should have no channels, got %v", preferences[1].Channels)
+	}
+
+	dto.Channels["b"] = []string{"sms"}
+	if _, _, err := dto.ToEntities(1, types); !errors.Is(err, notification.ErrUnknownChannel) {
+		t.Errorf("expected ErrUnknownChannel, got %v", err)
+	}
+	dto.Channels["b"] = nil
+	dto.TelegramChatID = "@me"
+	if _, _, err := dto.ToEntities(1, types); !errors.Is(err, notification.ErrInvalidTelegramChatID) {
+		t.Errorf("expected ErrInvalidTelegramChatID, got %v", err)
+	}
+}
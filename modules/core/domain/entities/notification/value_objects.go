@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"slices"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+)
+
+type Channel string
+
+const (
+	InApp    Channel = "in_app"
+	Email    Channel = "email"
+	Telegram Channel = "telegram"
+)
+
+var Channels = []Channel{InApp, Email, Telegram}
+
+// Type describes a kind of notification. Title and body are rendered from the
+// Notifications.Types.<Name>.Title and Notifications.Types.<Name>.Body messages
+// in the language of the recipient.
+type Type struct {
+	Name string
+	// Defaults are the channels used until the user saves own preferences.
+	Defaults []Channel
+	// Permission, when set, restricts the recipients of broadcasts to users having it.
+	Permission *permission.Permission
+}
+
+func (t Type) TitleMessageID() string {
+	return "Notifications.Types." + t.Name + ".Title"
+}
+
+func (t Type) BodyMessageID() string {
+	return "Notifications.Types." + t.Name + ".Body"
+}
+
+// Preference is the set of channels a user has chosen for a notification type.
+type Preference struct {
+	UserID   uint
+	Type     string
+	Channels []Channel
+}
+
+func (p *Preference) Has(channel Channel) bool {
+	return slices.Contains(p.Channels, channel)
+}
+
+// Settings holds the per-user delivery addresses that are not part of the user profile.
+type Settings struct {
+	UserID         uint
+	TelegramChatID int64
+}
+
+// Delivery is a rendered notification sent through an external channel.
+type Delivery struct {
+	Channel Channel `json:"channel"`
+	To      string  `json:"to"`
+	Title   string  `json:"title"`
+	Body    string  `json:"body"`
+	Link    string  `json:"link"`
+}
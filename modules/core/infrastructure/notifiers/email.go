@@ -0,0 +1,65 @@
+package notifiers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+)
+
+// SMTPConfig holds the mailbox notifications are sent from
+type SMTPConfig struct {
+	// host:port of the SMTP server
+	Address  string
+	Username string
+	Password string
+	// Address notifications are sent from, the username when empty
+	From string
+}
+
+func NewEmailSender(config SMTPConfig) *EmailSender {
+	if config.From == "" {
+		config.From = config.Username
+	}
+	return &EmailSender{config: config}
+}
+
+// EmailSender sends notifications as plain text emails to the address of the user
+type EmailSender struct {
+	config SMTPConfig
+}
+
+func (s *EmailSender) Channel() notification.Channel {
+	return notification.Email
+}
+
+func (s *EmailSender) Send(ctx context.Context, delivery notification.Delivery) error {
+	host, _, err := net.SplitHostPort(s.config.Address)
+	if err != nil {
+		return fmt.Errorf("invalid smtp address: %w", err)
+	}
+	body := delivery.Body
+	if delivery.Link != "" {
+		body += "\n\n" + delivery.Link
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", delivery.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", delivery.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	auth := smtp.PlainAuth("", s.config.Username, s.config.Password, host)
+	if err := smtp.SendMail(s.config.Address, auth, s.config.From, []string{delivery.To}, msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
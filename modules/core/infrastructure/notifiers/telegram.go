@@ -0,0 +1,46 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+)
+
+func NewTelegramSender(token string) (*TelegramSender, error) {
+	client, err := gotgbot.NewBot(token, &gotgbot.BotOpts{DisableTokenCheck: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bot: %w", err)
+	}
+	return &TelegramSender{client: client}, nil
+}
+
+// TelegramSender sends notifications to the chat a user has linked in the notification settings
+type TelegramSender struct {
+	client *gotgbot.Bot
+}
+
+func (s *TelegramSender) Channel() notification.Channel {
+	return notification.Telegram
+}
+
+func (s *TelegramSender) Send(ctx context.Context, delivery notification.Delivery) error {
+	chatID, err := strconv.ParseInt(delivery.To, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid telegram chat id %q: %w", delivery.To, err)
+	}
+	text := delivery.Title
+	if delivery.Body != "" {
+		text += "\n\n" + delivery.Body
+	}
+	if delivery.Link != "" {
+		text += "\n\n" + delivery.Link
+	}
+	if _, err := s.client.SendMessageWithContext(ctx, chatID, text, nil); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return nil
+}
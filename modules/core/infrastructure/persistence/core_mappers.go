@@ -10,7 +10,9 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/authlog"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/country"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/internet"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/position"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
@@ -267,6 +269,36 @@ func ToDomainCurrency(dbCurrency *models.Currency) (*currency.Currency, error) {
 	}, nil
 }
 
+func ToDBExchangeRate(entity *exchangerate.ExchangeRate) *models.ExchangeRate {
+	return &models.ExchangeRate{
+		ID:        entity.ID,
+		RateDate:  entity.Date,
+		BaseCode:  string(entity.Base),
+		QuoteCode: string(entity.Quote),
+		Rate:      entity.Rate,
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+func ToDomainExchangeRate(dbRate *models.ExchangeRate) (*exchangerate.ExchangeRate, error) {
+	base, err := currency.NewCode(dbRate.BaseCode)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := currency.NewCode(dbRate.QuoteCode)
+	if err != nil {
+		return nil, err
+	}
+	return &exchangerate.ExchangeRate{
+		ID:        dbRate.ID,
+		Date:      dbRate.RateDate,
+		Base:      base,
+		Quote:     quote,
+		Rate:      dbRate.Rate,
+		CreatedAt: dbRate.CreatedAt,
+	}, nil
+}
+
 func ToDBTab(tab *tab.Tab) *models.Tab {
 	return &models.Tab{
 		ID:       tab.ID,
@@ -285,6 +317,56 @@ func ToDomainTab(dbTab *models.Tab) (*tab.Tab, error) {
 	}, nil
 }
 
+func ToDBNotification(entity *notification.Notification) *models.Notification {
+	return &models.Notification{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		Type:      entity.Type,
+		Title:     entity.Title,
+		Body:      entity.Body,
+		Link:      entity.Link,
+		ReadAt:    mapping.PointerToSQLNullTime(entity.ReadAt),
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+func ToDomainNotification(dbNotification *models.Notification) *notification.Notification {
+	return &notification.Notification{
+		ID:        dbNotification.ID,
+		UserID:    dbNotification.UserID,
+		Type:      dbNotification.Type,
+		Title:     dbNotification.Title,
+		Body:      dbNotification.Body,
+		Link:      dbNotification.Link,
+		ReadAt:    mapping.SQLNullTimeToPointer(dbNotification.ReadAt),
+		CreatedAt: dbNotification.CreatedAt,
+	}
+}
+
+func ToDBNotificationPreference(entity *notification.Preference) *models.NotificationPreference {
+	channels := make([]string, 0, len(entity.Channels))
+	for _, c := range entity.Channels {
+		channels = append(channels, string(c))
+	}
+	return &models.NotificationPreference{
+		UserID:   entity.UserID,
+		Type:     entity.Type,
+		Channels: channels,
+	}
+}
+
+func ToDomainNotificationPreference(dbPreference *models.NotificationPreference) *notification.Preference {
+	channels := make([]notification.Channel, 0, len(dbPreference.Channels))
+	for _, c := range dbPreference.Channels {
+		channels = append(channels, notification.Channel(c))
+	}
+	return &notification.Preference{
+		UserID:   dbPreference.UserID,
+		Type:     dbPreference.Type,
+		Channels: channels,
+	}
+}
+
 func toDomainPosition(dbPosition *models.Position) (*position.Position, error) {
 	return &position.Position{
 		ID:          dbPosition.ID,
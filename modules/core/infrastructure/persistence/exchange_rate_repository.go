@@ -0,0 +1,154 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+)
+
+const (
+	exchangeRateFindQuery = `
+		SELECT id, rate_date, base_code, quote_code, rate, created_at
+		FROM exchange_rates`
+	exchangeRateCountQuery  = `SELECT COUNT(*) as count FROM exchange_rates`
+	exchangeRateUpsertQuery = `
+		INSERT INTO exchange_rates (rate_date, base_code, quote_code, rate, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (rate_date, base_code, quote_code) DO UPDATE SET rate = EXCLUDED.rate
+		RETURNING id`
+	exchangeRateDeleteQuery = `DELETE FROM exchange_rates WHERE id = $1`
+)
+
+type GormExchangeRateRepository struct{}
+
+func NewExchangeRateRepository() exchangerate.Repository {
+	return &GormExchangeRateRepository{}
+}
+
+func (g *GormExchangeRateRepository) GetPaginated(
+	ctx context.Context, params *exchangerate.FindParams,
+) ([]*exchangerate.ExchangeRate, error) {
+	var args []interface{}
+	where := []string{"1 = 1"}
+	if params.Base != "" {
+		args = append(args, params.Base)
+		where = append(where, fmt.Sprintf("base_code = $%d", len(args)))
+	}
+	if params.Quote != "" {
+		args = append(args, params.Quote)
+		where = append(where, fmt.Sprintf("quote_code = $%d", len(args)))
+	}
+	q := repo.Join(
+		exchangeRateFindQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY rate_date DESC, id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryRates(ctx, q, args...)
+}
+
+func (g *GormExchangeRateRepository) Count(ctx context.Context) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, exchangeRateCountQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormExchangeRateRepository) GetLatest(
+	ctx context.Context, base, quote currency.Code, at time.Time,
+) (*exchangerate.ExchangeRate, error) {
+	rates, err := g.queryRates(
+		ctx,
+		repo.Join(
+			exchangeRateFindQuery,
+			"WHERE base_code = $1 AND quote_code = $2 AND rate_date <= $3",
+			"ORDER BY rate_date DESC LIMIT 1",
+		),
+		base,
+		quote,
+		at,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest exchange rate")
+	}
+	if len(rates) == 0 {
+		return nil, ErrExchangeRateNotFound
+	}
+	return rates[0], nil
+}
+
+func (g *GormExchangeRateRepository) Upsert(ctx context.Context, data *exchangerate.ExchangeRate) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbRate := ToDBExchangeRate(data)
+	return tx.QueryRow(
+		ctx,
+		exchangeRateUpsertQuery,
+		dbRate.RateDate,
+		dbRate.BaseCode,
+		dbRate.QuoteCode,
+		dbRate.Rate,
+		dbRate.CreatedAt,
+	).Scan(&data.ID)
+}
+
+func (g *GormExchangeRateRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, exchangeRateDeleteQuery, id)
+	return err
+}
+
+func (g *GormExchangeRateRepository) queryRates(
+	ctx context.Context, query string, args ...interface{},
+) ([]*exchangerate.ExchangeRate, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbRows []*models.ExchangeRate
+	for rows.Next() {
+		r := &models.ExchangeRate{}
+		if err := rows.Scan(
+			&r.ID,
+			&r.RateDate,
+			&r.BaseCode,
+			&r.QuoteCode,
+			&r.Rate,
+			&r.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbRows = append(dbRows, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbRows, ToDomainExchangeRate)
+}
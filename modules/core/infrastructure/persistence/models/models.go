@@ -23,6 +23,15 @@ type Currency struct {
 	UpdatedAt time.Time
 }
 
+type ExchangeRate struct {
+	ID        uint
+	RateDate  time.Time
+	BaseCode  string
+	QuoteCode string
+	Rate      float64
+	CreatedAt time.Time
+}
+
 type Position struct {
 	ID          uint
 	Name        string
@@ -328,3 +337,20 @@ type Tab struct {
 	Position uint
 	UserID   uint
 }
+
+type Notification struct {
+	ID        uint
+	UserID    uint
+	Type      string
+	Title     string
+	Body      string
+	Link      string
+	ReadAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type NotificationPreference struct {
+	UserID   uint
+	Type     string
+	Channels []string
+}
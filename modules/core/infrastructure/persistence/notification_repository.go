@@ -0,0 +1,254 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+const (
+	notificationFindQuery = `
+		SELECT id,
+		user_id,
+		type,
+		title,
+		body,
+		link,
+		read_at,
+		created_at
+		FROM notifications`
+	notificationCountQuery  = `SELECT COUNT(*) as count FROM notifications`
+	notificationInsertQuery = `
+		INSERT INTO notifications (
+			user_id,
+			type,
+			title,
+			body,
+			link,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	notificationReadQuery    = `UPDATE notifications SET read_at = $1 WHERE id = $2`
+	notificationReadAllQuery = `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL`
+
+	notificationPreferenceFindQuery   = `SELECT user_id, type, channels FROM notification_preferences WHERE user_id = $1`
+	notificationPreferenceDeleteQuery = `DELETE FROM notification_preferences WHERE user_id = $1`
+	notificationPreferenceInsertQuery = `INSERT INTO notification_preferences (user_id, type, channels) VALUES ($1, $2, $3)`
+	notificationSettingsFindQuery     = `SELECT user_id, telegram_chat_id FROM notification_settings WHERE user_id = $1`
+	notificationSettingsUpsertQuery   = `
+		INSERT INTO notification_settings (user_id, telegram_chat_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET telegram_chat_id = EXCLUDED.telegram_chat_id`
+)
+
+type GormNotificationRepository struct{}
+
+func NewNotificationRepository() notification.Repository {
+	return &GormNotificationRepository{}
+}
+
+func (g *GormNotificationRepository) buildFilters(params *notification.FindParams) ([]string, []interface{}) {
+	var args []interface{}
+	where := []string{"1 = 1"}
+	if params.UserID != 0 {
+		where = append(where, fmt.Sprintf("user_id = $%d", len(args)+1))
+		args = append(args, params.UserID)
+	}
+	if params.Unread {
+		where = append(where, "read_at IS NULL")
+	}
+	return where, args
+}
+
+func (g *GormNotificationRepository) Count(ctx context.Context, params *notification.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := g.buildFilters(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(notificationCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormNotificationRepository) GetPaginated(ctx context.Context, params *notification.FindParams) ([]*notification.Notification, error) {
+	where, args := g.buildFilters(params)
+	q := repo.Join(
+		notificationFindQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY created_at DESC, id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryNotifications(ctx, q, args...)
+}
+
+func (g *GormNotificationRepository) GetByID(ctx context.Context, id uint) (*notification.Notification, error) {
+	notifications, err := g.queryNotifications(ctx, repo.Join(notificationFindQuery, "WHERE id = $1"), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get notification by id")
+	}
+	if len(notifications) == 0 {
+		return nil, ErrNotificationNotFound
+	}
+	return notifications[0], nil
+}
+
+func (g *GormNotificationRepository) Create(ctx context.Context, data *notification.Notification) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbNotification := ToDBNotification(data)
+	return tx.QueryRow(
+		ctx,
+		notificationInsertQuery,
+		dbNotification.UserID,
+		dbNotification.Type,
+		dbNotification.Title,
+		dbNotification.Body,
+		dbNotification.Link,
+		dbNotification.CreatedAt,
+	).Scan(&data.ID)
+}
+
+func (g *GormNotificationRepository) MarkAsRead(ctx context.Context, data *notification.Notification) error {
+	dbNotification := ToDBNotification(data)
+	return g.execQuery(ctx, notificationReadQuery, dbNotification.ReadAt, dbNotification.ID)
+}
+
+func (g *GormNotificationRepository) MarkAllAsRead(ctx context.Context, userID uint) error {
+	return g.execQuery(ctx, notificationReadAllQuery, time.Now(), userID)
+}
+
+func (g *GormNotificationRepository) queryNotifications(ctx context.Context, query string, args ...interface{}) ([]*notification.Notification, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*notification.Notification
+	for rows.Next() {
+		var n models.Notification
+		if err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.Type,
+			&n.Title,
+			&n.Body,
+			&n.Link,
+			&n.ReadAt,
+			&n.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, ToDomainNotification(&n))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (g *GormNotificationRepository) execQuery(ctx context.Context, query string, args ...interface{}) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
+
+type GormNotificationPreferenceRepository struct{}
+
+func NewNotificationPreferenceRepository() notification.PreferenceRepository {
+	return &GormNotificationPreferenceRepository{}
+}
+
+func (g *GormNotificationPreferenceRepository) GetByUserID(ctx context.Context, userID uint) ([]*notification.Preference, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, notificationPreferenceFindQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var preferences []*notification.Preference
+	for rows.Next() {
+		var p models.NotificationPreference
+		if err := rows.Scan(&p.UserID, &p.Type, &p.Channels); err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, ToDomainNotificationPreference(&p))
+	}
+	return preferences, rows.Err()
+}
+
+func (g *GormNotificationPreferenceRepository) Save(ctx context.Context, userID uint, preferences []*notification.Preference) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, notificationPreferenceDeleteQuery, userID); err != nil {
+		return err
+	}
+	for _, p := range preferences {
+		dbPreference := ToDBNotificationPreference(p)
+		if _, err := tx.Exec(ctx, notificationPreferenceInsertQuery, userID, dbPreference.Type, dbPreference.Channels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GormNotificationPreferenceRepository) GetSettings(ctx context.Context, userID uint) (*notification.Settings, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var chatID *int64
+	if err := tx.QueryRow(ctx, notificationSettingsFindQuery, userID).Scan(&userID, &chatID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &notification.Settings{UserID: userID}, nil
+		}
+		return nil, err
+	}
+	return &notification.Settings{
+		UserID:         userID,
+		TelegramChatID: mapping.Value(chatID),
+	}, nil
+}
+
+func (g *GormNotificationPreferenceRepository) SaveSettings(ctx context.Context, settings *notification.Settings) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, notificationSettingsUpsertQuery, settings.UserID, mapping.ValueToSQLNullInt64(settings.TelegramChatID))
+	return err
+}
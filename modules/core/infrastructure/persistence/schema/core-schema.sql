@@ -38,6 +38,17 @@ CREATE TABLE currencies
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
+CREATE TABLE exchange_rates
+(
+    id         SERIAL PRIMARY KEY,
+    rate_date  DATE           NOT NULL,
+    base_code  VARCHAR(3)     NOT NULL REFERENCES currencies (code) ON DELETE CASCADE,
+    quote_code VARCHAR(3)     NOT NULL REFERENCES currencies (code) ON DELETE CASCADE,
+    rate       NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    UNIQUE (rate_date, base_code, quote_code)
+);
+
 CREATE TABLE employees
 (
     id                 SERIAL PRIMARY KEY,
@@ -189,9 +200,58 @@ CREATE TABLE tabs
     UNIQUE (href, user_id)
 );
 
+CREATE TABLE outbox_events
+(
+    id              SERIAL PRIMARY KEY,
+    topic           VARCHAR(255)             NOT NULL,
+    payload         JSONB                    NOT NULL,
+    attempts        INT                      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error      TEXT,
+    processed_at    TIMESTAMP WITH TIME ZONE,
+    failed_at       TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE outbox_deliveries
+(
+    event_id     INT                      NOT NULL REFERENCES outbox_events (id) ON DELETE CASCADE,
+    subscriber   VARCHAR(255)             NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, subscriber)
+);
+
+CREATE TABLE notifications
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INT          NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type       VARCHAR(255) NOT NULL,
+    title      VARCHAR(255) NOT NULL,
+    body       TEXT         NOT NULL DEFAULT '',
+    link       VARCHAR(1024) NOT NULL DEFAULT '',
+    read_at    TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE notification_preferences
+(
+    user_id  INT          NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type     VARCHAR(255) NOT NULL,
+    channels VARCHAR(32)[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (user_id, type)
+);
+
+CREATE TABLE notification_settings
+(
+    user_id          INT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    telegram_chat_id BIGINT
+);
+
 CREATE INDEX users_first_name_idx ON users (first_name);
 CREATE INDEX users_last_name_idx ON users (last_name);
 
+CREATE INDEX exchange_rates_pair_date_idx ON exchange_rates (base_code, quote_code, rate_date);
+
 CREATE INDEX sessions_user_id_idx ON sessions (user_id);
 CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
 
@@ -202,8 +262,20 @@ CREATE INDEX uploaded_images_upload_id_idx ON uploaded_images (upload_id);
 
 CREATE INDEX employees_avatar_id_idx ON employees (avatar_id);
 
+CREATE INDEX notifications_user_id_idx ON notifications (user_id, created_at DESC);
+
+CREATE INDEX notifications_unread_idx ON notifications (user_id) WHERE read_at IS NULL;
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (next_attempt_at) WHERE processed_at IS NULL AND failed_at IS NULL;
+
 -- +migrate Down
+DROP TABLE IF EXISTS notification_settings CASCADE;
+DROP TABLE IF EXISTS notification_preferences CASCADE;
+DROP TABLE IF EXISTS notifications CASCADE;
+DROP TABLE IF EXISTS outbox_deliveries CASCADE;
+DROP TABLE IF EXISTS outbox_events CASCADE;
 DROP TABLE IF EXISTS companies CASCADE;
+DROP TABLE IF EXISTS exchange_rates CASCADE;
 DROP TABLE IF EXISTS currencies CASCADE;
 DROP TABLE IF EXISTS employee_contacts CASCADE;
 DROP TABLE IF EXISTS employee_meta CASCADE;
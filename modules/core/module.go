@@ -4,6 +4,11 @@ import (
 	"embed"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/notifiers"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
 
 	icons "github.com/iota-uz/icons/phosphor"
@@ -41,19 +46,51 @@ func (m *Module) Register(app application.Application) error {
 	app.RegisterSeedFuncs(
 		seed.CreatePermissions,
 		seed.CreateCurrencies,
+		seed.CreateExchangeRates,
 		seed.UserSeedFunc("test@gmail.com", "TestPass123!", user.UILanguageEN),
 	)
 	fsStorage, err := persistence.NewFSStorage()
 	if err != nil {
 		return err
 	}
+	conf := configuration.Use()
+	var senders []notification.Sender
+	if conf.SMTPAddress != "" {
+		senders = append(senders, notifiers.NewEmailSender(notifiers.SMTPConfig{
+			Address:  conf.SMTPAddress,
+			Username: conf.SMTPUsername,
+			Password: conf.SMTPPassword,
+			From:     conf.SMTPFrom,
+		}))
+	}
+	if conf.TelegramBotToken != "" {
+		telegramSender, err := notifiers.NewTelegramSender(conf.TelegramBotToken)
+		if err != nil {
+			return err
+		}
+		senders = append(senders, telegramSender)
+	}
+	notificationService := services.NewNotificationService(
+		persistence.NewNotificationRepository(),
+		persistence.NewNotificationPreferenceRepository(),
+		persistence.NewUserRepository(),
+		app.Bundle(),
+		conf.Origin,
+		senders...,
+	)
+	eventbus.SubscribeExternal(app.Outbox(), events.NotificationDeliveryTopic, "core.notification_delivery", notificationService.Deliver)
 	app.RegisterServices(
 		services.NewUserService(persistence.NewUserRepository(), app.EventPublisher()),
 		services.NewSessionService(persistence.NewSessionRepository(), app.EventPublisher()),
+		notificationService,
 	)
 	app.RegisterServices(
 		services.NewAuthService(app),
-		services.NewCurrencyService(persistence.NewCurrencyRepository(), app.EventPublisher()),
+		services.NewCurrencyService(
+			persistence.NewCurrencyRepository(),
+			persistence.NewExchangeRateRepository(),
+			app.EventPublisher(),
+		),
 		services.NewRoleService(persistence.NewRoleRepository(), app.EventPublisher()),
 		services.NewPositionService(persistence.NewPositionRepository(), app.EventPublisher()),
 		services.NewEmployeeService(persistence.NewEmployeeRepository(), app.EventPublisher()),
@@ -64,12 +101,14 @@ func (m *Module) Register(app application.Application) error {
 		controllers.NewDashboardController(app),
 		controllers.NewLoginController(app),
 		controllers.NewSpotlightController(app),
+		controllers.NewWebsocketController(app),
 		controllers.NewAccountController(app),
 		controllers.NewEmployeeController(app),
 		controllers.NewLogoutController(app),
 		controllers.NewUploadController(app),
 		controllers.NewUsersController(app),
 		controllers.NewRolesController(app),
+		controllers.NewNotificationController(app),
 		controllers.NewEmployeeController(app),
 	)
 	app.RegisterHashFsAssets(assets.HashFS)
@@ -7,10 +7,11 @@ import (
 )
 
 const (
-	ResourceUser     permission.Resource = "user"
-	ResourceRole     permission.Resource = "role"
-	ResourceEmployee permission.Resource = "employee"
-	ResourceUpload   permission.Resource = "upload"
+	ResourceUser         permission.Resource = "user"
+	ResourceRole         permission.Resource = "role"
+	ResourceEmployee     permission.Resource = "employee"
+	ResourceUpload       permission.Resource = "upload"
+	ResourceExchangeRate permission.Resource = "exchange_rate"
 )
 
 var (
@@ -98,6 +99,20 @@ var (
 		Action:   permission.ActionDelete,
 		Modifier: permission.ModifierAll,
 	}
+	ExchangeRateRead = &permission.Permission{
+		ID:       uuid.MustParse("dfa3959d-5cf7-47be-b283-cde968a1afa0"),
+		Name:     "ExchangeRate.Read",
+		Resource: ResourceExchangeRate,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	ExchangeRateUpdate = &permission.Permission{
+		ID:       uuid.MustParse("4d76c27f-3827-4c22-8632-f5f15099eefb"),
+		Name:     "ExchangeRate.Update",
+		Resource: ResourceExchangeRate,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
 )
 
 var Permissions = []*permission.Permission{
@@ -113,4 +128,6 @@ var Permissions = []*permission.Permission{
 	EmployeeRead,
 	EmployeeUpdate,
 	EmployeeDelete,
+	ExchangeRateRead,
+	ExchangeRateUpdate,
 }
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/pages/notifications"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type NotificationListQuery struct {
+	Unread bool
+}
+
+type NotificationController struct {
+	app                 application.Application
+	notificationService *services.NotificationService
+	basePath            string
+}
+
+func NewNotificationController(app application.Application) application.Controller {
+	return &NotificationController{
+		app:                 app,
+		notificationService: app.Service(services.NotificationService{}).(*services.NotificationService),
+		basePath:            "/notifications",
+	}
+}
+
+func (c *NotificationController) Key() string {
+	return c.basePath
+}
+
+func (c *NotificationController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/badge", c.Badge).Methods(http.MethodGet)
+	getRouter.HandleFunc("/settings", c.GetSettings).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("/read", c.MarkAllAsRead).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/read", c.MarkAsRead).Methods(http.MethodPost)
+	setRouter.HandleFunc("/settings", c.PostSettings).Methods(http.MethodPost)
+}
+
+func (c *NotificationController) List(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&NotificationListQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	paginationParams := composables.UsePaginated(r)
+	params := &notification.FindParams{
+		UserID: u.ID(),
+		Unread: query.Unread,
+		Limit:  paginationParams.Limit,
+		Offset: paginationParams.Offset,
+	}
+	entities, err := c.notificationService.GetPaginated(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	total, err := c.notificationService.Count(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &notifications.IndexPageProps{
+		Notifications:   mapping.MapViewModels(entities, mappers.NotificationToViewModel),
+		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
+		Unread:          query.Unread,
+		BasePath:        c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(notifications.NotificationsList(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(notifications.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *NotificationController) Badge(w http.ResponseWriter, r *http.Request) {
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	count, err := c.notificationService.CountUnread(r.Context(), u.ID())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(layouts.NotificationBadge(count)).ServeHTTP(w, r)
+}
+
+func (c *NotificationController) MarkAsRead(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	entity, err := c.notificationService.MarkAsRead(r.Context(), u.ID(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if entity.Link != "" {
+		shared.Redirect(w, r, entity.Link)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *NotificationController) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := c.notificationService.MarkAllAsRead(r.Context(), u.ID()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *NotificationController) settingsProps(r *http.Request, errorsMap map[string]string) (*notifications.SettingsPageProps, error) {
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	preferences, settings, err := c.notificationService.GetPreferences(r.Context(), u.ID())
+	if err != nil {
+		return nil, err
+	}
+	channels := make([]string, 0, len(notification.Channels))
+	for _, ch := range c.notificationService.Channels() {
+		channels = append(channels, string(ch))
+	}
+	telegramChatID := ""
+	if settings.TelegramChatID != 0 {
+		telegramChatID = strconv.FormatInt(settings.TelegramChatID, 10)
+	}
+	if errorsMap == nil {
+		errorsMap = map[string]string{}
+	}
+	return &notifications.SettingsPageProps{
+		Preferences:    mapping.MapViewModels(preferences, mappers.NotificationPreferenceToViewModel),
+		Channels:       channels,
+		TelegramChatID: telegramChatID,
+		Errors:         errorsMap,
+		PostPath:       c.basePath + "/settings",
+	}, nil
+}
+
+func (c *NotificationController) GetSettings(w http.ResponseWriter, r *http.Request) {
+	props, err := c.settingsProps(r, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(notifications.Settings(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *NotificationController) PostSettings(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	dto := &notification.PreferencesDTO{
+		TelegramChatID: r.PostForm.Get("TelegramChatID"),
+		Channels:       make(map[string][]string),
+	}
+	for _, t := range c.notificationService.Types() {
+		dto.Channels[t.Name] = r.PostForm[t.Name]
+	}
+	if err := c.notificationService.SavePreferences(r.Context(), u.ID(), dto); err != nil {
+		if !errors.Is(err, notification.ErrInvalidTelegramChatID) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		props, err := c.settingsProps(r, map[string]string{
+			"TelegramChatID": composables.MustT(r.Context(), "Notifications.Preferences.InvalidTelegramChatID"),
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Preferences = preferencesFromDTO(dto, c.notificationService.Types())
+		props.TelegramChatID = dto.TelegramChatID
+		templ.Handler(notifications.SettingsForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	shared.Redirect(w, r, c.basePath+"/settings")
+}
+
+// preferencesFromDTO keeps the submitted choices when the form is rendered again with errors.
+func preferencesFromDTO(dto *notification.PreferencesDTO, types []notification.Type) []*viewmodels.NotificationPreference {
+	preferences := make([]*viewmodels.NotificationPreference, 0, len(types))
+	for _, t := range types {
+		preferences = append(preferences, &viewmodels.NotificationPreference{
+			Type:     t.Name,
+			Channels: dto.Channels[t.Name],
+		})
+	}
+	return preferences
+}
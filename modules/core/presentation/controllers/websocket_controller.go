@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+)
+
+// WebsocketController serves the websocket hub of the application, every module broadcasts its
+// updates through it.
+type WebsocketController struct {
+	app      application.Application
+	basePath string
+}
+
+func NewWebsocketController(app application.Application) application.Controller {
+	return &WebsocketController{
+		app:      app,
+		basePath: "/ws",
+	}
+}
+
+func (c *WebsocketController) Key() string {
+	return c.basePath
+}
+
+func (c *WebsocketController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.ProvideUser(),
+	)
+	router.Handle("", c.app.Websocket())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tab"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/assets"
@@ -93,6 +94,29 @@ func TabToViewModel(entity *tab.Tab) *viewmodels.Tab {
 	}
 }
 
+func NotificationToViewModel(entity *notification.Notification) *viewmodels.Notification {
+	return &viewmodels.Notification{
+		ID:        strconv.FormatUint(uint64(entity.ID), 10),
+		Type:      entity.Type,
+		Title:     entity.Title,
+		Body:      entity.Body,
+		Link:      entity.Link,
+		Read:      entity.IsRead(),
+		CreatedAt: entity.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NotificationPreferenceToViewModel(entity *notification.Preference) *viewmodels.NotificationPreference {
+	channels := make([]string, 0, len(entity.Channels))
+	for _, c := range entity.Channels {
+		channels = append(channels, string(c))
+	}
+	return &viewmodels.NotificationPreference{
+		Type:     entity.Type,
+		Channels: channels,
+	}
+}
+
 func RoleToViewModel(entity role.Role) *viewmodels.Role {
 	return &viewmodels.Role{
 		ID:          strconv.FormatUint(uint64(entity.ID()), 10),
@@ -1,5 +1,7 @@
 package viewmodels
 
+import "slices"
+
 type ProjectStage struct {
 	ID        string
 	Name      string
@@ -63,3 +65,22 @@ type Tab struct {
 	ID   string
 	Href string
 }
+
+type Notification struct {
+	ID        string
+	Type      string
+	Title     string
+	Body      string
+	Link      string
+	Read      bool
+	CreatedAt string
+}
+
+type NotificationPreference struct {
+	Type     string
+	Channels []string
+}
+
+func (p *NotificationPreference) Has(channel string) bool {
+	return slices.Contains(p.Channels, channel)
+}
@@ -0,0 +1,78 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// usdRates are reference prices of one US dollar, they only give a fresh install a rate to start
+// from and are meant to be replaced with real ones on the exchange rates page.
+var usdRates = map[currency.Code]float64{
+	currency.UsdCode: 1,
+	currency.EurCode: 0.91,
+	currency.GbpCode: 0.79,
+	currency.AudCode: 1.47,
+	currency.CadCode: 1.33,
+	currency.ChfCode: 0.84,
+	currency.CnyCode: 7.1,
+	currency.JpyCode: 141,
+	currency.RubCode: 90,
+	currency.TryCode: 30,
+	currency.SomCode: 12340,
+}
+
+// referenceRateDate dates the seeded rates before any document so that they apply to all of them
+// until a real rate is set.
+var referenceRateDate = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// CreateExchangeRates seeds a rate from every currency to the base currency unless the pair is
+// already stored in either direction.
+func CreateExchangeRates(ctx context.Context, app application.Application) error {
+	rateRepository := persistence.NewExchangeRateRepository()
+	base := currency.Code(configuration.Use().BaseCurrency)
+	baseRate, ok := usdRates[base]
+	if !ok {
+		return nil
+	}
+	for _, c := range currency.Currencies {
+		if c.Code == base {
+			continue
+		}
+		stored, err := hasRate(ctx, rateRepository, c.Code, base)
+		if err != nil {
+			return err
+		}
+		if stored {
+			continue
+		}
+		rate, err := exchangerate.New(referenceRateDate, c.Code, base, baseRate/usdRates[c.Code])
+		if err != nil {
+			return err
+		}
+		if err := rateRepository.Upsert(ctx, rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasRate(ctx context.Context, repo exchangerate.Repository, base, quote currency.Code) (bool, error) {
+	now := time.Now()
+	for _, pair := range [][2]currency.Code{{base, quote}, {quote, base}} {
+		_, err := repo.GetLatest(ctx, pair[0], pair[1], now)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, persistence.ErrExchangeRateNotFound) {
+			return false, err
+		}
+	}
+	return false, nil
+}
@@ -2,19 +2,31 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-faster/errors"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/core/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 )
 
 type CurrencyService struct {
 	Repo      currency.Repository
+	RateRepo  exchangerate.Repository
 	Publisher eventbus.EventBus
 }
 
-func NewCurrencyService(repo currency.Repository, publisher eventbus.EventBus) *CurrencyService {
+func NewCurrencyService(
+	repo currency.Repository,
+	rateRepo exchangerate.Repository,
+	publisher eventbus.EventBus,
+) *CurrencyService {
 	return &CurrencyService{
 		Repo:      repo,
+		RateRepo:  rateRepo,
 		Publisher: publisher,
 	}
 }
@@ -83,3 +95,69 @@ func (s *CurrencyService) Delete(ctx context.Context, code string) (*currency.Cu
 	s.Publisher.Publish(deletedEvent)
 	return entity, nil
 }
+
+func (s *CurrencyService) GetRates(
+	ctx context.Context, params *exchangerate.FindParams,
+) ([]*exchangerate.ExchangeRate, error) {
+	if err := composables.CanUser(ctx, permissions.ExchangeRateRead); err != nil {
+		return nil, err
+	}
+	return s.RateRepo.GetPaginated(ctx, params)
+}
+
+// GetRate returns the rate of base expressed in quote effective at the given date.
+// A stored rate of the opposite pair is inverted when the direct pair is missing,
+// exchangerate.ErrRateNotFound is returned when neither pair is stored.
+func (s *CurrencyService) GetRate(
+	ctx context.Context, base, quote currency.Code, at time.Time,
+) (*exchangerate.ExchangeRate, error) {
+	if base == quote {
+		return &exchangerate.ExchangeRate{Date: at, Base: base, Quote: quote, Rate: 1}, nil
+	}
+	rate, err := s.RateRepo.GetLatest(ctx, base, quote, at)
+	if err == nil {
+		return rate, nil
+	}
+	if !errors.Is(err, persistence.ErrExchangeRateNotFound) {
+		return nil, err
+	}
+	inverse, err := s.RateRepo.GetLatest(ctx, quote, base, at)
+	if errors.Is(err, persistence.ErrExchangeRateNotFound) {
+		return nil, exchangerate.NewErrRateNotFound(base, quote, at)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return inverse.Inverse(), nil
+}
+
+// Convert converts an amount between currencies and returns the rate it used.
+func (s *CurrencyService) Convert(
+	ctx context.Context, amount float64, from, to currency.Code, at time.Time,
+) (float64, *exchangerate.ExchangeRate, error) {
+	rate, err := s.GetRate(ctx, from, to, at)
+	if err != nil {
+		return 0, nil, err
+	}
+	return rate.Convert(amount), rate, nil
+}
+
+func (s *CurrencyService) SetRate(ctx context.Context, data *exchangerate.CreateDTO) (*exchangerate.ExchangeRate, error) {
+	if err := composables.CanUser(ctx, permissions.ExchangeRateUpdate); err != nil {
+		return nil, err
+	}
+	createdEvent, err := exchangerate.NewCreatedEvent(ctx, *data)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.RateRepo.Upsert(ctx, entity); err != nil {
+		return nil, err
+	}
+	createdEvent.Result = *entity
+	s.Publisher.Publish(createdEvent)
+	return entity, nil
+}
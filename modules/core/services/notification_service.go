@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+type NotificationService struct {
+	repo           notification.Repository
+	preferenceRepo notification.PreferenceRepository
+	userRepo       user.Repository
+	bundle         *i18n.Bundle
+	origin         string
+	senders        map[notification.Channel]notification.Sender
+	mu             sync.RWMutex
+	types          []notification.Type
+}
+
+// NewNotificationService creates the service. Links of notifications sent through the
+// senders are prefixed with origin, in-app notifications keep them relative.
+func NewNotificationService(
+	repo notification.Repository,
+	preferenceRepo notification.PreferenceRepository,
+	userRepo user.Repository,
+	bundle *i18n.Bundle,
+	origin string,
+	senders ...notification.Sender,
+) *NotificationService {
+	s := &NotificationService{
+		repo:           repo,
+		preferenceRepo: preferenceRepo,
+		userRepo:       userRepo,
+		bundle:         bundle,
+		origin:         origin,
+		senders:        make(map[notification.Channel]notification.Sender, len(senders)),
+	}
+	for _, sender := range senders {
+		s.senders[sender.Channel()] = sender
+	}
+	return s
+}
+
+// RegisterTypes makes notification types known to the service. Modules register the types
+// they send when they are registered themselves.
+func (s *NotificationService) RegisterTypes(types ...notification.Type) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.types = append(s.types, types...)
+}
+
+func (s *NotificationService) Types() []notification.Type {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.types)
+}
+
+func (s *NotificationService) typeByName(name string) (notification.Type, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, t := range s.types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return notification.Type{}, false
+}
+
+// Channels returns the channels users can choose from: in-app and the ones with a sender.
+func (s *NotificationService) Channels() []notification.Channel {
+	channels := []notification.Channel{notification.InApp}
+	for _, c := range notification.Channels {
+		if _, ok := s.senders[c]; ok {
+			channels = append(channels, c)
+		}
+	}
+	return channels
+}
+
+// Send notifies the recipients through the channels they have chosen for the type. In-app
+// notifications are stored right away, the other channels are delivered through the outbox.
+func (s *NotificationService) Send(ctx context.Context, data notification.SendDTO) error {
+	t, ok := s.typeByName(data.Type)
+	if !ok {
+		return fmt.Errorf("%w: %s", notification.ErrUnknownType, data.Type)
+	}
+	recipients, err := s.recipients(ctx, t, data.UserIDs)
+	if err != nil {
+		return err
+	}
+	for _, u := range recipients {
+		if err := s.notify(ctx, t, u, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) recipients(ctx context.Context, t notification.Type, ids []uint) ([]user.User, error) {
+	if len(ids) > 0 {
+		users := make([]user.User, 0, len(ids))
+		for _, id := range ids {
+			u, err := s.userRepo.GetByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, u)
+		}
+		return users, nil
+	}
+	users, err := s.userRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if t.Permission == nil {
+		return users, nil
+	}
+	allowed := make([]user.User, 0, len(users))
+	for _, u := range users {
+		if u.Can(t.Permission) {
+			allowed = append(allowed, u)
+		}
+	}
+	return allowed, nil
+}
+
+func (s *NotificationService) notify(ctx context.Context, t notification.Type, u user.User, data notification.SendDTO) error {
+	channels, err := s.userChannels(ctx, u.ID(), t)
+	if err != nil {
+		return err
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+	localizer := i18n.NewLocalizer(s.bundle, string(u.UILanguage()))
+	title, err := s.localize(localizer, t.TitleMessageID(), data.Data)
+	if err != nil {
+		return err
+	}
+	body, err := s.localize(localizer, t.BodyMessageID(), data.Data)
+	if err != nil {
+		return err
+	}
+	for _, c := range channels {
+		switch c {
+		case notification.InApp:
+			if err := s.repo.Create(ctx, &notification.Notification{
+				UserID:    u.ID(),
+				Type:      t.Name,
+				Title:     title,
+				Body:      body,
+				Link:      data.Link,
+				CreatedAt: time.Now(),
+			}); err != nil {
+				return err
+			}
+		case notification.Email:
+			if u.Email() == "" {
+				continue
+			}
+			if err := s.enqueue(ctx, c, u.Email(), title, body, data.Link); err != nil {
+				return err
+			}
+		case notification.Telegram:
+			settings, err := s.preferenceRepo.GetSettings(ctx, u.ID())
+			if err != nil {
+				return err
+			}
+			if settings.TelegramChatID == 0 {
+				continue
+			}
+			if err := s.enqueue(ctx, c, strconv.FormatInt(settings.TelegramChatID, 10), title, body, data.Link); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) enqueue(ctx context.Context, channel notification.Channel, to, title, body, link string) error {
+	if _, ok := s.senders[channel]; !ok {
+		return nil
+	}
+	if link != "" {
+		link = s.origin + link
+	}
+	return eventbus.Enqueue(ctx, events.NotificationDeliveryTopic, events.NotificationDelivery{
+		Channel: string(channel),
+		To:      to,
+		Title:   title,
+		Body:    body,
+		Link:    link,
+	})
+}
+
+// localize renders a message of a notification type. A message missing in the language of
+// the user falls back to the default language, which go-i18n reports as an error.
+func (s *NotificationService) localize(localizer *i18n.Localizer, id string, data map[string]interface{}) (string, error) {
+	msg, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:    id,
+		TemplateData: data,
+	})
+	if msg == "" && err != nil {
+		return "", err
+	}
+	return msg, nil
+}
+
+// userChannels returns the channels the user has chosen for the type, or its defaults.
+func (s *NotificationService) userChannels(ctx context.Context, userID uint, t notification.Type) ([]notification.Channel, error) {
+	preferences, err := s.preferenceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range preferences {
+		if p.Type == t.Name {
+			return p.Channels, nil
+		}
+	}
+	return t.Defaults, nil
+}
+
+// Deliver sends a notification through its channel. It is subscribed to events.NotificationDeliveryTopic
+// as an external subscriber, so it runs outside of any transaction.
+func (s *NotificationService) Deliver(ctx context.Context, payload events.NotificationDelivery) error {
+	channel := notification.Channel(payload.Channel)
+	sender, ok := s.senders[channel]
+	if !ok {
+		log.Printf("Dropping %s notification: the channel is not configured", channel)
+		return nil
+	}
+	return sender.Send(ctx, notification.Delivery{
+		Channel: channel,
+		To:      payload.To,
+		Title:   payload.Title,
+		Body:    payload.Body,
+		Link:    payload.Link,
+	})
+}
+
+func (s *NotificationService) GetPaginated(ctx context.Context, params *notification.FindParams) ([]*notification.Notification, error) {
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *NotificationService) Count(ctx context.Context, params *notification.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	return s.repo.Count(ctx, &notification.FindParams{UserID: userID, Unread: true})
+}
+
+// MarkAsRead marks a notification of the user as read and returns it.
+func (s *NotificationService) MarkAsRead(ctx context.Context, userID, id uint) (*notification.Notification, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.UserID != userID {
+		return nil, fmt.Errorf("notification %d does not belong to user %d", id, userID)
+	}
+	if entity.IsRead() {
+		return entity, nil
+	}
+	entity.MarkAsRead()
+	if err := s.repo.MarkAsRead(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID uint) error {
+	return s.repo.MarkAllAsRead(ctx, userID)
+}
+
+// GetPreferences returns a preference for every registered type, filled with the type
+// defaults where the user has not chosen yet, and the settings of the user.
+func (s *NotificationService) GetPreferences(ctx context.Context, userID uint) ([]*notification.Preference, *notification.Settings, error) {
+	saved, err := s.preferenceRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	types := s.Types()
+	preferences := make([]*notification.Preference, 0, len(types))
+	for _, t := range types {
+		p := &notification.Preference{
+			UserID:   userID,
+			Type:     t.Name,
+			Channels: t.Defaults,
+		}
+		for _, sp := range saved {
+			if sp.Type == t.Name {
+				p = sp
+				break
+			}
+		}
+		preferences = append(preferences, p)
+	}
+	settings, err := s.preferenceRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return preferences, settings, nil
+}
+
+func (s *NotificationService) SavePreferences(ctx context.Context, userID uint, data *notification.PreferencesDTO) error {
+	preferences, settings, err := data.ToEntities(userID, s.Types())
+	if err != nil {
+		return err
+	}
+	if err := s.preferenceRepo.Save(ctx, userID, preferences); err != nil {
+		return err
+	}
+	return s.preferenceRepo.SaveSettings(ctx, settings)
+}
@@ -0,0 +1,34 @@
+package campaign
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+)
+
+// Campaign sends a message template to the clients of a segment, or to all clients when
+// SegmentID is 0, at a limited rate. The recipients are selected when the campaign starts.
+type Campaign interface {
+	ID() uint
+	Name() string
+	TemplateID() uint
+	SegmentID() uint
+	Channel() chat.Channel
+	// Locale picks the variant of the template the messages are rendered in.
+	Locale() string
+	RatePerMinute() int
+	Status() Status
+	StartedAt() *time.Time
+	CompletedAt() *time.Time
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// Update changes the settings of a draft campaign.
+	Update(name string, templateID, segmentID uint, channel chat.Channel, locale string, ratePerMinute int) error
+	Start(now time.Time) error
+	Pause() error
+	Resume() error
+	Cancel(now time.Time) error
+	// Complete finishes a running campaign once every recipient has been processed.
+	Complete(now time.Time) error
+}
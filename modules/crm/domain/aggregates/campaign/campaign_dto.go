@@ -0,0 +1,71 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+// SaveDTO is used both to create a campaign and to update a draft. SegmentID 0 targets all clients.
+type SaveDTO struct {
+	Name          string `validate:"required"`
+	TemplateID    uint   `validate:"required"`
+	SegmentID     uint
+	Channel       string `validate:"required"`
+	Locale        string
+	RatePerMinute int
+}
+
+func (d *SaveDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		panic(composables.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	if errs := constants.Validate.Struct(d); errs != nil {
+		for _, err := range errs.(validator.ValidationErrors) {
+			translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("Campaigns.Single.%s.Label", err.Field()),
+			})
+			errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+				TemplateData: map[string]string{
+					"Field": translatedFieldName,
+				},
+			})
+		}
+	}
+	if _, ok := errorMessages["Channel"]; !ok && !chat.Channel(d.Channel).IsValid() {
+		errorMessages["Channel"] = l.MustLocalize(&i18n.LocalizeConfig{MessageID: "Campaigns.Errors.Channel"})
+	}
+	if d.RatePerMinute <= 0 {
+		errorMessages["RatePerMinute"] = l.MustLocalize(&i18n.LocalizeConfig{MessageID: "Campaigns.Errors.RatePerMinute"})
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *SaveDTO) ToEntity() (Campaign, error) {
+	channel, err := chat.NewChannel(d.Channel)
+	if err != nil {
+		return nil, err
+	}
+	return New(strings.TrimSpace(d.Name), d.TemplateID, d.SegmentID, channel, d.Locale, d.RatePerMinute)
+}
+
+func (d *SaveDTO) Apply(entity Campaign) (Campaign, error) {
+	channel, err := chat.NewChannel(d.Channel)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Update(strings.TrimSpace(d.Name), d.TemplateID, d.SegmentID, channel, d.Locale, d.RatePerMinute); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
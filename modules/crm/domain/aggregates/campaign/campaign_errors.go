@@ -0,0 +1,12 @@
+package campaign
+
+import "errors"
+
+var (
+	ErrInvalidStatus     = errors.New("invalid campaign status")
+	ErrInvalidTransition = errors.New("campaign cannot change to the status")
+	ErrNotDraft          = errors.New("only draft campaigns can be changed")
+	ErrInvalidRate       = errors.New("rate must be positive")
+	ErrNoRecipients      = errors.New("campaign has no recipients")
+	ErrActive            = errors.New("campaign must be cancelled before it is deleted")
+)
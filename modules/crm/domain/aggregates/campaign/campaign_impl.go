@@ -0,0 +1,188 @@
+package campaign
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+)
+
+// New creates a draft campaign.
+func New(
+	name string,
+	templateID, segmentID uint,
+	channel chat.Channel,
+	locale string,
+	ratePerMinute int,
+) (Campaign, error) {
+	if ratePerMinute <= 0 {
+		return nil, ErrInvalidRate
+	}
+	now := time.Now()
+	return &campaign{
+		name:          name,
+		templateID:    templateID,
+		segmentID:     segmentID,
+		channel:       channel,
+		locale:        locale,
+		ratePerMinute: ratePerMinute,
+		status:        Draft,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+func NewWithID(
+	id uint,
+	name string,
+	templateID, segmentID uint,
+	channel chat.Channel,
+	locale string,
+	ratePerMinute int,
+	status Status,
+	startedAt, completedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) Campaign {
+	return &campaign{
+		id:            id,
+		name:          name,
+		templateID:    templateID,
+		segmentID:     segmentID,
+		channel:       channel,
+		locale:        locale,
+		ratePerMinute: ratePerMinute,
+		status:        status,
+		startedAt:     startedAt,
+		completedAt:   completedAt,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+type campaign struct {
+	id            uint
+	name          string
+	templateID    uint
+	segmentID     uint
+	channel       chat.Channel
+	locale        string
+	ratePerMinute int
+	status        Status
+	startedAt     *time.Time
+	completedAt   *time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+func (c *campaign) ID() uint {
+	return c.id
+}
+
+func (c *campaign) Name() string {
+	return c.name
+}
+
+func (c *campaign) TemplateID() uint {
+	return c.templateID
+}
+
+func (c *campaign) SegmentID() uint {
+	return c.segmentID
+}
+
+func (c *campaign) Channel() chat.Channel {
+	return c.channel
+}
+
+func (c *campaign) Locale() string {
+	return c.locale
+}
+
+func (c *campaign) RatePerMinute() int {
+	return c.ratePerMinute
+}
+
+func (c *campaign) Status() Status {
+	return c.status
+}
+
+func (c *campaign) StartedAt() *time.Time {
+	return c.startedAt
+}
+
+func (c *campaign) CompletedAt() *time.Time {
+	return c.completedAt
+}
+
+func (c *campaign) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *campaign) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+func (c *campaign) Update(
+	name string,
+	templateID, segmentID uint,
+	channel chat.Channel,
+	locale string,
+	ratePerMinute int,
+) error {
+	if c.status != Draft {
+		return ErrNotDraft
+	}
+	if ratePerMinute <= 0 {
+		return ErrInvalidRate
+	}
+	c.name = name
+	c.templateID = templateID
+	c.segmentID = segmentID
+	c.channel = channel
+	c.locale = locale
+	c.ratePerMinute = ratePerMinute
+	c.updatedAt = time.Now()
+	return nil
+}
+
+func (c *campaign) transition(from []Status, to Status) error {
+	for _, s := range from {
+		if c.status == s {
+			c.status = to
+			c.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrInvalidTransition
+}
+
+func (c *campaign) Start(now time.Time) error {
+	if err := c.transition([]Status{Draft}, Running); err != nil {
+		return err
+	}
+	c.startedAt = &now
+	return nil
+}
+
+func (c *campaign) Pause() error {
+	return c.transition([]Status{Running}, Paused)
+}
+
+func (c *campaign) Resume() error {
+	return c.transition([]Status{Paused}, Running)
+}
+
+func (c *campaign) Cancel(now time.Time) error {
+	if err := c.transition([]Status{Draft, Running, Paused}, Cancelled); err != nil {
+		return err
+	}
+	c.completedAt = &now
+	return nil
+}
+
+func (c *campaign) Complete(now time.Time) error {
+	if err := c.transition([]Status{Running}, Completed); err != nil {
+		return err
+	}
+	c.completedAt = &now
+	return nil
+}
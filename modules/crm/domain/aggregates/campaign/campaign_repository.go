@@ -0,0 +1,45 @@
+package campaign
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+)
+
+type FindParams struct {
+	Limit  int
+	Offset int
+	Status Status
+}
+
+type RecipientFindParams struct {
+	CampaignID uint
+	Status     RecipientStatus
+	Limit      int
+	Offset     int
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]Campaign, error)
+	GetByID(ctx context.Context, id uint) (Campaign, error)
+	Create(ctx context.Context, data Campaign) (Campaign, error)
+	Update(ctx context.Context, data Campaign) (Campaign, error)
+	Delete(ctx context.Context, id uint) error
+
+	AddRecipients(ctx context.Context, recipients []Recipient) error
+	CountRecipients(ctx context.Context, params *RecipientFindParams) (int64, error)
+	GetRecipients(ctx context.Context, params *RecipientFindParams) ([]Recipient, error)
+	// GetDueRecipients locks the queued recipients of a campaign whose next attempt is due, so
+	// concurrent senders skip them.
+	GetDueRecipients(ctx context.Context, campaignID uint, now time.Time, limit int) ([]Recipient, error)
+	GetRecipientByExternalID(ctx context.Context, channel chat.Channel, externalID string) (Recipient, error)
+	UpdateRecipient(ctx context.Context, data Recipient) error
+	Stats(ctx context.Context, campaignID uint) (Stats, error)
+
+	// OptOut stops campaigns on the channel from reaching the address until OptIn is called.
+	OptOut(ctx context.Context, channel chat.Channel, address string) error
+	OptIn(ctx context.Context, channel chat.Channel, address string) error
+	IsOptedOut(ctx context.Context, channel chat.Channel, address string) (bool, error)
+}
@@ -0,0 +1,103 @@
+package campaign_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/campaign"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+)
+
+func TestCampaign_Lifecycle(t *testing.T) {
+	c, err := campaign.New("Spring sale", 1, 0, chat.SMS, "en", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if err := c.Pause(); !errors.Is(err, campaign.ErrInvalidTransition) {
+		t.Errorf("expected a draft not to pause, got %v", err)
+	}
+	if err := c.Start(now); err != nil {
+		t.Fatal(err)
+	}
+	if c.Status() != campaign.Running || c.StartedAt() == nil {
+		t.Errorf("expected a running campaign, got %s", c.Status())
+	}
+	if err := c.Update("Summer sale", 1, 0, chat.SMS, "en", 30); !errors.Is(err, campaign.ErrNotDraft) {
+		t.Errorf("expected a running campaign not to change, got %v", err)
+	}
+	if err := c.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Complete(now); !errors.Is(err, campaign.ErrInvalidTransition) {
+		t.Errorf("expected a paused campaign not to complete, got %v", err)
+	}
+	if err := c.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Complete(now); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Cancel(now); !errors.Is(err, campaign.ErrInvalidTransition) {
+		t.Errorf("expected a completed campaign not to be cancelled, got %v", err)
+	}
+	if _, err := campaign.New("Broken", 1, 0, chat.SMS, "en", 0); !errors.Is(err, campaign.ErrInvalidRate) {
+		t.Errorf("expected ErrInvalidRate, got %v", err)
+	}
+}
+
+func TestRecipient_Retry(t *testing.T) {
+	now := time.Now()
+	r := campaign.NewRecipient(1, 2, "998901234567", now)
+	r.MarkAttemptFailed("timeout", now)
+	if r.Status() != campaign.Queued || !r.NextAttemptAt().Equal(now.Add(campaign.RetryBackoff)) {
+		t.Errorf("expected a retry after %s, got %s at %s", campaign.RetryBackoff, r.Status(), r.NextAttemptAt())
+	}
+	r.MarkAttemptFailed("timeout", now)
+	if !r.NextAttemptAt().Equal(now.Add(2 * campaign.RetryBackoff)) {
+		t.Errorf("expected the backoff to double, got %s", r.NextAttemptAt().Sub(now))
+	}
+	r.MarkAttemptFailed("timeout", now)
+	if r.Status() != campaign.Failed || r.Attempts() != campaign.MaxAttempts || r.Error() != "timeout" {
+		t.Errorf("expected the recipient to fail after %d attempts, got %s after %d", campaign.MaxAttempts, r.Status(), r.Attempts())
+	}
+}
+
+func TestRecipient_DeliveryReports(t *testing.T) {
+	now := time.Now()
+	r := campaign.NewRecipient(1, 2, "998901234567", now)
+	r.MarkDelivered(now)
+	if r.Status() != campaign.Queued {
+		t.Errorf("expected a report for an unsent message to be ignored, got %s", r.Status())
+	}
+	r.MarkSent("Hello", "SM123", now)
+	r.MarkDelivered(now)
+	if r.Status() != campaign.Delivered || r.DeliveredAt() == nil {
+		t.Errorf("expected the message to be delivered, got %s", r.Status())
+	}
+	r.MarkUndelivered("carrier rejected", now)
+	if r.Status() != campaign.Failed || r.Error() != "carrier rejected" {
+		t.Errorf("expected the message to fail, got %s", r.Status())
+	}
+	r.MarkDelivered(now)
+	if r.Status() != campaign.Failed {
+		t.Errorf("expected a failed message to stay failed, got %s", r.Status())
+	}
+}
+
+func TestStopRequests(t *testing.T) {
+	for _, msg := range []string{"STOP", " stop ", "Unsubscribe!", "quit."} {
+		if !campaign.IsStopRequest(msg) {
+			t.Errorf("expected %q to be a stop request", msg)
+		}
+	}
+	for _, msg := range []string{"please stop calling", "stopped", ""} {
+		if campaign.IsStopRequest(msg) {
+			t.Errorf("expected %q not to be a stop request", msg)
+		}
+	}
+	if !campaign.IsStartRequest("start") || campaign.IsStartRequest("STOP") {
+		t.Error("unexpected start request detection")
+	}
+}
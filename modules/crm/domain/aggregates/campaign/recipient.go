@@ -0,0 +1,187 @@
+package campaign
+
+import (
+	"time"
+)
+
+// Recipient is a client a campaign sends its message to, at the address the client has on the
+// campaign's channel.
+type Recipient interface {
+	ID() uint
+	CampaignID() uint
+	ClientID() uint
+	Address() string
+	Status() RecipientStatus
+	// Message is the text sent to the client, rendered when it is sent.
+	Message() string
+	// ExternalID is the id the provider gave the message, used to match its delivery reports.
+	ExternalID() string
+	Attempts() int
+	NextAttemptAt() time.Time
+	Error() string
+	SentAt() *time.Time
+	DeliveredAt() *time.Time
+	UpdatedAt() time.Time
+
+	// MarkSent records that the provider accepted the message.
+	MarkSent(message, externalID string, now time.Time)
+	// MarkAttemptFailed records a failed attempt and queues a retry with backoff until
+	// MaxAttempts is reached, when the recipient fails.
+	MarkAttemptFailed(reason string, now time.Time)
+	// MarkDelivered and MarkUndelivered apply the delivery reports of the provider. Reports for
+	// recipients that are not sent yet, or already failed, are ignored.
+	MarkDelivered(now time.Time)
+	MarkUndelivered(reason string, now time.Time)
+	MarkOptedOut(now time.Time)
+}
+
+func NewRecipient(campaignID, clientID uint, address string, now time.Time) Recipient {
+	return &recipient{
+		campaignID:    campaignID,
+		clientID:      clientID,
+		address:       address,
+		status:        Queued,
+		nextAttemptAt: now,
+		updatedAt:     now,
+	}
+}
+
+func NewRecipientWithID(
+	id, campaignID, clientID uint,
+	address string,
+	status RecipientStatus,
+	message, externalID string,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	sentAt, deliveredAt *time.Time,
+	updatedAt time.Time,
+) Recipient {
+	return &recipient{
+		id:            id,
+		campaignID:    campaignID,
+		clientID:      clientID,
+		address:       address,
+		status:        status,
+		message:       message,
+		externalID:    externalID,
+		attempts:      attempts,
+		nextAttemptAt: nextAttemptAt,
+		lastError:     lastError,
+		sentAt:        sentAt,
+		deliveredAt:   deliveredAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+type recipient struct {
+	id            uint
+	campaignID    uint
+	clientID      uint
+	address       string
+	status        RecipientStatus
+	message       string
+	externalID    string
+	attempts      int
+	nextAttemptAt time.Time
+	lastError     string
+	sentAt        *time.Time
+	deliveredAt   *time.Time
+	updatedAt     time.Time
+}
+
+func (r *recipient) ID() uint {
+	return r.id
+}
+
+func (r *recipient) CampaignID() uint {
+	return r.campaignID
+}
+
+func (r *recipient) ClientID() uint {
+	return r.clientID
+}
+
+func (r *recipient) Address() string {
+	return r.address
+}
+
+func (r *recipient) Status() RecipientStatus {
+	return r.status
+}
+
+func (r *recipient) Message() string {
+	return r.message
+}
+
+func (r *recipient) ExternalID() string {
+	return r.externalID
+}
+
+func (r *recipient) Attempts() int {
+	return r.attempts
+}
+
+func (r *recipient) NextAttemptAt() time.Time {
+	return r.nextAttemptAt
+}
+
+func (r *recipient) Error() string {
+	return r.lastError
+}
+
+func (r *recipient) SentAt() *time.Time {
+	return r.sentAt
+}
+
+func (r *recipient) DeliveredAt() *time.Time {
+	return r.deliveredAt
+}
+
+func (r *recipient) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+func (r *recipient) MarkSent(message, externalID string, now time.Time) {
+	r.attempts++
+	r.status = Sent
+	r.message = message
+	r.externalID = externalID
+	r.lastError = ""
+	r.sentAt = &now
+	r.updatedAt = now
+}
+
+func (r *recipient) MarkAttemptFailed(reason string, now time.Time) {
+	r.attempts++
+	r.lastError = reason
+	r.updatedAt = now
+	if r.attempts >= MaxAttempts {
+		r.status = Failed
+		return
+	}
+	r.nextAttemptAt = now.Add(RetryBackoff << (r.attempts - 1))
+}
+
+func (r *recipient) MarkDelivered(now time.Time) {
+	if r.status != Sent {
+		return
+	}
+	r.status = Delivered
+	r.deliveredAt = &now
+	r.updatedAt = now
+}
+
+func (r *recipient) MarkUndelivered(reason string, now time.Time) {
+	if r.status != Sent && r.status != Delivered {
+		return
+	}
+	r.status = Failed
+	r.lastError = reason
+	r.updatedAt = now
+}
+
+func (r *recipient) MarkOptedOut(now time.Time) {
+	r.status = OptedOut
+	r.updatedAt = now
+}
@@ -0,0 +1,99 @@
+package campaign
+
+import (
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	Draft     Status = "draft"
+	Running   Status = "running"
+	Paused    Status = "paused"
+	Completed Status = "completed"
+	Cancelled Status = "cancelled"
+)
+
+var Statuses = []Status{Draft, Running, Paused, Completed, Cancelled}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Draft, Running, Paused, Completed, Cancelled:
+		return true
+	}
+	return false
+}
+
+// IsFinished is true for the campaigns that send nothing anymore.
+func (s Status) IsFinished() bool {
+	return s == Completed || s == Cancelled
+}
+
+type RecipientStatus string
+
+const (
+	// Queued recipients wait for their message, including the ones a failed attempt is retried for.
+	Queued    RecipientStatus = "queued"
+	Sent      RecipientStatus = "sent"
+	Delivered RecipientStatus = "delivered"
+	Failed    RecipientStatus = "failed"
+	// OptedOut recipients asked not to receive campaigns on the channel and are skipped.
+	OptedOut RecipientStatus = "opted_out"
+)
+
+var RecipientStatuses = []RecipientStatus{Queued, Sent, Delivered, Failed, OptedOut}
+
+func (s RecipientStatus) IsValid() bool {
+	switch s {
+	case Queued, Sent, Delivered, Failed, OptedOut:
+		return true
+	}
+	return false
+}
+
+const (
+	// DefaultRatePerMinute is how many messages a campaign sends per minute unless set otherwise.
+	DefaultRatePerMinute = 60
+	// MaxAttempts is how many times a message is tried before the recipient is marked as failed.
+	MaxAttempts = 3
+	// RetryBackoff is the delay before the first retry, doubled for every further attempt.
+	RetryBackoff = time.Minute
+)
+
+// Stats counts the recipients of a campaign by status.
+type Stats struct {
+	Queued    int
+	Sent      int
+	Delivered int
+	Failed    int
+	OptedOut  int
+}
+
+func (s Stats) Total() int {
+	return s.Queued + s.Sent + s.Delivered + s.Failed + s.OptedOut
+}
+
+// Processed is the number of recipients that are done with, successfully or not.
+func (s Stats) Processed() int {
+	return s.Total() - s.Queued
+}
+
+var (
+	stopKeywords  = map[string]bool{"STOP": true, "STOPALL": true, "UNSUBSCRIBE": true, "CANCEL": true, "END": true, "QUIT": true}
+	startKeywords = map[string]bool{"START": true, "UNSTOP": true, "SUBSCRIBE": true}
+)
+
+func keyword(message string) string {
+	return strings.ToUpper(strings.Trim(strings.TrimSpace(message), ".!"))
+}
+
+// IsStopRequest tells whether a reply asks to stop receiving campaigns, like "STOP" or "unsubscribe".
+func IsStopRequest(message string) bool {
+	return stopKeywords[keyword(message)]
+}
+
+// IsStartRequest tells whether a reply asks to receive campaigns again after opting out.
+func IsStartRequest(message string) bool {
+	return startKeywords[keyword(message)]
+}
@@ -0,0 +1,40 @@
+package chat
+
+import "errors"
+
+var ErrUnknownChannel = errors.New("unknown channel")
+
+// Channel is the messaging service a message was received or sent through.
+type Channel string
+
+const (
+	SMS      Channel = "sms"
+	Telegram Channel = "telegram"
+	WhatsApp Channel = "whatsapp"
+	Email    Channel = "email"
+)
+
+var Channels = []Channel{SMS, Telegram, WhatsApp, Email}
+
+func NewChannel(value string) (Channel, error) {
+	c := Channel(value)
+	if !c.IsValid() {
+		return "", ErrUnknownChannel
+	}
+	return c, nil
+}
+
+func (c Channel) IsValid() bool {
+	switch c {
+	case SMS, Telegram, WhatsApp, Email:
+		return true
+	}
+	return false
+}
+
+// Contact is the address a client is reached at on a channel: a phone number for SMS and
+// WhatsApp, a chat id for Telegram and an email address for email.
+type Contact struct {
+	Channel Channel
+	Address string
+}
@@ -10,12 +10,28 @@ type Chat interface {
 	ID() uint
 	ClientID() uint
 	Messages() []Message
-	AddMessage(content string, sender Sender, attachments ...*upload.Upload) (Message, error)
+	Contacts() []Contact
+	AddContact(channel Channel, address string)
+	Contact(channel Channel) (Contact, bool)
+	ReplyChannel() Channel
+	AddMessage(channel Channel, content string, sender Sender, attachments ...*upload.Upload) (Message, error)
 	UnreadMessages() int
 	MarkAllAsRead()
 	LastMessage() (Message, error)
 	LastMessageAt() *time.Time
 	CreatedAt() time.Time
+
+	Status() Status
+	// SetStatus changes the status by hand. Closing ends the cycle, reopening resumes it.
+	SetStatus(status Status) error
+	Assignment() Assignment
+	// Assign makes the user and the team responsible for the chat, zero IDs unassign.
+	Assign(userID, teamID uint)
+	Cycle() Cycle
+	FirstResponseDueAt(policy SLAPolicy) *time.Time
+	ResolutionDueAt(policy SLAPolicy) *time.Time
+	// CheckSLA flags the SLAs of the cycle missed by now and returns the ones not flagged before.
+	CheckSLA(policy SLAPolicy, now time.Time) []SLAKind
 }
 
 type Sender interface {
@@ -29,6 +45,7 @@ type Sender interface {
 type Message interface {
 	ID() uint
 	Message() string
+	Channel() Channel
 	Sender() Sender
 	IsRead() bool
 	MarkAsRead()
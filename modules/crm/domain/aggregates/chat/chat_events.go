@@ -27,6 +27,23 @@ func NewMessageAddedEvent(ctx context.Context, result Chat) (*MessagedAddedEvent
 	}, nil
 }
 
+// NewAssignedEvent creates the event of a chat assigned by the user in ctx, or automatically
+// when there is none.
+func NewAssignedEvent(ctx context.Context, result Chat) (*AssignedEvent, error) {
+	u, _ := composables.UseUser(ctx)
+	return &AssignedEvent{
+		User:   u,
+		Result: result,
+	}, nil
+}
+
+func NewSLABreachedEvent(kind SLAKind, result Chat) *SLABreachedEvent {
+	return &SLABreachedEvent{
+		Kind:   kind,
+		Result: result,
+	}
+}
+
 func NewDeletedEvent(ctx context.Context, result Chat) (*DeletedEvent, error) {
 	u, err := composables.UseUser(ctx)
 	if err != nil {
@@ -49,6 +66,16 @@ type MessagedAddedEvent struct {
 	Result Chat
 }
 
+type AssignedEvent struct {
+	User   user.User
+	Result Chat
+}
+
+type SLABreachedEvent struct {
+	Kind   SLAKind
+	Result Chat
+}
+
 type DeletedEvent struct {
 	User   user.User
 	Result Chat
@@ -20,6 +20,7 @@ func New(
 		clientID:      clientID,
 		lastMessageAt: nil,
 		createdAt:     time.Now(),
+		status:        Open,
 	}
 }
 
@@ -28,14 +29,22 @@ func NewWithID(
 	clientID uint,
 	createdAt time.Time,
 	messages []Message,
+	contacts []Contact,
 	lastMessageAt *time.Time,
+	status Status,
+	assignment Assignment,
+	cycle Cycle,
 ) Chat {
 	return &chat{
 		id:            id,
 		clientID:      clientID,
 		messages:      messages,
+		contacts:      contacts,
 		lastMessageAt: lastMessageAt,
 		createdAt:     createdAt,
+		status:        status,
+		assignment:    assignment,
+		cycle:         cycle,
 	}
 }
 
@@ -43,8 +52,12 @@ type chat struct {
 	id            uint
 	clientID      uint
 	messages      []Message
+	contacts      []Contact
 	lastMessageAt *time.Time
 	createdAt     time.Time
+	status        Status
+	assignment    Assignment
+	cycle         Cycle
 }
 
 func (c *chat) ID() uint {
@@ -59,6 +72,42 @@ func (c *chat) ClientID() uint {
 	return c.clientID
 }
 
+func (c *chat) Contacts() []Contact {
+	return c.contacts
+}
+
+// AddContact makes the client reachable at the address on the channel. A chat keeps a single
+// address per channel, so a new address replaces the previous one.
+func (c *chat) AddContact(channel Channel, address string) {
+	for i, contact := range c.contacts {
+		if contact.Channel == channel {
+			c.contacts[i].Address = address
+			return
+		}
+	}
+	c.contacts = append(c.contacts, Contact{Channel: channel, Address: address})
+}
+
+func (c *chat) Contact(channel Channel) (Contact, bool) {
+	for _, contact := range c.contacts {
+		if contact.Channel == channel {
+			return contact, true
+		}
+	}
+	return Contact{}, false
+}
+
+// ReplyChannel is the channel the client wrote through last, so replies reach the client where
+// the conversation happens. Chats without client messages are answered by SMS.
+func (c *chat) ReplyChannel() Channel {
+	for i := len(c.messages) - 1; i >= 0; i-- {
+		if msg := c.messages[i]; msg.Sender().IsClient() {
+			return msg.Channel()
+		}
+	}
+	return SMS
+}
+
 // UnreadMessages returns the number of unread messages in the chat
 func (c *chat) UnreadMessages() int {
 	count := 0
@@ -78,12 +127,16 @@ func (c *chat) MarkAllAsRead() {
 }
 
 // AddMessage adds a new message to the chat
-func (c *chat) AddMessage(content string, sender Sender, attachments ...*upload.Upload) (Message, error) {
+func (c *chat) AddMessage(channel Channel, content string, sender Sender, attachments ...*upload.Upload) (Message, error) {
 	if content == "" && len(attachments) == 0 {
 		return nil, ErrEmptyMessage
 	}
+	if !channel.IsValid() {
+		return nil, ErrUnknownChannel
+	}
 
 	msg := WithAttachments(
+		channel,
 		content,
 		sender,
 		attachments...,
@@ -91,10 +144,29 @@ func (c *chat) AddMessage(content string, sender Sender, attachments ...*upload.
 
 	c.messages = append(c.messages, msg)
 	c.lastMessageAt = mapping.Pointer(time.Now())
+	c.track(sender, msg.CreatedAt())
 
 	return msg, nil
 }
 
+// track moves the chat to the party that has to write next. A client message on a closed chat
+// opens a new cycle, the first operator message of a cycle is its first response.
+func (c *chat) track(sender Sender, at time.Time) {
+	if sender.IsClient() {
+		if c.status == Closed || c.cycle.OpenedAt == nil {
+			c.cycle = Cycle{OpenedAt: &at}
+		}
+		c.status = Open
+		return
+	}
+	if c.cycle.OpenedAt != nil && c.cycle.FirstResponseAt == nil {
+		c.cycle.FirstResponseAt = &at
+	}
+	if c.status == Open {
+		c.status = Pending
+	}
+}
+
 func (c *chat) LastMessage() (Message, error) {
 	if len(c.messages) == 0 {
 		return nil, errors.New("no messages")
@@ -111,16 +183,78 @@ func (c *chat) CreatedAt() time.Time {
 	return c.createdAt
 }
 
+func (c *chat) Status() Status {
+	return c.status
+}
+
+func (c *chat) SetStatus(status Status) error {
+	if !status.IsValid() {
+		return ErrUnknownStatus
+	}
+	if status == c.status {
+		return nil
+	}
+	if status == Closed {
+		c.cycle.ClosedAt = mapping.Pointer(time.Now())
+	} else {
+		c.cycle.ClosedAt = nil
+	}
+	c.status = status
+	return nil
+}
+
+func (c *chat) Assignment() Assignment {
+	return c.assignment
+}
+
+func (c *chat) Assign(userID, teamID uint) {
+	if userID != c.assignment.UserID {
+		c.assignment.AssignedAt = nil
+		if userID != 0 {
+			c.assignment.AssignedAt = mapping.Pointer(time.Now())
+		}
+	}
+	c.assignment.UserID = userID
+	c.assignment.TeamID = teamID
+}
+
+func (c *chat) Cycle() Cycle {
+	return c.cycle
+}
+
+func (c *chat) FirstResponseDueAt(policy SLAPolicy) *time.Time {
+	return dueAt(c.cycle.OpenedAt, policy.FirstResponse)
+}
+
+func (c *chat) ResolutionDueAt(policy SLAPolicy) *time.Time {
+	return dueAt(c.cycle.OpenedAt, policy.Resolution)
+}
+
+func (c *chat) CheckSLA(policy SLAPolicy, now time.Time) []SLAKind {
+	var breached []SLAKind
+	if !c.cycle.FirstResponseBreached && late(c.FirstResponseDueAt(policy), c.cycle.FirstResponseAt, now) {
+		c.cycle.FirstResponseBreached = true
+		breached = append(breached, FirstResponseSLA)
+	}
+	if !c.cycle.ResolutionBreached && late(c.ResolutionDueAt(policy), c.cycle.ClosedAt, now) {
+		c.cycle.ResolutionBreached = true
+		breached = append(breached, ResolutionSLA)
+	}
+	return breached
+}
+
 // -------
 // Message
 // -------
 
 func NewMessage(
+	channel Channel,
 	msg string,
 	sender Sender,
 ) Message {
 	return &message{
 		id:          0,
+		channel:     channel,
 		message:     msg,
 		sender:      sender,
 		isRead:      false,
@@ -131,12 +265,14 @@ func NewMessage(
 }
 
 func WithAttachments(
+	channel Channel,
 	msg string,
 	sender Sender,
 	attachments ...*upload.Upload,
 ) Message {
 	return &message{
 		id:          0,
+		channel:     channel,
 		message:     msg,
 		sender:      sender,
 		isRead:      false,
@@ -148,6 +284,7 @@ func WithAttachments(
 
 func NewMessageWithID(
 	id uint,
+	channel Channel,
 	msg string,
 	sender Sender,
 	isRead bool,
@@ -156,6 +293,7 @@ func NewMessageWithID(
 ) Message {
 	return &message{
 		id:          id,
+		channel:     channel,
 		message:     msg,
 		sender:      sender,
 		isRead:      isRead,
@@ -168,6 +306,7 @@ func NewMessageWithID(
 type message struct {
 	id          uint
 	chatID      uint
+	channel     Channel
 	message     string
 	sender      Sender
 	isRead      bool
@@ -188,6 +327,10 @@ func (m *message) Message() string {
 	return m.message
 }
 
+func (m *message) Channel() Channel {
+	return m.channel
+}
+
 func (m *message) Sender() Sender {
 	return m.sender
 }
@@ -1,6 +1,9 @@
 package chat
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Field int
 
@@ -15,10 +18,14 @@ type SortBy struct {
 }
 
 type FindParams struct {
-	Limit  int
-	Offset int
-	Search string
-	SortBy SortBy
+	Limit      int
+	Offset     int
+	Search     string
+	SortBy     SortBy
+	Status     Status
+	AssigneeID uint
+	// Unassigned selects the chats without an operator, AssigneeID is ignored
+	Unassigned bool
 }
 
 type Repository interface {
@@ -27,6 +34,12 @@ type Repository interface {
 	GetPaginated(ctx context.Context, params *FindParams) ([]Chat, error)
 	GetByID(ctx context.Context, id uint) (Chat, error)
 	GetByClientID(ctx context.Context, clientID uint) (Chat, error)
+	GetByContact(ctx context.Context, channel Channel, address string) (Chat, error)
+	// GetOverdue returns the chats that missed an SLA of the policy by now and are not flagged yet.
+	GetOverdue(ctx context.Context, policy SLAPolicy, now time.Time) ([]Chat, error)
+	// Workload computes the workload of the operators, with the responses to the client messages
+	// received in [from, to).
+	Workload(ctx context.Context, from, to time.Time) ([]Workload, error)
 	Create(ctx context.Context, data Chat) (Chat, error)
 	Update(ctx context.Context, data Chat) (Chat, error)
 	Delete(ctx context.Context, id uint) error
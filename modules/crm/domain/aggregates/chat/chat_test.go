@@ -0,0 +1,159 @@
+package chat_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+)
+
+func TestChat_ReplyChannel(t *testing.T) {
+	c := chat.New(1)
+	if got := c.ReplyChannel(); got != chat.SMS {
+		t.Errorf("expected %s for a chat without messages, got %s", chat.SMS, got)
+	}
+	client := chat.NewClientSender(1, "John", "Doe")
+	user := chat.NewUserSender(2, "Jane", "Doe")
+	if _, err := c.AddMessage(chat.Telegram, "hello", client); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddMessage(chat.Email, "hi", user); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.ReplyChannel(); got != chat.Telegram {
+		t.Errorf("expected the channel of the last client message %s, got %s", chat.Telegram, got)
+	}
+}
+
+func TestChat_AddMessage(t *testing.T) {
+	c := chat.New(1)
+	sender := chat.NewClientSender(1, "John", "Doe")
+	if _, err := c.AddMessage(chat.Channel("fax"), "hello", sender); !errors.Is(err, chat.ErrUnknownChannel) {
+		t.Errorf("expected %v, got %v", chat.ErrUnknownChannel, err)
+	}
+	if _, err := c.AddMessage(chat.SMS, "", sender); !errors.Is(err, chat.ErrEmptyMessage) {
+		t.Errorf("expected %v, got %v", chat.ErrEmptyMessage, err)
+	}
+	msg, err := c.AddMessage(chat.WhatsApp, "hello", sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Channel() != chat.WhatsApp {
+		t.Errorf("expected %s, got %s", chat.WhatsApp, msg.Channel())
+	}
+}
+
+func TestChat_AddContact(t *testing.T) {
+	c := chat.New(1)
+	c.AddContact(chat.Telegram, "100")
+	c.AddContact(chat.Email, "john@example.com")
+	c.AddContact(chat.Telegram, "200")
+	if len(c.Contacts()) != 2 {
+		t.Fatalf("expected 2 contacts, got %d", len(c.Contacts()))
+	}
+	contact, ok := c.Contact(chat.Telegram)
+	if !ok || contact.Address != "200" {
+		t.Errorf("expected the telegram contact to be replaced, got %+v", contact)
+	}
+	if _, ok := c.Contact(chat.WhatsApp); ok {
+		t.Error("expected no whatsapp contact")
+	}
+}
+
+func TestChat_Status(t *testing.T) {
+	c := chat.New(1)
+	client := chat.NewClientSender(1, "John", "Doe")
+	user := chat.NewUserSender(2, "Jane", "Doe")
+	if _, err := c.AddMessage(chat.SMS, "hello", client); err != nil {
+		t.Fatal(err)
+	}
+	opened := c.Cycle().OpenedAt
+	if c.Status() != chat.Open || opened == nil {
+		t.Fatalf("expected an open cycle, got %s %+v", c.Status(), c.Cycle())
+	}
+	if _, err := c.AddMessage(chat.SMS, "hi", user); err != nil {
+		t.Fatal(err)
+	}
+	if c.Status() != chat.Pending || c.Cycle().FirstResponseAt == nil {
+		t.Fatalf("expected the first response to make the chat pending, got %s %+v", c.Status(), c.Cycle())
+	}
+	if _, err := c.AddMessage(chat.SMS, "thanks", client); err != nil {
+		t.Fatal(err)
+	}
+	if c.Status() != chat.Open || c.Cycle().OpenedAt != opened {
+		t.Errorf("expected the client reply to reopen the same cycle, got %s %+v", c.Status(), c.Cycle())
+	}
+
+	if err := c.SetStatus(chat.Status("archived")); !errors.Is(err, chat.ErrUnknownStatus) {
+		t.Errorf("expected %v, got %v", chat.ErrUnknownStatus, err)
+	}
+	if err := c.SetStatus(chat.Closed); err != nil {
+		t.Fatal(err)
+	}
+	if c.Cycle().ClosedAt == nil {
+		t.Error("expected the cycle to be closed")
+	}
+	if _, err := c.AddMessage(chat.SMS, "one more thing", client); err != nil {
+		t.Fatal(err)
+	}
+	if c.Status() != chat.Open || c.Cycle().OpenedAt == opened || c.Cycle().FirstResponseAt != nil {
+		t.Errorf("expected a client message on a closed chat to open a new cycle, got %s %+v", c.Status(), c.Cycle())
+	}
+}
+
+func TestChat_Assign(t *testing.T) {
+	c := chat.New(1)
+	c.Assign(2, 3)
+	a := c.Assignment()
+	if a.UserID != 2 || a.TeamID != 3 || a.AssignedAt == nil {
+		t.Fatalf("expected the chat to be assigned, got %+v", a)
+	}
+	c.Assign(2, 4)
+	if c.Assignment().AssignedAt != a.AssignedAt {
+		t.Error("expected moving the chat to another team to keep the time of the assignment")
+	}
+	c.Assign(0, 0)
+	if c.Assignment() != (chat.Assignment{}) {
+		t.Errorf("expected the chat to be unassigned, got %+v", c.Assignment())
+	}
+}
+
+func TestChat_CheckSLA(t *testing.T) {
+	policy := chat.SLAPolicy{FirstResponse: time.Hour, Resolution: 24 * time.Hour}
+	opened := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	c := chat.NewWithID(1, 1, opened, nil, nil, &opened, chat.Open, chat.Assignment{}, chat.Cycle{OpenedAt: &opened})
+
+	if due := c.FirstResponseDueAt(policy); due == nil || !due.Equal(opened.Add(time.Hour)) {
+		t.Errorf("expected the first response to be due an hour after opening, got %v", due)
+	}
+	if breached := c.CheckSLA(policy, opened.Add(30*time.Minute)); len(breached) != 0 {
+		t.Errorf("expected no breaches, got %v", breached)
+	}
+	breached := c.CheckSLA(policy, opened.Add(2*time.Hour))
+	if len(breached) != 1 || breached[0] != chat.FirstResponseSLA {
+		t.Fatalf("expected the first response SLA to be breached, got %v", breached)
+	}
+	if breached := c.CheckSLA(policy, opened.Add(3*time.Hour)); len(breached) != 0 {
+		t.Errorf("expected a breach to be reported once, got %v", breached)
+	}
+	breached = c.CheckSLA(policy, opened.Add(25*time.Hour))
+	if len(breached) != 1 || breached[0] != chat.ResolutionSLA {
+		t.Errorf("expected the resolution SLA to be breached, got %v", breached)
+	}
+
+	late := opened.Add(2 * time.Hour)
+	answered := chat.NewWithID(2, 1, opened, nil, nil, &opened, chat.Closed, chat.Assignment{}, chat.Cycle{
+		OpenedAt:        &opened,
+		FirstResponseAt: &late,
+		ClosedAt:        &late,
+	})
+	breached = answered.CheckSLA(policy, opened.Add(48*time.Hour))
+	if len(breached) != 1 || breached[0] != chat.FirstResponseSLA {
+		t.Errorf("expected only the late first response to be reported, got %v", breached)
+	}
+	unanswered := chat.NewWithID(3, 1, opened, nil, nil, &opened, chat.Open, chat.Assignment{}, chat.Cycle{OpenedAt: &opened})
+	if breached := unanswered.CheckSLA(chat.SLAPolicy{}, opened.Add(48*time.Hour)); len(breached) != 0 {
+		t.Errorf("expected no breaches without a policy, got %v", breached)
+	}
+}
@@ -0,0 +1,87 @@
+package chat
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrUnknownStatus = errors.New("unknown chat status")
+
+// Status tells who the chat is waiting for: an operator while it is open, the client while it
+// is pending and nobody once it is closed.
+type Status string
+
+const (
+	Open    Status = "open"
+	Pending Status = "pending"
+	Closed  Status = "closed"
+)
+
+var Statuses = []Status{Open, Pending, Closed}
+
+func NewStatus(value string) (Status, error) {
+	s := Status(value)
+	if !s.IsValid() {
+		return "", ErrUnknownStatus
+	}
+	return s, nil
+}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Open, Pending, Closed:
+		return true
+	}
+	return false
+}
+
+// Assignment is the operator and the team responsible for a chat. Either can be empty.
+type Assignment struct {
+	UserID     uint
+	TeamID     uint
+	AssignedAt *time.Time
+}
+
+// Cycle is the period from the client message that opened a chat until the chat is closed.
+// SLAs are measured per cycle, the breach flags keep a breach from being reported twice.
+type Cycle struct {
+	OpenedAt              *time.Time
+	FirstResponseAt       *time.Time
+	ClosedAt              *time.Time
+	FirstResponseBreached bool
+	ResolutionBreached    bool
+}
+
+// SLAKind is the target of an SLA.
+type SLAKind string
+
+const (
+	FirstResponseSLA SLAKind = "first_response"
+	ResolutionSLA    SLAKind = "resolution"
+)
+
+// SLAPolicy is the time operators have to answer and to close a chat from the start of its
+// cycle. A zero duration disables the SLA.
+type SLAPolicy struct {
+	FirstResponse time.Duration
+	Resolution    time.Duration
+}
+
+func dueAt(start *time.Time, d time.Duration) *time.Time {
+	if start == nil || d == 0 {
+		return nil
+	}
+	due := start.Add(d)
+	return &due
+}
+
+// late tells whether the event, or now when it has not happened yet, is past the due time.
+func late(due, event *time.Time, now time.Time) bool {
+	if due == nil {
+		return false
+	}
+	if event != nil {
+		return event.After(*due)
+	}
+	return now.After(*due)
+}
@@ -0,0 +1,24 @@
+package chat
+
+import "time"
+
+// Workload is the share of the chats of an operator and how fast the operator answers clients.
+// The chats nobody is assigned to are counted under UserID 0.
+type Workload struct {
+	UserID  uint
+	Open    int
+	Pending int
+	// Chats closed in the period
+	Closed int
+	// Open and pending chats that missed an SLA in their current cycle
+	Breached int
+	// Client messages of the period the operator answered first
+	Responses int
+	// Average time from a client message to the first operator reply
+	AverageResponse time.Duration
+}
+
+// Active is the number of chats waiting for the operator or the client.
+func (w Workload) Active() int {
+	return w.Open + w.Pending
+}
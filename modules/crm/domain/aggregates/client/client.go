@@ -12,9 +12,21 @@ type Client interface {
 	LastName() string
 	MiddleName() string
 	Phone() phone.Phone
+	Email() string
+	Address() string
+	DateOfBirth() *time.Time
+	Gender() Gender
+	HourlyRate() float64
+	Details() Details
+	Tags() []string
+	// CustomFields returns the values of the custom fields keyed by the field ID.
+	CustomFields() map[uint]string
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
 
 	SetPhone(number phone.Phone) Client
 	SetName(firstName, lastName, middleName string) Client
+	SetDetails(details Details) Client
+	SetTags(tags []string) Client
+	SetCustomFields(values map[uint]string) Client
 }
@@ -2,45 +2,37 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/phone"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
 )
 
 type CreateDTO struct {
-	FirstName  string `validate:"required"`
-	LastName   string `validate:"required"`
-	MiddleName string
-	Phone      string `validate:"required"`
+	FirstName    string `validate:"required"`
+	LastName     string `validate:"required"`
+	MiddleName   string
+	Phone        string `validate:"required"`
+	Email        string `validate:"omitempty,email"`
+	Address      string
+	DateOfBirth  shared.DateOnly
+	Gender       string
+	HourlyRate   float64
+	Tags         string
+	CustomFields map[uint]string
 }
 
 func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
-	l, ok := composables.UseLocalizer(ctx)
-	if !ok {
-		panic(composables.ErrNoLocalizer)
-	}
-	errorMessages := map[string]string{}
-	errs := constants.Validate.Struct(d)
-	if errs == nil {
-		return errorMessages, true
-	}
-	for _, err := range errs.(validator.ValidationErrors) {
-		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
-			MessageID: fmt.Sprintf("Clients.Single.%s.Label", err.Field()),
-		})
-		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
-			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
-			TemplateData: map[string]string{
-				"Field": translatedFieldName,
-			},
-		})
-	}
-
+	errorMessages := validate(ctx, d)
+	validateDetails(ctx, d.Gender, d.HourlyRate, errorMessages)
 	return errorMessages, len(errorMessages) == 0
 }
 
@@ -49,30 +41,124 @@ func (d *CreateDTO) ToEntity() (Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return New(
+	entity, err := New(
 		d.FirstName,
 		d.LastName,
 		d.MiddleName,
 		p,
 	)
+	if err != nil {
+		return nil, err
+	}
+	details, err := toDetails(d.Email, d.Address, d.DateOfBirth, d.Gender, d.HourlyRate)
+	if err != nil {
+		return nil, err
+	}
+	return entity.
+		SetDetails(details).
+		SetTags(ParseTags(d.Tags)).
+		SetCustomFields(d.CustomFields), nil
 }
 
 type UpdateDTO struct {
-	FirstName  string `validate:"required"`
-	LastName   string `validate:"required"`
-	MiddleName string
-	Phone      string `validate:"required"`
+	FirstName    string `validate:"required"`
+	LastName     string `validate:"required"`
+	MiddleName   string
+	Phone        string `validate:"required"`
+	Email        string `validate:"omitempty,email"`
+	Address      string
+	DateOfBirth  shared.DateOnly
+	Gender       string
+	HourlyRate   float64
+	Tags         string
+	CustomFields map[uint]string
 }
 
 func (d *UpdateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	errorMessages := validate(ctx, d)
+	validateDetails(ctx, d.Gender, d.HourlyRate, errorMessages)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *UpdateDTO) Apply(entity Client) (Client, error) {
+	p, err := phone.NewFromE164(d.Phone)
+	if err != nil {
+		return nil, err
+	}
+	details, err := toDetails(d.Email, d.Address, d.DateOfBirth, d.Gender, d.HourlyRate)
+	if err != nil {
+		return nil, err
+	}
+	return entity.
+		SetName(d.FirstName, d.LastName, d.MiddleName).
+		SetPhone(p).
+		SetDetails(details).
+		SetTags(ParseTags(d.Tags)).
+		SetCustomFields(d.CustomFields), nil
+}
+
+// ValidateCustomFields checks the custom field values of a form against the field definitions.
+// The errors are keyed by CustomFields.<field ID>.
+func ValidateCustomFields(ctx context.Context, fields []customfield.Field, values map[uint]string) map[string]string {
+	errorMessages := map[string]string{}
+	for _, f := range fields {
+		err := f.Validate(values[f.ID()])
+		if err == nil {
+			continue
+		}
+		key := fmt.Sprintf("CustomFields.%d", f.ID())
+		switch {
+		case errors.Is(err, customfield.ErrRequired):
+			errorMessages[key] = composables.MustUseLocalizer(ctx).MustLocalize(&i18n.LocalizeConfig{
+				MessageID:    "ValidationErrors.required",
+				TemplateData: map[string]string{"Field": f.Label()},
+			})
+		case errors.Is(err, customfield.ErrInvalidNumber):
+			errorMessages[key] = composables.MustT(ctx, "ClientFields.Errors.Number")
+		case errors.Is(err, customfield.ErrInvalidDate):
+			errorMessages[key] = composables.MustT(ctx, "ClientFields.Errors.Date")
+		default:
+			errorMessages[key] = composables.MustT(ctx, "ClientFields.Errors.Option")
+		}
+	}
+	return errorMessages
+}
+
+func validateDetails(ctx context.Context, gender string, hourlyRate float64, errorMessages map[string]string) {
+	if _, err := NewGender(gender); err != nil {
+		errorMessages["Gender"] = composables.MustT(ctx, "Clients.Errors.Gender")
+	}
+	if hourlyRate < 0 {
+		errorMessages["HourlyRate"] = composables.MustT(ctx, "Clients.Errors.HourlyRate")
+	}
+}
+
+func toDetails(email, address string, dateOfBirth shared.DateOnly, gender string, hourlyRate float64) (Details, error) {
+	g, err := NewGender(gender)
+	if err != nil {
+		return Details{}, err
+	}
+	details := Details{
+		Email:      email,
+		Address:    address,
+		Gender:     g,
+		HourlyRate: hourlyRate,
+	}
+	if t := time.Time(dateOfBirth); !t.IsZero() {
+		details.DateOfBirth = &t
+	}
+	return details, nil
+}
+
+func validate(ctx context.Context, dto interface{}) map[string]string {
 	l, ok := composables.UseLocalizer(ctx)
 	if !ok {
 		panic(composables.ErrNoLocalizer)
 	}
 	errorMessages := map[string]string{}
-	errs := constants.Validate.Struct(d)
+	errs := constants.Validate.Struct(dto)
 	if errs == nil {
-		return errorMessages, true
+		return errorMessages
 	}
 	for _, err := range errs.(validator.ValidationErrors) {
 		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
@@ -85,14 +171,5 @@ func (d *UpdateDTO) Ok(ctx context.Context) (map[string]string, bool) {
 			},
 		})
 	}
-
-	return errorMessages, len(errorMessages) == 0
-}
-
-func (d *UpdateDTO) Apply(entity Client) (Client, error) {
-	p, err := phone.NewFromE164(d.Phone)
-	if err != nil {
-		return nil, err
-	}
-	return entity.SetName(d.FirstName, d.LastName, d.MiddleName).SetPhone(p), nil
+	return errorMessages
 }
@@ -0,0 +1,8 @@
+package client
+
+import "errors"
+
+var (
+	ErrMergeIntoSelf = errors.New("client cannot be merged into itself")
+	ErrInvalidGender = errors.New("invalid gender")
+)
@@ -11,13 +11,14 @@ func New(
 	phoneNumber phone.Phone,
 ) (Client, error) {
 	return &client{
-		id:         0,
-		firstName:  firstName,
-		lastName:   lastName,
-		middleName: middleName,
-		phone:      phoneNumber,
-		createdAt:  time.Now(),
-		updatedAt:  time.Now(),
+		id:           0,
+		firstName:    firstName,
+		lastName:     lastName,
+		middleName:   middleName,
+		phone:        phoneNumber,
+		customFields: map[uint]string{},
+		createdAt:    time.Now(),
+		updatedAt:    time.Now(),
 	}, nil
 }
 
@@ -25,27 +26,39 @@ func NewWithID(
 	id uint,
 	firstName, lastName, middleName string,
 	phoneNumber phone.Phone,
+	details Details,
+	tags []string,
+	customFields map[uint]string,
 	createdAt, updatedAt time.Time,
 ) (Client, error) {
+	if customFields == nil {
+		customFields = map[uint]string{}
+	}
 	return &client{
-		id:         id,
-		firstName:  firstName,
-		lastName:   lastName,
-		middleName: middleName,
-		phone:      phoneNumber,
-		createdAt:  createdAt,
-		updatedAt:  updatedAt,
+		id:           id,
+		firstName:    firstName,
+		lastName:     lastName,
+		middleName:   middleName,
+		phone:        phoneNumber,
+		details:      details,
+		tags:         tags,
+		customFields: customFields,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
 	}, nil
 }
 
 type client struct {
-	id         uint
-	firstName  string
-	lastName   string
-	middleName string
-	phone      phone.Phone
-	createdAt  time.Time
-	updatedAt  time.Time
+	id           uint
+	firstName    string
+	lastName     string
+	middleName   string
+	phone        phone.Phone
+	details      Details
+	tags         []string
+	customFields map[uint]string
+	createdAt    time.Time
+	updatedAt    time.Time
 }
 
 func (c *client) ID() uint {
@@ -68,6 +81,38 @@ func (c *client) Phone() phone.Phone {
 	return c.phone
 }
 
+func (c *client) Email() string {
+	return c.details.Email
+}
+
+func (c *client) Address() string {
+	return c.details.Address
+}
+
+func (c *client) DateOfBirth() *time.Time {
+	return c.details.DateOfBirth
+}
+
+func (c *client) Gender() Gender {
+	return c.details.Gender
+}
+
+func (c *client) HourlyRate() float64 {
+	return c.details.HourlyRate
+}
+
+func (c *client) Details() Details {
+	return c.details
+}
+
+func (c *client) Tags() []string {
+	return c.tags
+}
+
+func (c *client) CustomFields() map[uint]string {
+	return c.customFields
+}
+
 func (c *client) CreatedAt() time.Time {
 	return c.createdAt
 }
@@ -77,25 +122,44 @@ func (c *client) UpdatedAt() time.Time {
 }
 
 func (c *client) SetPhone(number phone.Phone) Client {
-	return &client{
-		id:         c.id,
-		firstName:  c.firstName,
-		lastName:   c.lastName,
-		middleName: c.middleName,
-		phone:      number,
-		createdAt:  c.createdAt,
-		updatedAt:  time.Now(),
-	}
+	result := *c
+	result.phone = number
+	result.updatedAt = time.Now()
+	return &result
 }
 
 func (c *client) SetName(firstName, lastName, middleName string) Client {
-	return &client{
-		id:         c.id,
-		firstName:  firstName,
-		lastName:   lastName,
-		middleName: middleName,
-		phone:      c.phone,
-		createdAt:  c.createdAt,
-		updatedAt:  time.Now(),
+	result := *c
+	result.firstName = firstName
+	result.lastName = lastName
+	result.middleName = middleName
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (c *client) SetDetails(details Details) Client {
+	result := *c
+	result.details = details
+	result.updatedAt = time.Now()
+	return &result
+}
+
+func (c *client) SetTags(tags []string) Client {
+	result := *c
+	result.tags = NormalizeTags(tags)
+	result.updatedAt = time.Now()
+	return &result
+}
+
+// SetCustomFields replaces the values of the custom fields; empty values are dropped.
+func (c *client) SetCustomFields(values map[uint]string) Client {
+	result := *c
+	result.customFields = make(map[uint]string, len(values))
+	for id, v := range values {
+		if v != "" {
+			result.customFields[id] = v
+		}
 	}
+	result.updatedAt = time.Now()
+	return &result
 }
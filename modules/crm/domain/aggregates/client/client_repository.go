@@ -1,6 +1,10 @@
 package client
 
-import "context"
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+)
 
 type DateRange struct {
 	From string
@@ -30,15 +34,24 @@ type FindParams struct {
 	Field     string
 	SortBy    SortBy
 	CreatedAt DateRange
+	// Filter is a segment expression bound to the custom fields.
+	Filter segment.Expression
 }
 
 type Repository interface {
-	Count(ctx context.Context) (int64, error)
+	Count(ctx context.Context, params *FindParams) (int64, error)
 	GetAll(ctx context.Context) ([]Client, error)
 	GetPaginated(ctx context.Context, params *FindParams) ([]Client, error)
 	GetByID(ctx context.Context, id uint) (Client, error)
 	GetByPhone(ctx context.Context, phoneNumber string) (Client, error)
+	GetByEmail(ctx context.Context, email string) (Client, error)
 	Create(ctx context.Context, data Client) (Client, error)
 	Update(ctx context.Context, data Client) (Client, error)
 	Delete(ctx context.Context, id uint) error
+	// FindDuplicateCandidates returns the clients sharing a phone number, an email or a name
+	// with the client. The candidates are not scored yet, see FindDuplicates.
+	FindDuplicateCandidates(ctx context.Context, data Client) ([]Client, error)
+	// Merge moves the chats, messages and deals of the duplicate to the survivor and deletes
+	// the duplicate. The survivor takes the details it lacks from the duplicate.
+	Merge(ctx context.Context, survivorID, duplicateID uint) error
 }
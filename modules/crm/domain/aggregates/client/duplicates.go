@@ -0,0 +1,166 @@
+package client
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/phone"
+)
+
+// MatchReason is a piece of contact data two clients have in common.
+type MatchReason string
+
+const (
+	MatchPhone MatchReason = "phone"
+	MatchEmail MatchReason = "email"
+	MatchName  MatchReason = "name"
+)
+
+const (
+	phoneWeight = 0.6
+	emailWeight = 0.5
+	nameWeight  = 0.4
+
+	// minNameSimilarity is how close two names must be to count as a match on their own.
+	minNameSimilarity = 0.8
+	// minNationalDigits is the shortest number compared by its national part.
+	minNationalDigits = 7
+)
+
+// DuplicateThreshold is the score from which a candidate is reported as a duplicate.
+const DuplicateThreshold = 0.5
+
+// Duplicate is a client that probably is the same person as another one.
+type Duplicate struct {
+	Client  Client
+	Score   float64
+	Reasons []MatchReason
+}
+
+// NormalizePhone reduces a phone number to its digits, so numbers written with
+// a plus sign, spaces or dashes compare equal.
+func NormalizePhone(number string) string {
+	return phone.Strip(number)
+}
+
+// SamePhone reports whether two numbers belong to the same line. A number saved
+// without the country code or with a trunk zero matches the international form
+// ending with the same national number.
+func SamePhone(a, b string) bool {
+	a, b = NormalizePhone(a), NormalizePhone(b)
+	if a == "" || b == "" {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	a = strings.TrimLeft(a, "0")
+	if len(a) < minNationalDigits {
+		return false
+	}
+	return strings.HasSuffix(b, a)
+}
+
+// Similarity scores how likely two clients are the same person, from 0 to 1,
+// and lists what they have in common.
+func Similarity(a, b Client) (float64, []MatchReason) {
+	var score float64
+	var reasons []MatchReason
+	if a.Phone() != nil && b.Phone() != nil && SamePhone(a.Phone().Value(), b.Phone().Value()) {
+		score += phoneWeight
+		reasons = append(reasons, MatchPhone)
+	}
+	if a.Email() != "" && strings.EqualFold(strings.TrimSpace(a.Email()), strings.TrimSpace(b.Email())) {
+		score += emailWeight
+		reasons = append(reasons, MatchEmail)
+	}
+	nameScore := NameSimilarity(fullName(a), fullName(b))
+	score += nameScore * nameWeight
+	if nameScore >= minNameSimilarity {
+		reasons = append(reasons, MatchName)
+	}
+	if score > 1 {
+		score = 1
+	}
+	return score, reasons
+}
+
+// FindDuplicates scores the candidates against the client and returns the ones
+// reaching DuplicateThreshold, best match first.
+func FindDuplicates(entity Client, candidates []Client) []Duplicate {
+	duplicates := make([]Duplicate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.ID() == entity.ID() {
+			continue
+		}
+		score, reasons := Similarity(entity, candidate)
+		if score < DuplicateThreshold {
+			continue
+		}
+		duplicates = append(duplicates, Duplicate{
+			Client:  candidate,
+			Score:   score,
+			Reasons: reasons,
+		})
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score > duplicates[j].Score
+	})
+	return duplicates
+}
+
+// NameSimilarity compares two names ignoring case, punctuation and word order,
+// from 0 for nothing in common to 1 for the same name.
+func NameSimilarity(a, b string) float64 {
+	a, b = normalizeName(a), normalizeName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+	ar, br := []rune(a), []rune(b)
+	longest := len(ar)
+	if len(br) > longest {
+		longest = len(br)
+	}
+	return 1 - float64(levenshtein(ar, br))/float64(longest)
+}
+
+func fullName(c Client) string {
+	return strings.Join([]string{c.FirstName(), c.LastName()}, " ")
+}
+
+// normalizeName lowercases the name, drops punctuation and sorts its words, so
+// "Doe, John" and "john doe" normalize the same.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,83 @@
+package client_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/phone"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+)
+
+func newClient(t *testing.T, id uint, firstName, lastName, number, email string) client.Client {
+	t.Helper()
+	p, err := phone.NewFromE164(number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := client.NewWithID(id, firstName, lastName, "", p, client.Details{Email: email}, nil, nil, time.Now(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestSamePhone(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"+998 90 123-45-67", "998901234567", true},
+		{"998901234567", "901234567", true},
+		{"+44 20 7946 0958", "020 7946 0958", true},
+		{"998901234567", "998901234568", false},
+		{"998901234567", "4567", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := client.SamePhone(tt.a, tt.b); got != tt.want {
+			t.Errorf("SamePhone(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	if got := client.NameSimilarity("Doe, John", "john doe"); got != 1 {
+		t.Errorf("expected reordered names to match, got %v", got)
+	}
+	if got := client.NameSimilarity("John Doe", "Jon Doe"); got < 0.8 {
+		t.Errorf("expected a typo to stay similar, got %v", got)
+	}
+	if got := client.NameSimilarity("John Doe", "Mary Smith"); got > 0.5 {
+		t.Errorf("expected different names to differ, got %v", got)
+	}
+	if got := client.NameSimilarity("", "John"); got != 0 {
+		t.Errorf("expected an empty name to match nothing, got %v", got)
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	entity := newClient(t, 1, "John", "Doe", "+998 90 123 45 67", "john@example.com")
+	samePhone := newClient(t, 2, "Jon", "Doe", "901234567", "")
+	sameEmail := newClient(t, 3, "J.", "Doe", "998935554433", "JOHN@example.com")
+	sameName := newClient(t, 4, "John", "Doe", "998935550000", "")
+	other := newClient(t, 5, "Mary", "Smith", "998935551111", "mary@example.com")
+
+	duplicates := client.FindDuplicates(entity, []client.Client{entity, sameName, other, sameEmail, samePhone})
+	if len(duplicates) != 2 {
+		t.Fatalf("expected 2 duplicates, got %d", len(duplicates))
+	}
+	if duplicates[0].Client.ID() != 2 {
+		t.Errorf("expected the phone match first, got client %d", duplicates[0].Client.ID())
+	}
+	if duplicates[1].Client.ID() != 3 {
+		t.Errorf("expected the email match second, got client %d", duplicates[1].Client.ID())
+	}
+	for _, d := range duplicates {
+		if d.Score < client.DuplicateThreshold || d.Score > 1 {
+			t.Errorf("score %v of client %d out of range", d.Score, d.Client.ID())
+		}
+	}
+	if reasons := duplicates[0].Reasons; len(reasons) != 2 || reasons[0] != client.MatchPhone || reasons[1] != client.MatchName {
+		t.Errorf("unexpected reasons %v", reasons)
+	}
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"strings"
+	"time"
+)
+
+type Gender string
+
+const (
+	GenderUnknown Gender = ""
+	Male          Gender = "male"
+	Female        Gender = "female"
+	OtherGender   Gender = "other"
+)
+
+var Genders = []Gender{Male, Female, OtherGender}
+
+func NewGender(v string) (Gender, error) {
+	g := Gender(v)
+	if g != GenderUnknown && g != Male && g != Female && g != OtherGender {
+		return GenderUnknown, ErrInvalidGender
+	}
+	return g, nil
+}
+
+// Details are the optional facts about a client besides the name and the phone number.
+type Details struct {
+	Email       string
+	Address     string
+	DateOfBirth *time.Time
+	Gender      Gender
+	HourlyRate  float64
+}
+
+// NormalizeTags lowercases and trims the tags and drops the empty and repeated ones.
+func NormalizeTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		result = append(result, t)
+	}
+	return result
+}
+
+// ParseTags splits a comma separated list of tags.
+func ParseTags(v string) []string {
+	return NormalizeTags(strings.Split(v, ","))
+}
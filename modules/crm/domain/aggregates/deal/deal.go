@@ -0,0 +1,32 @@
+package deal
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/money"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+)
+
+type Deal interface {
+	ID() uint
+	Title() string
+	ClientID() uint
+	OwnerID() uint
+	PipelineID() uint
+	StageID() uint
+	Status() Status
+	Value() money.Amount
+	ExpectedCloseDate() *time.Time
+	ClosedAt() *time.Time
+	// Conversion is set once a won deal has been turned into an invoice or an order.
+	Conversion() *Conversion
+	History() []StageChange
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	Update(title string, clientID, ownerID uint, value money.Amount, expectedCloseDate *time.Time)
+	// MoveTo puts the deal in a stage of its pipeline and records the change in the history.
+	MoveTo(stage pipeline.Stage, userID uint) error
+	// Convert marks a won deal as converted.
+	Convert(target ConversionTarget) error
+}
@@ -0,0 +1,119 @@
+package deal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/money"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type CreateDTO struct {
+	Title      string `validate:"required,max=255"`
+	ClientID   uint   `validate:"required"`
+	OwnerID    uint   `validate:"required"`
+	PipelineID uint   `validate:"required"`
+	// StageID defaults to the first stage of the pipeline.
+	StageID           uint
+	Value             float64 `validate:"gte=0"`
+	CurrencyCode      string  `validate:"required,len=3"`
+	ExpectedCloseDate shared.DateOnly
+}
+
+type UpdateDTO struct {
+	Title             string  `validate:"required,max=255"`
+	ClientID          uint    `validate:"required"`
+	OwnerID           uint    `validate:"required"`
+	Value             float64 `validate:"gte=0"`
+	CurrencyCode      string  `validate:"required,len=3"`
+	ExpectedCloseDate shared.DateOnly
+}
+
+type MoveDTO struct {
+	StageID uint `validate:"required"`
+}
+
+type ConvertDTO struct {
+	Target string `validate:"required,oneof=invoice order"`
+}
+
+func validate(ctx context.Context, dto any) (map[string]string, bool) {
+	l, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		panic(composables.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("Deals.Single.%s.Label", err.Field()),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	return validate(ctx, d)
+}
+
+func (d *CreateDTO) ToEntity(stage pipeline.Stage, userID uint) (Deal, error) {
+	value, err := amount(d.Value, d.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	return New(d.Title, d.ClientID, d.OwnerID, stage, value, dateOrNil(d.ExpectedCloseDate), userID), nil
+}
+
+func (d *UpdateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	return validate(ctx, d)
+}
+
+func (d *UpdateDTO) Apply(entity Deal) error {
+	value, err := amount(d.Value, d.CurrencyCode)
+	if err != nil {
+		return err
+	}
+	entity.Update(d.Title, d.ClientID, d.OwnerID, value, dateOrNil(d.ExpectedCloseDate))
+	return nil
+}
+
+func (d *MoveDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	return validate(ctx, d)
+}
+
+func (d *ConvertDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	return validate(ctx, d)
+}
+
+func amount(value float64, currencyCode string) (money.Amount, error) {
+	code, err := currency.NewCode(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	return money.New(value, code), nil
+}
+
+func dateOrNil(d shared.DateOnly) *time.Time {
+	t := time.Time(d)
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,12 @@
+package deal
+
+import "errors"
+
+var (
+	ErrInvalidStatus           = errors.New("invalid deal status")
+	ErrInvalidConversionTarget = errors.New("invalid conversion target")
+	ErrStageOfOtherPipeline    = errors.New("stage belongs to another pipeline")
+	ErrSameStage               = errors.New("deal is already in the stage")
+	ErrNotWon                  = errors.New("only won deals can be converted")
+	ErrAlreadyConverted        = errors.New("deal is already converted")
+)
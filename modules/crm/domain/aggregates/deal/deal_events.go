@@ -0,0 +1,74 @@
+package deal
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func NewCreatedEvent(ctx context.Context, result Deal) (*CreatedEvent, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedEvent{
+		User:   u,
+		Result: result,
+	}, nil
+}
+
+func NewUpdatedEvent(ctx context.Context, result Deal) (*UpdatedEvent, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &UpdatedEvent{
+		User:   u,
+		Result: result,
+	}, nil
+}
+
+func NewStageChangedEvent(ctx context.Context, change StageChange, result Deal) (*StageChangedEvent, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &StageChangedEvent{
+		User:   u,
+		Change: change,
+		Result: result,
+	}, nil
+}
+
+func NewDeletedEvent(ctx context.Context, result Deal) (*DeletedEvent, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &DeletedEvent{
+		User:   u,
+		Result: result,
+	}, nil
+}
+
+type CreatedEvent struct {
+	User   user.User
+	Result Deal
+}
+
+type UpdatedEvent struct {
+	User   user.User
+	Result Deal
+}
+
+type StageChangedEvent struct {
+	User   user.User
+	Change StageChange
+	Result Deal
+}
+
+type DeletedEvent struct {
+	User   user.User
+	Result Deal
+}
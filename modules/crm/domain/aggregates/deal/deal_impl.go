@@ -0,0 +1,185 @@
+package deal
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/money"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+)
+
+// New creates a deal in a stage of a pipeline, the first entry of its history is made by userID.
+func New(
+	title string,
+	clientID, ownerID uint,
+	stage pipeline.Stage,
+	value money.Amount,
+	expectedCloseDate *time.Time,
+	userID uint,
+) Deal {
+	now := time.Now()
+	d := &deal{
+		title:             title,
+		clientID:          clientID,
+		ownerID:           ownerID,
+		pipelineID:        stage.PipelineID,
+		stageID:           stage.ID,
+		status:            statusOf(stage.Kind),
+		value:             value,
+		expectedCloseDate: expectedCloseDate,
+		createdAt:         now,
+		updatedAt:         now,
+	}
+	if stage.Kind.IsClosed() {
+		d.closedAt = &now
+	}
+	d.history = []StageChange{{ToStageID: stage.ID, ChangedBy: userID, ChangedAt: now}}
+	return d
+}
+
+func NewWithID(
+	id uint,
+	title string,
+	clientID, ownerID, pipelineID, stageID uint,
+	status Status,
+	value money.Amount,
+	expectedCloseDate, closedAt *time.Time,
+	conversion *Conversion,
+	history []StageChange,
+	createdAt, updatedAt time.Time,
+) Deal {
+	return &deal{
+		id:                id,
+		title:             title,
+		clientID:          clientID,
+		ownerID:           ownerID,
+		pipelineID:        pipelineID,
+		stageID:           stageID,
+		status:            status,
+		value:             value,
+		expectedCloseDate: expectedCloseDate,
+		closedAt:          closedAt,
+		conversion:        conversion,
+		history:           history,
+		createdAt:         createdAt,
+		updatedAt:         updatedAt,
+	}
+}
+
+type deal struct {
+	id                uint
+	title             string
+	clientID          uint
+	ownerID           uint
+	pipelineID        uint
+	stageID           uint
+	status            Status
+	value             money.Amount
+	expectedCloseDate *time.Time
+	closedAt          *time.Time
+	conversion        *Conversion
+	history           []StageChange
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+func (d *deal) ID() uint {
+	return d.id
+}
+
+func (d *deal) Title() string {
+	return d.title
+}
+
+func (d *deal) ClientID() uint {
+	return d.clientID
+}
+
+func (d *deal) OwnerID() uint {
+	return d.ownerID
+}
+
+func (d *deal) PipelineID() uint {
+	return d.pipelineID
+}
+
+func (d *deal) StageID() uint {
+	return d.stageID
+}
+
+func (d *deal) Status() Status {
+	return d.status
+}
+
+func (d *deal) Value() money.Amount {
+	return d.value
+}
+
+func (d *deal) ExpectedCloseDate() *time.Time {
+	return d.expectedCloseDate
+}
+
+func (d *deal) ClosedAt() *time.Time {
+	return d.closedAt
+}
+
+func (d *deal) Conversion() *Conversion {
+	return d.conversion
+}
+
+func (d *deal) History() []StageChange {
+	return d.history
+}
+
+func (d *deal) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *deal) UpdatedAt() time.Time {
+	return d.updatedAt
+}
+
+func (d *deal) Update(title string, clientID, ownerID uint, value money.Amount, expectedCloseDate *time.Time) {
+	d.title = title
+	d.clientID = clientID
+	d.ownerID = ownerID
+	d.value = value
+	d.expectedCloseDate = expectedCloseDate
+	d.updatedAt = time.Now()
+}
+
+func (d *deal) MoveTo(stage pipeline.Stage, userID uint) error {
+	if stage.PipelineID != d.pipelineID {
+		return ErrStageOfOtherPipeline
+	}
+	if stage.ID == d.stageID {
+		return ErrSameStage
+	}
+	now := time.Now()
+	d.history = append(d.history, StageChange{
+		FromStageID: d.stageID,
+		ToStageID:   stage.ID,
+		ChangedBy:   userID,
+		ChangedAt:   now,
+	})
+	d.stageID = stage.ID
+	d.status = statusOf(stage.Kind)
+	if stage.Kind.IsClosed() {
+		d.closedAt = &now
+	} else {
+		d.closedAt = nil
+	}
+	d.updatedAt = now
+	return nil
+}
+
+func (d *deal) Convert(target ConversionTarget) error {
+	if d.status != Won {
+		return ErrNotWon
+	}
+	if d.conversion != nil {
+		return ErrAlreadyConverted
+	}
+	d.conversion = &Conversion{Target: target, ConvertedAt: time.Now()}
+	d.updatedAt = d.conversion.ConvertedAt
+	return nil
+}
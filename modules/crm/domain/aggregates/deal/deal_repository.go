@@ -0,0 +1,24 @@
+package deal
+
+import "context"
+
+type FindParams struct {
+	PipelineID uint
+	StageID    uint
+	ClientID   uint
+	OwnerID    uint
+	Status     Status
+	Search     string
+	Limit      int
+	Offset     int
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]Deal, error)
+	GetByID(ctx context.Context, id uint) (Deal, error)
+	Create(ctx context.Context, data Deal) (Deal, error)
+	// Update saves the deal and appends the history entries it does not have yet.
+	Update(ctx context.Context, data Deal) (Deal, error)
+	Delete(ctx context.Context, id uint) error
+}
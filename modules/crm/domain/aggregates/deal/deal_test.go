@@ -0,0 +1,88 @@
+package deal_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/money"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+)
+
+func newPipeline(t *testing.T) pipeline.Pipeline {
+	t.Helper()
+	p, err := pipeline.NewWithID(1, "Sales", []pipeline.Stage{
+		{ID: 10, Name: "Lead", Position: 0, Kind: pipeline.Open},
+		{ID: 11, Name: "Proposal", Position: 1, Kind: pipeline.Open},
+		{ID: 12, Name: "Won", Position: 2, Kind: pipeline.Won},
+		{ID: 13, Name: "Lost", Position: 3, Kind: pipeline.Lost},
+	}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func stage(t *testing.T, p pipeline.Pipeline, id uint) pipeline.Stage {
+	t.Helper()
+	s, ok := p.Stage(id)
+	if !ok {
+		t.Fatalf("stage %d not found", id)
+	}
+	return s
+}
+
+func TestDeal_MoveTo(t *testing.T) {
+	p := newPipeline(t)
+	d := deal.New("Website", 1, 2, stage(t, p, 10), money.New(1000, currency.UsdCode), nil, 2)
+	if d.Status() != deal.Open || len(d.History()) != 1 {
+		t.Fatalf("expected an open deal with one history entry, got %s with %d", d.Status(), len(d.History()))
+	}
+
+	if err := d.MoveTo(stage(t, p, 10), 3); !errors.Is(err, deal.ErrSameStage) {
+		t.Errorf("expected ErrSameStage, got %v", err)
+	}
+	if err := d.MoveTo(pipeline.Stage{ID: 20, PipelineID: 2, Kind: pipeline.Open}, 3); !errors.Is(err, deal.ErrStageOfOtherPipeline) {
+		t.Errorf("expected ErrStageOfOtherPipeline, got %v", err)
+	}
+
+	if err := d.MoveTo(stage(t, p, 12), 3); err != nil {
+		t.Fatal(err)
+	}
+	if d.Status() != deal.Won || d.ClosedAt() == nil {
+		t.Errorf("expected a closed won deal, got %s", d.Status())
+	}
+	last := d.History()[len(d.History())-1]
+	if last.FromStageID != 10 || last.ToStageID != 12 || last.ChangedBy != 3 {
+		t.Errorf("unexpected history entry %+v", last)
+	}
+
+	if err := d.MoveTo(stage(t, p, 11), 3); err != nil {
+		t.Fatal(err)
+	}
+	if d.Status() != deal.Open || d.ClosedAt() != nil {
+		t.Errorf("expected a reopened deal, got %s", d.Status())
+	}
+}
+
+func TestDeal_Convert(t *testing.T) {
+	p := newPipeline(t)
+	d := deal.New("Website", 1, 2, stage(t, p, 11), money.New(1000, currency.UsdCode), nil, 2)
+	if err := d.Convert(deal.Invoice); !errors.Is(err, deal.ErrNotWon) {
+		t.Errorf("expected ErrNotWon, got %v", err)
+	}
+	if err := d.MoveTo(stage(t, p, 12), 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Convert(deal.Invoice); err != nil {
+		t.Fatal(err)
+	}
+	if d.Conversion() == nil || d.Conversion().Target != deal.Invoice {
+		t.Errorf("expected the deal to be converted into an invoice, got %+v", d.Conversion())
+	}
+	if err := d.Convert(deal.Order); !errors.Is(err, deal.ErrAlreadyConverted) {
+		t.Errorf("expected ErrAlreadyConverted, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package deal
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+)
+
+// Status follows the kind of the stage the deal is in.
+type Status string
+
+const (
+	Open Status = "open"
+	Won  Status = "won"
+	Lost Status = "lost"
+)
+
+var Statuses = []Status{Open, Won, Lost}
+
+func NewStatus(value string) (Status, error) {
+	s := Status(value)
+	switch s {
+	case Open, Won, Lost:
+		return s, nil
+	}
+	return "", ErrInvalidStatus
+}
+
+func statusOf(kind pipeline.StageKind) Status {
+	switch kind {
+	case pipeline.Won:
+		return Won
+	case pipeline.Lost:
+		return Lost
+	default:
+		return Open
+	}
+}
+
+// ConversionTarget is what a won deal is turned into.
+type ConversionTarget string
+
+const (
+	Invoice ConversionTarget = "invoice"
+	Order   ConversionTarget = "order"
+)
+
+var ConversionTargets = []ConversionTarget{Invoice, Order}
+
+func NewConversionTarget(value string) (ConversionTarget, error) {
+	t := ConversionTarget(value)
+	switch t {
+	case Invoice, Order:
+		return t, nil
+	}
+	return "", ErrInvalidConversionTarget
+}
+
+type Conversion struct {
+	Target      ConversionTarget
+	ConvertedAt time.Time
+}
+
+// StageChange is an entry of the stage history. FromStageID is zero for the stage a deal was created in.
+type StageChange struct {
+	ID          uint
+	FromStageID uint
+	ToStageID   uint
+	ChangedBy   uint
+	ChangedAt   time.Time
+}
@@ -0,0 +1,71 @@
+package activity
+
+import (
+	"time"
+)
+
+// Kind is what happened to a client. Notes and calls are logged by the staff, payments and
+// orders are recorded from the finance and warehouse modules, messages come from the chats.
+type Kind string
+
+const (
+	Message Kind = "message"
+	Note    Kind = "note"
+	Call    Kind = "call"
+	Payment Kind = "payment"
+	Order   Kind = "order"
+)
+
+var Kinds = []Kind{Message, Note, Call, Payment, Order}
+
+func NewKind(value string) (Kind, error) {
+	k := Kind(value)
+	if !k.IsValid() {
+		return "", ErrUnknownKind
+	}
+	return k, nil
+}
+
+func (k Kind) IsValid() bool {
+	switch k {
+	case Message, Note, Call, Payment, Order:
+		return true
+	}
+	return false
+}
+
+// Direction tells whether the client or the staff started a call or sent a message.
+type Direction string
+
+const (
+	Inbound  Direction = "inbound"
+	Outbound Direction = "outbound"
+)
+
+func NewDirection(value string) (Direction, error) {
+	d := Direction(value)
+	if d != Inbound && d != Outbound {
+		return "", ErrUnknownDirection
+	}
+	return d, nil
+}
+
+// Activity is an entry of the timeline of a client stored by the CRM. Chat messages are not
+// activities, they are read from the chats when the timeline is built.
+type Activity interface {
+	ID() uint
+	ClientID() uint
+	Kind() Kind
+	// RefID is the ID of the payment or the order in the module it comes from
+	RefID() uint
+	Body() string
+	Direction() Direction
+	Duration() time.Duration
+	Amount() float64
+	Currency() string
+	Status() string
+	// AuthorID is the user who logged a note or a call
+	AuthorID() uint
+	OccurredAt() time.Time
+	CreatedAt() time.Time
+}
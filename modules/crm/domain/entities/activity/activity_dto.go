@@ -0,0 +1,82 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// CreateDTO logs a note or a call, calls also need a direction. Activities are logged at the
+// current time unless OccurredAt sets an earlier day.
+type CreateDTO struct {
+	Kind       string `validate:"required"`
+	Body       string
+	Direction  string
+	Minutes    int
+	OccurredAt shared.DateOnly
+}
+
+func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		panic(composables.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	if errs := constants.Validate.Struct(d); errs != nil {
+		for _, err := range errs.(validator.ValidationErrors) {
+			errorMessages[err.Field()] = localizeError(l, err.Field(), err.Tag())
+		}
+	}
+	switch Kind(d.Kind) {
+	case Note:
+		if d.Body == "" {
+			errorMessages["Body"] = localizeError(l, "Body", "required")
+		}
+	case Call:
+		if _, err := NewDirection(d.Direction); err != nil {
+			errorMessages["Direction"] = localizeError(l, "Direction", "emptySelect")
+		}
+	default:
+		if _, ok := errorMessages["Kind"]; !ok {
+			errorMessages["Kind"] = localizeError(l, "Kind", "emptySelect")
+		}
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func localizeError(l *i18n.Localizer, field, tag string) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: fmt.Sprintf("ValidationErrors.%s", tag),
+		TemplateData: map[string]string{
+			"Field": l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("ClientActivities.Single.%s.Label", field),
+			}),
+		},
+	})
+}
+
+func (d *CreateDTO) ToEntity(clientID, authorID uint) (Activity, error) {
+	occurredAt := time.Now()
+	if day := time.Time(d.OccurredAt); !day.IsZero() && day.Before(occurredAt.Truncate(24*time.Hour)) {
+		occurredAt = day
+	}
+	switch Kind(d.Kind) {
+	case Note:
+		return NewNote(clientID, authorID, d.Body, occurredAt), nil
+	case Call:
+		direction, err := NewDirection(d.Direction)
+		if err != nil {
+			return nil, err
+		}
+		minutes := max(d.Minutes, 0)
+		return NewCall(clientID, authorID, direction, time.Duration(minutes)*time.Minute, d.Body, occurredAt), nil
+	}
+	return nil, ErrNotLoggable
+}
@@ -0,0 +1,9 @@
+package activity
+
+import "errors"
+
+var (
+	ErrUnknownKind      = errors.New("unknown activity kind")
+	ErrUnknownDirection = errors.New("unknown call direction")
+	ErrNotLoggable      = errors.New("only notes and calls can be logged")
+)
@@ -0,0 +1,158 @@
+package activity
+
+import (
+	"time"
+)
+
+func NewNote(clientID, authorID uint, body string, occurredAt time.Time) Activity {
+	return &activity{
+		clientID:   clientID,
+		kind:       Note,
+		body:       body,
+		authorID:   authorID,
+		occurredAt: occurredAt,
+		createdAt:  time.Now(),
+	}
+}
+
+func NewCall(
+	clientID, authorID uint,
+	direction Direction,
+	duration time.Duration,
+	body string,
+	occurredAt time.Time,
+) Activity {
+	return &activity{
+		clientID:   clientID,
+		kind:       Call,
+		body:       body,
+		direction:  direction,
+		duration:   duration,
+		authorID:   authorID,
+		occurredAt: occurredAt,
+		createdAt:  time.Now(),
+	}
+}
+
+// NewPayment records a payment of the finance module made by the counterparty of the client.
+func NewPayment(clientID, paymentID uint, amount float64, currency, comment string, occurredAt time.Time) Activity {
+	return &activity{
+		clientID:   clientID,
+		kind:       Payment,
+		refID:      paymentID,
+		body:       comment,
+		amount:     amount,
+		currency:   currency,
+		occurredAt: occurredAt,
+		createdAt:  time.Now(),
+	}
+}
+
+// NewOrder records a warehouse order of the client, in the status it has at occurredAt.
+func NewOrder(clientID, orderID uint, status string, occurredAt time.Time) Activity {
+	return &activity{
+		clientID:   clientID,
+		kind:       Order,
+		refID:      orderID,
+		status:     status,
+		occurredAt: occurredAt,
+		createdAt:  time.Now(),
+	}
+}
+
+func NewWithID(
+	id, clientID uint,
+	kind Kind,
+	refID uint,
+	body string,
+	direction Direction,
+	duration time.Duration,
+	amount float64,
+	currency, status string,
+	authorID uint,
+	occurredAt, createdAt time.Time,
+) Activity {
+	return &activity{
+		id:         id,
+		clientID:   clientID,
+		kind:       kind,
+		refID:      refID,
+		body:       body,
+		direction:  direction,
+		duration:   duration,
+		amount:     amount,
+		currency:   currency,
+		status:     status,
+		authorID:   authorID,
+		occurredAt: occurredAt,
+		createdAt:  createdAt,
+	}
+}
+
+type activity struct {
+	id         uint
+	clientID   uint
+	kind       Kind
+	refID      uint
+	body       string
+	direction  Direction
+	duration   time.Duration
+	amount     float64
+	currency   string
+	status     string
+	authorID   uint
+	occurredAt time.Time
+	createdAt  time.Time
+}
+
+func (a *activity) ID() uint {
+	return a.id
+}
+
+func (a *activity) ClientID() uint {
+	return a.clientID
+}
+
+func (a *activity) Kind() Kind {
+	return a.kind
+}
+
+func (a *activity) RefID() uint {
+	return a.refID
+}
+
+func (a *activity) Body() string {
+	return a.body
+}
+
+func (a *activity) Direction() Direction {
+	return a.direction
+}
+
+func (a *activity) Duration() time.Duration {
+	return a.duration
+}
+
+func (a *activity) Amount() float64 {
+	return a.amount
+}
+
+func (a *activity) Currency() string {
+	return a.currency
+}
+
+func (a *activity) Status() string {
+	return a.status
+}
+
+func (a *activity) AuthorID() uint {
+	return a.authorID
+}
+
+func (a *activity) OccurredAt() time.Time {
+	return a.occurredAt
+}
+
+func (a *activity) CreatedAt() time.Time {
+	return a.createdAt
+}
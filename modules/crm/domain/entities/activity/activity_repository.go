@@ -0,0 +1,20 @@
+package activity
+
+import (
+	"context"
+)
+
+type Repository interface {
+	GetByID(ctx context.Context, id uint) (Activity, error)
+	Create(ctx context.Context, data Activity) (Activity, error)
+	Delete(ctx context.Context, id uint) error
+	// DeleteByRef removes the activities recorded for a payment or an order.
+	DeleteByRef(ctx context.Context, kind Kind, refID uint) error
+	// ClientIDsByRef returns the clients activities were recorded for a payment or an order.
+	ClientIDsByRef(ctx context.Context, kind Kind, refID uint) ([]uint, error)
+	// Timeline returns the activities and the chat messages of a client, newest first.
+	Timeline(ctx context.Context, params *TimelineParams) ([]Entry, error)
+	// LinkCounterparty records that the finance counterparty pays on behalf of the client.
+	LinkCounterparty(ctx context.Context, clientID, counterpartyID uint) error
+	ClientIDsByCounterparty(ctx context.Context, counterpartyID uint) ([]uint, error)
+}
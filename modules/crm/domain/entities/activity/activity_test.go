@@ -0,0 +1,44 @@
+package activity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+func TestCreateDTO_ToEntity(t *testing.T) {
+	call := &activity.CreateDTO{Kind: "call", Direction: "inbound", Minutes: 5, Body: "asked about the delivery"}
+	entity, err := call.ToEntity(3, 7)
+	if err != nil {
+		t.Fatalf("expected a call, got %v", err)
+	}
+	if entity.Kind() != activity.Call || entity.Direction() != activity.Inbound || entity.Duration() != 5*time.Minute {
+		t.Errorf("expected an inbound call of 5 minutes, got %s %s %s", entity.Kind(), entity.Direction(), entity.Duration())
+	}
+	if entity.ClientID() != 3 || entity.AuthorID() != 7 {
+		t.Errorf("expected client 3 and author 7, got %d and %d", entity.ClientID(), entity.AuthorID())
+	}
+	if time.Since(entity.OccurredAt()) > time.Minute {
+		t.Errorf("expected the call to be logged now, got %s", entity.OccurredAt())
+	}
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	note := &activity.CreateDTO{Kind: "note", Body: "prefers email", OccurredAt: shared.DateOnly(day)}
+	entity, err = note.ToEntity(3, 7)
+	if err != nil {
+		t.Fatalf("expected a note, got %v", err)
+	}
+	if !entity.OccurredAt().Equal(day) {
+		t.Errorf("expected the note to be logged on %s, got %s", day, entity.OccurredAt())
+	}
+
+	if _, err := (&activity.CreateDTO{Kind: "payment"}).ToEntity(3, 7); !errors.Is(err, activity.ErrNotLoggable) {
+		t.Errorf("expected payments not to be loggable, got %v", err)
+	}
+	if _, err := (&activity.CreateDTO{Kind: "call", Direction: "sideways"}).ToEntity(3, 7); !errors.Is(err, activity.ErrUnknownDirection) {
+		t.Errorf("expected an unknown direction error, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package activity
+
+import (
+	"time"
+)
+
+// Entry is an item of the timeline of a client, either an activity or a chat message.
+type Entry struct {
+	Kind Kind
+	// ID of the activity, or of the message for messages
+	ID        uint
+	RefID     uint
+	Body      string
+	Channel   string
+	Direction Direction
+	Duration  time.Duration
+	Amount    float64
+	Currency  string
+	Status    string
+	// AuthorID is the user who logged the activity or sent the message, 0 for the client
+	AuthorID   uint
+	OccurredAt time.Time
+}
+
+type TimelineParams struct {
+	ClientID uint
+	// Kinds narrows the timeline down to some kinds, all kinds are included when it is empty
+	Kinds  []Kind
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
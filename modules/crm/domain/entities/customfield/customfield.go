@@ -0,0 +1,21 @@
+package customfield
+
+import "time"
+
+// Field is an admin-defined attribute of clients, such as a city or a contract number.
+type Field interface {
+	ID() uint
+	// Key names the field in segment filters, e.g. field.city.
+	Key() string
+	Label() string
+	Type() Type
+	// Options are the choices of a select field.
+	Options() []string
+	Required() bool
+	Position() int
+	CreatedAt() time.Time
+
+	// Validate checks a value entered for the field. An empty value is valid unless the field is required.
+	Validate(value string) error
+	Update(label string, fieldType Type, options []string, required bool, position int) (Field, error)
+}
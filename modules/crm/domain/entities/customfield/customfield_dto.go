@@ -0,0 +1,95 @@
+package customfield
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+type CreateDTO struct {
+	Key      string `validate:"required"`
+	Label    string `validate:"required"`
+	Type     string `validate:"required"`
+	Options  string
+	Required bool
+	Position int
+}
+
+func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	errorMessages := validate(ctx, d)
+	if d.Key != "" && !keyPattern.MatchString(d.Key) {
+		errorMessages["Key"] = composables.MustT(ctx, "ClientFields.Errors.Key")
+	}
+	validateTypeOptions(ctx, d.Type, d.Options, errorMessages)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) ToEntity() (Field, error) {
+	return New(strings.TrimSpace(d.Key), strings.TrimSpace(d.Label), Type(d.Type), ParseOptions(d.Options), d.Required, d.Position)
+}
+
+type UpdateDTO struct {
+	Label    string `validate:"required"`
+	Type     string `validate:"required"`
+	Options  string
+	Required bool
+	Position int
+}
+
+func (d *UpdateDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	errorMessages := validate(ctx, d)
+	validateTypeOptions(ctx, d.Type, d.Options, errorMessages)
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *UpdateDTO) Apply(entity Field) (Field, error) {
+	return entity.Update(strings.TrimSpace(d.Label), Type(d.Type), ParseOptions(d.Options), d.Required, d.Position)
+}
+
+// ParseOptions reads the options of a select field entered one per line.
+func ParseOptions(v string) []string {
+	return normalizeOptions(strings.Split(v, "\n"))
+}
+
+func validate(ctx context.Context, dto interface{}) map[string]string {
+	l, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		panic(composables.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(dto)
+	if errs == nil {
+		return errorMessages
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ClientFields.Single.%s.Label", err.Field()),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+	return errorMessages
+}
+
+func validateTypeOptions(ctx context.Context, fieldType, options string, errorMessages map[string]string) {
+	if _, ok := errorMessages["Type"]; ok {
+		return
+	}
+	if !Type(fieldType).IsValid() {
+		errorMessages["Type"] = composables.MustT(ctx, "ClientFields.Errors.Type")
+		return
+	}
+	if Type(fieldType) == Select && len(ParseOptions(options)) == 0 {
+		errorMessages["Options"] = composables.MustT(ctx, "ClientFields.Errors.Options")
+	}
+}
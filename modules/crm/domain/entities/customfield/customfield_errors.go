@@ -0,0 +1,15 @@
+package customfield
+
+import "errors"
+
+var (
+	ErrInvalidKey    = errors.New("custom field key must start with a letter and contain only lowercase letters, digits and underscores")
+	ErrInvalidType   = errors.New("invalid custom field type")
+	ErrNoOptions     = errors.New("select field has no options")
+	ErrRequired      = errors.New("value is required")
+	ErrInvalidNumber = errors.New("value is not a number")
+	ErrInvalidDate   = errors.New("value is not a date")
+	ErrUnknownOption = errors.New("value is not one of the options")
+	ErrKeyTaken      = errors.New("custom field key is taken")
+	ErrFieldInUse    = errors.New("custom field is used by a segment")
+)
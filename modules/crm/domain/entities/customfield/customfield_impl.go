@@ -0,0 +1,136 @@
+package customfield
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func New(key, label string, fieldType Type, options []string, required bool, position int) (Field, error) {
+	return NewWithID(0, key, label, fieldType, options, required, position, time.Now())
+}
+
+func NewWithID(
+	id uint,
+	key, label string,
+	fieldType Type,
+	options []string,
+	required bool,
+	position int,
+	createdAt time.Time,
+) (Field, error) {
+	if !keyPattern.MatchString(key) {
+		return nil, ErrInvalidKey
+	}
+	if !fieldType.IsValid() {
+		return nil, ErrInvalidType
+	}
+	options = normalizeOptions(options)
+	if fieldType != Select {
+		options = nil
+	} else if len(options) == 0 {
+		return nil, ErrNoOptions
+	}
+	return &field{
+		id:        id,
+		key:       key,
+		label:     label,
+		fieldType: fieldType,
+		options:   options,
+		required:  required,
+		position:  position,
+		createdAt: createdAt,
+	}, nil
+}
+
+type field struct {
+	id        uint
+	key       string
+	label     string
+	fieldType Type
+	options   []string
+	required  bool
+	position  int
+	createdAt time.Time
+}
+
+func normalizeOptions(options []string) []string {
+	result := make([]string, 0, len(options))
+	seen := make(map[string]bool, len(options))
+	for _, o := range options {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[o] {
+			continue
+		}
+		seen[o] = true
+		result = append(result, o)
+	}
+	return result
+}
+
+func (f *field) ID() uint {
+	return f.id
+}
+
+func (f *field) Key() string {
+	return f.key
+}
+
+func (f *field) Label() string {
+	return f.label
+}
+
+func (f *field) Type() Type {
+	return f.fieldType
+}
+
+func (f *field) Options() []string {
+	return f.options
+}
+
+func (f *field) Required() bool {
+	return f.required
+}
+
+func (f *field) Position() int {
+	return f.position
+}
+
+func (f *field) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+func (f *field) Validate(value string) error {
+	if value == "" {
+		if f.required {
+			return ErrRequired
+		}
+		return nil
+	}
+	switch f.fieldType {
+	case Number:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return ErrInvalidNumber
+		}
+	case Date:
+		if _, err := time.Parse(DateLayout, value); err != nil {
+			return ErrInvalidDate
+		}
+	case Select:
+		for _, o := range f.options {
+			if o == value {
+				return nil
+			}
+		}
+		return ErrUnknownOption
+	}
+	return nil
+}
+
+// Update changes everything but the key, which segment filters refer to.
+func (f *field) Update(label string, fieldType Type, options []string, required bool, position int) (Field, error) {
+	return NewWithID(f.id, f.key, label, fieldType, options, required, position, f.createdAt)
+}
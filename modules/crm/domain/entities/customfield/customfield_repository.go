@@ -0,0 +1,14 @@
+package customfield
+
+import "context"
+
+type Repository interface {
+	// GetAll returns the fields ordered by position.
+	GetAll(ctx context.Context) ([]Field, error)
+	GetByID(ctx context.Context, id uint) (Field, error)
+	GetByKey(ctx context.Context, key string) (Field, error)
+	Create(ctx context.Context, data Field) (Field, error)
+	Update(ctx context.Context, data Field) (Field, error)
+	// Delete removes the field along with its values.
+	Delete(ctx context.Context, id uint) error
+}
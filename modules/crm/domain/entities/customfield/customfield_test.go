@@ -0,0 +1,56 @@
+package customfield_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := customfield.New("City", "City", customfield.Text, nil, false, 0); !errors.Is(err, customfield.ErrInvalidKey) {
+		t.Errorf("expected ErrInvalidKey, got %v", err)
+	}
+	if _, err := customfield.New("city", "City", "list", nil, false, 0); !errors.Is(err, customfield.ErrInvalidType) {
+		t.Errorf("expected ErrInvalidType, got %v", err)
+	}
+	if _, err := customfield.New("source", "Source", customfield.Select, []string{" ", ""}, false, 0); !errors.Is(err, customfield.ErrNoOptions) {
+		t.Errorf("expected ErrNoOptions, got %v", err)
+	}
+	f, err := customfield.New("city", "City", customfield.Text, []string{"ignored"}, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Options()) != 0 {
+		t.Errorf("expected a text field to have no options, got %v", f.Options())
+	}
+}
+
+func TestField_Validate(t *testing.T) {
+	number, _ := customfield.New("visits", "Visits", customfield.Number, nil, true, 0)
+	date, _ := customfield.New("since", "Since", customfield.Date, nil, false, 1)
+	source, _ := customfield.New("source", "Source", customfield.Select, []string{"Ads", "Referral", "Ads"}, false, 2)
+
+	tests := []struct {
+		field customfield.Field
+		value string
+		want  error
+	}{
+		{number, "", customfield.ErrRequired},
+		{number, "12.5", nil},
+		{number, "twelve", customfield.ErrInvalidNumber},
+		{date, "", nil},
+		{date, "2024-02-29", nil},
+		{date, "29.02.2024", customfield.ErrInvalidDate},
+		{source, "Referral", nil},
+		{source, "TV", customfield.ErrUnknownOption},
+	}
+	for _, tt := range tests {
+		if err := tt.field.Validate(tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("%s.Validate(%q) = %v, want %v", tt.field.Key(), tt.value, err, tt.want)
+		}
+	}
+	if len(source.Options()) != 2 {
+		t.Errorf("expected repeated options to be dropped, got %v", source.Options())
+	}
+}
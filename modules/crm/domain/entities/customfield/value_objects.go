@@ -0,0 +1,25 @@
+package customfield
+
+import "time"
+
+type Type string
+
+const (
+	Text   Type = "text"
+	Number Type = "number"
+	Date   Type = "date"
+	Select Type = "select"
+)
+
+var Types = []Type{Text, Number, Date, Select}
+
+func (t Type) IsValid() bool {
+	switch t {
+	case Text, Number, Date, Select:
+		return true
+	}
+	return false
+}
+
+// DateLayout is how date values are stored.
+const DateLayout = time.DateOnly
@@ -1,26 +1,37 @@
 package messagetemplate
 
-import "time"
+import (
+	"slices"
+	"time"
 
-func New(template string) MessageTemplate {
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+)
+
+func New(name, template string, variants ...Variant) MessageTemplate {
 	return &messageTemplate{
 		id:        0,
+		name:      name,
 		template:  template,
+		variants:  variants,
 		createdAt: time.Now(),
 	}
 }
 
-func NewWithID(id uint, template string, createdAt time.Time) MessageTemplate {
+func NewWithID(id uint, name, template string, variants []Variant, createdAt time.Time) MessageTemplate {
 	return &messageTemplate{
 		id:        id,
+		name:      name,
 		template:  template,
+		variants:  variants,
 		createdAt: createdAt,
 	}
 }
 
 type messageTemplate struct {
 	id        uint
+	name      string
 	template  string
+	variants  []Variant
 	createdAt time.Time
 }
 
@@ -28,14 +39,34 @@ func (m *messageTemplate) ID() uint {
 	return m.id
 }
 
+func (m *messageTemplate) Name() string {
+	return m.name
+}
+
 func (m *messageTemplate) Template() string {
 	return m.template
 }
 
+func (m *messageTemplate) Variants() []Variant {
+	return slices.Clone(m.variants)
+}
+
 func (m *messageTemplate) UpdateTemplate(template string) MessageTemplate {
 	return &messageTemplate{
 		id:        m.id,
+		name:      m.name,
+		template:  template,
+		variants:  m.variants,
+		createdAt: m.createdAt,
+	}
+}
+
+func (m *messageTemplate) Update(name, template string, variants []Variant) MessageTemplate {
+	return &messageTemplate{
+		id:        m.id,
+		name:      name,
 		template:  template,
+		variants:  slices.Clone(variants),
 		createdAt: m.createdAt,
 	}
 }
@@ -43,3 +74,35 @@ func (m *messageTemplate) UpdateTemplate(template string) MessageTemplate {
 func (m *messageTemplate) CreatedAt() time.Time {
 	return m.createdAt
 }
+
+func (m *messageTemplate) Variant(locale string, channel chat.Channel) Variant {
+	candidates := [][2]string{
+		{locale, string(channel)},
+		{locale, ""},
+		{"", string(channel)},
+		{"", ""},
+	}
+	for _, c := range candidates {
+		for _, v := range m.variants {
+			if v.matches(c[0], chat.Channel(c[1])) {
+				return v
+			}
+		}
+	}
+	return Variant{Body: m.template}
+}
+
+func (m *messageTemplate) Render(locale string, channel chat.Channel, values Values) *Rendered {
+	variant := m.Variant(locale, channel)
+	text, params, missing := Render(variant.Body, values)
+	rendered := &Rendered{
+		Text:    text,
+		Variant: variant,
+		Params:  params,
+		Missing: missing,
+	}
+	if channel == chat.SMS {
+		rendered.SMSSegments, _ = SMSSegments(text)
+	}
+	return rendered
+}
@@ -1,10 +1,23 @@
 package messagetemplate
 
-import "time"
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+)
 
 type MessageTemplate interface {
 	ID() uint
+	Name() string
+	// Template is the default text used when no variant matches.
 	Template() string
+	Variants() []Variant
 	UpdateTemplate(template string) MessageTemplate
+	Update(name, template string, variants []Variant) MessageTemplate
 	CreatedAt() time.Time
+
+	// Variant picks the text for the locale and the channel, preferring an exact match over
+	// a variant for any channel, then a variant for any locale and finally the default text.
+	Variant(locale string, channel chat.Channel) Variant
+	Render(locale string, channel chat.Channel, values Values) *Rendered
 }
@@ -2,17 +2,39 @@ package messagetemplate
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
 )
 
+type VariantDTO struct {
+	Locale     string
+	Channel    string
+	Body       string
+	ExternalID string
+}
+
+func (d *VariantDTO) ToVariant() Variant {
+	return Variant{
+		Locale:     d.Locale,
+		Channel:    chat.Channel(d.Channel),
+		Body:       d.Body,
+		ExternalID: strings.TrimSpace(d.ExternalID),
+	}
+}
+
 type CreateDTO struct {
+	Name     string `validate:"required"`
 	Template string `validate:"required"`
+	Variants []VariantDTO
 }
 
 func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
@@ -22,30 +44,31 @@ func (d *CreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
 	}
 	errorMessages := map[string]string{}
 	errs := constants.Validate.Struct(d)
-	if errs == nil {
-		return errorMessages, true
-	}
-	for _, err := range errs.(validator.ValidationErrors) {
-		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
-			MessageID: fmt.Sprintf("InstantMessages.Single.%s.Label", err.Field()),
-		})
-		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
-			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
-			TemplateData: map[string]string{
-				"Field": translatedFieldName,
-			},
-		})
+	if errs != nil {
+		for _, err := range errs.(validator.ValidationErrors) {
+			translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("MessageTemplates.Single.%s.Label", err.Field()),
+			})
+			errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+				TemplateData: map[string]string{
+					"Field": translatedFieldName,
+				},
+			})
+		}
 	}
-
+	validateContent(l, d.Template, d.Variants, errorMessages)
 	return errorMessages, len(errorMessages) == 0
 }
 
-func (c *CreateDTO) ToEntity() MessageTemplate {
-	return New(c.Template)
+func (d *CreateDTO) ToEntity() MessageTemplate {
+	return New(d.Name, d.Template, toVariants(d.Variants)...)
 }
 
 type UpdateDTO struct {
+	Name     string `validate:"required"`
 	Template string `validate:"required"`
+	Variants []VariantDTO
 }
 
 func (d *UpdateDTO) Ok(ctx context.Context) (map[string]string, bool) {
@@ -55,24 +78,109 @@ func (d *UpdateDTO) Ok(ctx context.Context) (map[string]string, bool) {
 	}
 	errorMessages := map[string]string{}
 	errs := constants.Validate.Struct(d)
-	if errs == nil {
-		return errorMessages, true
+	if errs != nil {
+		for _, err := range errs.(validator.ValidationErrors) {
+			translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("MessageTemplates.Single.%s.Label", err.Field()),
+			})
+			errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+				TemplateData: map[string]string{
+					"Field": translatedFieldName,
+				},
+			})
+		}
 	}
-	for _, err := range errs.(validator.ValidationErrors) {
-		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
-			MessageID: fmt.Sprintf("InstantMessages.Single.%s.Label", err.Field()),
-		})
-		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
-			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
-			TemplateData: map[string]string{
-				"Field": translatedFieldName,
-			},
-		})
-	}
-
+	validateContent(l, d.Template, d.Variants, errorMessages)
 	return errorMessages, len(errorMessages) == 0
 }
 
 func (d *UpdateDTO) Apply(entity MessageTemplate) MessageTemplate {
-	return entity.UpdateTemplate(d.Template)
+	return entity.Update(d.Name, d.Template, toVariants(d.Variants))
+}
+
+// toVariants drops the variant rows left empty in the form.
+func toVariants(dtos []VariantDTO) []Variant {
+	variants := make([]Variant, 0, len(dtos))
+	for _, d := range dtos {
+		if strings.TrimSpace(d.Body) == "" {
+			continue
+		}
+		variants = append(variants, d.ToVariant())
+	}
+	return variants
+}
+
+// ValidateVariant checks a variant regardless of the form it came from.
+func ValidateVariant(v Variant) error {
+	if v.Locale != "" && !isSupportedLocale(v.Locale) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedLocale, v.Locale)
+	}
+	if v.Channel != "" && !v.Channel.IsValid() {
+		return fmt.Errorf("%w: %s", chat.ErrUnknownChannel, v.Channel)
+	}
+	if unknown := UnknownPlaceholders(v.Body); len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownPlaceholder, strings.Join(unknown, ", "))
+	}
+	if v.ExternalID != "" && v.Channel != chat.WhatsApp {
+		return ErrExternalIDChannel
+	}
+	if v.Channel == chat.SMS {
+		if segments, _ := SMSSegments(v.Body); segments > MaxSMSSegments {
+			return ErrSMSTooLong
+		}
+	}
+	return nil
+}
+
+func isSupportedLocale(locale string) bool {
+	for _, lang := range intl.SupportedLanguages {
+		if lang.Code == locale {
+			return true
+		}
+	}
+	return false
+}
+
+func validateContent(l *i18n.Localizer, template string, variants []VariantDTO, errorMessages map[string]string) {
+	if unknown := UnknownPlaceholders(template); len(unknown) > 0 {
+		errorMessages["Template"] = unknownPlaceholdersMessage(l, unknown)
+	}
+	seen := map[[2]string]bool{}
+	for i, dto := range variants {
+		if strings.TrimSpace(dto.Body) == "" {
+			continue
+		}
+		key := fmt.Sprintf("Variants.%d", i)
+		v := dto.ToVariant()
+		if seen[[2]string{v.Locale, string(v.Channel)}] {
+			errorMessages[key] = l.MustLocalize(&i18n.LocalizeConfig{MessageID: "MessageTemplates.Errors.DuplicateVariant"})
+			continue
+		}
+		seen[[2]string{v.Locale, string(v.Channel)}] = true
+		err := ValidateVariant(v)
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrUnknownPlaceholder):
+			errorMessages[key] = unknownPlaceholdersMessage(l, UnknownPlaceholders(v.Body))
+		case errors.Is(err, ErrSMSTooLong):
+			errorMessages[key] = l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID:    "MessageTemplates.Errors.SMSTooLong",
+				TemplateData: map[string]int{"Segments": MaxSMSSegments},
+			})
+		case errors.Is(err, ErrExternalIDChannel):
+			errorMessages[key] = l.MustLocalize(&i18n.LocalizeConfig{MessageID: "MessageTemplates.Errors.ExternalIDChannel"})
+		default:
+			errorMessages[key] = l.MustLocalize(&i18n.LocalizeConfig{MessageID: "MessageTemplates.Errors.InvalidVariant"})
+		}
+	}
+}
+
+func unknownPlaceholdersMessage(l *i18n.Localizer, unknown []string) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		MessageID: "MessageTemplates.Errors.UnknownPlaceholder",
+		TemplateData: map[string]string{
+			"Placeholders": strings.Join(unknown, ", "),
+		},
+	})
 }
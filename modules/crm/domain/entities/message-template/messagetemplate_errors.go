@@ -0,0 +1,12 @@
+package messagetemplate
+
+import "errors"
+
+var (
+	ErrUnknownPlaceholder = errors.New("unknown placeholder")
+	ErrUnsupportedLocale  = errors.New("unsupported locale")
+	ErrDuplicateVariant   = errors.New("duplicate variant")
+	ErrSMSTooLong         = errors.New("sms is too long")
+	ErrExternalIDChannel  = errors.New("approved template id is only supported for whatsapp")
+	ErrTemplateInUse      = errors.New("template is used by campaigns")
+)
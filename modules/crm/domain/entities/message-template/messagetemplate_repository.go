@@ -15,5 +15,6 @@ type Repository interface {
 	GetByID(ctx context.Context, id uint) (MessageTemplate, error)
 	Create(ctx context.Context, data MessageTemplate) (MessageTemplate, error)
 	Update(ctx context.Context, data MessageTemplate) (MessageTemplate, error)
+	// Delete fails with ErrTemplateInUse while campaigns send the template.
 	Delete(ctx context.Context, id uint) error
 }
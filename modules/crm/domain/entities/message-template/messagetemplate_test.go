@@ -0,0 +1,111 @@
+package messagetemplate_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/phone"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+)
+
+func TestMessageTemplate_Variant(t *testing.T) {
+	tmpl := messagetemplate.New(
+		"greeting",
+		"Hello",
+		messagetemplate.Variant{Locale: "ru", Body: "Здравствуйте"},
+		messagetemplate.Variant{Locale: "ru", Channel: chat.SMS, Body: "Привет"},
+		messagetemplate.Variant{Channel: chat.WhatsApp, Body: "Hi", ExternalID: "greeting"},
+	)
+	cases := []struct {
+		locale  string
+		channel chat.Channel
+		want    string
+	}{
+		{"ru", chat.SMS, "Привет"},
+		{"ru", chat.Telegram, "Здравствуйте"},
+		{"en", chat.WhatsApp, "Hi"},
+		{"en", chat.SMS, "Hello"},
+	}
+	for _, c := range cases {
+		if got := tmpl.Variant(c.locale, c.channel).Body; got != c.want {
+			t.Errorf("%s/%s: expected %q, got %q", c.locale, c.channel, c.want, got)
+		}
+	}
+}
+
+func TestMessageTemplate_Render(t *testing.T) {
+	p, err := phone.NewFromE164("+998901234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := client.New("John", "Doe", "", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := messagetemplate.New("order", "Dear {{ full_name }}, order {{custom.order}} for {{phone}}, {{middle_name}}")
+	rendered := tmpl.Render("en", chat.SMS, messagetemplate.ClientValues(c, map[string]string{"order": "42"}))
+	if want := "Dear John Doe, order 42 for +998901234567, "; rendered.Text != want {
+		t.Errorf("expected %q, got %q", want, rendered.Text)
+	}
+	if !slices.Equal(rendered.Params, []string{"John Doe", "42", "+998901234567", ""}) {
+		t.Errorf("unexpected params: %v", rendered.Params)
+	}
+	if !slices.Equal(rendered.Missing, []string{"middle_name"}) {
+		t.Errorf("unexpected missing placeholders: %v", rendered.Missing)
+	}
+	if rendered.SMSSegments != 1 {
+		t.Errorf("expected 1 SMS segment, got %d", rendered.SMSSegments)
+	}
+}
+
+func TestUnknownPlaceholders(t *testing.T) {
+	got := messagetemplate.UnknownPlaceholders("{{first_name}} {{email}} {{custom.vip}} {{custom.}} {{email}}")
+	if !slices.Equal(got, []string{"email", "custom."}) {
+		t.Errorf("unexpected unknown placeholders: %v", got)
+	}
+}
+
+func TestSMSSegments(t *testing.T) {
+	cases := []struct {
+		text     string
+		segments int
+		unicode  bool
+	}{
+		{"", 0, false},
+		{strings.Repeat("a", 160), 1, false},
+		{strings.Repeat("a", 161), 2, false},
+		{strings.Repeat("€", 80), 1, false},
+		{strings.Repeat("€", 81), 2, false},
+		{strings.Repeat("я", 70), 1, true},
+		{strings.Repeat("я", 71), 2, true},
+	}
+	for _, c := range cases {
+		segments, unicode := messagetemplate.SMSSegments(c.text)
+		if segments != c.segments || unicode != c.unicode {
+			t.Errorf("%d runes: expected %d/%t, got %d/%t", len([]rune(c.text)), c.segments, c.unicode, segments, unicode)
+		}
+	}
+}
+
+func TestValidateVariant(t *testing.T) {
+	cases := []struct {
+		variant messagetemplate.Variant
+		want    error
+	}{
+		{messagetemplate.Variant{Locale: "en", Channel: chat.SMS, Body: "Hi {{first_name}}"}, nil},
+		{messagetemplate.Variant{Locale: "de", Body: "Hallo"}, messagetemplate.ErrUnsupportedLocale},
+		{messagetemplate.Variant{Body: "Hi {{email}}"}, messagetemplate.ErrUnknownPlaceholder},
+		{messagetemplate.Variant{Channel: chat.SMS, Body: "Hi", ExternalID: "hi"}, messagetemplate.ErrExternalIDChannel},
+		{messagetemplate.Variant{Channel: chat.SMS, Body: strings.Repeat("я", 700)}, messagetemplate.ErrSMSTooLong},
+	}
+	for _, c := range cases {
+		err := messagetemplate.ValidateVariant(c.variant)
+		if c.want == nil && err != nil || c.want != nil && !errors.Is(err, c.want) {
+			t.Errorf("%+v: expected %v, got %v", c.variant, c.want, err)
+		}
+	}
+}
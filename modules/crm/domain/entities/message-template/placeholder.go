@@ -0,0 +1,113 @@
+package messagetemplate
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+)
+
+type PlaceholderType string
+
+const (
+	PlaceholderText  PlaceholderType = "text"
+	PlaceholderPhone PlaceholderType = "phone"
+)
+
+// Placeholder is a variable a template refers to as {{name}}. Custom fields of the client
+// are referred to as {{custom.key}}.
+type Placeholder struct {
+	Name string
+	Type PlaceholderType
+}
+
+const customPrefix = "custom."
+
+var (
+	Placeholders = []Placeholder{
+		{Name: "first_name", Type: PlaceholderText},
+		{Name: "last_name", Type: PlaceholderText},
+		{Name: "middle_name", Type: PlaceholderText},
+		{Name: "full_name", Type: PlaceholderText},
+		{Name: "phone", Type: PlaceholderPhone},
+	}
+
+	placeholderRe = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_.]+)\s*\}\}`)
+	customKeyRe   = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+)
+
+// IsKnownPlaceholder reports whether name is a client field or a well-formed custom field.
+func IsKnownPlaceholder(name string) bool {
+	if key, ok := strings.CutPrefix(name, customPrefix); ok {
+		return customKeyRe.MatchString(key)
+	}
+	for _, p := range Placeholders {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePlaceholders returns the placeholder names used in body in order of first appearance.
+func ParsePlaceholders(body string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range placeholderRe.FindAllStringSubmatch(body, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// UnknownPlaceholders returns the placeholders used in body that cannot be resolved.
+func UnknownPlaceholders(body string) []string {
+	var unknown []string
+	for _, name := range ParsePlaceholders(body) {
+		if !IsKnownPlaceholder(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// Values maps placeholder names to their values.
+type Values map[string]string
+
+// ClientValues resolves the placeholders of a client. Custom fields are passed by key
+// without the "custom." prefix.
+func ClientValues(c client.Client, custom map[string]string) Values {
+	fullName := strings.Join(strings.Fields(strings.Join([]string{c.FirstName(), c.MiddleName(), c.LastName()}, " ")), " ")
+	values := Values{
+		"first_name":  c.FirstName(),
+		"last_name":   c.LastName(),
+		"middle_name": c.MiddleName(),
+		"full_name":   fullName,
+		"phone":       "",
+	}
+	if c.Phone() != nil && c.Phone().Value() != "" {
+		values["phone"] = "+" + c.Phone().Value()
+	}
+	for k, v := range custom {
+		values[customPrefix+k] = v
+	}
+	return values
+}
+
+// Render substitutes the placeholders of body. Placeholders without a value are rendered
+// empty and returned in missing; params are the values in order of first appearance.
+func Render(body string, values Values) (text string, params []string, missing []string) {
+	for _, name := range ParsePlaceholders(body) {
+		v, ok := values[name]
+		if !ok || v == "" {
+			missing = append(missing, name)
+		}
+		params = append(params, v)
+	}
+	text = placeholderRe.ReplaceAllStringFunc(body, func(s string) string {
+		return values[placeholderRe.FindStringSubmatch(s)[1]]
+	})
+	return text, params, missing
+}
@@ -0,0 +1,65 @@
+package messagetemplate
+
+const (
+	gsmSingleSegment     = 160
+	gsmMultiSegment      = 153
+	unicodeSingleSegment = 70
+	unicodeMultiSegment  = 67
+	// MaxSMSSegments is the longest SMS a template may render to.
+	MaxSMSSegments = 10
+)
+
+// gsmBasic is the GSM 03.38 basic character set, gsmExtended the characters taking two septets.
+const (
+	gsmBasic    = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsmExtended = "^{}\\[~]|€\f"
+)
+
+var (
+	gsmBasicSet    = runeSet(gsmBasic)
+	gsmExtendedSet = runeSet(gsmExtended)
+)
+
+func runeSet(s string) map[rune]bool {
+	set := make(map[rune]bool, len(s))
+	for _, r := range s {
+		set[r] = true
+	}
+	return set
+}
+
+// SMSSegments returns how many segments an SMS with the text is split into and whether it
+// has to be sent in UCS-2 because of characters outside the GSM 7-bit alphabet.
+func SMSSegments(text string) (segments int, unicode bool) {
+	if text == "" {
+		return 0, false
+	}
+	length := 0
+	for _, r := range text {
+		switch {
+		case gsmBasicSet[r]:
+			length++
+		case gsmExtendedSet[r]:
+			length += 2
+		default:
+			unicode = true
+		}
+	}
+	single, multi := gsmSingleSegment, gsmMultiSegment
+	if unicode {
+		single, multi = unicodeSingleSegment, unicodeMultiSegment
+		length = 0
+		for _, r := range text {
+			// characters outside the basic multilingual plane take two UTF-16 units
+			if r > 0xFFFF {
+				length += 2
+			} else {
+				length++
+			}
+		}
+	}
+	if length <= single {
+		return 1, unicode
+	}
+	return (length + multi - 1) / multi, unicode
+}
@@ -0,0 +1,31 @@
+package messagetemplate
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+)
+
+// Variant is the text of a template for a locale and a channel. An empty locale or channel
+// matches any. ExternalID is the name of the template approved by WhatsApp, which is sent
+// instead of free text so the message is delivered outside of the customer service window.
+type Variant struct {
+	Locale     string
+	Channel    chat.Channel
+	Body       string
+	ExternalID string
+}
+
+func (v Variant) matches(locale string, channel chat.Channel) bool {
+	return v.Locale == locale && v.Channel == channel
+}
+
+// Rendered is a template rendered for a client.
+type Rendered struct {
+	Text    string
+	Variant Variant
+	// Params are the placeholder values in order of appearance, the parameters of a WhatsApp template
+	Params []string
+	// Missing are the placeholders the client has no value for
+	Missing []string
+	// SMSSegments is the number of SMS the text is split into, set for the SMS channel
+	SMSSegments int
+}
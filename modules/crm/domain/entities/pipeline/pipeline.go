@@ -0,0 +1,16 @@
+package pipeline
+
+import "time"
+
+// Pipeline is an ordered list of stages deals go through.
+type Pipeline interface {
+	ID() uint
+	Name() string
+	Stages() []Stage
+	CreatedAt() time.Time
+
+	Stage(id uint) (Stage, bool)
+	// FirstStage is the stage new deals are put in.
+	FirstStage() (Stage, bool)
+	Update(name string, stages []Stage) (Pipeline, error)
+}
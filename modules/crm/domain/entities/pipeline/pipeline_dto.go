@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+type StageDTO struct {
+	ID   uint
+	Name string
+	Kind string
+}
+
+// SaveDTO is used both to create a pipeline and to update it. The stages are ordered as submitted.
+type SaveDTO struct {
+	Name   string `validate:"required"`
+	Stages []StageDTO
+}
+
+func (d *SaveDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		panic(composables.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	if errs := constants.Validate.Struct(d); errs != nil {
+		for _, err := range errs.(validator.ValidationErrors) {
+			translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("Pipelines.Single.%s.Label", err.Field()),
+			})
+			errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+				TemplateData: map[string]string{
+					"Field": translatedFieldName,
+				},
+			})
+		}
+	}
+	for _, s := range d.Stages {
+		if strings.TrimSpace(s.Name) == "" {
+			errorMessages["Stages"] = l.MustLocalize(&i18n.LocalizeConfig{MessageID: "Pipelines.Errors.StageName"})
+			return errorMessages, false
+		}
+	}
+	err := validateStages(d.stages())
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrNoStages), errors.Is(err, ErrNoOpenStage), errors.Is(err, ErrNoWonStage):
+		errorMessages["Stages"] = l.MustLocalize(&i18n.LocalizeConfig{MessageID: "Pipelines.Errors.Stages"})
+	default:
+		errorMessages["Stages"] = err.Error()
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *SaveDTO) stages() []Stage {
+	stages := make([]Stage, 0, len(d.Stages))
+	for i, s := range d.Stages {
+		stages = append(stages, Stage{
+			ID:       s.ID,
+			Name:     strings.TrimSpace(s.Name),
+			Position: i,
+			Kind:     StageKind(s.Kind),
+		})
+	}
+	return stages
+}
+
+func (d *SaveDTO) ToEntity() (Pipeline, error) {
+	return New(d.Name, d.stages())
+}
+
+func (d *SaveDTO) Apply(entity Pipeline) (Pipeline, error) {
+	return entity.Update(d.Name, d.stages())
+}
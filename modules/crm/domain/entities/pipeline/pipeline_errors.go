@@ -0,0 +1,12 @@
+package pipeline
+
+import "errors"
+
+var (
+	ErrInvalidStageKind = errors.New("invalid stage kind")
+	ErrNoStages         = errors.New("pipeline has no stages")
+	ErrNoOpenStage      = errors.New("pipeline has no open stage")
+	ErrNoWonStage       = errors.New("pipeline has no won stage")
+	ErrStageInUse       = errors.New("stage has deals")
+	ErrPipelineInUse    = errors.New("pipeline has deals")
+)
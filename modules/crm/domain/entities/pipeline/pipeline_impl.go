@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"sort"
+	"time"
+)
+
+func New(name string, stages []Stage) (Pipeline, error) {
+	return NewWithID(0, name, stages, time.Now())
+}
+
+func NewWithID(id uint, name string, stages []Stage, createdAt time.Time) (Pipeline, error) {
+	if err := validateStages(stages); err != nil {
+		return nil, err
+	}
+	return &pipeline{
+		id:        id,
+		name:      name,
+		stages:    orderStages(id, stages),
+		createdAt: createdAt,
+	}, nil
+}
+
+type pipeline struct {
+	id        uint
+	name      string
+	stages    []Stage
+	createdAt time.Time
+}
+
+// validateStages makes sure deals can be opened and won in the pipeline.
+func validateStages(stages []Stage) error {
+	if len(stages) == 0 {
+		return ErrNoStages
+	}
+	var hasOpen, hasWon bool
+	for _, s := range stages {
+		if !s.Kind.IsValid() {
+			return ErrInvalidStageKind
+		}
+		hasOpen = hasOpen || s.Kind == Open
+		hasWon = hasWon || s.Kind == Won
+	}
+	if !hasOpen {
+		return ErrNoOpenStage
+	}
+	if !hasWon {
+		return ErrNoWonStage
+	}
+	return nil
+}
+
+// orderStages sorts the stages by position and renumbers them from zero.
+func orderStages(pipelineID uint, stages []Stage) []Stage {
+	ordered := make([]Stage, len(stages))
+	copy(ordered, stages)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Position < ordered[j].Position
+	})
+	for i := range ordered {
+		ordered[i].Position = i
+		ordered[i].PipelineID = pipelineID
+	}
+	return ordered
+}
+
+func (p *pipeline) ID() uint {
+	return p.id
+}
+
+func (p *pipeline) Name() string {
+	return p.name
+}
+
+func (p *pipeline) Stages() []Stage {
+	return p.stages
+}
+
+func (p *pipeline) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *pipeline) Stage(id uint) (Stage, bool) {
+	for _, s := range p.stages {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Stage{}, false
+}
+
+func (p *pipeline) FirstStage() (Stage, bool) {
+	for _, s := range p.stages {
+		if s.Kind == Open {
+			return s, true
+		}
+	}
+	return Stage{}, false
+}
+
+func (p *pipeline) Update(name string, stages []Stage) (Pipeline, error) {
+	return NewWithID(p.id, name, stages, p.createdAt)
+}
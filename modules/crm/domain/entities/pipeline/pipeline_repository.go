@@ -0,0 +1,16 @@
+package pipeline
+
+import "context"
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]Pipeline, error)
+	GetByID(ctx context.Context, id uint) (Pipeline, error)
+	// GetByStageID returns the pipeline a stage belongs to.
+	GetByStageID(ctx context.Context, stageID uint) (Pipeline, error)
+	Create(ctx context.Context, data Pipeline) (Pipeline, error)
+	// Update saves the pipeline and its stages. Stages missing from the pipeline are deleted,
+	// which fails with ErrStageInUse while deals are in them.
+	Update(ctx context.Context, data Pipeline) (Pipeline, error)
+	// Delete fails with ErrPipelineInUse while the pipeline has deals.
+	Delete(ctx context.Context, id uint) error
+}
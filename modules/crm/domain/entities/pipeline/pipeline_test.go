@@ -0,0 +1,29 @@
+package pipeline_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+)
+
+func TestPipeline_Stages(t *testing.T) {
+	if _, err := pipeline.New("Empty", nil); !errors.Is(err, pipeline.ErrNoStages) {
+		t.Errorf("expected ErrNoStages, got %v", err)
+	}
+	_, err := pipeline.New("No won", []pipeline.Stage{{Name: "Lead", Kind: pipeline.Open}})
+	if !errors.Is(err, pipeline.ErrNoWonStage) {
+		t.Errorf("expected ErrNoWonStage, got %v", err)
+	}
+	p, err := pipeline.New("Sales", []pipeline.Stage{
+		{Name: "Won", Position: 5, Kind: pipeline.Won},
+		{Name: "Lead", Position: 1, Kind: pipeline.Open},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, ok := p.FirstStage()
+	if !ok || first.Name != "Lead" || first.Position != 0 {
+		t.Errorf("expected Lead at position 0 to be the first stage, got %+v", first)
+	}
+}
@@ -0,0 +1,41 @@
+package pipeline
+
+// StageKind tells whether deals in a stage are still in progress or closed.
+type StageKind string
+
+const (
+	Open StageKind = "open"
+	Won  StageKind = "won"
+	Lost StageKind = "lost"
+)
+
+var StageKinds = []StageKind{Open, Won, Lost}
+
+func NewStageKind(value string) (StageKind, error) {
+	k := StageKind(value)
+	if !k.IsValid() {
+		return "", ErrInvalidStageKind
+	}
+	return k, nil
+}
+
+func (k StageKind) IsValid() bool {
+	switch k {
+	case Open, Won, Lost:
+		return true
+	}
+	return false
+}
+
+func (k StageKind) IsClosed() bool {
+	return k == Won || k == Lost
+}
+
+// Stage is a step of a pipeline. Stages are shown in the order of their position.
+type Stage struct {
+	ID         uint
+	PipelineID uint
+	Name       string
+	Position   int
+	Kind       StageKind
+}
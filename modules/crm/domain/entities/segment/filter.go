@@ -0,0 +1,444 @@
+package segment
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
+)
+
+// A filter expression selects clients by their details, tags and custom fields:
+//
+//	tag = vip AND (gender = female OR date_of_birth < 1990-01-01) AND NOT field.city = "New York"
+//
+// Conditions compare a field with a value using =, !=, >, >=, <, <= or ~ (contains). Values with
+// spaces or operators are quoted. AND binds tighter than OR; NOT and parentheses work as usual.
+// Custom fields are written as field.<key>; "tag = x" matches clients tagged x.
+
+type Operator string
+
+const (
+	Eq          Operator = "="
+	NotEq       Operator = "!="
+	Greater     Operator = ">"
+	GreaterOrEq Operator = ">="
+	Less        Operator = "<"
+	LessOrEq    Operator = "<="
+	Contains    Operator = "~"
+)
+
+func (o Operator) isOrdering() bool {
+	return o == Greater || o == GreaterOrEq || o == Less || o == LessOrEq
+}
+
+// ValueType is how the values of a field are compared.
+type ValueType string
+
+const (
+	TextValue   ValueType = "text"
+	NumberValue ValueType = "number"
+	DateValue   ValueType = "date"
+	TagValue    ValueType = "tag"
+)
+
+// CustomFieldPrefix starts the name of a custom field in a condition.
+const CustomFieldPrefix = "field."
+
+const TagField = "tag"
+
+// Fields are the client fields conditions can refer to besides the custom fields.
+var Fields = map[string]ValueType{
+	"first_name":    TextValue,
+	"last_name":     TextValue,
+	"middle_name":   TextValue,
+	"phone":         TextValue,
+	"email":         TextValue,
+	"address":       TextValue,
+	"gender":        TextValue,
+	"date_of_birth": DateValue,
+	"hourly_rate":   NumberValue,
+	"created_at":    DateValue,
+	TagField:        TagValue,
+}
+
+// Expression is a parsed filter: an And, an Or, a Not or a Condition.
+type Expression interface {
+	String() string
+	isExpression()
+}
+
+type And struct {
+	Left, Right Expression
+}
+
+type Or struct {
+	Left, Right Expression
+}
+
+type Not struct {
+	Expression Expression
+}
+
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    string
+	// Type is known for the client fields on parsing and for the custom fields once the
+	// expression is bound to their definitions.
+	Type ValueType
+}
+
+func (And) isExpression()       {}
+func (Or) isExpression()        {}
+func (Not) isExpression()       {}
+func (Condition) isExpression() {}
+
+func (e And) String() string {
+	return fmt.Sprintf("(%s AND %s)", e.Left, e.Right)
+}
+
+func (e Or) String() string {
+	return fmt.Sprintf("(%s OR %s)", e.Left, e.Right)
+}
+
+func (e Not) String() string {
+	return fmt.Sprintf("NOT %s", e.Expression)
+}
+
+func (c Condition) String() string {
+	return fmt.Sprintf("%s %s %s", c.Field, c.Operator, strconv.Quote(c.Value))
+}
+
+// CustomFieldKey returns the key of the custom field the condition is on.
+func (c Condition) CustomFieldKey() (string, bool) {
+	if !strings.HasPrefix(c.Field, CustomFieldPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(c.Field, CustomFieldPrefix), true
+}
+
+// Conditions lists the conditions of the expression from left to right.
+func Conditions(expr Expression) []Condition {
+	switch e := expr.(type) {
+	case And:
+		return append(Conditions(e.Left), Conditions(e.Right)...)
+	case Or:
+		return append(Conditions(e.Left), Conditions(e.Right)...)
+	case Not:
+		return Conditions(e.Expression)
+	case Condition:
+		return []Condition{e}
+	}
+	return nil
+}
+
+// Parse reads a filter expression and checks the conditions on the client fields. Conditions on
+// custom fields are checked by Bind.
+func Parse(input string) (Expression, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, ErrEmptyExpression
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, syntaxError(t, "unexpected %q", t.text)
+	}
+	return expr, nil
+}
+
+// Bind checks the conditions on custom fields against the field definitions and sets their types.
+func Bind(expr Expression, fields []customfield.Field) (Expression, error) {
+	switch e := expr.(type) {
+	case And:
+		left, err := Bind(e.Left, fields)
+		if err != nil {
+			return nil, err
+		}
+		right, err := Bind(e.Right, fields)
+		if err != nil {
+			return nil, err
+		}
+		return And{Left: left, Right: right}, nil
+	case Or:
+		left, err := Bind(e.Left, fields)
+		if err != nil {
+			return nil, err
+		}
+		right, err := Bind(e.Right, fields)
+		if err != nil {
+			return nil, err
+		}
+		return Or{Left: left, Right: right}, nil
+	case Not:
+		inner, err := Bind(e.Expression, fields)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expression: inner}, nil
+	case Condition:
+		key, ok := e.CustomFieldKey()
+		if !ok {
+			return e, nil
+		}
+		for _, f := range fields {
+			if f.Key() != key {
+				continue
+			}
+			switch f.Type() {
+			case customfield.Number:
+				e.Type = NumberValue
+			case customfield.Date:
+				e.Type = DateValue
+			default:
+				e.Type = TextValue
+			}
+			if err := checkCondition(e); err != nil {
+				return nil, err
+			}
+			return e, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUnknownField, e.Field)
+	}
+	return expr, nil
+}
+
+// checkCondition makes sure the operator and the value suit the type of the field.
+func checkCondition(c Condition) error {
+	switch c.Type {
+	case TextValue:
+		if c.Operator.isOrdering() {
+			return fmt.Errorf("%w: %s %s", ErrInvalidOperator, c.Field, c.Operator)
+		}
+	case TagValue:
+		if c.Operator.isOrdering() {
+			return fmt.Errorf("%w: %s %s", ErrInvalidOperator, c.Field, c.Operator)
+		}
+	case NumberValue:
+		if c.Operator == Contains {
+			return fmt.Errorf("%w: %s %s", ErrInvalidOperator, c.Field, c.Operator)
+		}
+		if c.Value == "" && (c.Operator == Eq || c.Operator == NotEq) {
+			return nil
+		}
+		if _, err := strconv.ParseFloat(c.Value, 64); err != nil {
+			return fmt.Errorf("%w: %s is not a number", ErrInvalidValue, strconv.Quote(c.Value))
+		}
+	case DateValue:
+		if c.Operator == Contains {
+			return fmt.Errorf("%w: %s %s", ErrInvalidOperator, c.Field, c.Operator)
+		}
+		if c.Value == "" && (c.Operator == Eq || c.Operator == NotEq) {
+			return nil
+		}
+		if _, err := time.Parse(time.DateOnly, c.Value); err != nil {
+			return fmt.Errorf("%w: %s is not a date, use YYYY-MM-DD", ErrInvalidValue, strconv.Quote(c.Value))
+		}
+	}
+	return nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func syntaxError(t token, format string, args ...interface{}) error {
+	return fmt.Errorf("%w at position %d: %s", ErrSyntax, t.pos+1, fmt.Sprintf(format, args...))
+}
+
+func isOperatorChar(r rune) bool {
+	return r == '=' || r == '!' || r == '<' || r == '>' || r == '~'
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case r == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, syntaxError(token{pos: start}, "unterminated string")
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: start})
+		case isOperatorChar(r):
+			start := i
+			for i < len(runes) && isOperatorChar(runes[i]) {
+				i++
+			}
+			op := Operator(runes[start:i])
+			switch op {
+			case Eq, NotEq, Greater, GreaterOrEq, Less, LessOrEq, Contains:
+			default:
+				return nil, syntaxError(token{pos: start}, "unknown operator %q", string(op))
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: string(op), pos: start})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !isOperatorChar(runes[i]) &&
+				runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i]), pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		end := 0
+		if len(p.tokens) > 0 {
+			last := p.tokens[len(p.tokens)-1]
+			end = last.pos + len([]rune(last.text))
+		}
+		return token{kind: tokenEOF, pos: end}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenWord && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) or() (Expression, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Expression, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (Expression, error) {
+	if p.keyword("NOT") {
+		inner, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expression: inner}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, syntaxError(t, "expected )")
+		}
+		return inner, nil
+	}
+	return p.condition()
+}
+
+func (p *parser) condition() (Expression, error) {
+	field := p.next()
+	if field.kind != tokenWord {
+		return nil, syntaxError(field, "expected a field name")
+	}
+	name := strings.ToLower(field.text)
+	op := p.next()
+	if op.kind != tokenOperator {
+		return nil, syntaxError(op, "expected an operator after %s", field.text)
+	}
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, syntaxError(value, "expected a value after %s %s", field.text, op.text)
+	}
+	c := Condition{Field: name, Operator: Operator(op.text), Value: value.text}
+	if key, ok := c.CustomFieldKey(); ok {
+		if key == "" {
+			return nil, syntaxError(field, "expected a custom field key after %s", CustomFieldPrefix)
+		}
+		return c, nil
+	}
+	fieldType, ok := Fields[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownField, field.text)
+	}
+	c.Type = fieldType
+	if err := checkCondition(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
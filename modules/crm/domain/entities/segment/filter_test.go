@@ -0,0 +1,99 @@
+package segment_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`tag = vip`, `tag = "vip"`},
+		{`first_name ~ "John Paul"`, `first_name ~ "John Paul"`},
+		{`tag = vip and gender = female or hourly_rate >= 10`, `((tag = "vip" AND gender = "female") OR hourly_rate >= "10")`},
+		{`tag = vip AND (gender = female OR date_of_birth < 1990-01-01)`, `(tag = "vip" AND (gender = "female" OR date_of_birth < "1990-01-01"))`},
+		{`NOT tag = lead AND email != ""`, `(NOT tag = "lead" AND email != "")`},
+		{`field.city = "New York"`, `field.city = "New York"`},
+	}
+	for _, tt := range tests {
+		expr, err := segment.Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.input, err)
+			continue
+		}
+		if got := expr.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{``, segment.ErrEmptyExpression},
+		{`tag =`, segment.ErrSyntax},
+		{`tag = vip AND`, segment.ErrSyntax},
+		{`(tag = vip`, segment.ErrSyntax},
+		{`tag = vip)`, segment.ErrSyntax},
+		{`first_name == John`, segment.ErrSyntax},
+		{`name = "John`, segment.ErrSyntax},
+		{`name = John`, segment.ErrUnknownField},
+		{`first_name > John`, segment.ErrInvalidOperator},
+		{`hourly_rate ~ 10`, segment.ErrInvalidOperator},
+		{`hourly_rate > ten`, segment.ErrInvalidValue},
+		{`created_at > 01.02.2024`, segment.ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		if _, err := segment.Parse(tt.input); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestBind(t *testing.T) {
+	city, err := customfield.New("city", "City", customfield.Text, nil, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	since, err := customfield.New("customer_since", "Customer since", customfield.Date, nil, false, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := []customfield.Field{city, since}
+
+	expr, err := segment.Parse(`field.city = Tashkent AND field.customer_since < 2020-01-01`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound, err := segment.Bind(expr, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conditions := segment.Conditions(bound)
+	if len(conditions) != 2 || conditions[0].Type != segment.TextValue || conditions[1].Type != segment.DateValue {
+		t.Errorf("unexpected conditions %+v", conditions)
+	}
+
+	expr, err = segment.Parse(`field.city > Tashkent`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := segment.Bind(expr, fields); !errors.Is(err, segment.ErrInvalidOperator) {
+		t.Errorf("expected ErrInvalidOperator, got %v", err)
+	}
+
+	expr, err = segment.Parse(`field.country = UZ`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := segment.Bind(expr, fields); !errors.Is(err, segment.ErrUnknownField) {
+		t.Errorf("expected ErrUnknownField, got %v", err)
+	}
+}
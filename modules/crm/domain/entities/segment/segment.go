@@ -0,0 +1,18 @@
+package segment
+
+import "time"
+
+// Segment is a saved group of clients defined by a filter expression. The clients are selected
+// when the segment is used, so the segment follows the changes to the clients.
+type Segment interface {
+	ID() uint
+	Name() string
+	Description() string
+	Expression() string
+	// Filter is the parsed expression, not bound to the custom fields yet.
+	Filter() Expression
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	Update(name, description, expression string) (Segment, error)
+}
@@ -0,0 +1,55 @@
+package segment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+// SaveDTO is used both to create a segment and to update it. The expression is only parsed here;
+// its custom fields are checked by the service, which knows their definitions.
+type SaveDTO struct {
+	Name        string `validate:"required"`
+	Description string
+	Expression  string `validate:"required"`
+}
+
+func (d *SaveDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		panic(composables.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	if errs := constants.Validate.Struct(d); errs != nil {
+		for _, err := range errs.(validator.ValidationErrors) {
+			translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("Segments.Single.%s.Label", err.Field()),
+			})
+			errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+				TemplateData: map[string]string{
+					"Field": translatedFieldName,
+				},
+			})
+		}
+	}
+	if _, ok := errorMessages["Expression"]; !ok {
+		if _, err := Parse(d.Expression); err != nil {
+			errorMessages["Expression"] = err.Error()
+		}
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *SaveDTO) ToEntity() (Segment, error) {
+	return New(d.Name, d.Description, d.Expression)
+}
+
+func (d *SaveDTO) Apply(entity Segment) (Segment, error) {
+	return entity.Update(d.Name, d.Description, d.Expression)
+}
@@ -0,0 +1,12 @@
+package segment
+
+import "errors"
+
+var (
+	ErrEmptyExpression = errors.New("filter expression is empty")
+	ErrSyntax          = errors.New("syntax error")
+	ErrUnknownField    = errors.New("unknown field")
+	ErrInvalidOperator = errors.New("operator does not apply to the field")
+	ErrInvalidValue    = errors.New("invalid value")
+	ErrSegmentInUse    = errors.New("segment is used by campaigns")
+)
@@ -0,0 +1,73 @@
+package segment
+
+import (
+	"strings"
+	"time"
+)
+
+func New(name, description, expression string) (Segment, error) {
+	return NewWithID(0, name, description, expression, time.Now(), time.Now())
+}
+
+func NewWithID(
+	id uint,
+	name, description, expression string,
+	createdAt, updatedAt time.Time,
+) (Segment, error) {
+	expression = strings.TrimSpace(expression)
+	filter, err := Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &segment{
+		id:          id,
+		name:        name,
+		description: description,
+		expression:  expression,
+		filter:      filter,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}, nil
+}
+
+type segment struct {
+	id          uint
+	name        string
+	description string
+	expression  string
+	filter      Expression
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func (s *segment) ID() uint {
+	return s.id
+}
+
+func (s *segment) Name() string {
+	return s.name
+}
+
+func (s *segment) Description() string {
+	return s.description
+}
+
+func (s *segment) Expression() string {
+	return s.expression
+}
+
+func (s *segment) Filter() Expression {
+	return s.filter
+}
+
+func (s *segment) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *segment) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+func (s *segment) Update(name, description, expression string) (Segment, error) {
+	return NewWithID(s.id, name, description, expression, s.createdAt, time.Now())
+}
@@ -0,0 +1,13 @@
+package segment
+
+import "context"
+
+type Repository interface {
+	Count(ctx context.Context) (int64, error)
+	GetAll(ctx context.Context) ([]Segment, error)
+	GetByID(ctx context.Context, id uint) (Segment, error)
+	Create(ctx context.Context, data Segment) (Segment, error)
+	Update(ctx context.Context, data Segment) (Segment, error)
+	// Delete fails with ErrSegmentInUse while campaigns target the segment.
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,17 @@
+package team
+
+import "time"
+
+// Team is a group of operators chats are assigned to. New inbound chats are assigned in turn to
+// the members of the teams with automatic assignment.
+type Team interface {
+	ID() uint
+	Name() string
+	MemberIDs() []uint
+	HasMember(userID uint) bool
+	AutoAssign() bool
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	Update(name string, memberIDs []uint, autoAssign bool) Team
+}
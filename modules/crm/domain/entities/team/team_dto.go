@@ -0,0 +1,51 @@
+package team
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+// SaveDTO is used both to create a team and to update it.
+type SaveDTO struct {
+	Name       string `validate:"required"`
+	MemberIDs  []uint
+	AutoAssign bool
+}
+
+func (d *SaveDTO) Ok(ctx context.Context) (map[string]string, bool) {
+	l, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		panic(composables.ErrNoLocalizer)
+	}
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		translatedFieldName := l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("Teams.Single.%s.Label", err.Field()),
+		})
+		errorMessages[err.Field()] = l.MustLocalize(&i18n.LocalizeConfig{
+			MessageID: fmt.Sprintf("ValidationErrors.%s", err.Tag()),
+			TemplateData: map[string]string{
+				"Field": translatedFieldName,
+			},
+		})
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *SaveDTO) ToEntity() Team {
+	return New(d.Name, d.MemberIDs, d.AutoAssign)
+}
+
+func (d *SaveDTO) Apply(entity Team) Team {
+	return entity.Update(d.Name, d.MemberIDs, d.AutoAssign)
+}
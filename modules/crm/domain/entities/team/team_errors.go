@@ -0,0 +1,8 @@
+package team
+
+import "errors"
+
+var (
+	ErrNotMember  = errors.New("user is not a member of the team")
+	ErrNoAssignee = errors.New("no team assigns chats automatically")
+)
@@ -0,0 +1,71 @@
+package team
+
+import (
+	"slices"
+	"time"
+)
+
+func New(name string, memberIDs []uint, autoAssign bool) Team {
+	return NewWithID(0, name, memberIDs, autoAssign, time.Now(), time.Now())
+}
+
+func NewWithID(
+	id uint,
+	name string,
+	memberIDs []uint,
+	autoAssign bool,
+	createdAt, updatedAt time.Time,
+) Team {
+	members := append([]uint{}, memberIDs...)
+	slices.Sort(members)
+	return &team{
+		id:         id,
+		name:       name,
+		memberIDs:  slices.Compact(members),
+		autoAssign: autoAssign,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+type team struct {
+	id         uint
+	name       string
+	memberIDs  []uint
+	autoAssign bool
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+func (t *team) ID() uint {
+	return t.id
+}
+
+func (t *team) Name() string {
+	return t.name
+}
+
+func (t *team) MemberIDs() []uint {
+	return t.memberIDs
+}
+
+func (t *team) HasMember(userID uint) bool {
+	_, ok := slices.BinarySearch(t.memberIDs, userID)
+	return ok
+}
+
+func (t *team) AutoAssign() bool {
+	return t.autoAssign
+}
+
+func (t *team) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *team) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+func (t *team) Update(name string, memberIDs []uint, autoAssign bool) Team {
+	return NewWithID(t.id, name, memberIDs, autoAssign, t.createdAt, time.Now())
+}
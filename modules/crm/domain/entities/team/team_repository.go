@@ -0,0 +1,15 @@
+package team
+
+import "context"
+
+type Repository interface {
+	Count(ctx context.Context) (int64, error)
+	GetAll(ctx context.Context) ([]Team, error)
+	GetByID(ctx context.Context, id uint) (Team, error)
+	// NextAssignee returns the member of a team with automatic assignment who was assigned a
+	// chat the longest time ago, or ErrNoAssignee.
+	NextAssignee(ctx context.Context) (teamID, userID uint, err error)
+	Create(ctx context.Context, data Team) (Team, error)
+	Update(ctx context.Context, data Team) (Team, error)
+	Delete(ctx context.Context, id uint) error
+}
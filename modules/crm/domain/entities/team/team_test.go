@@ -0,0 +1,29 @@
+package team_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
+)
+
+func TestTeam_Members(t *testing.T) {
+	tm := team.New("Support", []uint{7, 3, 7, 5}, true)
+	if got := tm.MemberIDs(); !slices.Equal(got, []uint{3, 5, 7}) {
+		t.Errorf("expected members to be sorted without duplicates, got %v", got)
+	}
+	if !tm.HasMember(5) || tm.HasMember(4) {
+		t.Errorf("expected 5 to be a member and 4 not to be")
+	}
+	empty := team.New("Empty", nil, false)
+	if empty.MemberIDs() == nil {
+		t.Errorf("expected an empty member list, got nil")
+	}
+	updated := tm.Update("Sales", []uint{4}, false)
+	if updated.Name() != "Sales" || updated.AutoAssign() || !updated.HasMember(4) || updated.HasMember(5) {
+		t.Errorf("expected the update to replace the name, the members and auto-assign")
+	}
+	if !tm.HasMember(5) {
+		t.Errorf("expected Update to leave the original team unchanged")
+	}
+}
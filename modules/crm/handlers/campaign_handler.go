@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// CampaignHandler applies the delivery reports of the channels to campaign recipients and
+// registers the opt-out replies of clients.
+type CampaignHandler struct {
+	pool            *pgxpool.Pool
+	campaignService *services.CampaignService
+}
+
+func RegisterCampaignHandlers(app application.Application, campaignService *services.CampaignService) *CampaignHandler {
+	handler := &CampaignHandler{
+		pool:            app.DB(),
+		campaignService: campaignService,
+	}
+	app.EventPublisher().Subscribe(handler.onDeliveryStatus)
+	app.EventPublisher().Subscribe(handler.onMessageReceived)
+	return handler
+}
+
+func (h *CampaignHandler) onDeliveryStatus(event *cpassproviders.DeliveryStatusEvent) {
+	ctx := composables.WithPool(context.Background(), h.pool)
+	if err := h.campaignService.UpdateDeliveryStatus(ctx, event); err != nil {
+		log.Printf("failed to update campaign delivery status: %v", err)
+	}
+}
+
+func (h *CampaignHandler) onMessageReceived(event *cpassproviders.ReceivedMessageEvent) {
+	ctx := composables.WithPool(context.Background(), h.pool)
+	if err := h.campaignService.RegisterReply(ctx, event); err != nil {
+		log.Printf("failed to register campaign reply: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const campaignSendInterval = 10 * time.Second
+
+// CampaignJob sends the running campaigns a batch at a time. Every campaign is processed in its
+// own transaction, so a failing campaign does not hold back the others and is retried on the
+// next tick.
+type CampaignJob struct {
+	pool            *pgxpool.Pool
+	campaignService *services.CampaignService
+}
+
+func RegisterCampaignJob(app application.Application, campaignService *services.CampaignService) *CampaignJob {
+	job := &CampaignJob{
+		pool:            app.DB(),
+		campaignService: campaignService,
+	}
+	app.RegisterJobs(job)
+	return job
+}
+
+func (j *CampaignJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(campaignSendInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.processRunning(ctx, now)
+		}
+	}
+}
+
+func (j *CampaignJob) processRunning(ctx context.Context, now time.Time) {
+	ctx = composables.WithPool(ctx, j.pool)
+	campaigns, err := j.campaignService.GetRunning(ctx)
+	if err != nil {
+		log.Printf("Error loading running campaigns: %v", err)
+		return
+	}
+	for _, c := range campaigns {
+		if err := j.campaignService.Process(ctx, c.ID(), now, campaignSendInterval); err != nil {
+			log.Printf("Error sending campaign %d: %v", c.ID(), err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const chatSLACheckInterval = time.Minute
+
+// ChatSLAJob flags the chats that missed their first response or resolution SLA.
+type ChatSLAJob struct {
+	pool        *pgxpool.Pool
+	chatService *services.ChatService
+}
+
+func RegisterChatSLAJob(app application.Application, chatService *services.ChatService) *ChatSLAJob {
+	job := &ChatSLAJob{
+		pool:        app.DB(),
+		chatService: chatService,
+	}
+	app.RegisterJobs(job)
+	return job
+}
+
+func (j *ChatSLAJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(chatSLACheckInterval)
+	defer ticker.Stop()
+	ctx = composables.WithPool(ctx, j.pool)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := j.chatService.CheckSLAs(ctx, now); err != nil {
+				log.Printf("Error checking chat SLAs: %v", err)
+			}
+		}
+	}
+}
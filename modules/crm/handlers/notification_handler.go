@@ -2,38 +2,181 @@ package handlers
 
 import (
 	"context"
-	"log"
-
-	"github.com/jackc/pgx/v5/pgxpool"
+	"errors"
+	"fmt"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
-	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/telegram"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+const (
+	MessageReceivedNotification       = "crm.message_received"
+	ChatAssignedNotification          = "crm.chat_assigned"
+	FirstResponseBreachedNotification = "crm.first_response_sla_breached"
+	ResolutionBreachedNotification    = "crm.resolution_sla_breached"
 )
 
+var slaBreachedNotifications = map[chat.SLAKind]string{
+	chat.FirstResponseSLA: FirstResponseBreachedNotification,
+	chat.ResolutionSLA:    ResolutionBreachedNotification,
+}
+
 type NotificationHandler struct {
-	pool  *pgxpool.Pool
-	tgBot *telegram.Bot
+	notificationService *coreservices.NotificationService
+	clientRepo          client.Repository
+	teamRepo            team.Repository
 }
 
-func RegisterNotificationHandler(app application.Application, botToken string) *NotificationHandler {
-	bot, err := telegram.NewBot(botToken)
-	if err != nil {
-		log.Fatalf("Error creating telegram bot: %v", err)
-	}
+func RegisterNotificationHandler(
+	app application.Application,
+	clientRepo client.Repository,
+	teamRepo team.Repository,
+) *NotificationHandler {
+	notificationService := app.Service(coreservices.NotificationService{}).(*coreservices.NotificationService)
+	notificationService.RegisterTypes(
+		notification.Type{
+			Name:       MessageReceivedNotification,
+			Defaults:   []notification.Channel{notification.InApp},
+			Permission: permissions.ClientRead,
+		},
+		notification.Type{
+			Name:       ChatAssignedNotification,
+			Defaults:   []notification.Channel{notification.InApp},
+			Permission: permissions.ClientRead,
+		},
+		notification.Type{
+			Name:       FirstResponseBreachedNotification,
+			Defaults:   []notification.Channel{notification.InApp},
+			Permission: permissions.ClientRead,
+		},
+		notification.Type{
+			Name:       ResolutionBreachedNotification,
+			Defaults:   []notification.Channel{notification.InApp},
+			Permission: permissions.ClientRead,
+		},
+	)
 	handler := &NotificationHandler{
-		pool:  app.DB(),
-		tgBot: bot,
+		notificationService: notificationService,
+		clientRepo:          clientRepo,
+		teamRepo:            teamRepo,
 	}
-	app.EventPublisher().Subscribe(handler.onNewMessage)
+	eventbus.Subscribe(app.Outbox(), events.ChatMessageAddedTopic, "crm.message_notification", handler.onNewMessage)
+	eventbus.Subscribe(app.Outbox(), events.ChatAssignedTopic, "crm.assigned_notification", handler.onAssigned)
+	eventbus.Subscribe(app.Outbox(), events.ChatSLABreachedTopic, "crm.sla_breached_notification", handler.onSLABreached)
 	return handler
 }
 
-func (h *NotificationHandler) onNewMessage(event *chat.MessagedAddedEvent) {
-	ctx := context.Background()
-	chatID := int64(-1001979082001)
-	if err := h.tgBot.SendMessage(ctx, chatID, "Получено новое сообщение"); err != nil {
-		log.Printf("Error sending telegram message: %v", err)
+// recipients returns who is responsible for a chat: its assignee, the members of its team
+// when nobody is assigned, or nil, meaning everybody allowed to read clients.
+func (h *NotificationHandler) recipients(ctx context.Context, assigneeID, teamID uint) ([]uint, error) {
+	if assigneeID != 0 {
+		return []uint{assigneeID}, nil
+	}
+	if teamID == 0 {
+		return nil, nil
+	}
+	t, err := h.teamRepo.GetByID(ctx, teamID)
+	if errors.Is(err, persistence.ErrTeamNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading team %d: %w", teamID, err)
+	}
+	return t.MemberIDs(), nil
+}
+
+func (h *NotificationHandler) clientName(ctx context.Context, clientID uint) (string, error) {
+	c, err := h.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		return "", fmt.Errorf("error loading client %d: %w", clientID, err)
+	}
+	return fmt.Sprintf("%s %s", c.FirstName(), c.LastName()), nil
+}
+
+func chatLink(chatID uint) string {
+	return fmt.Sprintf("/crm/chats?chat_id=%d", chatID)
+}
+
+// onNewMessage notifies the staff responsible for the chat about messages from clients.
+// Errors are returned so the outbox retries the notification.
+func (h *NotificationHandler) onNewMessage(ctx context.Context, payload events.ChatMessageAdded) error {
+	if !payload.FromClient {
+		return nil
+	}
+	name, err := h.clientName(ctx, payload.ClientID)
+	if err != nil {
+		return err
+	}
+	userIDs, err := h.recipients(ctx, payload.AssigneeID, payload.TeamID)
+	if err != nil {
+		return err
 	}
+	return h.notificationService.Send(ctx, notification.SendDTO{
+		Type:    MessageReceivedNotification,
+		UserIDs: userIDs,
+		Data: map[string]interface{}{
+			"Client":  name,
+			"Channel": payload.Channel,
+			"Message": payload.Message,
+		},
+		Link: chatLink(payload.ChatID),
+	})
+}
 
+// onAssigned notifies the new assignee of a chat, or its team when the chat is only given to a team.
+func (h *NotificationHandler) onAssigned(ctx context.Context, payload events.ChatAssigned) error {
+	if payload.AssigneeID == 0 && payload.TeamID == 0 {
+		return nil
+	}
+	name, err := h.clientName(ctx, payload.ClientID)
+	if err != nil {
+		return err
+	}
+	userIDs, err := h.recipients(ctx, payload.AssigneeID, payload.TeamID)
+	if err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return h.notificationService.Send(ctx, notification.SendDTO{
+		Type:    ChatAssignedNotification,
+		UserIDs: userIDs,
+		Data: map[string]interface{}{
+			"Client": name,
+		},
+		Link: chatLink(payload.ChatID),
+	})
+}
+
+// onSLABreached warns the staff responsible for a chat that it missed an SLA.
+func (h *NotificationHandler) onSLABreached(ctx context.Context, payload events.ChatSLABreached) error {
+	notificationType, ok := slaBreachedNotifications[chat.SLAKind(payload.Kind)]
+	if !ok {
+		return nil
+	}
+	name, err := h.clientName(ctx, payload.ClientID)
+	if err != nil {
+		return err
+	}
+	userIDs, err := h.recipients(ctx, payload.AssigneeID, payload.TeamID)
+	if err != nil {
+		return err
+	}
+	return h.notificationService.Send(ctx, notification.SendDTO{
+		Type:    notificationType,
+		UserIDs: userIDs,
+		Data: map[string]interface{}{
+			"Client": name,
+		},
+		Link: chatLink(payload.ChatID),
+	})
 }
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+// orderCompleted is the status the warehouse module gives to completed orders.
+const orderCompleted = "complete"
+
+// TimelineHandler records the payments and the orders of the clients on their timelines.
+// Payments are matched through the counterparties the deals of the client were invoiced to.
+type TimelineHandler struct {
+	activityService *services.ActivityService
+}
+
+func RegisterTimelineHandler(app application.Application, activityService *services.ActivityService) *TimelineHandler {
+	handler := &TimelineHandler{
+		activityService: activityService,
+	}
+	eventbus.Subscribe(app.Outbox(), events.DealInvoicedTopic, "crm.timeline_counterparty", handler.onDealInvoiced)
+	eventbus.Subscribe(app.Outbox(), events.PaymentSavedTopic, "crm.timeline_payment", handler.onPaymentSaved)
+	eventbus.Subscribe(app.Outbox(), events.PaymentRemovedTopic, "crm.timeline_payment_removed", handler.onPaymentRemoved)
+	eventbus.Subscribe(app.Outbox(), events.OrderCreatedTopic, "crm.timeline_order", handler.onOrderCreated)
+	eventbus.Subscribe(app.Outbox(), events.OrderCompletedTopic, "crm.timeline_order_completed", handler.onOrderCompleted)
+	return handler
+}
+
+func (h *TimelineHandler) onDealInvoiced(ctx context.Context, payload events.DealInvoiced) error {
+	if payload.ClientID == 0 || payload.CounterpartyID == 0 {
+		return nil
+	}
+	return h.activityService.LinkCounterparty(ctx, payload.ClientID, payload.CounterpartyID)
+}
+
+func (h *TimelineHandler) onPaymentSaved(ctx context.Context, payload events.PaymentSaved) error {
+	return h.activityService.RecordPayment(
+		ctx,
+		payload.PaymentID,
+		payload.CounterpartyID,
+		payload.Amount,
+		payload.Currency,
+		payload.Comment,
+		payload.TransactionDate,
+	)
+}
+
+func (h *TimelineHandler) onPaymentRemoved(ctx context.Context, payload events.PaymentRemoved) error {
+	return h.activityService.RemovePayment(ctx, payload.PaymentID)
+}
+
+// onOrderCreated only records the orders opened for the deals of a client, the other orders
+// have nothing to do with the CRM.
+func (h *TimelineHandler) onOrderCreated(ctx context.Context, payload events.OrderCreated) error {
+	if payload.ClientID == 0 {
+		return nil
+	}
+	return h.activityService.RecordOrder(ctx, payload.ClientID, payload.OrderID, payload.Status, time.Now())
+}
+
+func (h *TimelineHandler) onOrderCompleted(ctx context.Context, payload events.OrderCompleted) error {
+	return h.activityService.RecordOrder(ctx, 0, payload.OrderID, orderCompleted, time.Now())
+}
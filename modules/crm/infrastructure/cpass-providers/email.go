@@ -0,0 +1,216 @@
+package cpassproviders
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-imap"
+	imapclient "github.com/emersion/go-imap/client"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// EmailConfig holds the mailbox the CRM sends from over SMTP and reads replies from over IMAP
+type EmailConfig struct {
+	// host:port of the SMTP server
+	SMTPAddress string
+	// host:port of the IMAP server, connected to over TLS
+	IMAPAddress string
+	Username    string
+	Password    string
+	// Address messages are sent from, the username when empty
+	From    string
+	Subject string
+}
+
+// NewEmailProvider creates a provider that sends emails over SMTP and polls the inbox over IMAP
+func NewEmailProvider(config EmailConfig) *EmailProvider {
+	if config.From == "" {
+		config.From = config.Username
+	}
+	return &EmailProvider{config: config}
+}
+
+// EmailProvider sends and receives emails. A client is addressed by email address.
+type EmailProvider struct {
+	config EmailConfig
+}
+
+func (s *EmailProvider) Channel() chat.Channel {
+	return chat.Email
+}
+
+func (s *EmailProvider) SendMessage(ctx context.Context, data SendMessageDTO) (string, error) {
+	host, _, err := net.SplitHostPort(s.config.SMTPAddress)
+	if err != nil {
+		return "", fmt.Errorf("invalid smtp address: %w", err)
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", data.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", s.config.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(data.Message, "\n", "\r\n"))
+	auth := smtp.PlainAuth("", s.config.Username, s.config.Password, host)
+	if err := smtp.SendMail(s.config.SMTPAddress, auth, s.config.From, []string{data.To}, msg.Bytes()); err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+	return "", nil
+}
+
+// Listen polls the inbox for unseen emails until ctx is cancelled and publishes each of them
+// as a ReceivedMessageEvent. Emails are marked as seen once they are published.
+func (s *EmailProvider) Listen(ctx context.Context, eventBus eventbus.EventBus, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.poll(eventBus); err != nil {
+			log.Printf("Error polling email inbox: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *EmailProvider) poll(eventBus eventbus.EventBus) error {
+	c, err := imapclient.DialTLS(s.config.IMAPAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to imap server: %w", err)
+	}
+	defer func() {
+		_ = c.Logout()
+	}()
+	if err := c.Login(s.config.Username, s.config.Password); err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+	if _, err := c.Select("INBOX", false); err != nil {
+		return fmt.Errorf("failed to select inbox: %w", err)
+	}
+	criteria := imap.NewSearchCriteria()
+	criteria.WithoutFlags = []string{imap.SeenFlag}
+	uids, err := c.UidSearch(criteria)
+	if err != nil {
+		return fmt.Errorf("failed to search inbox: %w", err)
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+	section := &imap.BodySectionName{Peek: true}
+	messages := make(chan *imap.Message, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.UidFetch(seqset, []imap.FetchItem{imap.FetchUid, section.FetchItem()}, messages)
+	}()
+	seen := new(imap.SeqSet)
+	for msg := range messages {
+		body := msg.GetBody(section)
+		if body == nil {
+			continue
+		}
+		event, err := parseEmail(body)
+		if err != nil {
+			log.Printf("Error parsing email %d: %v", msg.Uid, err)
+			continue
+		}
+		eventBus.Publish(event)
+		seen.AddNum(msg.Uid)
+	}
+	if err := <-done; err != nil {
+		return fmt.Errorf("failed to fetch emails: %w", err)
+	}
+	if seen.Empty() {
+		return nil
+	}
+	flags := []interface{}{imap.SeenFlag}
+	return c.UidStore(seen, imap.FormatFlagsOp(imap.AddFlags, true), flags, nil)
+}
+
+func parseEmail(r io.Reader) (*ReceivedMessageEvent, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	text, err := plainText(msg.Header, msg.Body)
+	if err != nil {
+		return nil, err
+	}
+	firstName, lastName, _ := strings.Cut(from.Name, " ")
+	return &ReceivedMessageEvent{
+		Channel:   chat.Email,
+		From:      strings.ToLower(from.Address),
+		To:        msg.Header.Get("To"),
+		Body:      strings.TrimSpace(text),
+		FirstName: firstName,
+		LastName:  lastName,
+	}, nil
+}
+
+type mimeHeader interface {
+	Get(key string) string
+}
+
+// plainText returns the text/plain part of a message body, looking into multipart bodies.
+func plainText(header mimeHeader, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return "", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			text, err := plainText(part.Header, part)
+			if err != nil {
+				return "", err
+			}
+			if text != "" {
+				return text, nil
+			}
+		}
+	}
+	if mediaType != "text/plain" {
+		return "", nil
+	}
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 )
 
@@ -13,15 +14,56 @@ type SendMessageDTO struct {
 	To       string
 	From     string
 	MediaURL string
+	// Template is set when the message is a template approved by the channel, Message is its rendered text
+	Template *Template
 }
 
+// Template is a message template registered with the channel, such as an approved WhatsApp template.
+type Template struct {
+	Name     string
+	Language string
+	Params   []string
+}
+
+// ReceivedMessageEvent is published for every message a client sends on any channel.
+// From is the client's address on the channel; Phone is set when the client shared it.
 type ReceivedMessageEvent struct {
-	From string `json:"From"`
-	To   string `json:"To"`
-	Body string `json:"Body"`
+	Channel   chat.Channel `json:"Channel"`
+	From      string       `json:"From"`
+	To        string       `json:"To"`
+	Body      string       `json:"Body"`
+	FirstName string       `json:"FirstName"`
+	LastName  string       `json:"LastName"`
+	Phone     string       `json:"Phone"`
+}
+
+// DeliveryStatus is the state of a sent message reported by the channel.
+type DeliveryStatus string
+
+const (
+	DeliverySent      DeliveryStatus = "sent"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// DeliveryStatusEvent is published when a channel reports the delivery of a message.
+// MessageID is the ID SendMessage returned for it.
+type DeliveryStatusEvent struct {
+	Channel   chat.Channel   `json:"Channel"`
+	MessageID string         `json:"MessageID"`
+	Status    DeliveryStatus `json:"Status"`
+	Error     string         `json:"Error"`
 }
 
 type Provider interface {
-	SendMessage(ctx context.Context, dto SendMessageDTO) error
+	Channel() chat.Channel
+	// SendMessage returns the ID the channel assigned to the message, empty when the channel
+	// does not report deliveries.
+	SendMessage(ctx context.Context, dto SendMessageDTO) (string, error)
+}
+
+// WebhookProvider receives the messages of its channel through webhook calls.
+type WebhookProvider interface {
+	Provider
 	WebhookHandler(evb eventbus.EventBus) http.HandlerFunc
 }
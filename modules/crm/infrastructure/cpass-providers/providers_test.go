@@ -0,0 +1,209 @@
+package cpassproviders
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+func receivedEvents(bus eventbus.EventBus) *[]*ReceivedMessageEvent {
+	events := &[]*ReceivedMessageEvent{}
+	bus.Subscribe(func(e *ReceivedMessageEvent) {
+		*events = append(*events, e)
+	})
+	return events
+}
+
+func TestParseEmail(t *testing.T) {
+	raw := strings.Join([]string{
+		"From: John Doe <John@Example.com>",
+		"To: support@example.com",
+		"Subject: Order",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/alternative; boundary="b1"`,
+		"",
+		"--b1",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"Where is my =",
+		"order?",
+		"--b1",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<p>Where is my order?</p>",
+		"--b1--",
+		"",
+	}, "\r\n")
+	event, err := parseEmail(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Channel != chat.Email || event.From != "john@example.com" {
+		t.Errorf("unexpected sender: %s %s", event.Channel, event.From)
+	}
+	if event.FirstName != "John" || event.LastName != "Doe" {
+		t.Errorf("unexpected name: %s %s", event.FirstName, event.LastName)
+	}
+	if event.Body != "Where is my order?" {
+		t.Errorf("unexpected body: %q", event.Body)
+	}
+}
+
+func TestWhatsAppProvider_WebhookHandler(t *testing.T) {
+	provider := NewWhatsAppProvider(WhatsAppConfig{AppSecret: "secret", VerifyToken: "verify"})
+	bus := eventbus.NewEventPublisher()
+	events := receivedEvents(bus)
+	handler := provider.WebhookHandler(bus)
+
+	req := httptest.NewRequest(http.MethodGet, "/whatsapp?hub.mode=subscribe&hub.verify_token=verify&hub.challenge=42", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.String() != "42" {
+		t.Errorf("expected the challenge to be echoed, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	body := `{"entry":[{"changes":[{"value":{
+		"contacts":[{"wa_id":"998901234567","profile":{"name":"John Doe"}}],
+		"messages":[{"from":"998901234567","type":"text","text":{"body":"hello"}}]}}]}]}`
+	req = httptest.NewRequest(http.MethodPost, "/whatsapp", strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature-256", "sha256=invalid")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusUnauthorized || len(*events) != 0 {
+		t.Fatalf("expected an invalid signature to be rejected, got %d", rec.Code)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	req = httptest.NewRequest(http.MethodPost, "/whatsapp", strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, rec.Code)
+	}
+	if len(*events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(*events))
+	}
+	event := (*events)[0]
+	if event.Channel != chat.WhatsApp || event.From != "+998901234567" || event.Body != "hello" || event.FirstName != "John" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestTelegramProvider_WebhookHandler(t *testing.T) {
+	provider := NewTelegramProvider(nil, "secret")
+	bus := eventbus.NewEventPublisher()
+	events := receivedEvents(bus)
+	handler := provider.WebhookHandler(bus)
+	body := `{"update_id":1,"message":{"message_id":1,"date":0,
+		"chat":{"id":100,"type":"private"},
+		"from":{"id":100,"is_bot":false,"first_name":"John"},
+		"contact":{"phone_number":"998901234567","first_name":"John","user_id":100}}}`
+
+	req := httptest.NewRequest(http.MethodPost, "/telegram", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected a missing secret to be rejected, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/telegram", strings.NewReader(body))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "secret")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK || len(*events) != 1 {
+		t.Fatalf("expected 1 event, got %d (%d)", len(*events), rec.Code)
+	}
+	event := (*events)[0]
+	if event.Channel != chat.Telegram || event.From != "100" || event.Phone != "998901234567" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestWhatsAppPayload(t *testing.T) {
+	text, err := json.Marshal(whatsAppPayload(SendMessageDTO{To: "+998901234567", Message: "hi"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"messaging_product":"whatsapp","to":"998901234567","type":"text","text":{"body":"hi"}}`; string(text) != want {
+		t.Errorf("unexpected text payload: %s", text)
+	}
+	template, err := json.Marshal(whatsAppPayload(SendMessageDTO{
+		To:      "+998901234567",
+		Message: "Hello, John",
+		Template: &Template{
+			Name:     "greeting",
+			Language: "en",
+			Params:   []string{"John"},
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"messaging_product":"whatsapp","to":"998901234567","type":"template","template":{"name":"greeting",` +
+		`"language":{"code":"en"},"components":[{"type":"body","parameters":[{"type":"text","text":"John"}]}]}}`
+	if string(template) != want {
+		t.Errorf("unexpected template payload: %s", template)
+	}
+}
+
+func TestWhatsAppProvider_DeliveryStatuses(t *testing.T) {
+	provider := NewWhatsAppProvider(WhatsAppConfig{AppSecret: "secret"})
+	bus := eventbus.NewEventPublisher()
+	var events []*DeliveryStatusEvent
+	bus.Subscribe(func(e *DeliveryStatusEvent) {
+		events = append(events, e)
+	})
+	handler := provider.WebhookHandler(bus)
+
+	body := `{"entry":[{"changes":[{"value":{"statuses":[
+		{"id":"wamid.1","status":"delivered"},
+		{"id":"wamid.2","status":"failed","errors":[{"title":"Message undeliverable"}]},
+		{"id":"wamid.3","status":"deleted"}]}}]}]}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	req := httptest.NewRequest(http.MethodPost, "/whatsapp", strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, rec.Code)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].MessageID != "wamid.1" || events[0].Status != DeliveryDelivered {
+		t.Errorf("unexpected event: %+v", events[0])
+	}
+	if events[1].Status != DeliveryFailed || events[1].Error != "Message undeliverable" {
+		t.Errorf("unexpected event: %+v", events[1])
+	}
+}
+
+func TestTwilioDeliveryStatus(t *testing.T) {
+	tests := map[string]DeliveryStatus{
+		"queued":      DeliverySent,
+		"sent":        DeliverySent,
+		"delivered":   DeliveryDelivered,
+		"undelivered": DeliveryFailed,
+		"failed":      DeliveryFailed,
+	}
+	for status, want := range tests {
+		if got, ok := twilioDeliveryStatus(status); !ok || got != want {
+			t.Errorf("twilioDeliveryStatus(%q) = %q, want %q", status, got, want)
+		}
+	}
+	if _, ok := twilioDeliveryStatus("received"); ok {
+		t.Error("expected inbound messages not to be delivery reports")
+	}
+}
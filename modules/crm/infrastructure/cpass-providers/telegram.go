@@ -0,0 +1,91 @@
+package cpassproviders
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/telegram"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// NewTelegramProvider creates a provider for private chats with a Telegram bot. The secret must
+// match the secret token the bot's webhook was registered with.
+func NewTelegramProvider(bot *telegram.Bot, secret string) WebhookProvider {
+	return &TelegramProvider{
+		bot:    bot,
+		secret: secret,
+	}
+}
+
+// TelegramProvider sends and receives messages of clients chatting with a Telegram bot.
+// A client is addressed by the id of their private chat with the bot.
+type TelegramProvider struct {
+	bot    *telegram.Bot
+	secret string
+}
+
+func (s *TelegramProvider) Channel() chat.Channel {
+	return chat.Telegram
+}
+
+func (s *TelegramProvider) SendMessage(ctx context.Context, data SendMessageDTO) (string, error) {
+	chatID, err := strconv.ParseInt(data.To, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid telegram chat id %q: %w", data.To, err)
+	}
+	return "", s.bot.SendMessage(ctx, chatID, data.Message)
+}
+
+func (s *TelegramProvider) WebhookHandler(eventBus eventbus.EventBus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.secret)) != 1 {
+			log.Printf("Invalid telegram secret token")
+			http.Error(w, "invalid secret token", http.StatusUnauthorized)
+			return
+		}
+		var update gotgbot.Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			log.Printf("Error decoding telegram update: %v", err)
+			http.Error(w, "failed to decode update", http.StatusBadRequest)
+			return
+		}
+		// Updates other than messages in private chats are acknowledged so Telegram stops resending them.
+		msg := update.Message
+		if msg == nil || msg.Chat.Type != "private" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		event := &ReceivedMessageEvent{
+			Channel: chat.Telegram,
+			From:    strconv.FormatInt(msg.Chat.Id, 10),
+			Body:    msg.Text,
+		}
+		if event.Body == "" {
+			event.Body = msg.Caption
+		}
+		if msg.From != nil {
+			event.FirstName = msg.From.FirstName
+			event.LastName = msg.From.LastName
+		}
+		// A client shares their own phone number to be matched with the client registered with it.
+		if msg.Contact != nil && msg.From != nil && msg.Contact.UserId == msg.From.Id {
+			event.Phone = msg.Contact.PhoneNumber
+			if event.Body == "" {
+				event.Body = msg.Contact.PhoneNumber
+			}
+		}
+		if event.Body != "" {
+			eventBus.Publish(event)
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
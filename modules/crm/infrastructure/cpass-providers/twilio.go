@@ -3,11 +3,13 @@ package cpassproviders
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"strings"
 
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 	"github.com/twilio/twilio-go"
 	"github.com/twilio/twilio-go/client"
@@ -75,7 +77,7 @@ type InboundTwilioMessageDTO struct {
 }
 
 // NewTwilioProvider creates a new instance of TwilioProvider
-func NewTwilioProvider(params twilio.ClientParams, webhookURL string) Provider {
+func NewTwilioProvider(params twilio.ClientParams, webhookURL string) WebhookProvider {
 	restClient := twilio.NewRestClientWithParams(params)
 	return &TwilioProvider{
 		webhookURL: webhookURL,
@@ -91,19 +93,43 @@ type TwilioProvider struct {
 	validator  client.RequestValidator
 }
 
+func (s *TwilioProvider) Channel() chat.Channel {
+	return chat.SMS
+}
+
 // SendMessage sends a message using Twilio
-func (s *TwilioProvider) SendMessage(ctx context.Context, data SendMessageDTO) error {
+func (s *TwilioProvider) SendMessage(ctx context.Context, data SendMessageDTO) (string, error) {
 	params := &twilioApi.CreateMessageParams{}
 	params.SetBody(data.Message)
 	params.SetFrom(data.From)
 	params.SetTo(data.To)
+	params.SetStatusCallback(s.webhookURL)
 
 	if data.MediaURL != "" {
 		params.SetMediaUrl([]string{data.MediaURL})
 	}
 
-	_, err := s.client.Api.CreateMessage(params)
-	return err
+	resp, err := s.client.Api.CreateMessage(params)
+	if err != nil {
+		return "", err
+	}
+	if resp.Sid == nil {
+		return "", nil
+	}
+	return *resp.Sid, nil
+}
+
+// twilioDeliveryStatus maps the MessageStatus of a status callback, ok is false for inbound messages.
+func twilioDeliveryStatus(status string) (DeliveryStatus, bool) {
+	switch status {
+	case "queued", "accepted", "sending", "sent":
+		return DeliverySent, true
+	case "delivered", "read":
+		return DeliveryDelivered, true
+	case "failed", "undelivered":
+		return DeliveryFailed, true
+	}
+	return "", false
 }
 
 func (s *TwilioProvider) WebhookHandler(eventBus eventbus.EventBus) http.HandlerFunc {
@@ -112,11 +138,13 @@ func (s *TwilioProvider) WebhookHandler(eventBus eventbus.EventBus) http.Handler
 		if signature == "" {
 			log.Printf("Missing X-Twilio-Signature header")
 			http.Error(w, "missing signature header", http.StatusBadRequest)
+			return
 		}
 		// Parse form params if not already parsed
 		if err := r.ParseForm(); err != nil {
 			log.Printf("Error parsing form: %v", err)
 			http.Error(w, "failed to parse form", http.StatusBadRequest)
+			return
 		}
 		// Convert form values to params map
 		params := make(map[string]string)
@@ -134,12 +162,29 @@ func (s *TwilioProvider) WebhookHandler(eventBus eventbus.EventBus) http.Handler
 		if !isValid {
 			log.Printf("Invalid signature")
 			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		// Status callbacks of sent messages share the webhook with inbound messages.
+		if status, ok := twilioDeliveryStatus(params["MessageStatus"]); ok {
+			event := &DeliveryStatusEvent{
+				Channel:   chat.SMS,
+				MessageID: params["MessageSid"],
+				Status:    status,
+			}
+			if status == DeliveryFailed && params["ErrorCode"] != "" {
+				event.Error = fmt.Sprintf("twilio error %s", params["ErrorCode"])
+			}
+			eventBus.Publish(event)
+			w.WriteHeader(http.StatusOK)
+			return
 		}
 
 		eventBus.Publish(&ReceivedMessageEvent{
-			From: params["From"],
-			To:   params["To"],
-			Body: params["Body"],
+			Channel: chat.SMS,
+			From:    params["From"],
+			To:      params["To"],
+			Body:    params["Body"],
 		})
 		w.WriteHeader(http.StatusOK)
 	}
@@ -0,0 +1,278 @@
+package cpassproviders
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+const whatsAppAPIURL = "https://graph.facebook.com/v21.0"
+
+// WhatsAppConfig holds the WhatsApp Business Cloud API configuration
+type WhatsAppConfig struct {
+	// ID of the business phone number messages are sent from
+	PhoneNumberID string
+	AccessToken   string
+	// Secret of the Meta app, used to verify the signature of webhook calls
+	AppSecret string
+	// Token Meta echoes back when the webhook is registered
+	VerifyToken string
+}
+
+type whatsAppWebhookDTO struct {
+	Entry []struct {
+		Changes []struct {
+			Value struct {
+				Metadata struct {
+					DisplayPhoneNumber string `json:"display_phone_number"`
+				} `json:"metadata"`
+				Contacts []struct {
+					WaID    string `json:"wa_id"`
+					Profile struct {
+						Name string `json:"name"`
+					} `json:"profile"`
+				} `json:"contacts"`
+				Messages []struct {
+					From string `json:"from"`
+					Type string `json:"type"`
+					Text struct {
+						Body string `json:"body"`
+					} `json:"text"`
+				} `json:"messages"`
+				Statuses []struct {
+					ID     string `json:"id"`
+					Status string `json:"status"`
+					Errors []struct {
+						Title string `json:"title"`
+					} `json:"errors"`
+				} `json:"statuses"`
+			} `json:"value"`
+		} `json:"changes"`
+	} `json:"entry"`
+}
+
+type whatsAppSendResultDTO struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+}
+
+type whatsAppTextDTO struct {
+	MessagingProduct string `json:"messaging_product"`
+	To               string `json:"to"`
+	Type             string `json:"type"`
+	Text             struct {
+		Body string `json:"body"`
+	} `json:"text"`
+}
+
+type whatsAppTemplateParameterDTO struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type whatsAppTemplateComponentDTO struct {
+	Type       string                         `json:"type"`
+	Parameters []whatsAppTemplateParameterDTO `json:"parameters"`
+}
+
+type whatsAppTemplateDTO struct {
+	MessagingProduct string `json:"messaging_product"`
+	To               string `json:"to"`
+	Type             string `json:"type"`
+	Template         struct {
+		Name     string `json:"name"`
+		Language struct {
+			Code string `json:"code"`
+		} `json:"language"`
+		Components []whatsAppTemplateComponentDTO `json:"components,omitempty"`
+	} `json:"template"`
+}
+
+// NewWhatsAppProvider creates a provider for the WhatsApp Business Cloud API
+func NewWhatsAppProvider(config WhatsAppConfig) WebhookProvider {
+	return &WhatsAppProvider{
+		config: config,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// WhatsAppProvider sends and receives WhatsApp messages. A client is addressed by phone number.
+type WhatsAppProvider struct {
+	config WhatsAppConfig
+	client *http.Client
+}
+
+func (s *WhatsAppProvider) Channel() chat.Channel {
+	return chat.WhatsApp
+}
+
+// whatsAppPayload builds a text message, or a template message when an approved template is given.
+// Free text is only delivered within 24 hours of the last message of the client, templates always are.
+func whatsAppPayload(data SendMessageDTO) interface{} {
+	to := strings.TrimPrefix(data.To, "+")
+	if data.Template == nil {
+		payload := whatsAppTextDTO{
+			MessagingProduct: "whatsapp",
+			To:               to,
+			Type:             "text",
+		}
+		payload.Text.Body = data.Message
+		return payload
+	}
+	payload := whatsAppTemplateDTO{
+		MessagingProduct: "whatsapp",
+		To:               to,
+		Type:             "template",
+	}
+	payload.Template.Name = data.Template.Name
+	payload.Template.Language.Code = data.Template.Language
+	if len(data.Template.Params) > 0 {
+		params := make([]whatsAppTemplateParameterDTO, 0, len(data.Template.Params))
+		for _, p := range data.Template.Params {
+			params = append(params, whatsAppTemplateParameterDTO{Type: "text", Text: p})
+		}
+		payload.Template.Components = []whatsAppTemplateComponentDTO{{Type: "body", Parameters: params}}
+	}
+	return payload
+}
+
+func (s *WhatsAppProvider) SendMessage(ctx context.Context, data SendMessageDTO) (string, error) {
+	body, err := json.Marshal(whatsAppPayload(data))
+	if err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/%s/messages", whatsAppAPIURL, s.config.PhoneNumberID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.config.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send whatsapp message: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("failed to send whatsapp message: %s: %s", resp.Status, respBody)
+	}
+	var result whatsAppSendResultDTO
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode whatsapp response: %w", err)
+	}
+	if len(result.Messages) == 0 {
+		return "", nil
+	}
+	return result.Messages[0].ID, nil
+}
+
+// whatsAppDeliveryStatus maps the status of a sent message, read receipts count as delivered.
+func whatsAppDeliveryStatus(status string) (DeliveryStatus, bool) {
+	switch status {
+	case "sent":
+		return DeliverySent, true
+	case "delivered", "read":
+		return DeliveryDelivered, true
+	case "failed":
+		return DeliveryFailed, true
+	}
+	return "", false
+}
+
+// validSignature checks the X-Hub-Signature-256 header, the HMAC-SHA256 of the body keyed with the app secret.
+func (s *WhatsAppProvider) validSignature(body []byte, header string) bool {
+	signature, err := hex.DecodeString(strings.TrimPrefix(header, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(s.config.AppSecret))
+	mac.Write(body)
+	return hmac.Equal(signature, mac.Sum(nil))
+}
+
+func (s *WhatsAppProvider) WebhookHandler(eventBus eventbus.EventBus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Meta verifies the webhook with a GET request before sending any messages.
+		if r.Method == http.MethodGet {
+			q := r.URL.Query()
+			token := q.Get("hub.verify_token")
+			if q.Get("hub.mode") != "subscribe" || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.VerifyToken)) != 1 {
+				http.Error(w, "invalid verify token", http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(q.Get("hub.challenge")))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Printf("Error reading whatsapp webhook: %v", err)
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if !s.validSignature(body, r.Header.Get("X-Hub-Signature-256")) {
+			log.Printf("Invalid whatsapp signature")
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		var dto whatsAppWebhookDTO
+		if err := json.Unmarshal(body, &dto); err != nil {
+			log.Printf("Error decoding whatsapp webhook: %v", err)
+			http.Error(w, "failed to decode body", http.StatusBadRequest)
+			return
+		}
+		for _, entry := range dto.Entry {
+			for _, change := range entry.Changes {
+				names := make(map[string]string, len(change.Value.Contacts))
+				for _, c := range change.Value.Contacts {
+					names[c.WaID] = c.Profile.Name
+				}
+				for _, msg := range change.Value.Messages {
+					if msg.Type != "text" {
+						continue
+					}
+					firstName, lastName, _ := strings.Cut(names[msg.From], " ")
+					eventBus.Publish(&ReceivedMessageEvent{
+						Channel:   chat.WhatsApp,
+						From:      "+" + msg.From,
+						To:        change.Value.Metadata.DisplayPhoneNumber,
+						Body:      msg.Text.Body,
+						FirstName: firstName,
+						LastName:  lastName,
+						Phone:     "+" + msg.From,
+					})
+				}
+				for _, st := range change.Value.Statuses {
+					status, ok := whatsAppDeliveryStatus(st.Status)
+					if !ok {
+						continue
+					}
+					event := &DeliveryStatusEvent{
+						Channel:   chat.WhatsApp,
+						MessageID: st.ID,
+						Status:    status,
+					}
+					if len(st.Errors) > 0 {
+						event.Error = st.Errors[0].Title
+					}
+					eventBus.Publish(event)
+				}
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,293 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrActivityNotFound = errors.New("activity not found")
+)
+
+const (
+	selectActivityQuery = `
+		SELECT
+			a.id,
+			a.client_id,
+			a.kind,
+			a.ref_id,
+			a.body,
+			a.direction,
+			a.duration_seconds,
+			a.amount,
+			a.currency,
+			a.status,
+			a.author_id,
+			a.occurred_at,
+			a.created_at
+		FROM client_activities a`
+	insertActivityQuery = `
+		INSERT INTO client_activities (
+			client_id, kind, ref_id, body, direction, duration_seconds,
+			amount, currency, status, author_id, occurred_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
+	deleteActivityQuery      = `DELETE FROM client_activities WHERE id = $1`
+	deleteActivityByRefQuery = `DELETE FROM client_activities WHERE kind = $1 AND ref_id = $2`
+	selectClientIDsByRef     = `SELECT DISTINCT client_id FROM client_activities WHERE kind = $1 AND ref_id = $2`
+
+	insertClientCounterpartyQuery = `
+		INSERT INTO client_counterparties (client_id, counterparty_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+	selectClientIDsByCounterpartyQuery = `SELECT client_id FROM client_counterparties WHERE counterparty_id = $1`
+
+	// Messages are merged with the activities, a message from the client is inbound
+	selectTimelineQuery = `
+		SELECT kind, id, ref_id, body, channel, direction, duration_seconds, amount, currency, status, author_id, occurred_at
+		FROM (
+			SELECT
+				'message' AS kind,
+				m.id,
+				0 AS ref_id,
+				m.message AS body,
+				m.channel,
+				CASE WHEN m.sender_client_id IS NOT NULL THEN 'inbound' ELSE 'outbound' END AS direction,
+				0 AS duration_seconds,
+				0::float8 AS amount,
+				'' AS currency,
+				'' AS status,
+				COALESCE(m.sender_user_id, 0) AS author_id,
+				m.created_at::timestamptz AS occurred_at
+			FROM messages m
+			JOIN chats c ON c.id = m.chat_id
+			WHERE c.client_id = $1
+			UNION ALL
+			SELECT
+				a.kind,
+				a.id,
+				COALESCE(a.ref_id, 0),
+				a.body,
+				'',
+				COALESCE(a.direction, ''),
+				a.duration_seconds,
+				COALESCE(a.amount, 0)::float8,
+				COALESCE(a.currency, ''),
+				COALESCE(a.status, ''),
+				COALESCE(a.author_id, 0),
+				a.occurred_at
+			FROM client_activities a
+			WHERE a.client_id = $1
+		) t`
+)
+
+type ActivityRepository struct {
+}
+
+func NewActivityRepository() activity.Repository {
+	return &ActivityRepository{}
+}
+
+func (g *ActivityRepository) queryActivities(ctx context.Context, query string, args ...interface{}) ([]activity.Activity, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	activities := make([]activity.Activity, 0)
+	for rows.Next() {
+		var a models.ClientActivity
+		if err := rows.Scan(
+			&a.ID,
+			&a.ClientID,
+			&a.Kind,
+			&a.RefID,
+			&a.Body,
+			&a.Direction,
+			&a.DurationSeconds,
+			&a.Amount,
+			&a.Currency,
+			&a.Status,
+			&a.AuthorID,
+			&a.OccurredAt,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		activities = append(activities, toDomainActivity(&a))
+	}
+	return activities, rows.Err()
+}
+
+func (g *ActivityRepository) queryIDs(ctx context.Context, query string, args ...interface{}) ([]uint, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]uint, 0)
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (g *ActivityRepository) GetByID(ctx context.Context, id uint) (activity.Activity, error) {
+	activities, err := g.queryActivities(ctx, selectActivityQuery+" WHERE a.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(activities) == 0 {
+		return nil, ErrActivityNotFound
+	}
+	return activities[0], nil
+}
+
+func (g *ActivityRepository) Create(ctx context.Context, data activity.Activity) (activity.Activity, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbRow := toDBActivity(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertActivityQuery,
+		dbRow.ClientID,
+		dbRow.Kind,
+		dbRow.RefID,
+		dbRow.Body,
+		dbRow.Direction,
+		dbRow.DurationSeconds,
+		dbRow.Amount,
+		dbRow.Currency,
+		dbRow.Status,
+		dbRow.AuthorID,
+		dbRow.OccurredAt,
+		dbRow.CreatedAt,
+	).Scan(&dbRow.ID); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, dbRow.ID)
+}
+
+func (g *ActivityRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, deleteActivityQuery, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrActivityNotFound
+	}
+	return nil
+}
+
+func (g *ActivityRepository) DeleteByRef(ctx context.Context, kind activity.Kind, refID uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, deleteActivityByRefQuery, string(kind), refID)
+	return err
+}
+
+func (g *ActivityRepository) ClientIDsByRef(ctx context.Context, kind activity.Kind, refID uint) ([]uint, error) {
+	return g.queryIDs(ctx, selectClientIDsByRef, string(kind), refID)
+}
+
+func (g *ActivityRepository) LinkCounterparty(ctx context.Context, clientID, counterpartyID uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, insertClientCounterpartyQuery, clientID, counterpartyID)
+	return err
+}
+
+func (g *ActivityRepository) ClientIDsByCounterparty(ctx context.Context, counterpartyID uint) ([]uint, error) {
+	return g.queryIDs(ctx, selectClientIDsByCounterpartyQuery, counterpartyID)
+}
+
+func (g *ActivityRepository) Timeline(ctx context.Context, params *activity.TimelineParams) ([]activity.Entry, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where := []string{"TRUE"}
+	args := []interface{}{params.ClientID}
+	if len(params.Kinds) > 0 {
+		kinds := make([]string, 0, len(params.Kinds))
+		for _, k := range params.Kinds {
+			kinds = append(kinds, string(k))
+		}
+		args = append(args, kinds)
+		where = append(where, fmt.Sprintf("t.kind = ANY($%d)", len(args)))
+	}
+	if !params.From.IsZero() {
+		args = append(args, params.From)
+		where = append(where, fmt.Sprintf("t.occurred_at >= $%d", len(args)))
+	}
+	if !params.To.IsZero() {
+		args = append(args, params.To)
+		where = append(where, fmt.Sprintf("t.occurred_at < $%d", len(args)))
+	}
+	query := repo.Join(
+		selectTimelineQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY t.occurred_at DESC, t.kind, t.id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := make([]activity.Entry, 0)
+	for rows.Next() {
+		var e activity.Entry
+		var kind, direction string
+		var durationSeconds int
+		if err := rows.Scan(
+			&kind,
+			&e.ID,
+			&e.RefID,
+			&e.Body,
+			&e.Channel,
+			&direction,
+			&durationSeconds,
+			&e.Amount,
+			&e.Currency,
+			&e.Status,
+			&e.AuthorID,
+			&e.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		e.Kind = activity.Kind(kind)
+		e.Direction = activity.Direction(direction)
+		e.Duration = time.Duration(durationSeconds) * time.Second
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
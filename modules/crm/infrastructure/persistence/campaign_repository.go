@@ -0,0 +1,449 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/campaign"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrCampaignNotFound          = errors.New("campaign not found")
+	ErrCampaignRecipientNotFound = errors.New("campaign recipient not found")
+)
+
+const (
+	selectCampaignQuery = `
+		SELECT id, name, template_id, segment_id, channel, locale, rate_per_minute, status,
+			started_at, completed_at, created_at, updated_at
+		FROM campaigns`
+	countCampaignQuery  = `SELECT COUNT(*) FROM campaigns`
+	insertCampaignQuery = `
+		INSERT INTO campaigns (
+			name, template_id, segment_id, channel, locale, rate_per_minute, status,
+			started_at, completed_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`
+	updateCampaignQuery = `
+		UPDATE campaigns
+		SET name = $1, template_id = $2, segment_id = $3, channel = $4, locale = $5,
+			rate_per_minute = $6, status = $7, started_at = $8, completed_at = $9, updated_at = $10
+		WHERE id = $11`
+	deleteCampaignQuery = `DELETE FROM campaigns WHERE id = $1`
+
+	selectCampaignRecipientQuery = `
+		SELECT r.id, r.campaign_id, r.client_id, r.address, r.status, r.message, r.external_id,
+			r.attempts, r.next_attempt_at, r.last_error, r.sent_at, r.delivered_at, r.updated_at
+		FROM campaign_recipients r`
+	countCampaignRecipientQuery  = `SELECT COUNT(*) FROM campaign_recipients r`
+	insertCampaignRecipientQuery = `
+		INSERT INTO campaign_recipients (campaign_id, client_id, address, status, attempts, next_attempt_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (campaign_id, client_id) DO NOTHING`
+	updateCampaignRecipientQuery = `
+		UPDATE campaign_recipients
+		SET status = $1, message = $2, external_id = $3, attempts = $4, next_attempt_at = $5,
+			last_error = $6, sent_at = $7, delivered_at = $8, updated_at = $9
+		WHERE id = $10`
+	campaignStatsQuery = `
+		SELECT status, COUNT(*) FROM campaign_recipients
+		WHERE campaign_id = $1
+		GROUP BY status`
+
+	insertCampaignOptOutQuery = `
+		INSERT INTO campaign_opt_outs (channel, address) VALUES ($1, $2)
+		ON CONFLICT (channel, address) DO NOTHING`
+	deleteCampaignOptOutQuery = `DELETE FROM campaign_opt_outs WHERE channel = $1 AND address = $2`
+	existsCampaignOptOutQuery = `SELECT EXISTS (SELECT 1 FROM campaign_opt_outs WHERE channel = $1 AND address = $2)`
+)
+
+type CampaignRepository struct {
+}
+
+func NewCampaignRepository() campaign.Repository {
+	return &CampaignRepository{}
+}
+
+func (r *CampaignRepository) queryCampaigns(ctx context.Context, query string, args ...interface{}) ([]campaign.Campaign, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	campaigns := make([]campaign.Campaign, 0)
+	for rows.Next() {
+		var c models.Campaign
+		if err := rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.TemplateID,
+			&c.SegmentID,
+			&c.Channel,
+			&c.Locale,
+			&c.RatePerMinute,
+			&c.Status,
+			&c.StartedAt,
+			&c.CompletedAt,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainCampaign(&c)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, entity)
+	}
+	return campaigns, rows.Err()
+}
+
+func (r *CampaignRepository) queryRecipients(ctx context.Context, query string, args ...interface{}) ([]campaign.Recipient, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	recipients := make([]campaign.Recipient, 0)
+	for rows.Next() {
+		var cr models.CampaignRecipient
+		if err := rows.Scan(
+			&cr.ID,
+			&cr.CampaignID,
+			&cr.ClientID,
+			&cr.Address,
+			&cr.Status,
+			&cr.Message,
+			&cr.ExternalID,
+			&cr.Attempts,
+			&cr.NextAttemptAt,
+			&cr.LastError,
+			&cr.SentAt,
+			&cr.DeliveredAt,
+			&cr.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainCampaignRecipient(&cr)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, entity)
+	}
+	return recipients, rows.Err()
+}
+
+func (r *CampaignRepository) buildFilters(params *campaign.FindParams) ([]string, []interface{}) {
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if params.Status != "" {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, params.Status)
+	}
+	return where, args
+}
+
+func (r *CampaignRepository) Count(ctx context.Context, params *campaign.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := r.buildFilters(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countCampaignQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *CampaignRepository) GetPaginated(ctx context.Context, params *campaign.FindParams) ([]campaign.Campaign, error) {
+	where, args := r.buildFilters(params)
+	return r.queryCampaigns(
+		ctx,
+		repo.Join(
+			selectCampaignQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY created_at DESC",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (r *CampaignRepository) GetByID(ctx context.Context, id uint) (campaign.Campaign, error) {
+	campaigns, err := r.queryCampaigns(ctx, selectCampaignQuery+" WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(campaigns) == 0 {
+		return nil, ErrCampaignNotFound
+	}
+	return campaigns[0], nil
+}
+
+func (r *CampaignRepository) Create(ctx context.Context, data campaign.Campaign) (campaign.Campaign, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbRow := toDBCampaign(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertCampaignQuery,
+		dbRow.Name,
+		dbRow.TemplateID,
+		dbRow.SegmentID,
+		dbRow.Channel,
+		dbRow.Locale,
+		dbRow.RatePerMinute,
+		dbRow.Status,
+		dbRow.StartedAt,
+		dbRow.CompletedAt,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&dbRow.ID); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, dbRow.ID)
+}
+
+func (r *CampaignRepository) Update(ctx context.Context, data campaign.Campaign) (campaign.Campaign, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbRow := toDBCampaign(data)
+	result, err := tx.Exec(
+		ctx,
+		updateCampaignQuery,
+		dbRow.Name,
+		dbRow.TemplateID,
+		dbRow.SegmentID,
+		dbRow.Channel,
+		dbRow.Locale,
+		dbRow.RatePerMinute,
+		dbRow.Status,
+		dbRow.StartedAt,
+		dbRow.CompletedAt,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrCampaignNotFound
+	}
+	return r.GetByID(ctx, dbRow.ID)
+}
+
+func (r *CampaignRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(ctx, deleteCampaignQuery, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCampaignNotFound
+	}
+	return nil
+}
+
+// AddRecipients skips the clients that are already recipients of the campaign.
+func (r *CampaignRepository) AddRecipients(ctx context.Context, recipients []campaign.Recipient) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		dbRow := toDBCampaignRecipient(recipient)
+		if _, err := tx.Exec(
+			ctx,
+			insertCampaignRecipientQuery,
+			dbRow.CampaignID,
+			dbRow.ClientID,
+			dbRow.Address,
+			dbRow.Status,
+			dbRow.Attempts,
+			dbRow.NextAttemptAt,
+			dbRow.UpdatedAt,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CampaignRepository) buildRecipientFilters(params *campaign.RecipientFindParams) ([]string, []interface{}) {
+	where, args := []string{"r.campaign_id = $1"}, []interface{}{params.CampaignID}
+	if params.Status != "" {
+		where, args = append(where, fmt.Sprintf("r.status = $%d", len(args)+1)), append(args, params.Status)
+	}
+	return where, args
+}
+
+func (r *CampaignRepository) CountRecipients(ctx context.Context, params *campaign.RecipientFindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := r.buildRecipientFilters(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countCampaignRecipientQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *CampaignRepository) GetRecipients(ctx context.Context, params *campaign.RecipientFindParams) ([]campaign.Recipient, error) {
+	where, args := r.buildRecipientFilters(params)
+	return r.queryRecipients(
+		ctx,
+		repo.Join(
+			selectCampaignRecipientQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY r.id",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (r *CampaignRepository) GetDueRecipients(ctx context.Context, campaignID uint, now time.Time, limit int) ([]campaign.Recipient, error) {
+	return r.queryRecipients(
+		ctx,
+		repo.Join(
+			selectCampaignRecipientQuery,
+			"WHERE r.campaign_id = $1 AND r.status = $2 AND r.next_attempt_at <= $3",
+			"ORDER BY r.next_attempt_at, r.id",
+			repo.FormatLimitOffset(limit, 0),
+			"FOR UPDATE SKIP LOCKED",
+		),
+		campaignID,
+		campaign.Queued,
+		now,
+	)
+}
+
+func (r *CampaignRepository) GetRecipientByExternalID(ctx context.Context, channel chat.Channel, externalID string) (campaign.Recipient, error) {
+	recipients, err := r.queryRecipients(
+		ctx,
+		selectCampaignRecipientQuery+` JOIN campaigns c ON c.id = r.campaign_id
+		WHERE c.channel = $1 AND r.external_id = $2`,
+		channel,
+		externalID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipients) == 0 {
+		return nil, ErrCampaignRecipientNotFound
+	}
+	return recipients[0], nil
+}
+
+func (r *CampaignRepository) UpdateRecipient(ctx context.Context, data campaign.Recipient) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbRow := toDBCampaignRecipient(data)
+	result, err := tx.Exec(
+		ctx,
+		updateCampaignRecipientQuery,
+		dbRow.Status,
+		dbRow.Message,
+		dbRow.ExternalID,
+		dbRow.Attempts,
+		dbRow.NextAttemptAt,
+		dbRow.LastError,
+		dbRow.SentAt,
+		dbRow.DeliveredAt,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCampaignRecipientNotFound
+	}
+	return nil
+}
+
+func (r *CampaignRepository) Stats(ctx context.Context, campaignID uint) (campaign.Stats, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return campaign.Stats{}, err
+	}
+	rows, err := tx.Query(ctx, campaignStatsQuery, campaignID)
+	if err != nil {
+		return campaign.Stats{}, err
+	}
+	defer rows.Close()
+	var stats campaign.Stats
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return campaign.Stats{}, err
+		}
+		switch campaign.RecipientStatus(status) {
+		case campaign.Queued:
+			stats.Queued = count
+		case campaign.Sent:
+			stats.Sent = count
+		case campaign.Delivered:
+			stats.Delivered = count
+		case campaign.Failed:
+			stats.Failed = count
+		case campaign.OptedOut:
+			stats.OptedOut = count
+		}
+	}
+	return stats, rows.Err()
+}
+
+func (r *CampaignRepository) OptOut(ctx context.Context, channel chat.Channel, address string) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, insertCampaignOptOutQuery, channel, address)
+	return err
+}
+
+func (r *CampaignRepository) OptIn(ctx context.Context, channel chat.Channel, address string) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, deleteCampaignOptOutQuery, channel, address)
+	return err
+}
+
+func (r *CampaignRepository) IsOptedOut(ctx context.Context, channel chat.Channel, address string) (bool, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, existsCampaignOptOutQuery, channel, address).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
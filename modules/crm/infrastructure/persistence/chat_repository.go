@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-faster/errors"
 
@@ -24,8 +25,18 @@ const (
 			c.id,
 			c.created_at,
 			c.last_message_at,
-			c.client_id
+			c.client_id,
+			c.status,
+			c.assignee_id,
+			c.team_id,
+			c.assigned_at,
+			c.opened_at,
+			c.first_response_at,
+			c.closed_at,
+			c.first_response_breached,
+			c.resolution_breached
 		FROM chats c
+		LEFT JOIN clients cl ON cl.id = c.client_id
 	`
 
 	countChatQuery = `SELECT COUNT(*) as count FROM chats`
@@ -33,15 +44,81 @@ const (
 	insertChatQuery = `
 		INSERT INTO chats (
 			client_id,
-			created_at
-		) VALUES ($1, $2) RETURNING id
+			created_at,
+			status,
+			assignee_id,
+			team_id,
+			assigned_at,
+			opened_at,
+			first_response_at,
+			closed_at,
+			first_response_breached,
+			resolution_breached
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id
 	`
 
 	updateChatQuery = `UPDATE chats SET
 		client_id = $1,
 		created_at = $2,
-		last_message_at = $3
-		WHERE id = $4`
+		last_message_at = $3,
+		status = $4,
+		assignee_id = $5,
+		team_id = $6,
+		assigned_at = $7,
+		opened_at = $8,
+		first_response_at = $9,
+		closed_at = $10,
+		first_response_breached = $11,
+		resolution_breached = $12
+		WHERE id = $13`
+
+	// The SLA durations are passed in seconds, a zero duration disables the SLA
+	selectOverdueChatsWhere = `
+		c.opened_at IS NOT NULL AND (
+			(NOT c.first_response_breached AND $1::float8 > 0
+				AND COALESCE(c.first_response_at, $3) > c.opened_at + make_interval(secs => $1::float8))
+			OR (NOT c.resolution_breached AND $2::float8 > 0
+				AND COALESCE(c.closed_at, $3) > c.opened_at + make_interval(secs => $2::float8))
+		)`
+
+	selectChatWorkloadQuery = `
+		SELECT
+			COALESCE(assignee_id, 0),
+			COUNT(*) FILTER (WHERE status = 'open'),
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'closed' AND closed_at >= $1 AND closed_at < $2),
+			COUNT(*) FILTER (WHERE status <> 'closed' AND (first_response_breached OR resolution_breached))
+		FROM chats
+		GROUP BY COALESCE(assignee_id, 0)`
+
+	// A client message waits for an answer unless it follows another client message, the wait
+	// ends with the next operator message and is credited to its sender.
+	selectResponseTimesQuery = `
+		WITH ordered AS (
+			SELECT
+				chat_id,
+				created_at,
+				sender_client_id IS NOT NULL AS from_client,
+				LAG(sender_client_id IS NOT NULL) OVER (PARTITION BY chat_id ORDER BY created_at, id) AS after_client
+			FROM messages
+		), waits AS (
+			SELECT chat_id, created_at
+			FROM ordered
+			WHERE from_client AND after_client IS DISTINCT FROM true AND created_at >= $1 AND created_at < $2
+		)
+		SELECT
+			r.sender_user_id,
+			COUNT(*),
+			EXTRACT(EPOCH FROM AVG(r.created_at - w.created_at))::float8
+		FROM waits w
+		JOIN LATERAL (
+			SELECT m.sender_user_id, m.created_at
+			FROM messages m
+			WHERE m.chat_id = w.chat_id AND m.sender_user_id IS NOT NULL AND m.created_at >= w.created_at
+			ORDER BY m.created_at, m.id
+			LIMIT 1
+		) r ON true
+		GROUP BY r.sender_user_id`
 
 	deleteChatQuery = `DELETE FROM chats WHERE id = $1`
 
@@ -49,6 +126,7 @@ const (
 		SELECT 
 			m.id,
 			m.chat_id,
+			m.channel,
 			m.message,
 			m.sender_user_id,
 			m.sender_client_id,
@@ -77,27 +155,35 @@ const (
 		WHERE m.id = $1
 	`
 
+	selectChatContactsQuery = `SELECT chat_id, channel, address FROM chat_contacts WHERE chat_id = $1`
+
+	upsertChatContactQuery = `
+		INSERT INTO chat_contacts (chat_id, channel, address) VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id, channel) DO UPDATE SET address = EXCLUDED.address`
+
 	countMessagesQuery = `SELECT COUNT(*) as count FROM messages`
 
 	insertMessageQuery = `
 		INSERT INTO messages (
 			chat_id,
+			channel,
 			message,
 			sender_user_id,
 			sender_client_id,
 			is_read,
 			created_at
-		) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+		) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
 
 	updateMessageQuery = `
 		UPDATE messages SET 
 			chat_id = $1,
-			message = $2,
-			sender_user_id = $3,
-			sender_client_id = $4,
-			is_read = $5, 
-			read_at = $6
-		WHERE id = $7
+			channel = $2,
+			message = $3,
+			sender_user_id = $4,
+			sender_client_id = $5,
+			is_read = $6, 
+			read_at = $7
+		WHERE id = $8
 	`
 
 	deleteMessageQuery = `DELETE FROM messages WHERE id = $1`
@@ -130,6 +216,15 @@ func (g *ChatRepository) queryChats(ctx context.Context, query string, args ...i
 			&c.CreatedAt,
 			&c.LastMessageAt,
 			&c.ClientID,
+			&c.Status,
+			&c.AssigneeID,
+			&c.TeamID,
+			&c.AssignedAt,
+			&c.OpenedAt,
+			&c.FirstResponseAt,
+			&c.ClosedAt,
+			&c.FirstResponseBreached,
+			&c.ResolutionBreached,
 		); err != nil {
 			return nil, errors.Wrap(err, "failed to scan chat")
 		}
@@ -155,7 +250,11 @@ func (g *ChatRepository) queryChats(ctx context.Context, query string, args ...i
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get messages for chat")
 		}
-		domainChat, err := toDomainChat(c, messages)
+		contacts, err := g.queryContacts(ctx, c.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get contacts for chat")
+		}
+		domainChat, err := toDomainChat(c, messages, contacts)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to convert to domain chat")
 		}
@@ -165,6 +264,31 @@ func (g *ChatRepository) queryChats(ctx context.Context, query string, args ...i
 	return chats, nil
 }
 
+func (g *ChatRepository) queryContacts(ctx context.Context, chatID uint) ([]chat.Contact, error) {
+	pool, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	rows, err := pool.Query(ctx, selectChatContactsQuery, chatID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute chat contacts query")
+	}
+	defer rows.Close()
+
+	contacts := make([]chat.Contact, 0)
+	for rows.Next() {
+		var c models.ChatContact
+		if err := rows.Scan(&c.ChatID, &c.Channel, &c.Address); err != nil {
+			return nil, errors.Wrap(err, "failed to scan chat contact")
+		}
+		contacts = append(contacts, toDomainChatContact(&c))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error occurred while iterating chat contact rows")
+	}
+	return contacts, nil
+}
+
 func (g *ChatRepository) queryMessages(ctx context.Context, query string, args ...interface{}) ([]chat.Message, error) {
 	pool, err := composables.UseTx(ctx)
 	if err != nil {
@@ -183,6 +307,7 @@ func (g *ChatRepository) queryMessages(ctx context.Context, query string, args .
 		if err := rows.Scan(
 			&msg.ID,
 			&msg.ChatID,
+			&msg.Channel,
 			&msg.Message,
 			&msg.SenderUserID,
 			&msg.SenderClientID,
@@ -280,12 +405,24 @@ func (g *ChatRepository) GetPaginated(
 
 	where, args := []string{"1 = 1"}, []interface{}{}
 	if params.Search != "" {
+		n := len(args) + 1
 		where = append(
 			where,
-			"cl.first_name ILIKE $1 OR cl.last_name ILIKE $1 OR cl.middle_name ILIKE $1 OR cl.phone_number ILIKE $1",
+			fmt.Sprintf(
+				"(cl.first_name ILIKE $%d OR cl.last_name ILIKE $%d OR cl.middle_name ILIKE $%d OR cl.phone_number ILIKE $%d)",
+				n, n, n, n,
+			),
 		)
 		args = append(args, "%"+params.Search+"%")
 	}
+	if params.Status != "" {
+		where, args = append(where, fmt.Sprintf("c.status = $%d", len(args)+1)), append(args, string(params.Status))
+	}
+	if params.Unassigned {
+		where = append(where, "c.assignee_id IS NULL")
+	} else if params.AssigneeID != 0 {
+		where, args = append(where, fmt.Sprintf("c.assignee_id = $%d", len(args)+1)), append(args, params.AssigneeID)
+	}
 	return g.queryChats(
 		ctx,
 		repo.Join(
@@ -340,6 +477,93 @@ func (g *ChatRepository) GetByClientID(ctx context.Context, clientID uint) (chat
 	return chats[0], nil
 }
 
+func (g *ChatRepository) GetByContact(ctx context.Context, channel chat.Channel, address string) (chat.Chat, error) {
+	chats, err := g.queryChats(
+		ctx,
+		selectChatQuery+" WHERE c.id = (SELECT chat_id FROM chat_contacts WHERE channel = $1 AND address = $2)",
+		string(channel),
+		address,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get chat for %s contact %s", channel, address)
+	}
+	if len(chats) == 0 {
+		return nil, ErrChatNotFound
+	}
+	return chats[0], nil
+}
+
+func (g *ChatRepository) GetOverdue(ctx context.Context, policy chat.SLAPolicy, now time.Time) ([]chat.Chat, error) {
+	chats, err := g.queryChats(
+		ctx,
+		repo.Join(selectChatQuery, repo.JoinWhere(selectOverdueChatsWhere)),
+		policy.FirstResponse.Seconds(),
+		policy.Resolution.Seconds(),
+		now,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get overdue chats")
+	}
+	return chats, nil
+}
+
+func (g *ChatRepository) Workload(ctx context.Context, from, to time.Time) ([]chat.Workload, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	rows, err := tx.Query(ctx, selectChatWorkloadQuery, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query chat workload")
+	}
+	defer rows.Close()
+
+	byUser := make(map[uint]*chat.Workload)
+	result := make([]*chat.Workload, 0)
+	for rows.Next() {
+		w := &chat.Workload{}
+		if err := rows.Scan(&w.UserID, &w.Open, &w.Pending, &w.Closed, &w.Breached); err != nil {
+			return nil, errors.Wrap(err, "failed to scan chat workload")
+		}
+		byUser[w.UserID] = w
+		result = append(result, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error occurred while iterating chat workload rows")
+	}
+
+	responseRows, err := tx.Query(ctx, selectResponseTimesQuery, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query response times")
+	}
+	defer responseRows.Close()
+	for responseRows.Next() {
+		var userID uint
+		var count int
+		var seconds float64
+		if err := responseRows.Scan(&userID, &count, &seconds); err != nil {
+			return nil, errors.Wrap(err, "failed to scan response times")
+		}
+		w, ok := byUser[userID]
+		if !ok {
+			w = &chat.Workload{UserID: userID}
+			byUser[userID] = w
+			result = append(result, w)
+		}
+		w.Responses = count
+		w.AverageResponse = time.Duration(seconds * float64(time.Second))
+	}
+	if err := responseRows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error occurred while iterating response time rows")
+	}
+
+	workload := make([]chat.Workload, 0, len(result))
+	for _, w := range result {
+		workload = append(workload, *w)
+	}
+	return workload, nil
+}
+
 func (g *ChatRepository) GetMessageByID(ctx context.Context, id uint) (chat.Message, error) {
 	messages, err := g.queryMessages(ctx, selectMessagesQuery+" WHERE m.id = $1", id)
 	if err != nil {
@@ -368,6 +592,7 @@ func (g *ChatRepository) insertMessage(ctx context.Context, message *models.Mess
 		ctx,
 		insertMessageQuery,
 		message.ChatID,
+		message.Channel,
 		message.Message,
 		message.SenderUserID,
 		message.SenderClientID,
@@ -388,6 +613,7 @@ func (g *ChatRepository) updateMessage(ctx context.Context, message *models.Mess
 		ctx,
 		updateMessageQuery,
 		message.ChatID,
+		message.Channel,
 		message.Message,
 		message.SenderUserID,
 		message.SenderClientID,
@@ -426,24 +652,52 @@ func (g *ChatRepository) saveMessages(ctx context.Context, dbMessages []*models.
 	return nil
 }
 
+func (g *ChatRepository) saveContacts(ctx context.Context, chatID uint, dbContacts []*models.ChatContact) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction")
+	}
+	for _, c := range dbContacts {
+		if _, err := tx.Exec(ctx, upsertChatContactQuery, chatID, c.Channel, c.Address); err != nil {
+			return errors.Wrapf(err, "failed to save %s contact", c.Channel)
+		}
+	}
+	return nil
+}
+
 func (g *ChatRepository) Create(ctx context.Context, data chat.Chat) (chat.Chat, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get transaction")
 	}
 
-	dbChat, dbMessages := toDBChat(data)
+	dbChat, dbMessages, dbContacts := toDBChat(data)
 	if err := tx.QueryRow(
 		ctx,
 		insertChatQuery,
 		dbChat.ClientID,
 		&dbChat.CreatedAt,
+		dbChat.Status,
+		dbChat.AssigneeID,
+		dbChat.TeamID,
+		dbChat.AssignedAt,
+		dbChat.OpenedAt,
+		dbChat.FirstResponseAt,
+		dbChat.ClosedAt,
+		dbChat.FirstResponseBreached,
+		dbChat.ResolutionBreached,
 	).Scan(&dbChat.ID); err != nil {
 		return nil, errors.Wrap(err, "failed to insert chat")
 	}
+	for _, m := range dbMessages {
+		m.ChatID = dbChat.ID
+	}
 	if err := g.saveMessages(ctx, dbMessages); err != nil {
 		return nil, err
 	}
+	if err := g.saveContacts(ctx, dbChat.ID, dbContacts); err != nil {
+		return nil, err
+	}
 	return g.GetByID(ctx, dbChat.ID)
 }
 
@@ -453,13 +707,22 @@ func (g *ChatRepository) Update(ctx context.Context, data chat.Chat) (chat.Chat,
 		return nil, errors.Wrap(err, "failed to get transaction")
 	}
 
-	dbChat, dbMessages := toDBChat(data)
+	dbChat, dbMessages, dbContacts := toDBChat(data)
 	if _, err := tx.Exec(
 		ctx,
 		updateChatQuery,
 		dbChat.ClientID,
 		&dbChat.CreatedAt,
 		&dbChat.LastMessageAt,
+		dbChat.Status,
+		dbChat.AssigneeID,
+		dbChat.TeamID,
+		dbChat.AssignedAt,
+		dbChat.OpenedAt,
+		dbChat.FirstResponseAt,
+		dbChat.ClosedAt,
+		dbChat.FirstResponseBreached,
+		dbChat.ResolutionBreached,
 		dbChat.ID,
 	); err != nil {
 		return nil, errors.Wrap(err, "failed to update chat")
@@ -467,6 +730,9 @@ func (g *ChatRepository) Update(ctx context.Context, data chat.Chat) (chat.Chat,
 	if err := g.saveMessages(ctx, dbMessages); err != nil {
 		return nil, err
 	}
+	if err := g.saveContacts(ctx, dbChat.ID, dbContacts); err != nil {
+		return nil, err
+	}
 	return g.GetByID(ctx, dbChat.ID)
 }
 
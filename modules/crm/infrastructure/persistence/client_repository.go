@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
+
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
@@ -23,27 +25,110 @@ const (
 			c.last_name,
 			c.middle_name,
 			c.phone_number,
+			c.email,
+			c.address,
+			c.date_of_birth,
+			c.gender,
+			c.hourly_rate,
 			c.created_at,
 			c.updated_at
 		FROM clients c
 	`
-	countClientQuery  = `SELECT COUNT(*) as count FROM clients`
+	countClientQuery  = `SELECT COUNT(*) as count FROM clients c`
 	insertClientQuery = `
 		INSERT INTO clients (
 			first_name, 
 			last_name, 
 			middle_name, 
-			phone_number
-		) VALUES ($1, $2, $3, $4) RETURNING id`
+			phone_number,
+			email,
+			address,
+			date_of_birth,
+			gender,
+			hourly_rate
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 	updateClientQuery = `
 		UPDATE clients 
-		SET first_name = $1, last_name = $2, middle_name = $3, phone_number = $4
-		WHERE id = $5`
-	deleteChatMessagesQuery = `DELETE FROM messages WHERE chat_id IN (SELECT id FROM chats WHERE client_id = $1)`
-	deleteClientChatsQuery  = `DELETE FROM chats WHERE client_id = $1`
-	deleteClientQuery       = `DELETE FROM clients WHERE id = $1`
+		SET first_name = $1, last_name = $2, middle_name = $3, phone_number = $4, email = $5,
+			address = $6, date_of_birth = $7, gender = $8, hourly_rate = $9, updated_at = current_timestamp
+		WHERE id = $10`
+	selectClientTagsQuery        = `SELECT client_id, tag FROM client_tags WHERE client_id = ANY($1) ORDER BY tag`
+	deleteClientTagsQuery        = `DELETE FROM client_tags WHERE client_id = $1`
+	insertClientTagQuery         = `INSERT INTO client_tags (client_id, tag) VALUES ($1, $2)`
+	selectClientFieldValuesQuery = `SELECT client_id, field_id, value FROM client_custom_field_values WHERE client_id = ANY($1)`
+	deleteClientFieldValuesQuery = `DELETE FROM client_custom_field_values WHERE client_id = $1`
+	insertClientFieldValueQuery  = `INSERT INTO client_custom_field_values (client_id, field_id, value) VALUES ($1, $2, $3)`
+	deleteChatMessagesQuery      = `DELETE FROM messages WHERE chat_id IN (SELECT id FROM chats WHERE client_id = $1)`
+	deleteClientChatsQuery       = `DELETE FROM chats WHERE client_id = $1`
+	deleteClientQuery            = `DELETE FROM clients WHERE id = $1`
+
+	// Candidates share the last digits of the phone number, the email or the last name with the
+	// client; they are scored in the domain.
+	duplicateCandidatesQuery = selectClientQuery + `
+		WHERE c.id <> $1 AND (
+			right(regexp_replace(c.phone_number, '\D', '', 'g'), $2) = $3
+			OR ($4 <> '' AND lower(c.email) = lower($4))
+			OR ($5 <> '' AND lower(c.last_name) = lower($5))
+			OR lower(c.first_name) = lower($6)
+		)
+		ORDER BY c.id`
+	selectClientChatQuery    = `SELECT id FROM chats WHERE client_id = $1 ORDER BY id LIMIT 1`
+	reassignClientChatsQuery = `UPDATE chats SET client_id = $1 WHERE client_id = $2`
+	moveChatMessagesQuery    = `UPDATE messages SET chat_id = $1 WHERE chat_id IN (SELECT id FROM chats WHERE client_id = $2)`
+	moveChatContactsQuery    = `
+		UPDATE chat_contacts SET chat_id = $1
+		WHERE chat_id IN (SELECT id FROM chats WHERE client_id = $2)
+		  AND channel NOT IN (SELECT channel FROM chat_contacts WHERE chat_id = $1)`
+	touchMergedChatQuery = `
+		UPDATE chats SET last_message_at = (
+			SELECT GREATEST(MAX(m.created_at), chats.last_message_at) FROM messages m WHERE m.chat_id = $1
+		) WHERE id = $1`
+	reassignSenderClientQuery = `UPDATE messages SET sender_client_id = $1 WHERE sender_client_id = $2`
+	reassignDealsQuery        = `UPDATE deals SET client_id = $1 WHERE client_id = $2`
+	// Payments and orders both clients have are kept once, the duplicate's rows go with it
+	reassignActivitiesQuery = `
+		UPDATE client_activities a SET client_id = $1
+		WHERE a.client_id = $2 AND (a.ref_id IS NULL OR NOT EXISTS (
+			SELECT 1 FROM client_activities s WHERE s.client_id = $1 AND s.kind = a.kind AND s.ref_id = a.ref_id
+		))`
+	// A campaign both clients were sent keeps the survivor's recipient, the duplicate's goes with it
+	reassignCampaignRecipientsQuery = `
+		UPDATE campaign_recipients r SET client_id = $1
+		WHERE r.client_id = $2 AND NOT EXISTS (
+			SELECT 1 FROM campaign_recipients s WHERE s.client_id = $1 AND s.campaign_id = r.campaign_id
+		)`
+	mergeClientCounterpartiesQuery = `
+		INSERT INTO client_counterparties (client_id, counterparty_id)
+		SELECT $1, counterparty_id FROM client_counterparties WHERE client_id = $2
+		ON CONFLICT DO NOTHING`
+	mergeClientTagsQuery = `
+		INSERT INTO client_tags (client_id, tag)
+		SELECT $1, tag FROM client_tags WHERE client_id = $2
+		ON CONFLICT DO NOTHING`
+	mergeClientFieldValuesQuery = `
+		INSERT INTO client_custom_field_values (client_id, field_id, value)
+		SELECT $1, field_id, value FROM client_custom_field_values WHERE client_id = $2
+		ON CONFLICT DO NOTHING`
+	// The surviving client keeps its own details and takes the ones it lacks from the duplicate.
+	fillMergedClientQuery = `
+		UPDATE clients s SET
+			last_name = COALESCE(NULLIF(s.last_name, ''), d.last_name),
+			middle_name = COALESCE(NULLIF(s.middle_name, ''), d.middle_name),
+			email = COALESCE(NULLIF(s.email, ''), d.email),
+			address = COALESCE(NULLIF(s.address, ''), d.address),
+			hourly_rate = COALESCE(s.hourly_rate, d.hourly_rate),
+			date_of_birth = COALESCE(s.date_of_birth, d.date_of_birth),
+			gender = COALESCE(NULLIF(s.gender, ''), d.gender),
+			created_at = LEAST(s.created_at, d.created_at),
+			updated_at = current_timestamp
+		FROM clients d
+		WHERE s.id = $1 AND d.id = $2`
 )
 
+// phoneSuffixDigits is how many trailing digits of the phone numbers are compared when looking for
+// duplicate candidates, so numbers with and without the country code are found.
+const phoneSuffixDigits = 7
+
 type ClientRepository struct {
 }
 
@@ -62,8 +147,7 @@ func (g *ClientRepository) queryClients(ctx context.Context, query string, args
 	}
 	defer rows.Close()
 
-	clients := make([]client.Client, 0)
-
+	dbRows := make([]*models.Client, 0)
 	for rows.Next() {
 		var c models.Client
 		if err := rows.Scan(
@@ -72,27 +156,124 @@ func (g *ClientRepository) queryClients(ctx context.Context, query string, args
 			&c.LastName,
 			&c.MiddleName,
 			&c.PhoneNumber,
+			&c.Email,
+			&c.Address,
+			&c.DateOfBirth,
+			&c.Gender,
+			&c.HourlyRate,
 			&c.CreatedAt,
 			&c.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
-		entity, err := toDomainClient(&c)
+		dbRows = append(dbRows, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(dbRows))
+	for _, c := range dbRows {
+		ids = append(ids, int64(c.ID))
+	}
+	tags, err := g.queryTags(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	values, err := g.queryCustomFieldValues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]client.Client, 0, len(dbRows))
+	for _, c := range dbRows {
+		entity, err := toDomainClient(c, tags[c.ID], values[c.ID])
 		if err != nil {
 			return nil, err
 		}
 		clients = append(clients, entity)
 	}
+	return clients, nil
+}
 
-	if err := rows.Err(); err != nil {
+func (g *ClientRepository) queryTags(ctx context.Context, clientIDs []int64) (map[uint][]string, error) {
+	tags := make(map[uint][]string, len(clientIDs))
+	if len(clientIDs) == 0 {
+		return tags, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
 		return nil, err
 	}
-	return clients, nil
+	rows, err := tx.Query(ctx, selectClientTagsQuery, clientIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var clientID uint
+		var tag string
+		if err := rows.Scan(&clientID, &tag); err != nil {
+			return nil, err
+		}
+		tags[clientID] = append(tags[clientID], tag)
+	}
+	return tags, rows.Err()
 }
 
-func (g *ClientRepository) GetPaginated(
-	ctx context.Context, params *client.FindParams,
-) ([]client.Client, error) {
+func (g *ClientRepository) queryCustomFieldValues(ctx context.Context, clientIDs []int64) (map[uint]map[uint]string, error) {
+	values := make(map[uint]map[uint]string, len(clientIDs))
+	if len(clientIDs) == 0 {
+		return values, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectClientFieldValuesQuery, clientIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var clientID, fieldID uint
+		var value string
+		if err := rows.Scan(&clientID, &fieldID, &value); err != nil {
+			return nil, err
+		}
+		if values[clientID] == nil {
+			values[clientID] = map[uint]string{}
+		}
+		values[clientID][fieldID] = value
+	}
+	return values, rows.Err()
+}
+
+// saveTagsAndFields replaces the tags and the custom field values of the client.
+func (g *ClientRepository) saveTagsAndFields(ctx context.Context, data client.Client, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, deleteClientTagsQuery, id); err != nil {
+		return err
+	}
+	for _, tag := range data.Tags() {
+		if _, err := tx.Exec(ctx, insertClientTagQuery, id, tag); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, deleteClientFieldValuesQuery, id); err != nil {
+		return err
+	}
+	for fieldID, value := range data.CustomFields() {
+		if _, err := tx.Exec(ctx, insertClientFieldValueQuery, id, fieldID, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildClientFilters(params *client.FindParams) ([]string, []interface{}, error) {
 	where, args := []string{"1 = 1"}, []interface{}{}
 	if params.CreatedAt.To != "" && params.CreatedAt.From != "" {
 		where, args = append(where, fmt.Sprintf("c.created_at BETWEEN $%d and $%d", len(args)+1, len(args)+2)), append(args, params.CreatedAt.From, params.CreatedAt.To)
@@ -100,6 +281,23 @@ func (g *ClientRepository) GetPaginated(
 	if params.Query != "" && params.Field != "" {
 		where, args = append(where, fmt.Sprintf("c.%s::VARCHAR ILIKE $%d", params.Field, len(args)+1)), append(args, "%"+params.Query+"%")
 	}
+	if params.Filter != nil {
+		condition, filterArgs, err := segmentFilterSQL(params.Filter, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		where, args = append(where, condition), filterArgs
+	}
+	return where, args, nil
+}
+
+func (g *ClientRepository) GetPaginated(
+	ctx context.Context, params *client.FindParams,
+) ([]client.Client, error) {
+	where, args, err := buildClientFilters(params)
+	if err != nil {
+		return nil, err
+	}
 	sortFields := make([]string, 0, len(params.SortBy.Fields))
 	for _, f := range params.SortBy.Fields {
 		switch f {
@@ -130,13 +328,17 @@ func (g *ClientRepository) GetPaginated(
 	)
 }
 
-func (g *ClientRepository) Count(ctx context.Context) (int64, error) {
+func (g *ClientRepository) Count(ctx context.Context, params *client.FindParams) (int64, error) {
 	pool, err := composables.UseTx(ctx)
 	if err != nil {
 		return 0, err
 	}
+	where, args, err := buildClientFilters(params)
+	if err != nil {
+		return 0, err
+	}
 	var count int64
-	if err := pool.QueryRow(ctx, countClientQuery).Scan(&count); err != nil {
+	if err := pool.QueryRow(ctx, repo.Join(countClientQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -158,7 +360,22 @@ func (g *ClientRepository) GetByID(ctx context.Context, id uint) (client.Client,
 }
 
 func (g *ClientRepository) GetByPhone(ctx context.Context, phoneNumber string) (client.Client, error) {
-	clients, err := g.queryClients(ctx, selectClientQuery+" WHERE c.phone_number = $1", phoneNumber)
+	clients, err := g.queryClients(
+		ctx,
+		selectClientQuery+` WHERE regexp_replace(c.phone_number, '\D', '', 'g') = $1 ORDER BY c.id`,
+		client.NormalizePhone(phoneNumber),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		return nil, ErrClientNotFound
+	}
+	return clients[0], nil
+}
+
+func (g *ClientRepository) GetByEmail(ctx context.Context, email string) (client.Client, error) {
+	clients, err := g.queryClients(ctx, selectClientQuery+" WHERE lower(c.email) = lower($1)", email)
 	if err != nil {
 		return nil, err
 	}
@@ -181,9 +398,17 @@ func (g *ClientRepository) Create(ctx context.Context, data client.Client) (clie
 		dbRow.LastName,
 		dbRow.MiddleName,
 		dbRow.PhoneNumber,
+		dbRow.Email,
+		dbRow.Address,
+		dbRow.DateOfBirth,
+		dbRow.Gender,
+		dbRow.HourlyRate,
 	).Scan(&dbRow.ID); err != nil {
 		return nil, err
 	}
+	if err := g.saveTagsAndFields(ctx, data, dbRow.ID); err != nil {
+		return nil, err
+	}
 	return g.GetByID(ctx, dbRow.ID)
 }
 
@@ -200,10 +425,18 @@ func (g *ClientRepository) Update(ctx context.Context, data client.Client) (clie
 		dbRow.LastName,
 		dbRow.MiddleName,
 		dbRow.PhoneNumber,
+		dbRow.Email,
+		dbRow.Address,
+		dbRow.DateOfBirth,
+		dbRow.Gender,
+		dbRow.HourlyRate,
 		data.ID(),
 	); err != nil {
 		return nil, err
 	}
+	if err := g.saveTagsAndFields(ctx, data, data.ID()); err != nil {
+		return nil, err
+	}
 	return g.GetByID(ctx, data.ID())
 }
 
@@ -223,3 +456,78 @@ func (g *ClientRepository) Delete(ctx context.Context, id uint) error {
 	}
 	return nil
 }
+
+func (g *ClientRepository) FindDuplicateCandidates(ctx context.Context, data client.Client) ([]client.Client, error) {
+	digits := client.NormalizePhone(data.Phone().Value())
+	if len(digits) > phoneSuffixDigits {
+		digits = digits[len(digits)-phoneSuffixDigits:]
+	}
+	return g.queryClients(
+		ctx,
+		duplicateCandidatesQuery,
+		data.ID(),
+		phoneSuffixDigits,
+		digits,
+		data.Email(),
+		data.LastName(),
+		data.FirstName(),
+	)
+}
+
+func (g *ClientRepository) Merge(ctx context.Context, survivorID, duplicateID uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	var chatID uint
+	err = tx.QueryRow(ctx, selectClientChatQuery, survivorID).Scan(&chatID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		if _, err := tx.Exec(ctx, reassignClientChatsQuery, survivorID, duplicateID); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if _, err := tx.Exec(ctx, moveChatMessagesQuery, chatID, duplicateID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, moveChatContactsQuery, chatID, duplicateID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, touchMergedChatQuery, chatID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, deleteClientChatsQuery, duplicateID); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, reassignSenderClientQuery, survivorID, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, reassignDealsQuery, survivorID, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, reassignActivitiesQuery, survivorID, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, reassignCampaignRecipientsQuery, survivorID, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, mergeClientCounterpartiesQuery, survivorID, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, mergeClientTagsQuery, survivorID, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, mergeClientFieldValuesQuery, survivorID, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, fillMergedClientQuery, survivorID, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, deleteClientQuery, duplicateID); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package persistence_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/phone"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/money"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/campaign"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	messagetemplate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+)
+
+func createClient(t *testing.T, f *testFixtures, firstName, number string, tags ...string) client.Client {
+	t.Helper()
+	p, err := phone.NewFromE164(number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity, err := client.New(firstName, "Doe", "", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := persistence.NewClientRepository().Create(f.ctx, entity.SetTags(tags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created
+}
+
+func TestClientRepository_Merge(t *testing.T) {
+	t.Parallel()
+	f := setupTest(t)
+
+	clientRepository := persistence.NewClientRepository()
+	dealRepository := persistence.NewDealRepository()
+	activityRepository := persistence.NewActivityRepository()
+	campaignRepository := persistence.NewCampaignRepository()
+
+	owner, err := corepersistence.NewUserRepository().Create(f.ctx, user.New(
+		"John",
+		"Doe",
+		"",
+		"",
+		"test@gmail.com",
+		nil,
+		0,
+		user.UILanguageEN,
+		nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := corepersistence.NewCurrencyRepository().Create(f.ctx, &currency.USD); err != nil {
+		t.Fatal(err)
+	}
+	salesPipeline, err := pipeline.New("Sales", []pipeline.Stage{
+		{Name: "New", Position: 0, Kind: pipeline.Open},
+		{Name: "Won", Position: 1, Kind: pipeline.Won},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	salesPipeline, err = persistence.NewPipelineRepository().Create(f.ctx, salesPipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := persistence.NewMessageTemplateRepository().Create(
+		f.ctx, messagetemplate.New("Greeting", "Hello, {{.FirstName}}"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	survivor := createClient(t, f, "John", "+998901234567", "vip")
+	duplicate := createClient(t, f, "Jon", "+998907654321", "vip", "wholesale")
+
+	createdDeal, err := dealRepository.Create(f.ctx, deal.New(
+		"Order", duplicate.ID(), owner.ID(), salesPipeline.Stages()[0], money.New(100, currency.UsdCode), nil, owner.ID(),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// both clients have the same payment, the duplicate also has a note
+	for _, a := range []activity.Activity{
+		activity.NewPayment(survivor.ID(), 1, 100, string(currency.UsdCode), "", time.Now()),
+		activity.NewPayment(duplicate.ID(), 1, 100, string(currency.UsdCode), "", time.Now()),
+		activity.NewNote(duplicate.ID(), owner.ID(), "Called back", time.Now()),
+	} {
+		if _, err := activityRepository.Create(f.ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// both clients were sent the first campaign, only the duplicate the second one
+	campaigns := make([]campaign.Campaign, 0, 2)
+	for _, name := range []string{"Spring", "Summer"} {
+		entity, err := campaign.New(name, template.ID(), 0, chat.SMS, "en", 60)
+		if err != nil {
+			t.Fatal(err)
+		}
+		created, err := campaignRepository.Create(f.ctx, entity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		campaigns = append(campaigns, created)
+	}
+	if err := campaignRepository.AddRecipients(f.ctx, []campaign.Recipient{
+		campaign.NewRecipient(campaigns[0].ID(), survivor.ID(), "+998901234567", time.Now()),
+		campaign.NewRecipient(campaigns[0].ID(), duplicate.ID(), "+998907654321", time.Now()),
+		campaign.NewRecipient(campaigns[1].ID(), duplicate.ID(), "+998907654321", time.Now()),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := clientRepository.Merge(f.ctx, survivor.ID(), duplicate.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("DuplicateDeleted", func(t *testing.T) {
+		if _, err := clientRepository.GetByID(f.ctx, duplicate.ID()); !errors.Is(err, persistence.ErrClientNotFound) {
+			t.Fatalf("expected %v, got %v", persistence.ErrClientNotFound, err)
+		}
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		merged, err := clientRepository.GetByID(f.ctx, survivor.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(merged.Tags()) != 2 {
+			t.Fatalf("expected the tags of both clients, got %v", merged.Tags())
+		}
+	})
+
+	t.Run("Deals", func(t *testing.T) {
+		merged, err := dealRepository.GetByID(f.ctx, createdDeal.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if merged.ClientID() != survivor.ID() {
+			t.Fatalf("expected client %d, got %d", survivor.ID(), merged.ClientID())
+		}
+	})
+
+	t.Run("Activities", func(t *testing.T) {
+		ids, err := activityRepository.ClientIDsByRef(f.ctx, activity.Payment, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 1 || ids[0] != survivor.ID() {
+			t.Fatalf("expected the payment once for client %d, got %v", survivor.ID(), ids)
+		}
+		entries, err := activityRepository.Timeline(f.ctx, &activity.TimelineParams{
+			ClientID: survivor.ID(),
+			Kinds:    []activity.Kind{activity.Note},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("expected the note of the duplicate, got %d entries", len(entries))
+		}
+	})
+
+	t.Run("CampaignRecipients", func(t *testing.T) {
+		for _, c := range campaigns {
+			recipients, err := campaignRepository.GetRecipients(f.ctx, &campaign.RecipientFindParams{CampaignID: c.ID()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(recipients) != 1 || recipients[0].ClientID() != survivor.ID() {
+				t.Fatalf("expected one recipient of campaign %s for client %d, got %d", c.Name(), survivor.ID(), len(recipients))
+			}
+		}
+	})
+}
@@ -2,19 +2,29 @@ package persistence
 
 import (
 	"database/sql"
+	"time"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/phone"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/money"
 	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	coremodels "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/campaign"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 )
 
-func toDomainClient(dbRow *models.Client) (client.Client, error) {
+func toDomainClient(dbRow *models.Client, tags []string, customFields map[uint]string) (client.Client, error) {
 	p, err := phone.NewFromE164(dbRow.PhoneNumber)
 	if err != nil {
 		return nil, err
@@ -25,6 +35,15 @@ func toDomainClient(dbRow *models.Client) (client.Client, error) {
 		dbRow.LastName.String,
 		dbRow.MiddleName.String,
 		p,
+		client.Details{
+			Email:       dbRow.Email.String,
+			Address:     dbRow.Address.String,
+			DateOfBirth: mapping.SQLNullTimeToPointer(dbRow.DateOfBirth),
+			Gender:      client.Gender(dbRow.Gender.String),
+			HourlyRate:  dbRow.HourlyRate.Float64,
+		},
+		tags,
+		customFields,
 		dbRow.CreatedAt,
 		dbRow.UpdatedAt,
 	)
@@ -37,8 +56,68 @@ func toDBClient(domainEntity client.Client) *models.Client {
 		LastName:    mapping.ValueToSQLNullString(domainEntity.LastName()),
 		MiddleName:  mapping.ValueToSQLNullString(domainEntity.MiddleName()),
 		PhoneNumber: domainEntity.Phone().Value(),
-		CreatedAt:   domainEntity.CreatedAt(),
-		UpdatedAt:   domainEntity.UpdatedAt(),
+		Email:       mapping.ValueToSQLNullString(domainEntity.Email()),
+		Address:     mapping.ValueToSQLNullString(domainEntity.Address()),
+		DateOfBirth: mapping.PointerToSQLNullTime(domainEntity.DateOfBirth()),
+		Gender:      mapping.ValueToSQLNullString(string(domainEntity.Gender())),
+		HourlyRate: sql.NullFloat64{
+			Float64: domainEntity.HourlyRate(),
+			Valid:   domainEntity.HourlyRate() != 0,
+		},
+		CreatedAt: domainEntity.CreatedAt(),
+		UpdatedAt: domainEntity.UpdatedAt(),
+	}
+}
+
+func toDomainCustomField(dbRow *models.ClientCustomField) (customfield.Field, error) {
+	return customfield.NewWithID(
+		dbRow.ID,
+		dbRow.Key,
+		dbRow.Label,
+		customfield.Type(dbRow.Type),
+		dbRow.Options,
+		dbRow.Required,
+		dbRow.Position,
+		dbRow.CreatedAt,
+	)
+}
+
+func toDBCustomField(entity customfield.Field) *models.ClientCustomField {
+	options := entity.Options()
+	if options == nil {
+		options = []string{}
+	}
+	return &models.ClientCustomField{
+		ID:        entity.ID(),
+		Key:       entity.Key(),
+		Label:     entity.Label(),
+		Type:      string(entity.Type()),
+		Options:   options,
+		Required:  entity.Required(),
+		Position:  entity.Position(),
+		CreatedAt: entity.CreatedAt(),
+	}
+}
+
+func toDomainSegment(dbRow *models.ClientSegment) (segment.Segment, error) {
+	return segment.NewWithID(
+		dbRow.ID,
+		dbRow.Name,
+		dbRow.Description.String,
+		dbRow.Expression,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	)
+}
+
+func toDBSegment(entity segment.Segment) *models.ClientSegment {
+	return &models.ClientSegment{
+		ID:          entity.ID(),
+		Name:        entity.Name(),
+		Description: mapping.ValueToSQLNullString(entity.Description()),
+		Expression:  entity.Expression(),
+		CreatedAt:   entity.CreatedAt(),
+		UpdatedAt:   entity.UpdatedAt(),
 	}
 }
 
@@ -47,6 +126,7 @@ func toDBMessage(entity chat.Message, chatID uint) *models.Message {
 		ID:      entity.ID(),
 		Message: entity.Message(),
 		ChatID:  chatID,
+		Channel: string(entity.Channel()),
 		SenderUserID: sql.NullInt64{
 			Int64: 0,
 			Valid: false,
@@ -78,6 +158,7 @@ func toDomainMessage(
 	}
 	return chat.NewMessageWithID(
 		dbRow.ID,
+		chat.Channel(dbRow.Channel),
 		dbRow.Message,
 		sender,
 		dbRow.IsRead,
@@ -86,42 +167,373 @@ func toDomainMessage(
 	), nil
 }
 
-func toDBChat(domainEntity chat.Chat) (*models.Chat, []*models.Message) {
+func toDBChat(domainEntity chat.Chat) (*models.Chat, []*models.Message, []*models.ChatContact) {
 	dbMessages := make([]*models.Message, 0, len(domainEntity.Messages()))
 	for _, m := range domainEntity.Messages() {
 		dbMessages = append(dbMessages, toDBMessage(m, domainEntity.ID()))
 	}
+	dbContacts := make([]*models.ChatContact, 0, len(domainEntity.Contacts()))
+	for _, c := range domainEntity.Contacts() {
+		dbContacts = append(dbContacts, &models.ChatContact{
+			ChatID:  domainEntity.ID(),
+			Channel: string(c.Channel),
+			Address: c.Address,
+		})
+	}
+	assignment, cycle := domainEntity.Assignment(), domainEntity.Cycle()
 	return &models.Chat{
-		ID:            domainEntity.ID(),
-		ClientID:      domainEntity.ClientID(),
-		CreatedAt:     domainEntity.CreatedAt(),
-		LastMessageAt: mapping.PointerToSQLNullTime(domainEntity.LastMessageAt()),
-	}, dbMessages
+		ID:                    domainEntity.ID(),
+		ClientID:              domainEntity.ClientID(),
+		CreatedAt:             domainEntity.CreatedAt(),
+		LastMessageAt:         mapping.PointerToSQLNullTime(domainEntity.LastMessageAt()),
+		Status:                string(domainEntity.Status()),
+		AssigneeID:            mapping.ValueToSQLNullInt64(int64(assignment.UserID)),
+		TeamID:                mapping.ValueToSQLNullInt64(int64(assignment.TeamID)),
+		AssignedAt:            mapping.PointerToSQLNullTime(assignment.AssignedAt),
+		OpenedAt:              mapping.PointerToSQLNullTime(cycle.OpenedAt),
+		FirstResponseAt:       mapping.PointerToSQLNullTime(cycle.FirstResponseAt),
+		ClosedAt:              mapping.PointerToSQLNullTime(cycle.ClosedAt),
+		FirstResponseBreached: cycle.FirstResponseBreached,
+		ResolutionBreached:    cycle.ResolutionBreached,
+	}, dbMessages, dbContacts
 }
 
-func toDomainChat(dbRow *models.Chat, messages []chat.Message) (chat.Chat, error) {
+func toDomainChatContact(dbRow *models.ChatContact) chat.Contact {
+	return chat.Contact{
+		Channel: chat.Channel(dbRow.Channel),
+		Address: dbRow.Address,
+	}
+}
+
+func toDomainChat(dbRow *models.Chat, messages []chat.Message, contacts []chat.Contact) (chat.Chat, error) {
+	status, err := chat.NewStatus(dbRow.Status)
+	if err != nil {
+		return nil, err
+	}
 	domainChat := chat.NewWithID(
 		dbRow.ID,
 		dbRow.ClientID,
 		dbRow.CreatedAt,
 		messages,
+		contacts,
 		mapping.SQLNullTimeToPointer(dbRow.LastMessageAt),
+		status,
+		chat.Assignment{
+			UserID:     uint(dbRow.AssigneeID.Int64),
+			TeamID:     uint(dbRow.TeamID.Int64),
+			AssignedAt: mapping.SQLNullTimeToPointer(dbRow.AssignedAt),
+		},
+		chat.Cycle{
+			OpenedAt:              mapping.SQLNullTimeToPointer(dbRow.OpenedAt),
+			FirstResponseAt:       mapping.SQLNullTimeToPointer(dbRow.FirstResponseAt),
+			ClosedAt:              mapping.SQLNullTimeToPointer(dbRow.ClosedAt),
+			FirstResponseBreached: dbRow.FirstResponseBreached,
+			ResolutionBreached:    dbRow.ResolutionBreached,
+		},
 	)
 	return domainChat, nil
 }
 
-func toDomainMessageTemplate(dbTemplate *models.MessageTemplate) (messagetemplate.MessageTemplate, error) {
+func toDomainTeam(dbRow *models.Team, memberIDs []uint) team.Team {
+	return team.NewWithID(
+		dbRow.ID,
+		dbRow.Name,
+		memberIDs,
+		dbRow.AutoAssign,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	)
+}
+
+func toDBTeam(domainEntity team.Team) *models.Team {
+	return &models.Team{
+		ID:         domainEntity.ID(),
+		Name:       domainEntity.Name(),
+		AutoAssign: domainEntity.AutoAssign(),
+		CreatedAt:  domainEntity.CreatedAt(),
+		UpdatedAt:  domainEntity.UpdatedAt(),
+	}
+}
+
+func toDomainMessageTemplateVariant(dbRow *models.MessageTemplateVariant) messagetemplate.Variant {
+	return messagetemplate.Variant{
+		Locale:     dbRow.Locale,
+		Channel:    chat.Channel(dbRow.Channel),
+		Body:       dbRow.Body,
+		ExternalID: dbRow.ExternalID.String,
+	}
+}
+
+func toDomainMessageTemplate(dbTemplate *models.MessageTemplate, variants []messagetemplate.Variant) messagetemplate.MessageTemplate {
 	return messagetemplate.NewWithID(
 		dbTemplate.ID,
+		dbTemplate.Name,
 		dbTemplate.Template,
+		variants,
 		dbTemplate.CreatedAt,
-	), nil
+	)
 }
 
-func toDBMessageTemplate(domainTemplate messagetemplate.MessageTemplate) *models.MessageTemplate {
+func toDBMessageTemplate(domainTemplate messagetemplate.MessageTemplate) (*models.MessageTemplate, []*models.MessageTemplateVariant) {
+	dbVariants := make([]*models.MessageTemplateVariant, 0, len(domainTemplate.Variants()))
+	for _, v := range domainTemplate.Variants() {
+		dbVariants = append(dbVariants, &models.MessageTemplateVariant{
+			TemplateID: domainTemplate.ID(),
+			Locale:     v.Locale,
+			Channel:    string(v.Channel),
+			Body:       v.Body,
+			ExternalID: mapping.ValueToSQLNullString(v.ExternalID),
+		})
+	}
 	return &models.MessageTemplate{
 		ID:        domainTemplate.ID(),
+		Name:      domainTemplate.Name(),
 		Template:  domainTemplate.Template(),
 		CreatedAt: domainTemplate.CreatedAt(),
+	}, dbVariants
+}
+
+func toDomainDealStage(dbRow *models.DealStage) pipeline.Stage {
+	return pipeline.Stage{
+		ID:         dbRow.ID,
+		PipelineID: dbRow.PipelineID,
+		Name:       dbRow.Name,
+		Position:   dbRow.Position,
+		Kind:       pipeline.StageKind(dbRow.Kind),
+	}
+}
+
+func toDomainPipeline(dbPipeline *models.DealPipeline, stages []pipeline.Stage) (pipeline.Pipeline, error) {
+	return pipeline.NewWithID(
+		dbPipeline.ID,
+		dbPipeline.Name,
+		stages,
+		dbPipeline.CreatedAt,
+	)
+}
+
+func toDBPipeline(domainPipeline pipeline.Pipeline) (*models.DealPipeline, []*models.DealStage) {
+	dbStages := make([]*models.DealStage, 0, len(domainPipeline.Stages()))
+	for _, s := range domainPipeline.Stages() {
+		dbStages = append(dbStages, &models.DealStage{
+			ID:         s.ID,
+			PipelineID: domainPipeline.ID(),
+			Name:       s.Name,
+			Position:   s.Position,
+			Kind:       string(s.Kind),
+		})
+	}
+	return &models.DealPipeline{
+		ID:        domainPipeline.ID(),
+		Name:      domainPipeline.Name(),
+		CreatedAt: domainPipeline.CreatedAt(),
+	}, dbStages
+}
+
+func toDomainDealStageChange(dbRow *models.DealStageChange) deal.StageChange {
+	return deal.StageChange{
+		ID:          dbRow.ID,
+		FromStageID: uint(dbRow.FromStageID.Int64),
+		ToStageID:   dbRow.ToStageID,
+		ChangedBy:   uint(dbRow.ChangedBy.Int64),
+		ChangedAt:   dbRow.ChangedAt,
+	}
+}
+
+func toDBDealStageChange(dealID uint, change deal.StageChange) *models.DealStageChange {
+	return &models.DealStageChange{
+		ID:          change.ID,
+		DealID:      dealID,
+		FromStageID: mapping.ValueToSQLNullInt64(int64(change.FromStageID)),
+		ToStageID:   change.ToStageID,
+		ChangedBy:   mapping.ValueToSQLNullInt64(int64(change.ChangedBy)),
+		ChangedAt:   change.ChangedAt,
+	}
+}
+
+func toDomainDeal(dbRow *models.Deal, history []deal.StageChange) (deal.Deal, error) {
+	status, err := deal.NewStatus(dbRow.Status)
+	if err != nil {
+		return nil, err
+	}
+	code, err := currency.NewCode(dbRow.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+	var conversion *deal.Conversion
+	if dbRow.ConvertedTo.Valid {
+		target, err := deal.NewConversionTarget(dbRow.ConvertedTo.String)
+		if err != nil {
+			return nil, err
+		}
+		conversion = &deal.Conversion{Target: target, ConvertedAt: dbRow.ConvertedAt.Time}
+	}
+	return deal.NewWithID(
+		dbRow.ID,
+		dbRow.Title,
+		dbRow.ClientID,
+		dbRow.OwnerID,
+		dbRow.PipelineID,
+		dbRow.StageID,
+		status,
+		money.New(dbRow.Value, code),
+		mapping.SQLNullTimeToPointer(dbRow.ExpectedCloseDate),
+		mapping.SQLNullTimeToPointer(dbRow.ClosedAt),
+		conversion,
+		history,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	), nil
+}
+
+func toDBDeal(domainDeal deal.Deal) (*models.Deal, []*models.DealStageChange) {
+	dbHistory := make([]*models.DealStageChange, 0, len(domainDeal.History()))
+	for _, change := range domainDeal.History() {
+		dbHistory = append(dbHistory, toDBDealStageChange(domainDeal.ID(), change))
+	}
+	dbDeal := &models.Deal{
+		ID:                domainDeal.ID(),
+		Title:             domainDeal.Title(),
+		ClientID:          domainDeal.ClientID(),
+		OwnerID:           domainDeal.OwnerID(),
+		PipelineID:        domainDeal.PipelineID(),
+		StageID:           domainDeal.StageID(),
+		Status:            string(domainDeal.Status()),
+		Value:             domainDeal.Value().Value(),
+		CurrencyID:        string(domainDeal.Value().Currency()),
+		ExpectedCloseDate: mapping.PointerToSQLNullTime(domainDeal.ExpectedCloseDate()),
+		ClosedAt:          mapping.PointerToSQLNullTime(domainDeal.ClosedAt()),
+		CreatedAt:         domainDeal.CreatedAt(),
+		UpdatedAt:         domainDeal.UpdatedAt(),
+	}
+	if c := domainDeal.Conversion(); c != nil {
+		dbDeal.ConvertedTo = mapping.ValueToSQLNullString(string(c.Target))
+		dbDeal.ConvertedAt = mapping.ValueToSQLNullTime(c.ConvertedAt)
+	}
+	return dbDeal, dbHistory
+}
+
+func toDomainCampaign(dbRow *models.Campaign) (campaign.Campaign, error) {
+	channel, err := chat.NewChannel(dbRow.Channel)
+	if err != nil {
+		return nil, err
+	}
+	status := campaign.Status(dbRow.Status)
+	if !status.IsValid() {
+		return nil, campaign.ErrInvalidStatus
+	}
+	return campaign.NewWithID(
+		dbRow.ID,
+		dbRow.Name,
+		dbRow.TemplateID,
+		uint(dbRow.SegmentID.Int64),
+		channel,
+		dbRow.Locale,
+		dbRow.RatePerMinute,
+		status,
+		mapping.SQLNullTimeToPointer(dbRow.StartedAt),
+		mapping.SQLNullTimeToPointer(dbRow.CompletedAt),
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	), nil
+}
+
+func toDBCampaign(entity campaign.Campaign) *models.Campaign {
+	return &models.Campaign{
+		ID:            entity.ID(),
+		Name:          entity.Name(),
+		TemplateID:    entity.TemplateID(),
+		SegmentID:     mapping.ValueToSQLNullInt64(int64(entity.SegmentID())),
+		Channel:       string(entity.Channel()),
+		Locale:        entity.Locale(),
+		RatePerMinute: entity.RatePerMinute(),
+		Status:        string(entity.Status()),
+		StartedAt:     mapping.PointerToSQLNullTime(entity.StartedAt()),
+		CompletedAt:   mapping.PointerToSQLNullTime(entity.CompletedAt()),
+		CreatedAt:     entity.CreatedAt(),
+		UpdatedAt:     entity.UpdatedAt(),
+	}
+}
+
+func toDomainCampaignRecipient(dbRow *models.CampaignRecipient) (campaign.Recipient, error) {
+	status := campaign.RecipientStatus(dbRow.Status)
+	if !status.IsValid() {
+		return nil, campaign.ErrInvalidStatus
+	}
+	return campaign.NewRecipientWithID(
+		dbRow.ID,
+		dbRow.CampaignID,
+		dbRow.ClientID,
+		dbRow.Address,
+		status,
+		dbRow.Message.String,
+		dbRow.ExternalID.String,
+		dbRow.Attempts,
+		dbRow.NextAttemptAt,
+		dbRow.LastError.String,
+		mapping.SQLNullTimeToPointer(dbRow.SentAt),
+		mapping.SQLNullTimeToPointer(dbRow.DeliveredAt),
+		dbRow.UpdatedAt,
+	), nil
+}
+
+func toDBCampaignRecipient(entity campaign.Recipient) *models.CampaignRecipient {
+	return &models.CampaignRecipient{
+		ID:            entity.ID(),
+		CampaignID:    entity.CampaignID(),
+		ClientID:      entity.ClientID(),
+		Address:       entity.Address(),
+		Status:        string(entity.Status()),
+		Message:       mapping.ValueToSQLNullString(entity.Message()),
+		ExternalID:    mapping.ValueToSQLNullString(entity.ExternalID()),
+		Attempts:      entity.Attempts(),
+		NextAttemptAt: entity.NextAttemptAt(),
+		LastError:     mapping.ValueToSQLNullString(entity.Error()),
+		SentAt:        mapping.PointerToSQLNullTime(entity.SentAt()),
+		DeliveredAt:   mapping.PointerToSQLNullTime(entity.DeliveredAt()),
+		UpdatedAt:     entity.UpdatedAt(),
+	}
+}
+
+func toDomainActivity(dbRow *models.ClientActivity) activity.Activity {
+	return activity.NewWithID(
+		dbRow.ID,
+		dbRow.ClientID,
+		activity.Kind(dbRow.Kind),
+		uint(dbRow.RefID.Int64),
+		dbRow.Body,
+		activity.Direction(dbRow.Direction.String),
+		time.Duration(dbRow.DurationSeconds)*time.Second,
+		dbRow.Amount.Float64,
+		dbRow.Currency.String,
+		dbRow.Status.String,
+		uint(dbRow.AuthorID.Int64),
+		dbRow.OccurredAt,
+		dbRow.CreatedAt,
+	)
+}
+
+func toDBActivity(entity activity.Activity) *models.ClientActivity {
+	dbRow := &models.ClientActivity{
+		ID:              entity.ID(),
+		ClientID:        entity.ClientID(),
+		Kind:            string(entity.Kind()),
+		Body:            entity.Body(),
+		Direction:       mapping.ValueToSQLNullString(string(entity.Direction())),
+		DurationSeconds: int(entity.Duration().Seconds()),
+		Currency:        mapping.ValueToSQLNullString(entity.Currency()),
+		Status:          mapping.ValueToSQLNullString(entity.Status()),
+		OccurredAt:      entity.OccurredAt(),
+		CreatedAt:       entity.CreatedAt(),
+	}
+	if entity.RefID() != 0 {
+		dbRow.RefID = mapping.ValueToSQLNullInt64(int64(entity.RefID()))
+	}
+	if entity.AuthorID() != 0 {
+		dbRow.AuthorID = mapping.ValueToSQLNullInt64(int64(entity.AuthorID()))
+	}
+	if entity.Kind() == activity.Payment {
+		dbRow.Amount = sql.NullFloat64{Float64: entity.Amount(), Valid: true}
 	}
+	return dbRow
 }
@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+)
+
+const (
+	selectCustomFieldQuery = `
+		SELECT id, key, label, type, options, required, position, created_at
+		FROM client_custom_fields`
+	insertCustomFieldQuery = `
+		INSERT INTO client_custom_fields (key, label, type, options, required, position)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	updateCustomFieldQuery = `
+		UPDATE client_custom_fields
+		SET label = $1, type = $2, options = $3, required = $4, position = $5
+		WHERE id = $6`
+	deleteCustomFieldQuery = `DELETE FROM client_custom_fields WHERE id = $1`
+)
+
+type CustomFieldRepository struct {
+}
+
+func NewCustomFieldRepository() customfield.Repository {
+	return &CustomFieldRepository{}
+}
+
+func (g *CustomFieldRepository) queryFields(ctx context.Context, query string, args ...interface{}) ([]customfield.Field, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	fields := make([]customfield.Field, 0)
+	for rows.Next() {
+		var f models.ClientCustomField
+		if err := rows.Scan(
+			&f.ID,
+			&f.Key,
+			&f.Label,
+			&f.Type,
+			&f.Options,
+			&f.Required,
+			&f.Position,
+			&f.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainCustomField(&f)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, entity)
+	}
+	return fields, rows.Err()
+}
+
+func (g *CustomFieldRepository) GetAll(ctx context.Context) ([]customfield.Field, error) {
+	return g.queryFields(ctx, selectCustomFieldQuery+" ORDER BY position, id")
+}
+
+func (g *CustomFieldRepository) GetByID(ctx context.Context, id uint) (customfield.Field, error) {
+	fields, err := g.queryFields(ctx, selectCustomFieldQuery+" WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrCustomFieldNotFound
+	}
+	return fields[0], nil
+}
+
+func (g *CustomFieldRepository) GetByKey(ctx context.Context, key string) (customfield.Field, error) {
+	fields, err := g.queryFields(ctx, selectCustomFieldQuery+" WHERE key = $1", key)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrCustomFieldNotFound
+	}
+	return fields[0], nil
+}
+
+func (g *CustomFieldRepository) Create(ctx context.Context, data customfield.Field) (customfield.Field, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbRow := toDBCustomField(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertCustomFieldQuery,
+		dbRow.Key,
+		dbRow.Label,
+		dbRow.Type,
+		dbRow.Options,
+		dbRow.Required,
+		dbRow.Position,
+	).Scan(&dbRow.ID); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, dbRow.ID)
+}
+
+func (g *CustomFieldRepository) Update(ctx context.Context, data customfield.Field) (customfield.Field, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbRow := toDBCustomField(data)
+	if _, err := tx.Exec(
+		ctx,
+		updateCustomFieldQuery,
+		dbRow.Label,
+		dbRow.Type,
+		dbRow.Options,
+		dbRow.Required,
+		dbRow.Position,
+		dbRow.ID,
+	); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, dbRow.ID)
+}
+
+func (g *CustomFieldRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, deleteCustomFieldQuery, id)
+	return err
+}
@@ -0,0 +1,338 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrDealNotFound = errors.New("deal not found")
+)
+
+const (
+	selectDealQuery = `
+		SELECT
+			d.id,
+			d.title,
+			d.client_id,
+			d.owner_id,
+			d.pipeline_id,
+			d.stage_id,
+			d.status,
+			d.value,
+			d.currency_id,
+			d.expected_close_date,
+			d.closed_at,
+			d.converted_to,
+			d.converted_at,
+			d.created_at,
+			d.updated_at
+		FROM deals d
+	`
+
+	countDealQuery = `SELECT COUNT(*) FROM deals d`
+
+	selectDealHistoryQuery = `
+		SELECT id, deal_id, from_stage_id, to_stage_id, changed_by, changed_at
+		FROM deal_stage_history
+		WHERE deal_id = $1
+		ORDER BY changed_at, id`
+
+	insertDealQuery = `
+		INSERT INTO deals (
+			title,
+			client_id,
+			owner_id,
+			pipeline_id,
+			stage_id,
+			status,
+			value,
+			currency_id,
+			expected_close_date,
+			closed_at,
+			converted_to,
+			converted_at,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id`
+
+	updateDealQuery = `
+		UPDATE deals SET
+			title = $1,
+			client_id = $2,
+			owner_id = $3,
+			stage_id = $4,
+			status = $5,
+			value = $6,
+			currency_id = $7,
+			expected_close_date = $8,
+			closed_at = $9,
+			converted_to = $10,
+			converted_at = $11,
+			updated_at = $12
+		WHERE id = $13`
+
+	insertDealHistoryQuery = `
+		INSERT INTO deal_stage_history (deal_id, from_stage_id, to_stage_id, changed_by, changed_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	deleteDealQuery = `DELETE FROM deals WHERE id = $1`
+)
+
+type DealRepository struct {
+}
+
+func NewDealRepository() deal.Repository {
+	return &DealRepository{}
+}
+
+func (r *DealRepository) buildFilters(params *deal.FindParams) ([]string, []interface{}) {
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if params.PipelineID != 0 {
+		where, args = append(where, fmt.Sprintf("d.pipeline_id = $%d", len(args)+1)), append(args, params.PipelineID)
+	}
+	if params.StageID != 0 {
+		where, args = append(where, fmt.Sprintf("d.stage_id = $%d", len(args)+1)), append(args, params.StageID)
+	}
+	if params.ClientID != 0 {
+		where, args = append(where, fmt.Sprintf("d.client_id = $%d", len(args)+1)), append(args, params.ClientID)
+	}
+	if params.OwnerID != 0 {
+		where, args = append(where, fmt.Sprintf("d.owner_id = $%d", len(args)+1)), append(args, params.OwnerID)
+	}
+	if params.Status != "" {
+		where, args = append(where, fmt.Sprintf("d.status = $%d", len(args)+1)), append(args, params.Status)
+	}
+	if params.Search != "" {
+		where, args = append(where, fmt.Sprintf("d.title ILIKE $%d", len(args)+1)), append(args, "%"+params.Search+"%")
+	}
+	return where, args
+}
+
+func (r *DealRepository) queryDeals(ctx context.Context, query string, args ...interface{}) ([]deal.Deal, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dbDeals := make([]*models.Deal, 0)
+	for rows.Next() {
+		var d models.Deal
+		if err := rows.Scan(
+			&d.ID,
+			&d.Title,
+			&d.ClientID,
+			&d.OwnerID,
+			&d.PipelineID,
+			&d.StageID,
+			&d.Status,
+			&d.Value,
+			&d.CurrencyID,
+			&d.ExpectedCloseDate,
+			&d.ClosedAt,
+			&d.ConvertedTo,
+			&d.ConvertedAt,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbDeals = append(dbDeals, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	deals := make([]deal.Deal, 0, len(dbDeals))
+	for _, d := range dbDeals {
+		history, err := r.queryHistory(ctx, d.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get stage history of deal")
+		}
+		domainDeal, err := toDomainDeal(d, history)
+		if err != nil {
+			return nil, err
+		}
+		deals = append(deals, domainDeal)
+	}
+	return deals, nil
+}
+
+func (r *DealRepository) queryHistory(ctx context.Context, dealID uint) ([]deal.StageChange, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectDealHistoryQuery, dealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]deal.StageChange, 0)
+	for rows.Next() {
+		var c models.DealStageChange
+		if err := rows.Scan(&c.ID, &c.DealID, &c.FromStageID, &c.ToStageID, &c.ChangedBy, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, toDomainDealStageChange(&c))
+	}
+	return history, rows.Err()
+}
+
+// saveHistory inserts the history entries that were not saved yet, the saved ones are never changed.
+func (r *DealRepository) saveHistory(ctx context.Context, dealID uint, dbHistory []*models.DealStageChange) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range dbHistory {
+		if c.ID != 0 {
+			continue
+		}
+		if _, err := tx.Exec(
+			ctx,
+			insertDealHistoryQuery,
+			dealID,
+			c.FromStageID,
+			c.ToStageID,
+			c.ChangedBy,
+			c.ChangedAt,
+		); err != nil {
+			return errors.Wrap(err, "failed to save stage change")
+		}
+	}
+	return nil
+}
+
+func (r *DealRepository) Count(ctx context.Context, params *deal.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := r.buildFilters(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countDealQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *DealRepository) GetPaginated(ctx context.Context, params *deal.FindParams) ([]deal.Deal, error) {
+	where, args := r.buildFilters(params)
+	return r.queryDeals(
+		ctx,
+		repo.Join(
+			selectDealQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY d.updated_at DESC",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (r *DealRepository) GetByID(ctx context.Context, id uint) (deal.Deal, error) {
+	deals, err := r.queryDeals(ctx, selectDealQuery+` WHERE d.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(deals) == 0 {
+		return nil, ErrDealNotFound
+	}
+	return deals[0], nil
+}
+
+func (r *DealRepository) Create(ctx context.Context, data deal.Deal) (deal.Deal, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbDeal, dbHistory := toDBDeal(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertDealQuery,
+		dbDeal.Title,
+		dbDeal.ClientID,
+		dbDeal.OwnerID,
+		dbDeal.PipelineID,
+		dbDeal.StageID,
+		dbDeal.Status,
+		dbDeal.Value,
+		dbDeal.CurrencyID,
+		dbDeal.ExpectedCloseDate,
+		dbDeal.ClosedAt,
+		dbDeal.ConvertedTo,
+		dbDeal.ConvertedAt,
+		dbDeal.CreatedAt,
+		dbDeal.UpdatedAt,
+	).Scan(&dbDeal.ID); err != nil {
+		return nil, err
+	}
+	if err := r.saveHistory(ctx, dbDeal.ID, dbHistory); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, dbDeal.ID)
+}
+
+func (r *DealRepository) Update(ctx context.Context, data deal.Deal) (deal.Deal, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbDeal, dbHistory := toDBDeal(data)
+	result, err := tx.Exec(
+		ctx,
+		updateDealQuery,
+		dbDeal.Title,
+		dbDeal.ClientID,
+		dbDeal.OwnerID,
+		dbDeal.StageID,
+		dbDeal.Status,
+		dbDeal.Value,
+		dbDeal.CurrencyID,
+		dbDeal.ExpectedCloseDate,
+		dbDeal.ClosedAt,
+		dbDeal.ConvertedTo,
+		dbDeal.ConvertedAt,
+		dbDeal.UpdatedAt,
+		dbDeal.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrDealNotFound
+	}
+	if err := r.saveHistory(ctx, dbDeal.ID, dbHistory); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, dbDeal.ID)
+}
+
+func (r *DealRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(ctx, deleteDealQuery, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDealNotFound
+	}
+	return nil
+}
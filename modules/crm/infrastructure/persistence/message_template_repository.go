@@ -8,7 +8,6 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
-	"github.com/iota-uz/iota-sdk/pkg/mapping"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 )
 
@@ -20,25 +19,46 @@ const (
 	selectMessageTemplateQuery = `
 		SELECT 
 			id,
+			name,
 			template,
 			created_at
 		FROM message_templates
 	`
 
+	selectMessageTemplateVariantsQuery = `
+		SELECT id, template_id, locale, channel, body, external_id
+		FROM message_template_variants
+		WHERE template_id = $1
+		ORDER BY id`
+
 	countMessageTemplateQuery = `SELECT COUNT(*) as count FROM message_templates`
 
 	insertMessageTemplateQuery = `
 		INSERT INTO message_templates (
+			name,
 			template,
 			created_at
-		) VALUES ($1, $2) RETURNING id`
+		) VALUES ($1, $2, $3) RETURNING id`
 
 	updateMessageTemplateQuery = `
 		UPDATE message_templates 
-		SET template = $1 
-		WHERE id = $2`
+		SET name = $1, template = $2 
+		WHERE id = $3`
+
+	deleteMessageTemplateVariantsQuery = `DELETE FROM message_template_variants WHERE template_id = $1`
+
+	insertMessageTemplateVariantQuery = `
+		INSERT INTO message_template_variants (
+			template_id,
+			locale,
+			channel,
+			body,
+			external_id
+		) VALUES ($1, $2, $3, $4, $5)`
 
 	deleteMessageTemplateQuery = `DELETE FROM message_templates WHERE id = $1`
+
+	countMessageTemplateCampaignsQuery = `SELECT COUNT(*) FROM campaigns WHERE template_id = $1`
 )
 
 type MessageTemplateRepository struct {
@@ -68,6 +88,7 @@ func (r *MessageTemplateRepository) queryMessageTemplates(
 		var t models.MessageTemplate
 		if err := rows.Scan(
 			&t.ID,
+			&t.Name,
 			&t.Template,
 			&t.CreatedAt,
 		); err != nil {
@@ -80,7 +101,69 @@ func (r *MessageTemplateRepository) queryMessageTemplates(
 		return nil, err
 	}
 
-	return mapping.MapDBModels(dbTemplates, toDomainMessageTemplate)
+	templates := make([]messagetemplate.MessageTemplate, 0, len(dbTemplates))
+	for _, t := range dbTemplates {
+		variants, err := r.queryVariants(ctx, t.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get variants for message template")
+		}
+		templates = append(templates, toDomainMessageTemplate(t, variants))
+	}
+	return templates, nil
+}
+
+func (r *MessageTemplateRepository) queryVariants(ctx context.Context, templateID uint) ([]messagetemplate.Variant, error) {
+	pool, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := pool.Query(ctx, selectMessageTemplateVariantsQuery, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := make([]messagetemplate.Variant, 0)
+	for rows.Next() {
+		var v models.MessageTemplateVariant
+		if err := rows.Scan(
+			&v.ID,
+			&v.TemplateID,
+			&v.Locale,
+			&v.Channel,
+			&v.Body,
+			&v.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		variants = append(variants, toDomainMessageTemplateVariant(&v))
+	}
+	return variants, rows.Err()
+}
+
+// saveVariants replaces the variants of a template with the given ones.
+func (r *MessageTemplateRepository) saveVariants(ctx context.Context, templateID uint, dbVariants []*models.MessageTemplateVariant) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, deleteMessageTemplateVariantsQuery, templateID); err != nil {
+		return err
+	}
+	for _, v := range dbVariants {
+		if _, err := tx.Exec(
+			ctx,
+			insertMessageTemplateVariantQuery,
+			templateID,
+			v.Locale,
+			v.Channel,
+			v.Body,
+			v.ExternalID,
+		); err != nil {
+			return errors.Wrapf(err, "failed to save %s/%s variant", v.Locale, v.Channel)
+		}
+	}
+	return nil
 }
 
 func (r *MessageTemplateRepository) GetPaginated(
@@ -90,7 +173,7 @@ func (r *MessageTemplateRepository) GetPaginated(
 	return r.queryMessageTemplates(
 		ctx,
 		repo.Join(
-			selectClientQuery,
+			selectMessageTemplateQuery,
 			repo.FormatLimitOffset(params.Limit, params.Offset),
 		),
 	)
@@ -131,15 +214,19 @@ func (r *MessageTemplateRepository) Create(ctx context.Context, data messagetemp
 		return nil, err
 	}
 
-	dbTemplate := toDBMessageTemplate(data)
+	dbTemplate, dbVariants := toDBMessageTemplate(data)
 	if err := tx.QueryRow(
 		ctx,
 		insertMessageTemplateQuery,
+		dbTemplate.Name,
 		dbTemplate.Template,
 		dbTemplate.CreatedAt,
 	).Scan(&dbTemplate.ID); err != nil {
 		return nil, err
 	}
+	if err := r.saveVariants(ctx, dbTemplate.ID, dbVariants); err != nil {
+		return nil, err
+	}
 
 	return r.GetByID(ctx, dbTemplate.ID)
 }
@@ -153,10 +240,11 @@ func (r *MessageTemplateRepository) Update(
 		return nil, err
 	}
 
-	dbTemplate := toDBMessageTemplate(data)
+	dbTemplate, dbVariants := toDBMessageTemplate(data)
 	result, err := tx.Exec(
 		ctx,
 		updateMessageTemplateQuery,
+		dbTemplate.Name,
 		dbTemplate.Template,
 		dbTemplate.ID,
 	)
@@ -167,6 +255,9 @@ func (r *MessageTemplateRepository) Update(
 	if result.RowsAffected() == 0 {
 		return nil, ErrMessageTemplateNotFound
 	}
+	if err := r.saveVariants(ctx, dbTemplate.ID, dbVariants); err != nil {
+		return nil, err
+	}
 
 	return r.GetByID(ctx, dbTemplate.ID)
 }
@@ -177,6 +268,14 @@ func (r *MessageTemplateRepository) Delete(ctx context.Context, id uint) error {
 		return err
 	}
 
+	var campaigns int64
+	if err := tx.QueryRow(ctx, countMessageTemplateCampaignsQuery, id).Scan(&campaigns); err != nil {
+		return err
+	}
+	if campaigns > 0 {
+		return messagetemplate.ErrTemplateInUse
+	}
+
 	result, err := tx.Exec(ctx, deleteMessageTemplateQuery, id)
 	if err != nil {
 		return err
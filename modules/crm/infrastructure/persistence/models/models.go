@@ -11,21 +11,64 @@ type Client struct {
 	LastName    sql.NullString
 	MiddleName  sql.NullString
 	PhoneNumber string
+	Email       sql.NullString
+	Address     sql.NullString
+	DateOfBirth sql.NullTime
+	Gender      sql.NullString
+	HourlyRate  sql.NullFloat64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ClientCustomField struct {
+	ID        uint
+	Key       string
+	Label     string
+	Type      string
+	Options   []string
+	Required  bool
+	Position  int
+	CreatedAt time.Time
+}
+
+type ClientSegment struct {
+	ID          uint
+	Name        string
+	Description sql.NullString
+	Expression  string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
 type Chat struct {
-	ID            uint
-	ClientID      uint
-	LastMessageAt sql.NullTime
-	CreatedAt     time.Time
+	ID                    uint
+	ClientID              uint
+	LastMessageAt         sql.NullTime
+	CreatedAt             time.Time
+	Status                string
+	AssigneeID            sql.NullInt64
+	TeamID                sql.NullInt64
+	AssignedAt            sql.NullTime
+	OpenedAt              sql.NullTime
+	FirstResponseAt       sql.NullTime
+	ClosedAt              sql.NullTime
+	FirstResponseBreached bool
+	ResolutionBreached    bool
+}
+
+type Team struct {
+	ID         uint
+	Name       string
+	AutoAssign bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 type Message struct {
 	ID             uint
 	CreatedAt      time.Time
 	ChatID         uint
+	Channel        string
 	Message        string
 	SenderUserID   sql.NullInt64
 	SenderClientID sql.NullInt64
@@ -33,8 +76,112 @@ type Message struct {
 	IsRead         bool
 }
 
+type ChatContact struct {
+	ChatID  uint
+	Channel string
+	Address string
+}
+
 type MessageTemplate struct {
 	ID        uint
+	Name      string
 	Template  string
 	CreatedAt time.Time
 }
+
+type MessageTemplateVariant struct {
+	ID         uint
+	TemplateID uint
+	Locale     string
+	Channel    string
+	Body       string
+	ExternalID sql.NullString
+}
+
+type DealPipeline struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time
+}
+
+type DealStage struct {
+	ID         uint
+	PipelineID uint
+	Name       string
+	Position   int
+	Kind       string
+}
+
+type Deal struct {
+	ID                uint
+	Title             string
+	ClientID          uint
+	OwnerID           uint
+	PipelineID        uint
+	StageID           uint
+	Status            string
+	Value             float64
+	CurrencyID        string
+	ExpectedCloseDate sql.NullTime
+	ClosedAt          sql.NullTime
+	ConvertedTo       sql.NullString
+	ConvertedAt       sql.NullTime
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type DealStageChange struct {
+	ID          uint
+	DealID      uint
+	FromStageID sql.NullInt64
+	ToStageID   uint
+	ChangedBy   sql.NullInt64
+	ChangedAt   time.Time
+}
+
+type Campaign struct {
+	ID            uint
+	Name          string
+	TemplateID    uint
+	SegmentID     sql.NullInt64
+	Channel       string
+	Locale        string
+	RatePerMinute int
+	Status        string
+	StartedAt     sql.NullTime
+	CompletedAt   sql.NullTime
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type CampaignRecipient struct {
+	ID            uint
+	CampaignID    uint
+	ClientID      uint
+	Address       string
+	Status        string
+	Message       sql.NullString
+	ExternalID    sql.NullString
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     sql.NullString
+	SentAt        sql.NullTime
+	DeliveredAt   sql.NullTime
+	UpdatedAt     time.Time
+}
+
+type ClientActivity struct {
+	ID              uint
+	ClientID        uint
+	Kind            string
+	RefID           sql.NullInt64
+	Body            string
+	Direction       sql.NullString
+	DurationSeconds int
+	Amount          sql.NullFloat64
+	Currency        sql.NullString
+	Status          sql.NullString
+	AuthorID        sql.NullInt64
+	OccurredAt      time.Time
+	CreatedAt       time.Time
+}
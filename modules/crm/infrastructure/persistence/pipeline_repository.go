@@ -0,0 +1,247 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrPipelineNotFound = errors.New("pipeline not found")
+)
+
+const (
+	selectPipelineQuery = `
+		SELECT
+			id,
+			name,
+			created_at
+		FROM deal_pipelines
+	`
+
+	selectPipelineStagesQuery = `
+		SELECT id, pipeline_id, name, position, kind
+		FROM deal_stages
+		WHERE pipeline_id = $1
+		ORDER BY position, id`
+
+	insertPipelineQuery = `INSERT INTO deal_pipelines (name, created_at) VALUES ($1, $2) RETURNING id`
+
+	updatePipelineQuery = `UPDATE deal_pipelines SET name = $1 WHERE id = $2`
+
+	deletePipelineQuery = `DELETE FROM deal_pipelines WHERE id = $1`
+
+	insertStageQuery = `
+		INSERT INTO deal_stages (pipeline_id, name, position, kind)
+		VALUES ($1, $2, $3, $4) RETURNING id`
+
+	updateStageQuery = `
+		UPDATE deal_stages
+		SET name = $1, position = $2, kind = $3
+		WHERE id = $4 AND pipeline_id = $5`
+
+	deleteStagesQuery = `DELETE FROM deal_stages WHERE pipeline_id = $1 AND NOT (id = ANY($2))`
+
+	countDealsInRemovedStagesQuery = `
+		SELECT COUNT(*) FROM deals
+		WHERE pipeline_id = $1 AND NOT (stage_id = ANY($2))`
+
+	countPipelineDealsQuery = `SELECT COUNT(*) FROM deals WHERE pipeline_id = $1`
+)
+
+type PipelineRepository struct {
+}
+
+func NewPipelineRepository() pipeline.Repository {
+	return &PipelineRepository{}
+}
+
+func (r *PipelineRepository) queryPipelines(ctx context.Context, query string, args ...interface{}) ([]pipeline.Pipeline, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dbPipelines := make([]*models.DealPipeline, 0)
+	for rows.Next() {
+		var p models.DealPipeline
+		if err := rows.Scan(&p.ID, &p.Name, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		dbPipelines = append(dbPipelines, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pipelines := make([]pipeline.Pipeline, 0, len(dbPipelines))
+	for _, p := range dbPipelines {
+		stages, err := r.queryStages(ctx, p.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get stages of pipeline")
+		}
+		domainPipeline, err := toDomainPipeline(p, stages)
+		if err != nil {
+			return nil, errors.Wrapf(err, "pipeline %d", p.ID)
+		}
+		pipelines = append(pipelines, domainPipeline)
+	}
+	return pipelines, nil
+}
+
+func (r *PipelineRepository) queryStages(ctx context.Context, pipelineID uint) ([]pipeline.Stage, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectPipelineStagesQuery, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stages := make([]pipeline.Stage, 0)
+	for rows.Next() {
+		var s models.DealStage
+		if err := rows.Scan(&s.ID, &s.PipelineID, &s.Name, &s.Position, &s.Kind); err != nil {
+			return nil, err
+		}
+		stages = append(stages, toDomainDealStage(&s))
+	}
+	return stages, rows.Err()
+}
+
+// saveStages updates the kept stages, inserts the new ones and deletes the rest.
+func (r *PipelineRepository) saveStages(ctx context.Context, pipelineID uint, dbStages []*models.DealStage) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	kept := make([]int64, 0, len(dbStages))
+	for _, s := range dbStages {
+		if s.ID != 0 {
+			kept = append(kept, int64(s.ID))
+		}
+	}
+	var inUse int64
+	if err := tx.QueryRow(ctx, countDealsInRemovedStagesQuery, pipelineID, kept).Scan(&inUse); err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return pipeline.ErrStageInUse
+	}
+	if _, err := tx.Exec(ctx, deleteStagesQuery, pipelineID, kept); err != nil {
+		return err
+	}
+	for _, s := range dbStages {
+		if s.ID == 0 {
+			if err := tx.QueryRow(ctx, insertStageQuery, pipelineID, s.Name, s.Position, s.Kind).Scan(&s.ID); err != nil {
+				return errors.Wrapf(err, "failed to insert stage %q", s.Name)
+			}
+			continue
+		}
+		result, err := tx.Exec(ctx, updateStageQuery, s.Name, s.Position, s.Kind, s.ID, pipelineID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update stage %q", s.Name)
+		}
+		if result.RowsAffected() == 0 {
+			return errors.Errorf("stage %d does not belong to pipeline %d", s.ID, pipelineID)
+		}
+	}
+	return nil
+}
+
+func (r *PipelineRepository) GetAll(ctx context.Context) ([]pipeline.Pipeline, error) {
+	return r.queryPipelines(ctx, selectPipelineQuery+` ORDER BY id`)
+}
+
+func (r *PipelineRepository) GetByID(ctx context.Context, id uint) (pipeline.Pipeline, error) {
+	pipelines, err := r.queryPipelines(ctx, selectPipelineQuery+` WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, ErrPipelineNotFound
+	}
+	return pipelines[0], nil
+}
+
+func (r *PipelineRepository) GetByStageID(ctx context.Context, stageID uint) (pipeline.Pipeline, error) {
+	pipelines, err := r.queryPipelines(
+		ctx,
+		selectPipelineQuery+` WHERE id = (SELECT pipeline_id FROM deal_stages WHERE id = $1)`,
+		stageID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, ErrPipelineNotFound
+	}
+	return pipelines[0], nil
+}
+
+func (r *PipelineRepository) Create(ctx context.Context, data pipeline.Pipeline) (pipeline.Pipeline, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbPipeline, dbStages := toDBPipeline(data)
+	if err := tx.QueryRow(ctx, insertPipelineQuery, dbPipeline.Name, dbPipeline.CreatedAt).Scan(&dbPipeline.ID); err != nil {
+		return nil, err
+	}
+	if err := r.saveStages(ctx, dbPipeline.ID, dbStages); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, dbPipeline.ID)
+}
+
+func (r *PipelineRepository) Update(ctx context.Context, data pipeline.Pipeline) (pipeline.Pipeline, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbPipeline, dbStages := toDBPipeline(data)
+	result, err := tx.Exec(ctx, updatePipelineQuery, dbPipeline.Name, dbPipeline.ID)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrPipelineNotFound
+	}
+	if err := r.saveStages(ctx, dbPipeline.ID, dbStages); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, dbPipeline.ID)
+}
+
+func (r *PipelineRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	var deals int64
+	if err := tx.QueryRow(ctx, countPipelineDealsQuery, id).Scan(&deals); err != nil {
+		return err
+	}
+	if deals > 0 {
+		return pipeline.ErrPipelineInUse
+	}
+	result, err := tx.Exec(ctx, deletePipelineQuery, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrPipelineNotFound
+	}
+	return nil
+}
@@ -14,17 +14,42 @@ CREATE TABLE clients (
     updated_at    TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
+CREATE TABLE teams (
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    auto_assign BOOLEAN NOT NULL DEFAULT false,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE team_members (
+    team_id INT NOT NULL REFERENCES teams(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (team_id, user_id)
+);
+
 CREATE TABLE chats (
     id          SERIAL PRIMARY KEY,
     created_at  TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
     client_id   INT NOT NULL REFERENCES clients(id) ON DELETE RESTRICT ON UPDATE CASCADE,
-    last_message_at TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP
+    last_message_at TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP,
+    status      VARCHAR(20) NOT NULL DEFAULT 'open',
+    assignee_id INT REFERENCES users(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    team_id     INT REFERENCES teams(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    assigned_at TIMESTAMP(3),
+    -- SLA cycle: from the client message that opened the chat until it is closed
+    opened_at               TIMESTAMP(3),
+    first_response_at       TIMESTAMP(3),
+    closed_at               TIMESTAMP(3),
+    first_response_breached BOOLEAN NOT NULL DEFAULT false,
+    resolution_breached     BOOLEAN NOT NULL DEFAULT false
 );
 
 CREATE TABLE messages (
     id               SERIAL PRIMARY KEY,
     created_at       TIMESTAMP(3) DEFAULT CURRENT_TIMESTAMP NOT NULL,
     chat_id          INT NOT NULL REFERENCES chats(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    channel          VARCHAR(20) NOT NULL DEFAULT 'sms',
     message          TEXT NOT NULL,
     sender_user_id   INT REFERENCES users(id) ON DELETE SET NULL ON UPDATE CASCADE,
     sender_client_id INT REFERENCES clients(id) ON DELETE SET NULL ON UPDATE CASCADE,
@@ -32,6 +57,15 @@ CREATE TABLE messages (
     read_at          TIMESTAMP(3)
 );
 
+CREATE TABLE chat_contacts (
+    chat_id     INT NOT NULL REFERENCES chats(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    channel     VARCHAR(20) NOT NULL,
+    address     VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    PRIMARY KEY (chat_id, channel),
+    UNIQUE (channel, address)
+);
+
 CREATE TABLE message_media (
     message_id  INT NOT NULL REFERENCES messages(id) ON DELETE CASCADE ON UPDATE CASCADE,
     upload_id   INT NOT NULL REFERENCES uploads(id) ON DELETE CASCADE ON UPDATE CASCADE,
@@ -40,23 +74,212 @@ CREATE TABLE message_media (
 
 CREATE TABLE message_templates (
     id          SERIAL PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL DEFAULT '',
     template    TEXT NOT NULL,
     created_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
+CREATE TABLE message_template_variants (
+    id           SERIAL PRIMARY KEY,
+    template_id  INT NOT NULL REFERENCES message_templates(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    locale       VARCHAR(10) NOT NULL DEFAULT '',
+    channel      VARCHAR(20) NOT NULL DEFAULT '',
+    body         TEXT NOT NULL,
+    external_id  VARCHAR(255),
+    UNIQUE (template_id, locale, channel)
+);
+
+CREATE TABLE deal_pipelines (
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE deal_stages (
+    id           SERIAL PRIMARY KEY,
+    pipeline_id  INT NOT NULL REFERENCES deal_pipelines(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    name         VARCHAR(255) NOT NULL,
+    position     INT NOT NULL DEFAULT 0,
+    kind         VARCHAR(10) NOT NULL DEFAULT 'open'
+);
+
+CREATE TABLE deals (
+    id                   SERIAL PRIMARY KEY,
+    title                VARCHAR(255) NOT NULL,
+    client_id            INT NOT NULL REFERENCES clients(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    owner_id             INT NOT NULL REFERENCES users(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    pipeline_id          INT NOT NULL REFERENCES deal_pipelines(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    stage_id             INT NOT NULL REFERENCES deal_stages(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    status               VARCHAR(10) NOT NULL DEFAULT 'open',
+    value                NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    currency_id          VARCHAR(3) NOT NULL REFERENCES currencies(code) ON DELETE RESTRICT ON UPDATE CASCADE,
+    expected_close_date  DATE,
+    closed_at            TIMESTAMP WITH TIME ZONE,
+    converted_to         VARCHAR(10),
+    converted_at         TIMESTAMP WITH TIME ZONE,
+    created_at           TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at           TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE deal_stage_history (
+    id             SERIAL PRIMARY KEY,
+    deal_id        INT NOT NULL REFERENCES deals(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    from_stage_id  INT REFERENCES deal_stages(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    to_stage_id    INT NOT NULL REFERENCES deal_stages(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    changed_by     INT REFERENCES users(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    changed_at     TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE client_tags (
+    client_id  INT NOT NULL REFERENCES clients(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    tag        VARCHAR(100) NOT NULL,
+    PRIMARY KEY (client_id, tag)
+);
+
+CREATE TABLE client_custom_fields (
+    id          SERIAL PRIMARY KEY,
+    key         VARCHAR(50) NOT NULL UNIQUE,
+    label       VARCHAR(255) NOT NULL,
+    type        VARCHAR(10) NOT NULL,
+    options     TEXT[] NOT NULL DEFAULT '{}',
+    required    BOOLEAN NOT NULL DEFAULT false,
+    position    INT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE client_custom_field_values (
+    client_id  INT NOT NULL REFERENCES clients(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    field_id   INT NOT NULL REFERENCES client_custom_fields(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    value      TEXT NOT NULL,
+    PRIMARY KEY (client_id, field_id)
+);
+
+CREATE TABLE client_segments (
+    id           SERIAL PRIMARY KEY,
+    name         VARCHAR(255) NOT NULL,
+    description  TEXT,
+    expression   TEXT NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE campaigns (
+    id               SERIAL PRIMARY KEY,
+    name             VARCHAR(255) NOT NULL,
+    template_id      INT NOT NULL REFERENCES message_templates(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    segment_id       INT REFERENCES client_segments(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    channel          VARCHAR(20) NOT NULL,
+    locale           VARCHAR(10) NOT NULL,
+    rate_per_minute  INT NOT NULL,
+    status           VARCHAR(20) NOT NULL,
+    started_at       TIMESTAMP WITH TIME ZONE,
+    completed_at     TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at       TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE campaign_recipients (
+    id               SERIAL PRIMARY KEY,
+    campaign_id      INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    client_id        INT NOT NULL REFERENCES clients(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    address          VARCHAR(255) NOT NULL,
+    status           VARCHAR(20) NOT NULL,
+    message          TEXT,
+    external_id      VARCHAR(255),
+    attempts         INT NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error       TEXT,
+    sent_at          TIMESTAMP WITH TIME ZONE,
+    delivered_at     TIMESTAMP WITH TIME ZONE,
+    updated_at       TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    UNIQUE (campaign_id, client_id)
+);
+
+CREATE TABLE campaign_opt_outs (
+    channel     VARCHAR(20) NOT NULL,
+    address     VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    PRIMARY KEY (channel, address)
+);
+
+CREATE TABLE client_activities (
+    id               SERIAL PRIMARY KEY,
+    client_id        INT NOT NULL REFERENCES clients(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    kind             VARCHAR(20) NOT NULL,
+    -- payment or order of the finance and warehouse modules
+    ref_id           INT,
+    body             TEXT NOT NULL DEFAULT '',
+    direction        VARCHAR(20),
+    duration_seconds INT NOT NULL DEFAULT 0,
+    amount           NUMERIC(18, 2),
+    currency         VARCHAR(3),
+    status           VARCHAR(50),
+    author_id        INT REFERENCES users(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    occurred_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+-- finance counterparties invoiced for the deals of a client
+CREATE TABLE client_counterparties (
+    client_id       INT NOT NULL REFERENCES clients(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    counterparty_id INT NOT NULL,
+    PRIMARY KEY (client_id, counterparty_id)
+);
+
 CREATE INDEX idx_chats_client_id ON chats (client_id);
+CREATE INDEX idx_chats_assignee_id_status ON chats (assignee_id, status);
+CREATE INDEX idx_chats_team_id ON chats (team_id);
+CREATE INDEX idx_team_members_user_id ON team_members (user_id);
 
 CREATE INDEX idx_messages_chat_id ON messages (chat_id);
+CREATE INDEX idx_messages_created_at ON messages (created_at);
 CREATE INDEX idx_messages_sender_user_id ON messages (sender_user_id);
 CREATE INDEX idx_messages_sender_client_id ON messages (sender_client_id);
 
 CREATE INDEX idx_customers_first_name ON clients (first_name);
 CREATE INDEX idx_customers_last_name ON clients (last_name);
 CREATE INDEX idx_customers_phone_number ON clients (phone_number);
+CREATE INDEX idx_clients_phone_digits ON clients (regexp_replace(phone_number, '\D', '', 'g'));
+CREATE INDEX idx_clients_email ON clients (lower(email));
+
+CREATE INDEX idx_client_tags_tag ON client_tags (tag);
+CREATE INDEX idx_client_custom_field_values_field_id ON client_custom_field_values (field_id);
+
+CREATE INDEX idx_deal_stages_pipeline_id ON deal_stages (pipeline_id);
+CREATE INDEX idx_deals_pipeline_id_stage_id ON deals (pipeline_id, stage_id);
+CREATE INDEX idx_deals_client_id ON deals (client_id);
+CREATE INDEX idx_deals_owner_id ON deals (owner_id);
+CREATE INDEX idx_deal_stage_history_deal_id ON deal_stage_history (deal_id);
+
+CREATE INDEX idx_campaigns_template_id ON campaigns (template_id);
+CREATE INDEX idx_campaigns_segment_id ON campaigns (segment_id);
+CREATE INDEX idx_campaign_recipients_due ON campaign_recipients (campaign_id, status, next_attempt_at);
+CREATE INDEX idx_campaign_recipients_external_id ON campaign_recipients (external_id);
+
+CREATE INDEX idx_client_activities_client_id_occurred_at ON client_activities (client_id, occurred_at);
+CREATE INDEX idx_client_activities_kind_ref_id ON client_activities (kind, ref_id);
+CREATE INDEX idx_client_counterparties_counterparty_id ON client_counterparties (counterparty_id);
 
 -- +migrate Down
+DROP TABLE IF EXISTS client_counterparties;
+DROP TABLE IF EXISTS client_activities;
+DROP TABLE IF EXISTS campaign_opt_outs;
+DROP TABLE IF EXISTS campaign_recipients;
+DROP TABLE IF EXISTS campaigns;
+DROP TABLE IF EXISTS client_segments;
+DROP TABLE IF EXISTS client_custom_field_values;
+DROP TABLE IF EXISTS client_custom_fields;
+DROP TABLE IF EXISTS client_tags;
+DROP TABLE IF EXISTS deal_stage_history;
+DROP TABLE IF EXISTS deals;
+DROP TABLE IF EXISTS deal_stages;
+DROP TABLE IF EXISTS deal_pipelines;
+DROP TABLE IF EXISTS message_template_variants;
 DROP TABLE IF EXISTS message_templates;
 DROP TABLE IF EXISTS message_media;
 DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS chat_contacts;
 DROP TABLE IF EXISTS chats;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
 DROP TABLE IF EXISTS clients;
@@ -0,0 +1,184 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+)
+
+var (
+	ErrUnboundFilter = errors.New("segment filter is not bound to the custom fields")
+)
+
+var segmentTextColumns = map[string]string{
+	"first_name":  "c.first_name",
+	"last_name":   "c.last_name",
+	"middle_name": "c.middle_name",
+	"email":       "c.email",
+	"address":     "c.address",
+	"gender":      "c.gender",
+	"phone":       `regexp_replace(c.phone_number, '\D', '', 'g')`,
+}
+
+var segmentValueColumns = map[string]string{
+	"date_of_birth": "c.date_of_birth",
+	"created_at":    "c.created_at::date",
+	"hourly_rate":   "c.hourly_rate",
+}
+
+const customFieldValueQuery = `(SELECT %s FROM client_custom_field_values v
+	JOIN client_custom_fields f ON f.id = v.field_id
+	WHERE v.client_id = c.id AND f.key = %s)`
+
+// segmentFilterSQL compiles a segment expression to a condition on the clients aliased c. The
+// placeholders continue the numbering of args.
+func segmentFilterSQL(expr segment.Expression, args []interface{}) (string, []interface{}, error) {
+	b := &segmentFilterBuilder{args: args}
+	sql, err := b.build(expr)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql, b.args, nil
+}
+
+type segmentFilterBuilder struct {
+	args []interface{}
+}
+
+func (b *segmentFilterBuilder) arg(v interface{}) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *segmentFilterBuilder) build(expr segment.Expression) (string, error) {
+	switch e := expr.(type) {
+	case segment.And:
+		return b.binary(e.Left, e.Right, "AND")
+	case segment.Or:
+		return b.binary(e.Left, e.Right, "OR")
+	case segment.Not:
+		inner, err := b.build(e.Expression)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("NOT (%s)", inner), nil
+	case segment.Condition:
+		return b.condition(e)
+	}
+	return "", fmt.Errorf("unexpected segment expression %T", expr)
+}
+
+func (b *segmentFilterBuilder) binary(left, right segment.Expression, op string) (string, error) {
+	l, err := b.build(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := b.build(right)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(%s %s %s)", l, op, r), nil
+}
+
+func (b *segmentFilterBuilder) condition(c segment.Condition) (string, error) {
+	if c.Type == "" {
+		return "", ErrUnboundFilter
+	}
+	if c.Field == segment.TagField {
+		return b.tagCondition(c), nil
+	}
+	var column string
+	if key, ok := c.CustomFieldKey(); ok {
+		value := "v.value"
+		switch c.Type {
+		case segment.NumberValue:
+			value = "CASE WHEN f.type = 'number' THEN v.value::numeric END"
+		case segment.DateValue:
+			value = "CASE WHEN f.type = 'date' THEN v.value::date END"
+		}
+		column = fmt.Sprintf(customFieldValueQuery, value, b.arg(key))
+	} else if col, ok := segmentTextColumns[c.Field]; ok {
+		column = col
+	} else if col, ok := segmentValueColumns[c.Field]; ok {
+		column = col
+	} else {
+		return "", fmt.Errorf("%w: %s", segment.ErrUnknownField, c.Field)
+	}
+	if c.Type == segment.TextValue {
+		return b.textCondition(c, column), nil
+	}
+	return b.valueCondition(c, column)
+}
+
+func (b *segmentFilterBuilder) textCondition(c segment.Condition, column string) string {
+	value := c.Value
+	if c.Field == "phone" {
+		value = client.NormalizePhone(value)
+	}
+	column = fmt.Sprintf("COALESCE(%s, '')", column)
+	switch c.Operator {
+	case segment.NotEq:
+		return fmt.Sprintf("lower(%s) <> lower(%s)", column, b.arg(value))
+	case segment.Contains:
+		return fmt.Sprintf("%s ILIKE %s", column, b.arg("%"+escapeLike(value)+"%"))
+	default:
+		return fmt.Sprintf("lower(%s) = lower(%s)", column, b.arg(value))
+	}
+}
+
+func (b *segmentFilterBuilder) tagCondition(c segment.Condition) string {
+	const exists = "EXISTS (SELECT 1 FROM client_tags t WHERE t.client_id = c.id AND t.tag %s %s)"
+	value := strings.ToLower(strings.TrimSpace(c.Value))
+	switch c.Operator {
+	case segment.NotEq:
+		return "NOT " + fmt.Sprintf(exists, "=", b.arg(value))
+	case segment.Contains:
+		return fmt.Sprintf(exists, "LIKE", b.arg("%"+escapeLike(value)+"%"))
+	default:
+		return fmt.Sprintf(exists, "=", b.arg(value))
+	}
+}
+
+// valueCondition compares numbers and dates. Clients without a value never match a comparison,
+// which is also true under NOT.
+func (b *segmentFilterBuilder) valueCondition(c segment.Condition, column string) (string, error) {
+	if c.Value == "" {
+		if c.Operator == segment.NotEq {
+			return fmt.Sprintf("%s IS NOT NULL", column), nil
+		}
+		return fmt.Sprintf("%s IS NULL", column), nil
+	}
+	var value interface{}
+	switch c.Type {
+	case segment.NumberValue:
+		v, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", segment.ErrInvalidValue, c.Value)
+		}
+		value = v
+	case segment.DateValue:
+		v, err := time.Parse(time.DateOnly, c.Value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", segment.ErrInvalidValue, c.Value)
+		}
+		value = v
+	}
+	placeholder := b.arg(value)
+	if c.Type == segment.DateValue {
+		placeholder += "::date"
+	}
+	op := string(c.Operator)
+	if c.Operator == segment.NotEq {
+		op = "<>"
+	}
+	return fmt.Sprintf("COALESCE(%s %s %s, false)", column, op, placeholder), nil
+}
+
+func escapeLike(v string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(v)
+}
@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+)
+
+func TestSegmentFilterSQL(t *testing.T) {
+	visits, err := customfield.New("visits", "Visits", customfield.Number, nil, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expr, err := segment.Parse(`tag = VIP AND (phone ~ "+998 90" OR NOT field.visits >= 3)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound, err := segment.Bind(expr, []customfield.Field{visits})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql, args, err := segmentFilterSQL(bound, []interface{}{"existing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{
+		"t.tag = $2",
+		"ILIKE $3",
+		"NOT (COALESCE(",
+		"v.value::numeric",
+		"f.key = $4",
+		">= $5",
+	} {
+		if !strings.Contains(sql, part) {
+			t.Errorf("expected %q in %s", part, sql)
+		}
+	}
+	want := []interface{}{"existing", "vip", "%99890%", "visits", 3.0}
+	if len(args) != len(want) {
+		t.Fatalf("expected args %v, got %v", want, args)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Errorf("arg %d = %v, want %v", i, args[i], want[i])
+		}
+	}
+
+	if _, _, err := segmentFilterSQL(expr, nil); !errors.Is(err, ErrUnboundFilter) {
+		t.Errorf("expected ErrUnboundFilter for an unbound expression, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrSegmentNotFound = errors.New("segment not found")
+)
+
+const (
+	selectSegmentQuery = `
+		SELECT id, name, description, expression, created_at, updated_at
+		FROM client_segments`
+	countSegmentQuery  = `SELECT COUNT(*) FROM client_segments`
+	insertSegmentQuery = `
+		INSERT INTO client_segments (name, description, expression, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	updateSegmentQuery = `
+		UPDATE client_segments
+		SET name = $1, description = $2, expression = $3, updated_at = $4
+		WHERE id = $5`
+	deleteSegmentQuery = `DELETE FROM client_segments WHERE id = $1`
+
+	countSegmentCampaignsQuery = `SELECT COUNT(*) FROM campaigns WHERE segment_id = $1`
+)
+
+type SegmentRepository struct {
+}
+
+func NewSegmentRepository() segment.Repository {
+	return &SegmentRepository{}
+}
+
+func (g *SegmentRepository) querySegments(ctx context.Context, query string, args ...interface{}) ([]segment.Segment, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	segments := make([]segment.Segment, 0)
+	for rows.Next() {
+		var s models.ClientSegment
+		if err := rows.Scan(
+			&s.ID,
+			&s.Name,
+			&s.Description,
+			&s.Expression,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := toDomainSegment(&s)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, entity)
+	}
+	return segments, rows.Err()
+}
+
+func (g *SegmentRepository) Count(ctx context.Context) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, countSegmentQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *SegmentRepository) GetAll(ctx context.Context) ([]segment.Segment, error) {
+	return g.querySegments(ctx, selectSegmentQuery+" ORDER BY name")
+}
+
+func (g *SegmentRepository) GetByID(ctx context.Context, id uint) (segment.Segment, error) {
+	segments, err := g.querySegments(ctx, selectSegmentQuery+" WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, ErrSegmentNotFound
+	}
+	return segments[0], nil
+}
+
+func (g *SegmentRepository) Create(ctx context.Context, data segment.Segment) (segment.Segment, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbRow := toDBSegment(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertSegmentQuery,
+		dbRow.Name,
+		dbRow.Description,
+		dbRow.Expression,
+		dbRow.CreatedAt,
+		dbRow.UpdatedAt,
+	).Scan(&dbRow.ID); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, dbRow.ID)
+}
+
+func (g *SegmentRepository) Update(ctx context.Context, data segment.Segment) (segment.Segment, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbRow := toDBSegment(data)
+	if _, err := tx.Exec(
+		ctx,
+		updateSegmentQuery,
+		dbRow.Name,
+		dbRow.Description,
+		dbRow.Expression,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+	); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, dbRow.ID)
+}
+
+func (g *SegmentRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	var campaigns int64
+	if err := tx.QueryRow(ctx, countSegmentCampaignsQuery, id).Scan(&campaigns); err != nil {
+		return err
+	}
+	if campaigns > 0 {
+		return segment.ErrSegmentInUse
+	}
+	_, err = tx.Exec(ctx, deleteSegmentQuery, id)
+	return err
+}
@@ -0,0 +1,205 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var (
+	ErrTeamNotFound = errors.New("team not found")
+)
+
+const (
+	selectTeamQuery = `
+		SELECT
+			t.id,
+			t.name,
+			t.auto_assign,
+			t.created_at,
+			t.updated_at,
+			ARRAY(SELECT m.user_id FROM team_members m WHERE m.team_id = t.id ORDER BY m.user_id)
+		FROM teams t`
+	countTeamQuery  = `SELECT COUNT(*) FROM teams`
+	insertTeamQuery = `
+		INSERT INTO teams (name, auto_assign, created_at, updated_at)
+		VALUES ($1, $2, $3, $4) RETURNING id`
+	updateTeamQuery = `
+		UPDATE teams
+		SET name = $1, auto_assign = $2, updated_at = $3
+		WHERE id = $4`
+	deleteTeamQuery = `DELETE FROM teams WHERE id = $1`
+
+	deleteTeamMembersQuery = `DELETE FROM team_members WHERE team_id = $1 AND NOT (user_id = ANY($2))`
+	insertTeamMembersQuery = `
+		INSERT INTO team_members (team_id, user_id)
+		SELECT $1, unnest($2::int[])
+		ON CONFLICT DO NOTHING`
+
+	// The member assigned a chat the longest time ago is next, members never assigned go first
+	selectNextAssigneeQuery = `
+		SELECT m.team_id, m.user_id
+		FROM team_members m
+		JOIN teams t ON t.id = m.team_id
+		WHERE t.auto_assign
+		ORDER BY (SELECT MAX(c.assigned_at) FROM chats c WHERE c.assignee_id = m.user_id) NULLS FIRST, m.user_id, m.team_id
+		LIMIT 1`
+)
+
+type TeamRepository struct {
+}
+
+func NewTeamRepository() team.Repository {
+	return &TeamRepository{}
+}
+
+func (g *TeamRepository) queryTeams(ctx context.Context, query string, args ...interface{}) ([]team.Team, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	teams := make([]team.Team, 0)
+	for rows.Next() {
+		var t models.Team
+		var memberIDs []uint
+		if err := rows.Scan(
+			&t.ID,
+			&t.Name,
+			&t.AutoAssign,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+			&memberIDs,
+		); err != nil {
+			return nil, err
+		}
+		teams = append(teams, toDomainTeam(&t, memberIDs))
+	}
+	return teams, rows.Err()
+}
+
+func (g *TeamRepository) saveMembers(ctx context.Context, teamID uint, memberIDs []uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, deleteTeamMembersQuery, teamID, memberIDs); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, insertTeamMembersQuery, teamID, memberIDs)
+	return err
+}
+
+func (g *TeamRepository) Count(ctx context.Context) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, countTeamQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *TeamRepository) GetAll(ctx context.Context) ([]team.Team, error) {
+	return g.queryTeams(ctx, selectTeamQuery+" ORDER BY t.name")
+}
+
+func (g *TeamRepository) GetByID(ctx context.Context, id uint) (team.Team, error) {
+	teams, err := g.queryTeams(ctx, selectTeamQuery+" WHERE t.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return nil, ErrTeamNotFound
+	}
+	return teams[0], nil
+}
+
+func (g *TeamRepository) NextAssignee(ctx context.Context) (uint, uint, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	var teamID, userID uint
+	if err := tx.QueryRow(ctx, selectNextAssigneeQuery).Scan(&teamID, &userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, team.ErrNoAssignee
+		}
+		return 0, 0, err
+	}
+	return teamID, userID, nil
+}
+
+func (g *TeamRepository) Create(ctx context.Context, data team.Team) (team.Team, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbTeam := toDBTeam(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertTeamQuery,
+		dbTeam.Name,
+		dbTeam.AutoAssign,
+		dbTeam.CreatedAt,
+		dbTeam.UpdatedAt,
+	).Scan(&dbTeam.ID); err != nil {
+		return nil, err
+	}
+	if err := g.saveMembers(ctx, dbTeam.ID, data.MemberIDs()); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, dbTeam.ID)
+}
+
+func (g *TeamRepository) Update(ctx context.Context, data team.Team) (team.Team, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbTeam := toDBTeam(data)
+	result, err := tx.Exec(
+		ctx,
+		updateTeamQuery,
+		dbTeam.Name,
+		dbTeam.AutoAssign,
+		dbTeam.UpdatedAt,
+		dbTeam.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrTeamNotFound
+	}
+	if err := g.saveMembers(ctx, dbTeam.ID, data.MemberIDs()); err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, dbTeam.ID)
+}
+
+func (g *TeamRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(ctx, deleteTeamQuery, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
@@ -26,3 +26,11 @@ func (b *Bot) SendMessage(ctx context.Context, chatID int64, text string) error
 	}
 	return nil
 }
+
+// SetWebhook makes Telegram deliver the bot's updates to the url, signed with the secret token.
+func (b *Bot) SetWebhook(url, secret string) error {
+	if _, err := b.client.SetWebhook(url, &gotgbot.SetWebhookOpts{SecretToken: secret}); err != nil {
+		return fmt.Errorf("failed to set webhook: %w", err)
+	}
+	return nil
+}
@@ -1,88 +0,0 @@
-package websocket
-
-import (
-	"log"
-	"net/http"
-	"sync"
-
-	"github.com/gorilla/websocket"
-)
-
-// Hub handles WebSocket connections
-type Hub struct {
-	upgrader websocket.Upgrader
-	clients  map[*websocket.Conn]bool
-	mu       sync.RWMutex
-}
-
-// NewHub creates a new Hub
-func NewHub() *Hub {
-	return &Hub{
-		upgrader: websocket.Upgrader{
-			ReadBufferSize:  1024,
-			WriteBufferSize: 1024,
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
-		},
-		clients: make(map[*websocket.Conn]bool),
-	}
-}
-
-// readPump reads messages from the WebSocket connection
-func (h *Hub) readPump(conn *websocket.Conn) {
-	defer func() {
-		h.mu.Lock()
-		delete(h.clients, conn)
-		h.mu.Unlock()
-		conn.Close()
-	}()
-
-	for {
-		messageType, message, err := conn.ReadMessage()
-		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("Error reading message: %v", err)
-			}
-			return
-		}
-
-		if err := h.handleMessage(conn, messageType, message); err != nil {
-			log.Printf("Error handling message: %v", err)
-			return
-		}
-	}
-}
-
-// ServeHTTP implements the http.Handler interface
-func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	conn, err := h.upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Printf("Error upgrading connection: %v", err)
-		return
-	}
-
-	h.mu.Lock()
-	h.clients[conn] = true
-	h.mu.Unlock()
-
-	// Start reading messages in a separate goroutine and return
-	go h.readPump(conn)
-}
-
-// handleMessage processes incoming messages
-func (h *Hub) handleMessage(_ *websocket.Conn, _ int, _ []byte) error {
-	return nil
-}
-
-// broadcast sends a message to all connected clients except the sender
-func (h *Hub) Broadcast(message []byte) {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
-
-	for client := range h.clients {
-		if err := client.WriteMessage(websocket.TextMessage, message); err != nil {
-			log.Printf("Error broadcasting message: %v", err)
-		}
-	}
-}
@@ -0,0 +1,33 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+type Query struct {
+}
+
+type TimelineEntry struct {
+	ID              int64     `json:"id"`
+	Kind            string    `json:"kind"`
+	RefID           *int64    `json:"refId,omitempty"`
+	Body            string    `json:"body"`
+	Channel         *string   `json:"channel,omitempty"`
+	Direction       *string   `json:"direction,omitempty"`
+	DurationSeconds int       `json:"durationSeconds"`
+	Amount          *float64  `json:"amount,omitempty"`
+	Currency        *string   `json:"currency,omitempty"`
+	Status          *string   `json:"status,omitempty"`
+	AuthorID        *int64    `json:"authorId,omitempty"`
+	OccurredAt      time.Time `json:"occurredAt"`
+}
+
+type TimelineQuery struct {
+	Kinds  []string   `json:"kinds,omitempty"`
+	From   *time.Time `json:"from,omitempty"`
+	To     *time.Time `json:"to,omitempty"`
+	Limit  int        `json:"limit"`
+	Offset int        `json:"offset"`
+}
@@ -0,0 +1,42 @@
+package mappers
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	model "github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/gqlmodels"
+)
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalID(id uint) *int64 {
+	if id == 0 {
+		return nil
+	}
+	v := int64(id)
+	return &v
+}
+
+func TimelineEntryToGraphModel(e activity.Entry) *model.TimelineEntry {
+	entry := &model.TimelineEntry{
+		ID:              int64(e.ID),
+		Kind:            string(e.Kind),
+		RefID:           optionalID(e.RefID),
+		Body:            e.Body,
+		Channel:         optionalString(e.Channel),
+		Direction:       optionalString(string(e.Direction)),
+		DurationSeconds: int(e.Duration.Seconds()),
+		Currency:        optionalString(e.Currency),
+		Status:          optionalString(e.Status),
+		AuthorID:        optionalID(e.AuthorID),
+		OccurredAt:      e.OccurredAt,
+	}
+	if e.Kind == activity.Payment {
+		amount := e.Amount
+		entry.Amount = &amount
+	}
+	return entry
+}
@@ -0,0 +1,22 @@
+package graph
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+)
+
+// This file will not be regenerated automatically.
+//
+// It serves as dependency injection for your app, add any dependencies you require here.
+
+type Resolver struct {
+	app             application.Application
+	activityService *services.ActivityService
+}
+
+func NewResolver(app application.Application) *Resolver {
+	return &Resolver{
+		app:             app,
+		activityService: app.Service(services.ActivityService{}).(*services.ActivityService),
+	}
+}
@@ -0,0 +1,49 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	model "github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// ClientTimeline is the resolver for the clientTimeline field.
+func (r *queryResolver) ClientTimeline(ctx context.Context, clientID int64, query model.TimelineQuery) ([]*model.TimelineEntry, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	params := &activity.TimelineParams{
+		ClientID: uint(clientID),
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	}
+	for _, k := range query.Kinds {
+		kind, err := activity.NewKind(k)
+		if err != nil {
+			return nil, err
+		}
+		params.Kinds = append(params.Kinds, kind)
+	}
+	if query.From != nil {
+		params.From = *query.From
+	}
+	if query.To != nil {
+		params.To = *query.To
+	}
+	entries, err := r.activityService.Timeline(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapViewModels(entries, mappers.TimelineEntryToGraphModel), nil
+}
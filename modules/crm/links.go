@@ -3,6 +3,8 @@ package crm
 import (
 	icons "github.com/iota-uz/icons/phosphor"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/types"
 )
 
@@ -20,13 +22,76 @@ var ChatsLink = types.NavigationItem{
 	Children: nil,
 }
 
+var DealsLink = types.NavigationItem{
+	Name:        "NavigationLinks.Deals",
+	Icon:        icons.Kanban(icons.Props{Size: "20"}),
+	Href:        "/crm/deals",
+	Permissions: []*permission.Permission{permissions.DealRead},
+	Children:    nil,
+}
+
+var PipelinesLink = types.NavigationItem{
+	Name:        "NavigationLinks.Pipelines",
+	Icon:        icons.Funnel(icons.Props{Size: "20"}),
+	Href:        "/crm/pipelines",
+	Permissions: []*permission.Permission{permissions.DealUpdate},
+	Children:    nil,
+}
+
+var ClientFieldsLink = types.NavigationItem{
+	Name:        "NavigationLinks.ClientFields",
+	Icon:        icons.ListPlus(icons.Props{Size: "20"}),
+	Href:        "/crm/client-fields",
+	Permissions: []*permission.Permission{permissions.ClientUpdate},
+	Children:    nil,
+}
+
+var SegmentsLink = types.NavigationItem{
+	Name:        "NavigationLinks.Segments",
+	Icon:        icons.UsersThree(icons.Props{Size: "20"}),
+	Href:        "/crm/segments",
+	Permissions: []*permission.Permission{permissions.ClientRead},
+	Children:    nil,
+}
+
+var WorkloadLink = types.NavigationItem{
+	Name:        "NavigationLinks.Workload",
+	Icon:        icons.ChartBar(icons.Props{Size: "20"}),
+	Href:        "/crm/workload",
+	Permissions: []*permission.Permission{permissions.TeamRead},
+	Children:    nil,
+}
+
+var TeamsLink = types.NavigationItem{
+	Name:        "NavigationLinks.Teams",
+	Icon:        icons.UserCircleGear(icons.Props{Size: "20"}),
+	Href:        "/crm/teams",
+	Permissions: []*permission.Permission{permissions.TeamRead},
+	Children:    nil,
+}
+
+var CampaignsLink = types.NavigationItem{
+	Name:        "NavigationLinks.Campaigns",
+	Icon:        icons.Megaphone(icons.Props{Size: "20"}),
+	Href:        "/crm/campaigns",
+	Permissions: []*permission.Permission{permissions.CampaignRead},
+	Children:    nil,
+}
+
 var CRMLink = types.NavigationItem{
 	Name: "NavigationLinks.CRM",
 	Icon: icons.Handshake(icons.Props{Size: "20"}),
 	Href: "/crm",
 	Children: []types.NavigationItem{
 		ClientsLink,
+		SegmentsLink,
+		ClientFieldsLink,
 		ChatsLink,
+		TeamsLink,
+		WorkloadLink,
+		CampaignsLink,
+		DealsLink,
+		PipelinesLink,
 	},
 }
 
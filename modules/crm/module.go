@@ -1,11 +1,16 @@
 package crm
 
 import (
+	"context"
 	"embed"
+	"log"
 
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/handlers"
 	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/telegram"
+	"github.com/iota-uz/iota-sdk/modules/crm/interfaces/graph"
 	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/crm/services"
@@ -36,42 +41,147 @@ func (m *Module) Register(app application.Application) error {
 		},
 		conf.TwilioWebhookURL,
 	)
+	providers := []cpassproviders.Provider{twilioProvider}
+	webhookControllers := []application.Controller{
+		controllers.NewWebhookController(app, "/twilio", twilioProvider),
+	}
+	if conf.CrmTelegramBotToken != "" {
+		bot, err := telegram.NewBot(conf.CrmTelegramBotToken)
+		if err != nil {
+			return err
+		}
+		if conf.CrmTelegramWebhookURL != "" {
+			if err := bot.SetWebhook(conf.CrmTelegramWebhookURL, conf.CrmTelegramWebhookSecret); err != nil {
+				log.Printf("Error setting telegram webhook: %v", err)
+			}
+		}
+		telegramProvider := cpassproviders.NewTelegramProvider(bot, conf.CrmTelegramWebhookSecret)
+		providers = append(providers, telegramProvider)
+		webhookControllers = append(webhookControllers, controllers.NewWebhookController(app, "/telegram", telegramProvider))
+	}
+	if conf.WhatsAppPhoneNumberID != "" {
+		whatsAppProvider := cpassproviders.NewWhatsAppProvider(cpassproviders.WhatsAppConfig{
+			PhoneNumberID: conf.WhatsAppPhoneNumberID,
+			AccessToken:   conf.WhatsAppAccessToken,
+			AppSecret:     conf.WhatsAppAppSecret,
+			VerifyToken:   conf.WhatsAppVerifyToken,
+		})
+		providers = append(providers, whatsAppProvider)
+		webhookControllers = append(webhookControllers, controllers.NewWebhookController(app, "/whatsapp", whatsAppProvider))
+	}
+	if conf.CrmSMTPAddress != "" {
+		emailProvider := cpassproviders.NewEmailProvider(cpassproviders.EmailConfig{
+			SMTPAddress: conf.CrmSMTPAddress,
+			IMAPAddress: conf.CrmIMAPAddress,
+			Username:    conf.CrmEmailUsername,
+			Password:    conf.CrmEmailPassword,
+			From:        conf.CrmEmailFrom,
+			Subject:     conf.CrmEmailSubject,
+		})
+		providers = append(providers, emailProvider)
+		if conf.CrmIMAPAddress != "" {
+			go emailProvider.Listen(context.Background(), app.EventPublisher(), conf.CrmEmailPollInterval)
+		}
+	}
 	chatRepo := persistence.NewChatRepository()
 	clientRepo := persistence.NewClientRepository()
+	templateRepo := persistence.NewMessageTemplateRepository()
+	pipelineRepo := persistence.NewPipelineRepository()
+	fieldRepo := persistence.NewCustomFieldRepository()
+	segmentRepo := persistence.NewSegmentRepository()
+	campaignRepo := persistence.NewCampaignRepository()
+	teamRepo := persistence.NewTeamRepository()
+	activityService := services.NewActivityService(persistence.NewActivityRepository())
 	chatsService := services.NewChatService(
 		chatRepo,
 		clientRepo,
-		twilioProvider,
+		templateRepo,
+		teamRepo,
+		providers,
+		chat.SLAPolicy{
+			FirstResponse: conf.CrmFirstResponseSLA,
+			Resolution:    conf.CrmResolutionSLA,
+		},
+		app.EventPublisher(),
+	)
+	segmentService := services.NewSegmentService(
+		segmentRepo,
+		clientRepo,
+		fieldRepo,
 		app.EventPublisher(),
 	)
+	campaignService := services.NewCampaignService(
+		campaignRepo,
+		clientRepo,
+		chatRepo,
+		templateRepo,
+		segmentService,
+		providers,
+	)
 	app.RegisterServices(
 		chatsService,
 		services.NewClientService(
 			clientRepo,
+			fieldRepo,
 			chatsService,
 			app.EventPublisher(),
 		),
+		services.NewCustomFieldService(
+			fieldRepo,
+			segmentRepo,
+			app.EventPublisher(),
+		),
+		segmentService,
 		services.NewMessageTemplateService(
-			persistence.NewMessageTemplateRepository(),
+			templateRepo,
+			clientRepo,
+			app.EventPublisher(),
+		),
+		services.NewPipelineService(
+			pipelineRepo,
 			app.EventPublisher(),
 		),
+		services.NewDealService(
+			persistence.NewDealRepository(),
+			pipelineRepo,
+			clientRepo,
+			app.EventPublisher(),
+		),
+		campaignService,
+		services.NewTeamService(teamRepo),
+		activityService,
 	)
 
 	app.RegisterControllers(
 		controllers.NewClientController(app, "/crm/clients"),
+		controllers.NewClientFieldController(app, "/crm/client-fields"),
+		controllers.NewSegmentController(app, "/crm/segments"),
 		controllers.NewChatController(app, "/crm/chats"),
+		controllers.NewTeamController(app, "/crm/teams"),
+		controllers.NewWorkloadController(app, "/crm/workload"),
 		controllers.NewMessageTemplateController(app, "/crm/instant-messages"),
-		controllers.NewTwilioController(app, twilioProvider),
+		controllers.NewCampaignController(app, "/crm/campaigns"),
+		controllers.NewPipelineController(app, "/crm/pipelines"),
+		controllers.NewDealController(app, "/crm/deals"),
 	)
+	app.RegisterControllers(webhookControllers...)
 
 	handlers.RegisterSMSHandlers(app)
-	if botToken := conf.TelegramBotToken; botToken != "" {
-		handlers.RegisterNotificationHandler(app, botToken)
-	}
+	handlers.RegisterNotificationHandler(app, clientRepo, teamRepo)
+	handlers.RegisterCampaignHandlers(app, campaignService)
+	handlers.RegisterCampaignJob(app, campaignService)
+	handlers.RegisterChatSLAJob(app, chatsService)
+	handlers.RegisterTimelineHandler(app, activityService)
 
 	app.RBAC().Register(permissions.Permissions...)
 	app.RegisterLocaleFiles(&localeFiles)
 	app.RegisterMigrationDirs(&migrationFiles)
+	app.RegisterGraphSchema(application.GraphSchema{
+		Value: graph.NewExecutableSchema(graph.Config{
+			Resolvers: graph.NewResolver(app),
+		}),
+		BasePath: "/crm",
+	})
 	return nil
 }
 
@@ -7,7 +7,10 @@ import (
 )
 
 const (
-	ResourceClient permission.Resource = "client"
+	ResourceClient   permission.Resource = "client"
+	ResourceDeal     permission.Resource = "deal"
+	ResourceCampaign permission.Resource = "campaign"
+	ResourceTeam     permission.Resource = "team"
 )
 
 var (
@@ -39,6 +42,90 @@ var (
 		Action:   permission.ActionDelete,
 		Modifier: permission.ModifierAll,
 	}
+	DealCreate = &permission.Permission{
+		ID:       uuid.MustParse("54d12eaa-553b-4056-9caa-3a9cdfc30786"),
+		Name:     "Deal.Create",
+		Resource: ResourceDeal,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	DealRead = &permission.Permission{
+		ID:       uuid.MustParse("b931d175-8443-4d97-b210-1b754d401a92"),
+		Name:     "Deal.Read",
+		Resource: ResourceDeal,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	DealUpdate = &permission.Permission{
+		ID:       uuid.MustParse("38afd81d-314a-4f1e-ba0b-0504320240a3"),
+		Name:     "Deal.Update",
+		Resource: ResourceDeal,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	DealDelete = &permission.Permission{
+		ID:       uuid.MustParse("74cc4891-3fc0-408a-a8fa-99990170570c"),
+		Name:     "Deal.Delete",
+		Resource: ResourceDeal,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	CampaignCreate = &permission.Permission{
+		ID:       uuid.MustParse("4d2d197c-6f97-48e6-86d4-79a004f6b09b"),
+		Name:     "Campaign.Create",
+		Resource: ResourceCampaign,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	CampaignRead = &permission.Permission{
+		ID:       uuid.MustParse("4824219e-a9d2-43f1-8ec3-1b8ce5ec2bd4"),
+		Name:     "Campaign.Read",
+		Resource: ResourceCampaign,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	CampaignUpdate = &permission.Permission{
+		ID:       uuid.MustParse("eb1e58ec-35d4-4968-a07f-e6e366796eeb"),
+		Name:     "Campaign.Update",
+		Resource: ResourceCampaign,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	CampaignDelete = &permission.Permission{
+		ID:       uuid.MustParse("05339cc5-dfb0-452c-bd22-b123c917ccd0"),
+		Name:     "Campaign.Delete",
+		Resource: ResourceCampaign,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	TeamCreate = &permission.Permission{
+		ID:       uuid.MustParse("108c2c88-7b05-4f14-afaf-54cb5eeaaf8e"),
+		Name:     "Team.Create",
+		Resource: ResourceTeam,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	TeamRead = &permission.Permission{
+		ID:       uuid.MustParse("bdeb5f31-dc8b-41b7-b113-6d9b38ed10f3"),
+		Name:     "Team.Read",
+		Resource: ResourceTeam,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	TeamUpdate = &permission.Permission{
+		ID:       uuid.MustParse("569d9f40-bf33-4721-97c2-6ea1d569b8d1"),
+		Name:     "Team.Update",
+		Resource: ResourceTeam,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	TeamDelete = &permission.Permission{
+		ID:       uuid.MustParse("5e642a29-225c-491d-9b84-7b3f8098dbdb"),
+		Name:     "Team.Delete",
+		Resource: ResourceTeam,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
 )
 
 var Permissions = []*permission.Permission{
@@ -46,4 +133,16 @@ var Permissions = []*permission.Permission{
 	ClientRead,
 	ClientUpdate,
 	ClientDelete,
+	DealCreate,
+	DealRead,
+	DealUpdate,
+	DealDelete,
+	CampaignCreate,
+	CampaignRead,
+	CampaignUpdate,
+	CampaignDelete,
+	TeamCreate,
+	TeamRead,
+	TeamUpdate,
+	TeamDelete,
 }
@@ -0,0 +1,452 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/campaign"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	campaignsui "github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/campaigns"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type CampaignController struct {
+	app             application.Application
+	campaignService *services.CampaignService
+	templateService *services.MessageTemplateService
+	segmentService  *services.SegmentService
+	clientService   *services.ClientService
+	basePath        string
+}
+
+func NewCampaignController(app application.Application, basePath string) application.Controller {
+	return &CampaignController{
+		app:             app,
+		campaignService: app.Service(services.CampaignService{}).(*services.CampaignService),
+		templateService: app.Service(services.MessageTemplateService{}).(*services.MessageTemplateService),
+		segmentService:  app.Service(services.SegmentService{}).(*services.SegmentService),
+		clientService:   app.Service(services.ClientService{}).(*services.ClientService),
+		basePath:        basePath,
+	}
+}
+
+func (c *CampaignController) Key() string {
+	return c.basePath
+}
+
+func (c *CampaignController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetByID).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/start", c.Start).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/pause", c.Pause).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/resume", c.Resume).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/cancel", c.Cancel).Methods(http.MethodPost)
+}
+
+// names returns the names of the templates and the segments by ID.
+func (c *CampaignController) names(r *http.Request) (map[uint]string, map[uint]string, error) {
+	templates, err := c.templateService.GetAll(r.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	segments, err := c.segmentService.GetAll(r.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	templateNames := make(map[uint]string, len(templates))
+	for _, t := range templates {
+		templateNames[t.ID()] = t.Name()
+	}
+	segmentNames := make(map[uint]string, len(segments))
+	for _, s := range segments {
+		segmentNames[s.ID()] = s.Name()
+	}
+	return templateNames, segmentNames, nil
+}
+
+func (c *CampaignController) viewModelCampaign(r *http.Request, entity campaign.Campaign) (*viewmodels.Campaign, error) {
+	templateNames, segmentNames, err := c.names(r)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := c.campaignService.Stats(r.Context(), entity.ID())
+	if err != nil {
+		return nil, err
+	}
+	return mappers.CampaignToViewModel(entity, templateNames[entity.TemplateID()], segmentNames[entity.SegmentID()], stats), nil
+}
+
+// formProps returns the props of the draft form with the choices of templates, segments and
+// channels.
+func (c *CampaignController) formProps(r *http.Request, vm *viewmodels.Campaign, errorsMap map[string]string) (*campaignsui.FieldsProps, error) {
+	templates, err := c.templateService.GetAll(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	segments, err := c.segmentService.GetAll(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	channels := make([]string, 0)
+	for _, ch := range c.campaignService.Channels() {
+		channels = append(channels, string(ch))
+	}
+	return &campaignsui.FieldsProps{
+		Campaign:  vm,
+		Templates: mapping.MapViewModels(templates, mappers.MessageTemplateToViewModel),
+		Segments:  mapping.MapViewModels(segments, mappers.SegmentToViewModel),
+		Channels:  channels,
+		Errors:    errorsMap,
+	}, nil
+}
+
+func (c *CampaignController) renderCreateForm(w http.ResponseWriter, r *http.Request, vm *viewmodels.Campaign, errorsMap map[string]string, page bool) {
+	fields, err := c.formProps(r, vm, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &campaignsui.CreatePageProps{
+		Campaign:  fields.Campaign,
+		Templates: fields.Templates,
+		Segments:  fields.Segments,
+		Channels:  fields.Channels,
+		Errors:    fields.Errors,
+		SaveURL:   c.basePath,
+	}
+	if page {
+		templ.Handler(campaignsui.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(campaignsui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *CampaignController) renderEditForm(w http.ResponseWriter, r *http.Request, id uint, vm *viewmodels.Campaign, errorsMap map[string]string, page bool) {
+	fields, err := c.formProps(r, vm, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &campaignsui.EditPageProps{
+		Campaign:  fields.Campaign,
+		Templates: fields.Templates,
+		Segments:  fields.Segments,
+		Channels:  fields.Channels,
+		Errors:    fields.Errors,
+		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+		StartURL:  fmt.Sprintf("%s/%d/start", c.basePath, id),
+	}
+	if page {
+		templ.Handler(campaignsui.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(campaignsui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *CampaignController) renderShow(w http.ResponseWriter, r *http.Request, entity campaign.Campaign, errorMessage string, page bool) {
+	vm, err := c.viewModelCampaign(r, entity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	paginationParams := composables.UsePaginated(r)
+	params := &campaign.RecipientFindParams{
+		CampaignID: entity.ID(),
+		Limit:      paginationParams.Limit,
+		Offset:     paginationParams.Offset,
+	}
+	recipients, err := c.campaignService.GetRecipients(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	total, err := c.campaignService.CountRecipients(r.Context(), &campaign.RecipientFindParams{CampaignID: entity.ID()})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	recipientViewModels := make([]*viewmodels.CampaignRecipient, 0, len(recipients))
+	for _, recipient := range recipients {
+		clientEntity, err := c.clientService.GetByID(r.Context(), recipient.ClientID())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		clientName := mappers.ClientToViewModel(clientEntity).FullName()
+		recipientViewModels = append(recipientViewModels, mappers.CampaignRecipientToViewModel(recipient, clientName))
+	}
+	props := &campaignsui.ShowPageProps{
+		Campaign:        vm,
+		Recipients:      recipientViewModels,
+		PaginationState: pagination.New(fmt.Sprintf("%s/%d", c.basePath, entity.ID()), paginationParams.Page, int(total), params.Limit),
+		BaseURL:         c.basePath,
+		Error:           errorMessage,
+	}
+	if page {
+		templ.Handler(campaignsui.Show(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(campaignsui.ShowContent(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// campaignFromForm keeps the submitted values when the form is rendered again with errors.
+func campaignFromForm(dto *campaign.SaveDTO) *viewmodels.Campaign {
+	vm := &viewmodels.Campaign{
+		Name:          dto.Name,
+		TemplateID:    fmt.Sprintf("%d", dto.TemplateID),
+		Channel:       dto.Channel,
+		Locale:        dto.Locale,
+		RatePerMinute: fmt.Sprintf("%d", dto.RatePerMinute),
+		Status:        string(campaign.Draft),
+	}
+	if dto.SegmentID != 0 {
+		vm.SegmentID = fmt.Sprintf("%d", dto.SegmentID)
+	}
+	return vm
+}
+
+func (c *CampaignController) List(w http.ResponseWriter, r *http.Request) {
+	entities, err := c.campaignService.GetPaginated(r.Context(), &campaign.FindParams{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templateNames, segmentNames, err := c.names(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	campaigns := make([]*viewmodels.Campaign, 0, len(entities))
+	for _, entity := range entities {
+		stats, err := c.campaignService.Stats(r.Context(), entity.ID())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		campaigns = append(campaigns, mappers.CampaignToViewModel(
+			entity,
+			templateNames[entity.TemplateID()],
+			segmentNames[entity.SegmentID()],
+			stats,
+		))
+	}
+	props := &campaignsui.IndexPageProps{
+		BaseURL:   c.basePath,
+		NewURL:    fmt.Sprintf("%s/new", c.basePath),
+		Campaigns: campaigns,
+	}
+	templ.Handler(campaignsui.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *CampaignController) GetNew(w http.ResponseWriter, r *http.Request) {
+	vm := &viewmodels.Campaign{
+		Locale:        composables.UsePageCtx(r.Context()).Locale.String(),
+		RatePerMinute: fmt.Sprintf("%d", campaign.DefaultRatePerMinute),
+		Status:        string(campaign.Draft),
+	}
+	c.renderCreateForm(w, r, vm, map[string]string{}, true)
+}
+
+// GetByID shows the form of a draft campaign and the progress of a started one.
+func (c *CampaignController) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.campaignService.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrCampaignNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if entity.Status() != campaign.Draft {
+		c.renderShow(w, r, entity, "", true)
+		return
+	}
+	vm, err := c.viewModelCampaign(r, entity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderEditForm(w, r, id, vm, map[string]string{}, true)
+}
+
+func (c *CampaignController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&campaign.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		c.renderCreateForm(w, r, campaignFromForm(dto), errorsMap, false)
+		return
+	}
+	entity, err := c.campaignService.Create(r.Context(), dto)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, entity.ID()))
+}
+
+func (c *CampaignController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&campaign.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vm := campaignFromForm(dto)
+	vm.ID = fmt.Sprintf("%d", id)
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		c.renderEditForm(w, r, id, vm, errorsMap, false)
+		return
+	}
+	if _, err := c.campaignService.Update(r.Context(), id, dto); err != nil {
+		if !errors.Is(err, campaign.ErrNotDraft) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *CampaignController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.campaignService.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, campaign.ErrActive) {
+			http.Error(w, composables.MustT(r.Context(), "Campaigns.Errors.Active"), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+// Start sends a draft campaign to its recipients. A campaign that cannot start, because nobody
+// in the segment can be reached or the channel is not configured, stays a draft.
+func (c *CampaignController) Start(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, err = c.campaignService.Start(r.Context(), id)
+	if err == nil {
+		shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+		return
+	}
+	var errorMessage string
+	switch {
+	case errors.Is(err, campaign.ErrNoRecipients):
+		errorMessage = composables.MustT(r.Context(), "Campaigns.Errors.NoRecipients")
+	case errors.Is(err, services.ErrChannelUnavailable):
+		errorMessage = composables.MustT(r.Context(), "Campaigns.Errors.ChannelUnavailable")
+	case errors.Is(err, campaign.ErrInvalidTransition):
+		shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entity, err := c.campaignService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	vm, err := c.viewModelCampaign(r, entity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderEditForm(w, r, id, vm, map[string]string{"Campaign": errorMessage}, false)
+}
+
+func (c *CampaignController) Pause(w http.ResponseWriter, r *http.Request) {
+	c.changeStatus(w, r, c.campaignService.Pause)
+}
+
+func (c *CampaignController) Resume(w http.ResponseWriter, r *http.Request) {
+	c.changeStatus(w, r, c.campaignService.Resume)
+}
+
+func (c *CampaignController) Cancel(w http.ResponseWriter, r *http.Request) {
+	c.changeStatus(w, r, c.campaignService.Cancel)
+}
+
+// changeStatus applies a status change and renders the campaign again, with an error when the
+// campaign has moved on in the meantime, for example completed before it could be paused.
+func (c *CampaignController) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, id uint) (campaign.Campaign, error),
+) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := change(r.Context(), id)
+	if err == nil {
+		c.renderShow(w, r, entity, "", false)
+		return
+	}
+	if !errors.Is(err, campaign.ErrInvalidTransition) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entity, err = c.campaignService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderShow(w, r, entity, composables.MustT(r.Context(), "Campaigns.Errors.InvalidTransition"), false)
+}
@@ -15,11 +15,14 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/phone"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
 	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
-	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/websocket"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/chats"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
@@ -30,6 +33,14 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
 	"github.com/iota-uz/iota-sdk/pkg/shared"
 	"github.com/iota-uz/iota-sdk/pkg/types"
+	"github.com/iota-uz/iota-sdk/pkg/ws"
+)
+
+const (
+	// Websocket room of the chats list
+	chatsRoom = "chats"
+	// Websocket rooms of the messages of a single chat
+	chatRoom = "chat"
 )
 
 type CreateChatDTO struct {
@@ -37,26 +48,48 @@ type CreateChatDTO struct {
 }
 
 type SendMessageDTO struct {
-	Message string
+	Channel    string
+	Message    string
+	TemplateID uint
+	Locale     string
+}
+
+type ChatTemplatesQuery struct {
+	Locale string
+}
+
+type ChatSearchQuery struct {
+	Query    string
+	Assignee string
+	Status   string
+}
+
+type AssignChatDTO struct {
+	AssigneeID uint
+	TeamID     uint
+}
+
+type ChatStatusDTO struct {
+	Status string
 }
 
 type ChatController struct {
 	app             application.Application
-	wsHandler       *websocket.Hub
 	userService     *coreservices.UserService
 	templateService *services.MessageTemplateService
 	clientService   *services.ClientService
 	chatService     *services.ChatService
+	teamService     *services.TeamService
 	basePath        string
 }
 
 func NewChatController(app application.Application, basePath string) application.Controller {
 	return &ChatController{
 		app:             app,
-		wsHandler:       websocket.NewHub(),
 		userService:     app.Service(coreservices.UserService{}).(*coreservices.UserService),
 		clientService:   app.Service(services.ClientService{}).(*services.ClientService),
 		chatService:     app.Service(services.ChatService{}).(*services.ChatService),
+		teamService:     app.Service(services.TeamService{}).(*services.TeamService),
 		templateService: app.Service(services.MessageTemplateService{}).(*services.MessageTemplateService),
 		basePath:        basePath,
 	}
@@ -80,10 +113,14 @@ func (c *ChatController) Register(r *mux.Router) {
 	router.HandleFunc("", c.List).Methods(http.MethodGet)
 	router.HandleFunc("/search", c.Search).Methods(http.MethodGet)
 	router.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
-	router.Handle("/ws", c.wsHandler)
 	router.HandleFunc("", c.Create).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9]+}/messages", c.SendMessage).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/templates", c.Templates).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}/assign", c.Assign).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/status", c.SetStatus).Methods(http.MethodPost)
 
+	c.app.Websocket().RegisterRoom(chatsRoom, ws.RequirePermissions(permissions.ClientRead))
+	c.app.Websocket().RegisterRoom(chatRoom, ws.RequirePermissions(permissions.ClientRead))
 	c.app.EventPublisher().Subscribe(c.onMessageAdded)
 	c.app.EventPublisher().Subscribe(c.onChatCreated)
 }
@@ -135,7 +172,7 @@ func (c *ChatController) onMessageAdded(event *chat.MessagedAddedEvent) {
 		log.Printf("Error rendering chat messages: %v", err)
 		return
 	}
-	c.wsHandler.Broadcast(buf.Bytes())
+	c.app.Websocket().Broadcast(ws.Room(chatRoom, event.Result.ID()), buf.Bytes())
 	c.broadcastChatsListUpdate(ctx)
 }
 
@@ -159,15 +196,86 @@ func (c *ChatController) broadcastChatsListUpdate(ctx context.Context) {
 		log.Printf("Error rendering chat list: %v", err)
 		return
 	}
-	c.wsHandler.Broadcast(buf.Bytes())
+	c.app.Websocket().Broadcast(chatsRoom, buf.Bytes())
 }
 
-func (c *ChatController) messageTemplates(ctx context.Context) ([]*viewmodels.MessageTemplate, error) {
+// messageTemplates renders the templates for the client of the chat in the variant for its reply channel.
+func (c *ChatController) messageTemplates(
+	ctx context.Context,
+	chatEntity chat.Chat,
+	clientEntity client.Client,
+	locale string,
+) ([]*viewmodels.RenderedMessageTemplate, error) {
 	templates, err := c.templateService.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return mapping.MapViewModels(templates, mappers.MessageTemplateToViewModel), nil
+	values := messagetemplate.ClientValues(clientEntity, nil)
+	result := make([]*viewmodels.RenderedMessageTemplate, 0, len(templates))
+	for _, t := range templates {
+		rendered := t.Render(locale, chatEntity.ReplyChannel(), values)
+		result = append(result, mappers.RenderedMessageTemplateToViewModel(t, rendered))
+	}
+	return result, nil
+}
+
+// userLocale is the language templates are rendered in unless the operator picks another one.
+func (c *ChatController) userLocale(ctx context.Context) string {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return ""
+	}
+	return string(u.UILanguage())
+}
+
+func (c *ChatController) selectedChatProps(
+	ctx context.Context,
+	chatEntity chat.Chat,
+	clientEntity client.Client,
+) (chatsui.SelectedChatProps, error) {
+	locale := c.userLocale(ctx)
+	messageTemplates, err := c.messageTemplates(ctx, chatEntity, clientEntity, locale)
+	if err != nil {
+		return chatsui.SelectedChatProps{}, err
+	}
+	users, err := c.userService.GetAll(ctx)
+	if err != nil {
+		return chatsui.SelectedChatProps{}, err
+	}
+	teams, err := c.teamService.GetAll(ctx)
+	if err != nil {
+		return chatsui.SelectedChatProps{}, err
+	}
+	return chatsui.SelectedChatProps{
+		BaseURL:    c.basePath,
+		ClientsURL: "/crm/clients",
+		Chat:       mappers.ChatToViewModel(chatEntity, clientEntity),
+		Templates:  messageTemplates,
+		Locale:     locale,
+		Channels:   c.channels(),
+		Room:       ws.Room(chatRoom, chatEntity.ID()),
+		Users:      mapping.MapViewModels(users, coremappers.UserToViewModel),
+		Teams:      mapping.MapViewModels(teams, mappers.TeamToViewModel),
+		Statuses:   chatStatuses(),
+		SLA:        mappers.ChatSLAToViewModel(chatEntity, c.chatService.SLAPolicy()),
+	}, nil
+}
+
+func chatStatuses() []string {
+	result := make([]string, 0, len(chat.Statuses))
+	for _, s := range chat.Statuses {
+		result = append(result, string(s))
+	}
+	return result
+}
+
+func (c *ChatController) channels() []string {
+	channels := c.chatService.Channels()
+	result := make([]string, 0, len(channels))
+	for _, ch := range channels {
+		result = append(result, string(ch))
+	}
+	return result
 }
 
 func (c *ChatController) chatViewModels(
@@ -189,17 +297,37 @@ func (c *ChatController) chatViewModels(
 }
 
 func (c *ChatController) Search(w http.ResponseWriter, r *http.Request) {
-	searchQ := r.URL.Query().Get("Query")
-	chatViewModels, err := c.chatViewModels(
-		r.Context(),
-		&chat.FindParams{
-			Search: searchQ,
-			SortBy: chat.SortBy{
-				Fields:    []chat.Field{chat.LastMessageAt},
-				Ascending: false,
-			},
+	query, err := composables.UseQuery(&ChatSearchQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := &chat.FindParams{
+		Search: query.Query,
+		SortBy: chat.SortBy{
+			Fields:    []chat.Field{chat.LastMessageAt},
+			Ascending: false,
 		},
-	)
+	}
+	if query.Status != "" {
+		params.Status, err = chat.NewStatus(query.Status)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	switch query.Assignee {
+	case chatsui.AssigneeMe:
+		u, err := composables.UseUser(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		params.AssigneeID = u.ID()
+	case chatsui.AssigneeNobody:
+		params.Unassigned = true
+	}
+	chatViewModels, err := c.chatViewModels(r.Context(), params)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -223,10 +351,11 @@ func (c *ChatController) renderChats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	props := chatsui.IndexPageProps{
-		WebsocketURL: c.basePath + "/ws",
+		WebsocketURL: "/ws?room=" + chatsRoom,
 		SearchURL:    c.basePath + "/search",
 		NewChatURL:   "/crm/chats/new",
 		Chats:        chatViewModels,
+		Statuses:     chatStatuses(),
 	}
 	var component templ.Component
 	isHxRequest := len(r.Header.Get("Hx-Request")) > 0
@@ -273,18 +402,11 @@ func (c *ChatController) List(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	messageTemplates, err := c.messageTemplates(r.Context())
+	props, err := c.selectedChatProps(r.Context(), chatEntity, clientEntity)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-
-	props := chatsui.SelectedChatProps{
-		BaseURL:    c.basePath,
-		ClientsURL: "/crm/clients",
-		Chat:       mappers.ChatToViewModel(chatEntity, clientEntity),
-		Templates:  messageTemplates,
-	}
 	c.renderChats(w, r.WithContext(templ.WithChildren(ctx, chatsui.SelectedChat(props))))
 }
 
@@ -341,29 +463,142 @@ func (c *ChatController) SendMessage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	var channel chat.Channel
+	if dto.Channel != "" {
+		channel, err = chat.NewChannel(dto.Channel)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 	chatEntity, err := c.chatService.SendMessage(r.Context(), services.SendMessageDTO{
-		ChatID:  chatID,
-		Message: dto.Message,
+		ChatID:     chatID,
+		Channel:    channel,
+		Message:    dto.Message,
+		TemplateID: dto.TemplateID,
+		Locale:     dto.Locale,
 	})
+	if errors.Is(err, services.ErrChannelUnavailable) || errors.Is(err, services.ErrNoContact) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	clientEntity, err := c.clientService.GetByID(r.Context(), chatEntity.ClientID())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.selectedChatProps(r.Context(), chatEntity, clientEntity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(chatsui.SelectedChat(props)).ServeHTTP(w, r)
+}
+
+// Templates renders the message templates of the dialog in the chosen language.
+func (c *ChatController) Templates(w http.ResponseWriter, r *http.Request) {
+	chatID, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query, err := composables.UseQuery(&ChatTemplatesQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	chatEntity, err := c.chatService.GetByID(r.Context(), chatID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	clientEntity, err := c.clientService.GetByID(r.Context(), chatEntity.ClientID())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	messageTemplates, err := c.messageTemplates(r.Context())
+	messageTemplates, err := c.messageTemplates(r.Context(), chatEntity, clientEntity, query.Locale)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	templ.Handler(chatsui.InstantMessagesList(chatsui.InstantMessagesDialogProps{
+		OnClick:   "onUseTemplate",
+		Locale:    query.Locale,
+		Templates: messageTemplates,
+	})).ServeHTTP(w, r)
+}
+
+// renderSelectedChat renders the chat after a change made from its header.
+func (c *ChatController) renderSelectedChat(w http.ResponseWriter, r *http.Request, chatEntity chat.Chat) {
 	clientEntity, err := c.clientService.GetByID(r.Context(), chatEntity.ClientID())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	props := chatsui.SelectedChatProps{
-		BaseURL:    c.basePath,
-		ClientsURL: "/crm/clients",
-		Chat:       mappers.ChatToViewModel(chatEntity, clientEntity),
-		Templates:  messageTemplates,
+	props, err := c.selectedChatProps(r.Context(), chatEntity, clientEntity)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	templ.Handler(chatsui.SelectedChat(props)).ServeHTTP(w, r)
 }
+
+func (c *ChatController) Assign(w http.ResponseWriter, r *http.Request) {
+	chatID, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&AssignChatDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	chatEntity, err := c.chatService.Assign(r.Context(), chatID, dto.AssigneeID, dto.TeamID)
+	if errors.Is(err, team.ErrNotMember) {
+		http.Error(w, composables.MustT(r.Context(), "Chats.Errors.NotMember"), http.StatusUnprocessableEntity)
+		return
+	}
+	if errors.Is(err, persistence.ErrChatNotFound) || errors.Is(err, persistence.ErrTeamNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderSelectedChat(w, r, chatEntity)
+}
+
+func (c *ChatController) SetStatus(w http.ResponseWriter, r *http.Request) {
+	chatID, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&ChatStatusDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	status, err := chat.NewStatus(dto.Status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	chatEntity, err := c.chatService.SetStatus(r.Context(), chatID, status)
+	if errors.Is(err, persistence.ErrChatNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderSelectedChat(w, r, chatEntity)
+}
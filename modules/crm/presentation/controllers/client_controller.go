@@ -3,14 +3,21 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/a-h/templ"
 	"github.com/go-faster/errors"
 	"github.com/gorilla/mux"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 
+	"github.com/iota-uz/iota-sdk/components/base"
 	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
 	chatsui "github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/chats"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/clients"
@@ -24,10 +31,13 @@ import (
 )
 
 type ClientController struct {
-	app           application.Application
-	clientService *services.ClientService
-	chatService   *services.ChatService
-	basePath      string
+	app             application.Application
+	clientService   *services.ClientService
+	chatService     *services.ChatService
+	segmentService  *services.SegmentService
+	activityService *services.ActivityService
+	userService     *coreservices.UserService
+	basePath        string
 }
 
 type ClientsPaginatedResponse struct {
@@ -37,10 +47,13 @@ type ClientsPaginatedResponse struct {
 
 func NewClientController(app application.Application, basePath string) application.Controller {
 	return &ClientController{
-		app:           app,
-		clientService: app.Service(services.ClientService{}).(*services.ClientService),
-		chatService:   app.Service(services.ChatService{}).(*services.ChatService),
-		basePath:      basePath,
+		app:             app,
+		clientService:   app.Service(services.ClientService{}).(*services.ClientService),
+		chatService:     app.Service(services.ChatService{}).(*services.ChatService),
+		segmentService:  app.Service(services.SegmentService{}).(*services.SegmentService),
+		activityService: app.Service(services.ActivityService{}).(*services.ActivityService),
+		userService:     app.Service(coreservices.UserService{}).(*coreservices.UserService),
+		basePath:        basePath,
 	}
 }
 
@@ -64,9 +77,13 @@ func (c *ClientController) Register(r *mux.Router) {
 	router.HandleFunc("", c.Create).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	router.HandleFunc("/{id:[0-9]+}/merge", c.Merge).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/activities", c.LogActivity).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/activities/{activityId:[0-9]+}", c.DeleteActivity).Methods(http.MethodDelete)
 
 	hxRouter := r.PathPrefix(c.basePath).Subrouter()
 	hxRouter.Use(commonMiddleware...)
+	hxRouter.HandleFunc("/search", c.Search).Methods(http.MethodGet)
 	hxRouter.HandleFunc("/{id:[0-9]+}", c.View).Methods(http.MethodGet)
 	hxRouter.HandleFunc("/{id:[0-9]+}/edit", c.GetEdit).Methods(http.MethodGet)
 	hxRouter.HandleFunc("/{id:[0-9]+}/tab/{tab:[a-z]+}", c.TabContents).Methods(http.MethodGet)
@@ -85,13 +102,23 @@ func (c *ClientController) viewModelClients(r *http.Request) (*ClientsPaginatedR
 	if err != nil {
 		return nil, errors.Wrap(err, "Error using query")
 	}
+	if v := r.URL.Query().Get("Segment"); v != "" {
+		segmentID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error parsing segment id")
+		}
+		params.Filter, err = c.segmentService.Filter(r.Context(), uint(segmentID))
+		if err != nil {
+			return nil, errors.Wrap(err, "Error retrieving segment")
+		}
+	}
 
 	expenseEntities, err := c.clientService.GetPaginated(r.Context(), params)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error retrieving expenses")
 	}
 
-	total, err := c.clientService.Count(r.Context())
+	total, err := c.clientService.Count(r.Context(), params)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error counting expenses")
 	}
@@ -101,15 +128,57 @@ func (c *ClientController) viewModelClients(r *http.Request) (*ClientsPaginatedR
 		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
 	}, nil
 }
+
+func (c *ClientController) viewModelFields(r *http.Request) ([]*viewmodels.ClientField, error) {
+	fields, err := c.clientService.CustomFields(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving custom fields")
+	}
+	return mapping.MapViewModels(fields, mappers.ClientFieldToViewModel), nil
+}
+
+// Search returns the clients matching the query as combobox options.
+func (c *ClientController) Search(w http.ResponseWriter, r *http.Request) {
+	entities, err := c.clientService.GetPaginated(r.Context(), &client.FindParams{
+		Query: r.URL.Query().Get("q"),
+		Field: "first_name",
+		Limit: 10,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	options := mapping.MapViewModels(entities, func(e client.Client) *base.ComboboxOption {
+		vm := mappers.ClientToViewModel(e)
+		return &base.ComboboxOption{
+			Value: vm.ID,
+			Label: fmt.Sprintf("%s (+%s)", vm.FullName(), vm.Phone),
+		}
+	})
+	templ.Handler(base.ComboboxOptions(options), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
 func (c *ClientController) List(w http.ResponseWriter, r *http.Request) {
 	paginated, err := c.viewModelClients(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	segments, err := c.segmentService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	props := &clients.IndexPageProps{
 		NewURL:          fmt.Sprintf("%s/new", c.basePath),
 		Clients:         paginated.Clients,
+		Fields:          fields,
+		Segments:        mapping.MapViewModels(segments, mappers.SegmentToViewModel),
 		PaginationState: paginated.PaginationState,
 	}
 	if shared.IsHxRequest(r) {
@@ -120,8 +189,14 @@ func (c *ClientController) List(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *ClientController) GetNew(w http.ResponseWriter, r *http.Request) {
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	props := &clients.CreatePageProps{
 		Client:  &viewmodels.Client{},
+		Fields:  fields,
 		SaveURL: c.basePath,
 	}
 	templ.Handler(clients.New(props), templ.WithStreaming()).ServeHTTP(w, r)
@@ -134,16 +209,39 @@ func (c *ClientController) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+	fields, err := c.clientService.CustomFields(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(r.Context())
+	for k, v := range client.ValidateCustomFields(r.Context(), fields, dto.CustomFields) {
+		errorsMap[k], ok = v, false
+	}
+	if !ok {
 		props := &clients.CreatePageProps{
 			Errors: errorsMap,
 			Client: &viewmodels.Client{
-				FirstName:  dto.FirstName,
-				LastName:   dto.LastName,
-				MiddleName: dto.MiddleName,
-				Phone:      dto.Phone,
+				FirstName:   dto.FirstName,
+				LastName:    dto.LastName,
+				MiddleName:  dto.MiddleName,
+				Phone:       dto.Phone,
+				Email:       dto.Email,
+				Address:     dto.Address,
+				DateOfBirth: dateOnlyValue(dto.DateOfBirth),
+				Gender:      dto.Gender,
+				HourlyRate:  strconv.FormatFloat(dto.HourlyRate, 'f', -1, 64),
+				Tags:        client.ParseTags(dto.Tags),
+				CustomFields: func() map[string]string {
+					values := make(map[string]string, len(dto.CustomFields))
+					for id, v := range dto.CustomFields {
+						values[strconv.FormatUint(uint64(id), 10)] = v
+					}
+					return values
+				}(),
 			},
-			SaveURL: fmt.Sprintf("%s", c.basePath),
+			Fields:  mapping.MapViewModels(fields, mappers.ClientFieldToViewModel),
+			SaveURL: c.basePath,
 		}
 		templ.Handler(clients.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
 		return
@@ -169,8 +267,14 @@ func (c *ClientController) GetEdit(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Error retrieving client", http.StatusInternalServerError)
 		return
 	}
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	props := &clients.EditPageProps{
 		Client:    mappers.ClientToViewModel(entity),
+		Fields:    fields,
 		Errors:    map[string]string{},
 		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
 		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
@@ -208,6 +312,18 @@ func (c *ClientController) buildViewLayoutProps(
 				}),
 				URL: fmt.Sprintf("%s/tab/chat", clientURL),
 			},
+			{
+				Name: localizer.MustLocalize(&i18n.LocalizeConfig{
+					MessageID: "Clients.Tabs.Timeline",
+				}),
+				URL: fmt.Sprintf("%s/tab/timeline", clientURL),
+			},
+			{
+				Name: localizer.MustLocalize(&i18n.LocalizeConfig{
+					MessageID: "Clients.Tabs.Duplicates",
+				}),
+				URL: fmt.Sprintf("%s/tab/duplicates", clientURL),
+			},
 		},
 	}
 }
@@ -217,7 +333,18 @@ func (c *ClientController) tabToComponent(r *http.Request, clientID uint, tab st
 	case "":
 		fallthrough
 	case "general":
-		return clients.General(), nil
+		entity, err := c.clientService.GetByID(r.Context(), clientID)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error retrieving client")
+		}
+		fields, err := c.viewModelFields(r)
+		if err != nil {
+			return nil, err
+		}
+		return clients.General(&clients.GeneralProps{
+			Client: mappers.ClientToViewModel(entity),
+			Fields: fields,
+		}), nil
 	case "chat":
 		entity, err := c.clientService.GetByID(r.Context(), clientID)
 		if err != nil {
@@ -231,6 +358,21 @@ func (c *ClientController) tabToComponent(r *http.Request, clientID uint, tab st
 			Chat:       mappers.ChatToViewModel(chatEntity, entity),
 			ClientsURL: c.basePath,
 		}), nil
+	case "timeline":
+		query, err := composables.UseQuery(&TimelineQuery{}, r)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error using query")
+		}
+		return c.timeline(r, clientID, query, clients.TimelineForm{}, map[string]string{})
+	case "duplicates":
+		duplicates, err := c.clientService.FindDuplicates(r.Context(), clientID)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error finding duplicates")
+		}
+		return clients.Duplicates(&clients.DuplicatesProps{
+			MergeURL:   fmt.Sprintf("%s/%d/merge", c.basePath, clientID),
+			Duplicates: mapping.MapViewModels(duplicates, mappers.ClientDuplicateToViewModel),
+		}), nil
 	default:
 		return clients.NotFound(), nil
 	}
@@ -289,7 +431,16 @@ func (c *ClientController) Update(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+	fields, err := c.clientService.CustomFields(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(r.Context())
+	for k, v := range client.ValidateCustomFields(r.Context(), fields, dto.CustomFields) {
+		errorsMap[k], ok = v, false
+	}
+	if !ok {
 		entity, err := c.clientService.GetByID(r.Context(), id)
 		if err != nil {
 			http.Error(w, "Error retrieving expense", http.StatusInternalServerError)
@@ -297,6 +448,7 @@ func (c *ClientController) Update(w http.ResponseWriter, r *http.Request) {
 		}
 		props := &clients.EditPageProps{
 			Client:    mappers.ClientToViewModel(entity),
+			Fields:    mapping.MapViewModels(fields, mappers.ClientFieldToViewModel),
 			Errors:    errorsMap,
 			SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
 			DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
@@ -324,3 +476,166 @@ func (c *ClientController) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	shared.Redirect(w, r, c.basePath)
 }
+
+func dateOnlyValue(d shared.DateOnly) string {
+	if t := time.Time(d); !t.IsZero() {
+		return t.Format(time.DateOnly)
+	}
+	return ""
+}
+
+type ClientMergeDTO struct {
+	DuplicateID uint
+}
+
+// Merge folds the client from the form into the client of the URL.
+func (c *ClientController) Merge(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusInternalServerError)
+		return
+	}
+	dto, err := composables.UseForm(&ClientMergeDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.clientService.Merge(r.Context(), id, dto.DuplicateID); err != nil {
+		if errors.Is(err, client.ErrMergeIntoSelf) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+// TimelineQuery filters the timeline tab, To is the last day shown.
+type TimelineQuery struct {
+	Kind string
+	From shared.DateOnly
+	To   shared.DateOnly
+}
+
+// timelineLimit is how many entries the timeline tab shows, filters narrow down older ones.
+const timelineLimit = 100
+
+func (c *ClientController) timeline(
+	r *http.Request,
+	clientID uint,
+	query *TimelineQuery,
+	form clients.TimelineForm,
+	errorsMap map[string]string,
+) (templ.Component, error) {
+	params := &activity.TimelineParams{
+		ClientID: clientID,
+		From:     time.Time(query.From),
+		Limit:    timelineLimit,
+	}
+	if kind, err := activity.NewKind(query.Kind); err == nil {
+		params.Kinds = []activity.Kind{kind}
+	}
+	if to := time.Time(query.To); !to.IsZero() {
+		params.To = to.AddDate(0, 0, 1)
+	}
+	entries, err := c.activityService.Timeline(r.Context(), params)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving timeline")
+	}
+	users, err := c.userService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving users")
+	}
+	names := make(map[uint]string, len(users))
+	for _, u := range users {
+		names[u.ID()] = coremappers.UserToViewModel(u).FullName()
+	}
+	kinds := make([]string, 0, len(activity.Kinds))
+	for _, k := range activity.Kinds {
+		kinds = append(kinds, string(k))
+	}
+	clientURL := fmt.Sprintf("%s/%d", c.basePath, clientID)
+	return clients.Timeline(&clients.TimelineProps{
+		TimelineURL:   fmt.Sprintf("%s/tab/timeline", clientURL),
+		ActivitiesURL: fmt.Sprintf("%s/activities", clientURL),
+		Entries: mapping.MapViewModels(entries, func(e activity.Entry) *viewmodels.TimelineEntry {
+			return mappers.TimelineEntryToViewModel(e, names)
+		}),
+		Kinds: kinds,
+		Filter: clients.TimelineFilter{
+			Kind: query.Kind,
+			From: dateOnlyValue(query.From),
+			To:   dateOnlyValue(query.To),
+		},
+		Form:   form,
+		Errors: errorsMap,
+	}), nil
+}
+
+func (c *ClientController) renderTimeline(w http.ResponseWriter, r *http.Request, component templ.Component, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(component, templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// LogActivity logs a note or a call and renders the timeline again.
+func (c *ClientController) LogActivity(w http.ResponseWriter, r *http.Request) {
+	clientID, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusInternalServerError)
+		return
+	}
+	dto, err := composables.UseForm(&activity.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		form := clients.TimelineForm{
+			Kind:       dto.Kind,
+			Body:       dto.Body,
+			Direction:  dto.Direction,
+			Minutes:    strconv.Itoa(dto.Minutes),
+			OccurredAt: dateOnlyValue(dto.OccurredAt),
+		}
+		component, err := c.timeline(r, clientID, &TimelineQuery{}, form, errorsMap)
+		c.renderTimeline(w, r, component, err)
+		return
+	}
+	if _, err := c.activityService.Log(r.Context(), clientID, dto); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	component, err := c.timeline(r, clientID, &TimelineQuery{}, clients.TimelineForm{}, map[string]string{})
+	c.renderTimeline(w, r, component, err)
+}
+
+func (c *ClientController) DeleteActivity(w http.ResponseWriter, r *http.Request) {
+	clientID, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, "Error parsing id", http.StatusInternalServerError)
+		return
+	}
+	activityID, err := strconv.ParseUint(mux.Vars(r)["activityId"], 10, 64)
+	if err != nil {
+		http.Error(w, "Error parsing activity id", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.activityService.Delete(r.Context(), clientID, uint(activityID)); err != nil {
+		if errors.Is(err, activity.ErrNotLoggable) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, persistence.ErrActivityNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	component, err := c.timeline(r, clientID, &TimelineQuery{}, clients.TimelineForm{}, map[string]string{})
+	c.renderTimeline(w, r, component, err)
+}
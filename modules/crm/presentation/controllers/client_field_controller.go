@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	clientfieldsui "github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/client-fields"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type ClientFieldController struct {
+	app                application.Application
+	customFieldService *services.CustomFieldService
+	basePath           string
+}
+
+func NewClientFieldController(app application.Application, basePath string) application.Controller {
+	return &ClientFieldController{
+		app:                app,
+		customFieldService: app.Service(services.CustomFieldService{}).(*services.CustomFieldService),
+		basePath:           basePath,
+	}
+}
+
+func (c *ClientFieldController) Key() string {
+	return c.basePath
+}
+
+func (c *ClientFieldController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+}
+
+func (c *ClientFieldController) List(w http.ResponseWriter, r *http.Request) {
+	fields, err := c.customFieldService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &clientfieldsui.IndexPageProps{
+		BaseURL: c.basePath,
+		NewURL:  fmt.Sprintf("%s/new", c.basePath),
+		Fields:  mapping.MapViewModels(fields, mappers.ClientFieldToViewModel),
+	}
+	templ.Handler(clientfieldsui.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *ClientFieldController) GetNew(w http.ResponseWriter, r *http.Request) {
+	props := &clientfieldsui.CreatePageProps{
+		SaveURL: c.basePath,
+		Field:   &viewmodels.ClientField{Type: string(customfield.Text), Position: "0"},
+		Errors:  map[string]string{},
+	}
+	templ.Handler(clientfieldsui.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *ClientFieldController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.customFieldService.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrCustomFieldNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	props := &clientfieldsui.EditPageProps{
+		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+		Field:     mappers.ClientFieldToViewModel(entity),
+		Errors:    map[string]string{},
+	}
+	templ.Handler(clientfieldsui.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *ClientFieldController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&customfield.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	props := &clientfieldsui.CreatePageProps{
+		SaveURL: c.basePath,
+		Field: &viewmodels.ClientField{
+			Key:      dto.Key,
+			Label:    dto.Label,
+			Type:     dto.Type,
+			Options:  customfield.ParseOptions(dto.Options),
+			Required: dto.Required,
+			Position: strconv.Itoa(dto.Position),
+		},
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		props.Errors = errorsMap
+		templ.Handler(clientfieldsui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.customFieldService.Create(r.Context(), dto); err != nil {
+		if !errors.Is(err, customfield.ErrKeyTaken) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Errors = map[string]string{
+			"Key": composables.MustT(r.Context(), "ClientFields.Errors.KeyTaken"),
+		}
+		templ.Handler(clientfieldsui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *ClientFieldController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&customfield.UpdateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		entity, err := c.customFieldService.GetByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props := &clientfieldsui.EditPageProps{
+			SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+			DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+			Field: &viewmodels.ClientField{
+				ID:       strconv.FormatUint(uint64(id), 10),
+				Key:      entity.Key(),
+				Label:    dto.Label,
+				Type:     dto.Type,
+				Options:  customfield.ParseOptions(dto.Options),
+				Required: dto.Required,
+				Position: strconv.Itoa(dto.Position),
+			},
+			Errors: errorsMap,
+		}
+		templ.Handler(clientfieldsui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.customFieldService.Update(r.Context(), id, dto); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *ClientFieldController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.customFieldService.Delete(r.Context(), id)
+	if err == nil {
+		shared.Redirect(w, r, c.basePath)
+		return
+	}
+	if !errors.Is(err, customfield.ErrFieldInUse) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entity, err = c.customFieldService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &clientfieldsui.EditPageProps{
+		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+		Field:     mappers.ClientFieldToViewModel(entity),
+		Errors: map[string]string{
+			"Field": composables.MustT(r.Context(), "ClientFields.Errors.InUse"),
+		},
+	}
+	templ.Handler(clientfieldsui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
@@ -0,0 +1,513 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	dealsui "github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/deals"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type DealBoardQuery struct {
+	PipelineID uint
+	OwnerID    uint
+	Search     string
+}
+
+type DealController struct {
+	app             application.Application
+	dealService     *services.DealService
+	pipelineService *services.PipelineService
+	clientService   *services.ClientService
+	userService     *coreservices.UserService
+	currencyService *coreservices.CurrencyService
+	basePath        string
+}
+
+func NewDealController(app application.Application, basePath string) application.Controller {
+	return &DealController{
+		app:             app,
+		dealService:     app.Service(services.DealService{}).(*services.DealService),
+		pipelineService: app.Service(services.PipelineService{}).(*services.PipelineService),
+		clientService:   app.Service(services.ClientService{}).(*services.ClientService),
+		userService:     app.Service(coreservices.UserService{}).(*coreservices.UserService),
+		currencyService: app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService),
+		basePath:        basePath,
+	}
+}
+
+func (c *DealController) Key() string {
+	return c.basePath
+}
+
+func (c *DealController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.Board).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/move", c.Move).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/convert", c.Convert).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+}
+
+func (c *DealController) userNames(r *http.Request) (map[uint]string, error) {
+	users, err := c.userService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving users")
+	}
+	names := make(map[uint]string, len(users))
+	for _, u := range users {
+		names[u.ID()] = strings.TrimSpace(u.FirstName() + " " + u.LastName())
+	}
+	return names, nil
+}
+
+// dealViewModels maps deals of a pipeline, looking every client up once.
+func (c *DealController) dealViewModels(
+	r *http.Request,
+	p pipeline.Pipeline,
+	deals []deal.Deal,
+	userNames map[uint]string,
+) ([]*viewmodels.Deal, error) {
+	clients := map[uint]client.Client{}
+	vms := make([]*viewmodels.Deal, 0, len(deals))
+	for _, d := range deals {
+		clientEntity, ok := clients[d.ClientID()]
+		if !ok {
+			var err error
+			clientEntity, err = c.clientService.GetByID(r.Context(), d.ClientID())
+			if err != nil {
+				return nil, errors.Wrap(err, "Error retrieving client")
+			}
+			clients[d.ClientID()] = clientEntity
+		}
+		vms = append(vms, mappers.DealToViewModel(d, p, clientEntity, userNames))
+	}
+	return vms, nil
+}
+
+// columnTotal sums the values of the deals of a column by currency.
+func columnTotal(deals []deal.Deal) string {
+	totals := map[string]float64{}
+	for _, d := range deals {
+		totals[string(d.Value().Currency())] += d.Value().Value()
+	}
+	codes := make([]string, 0, len(totals))
+	for code := range totals {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprintf("%.2f %s", totals[code], code))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (c *DealController) boardProps(r *http.Request, pipelineID uint, query *DealBoardQuery) (*dealsui.BoardPageProps, error) {
+	pipelines, err := c.pipelineService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving pipelines")
+	}
+	props := &dealsui.BoardPageProps{
+		BaseURL:      c.basePath,
+		NewURL:       fmt.Sprintf("%s/new", c.basePath),
+		PipelinesURL: "/crm/pipelines",
+		Pipelines:    mapping.MapViewModels(pipelines, mappers.PipelineToViewModel),
+	}
+	if len(pipelines) == 0 {
+		return props, nil
+	}
+	if pipelineID == 0 {
+		pipelineID = pipelines[0].ID()
+	}
+	board, err := c.dealService.Board(r.Context(), pipelineID, &deal.FindParams{
+		OwnerID: query.OwnerID,
+		Search:  query.Search,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving deals")
+	}
+	userNames, err := c.userNames(r)
+	if err != nil {
+		return nil, err
+	}
+	props.PipelineID = strconv.FormatUint(uint64(pipelineID), 10)
+	for _, column := range board.Columns {
+		deals, err := c.dealViewModels(r, board.Pipeline, column.Deals, userNames)
+		if err != nil {
+			return nil, err
+		}
+		props.Columns = append(props.Columns, &viewmodels.BoardColumn{
+			Stage: mappers.PipelineStageToViewModel(column.Stage),
+			Total: columnTotal(column.Deals),
+			Deals: deals,
+		})
+	}
+	return props, nil
+}
+
+func (c *DealController) Board(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&DealBoardQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	props, err := c.boardProps(r, query.PipelineID, query)
+	if err != nil {
+		if errors.Is(err, persistence.ErrPipelineNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(dealsui.Board(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(dealsui.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *DealController) fieldsProps(r *http.Request, vm *viewmodels.Deal, errorsMap map[string]string) (*dealsui.FieldsProps, error) {
+	pipelines, err := c.pipelineService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving pipelines")
+	}
+	users, err := c.userService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving users")
+	}
+	currencies, err := c.currencyService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving currencies")
+	}
+	return &dealsui.FieldsProps{
+		Deal:       vm,
+		Pipelines:  mapping.MapViewModels(pipelines, mappers.PipelineToViewModel),
+		Users:      mapping.MapViewModels(users, coremappers.UserToViewModel),
+		Currencies: mapping.MapViewModels(currencies, coremappers.CurrencyToViewModel),
+		Errors:     errorsMap,
+	}, nil
+}
+
+func (c *DealController) renderCreateForm(w http.ResponseWriter, r *http.Request, vm *viewmodels.Deal, errorsMap map[string]string) {
+	fieldsProps, err := c.fieldsProps(r, vm, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fieldsProps.IsNew = true
+	props := &dealsui.CreatePageProps{
+		FieldsProps: *fieldsProps,
+		SaveURL:     c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(dealsui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(dealsui.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// renderEditForm shows a saved deal, vm carries the submitted values when the form is rendered again with errors.
+func (c *DealController) renderEditForm(
+	w http.ResponseWriter,
+	r *http.Request,
+	id uint,
+	vm *viewmodels.Deal,
+	errorsMap map[string]string,
+) {
+	entity, err := c.dealService.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrDealNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	p, err := c.pipelineService.GetByID(r.Context(), entity.PipelineID())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	userNames, err := c.userNames(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	vms, err := c.dealViewModels(r, p, []deal.Deal{entity}, userNames)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	saved := vms[0]
+	if vm != nil {
+		saved.Title = vm.Title
+		saved.OwnerID = vm.OwnerID
+		saved.Value = vm.Value
+		saved.CurrencyCode = vm.CurrencyCode
+		saved.ExpectedCloseDate = vm.ExpectedCloseDate
+		if vm.ClientID != "" {
+			saved.ClientID, saved.ClientName = vm.ClientID, vm.ClientName
+		}
+	}
+	fieldsProps, err := c.fieldsProps(r, saved, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &dealsui.EditPageProps{
+		FieldsProps: *fieldsProps,
+		Stages:      mapping.MapViewModels(p.Stages(), mappers.PipelineStageToViewModel),
+		SaveURL:     fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+		MoveURL:     fmt.Sprintf("%s/%d/move", c.basePath, id),
+		ConvertURL:  fmt.Sprintf("%s/%d/convert", c.basePath, id),
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(dealsui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(dealsui.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *DealController) GetNew(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&DealBoardQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	vm := &viewmodels.Deal{
+		OwnerID: strconv.FormatUint(uint64(u.ID()), 10),
+	}
+	if query.PipelineID != 0 {
+		vm.PipelineID = strconv.FormatUint(uint64(query.PipelineID), 10)
+	}
+	c.renderCreateForm(w, r, vm, map[string]string{})
+}
+
+func (c *DealController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.renderEditForm(w, r, id, nil, map[string]string{})
+}
+
+// dealFromForm keeps the submitted values when the form is rendered again with errors.
+func (c *DealController) dealFromForm(
+	r *http.Request,
+	title string,
+	clientID, ownerID uint,
+	value float64,
+	currencyCode string,
+	expectedCloseDate shared.DateOnly,
+) *viewmodels.Deal {
+	vm := &viewmodels.Deal{
+		Title:        title,
+		Value:        fmt.Sprintf("%.2f", value),
+		CurrencyCode: currencyCode,
+	}
+	if ownerID != 0 {
+		vm.OwnerID = strconv.FormatUint(uint64(ownerID), 10)
+	}
+	if clientID != 0 {
+		if clientEntity, err := c.clientService.GetByID(r.Context(), clientID); err == nil {
+			clientVM := mappers.ClientToViewModel(clientEntity)
+			vm.ClientID, vm.ClientName = clientVM.ID, clientVM.FullName()
+		}
+	}
+	if d := time.Time(expectedCloseDate); !d.IsZero() {
+		vm.ExpectedCloseDate = d.Format(time.DateOnly)
+	}
+	return vm
+}
+
+func (c *DealController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&deal.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	errorsMap, ok := dto.Ok(r.Context())
+	if ok {
+		_, err = c.dealService.Create(r.Context(), dto)
+		if err == nil {
+			shared.Redirect(w, r, fmt.Sprintf("%s?PipelineID=%d", c.basePath, dto.PipelineID))
+			return
+		}
+		if !errors.Is(err, services.ErrStageNotFound) && !errors.Is(err, persistence.ErrPipelineNotFound) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		errorsMap = map[string]string{"PipelineID": composables.MustT(r.Context(), "Deals.Errors.StageNotFound")}
+	}
+	vm := c.dealFromForm(r, dto.Title, dto.ClientID, dto.OwnerID, dto.Value, dto.CurrencyCode, dto.ExpectedCloseDate)
+	vm.PipelineID = strconv.FormatUint(uint64(dto.PipelineID), 10)
+	c.renderCreateForm(w, r, vm, errorsMap)
+}
+
+func (c *DealController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&deal.UpdateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		vm := c.dealFromForm(r, dto.Title, dto.ClientID, dto.OwnerID, dto.Value, dto.CurrencyCode, dto.ExpectedCloseDate)
+		c.renderEditForm(w, r, id, vm, errorsMap)
+		return
+	}
+	if _, err := c.dealService.Update(r.Context(), id, dto); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderEditForm(w, r, id, nil, map[string]string{})
+}
+
+// Move is posted both by the kanban board, which gets the board back, and by the deal page.
+func (c *DealController) Move(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&deal.MoveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		http.Error(w, errorsMap["StageID"], http.StatusBadRequest)
+		return
+	}
+	entity, err := c.dealService.Move(r.Context(), id, dto)
+	switch {
+	case err == nil, errors.Is(err, deal.ErrSameStage):
+	case errors.Is(err, persistence.ErrDealNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrStageNotFound), errors.Is(err, deal.ErrStageOfOtherPipeline):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if r.Header.Get("Hx-Target") != "deal-board" {
+		c.renderEditForm(w, r, id, nil, map[string]string{})
+		return
+	}
+	pipelineID := uint(0)
+	if entity != nil {
+		pipelineID = entity.PipelineID()
+	} else if existing, err := c.dealService.GetByID(r.Context(), id); err == nil {
+		pipelineID = existing.PipelineID()
+	}
+	props, err := c.boardProps(r, pipelineID, &DealBoardQuery{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(dealsui.Board(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *DealController) Convert(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&deal.ConvertDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		http.Error(w, errorsMap["Target"], http.StatusBadRequest)
+		return
+	}
+	errorsMap := map[string]string{}
+	_, err = c.dealService.Convert(r.Context(), id, dto)
+	switch {
+	case err == nil:
+	case errors.Is(err, deal.ErrNotWon):
+		errorsMap["Deal"] = composables.MustT(r.Context(), "Deals.Errors.NotWon")
+	case errors.Is(err, deal.ErrAlreadyConverted):
+		errorsMap["Deal"] = composables.MustT(r.Context(), "Deals.Errors.AlreadyConverted")
+	case errors.Is(err, persistence.ErrDealNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderEditForm(w, r, id, nil, errorsMap)
+}
+
+func (c *DealController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.dealService.Delete(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrDealNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s?PipelineID=%d", c.basePath, entity.PipelineID()))
+}
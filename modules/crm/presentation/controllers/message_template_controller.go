@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 
 	_ "github.com/iota-uz/iota-sdk/components/base/pagination"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	messagetemplate "github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
@@ -25,6 +26,12 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/shared"
 )
 
+type MessageTemplatePreviewQuery struct {
+	ClientID uint
+	Locale   string
+	Channel  string
+}
+
 type MessageTemplateController struct {
 	basePath        string
 	app             application.Application
@@ -58,6 +65,7 @@ func (c *MessageTemplateController) Register(r *mux.Router) {
 	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
 	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
 	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}/preview", c.Preview).Methods(http.MethodGet)
 
 	setRouter := r.PathPrefix(c.basePath).Subrouter()
 	setRouter.Use(commonMiddleware...)
@@ -112,10 +120,11 @@ func (c *MessageTemplateController) GetEdit(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	props := &msgtui.EditPageProps{
-		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
-		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
-		Errors:    map[string]string{},
-		Template:  mappers.MessageTemplateToViewModel(templateEntity),
+		SaveURL:    fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL:  fmt.Sprintf("%s/%d", c.basePath, id),
+		PreviewURL: fmt.Sprintf("%s/%d/preview", c.basePath, id),
+		Errors:     map[string]string{},
+		Template:   mappers.MessageTemplateToViewModel(templateEntity),
 	}
 	templ.Handler(msgtui.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
@@ -129,11 +138,9 @@ func (c *MessageTemplateController) Create(w http.ResponseWriter, r *http.Reques
 
 	if errorsMap, ok := dto.Ok(r.Context()); !ok {
 		props := &msgtui.CreatePageProps{
-			Errors: errorsMap,
-			Template: &viewmodels.MessageTemplate{
-				Template: dto.Template,
-			},
-			SaveURL: c.basePath,
+			Errors:   errorsMap,
+			Template: templateFromForm(dto.Name, dto.Template, dto.Variants),
+			SaveURL:  c.basePath,
 		}
 		templ.Handler(msgtui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
 		return
@@ -162,14 +169,13 @@ func (c *MessageTemplateController) Update(w http.ResponseWriter, r *http.Reques
 
 	if errorsMap, ok := dto.Ok(r.Context()); !ok {
 		props := &msgtui.EditPageProps{
-			Errors: errorsMap,
-			Template: &viewmodels.MessageTemplate{
-				Template: dto.Template,
-			},
-			SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
-			DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+			Errors:     errorsMap,
+			Template:   templateFromForm(dto.Name, dto.Template, dto.Variants),
+			SaveURL:    fmt.Sprintf("%s/%d", c.basePath, id),
+			DeleteURL:  fmt.Sprintf("%s/%d", c.basePath, id),
+			PreviewURL: fmt.Sprintf("%s/%d/preview", c.basePath, id),
 		}
-		templ.Handler(msgtui.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+		templ.Handler(msgtui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
 
@@ -188,10 +194,80 @@ func (c *MessageTemplateController) Delete(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if _, err := c.templateService.Delete(r.Context(), id); err != nil {
+	_, err = c.templateService.Delete(r.Context(), id)
+	if err == nil {
+		shared.Redirect(w, r, c.basePath)
+		return
+	}
+	if !errors.Is(err, messagetemplate.ErrTemplateInUse) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templateEntity, err := c.templateService.GetByID(r.Context(), id)
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	props := &msgtui.EditPageProps{
+		SaveURL:    fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL:  fmt.Sprintf("%s/%d", c.basePath, id),
+		PreviewURL: fmt.Sprintf("%s/%d/preview", c.basePath, id),
+		Errors: map[string]string{
+			"Template": composables.MustT(r.Context(), "MessageTemplates.Errors.InUse"),
+		},
+		Template: mappers.MessageTemplateToViewModel(templateEntity),
+	}
+	templ.Handler(msgtui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
 
-	shared.Redirect(w, r, c.basePath)
+// Preview renders a saved template for a client in the variant for the given locale and channel.
+func (c *MessageTemplateController) Preview(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query, err := composables.UseQuery(&MessageTemplatePreviewQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.ClientID == 0 {
+		http.Error(w, "client is required", http.StatusBadRequest)
+		return
+	}
+	channel, err := chat.NewChannel(query.Channel)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	templateEntity, err := c.templateService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	rendered, err := c.templateService.Render(r.Context(), id, query.ClientID, query.Locale, channel)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	templ.Handler(msgtui.Preview(mappers.RenderedMessageTemplateToViewModel(templateEntity, rendered))).ServeHTTP(w, r)
+}
+
+// templateFromForm keeps the submitted values when the form is rendered again with errors.
+func templateFromForm(name, template string, variants []messagetemplate.VariantDTO) *viewmodels.MessageTemplate {
+	vm := &viewmodels.MessageTemplate{
+		Name:     name,
+		Template: template,
+		Variants: make([]*viewmodels.MessageTemplateVariant, 0, len(variants)),
+	}
+	for _, v := range variants {
+		vm.Variants = append(vm.Variants, &viewmodels.MessageTemplateVariant{
+			Locale:     v.Locale,
+			Channel:    v.Channel,
+			Body:       v.Body,
+			ExternalID: v.ExternalID,
+		})
+	}
+	return vm
 }
@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	pipelinesui "github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/pipelines"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type PipelineController struct {
+	app             application.Application
+	pipelineService *services.PipelineService
+	basePath        string
+}
+
+func NewPipelineController(app application.Application, basePath string) application.Controller {
+	return &PipelineController{
+		app:             app,
+		pipelineService: app.Service(services.PipelineService{}).(*services.PipelineService),
+		basePath:        basePath,
+	}
+}
+
+func (c *PipelineController) Key() string {
+	return c.basePath
+}
+
+func (c *PipelineController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+}
+
+func (c *PipelineController) List(w http.ResponseWriter, r *http.Request) {
+	pipelines, err := c.pipelineService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &pipelinesui.IndexPageProps{
+		BaseURL:   c.basePath,
+		NewURL:    fmt.Sprintf("%s/new", c.basePath),
+		Pipelines: mapping.MapViewModels(pipelines, mappers.PipelineToViewModel),
+	}
+	templ.Handler(pipelinesui.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *PipelineController) GetNew(w http.ResponseWriter, r *http.Request) {
+	props := &pipelinesui.CreatePageProps{
+		SaveURL: c.basePath,
+		Pipeline: &viewmodels.Pipeline{
+			Stages: []*viewmodels.PipelineStage{
+				{Kind: string(pipeline.Open)},
+				{Kind: string(pipeline.Won)},
+				{Kind: string(pipeline.Lost)},
+			},
+		},
+		Errors: map[string]string{},
+	}
+	templ.Handler(pipelinesui.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *PipelineController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.pipelineService.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrPipelineNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	props := &pipelinesui.EditPageProps{
+		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+		Pipeline:  mappers.PipelineToViewModel(entity),
+		Errors:    map[string]string{},
+	}
+	templ.Handler(pipelinesui.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *PipelineController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&pipeline.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		props := &pipelinesui.CreatePageProps{
+			SaveURL:  c.basePath,
+			Pipeline: pipelineFromForm(dto),
+			Errors:   errorsMap,
+		}
+		templ.Handler(pipelinesui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.pipelineService.Create(r.Context(), dto); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *PipelineController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&pipeline.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	props := &pipelinesui.EditPageProps{
+		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+		Pipeline:  pipelineFromForm(dto),
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		props.Errors = errorsMap
+		templ.Handler(pipelinesui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.pipelineService.Update(r.Context(), id, dto); err != nil {
+		if !errors.Is(err, pipeline.ErrStageInUse) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Errors = map[string]string{
+			"Pipeline": composables.MustT(r.Context(), "Pipelines.Errors.StageInUse"),
+		}
+		templ.Handler(pipelinesui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *PipelineController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.pipelineService.Delete(r.Context(), id)
+	if err == nil {
+		shared.Redirect(w, r, c.basePath)
+		return
+	}
+	if !errors.Is(err, pipeline.ErrPipelineInUse) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entity, err = c.pipelineService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &pipelinesui.EditPageProps{
+		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+		Pipeline:  mappers.PipelineToViewModel(entity),
+		Errors: map[string]string{
+			"Pipeline": composables.MustT(r.Context(), "Pipelines.Errors.PipelineInUse"),
+		},
+	}
+	templ.Handler(pipelinesui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// pipelineFromForm keeps the submitted values when the form is rendered again with errors.
+func pipelineFromForm(dto *pipeline.SaveDTO) *viewmodels.Pipeline {
+	vm := &viewmodels.Pipeline{
+		Name:   dto.Name,
+		Stages: make([]*viewmodels.PipelineStage, 0, len(dto.Stages)),
+	}
+	for _, s := range dto.Stages {
+		stage := &viewmodels.PipelineStage{Name: s.Name, Kind: s.Kind}
+		if s.ID != 0 {
+			stage.ID = fmt.Sprintf("%d", s.ID)
+		}
+		vm.Stages = append(vm.Stages, stage)
+	}
+	return vm
+}
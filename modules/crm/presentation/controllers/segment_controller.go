@@ -0,0 +1,393 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	segmentsui "github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/segments"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/export"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// segmentPreviewSize is how many of the matching clients the preview lists.
+const segmentPreviewSize = 10
+
+type SegmentController struct {
+	app            application.Application
+	segmentService *services.SegmentService
+	clientService  *services.ClientService
+	basePath       string
+}
+
+type SegmentExportQuery struct {
+	Format export.Format
+}
+
+func NewSegmentController(app application.Application, basePath string) application.Controller {
+	return &SegmentController{
+		app:            app,
+		segmentService: app.Service(services.SegmentService{}).(*services.SegmentService),
+		clientService:  app.Service(services.ClientService{}).(*services.ClientService),
+		basePath:       basePath,
+	}
+}
+
+func (c *SegmentController) Key() string {
+	return c.basePath
+}
+
+func (c *SegmentController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}/export", c.Export).Methods(http.MethodGet)
+	getRouter.HandleFunc("/preview", c.Preview).Methods(http.MethodPost)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+}
+
+func (c *SegmentController) viewModelFields(r *http.Request) ([]*viewmodels.ClientField, error) {
+	fields, err := c.clientService.CustomFields(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapViewModels(fields, mappers.ClientFieldToViewModel), nil
+}
+
+// isExpressionError tells the errors of a filter expression apart from the failures of the service.
+func isExpressionError(err error) bool {
+	for _, target := range []error{
+		segment.ErrEmptyExpression,
+		segment.ErrSyntax,
+		segment.ErrUnknownField,
+		segment.ErrInvalidOperator,
+		segment.ErrInvalidValue,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *SegmentController) List(w http.ResponseWriter, r *http.Request) {
+	segments, err := c.segmentService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &segmentsui.IndexPageProps{
+		BaseURL:  c.basePath,
+		NewURL:   fmt.Sprintf("%s/new", c.basePath),
+		Segments: mapping.MapViewModels(segments, mappers.SegmentToViewModel),
+	}
+	templ.Handler(segmentsui.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *SegmentController) GetNew(w http.ResponseWriter, r *http.Request) {
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &segmentsui.CreatePageProps{
+		SaveURL:    c.basePath,
+		PreviewURL: fmt.Sprintf("%s/preview", c.basePath),
+		Segment:    &viewmodels.Segment{},
+		Fields:     fields,
+		Errors:     map[string]string{},
+	}
+	templ.Handler(segmentsui.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *SegmentController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.segmentService.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrSegmentNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &segmentsui.EditPageProps{
+		SaveURL:    fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL:  fmt.Sprintf("%s/%d", c.basePath, id),
+		PreviewURL: fmt.Sprintf("%s/preview", c.basePath),
+		Segment:    mappers.SegmentToViewModel(entity),
+		Fields:     fields,
+		Errors:     map[string]string{},
+	}
+	templ.Handler(segmentsui.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *SegmentController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&segment.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &segmentsui.CreatePageProps{
+		SaveURL:    c.basePath,
+		PreviewURL: fmt.Sprintf("%s/preview", c.basePath),
+		Segment: &viewmodels.Segment{
+			Name:        dto.Name,
+			Description: dto.Description,
+			Expression:  dto.Expression,
+		},
+		Fields: fields,
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		props.Errors = errorsMap
+		templ.Handler(segmentsui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.segmentService.Create(r.Context(), dto); err != nil {
+		if !isExpressionError(err) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Errors = map[string]string{"Expression": err.Error()}
+		templ.Handler(segmentsui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *SegmentController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&segment.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &segmentsui.EditPageProps{
+		SaveURL:    fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL:  fmt.Sprintf("%s/%d", c.basePath, id),
+		PreviewURL: fmt.Sprintf("%s/preview", c.basePath),
+		Segment: &viewmodels.Segment{
+			ID:          fmt.Sprintf("%d", id),
+			Name:        dto.Name,
+			Description: dto.Description,
+			Expression:  dto.Expression,
+		},
+		Fields: fields,
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		props.Errors = errorsMap
+		templ.Handler(segmentsui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.segmentService.Update(r.Context(), id, dto); err != nil {
+		if !isExpressionError(err) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Errors = map[string]string{"Expression": err.Error()}
+		templ.Handler(segmentsui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *SegmentController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, err = c.segmentService.Delete(r.Context(), id)
+	if err == nil {
+		shared.Redirect(w, r, c.basePath)
+		return
+	}
+	if !errors.Is(err, segment.ErrSegmentInUse) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entity, err := c.segmentService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &segmentsui.EditPageProps{
+		SaveURL:    fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL:  fmt.Sprintf("%s/%d", c.basePath, id),
+		PreviewURL: fmt.Sprintf("%s/preview", c.basePath),
+		Segment:    mappers.SegmentToViewModel(entity),
+		Fields:     fields,
+		Errors: map[string]string{
+			"Segment": composables.MustT(r.Context(), "Segments.Errors.InUse"),
+		},
+	}
+	templ.Handler(segmentsui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// Preview shows how many clients match the expression in the form and the first of them.
+func (c *SegmentController) Preview(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&segment.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	props := &segmentsui.PreviewProps{}
+	entities, total, err := c.segmentService.Preview(r.Context(), dto.Expression, segmentPreviewSize)
+	if err != nil {
+		if !isExpressionError(err) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Error = err.Error()
+	} else {
+		props.Clients = mapping.MapViewModels(entities, mappers.ClientToViewModel)
+		props.Total = total
+	}
+	templ.Handler(segmentsui.Preview(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// Export downloads the clients of the segment as CSV or XLSX.
+func (c *SegmentController) Export(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query, err := composables.UseQuery(&SegmentExportQuery{Format: export.CSV}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !query.Format.IsValid() {
+		http.Error(w, "unsupported format", http.StatusBadRequest)
+		return
+	}
+	entity, err := c.segmentService.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrSegmentNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	clients, err := c.segmentService.Clients(r.Context(), id, &client.FindParams{
+		SortBy: client.SortBy{Fields: []client.Field{client.FirstName, client.LastName}, Ascending: true},
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fields, err := c.viewModelFields(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	filename := fmt.Sprintf("segment_%s", strings.ReplaceAll(strings.ToLower(entity.Name()), " ", "_"))
+	if err := export.Write(w, query.Format, filename, c.clientsTable(r, clients, fields)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (c *SegmentController) clientsTable(r *http.Request, clients []client.Client, fields []*viewmodels.ClientField) *export.Table {
+	pageCtx := composables.UsePageCtx(r.Context())
+	header := []string{
+		pageCtx.T("Clients.Single.FirstName.Label"),
+		pageCtx.T("Clients.Single.LastName.Label"),
+		pageCtx.T("Clients.Single.MiddleName.Label"),
+		pageCtx.T("Clients.Single.Phone.Label"),
+		pageCtx.T("Clients.Single.Email.Label"),
+		pageCtx.T("Clients.Single.Address.Label"),
+		pageCtx.T("Clients.Single.DateOfBirth.Label"),
+		pageCtx.T("Clients.Single.Gender.Label"),
+		pageCtx.T("Clients.Single.HourlyRate.Label"),
+		pageCtx.T("Clients.Single.Tags.Label"),
+	}
+	for _, f := range fields {
+		header = append(header, f.Label)
+	}
+	table := &export.Table{Header: header}
+	for _, entity := range clients {
+		vm := mappers.ClientToViewModel(entity)
+		gender := ""
+		if vm.Gender != "" {
+			gender = pageCtx.T(fmt.Sprintf("Clients.Genders.%s", vm.Gender))
+		}
+		row := []interface{}{
+			vm.FirstName,
+			vm.LastName,
+			vm.MiddleName,
+			vm.Phone,
+			vm.Email,
+			vm.Address,
+			vm.DateOfBirth,
+			gender,
+			vm.HourlyRate,
+			vm.TagsString(),
+		}
+		for _, f := range fields {
+			row = append(row, vm.CustomFields[f.ID])
+		}
+		table.Append(row...)
+	}
+	return table
+}
@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreviewmodels "github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	teamsui "github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/teams"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type TeamController struct {
+	app         application.Application
+	teamService *services.TeamService
+	userService *coreservices.UserService
+	basePath    string
+}
+
+func NewTeamController(app application.Application, basePath string) application.Controller {
+	return &TeamController{
+		app:         app,
+		teamService: app.Service(services.TeamService{}).(*services.TeamService),
+		userService: app.Service(coreservices.UserService{}).(*coreservices.UserService),
+		basePath:    basePath,
+	}
+}
+
+func (c *TeamController) Key() string {
+	return c.basePath
+}
+
+func (c *TeamController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+}
+
+func (c *TeamController) viewModelUsers(r *http.Request) ([]*coreviewmodels.User, error) {
+	users, err := c.userService.GetAll(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapViewModels(users, coremappers.UserToViewModel), nil
+}
+
+// teamFormViewModel keeps what was submitted when the form is rendered again with errors.
+func teamFormViewModel(id uint, dto *team.SaveDTO) *viewmodels.Team {
+	memberIDs := make([]string, 0, len(dto.MemberIDs))
+	for _, memberID := range dto.MemberIDs {
+		memberIDs = append(memberIDs, fmt.Sprintf("%d", memberID))
+	}
+	vm := &viewmodels.Team{
+		Name:       dto.Name,
+		MemberIDs:  memberIDs,
+		AutoAssign: dto.AutoAssign,
+	}
+	if id != 0 {
+		vm.ID = fmt.Sprintf("%d", id)
+	}
+	return vm
+}
+
+func (c *TeamController) List(w http.ResponseWriter, r *http.Request) {
+	teams, err := c.teamService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &teamsui.IndexPageProps{
+		BaseURL: c.basePath,
+		NewURL:  fmt.Sprintf("%s/new", c.basePath),
+		Teams:   mapping.MapViewModels(teams, mappers.TeamToViewModel),
+	}
+	templ.Handler(teamsui.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *TeamController) GetNew(w http.ResponseWriter, r *http.Request) {
+	users, err := c.viewModelUsers(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &teamsui.CreatePageProps{
+		SaveURL: c.basePath,
+		Team:    &viewmodels.Team{},
+		Users:   users,
+		Errors:  map[string]string{},
+	}
+	templ.Handler(teamsui.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *TeamController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.teamService.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrTeamNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	users, err := c.viewModelUsers(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &teamsui.EditPageProps{
+		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+		Team:      mappers.TeamToViewModel(entity),
+		Users:     users,
+		Errors:    map[string]string{},
+	}
+	templ.Handler(teamsui.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *TeamController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&team.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		users, err := c.viewModelUsers(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props := &teamsui.CreatePageProps{
+			SaveURL: c.basePath,
+			Team:    teamFormViewModel(0, dto),
+			Users:   users,
+			Errors:  errorsMap,
+		}
+		templ.Handler(teamsui.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.teamService.Create(r.Context(), dto); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *TeamController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&team.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errorsMap, ok := dto.Ok(r.Context()); !ok {
+		users, err := c.viewModelUsers(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props := &teamsui.EditPageProps{
+			SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
+			DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
+			Team:      teamFormViewModel(id, dto),
+			Users:     users,
+			Errors:    errorsMap,
+		}
+		templ.Handler(teamsui.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		return
+	}
+	if _, err := c.teamService.Update(r.Context(), id, dto); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *TeamController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.teamService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
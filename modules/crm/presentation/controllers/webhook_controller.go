@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+)
+
+// NewWebhookController mounts the webhook of a messaging provider at basePath.
+func NewWebhookController(
+	app application.Application,
+	basePath string,
+	provider cpassproviders.WebhookProvider,
+) *WebhookController {
+	return &WebhookController{
+		app:      app,
+		basePath: basePath,
+		provider: provider,
+	}
+}
+
+type WebhookController struct {
+	app      application.Application
+	basePath string
+	provider cpassproviders.WebhookProvider
+}
+
+func (c *WebhookController) Register(r *mux.Router) {
+	subRouter := r.PathPrefix(c.basePath).Subrouter()
+	subRouter.Use(
+		middleware.WithTransaction(),
+	)
+	webhookHandler := c.provider.WebhookHandler(c.app.EventPublisher())
+	subRouter.HandleFunc("", webhookHandler).Methods(http.MethodGet, http.MethodPost)
+}
+
+func (c *WebhookController) Key() string {
+	return c.basePath
+}
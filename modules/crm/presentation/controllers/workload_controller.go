@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/mappers"
+	workloadui "github.com/iota-uz/iota-sdk/modules/crm/presentation/templates/pages/workload"
+	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/crm/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+)
+
+// Periods of the workload dashboard, counted back from now
+var workloadPeriods = map[string]time.Duration{
+	"Day":   24 * time.Hour,
+	"Week":  7 * 24 * time.Hour,
+	"Month": 30 * 24 * time.Hour,
+}
+
+var workloadPeriodOrder = []string{"Day", "Week", "Month"}
+
+type WorkloadQuery struct {
+	Period string
+}
+
+type WorkloadController struct {
+	app         application.Application
+	chatService *services.ChatService
+	userService *coreservices.UserService
+	basePath    string
+}
+
+func NewWorkloadController(app application.Application, basePath string) application.Controller {
+	return &WorkloadController{
+		app:         app,
+		chatService: app.Service(services.ChatService{}).(*services.ChatService),
+		userService: app.Service(coreservices.UserService{}).(*coreservices.UserService),
+		basePath:    basePath,
+	}
+}
+
+func (c *WorkloadController) Key() string {
+	return c.basePath
+}
+
+func (c *WorkloadController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", c.Index).Methods(http.MethodGet)
+}
+
+// Index shows the chats of every operator and how fast they answer, the unassigned chats last.
+func (c *WorkloadController) Index(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&WorkloadQuery{Period: "Week"}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	period, ok := workloadPeriods[query.Period]
+	if !ok {
+		http.Error(w, "unknown period", http.StatusBadRequest)
+		return
+	}
+	to := time.Now()
+	workload, err := c.chatService.Workload(r.Context(), to.Add(-period), to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	users, err := c.userService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	names := make(map[uint]string, len(users))
+	for _, u := range users {
+		names[u.ID()] = u.FirstName() + " " + u.LastName()
+	}
+	rows := make([]*viewmodels.Workload, 0, len(workload)+1)
+	var unassigned *viewmodels.Workload
+	for _, item := range workload {
+		if item.UserID == 0 {
+			unassigned = mappers.WorkloadToViewModel(item, "")
+			continue
+		}
+		rows = append(rows, mappers.WorkloadToViewModel(item, names[item.UserID]))
+	}
+	if unassigned != nil {
+		rows = append(rows, unassigned)
+	}
+	policy := c.chatService.SLAPolicy()
+	props := &workloadui.IndexPageProps{
+		BaseURL:          c.basePath,
+		Period:           query.Period,
+		Periods:          workloadPeriodOrder,
+		Workload:         rows,
+		FirstResponseSLA: policy.FirstResponse.String(),
+		ResolutionSLA:    policy.Resolution.String(),
+	}
+	if len(r.Header.Get("Hx-Request")) > 0 {
+		templ.Handler(workloadui.WorkloadTable(props)).ServeHTTP(w, r)
+		return
+	}
+	templ.Handler(workloadui.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
@@ -1,12 +1,21 @@
 package mappers
 
 import (
+	"fmt"
+	"math"
 	"strconv"
 	"time"
 
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/campaign"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/viewmodels"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 )
@@ -18,11 +27,88 @@ func ClientToViewModel(entity client.Client) *viewmodels.Client {
 		LastName:   entity.LastName(),
 		MiddleName: entity.MiddleName(),
 		Phone:      entity.Phone().Value(),
+		Email:      entity.Email(),
+		Address:    entity.Address(),
+		DateOfBirth: func() string {
+			if entity.DateOfBirth() == nil {
+				return ""
+			}
+			return entity.DateOfBirth().Format(time.DateOnly)
+		}(),
+		Gender:       string(entity.Gender()),
+		HourlyRate:   formatHourlyRate(entity.HourlyRate()),
+		Tags:         entity.Tags(),
+		CustomFields: customFieldValuesToViewModel(entity.CustomFields()),
+		CreatedAt:    entity.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:    entity.UpdatedAt().Format(time.RFC3339),
+	}
+}
+
+func formatHourlyRate(rate float64) string {
+	if rate == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
+
+func customFieldValuesToViewModel(values map[uint]string) map[string]string {
+	result := make(map[string]string, len(values))
+	for id, v := range values {
+		result[strconv.FormatUint(uint64(id), 10)] = v
+	}
+	return result
+}
+
+func ClientFieldToViewModel(entity customfield.Field) *viewmodels.ClientField {
+	return &viewmodels.ClientField{
+		ID:       strconv.FormatUint(uint64(entity.ID()), 10),
+		Key:      entity.Key(),
+		Label:    entity.Label(),
+		Type:     string(entity.Type()),
+		Options:  entity.Options(),
+		Required: entity.Required(),
+		Position: strconv.Itoa(entity.Position()),
+	}
+}
+
+func SegmentToViewModel(entity segment.Segment) *viewmodels.Segment {
+	return &viewmodels.Segment{
+		ID:          strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:        entity.Name(),
+		Description: entity.Description(),
+		Expression:  entity.Expression(),
+		CreatedAt:   entity.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:   entity.UpdatedAt().Format(time.RFC3339),
+	}
+}
+
+func TeamToViewModel(entity team.Team) *viewmodels.Team {
+	memberIDs := make([]string, 0, len(entity.MemberIDs()))
+	for _, id := range entity.MemberIDs() {
+		memberIDs = append(memberIDs, strconv.FormatUint(uint64(id), 10))
+	}
+	return &viewmodels.Team{
+		ID:         strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:       entity.Name(),
+		MemberIDs:  memberIDs,
+		AutoAssign: entity.AutoAssign(),
 		CreatedAt:  entity.CreatedAt().Format(time.RFC3339),
 		UpdatedAt:  entity.UpdatedAt().Format(time.RFC3339),
 	}
 }
 
+func ClientDuplicateToViewModel(d client.Duplicate) *viewmodels.ClientDuplicate {
+	reasons := make([]string, 0, len(d.Reasons))
+	for _, r := range d.Reasons {
+		reasons = append(reasons, string(r))
+	}
+	return &viewmodels.ClientDuplicate{
+		Client:  ClientToViewModel(d.Client),
+		Score:   int(math.Round(d.Score * 100)),
+		Reasons: reasons,
+	}
+}
+
 func initialsFromFullName(firstName, lastName string) string {
 	res := ""
 	if len(firstName) > 0 {
@@ -46,6 +132,7 @@ func SenderToViewModel(entity chat.Sender) viewmodels.MessageSender {
 func MessageToViewModel(entity chat.Message) *viewmodels.Message {
 	return &viewmodels.Message{
 		ID:        strconv.FormatUint(uint64(entity.ID()), 10),
+		Channel:   string(entity.Channel()),
 		Sender:    SenderToViewModel(entity.Sender()),
 		Message:   entity.Message(),
 		CreatedAt: entity.CreatedAt(),
@@ -58,14 +145,243 @@ func ChatToViewModel(entity chat.Chat, clientEntity client.Client) *viewmodels.C
 		Client:         ClientToViewModel(clientEntity),
 		Messages:       mapping.MapViewModels(entity.Messages(), MessageToViewModel),
 		UnreadMessages: entity.UnreadMessages(),
+		ReplyChannel:   string(entity.ReplyChannel()),
+		Status:         string(entity.Status()),
+		AssigneeID:     formatOptionalID(entity.Assignment().UserID),
+		TeamID:         formatOptionalID(entity.Assignment().TeamID),
+		Breached:       entity.Cycle().FirstResponseBreached || entity.Cycle().ResolutionBreached,
 		CreatedAt:      entity.CreatedAt().Format(time.RFC3339),
 	}
 }
 
+func formatOptionalID(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func ChatSLAToViewModel(entity chat.Chat, policy chat.SLAPolicy) *viewmodels.ChatSLA {
+	cycle := entity.Cycle()
+	return &viewmodels.ChatSLA{
+		FirstResponseDueAt:    formatOptionalTime(entity.FirstResponseDueAt(policy)),
+		ResolutionDueAt:       formatOptionalTime(entity.ResolutionDueAt(policy)),
+		FirstResponseBreached: cycle.FirstResponseBreached,
+		ResolutionBreached:    cycle.ResolutionBreached,
+		Answered:              cycle.FirstResponseAt != nil,
+		Closed:                entity.Status() == chat.Closed,
+	}
+}
+
+// WorkloadToViewModel maps the workload of an operator, names are looked up by the caller.
+func WorkloadToViewModel(w chat.Workload, userName string) *viewmodels.Workload {
+	average := ""
+	if w.Responses > 0 {
+		average = w.AverageResponse.Round(time.Second).String()
+	}
+	return &viewmodels.Workload{
+		UserID:          formatOptionalID(w.UserID),
+		UserName:        userName,
+		Open:            w.Open,
+		Pending:         w.Pending,
+		Closed:          w.Closed,
+		Breached:        w.Breached,
+		Responses:       w.Responses,
+		AverageResponse: average,
+	}
+}
+
 func MessageTemplateToViewModel(entity messagetemplate.MessageTemplate) *viewmodels.MessageTemplate {
 	return &viewmodels.MessageTemplate{
 		ID:        strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:      entity.Name(),
 		Template:  entity.Template(),
+		Variants:  mapping.MapViewModels(entity.Variants(), MessageTemplateVariantToViewModel),
+		CreatedAt: entity.CreatedAt().Format(time.RFC3339),
+	}
+}
+
+func MessageTemplateVariantToViewModel(v messagetemplate.Variant) *viewmodels.MessageTemplateVariant {
+	return &viewmodels.MessageTemplateVariant{
+		Locale:     v.Locale,
+		Channel:    string(v.Channel),
+		Body:       v.Body,
+		ExternalID: v.ExternalID,
+	}
+}
+
+func RenderedMessageTemplateToViewModel(
+	entity messagetemplate.MessageTemplate,
+	r *messagetemplate.Rendered,
+) *viewmodels.RenderedMessageTemplate {
+	return &viewmodels.RenderedMessageTemplate{
+		ID:          strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:        entity.Name(),
+		Text:        r.Text,
+		Locale:      r.Variant.Locale,
+		Channel:     string(r.Variant.Channel),
+		ExternalID:  r.Variant.ExternalID,
+		Missing:     r.Missing,
+		SMSSegments: r.SMSSegments,
+	}
+}
+
+func PipelineStageToViewModel(s pipeline.Stage) *viewmodels.PipelineStage {
+	return &viewmodels.PipelineStage{
+		ID:   strconv.FormatUint(uint64(s.ID), 10),
+		Name: s.Name,
+		Kind: string(s.Kind),
+	}
+}
+
+func PipelineToViewModel(entity pipeline.Pipeline) *viewmodels.Pipeline {
+	return &viewmodels.Pipeline{
+		ID:        strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:      entity.Name(),
+		Stages:    mapping.MapViewModels(entity.Stages(), PipelineStageToViewModel),
 		CreatedAt: entity.CreatedAt().Format(time.RFC3339),
 	}
 }
+
+// DealToViewModel resolves the stages of the deal in its pipeline and the users by their names,
+// the history is listed newest first.
+func DealToViewModel(
+	entity deal.Deal,
+	p pipeline.Pipeline,
+	clientEntity client.Client,
+	userNames map[uint]string,
+) *viewmodels.Deal {
+	stageName := func(id uint) string {
+		if s, ok := p.Stage(id); ok {
+			return s.Name
+		}
+		return ""
+	}
+	vm := &viewmodels.Deal{
+		ID:           strconv.FormatUint(uint64(entity.ID()), 10),
+		Title:        entity.Title(),
+		ClientID:     strconv.FormatUint(uint64(entity.ClientID()), 10),
+		ClientName:   ClientToViewModel(clientEntity).FullName(),
+		OwnerID:      strconv.FormatUint(uint64(entity.OwnerID()), 10),
+		OwnerName:    userNames[entity.OwnerID()],
+		PipelineID:   strconv.FormatUint(uint64(entity.PipelineID()), 10),
+		StageID:      strconv.FormatUint(uint64(entity.StageID()), 10),
+		StageName:    stageName(entity.StageID()),
+		Status:       string(entity.Status()),
+		Value:        fmt.Sprintf("%.2f", entity.Value().Value()),
+		CurrencyCode: string(entity.Value().Currency()),
+		History:      make([]*viewmodels.DealStageChange, 0, len(entity.History())),
+		CreatedAt:    entity.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:    entity.UpdatedAt().Format(time.RFC3339),
+	}
+	if d := entity.ExpectedCloseDate(); d != nil {
+		vm.ExpectedCloseDate = d.Format(time.DateOnly)
+	}
+	if d := entity.ClosedAt(); d != nil {
+		vm.ClosedAt = d.Format(time.RFC3339)
+	}
+	if c := entity.Conversion(); c != nil {
+		vm.ConvertedTo = string(c.Target)
+		vm.ConvertedAt = c.ConvertedAt.Format(time.RFC3339)
+	}
+	for i := len(entity.History()) - 1; i >= 0; i-- {
+		change := entity.History()[i]
+		vm.History = append(vm.History, &viewmodels.DealStageChange{
+			FromStage: stageName(change.FromStageID),
+			ToStage:   stageName(change.ToStageID),
+			ChangedBy: userNames[change.ChangedBy],
+			ChangedAt: change.ChangedAt.Format(time.RFC3339),
+		})
+	}
+	return vm
+}
+
+func CampaignStatsToViewModel(stats campaign.Stats) *viewmodels.CampaignStats {
+	return &viewmodels.CampaignStats{
+		Total:     stats.Total(),
+		Queued:    stats.Queued,
+		Sent:      stats.Sent,
+		Delivered: stats.Delivered,
+		Failed:    stats.Failed,
+		OptedOut:  stats.OptedOut,
+	}
+}
+
+// CampaignToViewModel shows the template and the segment by their names, an empty segment name
+// means the campaign targets all clients.
+func CampaignToViewModel(entity campaign.Campaign, templateName, segmentName string, stats campaign.Stats) *viewmodels.Campaign {
+	vm := &viewmodels.Campaign{
+		ID:            strconv.FormatUint(uint64(entity.ID()), 10),
+		Name:          entity.Name(),
+		TemplateID:    strconv.FormatUint(uint64(entity.TemplateID()), 10),
+		TemplateName:  templateName,
+		SegmentName:   segmentName,
+		Channel:       string(entity.Channel()),
+		Locale:        entity.Locale(),
+		RatePerMinute: strconv.Itoa(entity.RatePerMinute()),
+		Status:        string(entity.Status()),
+		Stats:         CampaignStatsToViewModel(stats),
+		CreatedAt:     entity.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:     entity.UpdatedAt().Format(time.RFC3339),
+	}
+	if entity.SegmentID() != 0 {
+		vm.SegmentID = strconv.FormatUint(uint64(entity.SegmentID()), 10)
+	}
+	if t := entity.StartedAt(); t != nil {
+		vm.StartedAt = t.Format(time.RFC3339)
+	}
+	if t := entity.CompletedAt(); t != nil {
+		vm.CompletedAt = t.Format(time.RFC3339)
+	}
+	return vm
+}
+
+func CampaignRecipientToViewModel(entity campaign.Recipient, clientName string) *viewmodels.CampaignRecipient {
+	vm := &viewmodels.CampaignRecipient{
+		ID:         strconv.FormatUint(uint64(entity.ID()), 10),
+		ClientID:   strconv.FormatUint(uint64(entity.ClientID()), 10),
+		ClientName: clientName,
+		Address:    entity.Address(),
+		Status:     string(entity.Status()),
+		Attempts:   strconv.Itoa(entity.Attempts()),
+		Error:      entity.Error(),
+	}
+	if t := entity.SentAt(); t != nil {
+		vm.SentAt = t.Format(time.RFC3339)
+	}
+	if t := entity.DeliveredAt(); t != nil {
+		vm.DeliveredAt = t.Format(time.RFC3339)
+	}
+	return vm
+}
+
+// TimelineEntryToViewModel maps a timeline entry, authors are looked up in users by their ID.
+func TimelineEntryToViewModel(entry activity.Entry, users map[uint]string) *viewmodels.TimelineEntry {
+	vm := &viewmodels.TimelineEntry{
+		ID:         strconv.FormatUint(uint64(entry.ID), 10),
+		Kind:       string(entry.Kind),
+		Body:       entry.Body,
+		Channel:    entry.Channel,
+		Direction:  string(entry.Direction),
+		Minutes:    int(entry.Duration.Minutes()),
+		Currency:   entry.Currency,
+		Status:     entry.Status,
+		AuthorName: users[entry.AuthorID],
+		OccurredAt: entry.OccurredAt,
+		Deletable:  entry.Kind == activity.Note || entry.Kind == activity.Call,
+	}
+	if entry.RefID != 0 {
+		vm.RefID = strconv.FormatUint(uint64(entry.RefID), 10)
+	}
+	if entry.Kind == activity.Payment {
+		vm.Amount = strconv.FormatFloat(entry.Amount, 'f', 2, 64)
+	}
+	return vm
+}
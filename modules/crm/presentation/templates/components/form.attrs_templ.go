@@ -0,0 +1,19 @@
+// Code generated by templ - DO NOT EDIT.
+
+// templ: version: v0.3.819
+package components
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import templruntime "github.com/a-h/templ/runtime"
+
+// FormAttrs ties an input to the form with the given id when it is rendered outside of it.
+func FormAttrs(form string, attrs templ.Attributes) templ.Attributes {
+	if form != "" {
+		attrs["form"] = form
+	}
+	return attrs
+}
+
+var _ = templruntime.GeneratedTemplate
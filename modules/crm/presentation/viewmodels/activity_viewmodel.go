@@ -0,0 +1,28 @@
+package viewmodels
+
+import "time"
+
+type TimelineEntry struct {
+	ID         string
+	Kind       string
+	RefID      string
+	Body       string
+	Channel    string
+	Direction  string
+	Minutes    int
+	Amount     string
+	Currency   string
+	Status     string
+	AuthorName string
+	OccurredAt time.Time
+	// Deletable is set for the notes and calls logged by the staff
+	Deletable bool
+}
+
+func (e *TimelineEntry) Date() string {
+	return e.OccurredAt.Format("2006/01/02")
+}
+
+func (e *TimelineEntry) Time() string {
+	return e.OccurredAt.Format("15:04")
+}
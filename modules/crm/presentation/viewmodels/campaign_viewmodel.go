@@ -0,0 +1,64 @@
+package viewmodels
+
+type Campaign struct {
+	ID            string
+	Name          string
+	TemplateID    string
+	TemplateName  string
+	SegmentID     string
+	SegmentName   string
+	Channel       string
+	Locale        string
+	RatePerMinute string
+	Status        string
+	StartedAt     string
+	CompletedAt   string
+	Stats         *CampaignStats
+	CreatedAt     string
+	UpdatedAt     string
+}
+
+func (c *Campaign) IsDraft() bool {
+	return c.Status == "draft"
+}
+
+func (c *Campaign) IsRunning() bool {
+	return c.Status == "running"
+}
+
+func (c *Campaign) IsPaused() bool {
+	return c.Status == "paused"
+}
+
+func (c *Campaign) IsFinished() bool {
+	return c.Status == "completed" || c.Status == "cancelled"
+}
+
+type CampaignStats struct {
+	Total     int
+	Queued    int
+	Sent      int
+	Delivered int
+	Failed    int
+	OptedOut  int
+}
+
+// Progress is the share of recipients that were processed, in percent.
+func (s *CampaignStats) Progress() int {
+	if s.Total == 0 {
+		return 0
+	}
+	return (s.Total - s.Queued) * 100 / s.Total
+}
+
+type CampaignRecipient struct {
+	ID          string
+	ClientID    string
+	ClientName  string
+	Address     string
+	Status      string
+	Attempts    string
+	Error       string
+	SentAt      string
+	DeliveredAt string
+}
@@ -11,6 +11,12 @@ type Chat struct {
 	CreatedAt      string
 	Messages       []*Message
 	UnreadMessages int
+	ReplyChannel   string
+	Status         string
+	AssigneeID     string
+	TeamID         string
+	// Breached is set when the current cycle of the chat missed an SLA
+	Breached bool
 }
 
 func (c *Chat) ReversedMessages() []*Message {
@@ -39,6 +45,28 @@ func (c *Chat) UnreadMessagesFormatted() string {
 	return strconv.Itoa(c.UnreadMessages)
 }
 
+// ChatSLA holds the SLA deadlines of the current cycle of a chat, empty when an SLA does not apply.
+type ChatSLA struct {
+	FirstResponseDueAt    string
+	ResolutionDueAt       string
+	FirstResponseBreached bool
+	ResolutionBreached    bool
+	// Answered is set once an operator replied in the current cycle
+	Answered bool
+	Closed   bool
+}
+
+type Workload struct {
+	UserID          string
+	UserName        string
+	Open            int
+	Pending         int
+	Closed          int
+	Breached        int
+	Responses       int
+	AverageResponse string
+}
+
 type MessageSender interface {
 	ID() string
 	IsUser() bool
@@ -86,6 +114,7 @@ func (ms *messageSender) Initials() string {
 
 type Message struct {
 	ID        string
+	Channel   string
 	Message   string
 	Sender    MessageSender
 	CreatedAt time.Time
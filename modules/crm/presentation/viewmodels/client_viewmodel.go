@@ -3,13 +3,25 @@ package viewmodels
 import "strings"
 
 type Client struct {
-	ID         string
-	FirstName  string
-	LastName   string
-	MiddleName string
-	Phone      string
-	CreatedAt  string
-	UpdatedAt  string
+	ID          string
+	FirstName   string
+	LastName    string
+	MiddleName  string
+	Phone       string
+	Email       string
+	Address     string
+	DateOfBirth string
+	Gender      string
+	HourlyRate  string
+	Tags        []string
+	// CustomFields are the values of the custom fields keyed by the field ID.
+	CustomFields map[string]string
+	CreatedAt    string
+	UpdatedAt    string
+}
+
+func (c *Client) TagsString() string {
+	return strings.Join(c.Tags, ", ")
 }
 
 func (c *Client) FullName() string {
@@ -39,3 +51,33 @@ func (c *Client) Initials() string {
 	}
 	return res
 }
+
+// ClientDuplicate is a client suspected to be the same person as the one being viewed.
+type ClientDuplicate struct {
+	Client  *Client
+	Score   int
+	Reasons []string
+}
+
+type ClientField struct {
+	ID       string
+	Key      string
+	Label    string
+	Type     string
+	Options  []string
+	Required bool
+	Position string
+}
+
+func (f *ClientField) OptionsText() string {
+	return strings.Join(f.Options, "\n")
+}
+
+type Segment struct {
+	ID          string
+	Name        string
+	Description string
+	Expression  string
+	CreatedAt   string
+	UpdatedAt   string
+}
@@ -0,0 +1,45 @@
+package viewmodels
+
+type DealStageChange struct {
+	FromStage string
+	ToStage   string
+	ChangedBy string
+	ChangedAt string
+}
+
+type Deal struct {
+	ID                string
+	Title             string
+	ClientID          string
+	ClientName        string
+	OwnerID           string
+	OwnerName         string
+	PipelineID        string
+	StageID           string
+	StageName         string
+	Status            string
+	Value             string
+	CurrencyCode      string
+	ExpectedCloseDate string
+	ClosedAt          string
+	ConvertedTo       string
+	ConvertedAt       string
+	History           []*DealStageChange
+	CreatedAt         string
+	UpdatedAt         string
+}
+
+func (d *Deal) IsWon() bool {
+	return d.Status == "won"
+}
+
+func (d *Deal) IsConverted() bool {
+	return d.ConvertedTo != ""
+}
+
+// BoardColumn is a stage of the kanban board.
+type BoardColumn struct {
+	Stage *PipelineStage
+	Total string
+	Deals []*Deal
+}
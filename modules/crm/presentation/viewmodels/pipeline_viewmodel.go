@@ -0,0 +1,14 @@
+package viewmodels
+
+type PipelineStage struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type Pipeline struct {
+	ID        string
+	Name      string
+	Stages    []*PipelineStage
+	CreatedAt string
+}
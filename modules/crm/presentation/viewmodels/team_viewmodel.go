@@ -0,0 +1,16 @@
+package viewmodels
+
+import "slices"
+
+type Team struct {
+	ID         string
+	Name       string
+	MemberIDs  []string
+	AutoAssign bool
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+func (t *Team) HasMember(id string) bool {
+	return slices.Contains(t.MemberIDs, id)
+}
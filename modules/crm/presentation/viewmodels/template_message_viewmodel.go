@@ -2,6 +2,28 @@ package viewmodels
 
 type MessageTemplate struct {
 	ID        string
+	Name      string
 	Template  string
+	Variants  []*MessageTemplateVariant
 	CreatedAt string
 }
+
+type MessageTemplateVariant struct {
+	Locale     string `json:"locale"`
+	Channel    string `json:"channel"`
+	Body       string `json:"body"`
+	ExternalID string `json:"externalId"`
+}
+
+// RenderedMessageTemplate is a template rendered for a client; Locale, Channel and
+// ExternalID describe the variant that was picked.
+type RenderedMessageTemplate struct {
+	ID          string
+	Name        string
+	Text        string
+	Locale      string
+	Channel     string
+	ExternalID  string
+	Missing     []string
+	SMSSegments int
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/activity"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type ActivityService struct {
+	repo activity.Repository
+}
+
+func NewActivityService(repo activity.Repository) *ActivityService {
+	return &ActivityService{
+		repo: repo,
+	}
+}
+
+func (s *ActivityService) Timeline(ctx context.Context, params *activity.TimelineParams) ([]activity.Entry, error) {
+	return s.repo.Timeline(ctx, params)
+}
+
+// Log records a note or a call of the current user with the client.
+func (s *ActivityService) Log(ctx context.Context, clientID uint, data *activity.CreateDTO) (activity.Activity, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity(clientID, u.ID())
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, entity)
+}
+
+// Delete removes a note or a call of the client, payments and orders follow the modules they
+// come from.
+func (s *ActivityService) Delete(ctx context.Context, clientID, id uint) (activity.Activity, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.ClientID() != clientID {
+		return nil, persistence.ErrActivityNotFound
+	}
+	if entity.Kind() != activity.Note && entity.Kind() != activity.Call {
+		return nil, activity.ErrNotLoggable
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *ActivityService) LinkCounterparty(ctx context.Context, clientID, counterpartyID uint) error {
+	return s.repo.LinkCounterparty(ctx, clientID, counterpartyID)
+}
+
+// RecordPayment adds the payment to the timeline of every client paid for by the counterparty,
+// replacing what was recorded before when the payment is updated.
+func (s *ActivityService) RecordPayment(
+	ctx context.Context,
+	paymentID, counterpartyID uint,
+	amount float64,
+	currency, comment string,
+	occurredAt time.Time,
+) error {
+	clientIDs, err := s.repo.ClientIDsByCounterparty(ctx, counterpartyID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteByRef(ctx, activity.Payment, paymentID); err != nil {
+		return err
+	}
+	for _, clientID := range clientIDs {
+		if _, err := s.repo.Create(ctx, activity.NewPayment(clientID, paymentID, amount, currency, comment, occurredAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ActivityService) RemovePayment(ctx context.Context, paymentID uint) error {
+	return s.repo.DeleteByRef(ctx, activity.Payment, paymentID)
+}
+
+// RecordOrder adds an order to the timeline of the client. Once an order is known, every change
+// of its status is recorded for the clients it was recorded for, so clientID may be 0.
+func (s *ActivityService) RecordOrder(ctx context.Context, clientID, orderID uint, status string, occurredAt time.Time) error {
+	clientIDs := []uint{clientID}
+	if clientID == 0 {
+		ids, err := s.repo.ClientIDsByRef(ctx, activity.Order, orderID)
+		if err != nil {
+			return err
+		}
+		clientIDs = ids
+	}
+	for _, id := range clientIDs {
+		if _, err := s.repo.Create(ctx, activity.NewOrder(id, orderID, status, occurredAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
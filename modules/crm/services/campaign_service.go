@@ -0,0 +1,414 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/campaign"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+type CampaignService struct {
+	repo           campaign.Repository
+	clientRepo     client.Repository
+	chatRepo       chat.Repository
+	templateRepo   messagetemplate.Repository
+	segmentService *SegmentService
+	providers      map[chat.Channel]cpassproviders.Provider
+}
+
+func NewCampaignService(
+	repo campaign.Repository,
+	clientRepo client.Repository,
+	chatRepo chat.Repository,
+	templateRepo messagetemplate.Repository,
+	segmentService *SegmentService,
+	providers []cpassproviders.Provider,
+) *CampaignService {
+	byChannel := make(map[chat.Channel]cpassproviders.Provider, len(providers))
+	for _, p := range providers {
+		byChannel[p.Channel()] = p
+	}
+	return &CampaignService{
+		repo:           repo,
+		clientRepo:     clientRepo,
+		chatRepo:       chatRepo,
+		templateRepo:   templateRepo,
+		segmentService: segmentService,
+		providers:      byChannel,
+	}
+}
+
+func (s *CampaignService) Count(ctx context.Context, params *campaign.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *CampaignService) GetPaginated(ctx context.Context, params *campaign.FindParams) ([]campaign.Campaign, error) {
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *CampaignService) GetByID(ctx context.Context, id uint) (campaign.Campaign, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *CampaignService) Stats(ctx context.Context, id uint) (campaign.Stats, error) {
+	return s.repo.Stats(ctx, id)
+}
+
+func (s *CampaignService) CountRecipients(ctx context.Context, params *campaign.RecipientFindParams) (int64, error) {
+	return s.repo.CountRecipients(ctx, params)
+}
+
+func (s *CampaignService) GetRecipients(ctx context.Context, params *campaign.RecipientFindParams) ([]campaign.Recipient, error) {
+	return s.repo.GetRecipients(ctx, params)
+}
+
+// Channels returns the channels campaigns can be sent on.
+func (s *CampaignService) Channels() []chat.Channel {
+	channels := make([]chat.Channel, 0, len(s.providers))
+	for _, c := range chat.Channels {
+		if _, ok := s.providers[c]; ok {
+			channels = append(channels, c)
+		}
+	}
+	return channels
+}
+
+func (s *CampaignService) Create(ctx context.Context, data *campaign.SaveDTO) (campaign.Campaign, error) {
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, entity)
+}
+
+func (s *CampaignService) Update(ctx context.Context, id uint, data *campaign.SaveDTO) (campaign.Campaign, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := data.Apply(entity)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, modified)
+}
+
+// Delete removes a draft or finished campaign with its recipients.
+func (s *CampaignService) Delete(ctx context.Context, id uint) (campaign.Campaign, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.Status() == campaign.Running || entity.Status() == campaign.Paused {
+		return nil, campaign.ErrActive
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// address returns where the client is reached on the channel: the chat contact the client wrote
+// from, else the phone number for the phone channels or the email address. Empty when the client
+// cannot be reached on the channel.
+func (s *CampaignService) address(ctx context.Context, channel chat.Channel, clientEntity client.Client) (string, error) {
+	chatEntity, err := s.chatRepo.GetByClientID(ctx, clientEntity.ID())
+	if err != nil && !errors.Is(err, persistence.ErrChatNotFound) {
+		return "", err
+	}
+	if chatEntity != nil {
+		if contact, ok := chatEntity.Contact(channel); ok {
+			return contact.Address, nil
+		}
+	}
+	switch channel {
+	case chat.SMS, chat.WhatsApp:
+		if clientEntity.Phone() != nil {
+			return clientEntity.Phone().Value(), nil
+		}
+	case chat.Email:
+		return clientEntity.Email(), nil
+	}
+	return "", nil
+}
+
+// Start snapshots the clients of the segment as recipients and starts sending. Clients that
+// cannot be reached on the channel are left out, those who opted out are recorded as such.
+func (s *CampaignService) Start(ctx context.Context, id uint) (campaign.Campaign, error) {
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.providers[entity.Channel()]; !ok {
+		return nil, ErrChannelUnavailable
+	}
+	params := &client.FindParams{}
+	if entity.SegmentID() != 0 {
+		params.Filter, err = s.segmentService.Filter(ctx, entity.SegmentID())
+		if err != nil {
+			return nil, err
+		}
+	}
+	clients, err := s.clientRepo.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	recipients := make([]campaign.Recipient, 0, len(clients))
+	for _, c := range clients {
+		address, err := s.address(ctx, entity.Channel(), c)
+		if err != nil {
+			return nil, err
+		}
+		if address == "" {
+			continue
+		}
+		recipient := campaign.NewRecipient(entity.ID(), c.ID(), address, now)
+		optedOut, err := s.repo.IsOptedOut(ctx, entity.Channel(), contactAddress(entity.Channel(), address))
+		if err != nil {
+			return nil, err
+		}
+		if optedOut {
+			recipient.MarkOptedOut(now)
+		}
+		recipients = append(recipients, recipient)
+	}
+	if len(recipients) == 0 {
+		return nil, campaign.ErrNoRecipients
+	}
+	if err := entity.Start(now); err != nil {
+		return nil, err
+	}
+	updatedEntity, err := s.repo.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddRecipients(ctx, recipients); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updatedEntity, nil
+}
+
+func (s *CampaignService) Pause(ctx context.Context, id uint) (campaign.Campaign, error) {
+	return s.transition(ctx, id, func(entity campaign.Campaign) error {
+		return entity.Pause()
+	})
+}
+
+func (s *CampaignService) Resume(ctx context.Context, id uint) (campaign.Campaign, error) {
+	return s.transition(ctx, id, func(entity campaign.Campaign) error {
+		return entity.Resume()
+	})
+}
+
+// Cancel stops the campaign for good, the recipients that were not reached stay queued.
+func (s *CampaignService) Cancel(ctx context.Context, id uint) (campaign.Campaign, error) {
+	return s.transition(ctx, id, func(entity campaign.Campaign) error {
+		return entity.Cancel(time.Now())
+	})
+}
+
+func (s *CampaignService) transition(ctx context.Context, id uint, change func(campaign.Campaign) error) (campaign.Campaign, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(entity); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, entity)
+}
+
+// GetRunning returns the campaigns that are sending.
+func (s *CampaignService) GetRunning(ctx context.Context) ([]campaign.Campaign, error) {
+	return s.repo.GetPaginated(ctx, &campaign.FindParams{Status: campaign.Running})
+}
+
+// batchSize is how many messages a campaign may send in an interval to keep to its rate.
+func batchSize(ratePerMinute int, interval time.Duration) int {
+	size := int(int64(ratePerMinute) * int64(interval) / int64(time.Minute))
+	if size < 1 {
+		return 1
+	}
+	return size
+}
+
+// Process sends the next batch of a running campaign, sized so the campaign keeps to its rate
+// when Process is called every interval. The message is rendered for every client when it is
+// sent. A failed send is retried with backoff, and the campaign completes once no recipient is
+// queued anymore.
+func (s *CampaignService) Process(ctx context.Context, id uint, now time.Time, interval time.Duration) error {
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if entity.Status() != campaign.Running {
+		return nil
+	}
+	provider, ok := s.providers[entity.Channel()]
+	if !ok {
+		return ErrChannelUnavailable
+	}
+	templateEntity, err := s.templateRepo.GetByID(ctx, entity.TemplateID())
+	if err != nil {
+		return err
+	}
+	recipients, err := s.repo.GetDueRecipients(ctx, entity.ID(), now, batchSize(entity.RatePerMinute(), interval))
+	if err != nil {
+		return err
+	}
+	for _, r := range recipients {
+		if err := s.send(ctx, entity, templateEntity, provider, r, now); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateRecipient(ctx, r); err != nil {
+			return err
+		}
+	}
+	stats, err := s.repo.Stats(ctx, entity.ID())
+	if err != nil {
+		return err
+	}
+	if stats.Queued == 0 {
+		if err := entity.Complete(now); err != nil {
+			return err
+		}
+		if _, err := s.repo.Update(ctx, entity); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// send delivers the campaign message to a recipient. Errors of the provider are recorded on the
+// recipient, only database errors are returned.
+func (s *CampaignService) send(
+	ctx context.Context,
+	entity campaign.Campaign,
+	templateEntity messagetemplate.MessageTemplate,
+	provider cpassproviders.Provider,
+	r campaign.Recipient,
+	now time.Time,
+) error {
+	optedOut, err := s.repo.IsOptedOut(ctx, entity.Channel(), contactAddress(entity.Channel(), r.Address()))
+	if err != nil {
+		return err
+	}
+	if optedOut {
+		r.MarkOptedOut(now)
+		return nil
+	}
+	clientEntity, err := s.clientRepo.GetByID(ctx, r.ClientID())
+	if err != nil {
+		return err
+	}
+	rendered := templateEntity.Render(entity.Locale(), entity.Channel(), messagetemplate.ClientValues(clientEntity, nil))
+	dto := cpassproviders.SendMessageDTO{
+		To:      r.Address(),
+		Message: rendered.Text,
+	}
+	switch entity.Channel() {
+	case chat.SMS:
+		dto.From = configuration.Use().TwilioPhoneNumber
+	case chat.WhatsApp:
+		if rendered.Variant.ExternalID != "" {
+			language := rendered.Variant.Locale
+			if language == "" {
+				language = entity.Locale()
+			}
+			dto.Template = &cpassproviders.Template{
+				Name:     rendered.Variant.ExternalID,
+				Language: language,
+				Params:   rendered.Params,
+			}
+		}
+	}
+	externalID, err := provider.SendMessage(ctx, dto)
+	if err != nil {
+		r.MarkAttemptFailed(err.Error(), now)
+		return nil
+	}
+	r.MarkSent(rendered.Text, externalID, now)
+	return nil
+}
+
+// UpdateDeliveryStatus applies a delivery report of a channel to the campaign recipient the
+// message was sent to. Reports of messages that were not sent by a campaign are ignored.
+func (s *CampaignService) UpdateDeliveryStatus(ctx context.Context, event *cpassproviders.DeliveryStatusEvent) error {
+	if event.MessageID == "" {
+		return nil
+	}
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+
+	r, err := s.repo.GetRecipientByExternalID(ctx, event.Channel, event.MessageID)
+	if errors.Is(err, persistence.ErrCampaignRecipientNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	switch event.Status {
+	case cpassproviders.DeliveryDelivered:
+		r.MarkDelivered(now)
+	case cpassproviders.DeliveryFailed:
+		reason := event.Error
+		if reason == "" {
+			reason = string(cpassproviders.DeliveryFailed)
+		}
+		r.MarkUndelivered(reason, now)
+	default:
+		return nil
+	}
+	if err := s.repo.UpdateRecipient(ctx, r); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RegisterReply opts the sender out of campaigns on the channel when a client replies with a
+// stop keyword such as STOP, and back in when the client replies with START.
+func (s *CampaignService) RegisterReply(ctx context.Context, event *cpassproviders.ReceivedMessageEvent) error {
+	channel := event.Channel
+	if channel == "" {
+		channel = chat.SMS
+	}
+	address := contactAddress(channel, event.From)
+	switch {
+	case campaign.IsStopRequest(event.Body):
+		return s.repo.OptOut(ctx, channel, address)
+	case campaign.IsStartRequest(event.Body):
+		return s.repo.OptIn(ctx, channel, address)
+	}
+	return nil
+}
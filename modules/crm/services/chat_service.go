@@ -3,16 +3,22 @@ package services
 import (
 	"context"
 	"errors"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/phone"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
 	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
 )
 
 // MessageMedia represents media attached to a message
@@ -22,34 +28,74 @@ type MessageMedia struct {
 	MimeType      string
 }
 
-// SendMessageDTO represents the data needed to send a message
+var (
+	ErrChannelUnavailable = errors.New("no provider is configured for the channel")
+	ErrUnknownContact     = errors.New("no client is registered with the contact")
+	ErrNoContact          = errors.New("client has no contact on the channel")
+)
+
+// SendMessageDTO represents the data needed to send a message.
+// The message goes out on the chat's reply channel unless a channel is given.
+// TemplateID and Locale tell which template the message was rendered from, if any.
 type SendMessageDTO struct {
 	ChatID      uint
+	Channel     chat.Channel
 	Message     string
+	TemplateID  uint
+	Locale      string
 	Attachments []*upload.Upload
 }
 
 type ChatService struct {
-	repo          chat.Repository
-	clientRepo    client.Repository
-	cpassProvider cpassproviders.Provider
-	publisher     eventbus.EventBus
+	repo         chat.Repository
+	clientRepo   client.Repository
+	templateRepo messagetemplate.Repository
+	teamRepo     team.Repository
+	providers    map[chat.Channel]cpassproviders.Provider
+	slaPolicy    chat.SLAPolicy
+	publisher    eventbus.EventBus
 }
 
 func NewChatService(
 	repo chat.Repository,
 	clientRepo client.Repository,
-	cpassProvider cpassproviders.Provider,
+	templateRepo messagetemplate.Repository,
+	teamRepo team.Repository,
+	providers []cpassproviders.Provider,
+	slaPolicy chat.SLAPolicy,
 	publisher eventbus.EventBus,
 ) *ChatService {
+	byChannel := make(map[chat.Channel]cpassproviders.Provider, len(providers))
+	for _, p := range providers {
+		byChannel[p.Channel()] = p
+	}
 	return &ChatService{
-		repo:          repo,
-		clientRepo:    clientRepo,
-		cpassProvider: cpassProvider,
-		publisher:     publisher,
+		repo:         repo,
+		clientRepo:   clientRepo,
+		templateRepo: templateRepo,
+		teamRepo:     teamRepo,
+		providers:    byChannel,
+		slaPolicy:    slaPolicy,
+		publisher:    publisher,
 	}
 }
 
+// SLAPolicy returns the time operators have to answer and to close chats.
+func (s *ChatService) SLAPolicy() chat.SLAPolicy {
+	return s.slaPolicy
+}
+
+// Channels returns the channels messages can be sent on, in display order.
+func (s *ChatService) Channels() []chat.Channel {
+	channels := make([]chat.Channel, 0, len(s.providers))
+	for _, c := range chat.Channels {
+		if _, ok := s.providers[c]; ok {
+			channels = append(channels, c)
+		}
+	}
+	return channels
+}
+
 func (s *ChatService) Count(ctx context.Context) (int64, error) {
 	return s.repo.Count(ctx)
 }
@@ -109,17 +155,46 @@ func (s *ChatService) Update(ctx context.Context, entity chat.Chat) (chat.Chat,
 	return s.repo.Update(ctx, entity)
 }
 
+// findClient finds the client a message comes from when its sender is not a known contact yet:
+// by email address for emails and by the phone number for the other channels.
+func (s *ChatService) findClient(ctx context.Context, params *cpassproviders.ReceivedMessageEvent) (client.Client, error) {
+	if params.Channel == chat.Email {
+		return s.clientRepo.GetByEmail(ctx, params.From)
+	}
+	number := params.Phone
+	if number == "" && params.Channel == chat.SMS {
+		number = params.From
+	}
+	if number == "" {
+		return nil, ErrUnknownContact
+	}
+	p, err := phone.NewFromE164(number)
+	if err != nil {
+		return nil, err
+	}
+	return s.clientRepo.GetByPhone(ctx, p.Value())
+}
+
+// contactAddress normalizes the sender of a message to the address stored in the chat contacts.
+func contactAddress(channel chat.Channel, from string) string {
+	switch channel {
+	case chat.SMS, chat.WhatsApp:
+		return phone.Strip(from)
+	case chat.Email:
+		return strings.ToLower(from)
+	}
+	return from
+}
+
+// RegisterClientMessage adds a message a client sent on any channel to the client's chat. The
+// sender is looked up among the chat contacts first; an unknown sender is matched with a client
+// and the address is added to the client's chat, so the client can be answered on that channel.
 func (s *ChatService) RegisterClientMessage(
 	ctx context.Context,
 	params *cpassproviders.ReceivedMessageEvent,
 ) (chat.Chat, error) {
-	p, err := phone.NewFromE164(params.From)
-	if err != nil {
-		return nil, err
-	}
-	clientEntity, err := s.clientRepo.GetByPhone(ctx, p.Value())
-	if err != nil {
-		return nil, err
+	if params.Channel == "" {
+		params.Channel = chat.SMS
 	}
 	pool, err := composables.UsePool(ctx)
 	if err != nil {
@@ -131,12 +206,33 @@ func (s *ChatService) RegisterClientMessage(
 	}
 	defer tx.Rollback(ctx)
 	ctx = composables.WithTx(ctx, tx)
-	chatEntity, err := s.GetByClientIDOrCreate(ctx, clientEntity.ID())
+
+	address := contactAddress(params.Channel, params.From)
+	chatEntity, err := s.repo.GetByContact(ctx, params.Channel, address)
+	if err != nil && !errors.Is(err, persistence.ErrChatNotFound) {
+		return nil, err
+	}
+	if chatEntity == nil {
+		clientEntity, err := s.findClient(ctx, params)
+		if errors.Is(err, persistence.ErrClientNotFound) {
+			return nil, ErrUnknownContact
+		}
+		if err != nil {
+			return nil, err
+		}
+		chatEntity, err = s.GetByClientIDOrCreate(ctx, clientEntity.ID())
+		if err != nil {
+			return nil, err
+		}
+		chatEntity.AddContact(params.Channel, address)
+	}
+	clientEntity, err := s.clientRepo.GetByID(ctx, chatEntity.ClientID())
 	if err != nil {
 		return nil, err
 	}
 
 	if _, err := chatEntity.AddMessage(
+		params.Channel,
 		params.Body,
 		chat.NewClientSender(
 			clientEntity.ID(),
@@ -146,24 +242,209 @@ func (s *ChatService) RegisterClientMessage(
 	); err != nil {
 		return nil, err
 	}
+	assigned, err := s.autoAssign(ctx, chatEntity)
+	if err != nil {
+		return nil, err
+	}
 
 	updatedChat, err := s.repo.Update(ctx, chatEntity)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := eventbus.Enqueue(ctx, events.ChatMessageAddedTopic, events.ChatMessageAdded{
+		ChatID:     updatedChat.ID(),
+		ClientID:   clientEntity.ID(),
+		Channel:    string(params.Channel),
+		Message:    params.Body,
+		FromClient: true,
+		AssigneeID: updatedChat.Assignment().UserID,
+		TeamID:     updatedChat.Assignment().TeamID,
+	}); err != nil {
+		return nil, err
+	}
+	if assigned {
+		if err := enqueueAssigned(ctx, updatedChat); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
-	event, err := chat.NewMessageAddedEvent(ctx, chatEntity)
+	event, err := chat.NewMessageAddedEvent(ctx, updatedChat)
 	if err != nil {
 		return nil, err
 	}
 	s.publisher.Publish(event)
+	if assigned {
+		assignedEvent, err := chat.NewAssignedEvent(ctx, updatedChat)
+		if err != nil {
+			return nil, err
+		}
+		s.publisher.Publish(assignedEvent)
+	}
 	return updatedChat, nil
 }
 
+// autoAssign gives a chat nobody is assigned to to the next member of the teams with automatic
+// assignment, in turn. Chats stay unassigned when no team assigns chats automatically.
+func (s *ChatService) autoAssign(ctx context.Context, chatEntity chat.Chat) (bool, error) {
+	if chatEntity.Assignment().UserID != 0 {
+		return false, nil
+	}
+	teamID, userID, err := s.teamRepo.NextAssignee(ctx)
+	if errors.Is(err, team.ErrNoAssignee) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	chatEntity.Assign(userID, teamID)
+	return true, nil
+}
+
+// Assign makes the user and the team responsible for the chat. A user assigned along with a
+// team has to be a member of it.
+func (s *ChatService) Assign(ctx context.Context, chatID, userID, teamID uint) (chat.Chat, error) {
+	if teamID != 0 && userID != 0 {
+		teamEntity, err := s.teamRepo.GetByID(ctx, teamID)
+		if err != nil {
+			return nil, err
+		}
+		if !teamEntity.HasMember(userID) {
+			return nil, team.ErrNotMember
+		}
+	}
+	pool, err := composables.UsePool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+
+	chatEntity, err := s.repo.GetByID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	previous := chatEntity.Assignment()
+	if previous.UserID == userID && previous.TeamID == teamID {
+		return chatEntity, nil
+	}
+	chatEntity.Assign(userID, teamID)
+	updatedChat, err := s.repo.Update(ctx, chatEntity)
+	if err != nil {
+		return nil, err
+	}
+	if err := enqueueAssigned(ctx, updatedChat); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	event, err := chat.NewAssignedEvent(ctx, updatedChat)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(event)
+	return updatedChat, nil
+}
+
+func enqueueAssigned(ctx context.Context, chatEntity chat.Chat) error {
+	return eventbus.Enqueue(ctx, events.ChatAssignedTopic, events.ChatAssigned{
+		ChatID:     chatEntity.ID(),
+		ClientID:   chatEntity.ClientID(),
+		AssigneeID: chatEntity.Assignment().UserID,
+		TeamID:     chatEntity.Assignment().TeamID,
+	})
+}
+
+func (s *ChatService) SetStatus(ctx context.Context, chatID uint, status chat.Status) (chat.Chat, error) {
+	chatEntity, err := s.repo.GetByID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if err := chatEntity.SetStatus(status); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, chatEntity)
+}
+
+// CheckSLAs flags the chats that missed an SLA by now and publishes an SLABreachedEvent for
+// each breach once the flags are saved.
+func (s *ChatService) CheckSLAs(ctx context.Context, now time.Time) error {
+	pool, err := composables.UsePool(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+
+	chats, err := s.repo.GetOverdue(ctx, s.slaPolicy, now)
+	if err != nil {
+		return err
+	}
+	breaches := make([]*chat.SLABreachedEvent, 0)
+	for _, chatEntity := range chats {
+		breached := chatEntity.CheckSLA(s.slaPolicy, now)
+		if len(breached) == 0 {
+			continue
+		}
+		updatedChat, err := s.repo.Update(ctx, chatEntity)
+		if err != nil {
+			return err
+		}
+		for _, kind := range breached {
+			if err := eventbus.Enqueue(ctx, events.ChatSLABreachedTopic, events.ChatSLABreached{
+				ChatID:     updatedChat.ID(),
+				ClientID:   updatedChat.ClientID(),
+				Kind:       string(kind),
+				AssigneeID: updatedChat.Assignment().UserID,
+				TeamID:     updatedChat.Assignment().TeamID,
+			}); err != nil {
+				return err
+			}
+			breaches = append(breaches, chat.NewSLABreachedEvent(kind, updatedChat))
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	for _, event := range breaches {
+		log.Printf("Chat %d missed the %s SLA", event.Result.ID(), event.Kind)
+		s.publisher.Publish(event)
+	}
+	return nil
+}
+
+// Workload returns the workload of the operators with their response times in [from, to).
+func (s *ChatService) Workload(ctx context.Context, from, to time.Time) ([]chat.Workload, error) {
+	return s.repo.Workload(ctx, from, to)
+}
+
+// recipient returns the address of the chat's client on the channel. Clients are reached on the
+// phone channels by their phone number until they write from another one.
+func (s *ChatService) recipient(chatEntity chat.Chat, clientEntity client.Client, channel chat.Channel) (string, error) {
+	if contact, ok := chatEntity.Contact(channel); ok {
+		return contact.Address, nil
+	}
+	switch channel {
+	case chat.SMS, chat.WhatsApp:
+		return clientEntity.Phone().Value(), nil
+	}
+	return "", ErrNoContact
+}
+
 func (s *ChatService) SendMessage(ctx context.Context, dto SendMessageDTO) (chat.Chat, error) {
 	user, err := composables.UseUser(ctx)
 	if err != nil {
@@ -184,22 +465,54 @@ func (s *ChatService) SendMessage(ctx context.Context, dto SendMessageDTO) (chat
 	if err != nil {
 		return nil, err
 	}
-	_, err = chatEntity.AddMessage(dto.Message, chat.NewUserSender(user.ID(), user.FirstName(), user.LastName()))
+	channel := dto.Channel
+	if channel == "" {
+		channel = chatEntity.ReplyChannel()
+	}
+	provider, ok := s.providers[channel]
+	if !ok {
+		return nil, ErrChannelUnavailable
+	}
+	clientEntity, err := s.clientRepo.GetByID(ctx, chatEntity.ClientID())
 	if err != nil {
 		return nil, err
 	}
-	updatedChat, err := s.repo.Update(ctx, chatEntity)
+	to, err := s.recipient(chatEntity, clientEntity, channel)
 	if err != nil {
 		return nil, err
 	}
-	clientEntity, err := s.clientRepo.GetByID(ctx, chatEntity.ClientID())
+	_, err = chatEntity.AddMessage(channel, dto.Message, chat.NewUserSender(user.ID(), user.FirstName(), user.LastName()))
 	if err != nil {
 		return nil, err
 	}
-	if err := s.cpassProvider.SendMessage(ctx, cpassproviders.SendMessageDTO{
-		From:    configuration.Use().TwilioPhoneNumber,
-		To:      clientEntity.Phone().Value(),
+	updatedChat, err := s.repo.Update(ctx, chatEntity)
+	if err != nil {
+		return nil, err
+	}
+	sendDTO := cpassproviders.SendMessageDTO{
+		To:      to,
 		Message: dto.Message,
+	}
+	if channel == chat.SMS {
+		sendDTO.From = configuration.Use().TwilioPhoneNumber
+	}
+	if dto.TemplateID != 0 && channel == chat.WhatsApp {
+		sendDTO.Template, err = s.approvedTemplate(ctx, dto, clientEntity)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, err := provider.SendMessage(ctx, sendDTO); err != nil {
+		return nil, err
+	}
+
+	if err := eventbus.Enqueue(ctx, events.ChatMessageAddedTopic, events.ChatMessageAdded{
+		ChatID:     updatedChat.ID(),
+		ClientID:   clientEntity.ID(),
+		Channel:    string(channel),
+		Message:    dto.Message,
+		AssigneeID: updatedChat.Assignment().UserID,
+		TeamID:     updatedChat.Assignment().TeamID,
 	}); err != nil {
 		return nil, err
 	}
@@ -216,6 +529,33 @@ func (s *ChatService) SendMessage(ctx context.Context, dto SendMessageDTO) (chat
 	return updatedChat, nil
 }
 
+// approvedTemplate returns the approved WhatsApp template the message was rendered from.
+// A message edited after it was rendered, or rendered from a variant without an approved
+// template, is sent as free text.
+func (s *ChatService) approvedTemplate(
+	ctx context.Context,
+	dto SendMessageDTO,
+	clientEntity client.Client,
+) (*cpassproviders.Template, error) {
+	templateEntity, err := s.templateRepo.GetByID(ctx, dto.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	rendered := templateEntity.Render(dto.Locale, chat.WhatsApp, messagetemplate.ClientValues(clientEntity, nil))
+	if rendered.Variant.ExternalID == "" || rendered.Text != dto.Message {
+		return nil, nil
+	}
+	language := rendered.Variant.Locale
+	if language == "" {
+		language = dto.Locale
+	}
+	return &cpassproviders.Template{
+		Name:     rendered.Variant.ExternalID,
+		Language: language,
+		Params:   rendered.Params,
+	}, nil
+}
+
 func (s *ChatService) Delete(ctx context.Context, id uint) (chat.Chat, error) {
 	entity, err := s.repo.GetByID(ctx, id)
 	if err != nil {
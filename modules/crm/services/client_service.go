@@ -2,33 +2,61 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 )
 
 type ClientService struct {
 	repo        client.Repository
+	fieldRepo   customfield.Repository
 	chatService *ChatService
 	publisher   eventbus.EventBus
 }
 
 func NewClientService(
 	repo client.Repository,
+	fieldRepo customfield.Repository,
 	chatService *ChatService,
 	publisher eventbus.EventBus,
 ) *ClientService {
 	return &ClientService{
 		repo:        repo,
+		fieldRepo:   fieldRepo,
 		chatService: chatService,
 		publisher:   publisher,
 	}
 }
 
-func (s *ClientService) Count(ctx context.Context) (int64, error) {
-	return s.repo.Count(ctx)
+func (s *ClientService) Count(ctx context.Context, params *client.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+// CustomFields returns the custom field definitions clients are filled in with.
+func (s *ClientService) CustomFields(ctx context.Context) ([]customfield.Field, error) {
+	return s.fieldRepo.GetAll(ctx)
+}
+
+// validateCustomFields checks the values against the current field definitions and drops the
+// values of fields that no longer exist.
+func (s *ClientService) validateCustomFields(ctx context.Context, entity client.Client) (client.Client, error) {
+	fields, err := s.fieldRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[uint]string, len(fields))
+	for _, f := range fields {
+		v := entity.CustomFields()[f.ID()]
+		if err := f.Validate(v); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Key(), err)
+		}
+		values[f.ID()] = v
+	}
+	return entity.SetCustomFields(values), nil
 }
 
 func (s *ClientService) GetAll(ctx context.Context) ([]client.Client, error) {
@@ -54,6 +82,10 @@ func (s *ClientService) Create(ctx context.Context, data *client.CreateDTO) (cli
 	}
 	defer tx.Rollback(ctx)
 	ctx = composables.WithTx(ctx, tx)
+	entity, err = s.validateCustomFields(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
 	createdEntity, err := s.repo.Create(ctx, entity)
 	if err != nil {
 		return nil, err
@@ -85,6 +117,10 @@ func (s *ClientService) Update(ctx context.Context, id uint, data *client.Update
 	if err != nil {
 		return err
 	}
+	modified, err = s.validateCustomFields(ctx, modified)
+	if err != nil {
+		return err
+	}
 	if _, err := s.repo.Update(ctx, modified); err != nil {
 		return err
 	}
@@ -113,3 +149,47 @@ func (s *ClientService) Delete(ctx context.Context, id uint) (client.Client, err
 	}
 	return entity, nil
 }
+
+// FindDuplicates returns the clients that are probably the same person as the client, best match first.
+func (s *ClientService) FindDuplicates(ctx context.Context, id uint) ([]client.Duplicate, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.repo.FindDuplicateCandidates(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return client.FindDuplicates(entity, candidates), nil
+}
+
+// Merge folds the duplicate into the survivor: the duplicate's chats, messages and deals move to
+// the survivor and the duplicate is deleted, all in one transaction.
+func (s *ClientService) Merge(ctx context.Context, survivorID, duplicateID uint) (client.Client, error) {
+	if survivorID == duplicateID {
+		return nil, client.ErrMergeIntoSelf
+	}
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+	if _, err := s.repo.GetByID(ctx, survivorID); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, duplicateID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Merge(ctx, survivorID, duplicateID); err != nil {
+		return nil, err
+	}
+	merged, err := s.repo.GetByID(ctx, survivorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
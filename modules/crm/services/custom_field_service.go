@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+type CustomFieldService struct {
+	repo        customfield.Repository
+	segmentRepo segment.Repository
+	publisher   eventbus.EventBus
+}
+
+func NewCustomFieldService(
+	repo customfield.Repository,
+	segmentRepo segment.Repository,
+	publisher eventbus.EventBus,
+) *CustomFieldService {
+	return &CustomFieldService{
+		repo:        repo,
+		segmentRepo: segmentRepo,
+		publisher:   publisher,
+	}
+}
+
+func (s *CustomFieldService) GetAll(ctx context.Context) ([]customfield.Field, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *CustomFieldService) GetByID(ctx context.Context, id uint) (customfield.Field, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Create fails with customfield.ErrKeyTaken when another field has the key.
+func (s *CustomFieldService) Create(ctx context.Context, data *customfield.CreateDTO) (customfield.Field, error) {
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.repo.GetByKey(ctx, entity.Key())
+	if err == nil {
+		return nil, customfield.ErrKeyTaken
+	}
+	if !errors.Is(err, persistence.ErrCustomFieldNotFound) {
+		return nil, err
+	}
+	return s.repo.Create(ctx, entity)
+}
+
+func (s *CustomFieldService) Update(ctx context.Context, id uint, data *customfield.UpdateDTO) (customfield.Field, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := data.Apply(entity)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, modified)
+}
+
+// Delete removes the field and the values clients have for it. It fails with
+// customfield.ErrFieldInUse while a segment filters by the field.
+func (s *CustomFieldService) Delete(ctx context.Context, id uint) (customfield.Field, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := s.segmentRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, seg := range segments {
+		for _, c := range segment.Conditions(seg.Filter()) {
+			if key, ok := c.CustomFieldKey(); ok && key == entity.Key() {
+				return nil, customfield.ErrFieldInUse
+			}
+		}
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/deal"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+var ErrStageNotFound = errors.New("stage not found in the pipeline")
+
+// BoardColumn is a stage of the kanban board with the deals in it.
+type BoardColumn struct {
+	Stage pipeline.Stage
+	Deals []deal.Deal
+}
+
+type Board struct {
+	Pipeline pipeline.Pipeline
+	Columns  []BoardColumn
+}
+
+type DealService struct {
+	repo         deal.Repository
+	pipelineRepo pipeline.Repository
+	clientRepo   client.Repository
+	publisher    eventbus.EventBus
+}
+
+func NewDealService(
+	repo deal.Repository,
+	pipelineRepo pipeline.Repository,
+	clientRepo client.Repository,
+	publisher eventbus.EventBus,
+) *DealService {
+	return &DealService{
+		repo:         repo,
+		pipelineRepo: pipelineRepo,
+		clientRepo:   clientRepo,
+		publisher:    publisher,
+	}
+}
+
+func (s *DealService) Count(ctx context.Context, params *deal.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *DealService) GetByID(ctx context.Context, id uint) (deal.Deal, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *DealService) GetPaginated(ctx context.Context, params *deal.FindParams) ([]deal.Deal, error) {
+	return s.repo.GetPaginated(ctx, params)
+}
+
+// Board groups the deals of a pipeline by stage.
+func (s *DealService) Board(ctx context.Context, pipelineID uint, params *deal.FindParams) (*Board, error) {
+	p, err := s.pipelineRepo.GetByID(ctx, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	params.PipelineID = pipelineID
+	deals, err := s.repo.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	byStage := make(map[uint][]deal.Deal, len(p.Stages()))
+	for _, d := range deals {
+		byStage[d.StageID()] = append(byStage[d.StageID()], d)
+	}
+	board := &Board{Pipeline: p, Columns: make([]BoardColumn, 0, len(p.Stages()))}
+	for _, stage := range p.Stages() {
+		board.Columns = append(board.Columns, BoardColumn{Stage: stage, Deals: byStage[stage.ID]})
+	}
+	return board, nil
+}
+
+func (s *DealService) Create(ctx context.Context, data *deal.CreateDTO) (deal.Deal, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.pipelineRepo.GetByID(ctx, data.PipelineID)
+	if err != nil {
+		return nil, err
+	}
+	stage, ok := p.FirstStage()
+	if data.StageID != 0 {
+		stage, ok = p.Stage(data.StageID)
+	}
+	if !ok {
+		return nil, ErrStageNotFound
+	}
+	entity, err := data.ToEntity(stage, u.ID())
+	if err != nil {
+		return nil, err
+	}
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+	createdEntity, err := s.repo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	createdEvent, err := deal.NewCreatedEvent(ctx, createdEntity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(createdEvent)
+	return createdEntity, nil
+}
+
+func (s *DealService) Update(ctx context.Context, id uint, data *deal.UpdateDTO) (deal.Deal, error) {
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := data.Apply(entity); err != nil {
+		return nil, err
+	}
+	updatedEntity, err := s.repo.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	updatedEvent, err := deal.NewUpdatedEvent(ctx, updatedEntity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(updatedEvent)
+	return updatedEntity, nil
+}
+
+// Move puts a deal in another stage of its pipeline.
+func (s *DealService) Move(ctx context.Context, id uint, data *deal.MoveDTO) (deal.Deal, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.pipelineRepo.GetByID(ctx, entity.PipelineID())
+	if err != nil {
+		return nil, err
+	}
+	stage, ok := p.Stage(data.StageID)
+	if !ok {
+		return nil, ErrStageNotFound
+	}
+	if err := entity.MoveTo(stage, u.ID()); err != nil {
+		return nil, err
+	}
+	updatedEntity, err := s.repo.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	history := updatedEntity.History()
+	stageChangedEvent, err := deal.NewStageChangedEvent(ctx, history[len(history)-1], updatedEntity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(stageChangedEvent)
+	return updatedEntity, nil
+}
+
+// Convert marks a won deal as converted and enqueues the creation of the invoice or the order,
+// both happen in the same transaction so a conversion is never lost or done twice.
+func (s *DealService) Convert(ctx context.Context, id uint, data *deal.ConvertDTO) (deal.Deal, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target, err := deal.NewConversionTarget(data.Target)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Convert(target); err != nil {
+		return nil, err
+	}
+	clientEntity, err := s.clientRepo.GetByID(ctx, entity.ClientID())
+	if err != nil {
+		return nil, err
+	}
+	updatedEntity, err := s.repo.Update(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	converted := events.DealConverted{
+		DealID:      updatedEntity.ID(),
+		Target:      string(target),
+		Title:       updatedEntity.Title(),
+		ClientID:    clientEntity.ID(),
+		ClientName:  clientFullName(clientEntity),
+		Amount:      updatedEntity.Value().Value(),
+		Currency:    string(updatedEntity.Value().Currency()),
+		ConvertedBy: u.ID(),
+	}
+	if clientEntity.Phone() != nil {
+		converted.ClientPhone = clientEntity.Phone().Value()
+	}
+	if err := eventbus.Enqueue(ctx, events.DealConvertedTopic, converted); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updatedEntity, nil
+}
+
+func (s *DealService) Delete(ctx context.Context, id uint) (deal.Deal, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	deletedEvent, err := deal.NewDeletedEvent(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(deletedEvent)
+	return entity, nil
+}
+
+func clientFullName(c client.Client) string {
+	if c.LastName() == "" {
+		return c.FirstName()
+	}
+	return c.FirstName() + " " + c.LastName()
+}
@@ -3,21 +3,27 @@ package services
 import (
 	"context"
 
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/message-template"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 )
 
 type MessageTemplateService struct {
-	repo      messagetemplate.Repository
-	publisher eventbus.EventBus
+	repo       messagetemplate.Repository
+	clientRepo client.Repository
+	publisher  eventbus.EventBus
 }
 
 func NewMessageTemplateService(
-	repo messagetemplate.Repository, publisher eventbus.EventBus,
+	repo messagetemplate.Repository,
+	clientRepo client.Repository,
+	publisher eventbus.EventBus,
 ) *MessageTemplateService {
 	return &MessageTemplateService{
-		repo:      repo,
-		publisher: publisher,
+		repo:       repo,
+		clientRepo: clientRepo,
+		publisher:  publisher,
 	}
 }
 
@@ -78,3 +84,21 @@ func (s *MessageTemplateService) Delete(ctx context.Context, id uint) (messagete
 	}
 	return entity, nil
 }
+
+// Render renders a template for a client in the variant matching the locale and the channel.
+func (s *MessageTemplateService) Render(
+	ctx context.Context,
+	id, clientID uint,
+	locale string,
+	channel chat.Channel,
+) (*messagetemplate.Rendered, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	clientEntity, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return entity.Render(locale, channel, messagetemplate.ClientValues(clientEntity, nil)), nil
+}
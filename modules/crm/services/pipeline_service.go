@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/pipeline"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+type PipelineService struct {
+	repo      pipeline.Repository
+	publisher eventbus.EventBus
+}
+
+func NewPipelineService(repo pipeline.Repository, publisher eventbus.EventBus) *PipelineService {
+	return &PipelineService{
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+func (s *PipelineService) GetAll(ctx context.Context) ([]pipeline.Pipeline, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *PipelineService) GetByID(ctx context.Context, id uint) (pipeline.Pipeline, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *PipelineService) Create(ctx context.Context, data *pipeline.SaveDTO) (pipeline.Pipeline, error) {
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+	createdEntity, err := s.repo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return createdEntity, nil
+}
+
+// Update saves the pipeline with its stages in a transaction of its own, so nothing is kept
+// when a removed stage still has deals.
+func (s *PipelineService) Update(ctx context.Context, id uint, data *pipeline.SaveDTO) (pipeline.Pipeline, error) {
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	ctx = composables.WithTx(ctx, tx)
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := data.Apply(entity)
+	if err != nil {
+		return nil, err
+	}
+	updatedEntity, err := s.repo.Update(ctx, modified)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updatedEntity, nil
+}
+
+func (s *PipelineService) Delete(ctx context.Context, id uint) (pipeline.Pipeline, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
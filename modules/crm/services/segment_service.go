@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/client"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/customfield"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/segment"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+type SegmentService struct {
+	repo       segment.Repository
+	clientRepo client.Repository
+	fieldRepo  customfield.Repository
+	publisher  eventbus.EventBus
+}
+
+func NewSegmentService(
+	repo segment.Repository,
+	clientRepo client.Repository,
+	fieldRepo customfield.Repository,
+	publisher eventbus.EventBus,
+) *SegmentService {
+	return &SegmentService{
+		repo:       repo,
+		clientRepo: clientRepo,
+		fieldRepo:  fieldRepo,
+		publisher:  publisher,
+	}
+}
+
+func (s *SegmentService) Count(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+func (s *SegmentService) GetAll(ctx context.Context) ([]segment.Segment, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *SegmentService) GetByID(ctx context.Context, id uint) (segment.Segment, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// bind checks the custom fields of the expression against their definitions.
+func (s *SegmentService) bind(ctx context.Context, expr segment.Expression) (segment.Expression, error) {
+	fields, err := s.fieldRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return segment.Bind(expr, fields)
+}
+
+// Validate parses the expression and checks it against the custom fields.
+func (s *SegmentService) Validate(ctx context.Context, expression string) error {
+	expr, err := segment.Parse(expression)
+	if err != nil {
+		return err
+	}
+	_, err = s.bind(ctx, expr)
+	return err
+}
+
+func (s *SegmentService) Create(ctx context.Context, data *segment.SaveDTO) (segment.Segment, error) {
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.bind(ctx, entity.Filter()); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, entity)
+}
+
+func (s *SegmentService) Update(ctx context.Context, id uint, data *segment.SaveDTO) (segment.Segment, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := data.Apply(entity)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.bind(ctx, modified.Filter()); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, modified)
+}
+
+func (s *SegmentService) Delete(ctx context.Context, id uint) (segment.Segment, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Filter returns the expression of the segment ready to be used in client.FindParams.
+func (s *SegmentService) Filter(ctx context.Context, id uint) (segment.Expression, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.bind(ctx, entity.Filter())
+}
+
+// Clients returns the clients currently in the segment. Limit and Offset of params page the result.
+func (s *SegmentService) Clients(ctx context.Context, id uint, params *client.FindParams) ([]client.Client, error) {
+	filter, err := s.Filter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	params.Filter = filter
+	return s.clientRepo.GetPaginated(ctx, params)
+}
+
+func (s *SegmentService) CountClients(ctx context.Context, id uint) (int64, error) {
+	filter, err := s.Filter(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return s.clientRepo.Count(ctx, &client.FindParams{Filter: filter})
+}
+
+// Preview returns the first clients matching an expression that is not saved yet and how many
+// clients match it.
+func (s *SegmentService) Preview(ctx context.Context, expression string, limit int) ([]client.Client, int64, error) {
+	expr, err := segment.Parse(expression)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter, err := s.bind(ctx, expr)
+	if err != nil {
+		return nil, 0, err
+	}
+	params := &client.FindParams{
+		Filter: filter,
+		Limit:  limit,
+		SortBy: client.SortBy{Fields: []client.Field{client.FirstName, client.LastName}, Ascending: true},
+	}
+	clients, err := s.clientRepo.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := s.clientRepo.Count(ctx, &client.FindParams{Filter: filter})
+	if err != nil {
+		return nil, 0, err
+	}
+	return clients, count, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/entities/team"
+)
+
+type TeamService struct {
+	repo team.Repository
+}
+
+func NewTeamService(repo team.Repository) *TeamService {
+	return &TeamService{
+		repo: repo,
+	}
+}
+
+func (s *TeamService) Count(ctx context.Context) (int64, error) {
+	return s.repo.Count(ctx)
+}
+
+func (s *TeamService) GetAll(ctx context.Context) ([]team.Team, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *TeamService) GetByID(ctx context.Context, id uint) (team.Team, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *TeamService) Create(ctx context.Context, data *team.SaveDTO) (team.Team, error) {
+	return s.repo.Create(ctx, data.ToEntity())
+}
+
+func (s *TeamService) Update(ctx context.Context, id uint, data *team.SaveDTO) (team.Team, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, data.Apply(entity))
+}
+
+// Delete removes the team, its chats stay assigned to their operators.
+func (s *TeamService) Delete(ctx context.Context, id uint) (team.Team, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
@@ -0,0 +1,93 @@
+package accountingperiod
+
+import (
+	"time"
+)
+
+// Balance is the balance of a money account at the end of a closed period.
+type Balance struct {
+	MoneyAccountID uint
+	Balance        float64
+	CurrencyCode   string
+}
+
+// Period is a calendar month of the books.
+type Period struct {
+	ID        uint
+	Start     time.Time
+	Status    Status
+	ClosedAt  *time.Time
+	Balances  []Balance
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// New returns an open period for the month containing date.
+func New(date time.Time) *Period {
+	return &Period{
+		ID:        0,
+		Start:     MonthStart(date),
+		Status:    Open,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// MonthStart truncates date to the first day of its month.
+func MonthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the last day of the period.
+func (p *Period) End() time.Time {
+	return p.Start.AddDate(0, 1, -1)
+}
+
+func (p *Period) Contains(date time.Time) bool {
+	return MonthStart(date).Equal(p.Start)
+}
+
+func (p *Period) IsOpen() bool {
+	return p.Status == Open
+}
+
+// Close closes the period and records the closing balances.
+func (p *Period) Close(balances []Balance) error {
+	if !p.IsOpen() {
+		return ErrAlreadyClosed
+	}
+	now := time.Now()
+	p.Status = Closed
+	p.ClosedAt = &now
+	p.Balances = balances
+	p.UpdatedAt = now
+	return nil
+}
+
+// Reopen opens a closed period again and discards its closing balances.
+func (p *Period) Reopen() error {
+	switch p.Status {
+	case Locked:
+		return ErrPeriodLocked
+	case Open:
+		return ErrNotClosed
+	}
+	p.Status = Open
+	p.ClosedAt = nil
+	p.Balances = nil
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Lock makes a closed period permanent.
+func (p *Period) Lock() error {
+	switch p.Status {
+	case Locked:
+		return ErrPeriodLocked
+	case Open:
+		return ErrNotClosed
+	}
+	p.Status = Locked
+	p.UpdatedAt = time.Now()
+	return nil
+}
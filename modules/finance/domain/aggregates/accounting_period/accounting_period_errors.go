@@ -0,0 +1,10 @@
+package accountingperiod
+
+import "errors"
+
+var (
+	ErrPeriodClosed  = errors.New("accounting period is closed")
+	ErrPeriodLocked  = errors.New("accounting period is locked")
+	ErrAlreadyClosed = errors.New("accounting period is already closed")
+	ErrNotClosed     = errors.New("accounting period is not closed")
+)
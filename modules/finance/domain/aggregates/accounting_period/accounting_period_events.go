@@ -0,0 +1,32 @@
+package accountingperiod
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func NewStatusChangedEvent(ctx context.Context, result Period) (*StatusChangedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &StatusChangedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+// StatusChangedEvent is published when a period is closed, reopened or locked.
+type StatusChangedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Period
+}
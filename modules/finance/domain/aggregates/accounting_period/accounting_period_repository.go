@@ -0,0 +1,23 @@
+package accountingperiod
+
+import (
+	"context"
+	"time"
+)
+
+type FindParams struct {
+	Limit  int
+	Offset int
+	SortBy []string
+}
+
+type Repository interface {
+	Count(ctx context.Context) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Period, error)
+	GetByID(ctx context.Context, id uint) (*Period, error)
+	// GetByMonth returns the period starting on the first day of the month containing date.
+	GetByMonth(ctx context.Context, date time.Time) (*Period, error)
+	Create(ctx context.Context, data *Period) error
+	// Update saves the status of the period and replaces its closing balances.
+	Update(ctx context.Context, data *Period) error
+}
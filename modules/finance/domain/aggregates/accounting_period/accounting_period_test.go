@@ -0,0 +1,51 @@
+package accountingperiod_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+)
+
+func TestPeriod_Bounds(t *testing.T) {
+	period := accountingperiod.New(time.Date(2024, time.February, 17, 15, 0, 0, 0, time.UTC))
+	if got := period.End(); !got.Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected end of february, got %s", got)
+	}
+	if !period.Contains(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected period to contain its first day")
+	}
+	if period.Contains(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected period not to contain the next month")
+	}
+}
+
+func TestPeriod_Transitions(t *testing.T) {
+	period := accountingperiod.New(time.Now())
+	if err := period.Lock(); !errors.Is(err, accountingperiod.ErrNotClosed) {
+		t.Errorf("expected %v, got %v", accountingperiod.ErrNotClosed, err)
+	}
+	balances := []accountingperiod.Balance{{MoneyAccountID: 1, Balance: 100, CurrencyCode: "USD"}}
+	if err := period.Close(balances); err != nil {
+		t.Fatal(err)
+	}
+	if err := period.Close(balances); !errors.Is(err, accountingperiod.ErrAlreadyClosed) {
+		t.Errorf("expected %v, got %v", accountingperiod.ErrAlreadyClosed, err)
+	}
+	if err := period.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	if period.ClosedAt != nil || period.Balances != nil {
+		t.Error("expected reopening to discard the closing snapshot")
+	}
+	if err := period.Close(balances); err != nil {
+		t.Fatal(err)
+	}
+	if err := period.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := period.Reopen(); !errors.Is(err, accountingperiod.ErrPeriodLocked) {
+		t.Errorf("expected %v, got %v", accountingperiod.ErrPeriodLocked, err)
+	}
+}
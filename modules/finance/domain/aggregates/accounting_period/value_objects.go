@@ -0,0 +1,30 @@
+package accountingperiod
+
+import "fmt"
+
+type Status string
+
+const (
+	// Open periods accept new and changed documents.
+	Open Status = "OPEN"
+	// Closed periods refuse changes but can be reopened.
+	Closed Status = "CLOSED"
+	// Locked periods are closed for good.
+	Locked Status = "LOCKED"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Open, Closed, Locked:
+		return true
+	}
+	return false
+}
+
+func NewStatus(value string) (Status, error) {
+	s := Status(value)
+	if !s.IsValid() {
+		return "", fmt.Errorf("invalid accounting period status: %s", value)
+	}
+	return s, nil
+}
@@ -0,0 +1,101 @@
+package bankstatement
+
+import (
+	"time"
+)
+
+// Statement is a file imported from a bank for a money account.
+type Statement struct {
+	ID             uint
+	MoneyAccountID uint
+	Format         Format
+	FileName       string
+	Lines          []*Line
+	CreatedAt      time.Time
+}
+
+// Line is a single booking of a bank statement. Amount is positive for money received
+// and negative for money paid from the account.
+type Line struct {
+	ID               uint
+	StatementID      uint
+	MoneyAccountID   uint
+	Date             time.Time
+	Amount           float64
+	Currency         string
+	Description      string
+	CounterpartyName string
+	CounterpartyTIN  string
+	Reference        string
+	Status           Status
+	TransactionID    *uint
+	CreatedAt        time.Time
+}
+
+func New(moneyAccountID uint, format Format, fileName string, lines []*Line) (*Statement, error) {
+	if len(lines) == 0 {
+		return nil, ErrEmptyStatement
+	}
+	for _, l := range lines {
+		l.MoneyAccountID = moneyAccountID
+		l.Status = Unmatched
+		l.TransactionID = nil
+	}
+	return &Statement{
+		ID:             0,
+		MoneyAccountID: moneyAccountID,
+		Format:         format,
+		FileName:       fileName,
+		Lines:          lines,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+func (l *Line) IsInflow() bool {
+	return l.Amount > 0
+}
+
+// Match suggests a transaction for the line.
+func (l *Line) Match(transactionID uint) error {
+	switch l.Status {
+	case Reconciled:
+		return ErrAlreadyReconciled
+	case Ignored:
+		return ErrLineIgnored
+	}
+	l.Status = Matched
+	l.TransactionID = &transactionID
+	return nil
+}
+
+// Confirm accepts the suggested transaction.
+func (l *Line) Confirm() error {
+	if l.Status != Matched || l.TransactionID == nil {
+		return ErrNotMatched
+	}
+	l.Status = Reconciled
+	return nil
+}
+
+// Reconcile links the line to a transaction created for it.
+func (l *Line) Reconcile(transactionID uint) error {
+	if err := l.Match(transactionID); err != nil {
+		return err
+	}
+	return l.Confirm()
+}
+
+func (l *Line) Ignore() error {
+	if l.Status == Reconciled {
+		return ErrAlreadyReconciled
+	}
+	l.Status = Ignored
+	l.TransactionID = nil
+	return nil
+}
+
+// Reset returns the line to the unmatched state and releases its transaction.
+func (l *Line) Reset() {
+	l.Status = Unmatched
+	l.TransactionID = nil
+}
@@ -0,0 +1,62 @@
+package bankstatement
+
+import (
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type ImportDTO struct {
+	MoneyAccountID uint   `validate:"required"`
+	Format         string `validate:"required,oneof=CSV MT940 CAMT053"`
+}
+
+// CreateTransactionDTO describes the payment (for money received) or the expense (for money paid)
+// created from an unmatched line.
+type CreateTransactionDTO struct {
+	CounterpartyID   uint
+	CategoryID       uint
+	AccountingPeriod shared.DateOnly
+	Comment          string
+}
+
+func translateErrors(l ut.Translator, errs error) (map[string]string, bool) {
+	errors := map[string]string{}
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *ImportDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	return translateErrors(l, constants.Validate.Struct(d))
+}
+
+// Ok checks that the document type of the line has what it needs.
+func (d *CreateTransactionDTO) Ok(line *Line, l ut.Translator) (map[string]string, bool) {
+	var errs error
+	if line.IsInflow() {
+		errs = constants.Validate.Var(d.CounterpartyID, "required")
+		if errs != nil {
+			return translateField(l, "CounterpartyID", errs)
+		}
+	} else {
+		errs = constants.Validate.Var(d.CategoryID, "required")
+		if errs != nil {
+			return translateField(l, "CategoryID", errs)
+		}
+	}
+	return map[string]string{}, true
+}
+
+func translateField(l ut.Translator, field string, errs error) (map[string]string, bool) {
+	errors := map[string]string{}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[field] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
@@ -0,0 +1,10 @@
+package bankstatement
+
+import "errors"
+
+var (
+	ErrAlreadyReconciled = errors.New("bank line is already reconciled")
+	ErrNotMatched        = errors.New("bank line is not matched to a transaction")
+	ErrLineIgnored       = errors.New("bank line is ignored")
+	ErrEmptyStatement    = errors.New("bank statement has no lines")
+)
@@ -0,0 +1,54 @@
+package bankstatement
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func NewImportedEvent(ctx context.Context, result Statement) (*ImportedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+func NewLineReconciledEvent(ctx context.Context, result Line) (*LineReconciledEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &LineReconciledEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+type ImportedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Statement
+}
+
+// LineReconciledEvent is published when a line is confirmed against a transaction.
+type LineReconciledEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Line
+}
@@ -0,0 +1,35 @@
+package bankstatement
+
+import (
+	"context"
+	"time"
+)
+
+type FindParams struct {
+	MoneyAccountID uint
+	Limit          int
+	Offset         int
+	SortBy         []string
+}
+
+type LineFindParams struct {
+	StatementID uint
+	Status      Status
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	// GetPaginated returns statements without their lines.
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Statement, error)
+	GetByID(ctx context.Context, id uint) (*Statement, error)
+	GetLines(ctx context.Context, params *LineFindParams) ([]*Line, error)
+	GetLineByID(ctx context.Context, id uint) (*Line, error)
+	// LineExists reports whether the money account already has a line with the same date, amount and reference.
+	LineExists(ctx context.Context, line *Line) (bool, error)
+	// Candidates returns unreconciled transactions of the money account between from and to
+	// that are not suggested for another line.
+	Candidates(ctx context.Context, moneyAccountID uint, from, to time.Time) ([]*Candidate, error)
+	Create(ctx context.Context, data *Statement) error
+	UpdateLine(ctx context.Context, data *Line) error
+	Delete(ctx context.Context, id uint) error
+}
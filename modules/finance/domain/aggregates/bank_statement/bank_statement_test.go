@@ -0,0 +1,70 @@
+package bankstatement_test
+
+import (
+	"testing"
+	"time"
+
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+)
+
+func date(day int) time.Time {
+	return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLine_Lifecycle(t *testing.T) {
+	line := &bankstatement.Line{Amount: 100, Status: bankstatement.Unmatched}
+	if err := line.Confirm(); err != bankstatement.ErrNotMatched {
+		t.Errorf("expected ErrNotMatched, got %v", err)
+	}
+	if err := line.Match(7); err != nil {
+		t.Fatal(err)
+	}
+	if err := line.Confirm(); err != nil {
+		t.Fatal(err)
+	}
+	if line.Status != bankstatement.Reconciled || *line.TransactionID != 7 {
+		t.Errorf("unexpected line state: %s", line.Status)
+	}
+	if err := line.Ignore(); err != bankstatement.ErrAlreadyReconciled {
+		t.Errorf("expected ErrAlreadyReconciled, got %v", err)
+	}
+	line.Reset()
+	if line.Status != bankstatement.Unmatched || line.TransactionID != nil {
+		t.Errorf("expected reset line, got %s", line.Status)
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	candidates := []*bankstatement.Candidate{
+		{TransactionID: 1, Date: date(1), Amount: 100},
+		{TransactionID: 2, Date: date(10), Amount: 100},
+		{TransactionID: 3, Date: date(9), Amount: -100},
+		{TransactionID: 4, Date: date(12), Amount: 100, CounterpartyTIN: "123456789"},
+		{TransactionID: 5, Date: date(10), Amount: 100, CounterpartyTIN: "987654321"},
+	}
+	cases := []struct {
+		name     string
+		line     *bankstatement.Line
+		taken    map[uint]bool
+		expected uint
+	}{
+		{"nearest date", &bankstatement.Line{Date: date(9), Amount: 100}, nil, 2},
+		{"sign matters", &bankstatement.Line{Date: date(9), Amount: -100}, nil, 3},
+		{"tin outweighs date", &bankstatement.Line{Date: date(10), Amount: 100, CounterpartyTIN: "123456789"}, nil, 4},
+		{"taken skipped", &bankstatement.Line{Date: date(9), Amount: 100}, map[uint]bool{2: true, 5: true}, 4},
+		{"outside window", &bankstatement.Line{Date: date(20), Amount: 100}, nil, 0},
+		{"different amount", &bankstatement.Line{Date: date(10), Amount: 99.5}, nil, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := bankstatement.BestMatch(c.line, candidates, c.taken)
+			var id uint
+			if got != nil {
+				id = got.TransactionID
+			}
+			if id != c.expected {
+				t.Errorf("expected transaction %d, got %d", c.expected, id)
+			}
+		})
+	}
+}
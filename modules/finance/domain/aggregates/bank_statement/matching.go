@@ -0,0 +1,57 @@
+package bankstatement
+
+import (
+	"math"
+	"time"
+)
+
+// MatchWindow is how far apart the bank date and the transaction date may be.
+const MatchWindow = 3 * 24 * time.Hour
+
+// Candidate is an unreconciled transaction of the money account that a line may be matched to.
+// Amount has the same sign convention as Line.Amount.
+type Candidate struct {
+	TransactionID   uint
+	Date            time.Time
+	Amount          float64
+	CounterpartyTIN string
+}
+
+func (c *Candidate) score(l *Line) (int, bool) {
+	if math.Abs(c.Amount-l.Amount) >= 0.005 {
+		return 0, false
+	}
+	distance := c.Date.Sub(l.Date)
+	if distance < 0 {
+		distance = -distance
+	}
+	if distance > MatchWindow {
+		return 0, false
+	}
+	// Closer dates score higher; a matching TIN outweighs any date difference.
+	score := int((MatchWindow - distance) / (24 * time.Hour))
+	if l.CounterpartyTIN != "" && c.CounterpartyTIN != "" {
+		if l.CounterpartyTIN != c.CounterpartyTIN {
+			return 0, false
+		}
+		score += 10
+	}
+	return score, true
+}
+
+// BestMatch picks the candidate with the same amount, the nearest date and, when both sides
+// know it, the same counterparty TIN. Candidates in taken are skipped. Returns nil when nothing fits.
+func BestMatch(l *Line, candidates []*Candidate, taken map[uint]bool) *Candidate {
+	var best *Candidate
+	bestScore := -1
+	for _, c := range candidates {
+		if taken[c.TransactionID] {
+			continue
+		}
+		score, ok := c.score(l)
+		if ok && score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+	return best
+}
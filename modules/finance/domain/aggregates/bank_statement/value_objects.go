@@ -0,0 +1,56 @@
+package bankstatement
+
+import "fmt"
+
+type Format string
+
+const (
+	CSV     Format = "CSV"
+	MT940   Format = "MT940"
+	Camt053 Format = "CAMT053"
+)
+
+func (f Format) IsValid() bool {
+	switch f {
+	case CSV, MT940, Camt053:
+		return true
+	}
+	return false
+}
+
+func NewFormat(value string) (Format, error) {
+	f := Format(value)
+	if !f.IsValid() {
+		return "", fmt.Errorf("invalid bank statement format: %s", value)
+	}
+	return f, nil
+}
+
+type Status string
+
+const (
+	// Unmatched lines have no transaction yet.
+	Unmatched Status = "UNMATCHED"
+	// Matched lines have a transaction suggested by auto-matching that awaits confirmation.
+	Matched Status = "MATCHED"
+	// Reconciled lines are confirmed against a transaction.
+	Reconciled Status = "RECONCILED"
+	// Ignored lines need no transaction, e.g. bank fees booked elsewhere.
+	Ignored Status = "IGNORED"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Unmatched, Matched, Reconciled, Ignored:
+		return true
+	}
+	return false
+}
+
+func NewStatus(value string) (Status, error) {
+	s := Status(value)
+	if !s.IsValid() {
+		return "", fmt.Errorf("invalid bank line status: %s", value)
+	}
+	return s, nil
+}
@@ -0,0 +1,163 @@
+package bill
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+)
+
+// Bill is an invoice received from a supplier. It goes through
+// submitted -> approved -> scheduled -> paid; a submitted or approved bill may be rejected
+// and a rejected bill is submitted again once corrected. Paying a bill records an expense.
+type Bill struct {
+	ID              uint
+	Number          string // supplier's invoice number
+	CounterpartyID  uint
+	CategoryID      uint
+	Currency        currency.Code
+	Amount          float64
+	BillDate        time.Time
+	DueDate         time.Time
+	Description     string
+	Status          Status
+	SubmittedBy     uint
+	ApprovedBy      *uint
+	ApprovedAt      *time.Time
+	RejectionReason string
+	ScheduledDate   *time.Time
+	MoneyAccountID  *uint
+	ExpenseID       *uint
+	PaidAt          *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func New(
+	number string,
+	counterpartyID, categoryID uint,
+	code currency.Code,
+	amount float64,
+	billDate, dueDate time.Time,
+	description string,
+	submittedBy uint,
+) (*Bill, error) {
+	if dueDate.Before(billDate) {
+		return nil, ErrInvalidDueDate
+	}
+	return &Bill{
+		ID:             0,
+		Number:         number,
+		CounterpartyID: counterpartyID,
+		CategoryID:     categoryID,
+		Currency:       code,
+		Amount:         amount,
+		BillDate:       billDate,
+		DueDate:        dueDate,
+		Description:    description,
+		Status:         Submitted,
+		SubmittedBy:    submittedBy,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}, nil
+}
+
+// IsEditable reports whether the content of the bill can still change.
+func (b *Bill) IsEditable() bool {
+	return b.Status == Submitted || b.Status == Rejected
+}
+
+// IsPosted reports whether the bill is recognized as a payable in the ledger.
+func (b *Bill) IsPosted() bool {
+	return b.Status == Approved || b.Status == Scheduled || b.Status == Paid
+}
+
+// Update corrects a submitted or rejected bill and submits it for approval again.
+func (b *Bill) Update(
+	number string,
+	counterpartyID, categoryID uint,
+	code currency.Code,
+	amount float64,
+	billDate, dueDate time.Time,
+	description string,
+) error {
+	if !b.IsEditable() {
+		return ErrInvalidTransition
+	}
+	if dueDate.Before(billDate) {
+		return ErrInvalidDueDate
+	}
+	b.Number = number
+	b.CounterpartyID = counterpartyID
+	b.CategoryID = categoryID
+	b.Currency = code
+	b.Amount = amount
+	b.BillDate = billDate
+	b.DueDate = dueDate
+	b.Description = description
+	b.Status = Submitted
+	b.RejectionReason = ""
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// Approve accepts a submitted bill. The approver must be someone else than the submitter.
+func (b *Bill) Approve(approverID uint) error {
+	if b.Status != Submitted {
+		return ErrInvalidTransition
+	}
+	if approverID == b.SubmittedBy {
+		return ErrSelfApproval
+	}
+	now := time.Now()
+	b.Status = Approved
+	b.ApprovedBy = &approverID
+	b.ApprovedAt = &now
+	b.UpdatedAt = now
+	return nil
+}
+
+// Reject sends a submitted or approved bill back to the submitter.
+func (b *Bill) Reject(reason string) error {
+	if b.Status != Submitted && b.Status != Approved {
+		return ErrInvalidTransition
+	}
+	b.Status = Rejected
+	b.RejectionReason = reason
+	b.ApprovedBy = nil
+	b.ApprovedAt = nil
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// Schedule plans the payment of an approved bill from a money account on the given date.
+// An already scheduled bill can be rescheduled.
+func (b *Bill) Schedule(moneyAccountID uint, date time.Time) error {
+	if b.Status != Approved && b.Status != Scheduled {
+		return ErrInvalidTransition
+	}
+	b.Status = Scheduled
+	b.MoneyAccountID = &moneyAccountID
+	b.ScheduledDate = &date
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkPaid records the expense the scheduled bill was paid with.
+func (b *Bill) MarkPaid(expenseID uint, at time.Time) error {
+	if b.Status != Scheduled {
+		return ErrInvalidTransition
+	}
+	b.Status = Paid
+	b.ExpenseID = &expenseID
+	b.PaidAt = &at
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsOverdue reports whether an unpaid bill is past its due date at the given moment.
+func (b *Bill) IsOverdue(at time.Time) bool {
+	if b.Status == Paid || b.Status == Rejected {
+		return false
+	}
+	return b.DueDate.Before(time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC))
+}
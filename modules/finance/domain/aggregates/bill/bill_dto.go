@@ -0,0 +1,96 @@
+package bill
+
+import (
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// SaveDTO is used both to submit a bill and to correct it.
+type SaveDTO struct {
+	Number         string          `validate:"required,max=64"`
+	CounterpartyID uint            `validate:"required"`
+	CategoryID     uint            `validate:"required"`
+	CurrencyCode   string          `validate:"required,len=3"`
+	Amount         float64         `validate:"required,gt=0"`
+	BillDate       shared.DateOnly `validate:"required"`
+	DueDate        shared.DateOnly `validate:"required"`
+	Description    string
+}
+
+type RejectDTO struct {
+	Reason string `validate:"required"`
+}
+
+type ScheduleDTO struct {
+	MoneyAccountID uint            `validate:"required"`
+	Date           shared.DateOnly `validate:"required"`
+}
+
+func translateErrors(l ut.Translator, errs error) (map[string]string, bool) {
+	errors := map[string]string{}
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *SaveDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors, ok := translateErrors(l, constants.Validate.Struct(d))
+	if time.Time(d.DueDate).Before(time.Time(d.BillDate)) {
+		errors["DueDate"] = ErrInvalidDueDate.Error()
+		ok = false
+	}
+	return errors, ok
+}
+
+func (d *SaveDTO) ToEntity(submittedBy uint) (*Bill, error) {
+	code, err := currency.NewCode(d.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	return New(
+		d.Number,
+		d.CounterpartyID,
+		d.CategoryID,
+		code,
+		d.Amount,
+		time.Time(d.BillDate),
+		time.Time(d.DueDate),
+		d.Description,
+		submittedBy,
+	)
+}
+
+// Apply corrects a bill with the content of the DTO.
+func (d *SaveDTO) Apply(entity *Bill) error {
+	code, err := currency.NewCode(d.CurrencyCode)
+	if err != nil {
+		return err
+	}
+	return entity.Update(
+		d.Number,
+		d.CounterpartyID,
+		d.CategoryID,
+		code,
+		d.Amount,
+		time.Time(d.BillDate),
+		time.Time(d.DueDate),
+		d.Description,
+	)
+}
+
+func (d *RejectDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	return translateErrors(l, constants.Validate.Struct(d))
+}
+
+func (d *ScheduleDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	return translateErrors(l, constants.Validate.Struct(d))
+}
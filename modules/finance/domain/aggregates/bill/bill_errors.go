@@ -0,0 +1,12 @@
+package bill
+
+import "errors"
+
+var (
+	ErrInvalidTransition = errors.New("bill status does not allow this action")
+	ErrSelfApproval      = errors.New("a bill cannot be approved by the user who submitted it")
+	ErrNotApprover       = errors.New("user is not allowed to approve bills")
+	ErrNotSupplier       = errors.New("bills can only be issued by suppliers")
+	ErrInvalidDueDate    = errors.New("due date is before the bill date")
+	ErrCurrencyMismatch  = errors.New("money account currency does not match the bill currency")
+)
@@ -0,0 +1,107 @@
+package bill
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+func NewStatusChanged(b Bill) events.BillStatusChanged {
+	return events.BillStatusChanged{
+		BillID:         b.ID,
+		CounterpartyID: b.CounterpartyID,
+		Number:         b.Number,
+		Status:         string(b.Status),
+		Amount:         b.Amount,
+		Currency:       string(b.Currency),
+		SubmittedBy:    b.SubmittedBy,
+		Reason:         b.RejectionReason,
+	}
+}
+
+// Event carries who changed the bill, the session it was done in and the bill after the change.
+// Each step of the workflow publishes its own event type embedding it.
+type Event struct {
+	Sender  user.User
+	Session session.Session
+	Result  Bill
+}
+
+func newEvent(ctx context.Context, result Bill) (Event, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return Event{}, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+type SubmittedEvent struct{ Event }
+
+type ApprovedEvent struct{ Event }
+
+type RejectedEvent struct{ Event }
+
+type ScheduledEvent struct{ Event }
+
+type PaidEvent struct{ Event }
+
+type DeletedEvent struct{ Event }
+
+func NewSubmittedEvent(ctx context.Context, result Bill) (*SubmittedEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &SubmittedEvent{e}, nil
+}
+
+func NewApprovedEvent(ctx context.Context, result Bill) (*ApprovedEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &ApprovedEvent{e}, nil
+}
+
+func NewRejectedEvent(ctx context.Context, result Bill) (*RejectedEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &RejectedEvent{e}, nil
+}
+
+func NewScheduledEvent(ctx context.Context, result Bill) (*ScheduledEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &ScheduledEvent{e}, nil
+}
+
+func NewPaidEvent(ctx context.Context, result Bill) (*PaidEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &PaidEvent{e}, nil
+}
+
+func NewDeletedEvent(ctx context.Context, result Bill) (*DeletedEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &DeletedEvent{e}, nil
+}
@@ -0,0 +1,20 @@
+package bill
+
+import "context"
+
+type FindParams struct {
+	CounterpartyID uint
+	Status         Status
+	Limit          int
+	Offset         int
+	SortBy         []string
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Bill, error)
+	GetByID(ctx context.Context, id uint) (*Bill, error)
+	Create(ctx context.Context, data *Bill) error
+	Update(ctx context.Context, data *Bill) error
+	Delete(ctx context.Context, id uint) error
+}
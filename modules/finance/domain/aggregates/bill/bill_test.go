@@ -0,0 +1,78 @@
+package bill_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+)
+
+func newBill(t *testing.T) *bill.Bill {
+	t.Helper()
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	b, err := bill.New("S-42", 1, 2, currency.UsdCode, 150, date, date.AddDate(0, 0, 14), "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestBill_Workflow(t *testing.T) {
+	b := newBill(t)
+	if err := b.Schedule(3, time.Now()); !errors.Is(err, bill.ErrInvalidTransition) {
+		t.Errorf("expected %v when scheduling a submitted bill, got %v", bill.ErrInvalidTransition, err)
+	}
+	if err := b.Approve(10); !errors.Is(err, bill.ErrSelfApproval) {
+		t.Errorf("expected %v, got %v", bill.ErrSelfApproval, err)
+	}
+	if err := b.Approve(11); err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsPosted() || b.IsEditable() {
+		t.Errorf("approved bill should be posted and locked")
+	}
+	if err := b.Schedule(3, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Reject("late"); !errors.Is(err, bill.ErrInvalidTransition) {
+		t.Errorf("expected %v when rejecting a scheduled bill, got %v", bill.ErrInvalidTransition, err)
+	}
+	if err := b.MarkPaid(7, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if b.Status != bill.Paid || *b.ExpenseID != 7 {
+		t.Errorf("unexpected paid bill %+v", b)
+	}
+}
+
+func TestBill_RejectAndResubmit(t *testing.T) {
+	b := newBill(t)
+	if err := b.Approve(11); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Reject("wrong amount"); err != nil {
+		t.Fatal(err)
+	}
+	if b.ApprovedBy != nil || b.RejectionReason != "wrong amount" {
+		t.Errorf("rejection should clear the approval, got %+v", b)
+	}
+	err := b.Update(b.Number, b.CounterpartyID, b.CategoryID, b.Currency, 120, b.BillDate, b.DueDate, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Status != bill.Submitted || b.RejectionReason != "" {
+		t.Errorf("corrected bill should be submitted again, got %s", b.Status)
+	}
+}
+
+func TestBill_IsOverdue(t *testing.T) {
+	b := newBill(t)
+	if b.IsOverdue(b.DueDate) {
+		t.Errorf("bill is not overdue on its due date")
+	}
+	if !b.IsOverdue(b.DueDate.AddDate(0, 0, 1)) {
+		t.Errorf("bill should be overdue the day after its due date")
+	}
+}
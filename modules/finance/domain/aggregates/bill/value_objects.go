@@ -0,0 +1,29 @@
+package bill
+
+import "fmt"
+
+type Status string
+
+const (
+	Submitted Status = "SUBMITTED"
+	Approved  Status = "APPROVED"
+	Rejected  Status = "REJECTED"
+	Scheduled Status = "SCHEDULED"
+	Paid      Status = "PAID"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Submitted, Approved, Rejected, Scheduled, Paid:
+		return true
+	}
+	return false
+}
+
+func NewStatus(value string) (Status, error) {
+	s := Status(value)
+	if !s.IsValid() {
+		return "", fmt.Errorf("invalid bill status: %s", value)
+	}
+	return s, nil
+}
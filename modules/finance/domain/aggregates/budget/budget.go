@@ -0,0 +1,90 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+)
+
+// Budget is the amount planned to be spent on an expense category over a month, quarter or year.
+// A budget without a project limits all expenses of the category; a project budget only
+// limits the expenses charged to that project.
+type Budget struct {
+	ID          uint
+	CategoryID  uint
+	ProjectID   *uint
+	Periodicity Periodicity
+	PeriodStart time.Time
+	Amount      float64
+	Currency    currency.Code
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// New plans a budget for the period of the given periodicity that contains date.
+func New(
+	categoryID uint,
+	projectID *uint,
+	periodicity Periodicity,
+	date time.Time,
+	amount float64,
+	code currency.Code,
+) (*Budget, error) {
+	if !periodicity.IsValid() {
+		return nil, ErrInvalidPeriodicity
+	}
+	return &Budget{
+		ID:          0,
+		CategoryID:  categoryID,
+		ProjectID:   projectID,
+		Periodicity: periodicity,
+		PeriodStart: periodicity.Start(date),
+		Amount:      amount,
+		Currency:    code,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// Update replans the budget.
+func (b *Budget) Update(
+	categoryID uint,
+	projectID *uint,
+	periodicity Periodicity,
+	date time.Time,
+	amount float64,
+	code currency.Code,
+) error {
+	if !periodicity.IsValid() {
+		return ErrInvalidPeriodicity
+	}
+	b.CategoryID = categoryID
+	b.ProjectID = projectID
+	b.Periodicity = periodicity
+	b.PeriodStart = periodicity.Start(date)
+	b.Amount = amount
+	b.Currency = code
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// PeriodEnd is the first day after the budget period.
+func (b *Budget) PeriodEnd() time.Time {
+	return b.Periodicity.End(b.PeriodStart)
+}
+
+// Contains reports whether t falls within the budget period.
+func (b *Budget) Contains(t time.Time) bool {
+	return !t.Before(b.PeriodStart) && t.Before(b.PeriodEnd())
+}
+
+// SameScope reports whether both budgets limit the same category, project and period.
+func (b *Budget) SameScope(other *Budget) bool {
+	if b.CategoryID != other.CategoryID || b.Periodicity != other.Periodicity || !b.PeriodStart.Equal(other.PeriodStart) {
+		return false
+	}
+	if b.ProjectID == nil || other.ProjectID == nil {
+		return b.ProjectID == nil && other.ProjectID == nil
+	}
+	return *b.ProjectID == *other.ProjectID
+}
@@ -0,0 +1,66 @@
+package budget
+
+import (
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// SaveDTO is used both to plan a budget and to replan it. PeriodStart may be any day of the period.
+type SaveDTO struct {
+	CategoryID   uint `validate:"required"`
+	ProjectID    uint
+	Periodicity  string          `validate:"required,oneof=MONTHLY QUARTERLY YEARLY"`
+	PeriodStart  shared.DateOnly `validate:"required"`
+	Amount       float64         `validate:"required,gt=0"`
+	CurrencyCode string          `validate:"required,len=3"`
+}
+
+func (d *SaveDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *SaveDTO) projectID() *uint {
+	if d.ProjectID == 0 {
+		return nil
+	}
+	id := d.ProjectID
+	return &id
+}
+
+func (d *SaveDTO) ToEntity() (*Budget, error) {
+	code, err := currency.NewCode(d.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	periodicity, err := NewPeriodicity(d.Periodicity)
+	if err != nil {
+		return nil, err
+	}
+	return New(d.CategoryID, d.projectID(), periodicity, time.Time(d.PeriodStart), d.Amount, code)
+}
+
+// Apply replans a budget with the content of the DTO.
+func (d *SaveDTO) Apply(entity *Budget) error {
+	code, err := currency.NewCode(d.CurrencyCode)
+	if err != nil {
+		return err
+	}
+	periodicity, err := NewPeriodicity(d.Periodicity)
+	if err != nil {
+		return err
+	}
+	return entity.Update(d.CategoryID, d.projectID(), periodicity, time.Time(d.PeriodStart), d.Amount, code)
+}
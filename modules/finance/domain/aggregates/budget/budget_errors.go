@@ -0,0 +1,8 @@
+package budget
+
+import "errors"
+
+var (
+	ErrInvalidPeriodicity = errors.New("invalid budget periodicity")
+	ErrDuplicate          = errors.New("a budget for this category, project and period already exists")
+)
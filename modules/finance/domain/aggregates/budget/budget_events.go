@@ -0,0 +1,105 @@
+package budget
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type CreatedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Budget
+}
+
+type UpdatedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Budget
+}
+
+type DeletedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Budget
+}
+
+// ExceededEvent is published when an expense takes a budget over its planned amount.
+type ExceededEvent struct {
+	Sender  user.User
+	Session session.Session
+	Budget  Budget
+	Actual  float64
+	Overrun float64
+	Expense expense.Expense
+}
+
+func NewCreatedEvent(ctx context.Context, result Budget) (*CreatedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+func NewUpdatedEvent(ctx context.Context, result Budget) (*UpdatedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &UpdatedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+func NewDeletedEvent(ctx context.Context, result Budget) (*DeletedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &DeletedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+func NewExceededEvent(ctx context.Context, variance Variance, e expense.Expense) (*ExceededEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ExceededEvent{
+		Sender:  sender,
+		Session: *sess,
+		Budget:  *variance.Budget,
+		Actual:  variance.Actual,
+		Overrun: -variance.Remaining(),
+		Expense: e,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package budget
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+)
+
+type FindParams struct {
+	CategoryID  uint
+	Periodicity Periodicity
+	// Date selects the budgets whose period contains it.
+	Date   time.Time
+	Limit  int
+	Offset int
+	SortBy []string
+}
+
+// Actual is the total of the expenses of a budget recorded in one currency.
+type Actual struct {
+	Currency currency.Code
+	Amount   float64
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Budget, error)
+	GetByID(ctx context.Context, id uint) (*Budget, error)
+	GetActuals(ctx context.Context, b *Budget) ([]*Actual, error)
+	Create(ctx context.Context, data *Budget) error
+	Update(ctx context.Context, data *Budget) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,77 @@
+package budget_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/budget"
+)
+
+func TestPeriodicity_Start(t *testing.T) {
+	date := time.Date(2024, time.August, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		periodicity budget.Periodicity
+		start       time.Time
+		end         time.Time
+	}{
+		{budget.Monthly, time.Date(2024, time.August, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)},
+		{budget.Quarterly, time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{budget.Yearly, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.periodicity), func(t *testing.T) {
+			b, err := budget.New(1, nil, tt.periodicity, date, 100, currency.UsdCode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !b.PeriodStart.Equal(tt.start) || !b.PeriodEnd().Equal(tt.end) {
+				t.Errorf("expected %s - %s, got %s - %s", tt.start, tt.end, b.PeriodStart, b.PeriodEnd())
+			}
+			if !b.Contains(date) || b.Contains(tt.end) {
+				t.Errorf("unexpected period bounds")
+			}
+		})
+	}
+}
+
+func TestVariance_ExceededBy(t *testing.T) {
+	b, err := budget.New(1, nil, budget.Monthly, time.Now(), 100, currency.UsdCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		actual  float64
+		expense float64
+		want    bool
+	}{
+		{"within budget", 90, 30, false},
+		{"reaches the limit", 100, 30, false},
+		{"crosses the limit", 120, 30, true},
+		{"crosses from the limit", 110, 10, true},
+		{"already exceeded", 150, 30, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &budget.Variance{Budget: b, Actual: tt.actual}
+			if got := v.ExceededBy(tt.expense); got != tt.want {
+				t.Errorf("ExceededBy(%v) with actual %v = %v, want %v", tt.expense, tt.actual, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudget_SameScope(t *testing.T) {
+	project := uint(3)
+	date := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	a, _ := budget.New(1, nil, budget.Monthly, date, 100, currency.UsdCode)
+	b, _ := budget.New(1, nil, budget.Monthly, date.AddDate(0, 0, 5), 200, currency.UsdCode)
+	c, _ := budget.New(1, &project, budget.Monthly, date, 100, currency.UsdCode)
+	if !a.SameScope(b) {
+		t.Errorf("budgets of the same month should share a scope")
+	}
+	if a.SameScope(c) {
+		t.Errorf("a project budget should not share the scope of the category budget")
+	}
+}
@@ -0,0 +1,53 @@
+package budget
+
+import "time"
+
+// Periodicity is the length of the period a budget is planned for.
+type Periodicity string
+
+const (
+	Monthly   Periodicity = "MONTHLY"
+	Quarterly Periodicity = "QUARTERLY"
+	Yearly    Periodicity = "YEARLY"
+)
+
+func (p Periodicity) IsValid() bool {
+	switch p {
+	case Monthly, Quarterly, Yearly:
+		return true
+	}
+	return false
+}
+
+func NewPeriodicity(value string) (Periodicity, error) {
+	p := Periodicity(value)
+	if !p.IsValid() {
+		return "", ErrInvalidPeriodicity
+	}
+	return p, nil
+}
+
+// Start returns the first day of the period that contains t.
+func (p Periodicity) Start(t time.Time) time.Time {
+	switch p {
+	case Quarterly:
+		month := time.Month((int(t.Month())-1)/3*3 + 1)
+		return time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+	case Yearly:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// End returns the first day after the period that starts at start.
+func (p Periodicity) End(start time.Time) time.Time {
+	switch p {
+	case Quarterly:
+		return start.AddDate(0, 3, 0)
+	case Yearly:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
@@ -0,0 +1,33 @@
+package budget
+
+import "math"
+
+// Variance compares the planned amount of a budget with the expenses actually recorded,
+// both in the budget currency.
+type Variance struct {
+	Budget *Budget
+	Actual float64
+}
+
+// Remaining is the amount still available; it is negative once the budget is exceeded.
+func (v *Variance) Remaining() float64 {
+	return math.Round((v.Budget.Amount-v.Actual)*100) / 100
+}
+
+// Utilization is the share of the budget spent, in percent.
+func (v *Variance) Utilization() float64 {
+	if v.Budget.Amount == 0 {
+		return 0
+	}
+	return math.Round(v.Actual/v.Budget.Amount*10000) / 100
+}
+
+func (v *Variance) IsExceeded() bool {
+	return v.Remaining() < 0
+}
+
+// ExceededBy reports whether the last amount added to the actuals is the one that took
+// the budget over its limit.
+func (v *Variance) ExceededBy(amount float64) bool {
+	return v.IsExceeded() && v.Budget.Amount-(v.Actual-amount) >= 0
+}
@@ -11,6 +11,7 @@ type Expense struct {
 	Amount           float64
 	Account          moneyaccount.Account
 	Category         category.ExpenseCategory
+	ProjectID        *uint // optional project the expense is charged to
 	Comment          string
 	TransactionID    uint
 	AccountingPeriod time.Time
@@ -13,6 +13,7 @@ type CreateDTO struct {
 	Amount           float64
 	AccountID        uint
 	CategoryID       uint
+	ProjectID        uint
 	Comment          string
 	AccountingPeriod time.Time
 	Date             time.Time
@@ -22,6 +23,7 @@ type UpdateDTO struct {
 	Amount           float64
 	AccountID        uint
 	CategoryID       uint
+	ProjectID        uint
 	Comment          string
 	AccountingPeriod time.Time
 	Date             time.Time
@@ -66,6 +68,7 @@ func (d *CreateDTO) ToEntity() (*Expense, error) {
 			time.Now(),
 			time.Now(),
 		),
+		ProjectID:        projectID(d.ProjectID),
 		Comment:          d.Comment,
 		AccountingPeriod: d.AccountingPeriod,
 		Date:             d.Date,
@@ -89,6 +92,7 @@ func (d *UpdateDTO) ToEntity(id uint) (*Expense, error) {
 			time.Now(),
 			time.Now(),
 		),
+		ProjectID:        projectID(d.ProjectID),
 		Comment:          d.Comment,
 		AccountingPeriod: d.AccountingPeriod,
 		Date:             d.Date,
@@ -97,3 +101,10 @@ func (d *UpdateDTO) ToEntity(id uint) (*Expense, error) {
 		UpdatedAt:        time.Now(),
 	}, nil
 }
+
+func projectID(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
@@ -15,6 +15,8 @@ type FindParams struct {
 	Query     string
 	Field     string
 	CreatedAt DateRange
+	// AccountID keeps the expenses paid from the money account
+	AccountID uint
 }
 
 type Repository interface {
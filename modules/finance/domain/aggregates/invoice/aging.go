@@ -0,0 +1,63 @@
+package invoice
+
+import "time"
+
+// AgingRow splits the outstanding amount of a counterparty by how long it is past due.
+type AgingRow struct {
+	CounterpartyID uint
+	Currency       string
+	Current        float64
+	Days1To30      float64
+	Days31To60     float64
+	Days61To90     float64
+	Over90         float64
+}
+
+func (r *AgingRow) Total() float64 {
+	return roundCents(r.Current + r.Days1To30 + r.Days31To60 + r.Days61To90 + r.Over90)
+}
+
+func (r *AgingRow) add(daysPastDue int, amount float64) {
+	switch {
+	case daysPastDue <= 0:
+		r.Current = roundCents(r.Current + amount)
+	case daysPastDue <= 30:
+		r.Days1To30 = roundCents(r.Days1To30 + amount)
+	case daysPastDue <= 60:
+		r.Days31To60 = roundCents(r.Days31To60 + amount)
+	case daysPastDue <= 90:
+		r.Days61To90 = roundCents(r.Days61To90 + amount)
+	default:
+		r.Over90 = roundCents(r.Over90 + amount)
+	}
+}
+
+// Aging builds the receivables aging at the given date, one row per counterparty and currency,
+// in the order counterparties first appear among the invoices. Drafts and paid invoices are skipped.
+func Aging(invoices []*Invoice, at time.Time) []*AgingRow {
+	type key struct {
+		counterpartyID uint
+		currency       string
+	}
+	day := startOfDay(at)
+	rows := map[key]*AgingRow{}
+	var result []*AgingRow
+	for _, inv := range invoices {
+		if inv.Status != Sent && inv.Status != PartiallyPaid {
+			continue
+		}
+		outstanding := inv.Outstanding()
+		if outstanding <= 0 {
+			continue
+		}
+		k := key{inv.CounterpartyID, string(inv.Currency)}
+		row, ok := rows[k]
+		if !ok {
+			row = &AgingRow{CounterpartyID: inv.CounterpartyID, Currency: string(inv.Currency)}
+			rows[k] = row
+			result = append(result, row)
+		}
+		row.add(int(day.Sub(startOfDay(inv.DueDate)).Hours()/24), outstanding)
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package invoice
+
+import "time"
+
+// Allocation assigns a part of an incoming payment to an invoice.
+// A payment may be split between several invoices.
+type Allocation struct {
+	ID        uint
+	InvoiceID uint
+	PaymentID uint
+	Amount    float64
+	Date      time.Time
+	CreatedAt time.Time
+}
+
+// PaymentBalance is an incoming payment of a counterparty together with the part of it already allocated.
+type PaymentBalance struct {
+	PaymentID      uint
+	CounterpartyID uint
+	Date           time.Time
+	Amount         float64
+	CurrencyCode   string
+	Allocated      float64
+}
+
+func (p *PaymentBalance) Unallocated() float64 {
+	return roundCents(p.Amount - p.Allocated)
+}
+
+// Allocate checks an allocation of amount from the payment to the invoice and applies it to the invoice.
+func (i *Invoice) Allocate(payment *PaymentBalance, amount float64) (*Allocation, error) {
+	if i.IsDraft() {
+		return nil, ErrNotSent
+	}
+	if payment.CounterpartyID != i.CounterpartyID {
+		return nil, ErrCounterpartyMismatch
+	}
+	if payment.CurrencyCode != string(i.Currency) {
+		return nil, ErrCurrencyMismatch
+	}
+	amount = roundCents(amount)
+	if amount <= 0 || amount > i.Outstanding() {
+		return nil, ErrOverallocated
+	}
+	if amount > payment.Unallocated() {
+		return nil, ErrPaymentExhausted
+	}
+	if err := i.SetPaidAmount(i.PaidAmount + amount); err != nil {
+		return nil, err
+	}
+	return &Allocation{
+		InvoiceID: i.ID,
+		PaymentID: payment.PaymentID,
+		Amount:    amount,
+		Date:      time.Now(),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Deallocate reverses an allocation of the invoice.
+func (i *Invoice) Deallocate(a *Allocation) error {
+	return i.SetPaidAmount(i.PaidAmount - a.Amount)
+}
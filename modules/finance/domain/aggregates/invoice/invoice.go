@@ -0,0 +1,180 @@
+package invoice
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+)
+
+// Line is a billed item. TaxRate is a percentage, e.g. 12 for 12% VAT.
+type Line struct {
+	ID          uint
+	Description string
+	Quantity    float64
+	UnitPrice   float64
+	TaxRate     float64
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func (l *Line) Net() float64 {
+	return roundCents(l.Quantity * l.UnitPrice)
+}
+
+func (l *Line) Tax() float64 {
+	return roundCents(l.Net() * l.TaxRate / 100)
+}
+
+func (l *Line) Total() float64 {
+	return l.Net() + l.Tax()
+}
+
+// Invoice is a bill issued to a counterparty. PaidAmount is the sum of the payments allocated to it.
+type Invoice struct {
+	ID             uint
+	Number         string
+	CounterpartyID uint
+	Currency       currency.Code
+	IssueDate      time.Time
+	DueDate        time.Time
+	Status         Status
+	Lines          []*Line
+	PaidAmount     float64
+	Comment        string
+	SentAt         *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func New(
+	counterpartyID uint, code currency.Code, issueDate, dueDate time.Time, comment string, lines []*Line,
+) (*Invoice, error) {
+	if dueDate.Before(issueDate) {
+		return nil, ErrInvalidDueDate
+	}
+	return &Invoice{
+		ID:             0,
+		CounterpartyID: counterpartyID,
+		Currency:       code,
+		IssueDate:      issueDate,
+		DueDate:        dueDate,
+		Status:         Draft,
+		Lines:          lines,
+		Comment:        comment,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}, nil
+}
+
+// NumberFor formats the number given to an invoice once it is stored.
+func NumberFor(id uint) string {
+	return fmt.Sprintf("INV-%06d", id)
+}
+
+func (i *Invoice) Net() float64 {
+	var total float64
+	for _, l := range i.Lines {
+		total += l.Net()
+	}
+	return roundCents(total)
+}
+
+func (i *Invoice) Tax() float64 {
+	var total float64
+	for _, l := range i.Lines {
+		total += l.Tax()
+	}
+	return roundCents(total)
+}
+
+func (i *Invoice) Total() float64 {
+	return roundCents(i.Net() + i.Tax())
+}
+
+func (i *Invoice) Outstanding() float64 {
+	return roundCents(i.Total() - i.PaidAmount)
+}
+
+func (i *Invoice) IsDraft() bool {
+	return i.Status == Draft
+}
+
+// IsOverdue reports whether an unpaid invoice is past its due date at the given moment.
+func (i *Invoice) IsOverdue(at time.Time) bool {
+	if i.Status != Sent && i.Status != PartiallyPaid {
+		return false
+	}
+	return startOfDay(i.DueDate).Before(startOfDay(at))
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StatusAt is the status shown to users, which is Overdue for unpaid invoices past their due date.
+func (i *Invoice) StatusAt(at time.Time) Status {
+	if i.IsOverdue(at) {
+		return Overdue
+	}
+	return i.Status
+}
+
+// Update replaces the content of a draft invoice.
+func (i *Invoice) Update(
+	counterpartyID uint, code currency.Code, issueDate, dueDate time.Time, comment string, lines []*Line,
+) error {
+	if !i.IsDraft() {
+		return ErrNotDraft
+	}
+	if dueDate.Before(issueDate) {
+		return ErrInvalidDueDate
+	}
+	i.CounterpartyID = counterpartyID
+	i.Currency = code
+	i.IssueDate = issueDate
+	i.DueDate = dueDate
+	i.Comment = comment
+	i.Lines = lines
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// Send issues a draft invoice to the counterparty, after which it can no longer be changed.
+func (i *Invoice) Send() error {
+	if !i.IsDraft() {
+		return ErrNotDraft
+	}
+	if len(i.Lines) == 0 || i.Total() <= 0 {
+		return ErrNoLines
+	}
+	now := time.Now()
+	i.Status = Sent
+	i.SentAt = &now
+	i.UpdatedAt = now
+	return nil
+}
+
+// SetPaidAmount records the allocated payments and moves the invoice between sent, partially paid and paid.
+func (i *Invoice) SetPaidAmount(amount float64) error {
+	if i.IsDraft() {
+		return ErrNotSent
+	}
+	if roundCents(amount) > i.Total() {
+		return ErrOverallocated
+	}
+	i.PaidAmount = roundCents(amount)
+	switch {
+	case i.PaidAmount >= i.Total():
+		i.Status = Paid
+	case i.PaidAmount > 0:
+		i.Status = PartiallyPaid
+	default:
+		i.Status = Sent
+	}
+	i.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,101 @@
+package invoice
+
+import (
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type LineDTO struct {
+	Description string  `validate:"required"`
+	Quantity    float64 `validate:"gt=0"`
+	UnitPrice   float64 `validate:"gte=0"`
+	TaxRate     float64 `validate:"gte=0,lte=100"`
+}
+
+// SaveDTO is used both to create an invoice and to update a draft.
+type SaveDTO struct {
+	CounterpartyID uint            `validate:"required"`
+	CurrencyCode   string          `validate:"required,len=3"`
+	IssueDate      shared.DateOnly `validate:"required"`
+	DueDate        shared.DateOnly `validate:"required"`
+	Comment        string
+	Lines          []LineDTO `validate:"required,min=1,dive"`
+}
+
+type AllocateDTO struct {
+	PaymentID uint    `validate:"required"`
+	Amount    float64 `validate:"required,gt=0"`
+}
+
+func translateErrors(l ut.Translator, errs error) (map[string]string, bool) {
+	errors := map[string]string{}
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *SaveDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors, ok := translateErrors(l, constants.Validate.Struct(d))
+	if time.Time(d.DueDate).Before(time.Time(d.IssueDate)) {
+		errors["DueDate"] = ErrInvalidDueDate.Error()
+		ok = false
+	}
+	return errors, ok
+}
+
+func (d *SaveDTO) entityLines() []*Line {
+	lines := make([]*Line, 0, len(d.Lines))
+	for _, l := range d.Lines {
+		lines = append(lines, &Line{
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			TaxRate:     l.TaxRate,
+		})
+	}
+	return lines
+}
+
+func (d *SaveDTO) ToEntity() (*Invoice, error) {
+	code, err := currency.NewCode(d.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	return New(
+		d.CounterpartyID,
+		code,
+		time.Time(d.IssueDate),
+		time.Time(d.DueDate),
+		d.Comment,
+		d.entityLines(),
+	)
+}
+
+// Apply updates a draft invoice with the content of the DTO.
+func (d *SaveDTO) Apply(entity *Invoice) error {
+	code, err := currency.NewCode(d.CurrencyCode)
+	if err != nil {
+		return err
+	}
+	return entity.Update(
+		d.CounterpartyID,
+		code,
+		time.Time(d.IssueDate),
+		time.Time(d.DueDate),
+		d.Comment,
+		d.entityLines(),
+	)
+}
+
+func (d *AllocateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	return translateErrors(l, constants.Validate.Struct(d))
+}
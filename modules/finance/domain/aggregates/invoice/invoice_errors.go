@@ -0,0 +1,14 @@
+package invoice
+
+import "errors"
+
+var (
+	ErrNotDraft             = errors.New("only draft invoices can be changed")
+	ErrNoLines              = errors.New("invoice has no lines")
+	ErrInvalidDueDate       = errors.New("due date is before the issue date")
+	ErrNotSent              = errors.New("invoice is not sent")
+	ErrOverallocated        = errors.New("allocation exceeds the outstanding amount of the invoice")
+	ErrPaymentExhausted     = errors.New("allocation exceeds the unallocated amount of the payment")
+	ErrCurrencyMismatch     = errors.New("payment and invoice currencies differ")
+	ErrCounterpartyMismatch = errors.New("payment and invoice counterparties differ")
+)
@@ -0,0 +1,78 @@
+package invoice
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func NewCreatedEvent(ctx context.Context, result Invoice) (*CreatedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+func NewSentEvent(ctx context.Context, result Invoice) (*SentEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &SentEvent{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+func NewPaymentAllocatedEvent(ctx context.Context, invoice Invoice, allocation Allocation) (*PaymentAllocatedEvent, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentAllocatedEvent{
+		Sender:     sender,
+		Session:    *sess,
+		Invoice:    invoice,
+		Allocation: allocation,
+	}, nil
+}
+
+type CreatedEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Invoice
+}
+
+type SentEvent struct {
+	Sender  user.User
+	Session session.Session
+	Result  Invoice
+}
+
+// PaymentAllocatedEvent is published when a payment is allocated to an invoice.
+type PaymentAllocatedEvent struct {
+	Sender     user.User
+	Session    session.Session
+	Invoice    Invoice
+	Allocation Allocation
+}
@@ -0,0 +1,40 @@
+package invoice
+
+import (
+	"context"
+)
+
+type FindParams struct {
+	CounterpartyID uint
+	Status         Status
+	Limit          int
+	Offset         int
+	SortBy         []string
+}
+
+type AllocationFindParams struct {
+	InvoiceID uint
+	PaymentID uint
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	// GetPaginated returns invoices with their lines. Filtering by Overdue returns unpaid invoices past due today.
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Invoice, error)
+	GetByID(ctx context.Context, id uint) (*Invoice, error)
+	// GetOutstanding returns every sent or partially paid invoice.
+	GetOutstanding(ctx context.Context) ([]*Invoice, error)
+	// Create stores the invoice with its lines and assigns its number.
+	Create(ctx context.Context, data *Invoice) error
+	// Update saves the invoice and replaces its lines.
+	Update(ctx context.Context, data *Invoice) error
+	Delete(ctx context.Context, id uint) error
+
+	GetAllocations(ctx context.Context, params *AllocationFindParams) ([]*Allocation, error)
+	GetAllocationByID(ctx context.Context, id uint) (*Allocation, error)
+	CreateAllocation(ctx context.Context, data *Allocation) error
+	DeleteAllocation(ctx context.Context, id uint) error
+	// GetPaymentBalances returns the payments of a counterparty with the amounts allocated from them.
+	GetPaymentBalances(ctx context.Context, counterpartyID uint) ([]*PaymentBalance, error)
+	GetPaymentBalance(ctx context.Context, paymentID uint) (*PaymentBalance, error)
+}
@@ -0,0 +1,124 @@
+package invoice_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newInvoice(t *testing.T, counterpartyID uint, due time.Time, lines ...*invoice.Line) *invoice.Invoice {
+	t.Helper()
+	inv, err := invoice.New(counterpartyID, currency.UsdCode, date(time.January, 1), due, "", lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inv
+}
+
+func TestInvoice_Totals(t *testing.T) {
+	inv := newInvoice(t, 1, date(time.January, 31),
+		&invoice.Line{Description: "Consulting", Quantity: 3, UnitPrice: 100, TaxRate: 12},
+		&invoice.Line{Description: "Hosting", Quantity: 1, UnitPrice: 49.99, TaxRate: 0},
+	)
+	if inv.Net() != 349.99 {
+		t.Errorf("expected net 349.99, got %.2f", inv.Net())
+	}
+	if inv.Tax() != 36 {
+		t.Errorf("expected tax 36, got %.2f", inv.Tax())
+	}
+	if inv.Total() != 385.99 {
+		t.Errorf("expected total 385.99, got %.2f", inv.Total())
+	}
+}
+
+func TestInvoice_Lifecycle(t *testing.T) {
+	inv := newInvoice(t, 1, date(time.January, 31),
+		&invoice.Line{Description: "Consulting", Quantity: 1, UnitPrice: 100, TaxRate: 12},
+	)
+	payment := &invoice.PaymentBalance{PaymentID: 5, CounterpartyID: 1, Amount: 200, CurrencyCode: string(currency.UsdCode)}
+	if _, err := inv.Allocate(payment, 10); !errors.Is(err, invoice.ErrNotSent) {
+		t.Errorf("expected ErrNotSent, got %v", err)
+	}
+	if err := inv.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.Update(1, currency.UsdCode, inv.IssueDate, inv.DueDate, "", nil); !errors.Is(err, invoice.ErrNotDraft) {
+		t.Errorf("expected ErrNotDraft, got %v", err)
+	}
+
+	foreign := &invoice.PaymentBalance{PaymentID: 6, CounterpartyID: 2, Amount: 200, CurrencyCode: string(currency.UsdCode)}
+	if _, err := inv.Allocate(foreign, 10); !errors.Is(err, invoice.ErrCounterpartyMismatch) {
+		t.Errorf("expected ErrCounterpartyMismatch, got %v", err)
+	}
+
+	allocation, err := inv.Allocate(payment, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocation.Amount != 50 || inv.Status != invoice.PartiallyPaid || inv.Outstanding() != 62 {
+		t.Errorf("unexpected state after partial allocation: %s, outstanding %.2f", inv.Status, inv.Outstanding())
+	}
+	if _, err := inv.Allocate(payment, 100); !errors.Is(err, invoice.ErrOverallocated) {
+		t.Errorf("expected ErrOverallocated, got %v", err)
+	}
+	payment.Allocated = 150
+	if _, err := inv.Allocate(payment, 62); !errors.Is(err, invoice.ErrPaymentExhausted) {
+		t.Errorf("expected ErrPaymentExhausted, got %v", err)
+	}
+	payment.Allocated = 50
+	if _, err := inv.Allocate(payment, 62); err != nil {
+		t.Fatal(err)
+	}
+	if inv.Status != invoice.Paid || inv.StatusAt(date(time.March, 1)) != invoice.Paid {
+		t.Errorf("expected paid invoice, got %s", inv.Status)
+	}
+	if err := inv.Deallocate(allocation); err != nil {
+		t.Fatal(err)
+	}
+	if inv.Status != invoice.PartiallyPaid || inv.StatusAt(date(time.March, 1)) != invoice.Overdue {
+		t.Errorf("expected overdue partially paid invoice, got %s", inv.StatusAt(date(time.March, 1)))
+	}
+}
+
+func TestAging(t *testing.T) {
+	at := date(time.April, 30)
+	line := func(amount float64) *invoice.Line {
+		return &invoice.Line{Description: "Item", Quantity: 1, UnitPrice: amount}
+	}
+	sent := func(counterpartyID uint, due time.Time, amount float64) *invoice.Invoice {
+		inv := newInvoice(t, counterpartyID, due, line(amount))
+		if err := inv.Send(); err != nil {
+			t.Fatal(err)
+		}
+		return inv
+	}
+	partial := sent(1, date(time.March, 20), 300)
+	if err := partial.SetPaidAmount(100); err != nil {
+		t.Fatal(err)
+	}
+	invoices := []*invoice.Invoice{
+		sent(1, date(time.May, 15), 100),
+		sent(1, date(time.April, 20), 50),
+		partial,
+		sent(2, date(time.January, 15), 70),
+		newInvoice(t, 2, date(time.April, 1), line(1000)),
+	}
+	rows := invoice.Aging(invoices, at)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	first := rows[0]
+	if first.Current != 100 || first.Days1To30 != 50 || first.Days31To60 != 200 || first.Total() != 350 {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	if rows[1].Over90 != 70 || rows[1].Total() != 70 {
+		t.Errorf("unexpected second row: %+v", rows[1])
+	}
+}
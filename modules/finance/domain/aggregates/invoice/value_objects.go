@@ -0,0 +1,30 @@
+package invoice
+
+import "fmt"
+
+type Status string
+
+const (
+	Draft         Status = "DRAFT"
+	Sent          Status = "SENT"
+	PartiallyPaid Status = "PARTIALLY_PAID"
+	Paid          Status = "PAID"
+	// Overdue is never stored; it is reported for unpaid invoices past their due date.
+	Overdue Status = "OVERDUE"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Draft, Sent, PartiallyPaid, Paid, Overdue:
+		return true
+	}
+	return false
+}
+
+func NewStatus(value string) (Status, error) {
+	s := Status(value)
+	if !s.IsValid() {
+		return "", fmt.Errorf("invalid invoice status: %s", value)
+	}
+	return s, nil
+}
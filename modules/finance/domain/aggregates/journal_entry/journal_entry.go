@@ -0,0 +1,150 @@
+package journalentry
+
+import (
+	"math"
+	"time"
+)
+
+type Line struct {
+	ID        uint
+	AccountID uint
+	Debit     float64
+	Credit    float64
+	Comment   string
+}
+
+func DebitLine(accountID uint, amount float64) Line {
+	return Line{AccountID: accountID, Debit: amount}
+}
+
+func CreditLine(accountID uint, amount float64) Line {
+	return Line{AccountID: accountID, Credit: amount}
+}
+
+// Entry is a balanced set of debit and credit lines posted on a single date.
+type Entry struct {
+	ID               uint
+	Date             time.Time
+	AccountingPeriod time.Time
+	Description      string
+	SourceType       SourceType
+	SourceID         uint
+	Lines            []Line
+	CreatedAt        time.Time
+}
+
+func New(
+	date, accountingPeriod time.Time,
+	description string,
+	sourceType SourceType,
+	sourceID uint,
+	lines ...Line,
+) (*Entry, error) {
+	entry := &Entry{
+		ID:               0,
+		Date:             date,
+		AccountingPeriod: accountingPeriod,
+		Description:      description,
+		SourceType:       sourceType,
+		SourceID:         sourceID,
+		Lines:            lines,
+		CreatedAt:        time.Now(),
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (e *Entry) TotalDebit() float64 {
+	var total float64
+	for _, l := range e.Lines {
+		total += l.Debit
+	}
+	return total
+}
+
+func (e *Entry) TotalCredit() float64 {
+	var total float64
+	for _, l := range e.Lines {
+		total += l.Credit
+	}
+	return total
+}
+
+// Validate checks that every line is one-sided and that debits equal credits to the cent.
+func (e *Entry) Validate() error {
+	if len(e.Lines) < 2 {
+		return ErrNoLines
+	}
+	for _, l := range e.Lines {
+		if l.AccountID == 0 {
+			return ErrNoAccount
+		}
+		if l.Debit < 0 || l.Credit < 0 || (l.Debit == 0) == (l.Credit == 0) {
+			return ErrInvalidLine
+		}
+	}
+	if toCents(e.TotalDebit()) != toCents(e.TotalCredit()) {
+		return ErrUnbalanced
+	}
+	return nil
+}
+
+// Reversal returns an entry with debits and credits swapped, cancelling e.
+func (e *Entry) Reversal(date time.Time, description string) *Entry {
+	lines := make([]Line, len(e.Lines))
+	for i, l := range e.Lines {
+		lines[i] = Line{AccountID: l.AccountID, Debit: l.Credit, Credit: l.Debit, Comment: l.Comment}
+	}
+	return &Entry{
+		Date:             date,
+		AccountingPeriod: date,
+		Description:      description,
+		SourceType:       SourceManual,
+		Lines:            lines,
+		CreatedAt:        time.Now(),
+	}
+}
+
+// Convert restates every line in another currency, e.g. the base currency of the ledger.
+// Lines are rounded to the cent and the rounding remainder is put on the largest line of the lighter side,
+// so the converted entry stays balanced. The entry is validated again afterwards.
+func (e *Entry) Convert(rate float64) error {
+	var debit, credit int64
+	for i := range e.Lines {
+		e.Lines[i].Debit = roundCents(e.Lines[i].Debit * rate)
+		e.Lines[i].Credit = roundCents(e.Lines[i].Credit * rate)
+		debit += toCents(e.Lines[i].Debit)
+		credit += toCents(e.Lines[i].Credit)
+	}
+	if remainder := debit - credit; remainder != 0 {
+		largest := -1
+		for i, l := range e.Lines {
+			amount := l.Debit
+			if remainder > 0 {
+				amount = l.Credit
+			}
+			if amount > 0 && (largest == -1 || amount > e.Lines[largest].Debit+e.Lines[largest].Credit) {
+				largest = i
+			}
+		}
+		if largest == -1 {
+			return ErrUnbalanced
+		}
+		if remainder > 0 {
+			e.Lines[largest].Credit = roundCents(e.Lines[largest].Credit + float64(remainder)/100)
+		} else {
+			e.Lines[largest].Debit = roundCents(e.Lines[largest].Debit - float64(remainder)/100)
+		}
+	}
+	return e.Validate()
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func toCents(v float64) int64 {
+	return int64(math.Round(v * 100))
+}
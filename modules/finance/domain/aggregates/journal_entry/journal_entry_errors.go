@@ -0,0 +1,11 @@
+package journalentry
+
+import "errors"
+
+var (
+	ErrNoLines      = errors.New("journal entry must have at least two lines")
+	ErrInvalidLine  = errors.New("journal line must have either a positive debit or a positive credit")
+	ErrUnbalanced   = errors.New("journal entry debits and credits are not balanced")
+	ErrNoAccount    = errors.New("journal line has no account")
+	ErrInvalidEntry = errors.New("transaction can not be posted to the ledger")
+)
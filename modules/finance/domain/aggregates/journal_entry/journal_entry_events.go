@@ -0,0 +1,6 @@
+package journalentry
+
+// Posted is published after an entry has been written to the ledger.
+type Posted struct {
+	Entry *Entry
+}
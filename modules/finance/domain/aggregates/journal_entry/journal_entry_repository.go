@@ -0,0 +1,37 @@
+package journalentry
+
+import (
+	"context"
+	"time"
+)
+
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+type FindParams struct {
+	Limit     int
+	Offset    int
+	SortBy    []string
+	AccountID uint
+	Date      DateRange
+}
+
+// AccountTurnover is the sum of debits and credits posted to an account.
+type AccountTurnover struct {
+	AccountID uint
+	Debit     float64
+	Credit    float64
+}
+
+type Repository interface {
+	Count(ctx context.Context) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Entry, error)
+	GetByID(ctx context.Context, id uint) (*Entry, error)
+	GetBySource(ctx context.Context, sourceType SourceType, sourceID uint) (*Entry, error)
+	Turnovers(ctx context.Context, from, to time.Time) ([]*AccountTurnover, error)
+	Create(ctx context.Context, data *Entry) error
+	Delete(ctx context.Context, id uint) error
+	DeleteBySource(ctx context.Context, sourceType SourceType, sourceID uint) error
+}
@@ -0,0 +1,265 @@
+package journalentry_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
+)
+
+func TestEntry_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []journalentry.Line
+		err   error
+	}{
+		{
+			name: "balanced",
+			lines: []journalentry.Line{
+				journalentry.DebitLine(1, 100.10),
+				journalentry.CreditLine(2, 60.05),
+				journalentry.CreditLine(3, 40.05),
+			},
+		},
+		{
+			name:  "single line",
+			lines: []journalentry.Line{journalentry.DebitLine(1, 100)},
+			err:   journalentry.ErrNoLines,
+		},
+		{
+			name: "unbalanced",
+			lines: []journalentry.Line{
+				journalentry.DebitLine(1, 100),
+				journalentry.CreditLine(2, 99.99),
+			},
+			err: journalentry.ErrUnbalanced,
+		},
+		{
+			name: "two-sided line",
+			lines: []journalentry.Line{
+				{AccountID: 1, Debit: 100, Credit: 100},
+				journalentry.CreditLine(2, 0),
+			},
+			err: journalentry.ErrInvalidLine,
+		},
+		{
+			name: "missing account",
+			lines: []journalentry.Line{
+				journalentry.DebitLine(0, 100),
+				journalentry.CreditLine(2, 100),
+			},
+			err: journalentry.ErrNoAccount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := journalentry.New(time.Now(), time.Now(), "", journalentry.SourceManual, 0, tt.lines...)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestFromTransaction(t *testing.T) {
+	tr := transaction.NewTransfer(250, 1, 2, time.Now(), time.Now(), "")
+	entry, err := journalentry.FromTransaction(tr, 10, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Lines[0].AccountID != 20 || entry.Lines[0].Debit != 250 {
+		t.Errorf("expected destination account to be debited, got %+v", entry.Lines[0])
+	}
+	if entry.Lines[1].AccountID != 10 || entry.Lines[1].Credit != 250 {
+		t.Errorf("expected origin account to be credited, got %+v", entry.Lines[1])
+	}
+	if _, err := journalentry.FromTransaction(tr, 10, 10); !errors.Is(err, journalentry.ErrInvalidEntry) {
+		t.Errorf("expected %v, got %v", journalentry.ErrInvalidEntry, err)
+	}
+}
+
+func TestEntry_Reversal(t *testing.T) {
+	entry, err := journalentry.New(
+		time.Now(), time.Now(), "", journalentry.SourceManual, 0,
+		journalentry.DebitLine(1, 10),
+		journalentry.CreditLine(2, 10),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reversal := entry.Reversal(time.Now(), "reversal")
+	if err := reversal.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if reversal.Lines[0].Credit != 10 || reversal.Lines[1].Debit != 10 {
+		t.Errorf("expected sides to be swapped, got %+v", reversal.Lines)
+	}
+}
+
+func TestEntry_Convert(t *testing.T) {
+	entry, err := journalentry.New(
+		time.Now(), time.Now(), "", journalentry.SourceManual, 0,
+		journalentry.DebitLine(1, 3),
+		journalentry.CreditLine(2, 1),
+		journalentry.CreditLine(3, 1),
+		journalentry.CreditLine(4, 1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3 * 0.3333 rounds to 1.00 while each credit rounds to 0.33: the missing cent goes to a credit line.
+	if err := entry.Convert(0.3333); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Lines[0].Debit != 1 || entry.Lines[1].Credit != 0.34 || entry.Lines[2].Credit != 0.33 {
+		t.Errorf("unexpected converted lines %+v", entry.Lines)
+	}
+
+	tiny, err := journalentry.New(
+		time.Now(), time.Now(), "", journalentry.SourceManual, 0,
+		journalentry.DebitLine(1, 0.01),
+		journalentry.CreditLine(2, 0.01),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tiny.Convert(0.001); !errors.Is(err, journalentry.ErrInvalidLine) {
+		t.Errorf("expected %v, got %v", journalentry.ErrInvalidLine, err)
+	}
+}
+
+func TestFromExchange(t *testing.T) {
+	// 1000 EUR sold for 1080 USD while the ledger rate is 1.10 USD per EUR: 20 USD loss.
+	tr := transaction.NewTransfer(1000, 1, 2, time.Now(), time.Now(), "").WithExchange(1080, 1.08)
+	entry, err := journalentry.FromExchange(tr, 10, 20, 70, 1.10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(entry.Lines))
+	}
+	if entry.Lines[0].Debit != 1080 || entry.Lines[1].Credit != 1100 {
+		t.Errorf("unexpected transfer lines %+v", entry.Lines[:2])
+	}
+	if entry.Lines[2].AccountID != 70 || entry.Lines[2].Debit != 20 {
+		t.Errorf("expected exchange loss to be debited, got %+v", entry.Lines[2])
+	}
+}
+
+func TestFromRevaluation(t *testing.T) {
+	date := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	entry, err := journalentry.FromRevaluation(date, 70, map[uint]float64{10: 15.5, 20: -5.25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.SourceID != 202403 {
+		t.Errorf("expected source id 202403, got %d", entry.SourceID)
+	}
+	last := entry.Lines[len(entry.Lines)-1]
+	if last.AccountID != 70 || last.Credit != 10.25 {
+		t.Errorf("expected unrealized gain of 10.25, got %+v", last)
+	}
+	if _, err := journalentry.FromRevaluation(date, 70, nil); !errors.Is(err, journalentry.ErrNoLines) {
+		t.Errorf("expected %v, got %v", journalentry.ErrNoLines, err)
+	}
+}
+
+func TestFromInvoice(t *testing.T) {
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	inv, err := invoice.New(1, currency.UsdCode, date, date.AddDate(0, 0, 30), "", []*invoice.Line{
+		{Description: "Consulting", Quantity: 3, UnitPrice: 33.33, TaxRate: 12},
+		{Description: "Exempt", Quantity: 1, UnitPrice: 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := journalentry.FromInvoice(inv, 12, 40, 22, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(entry.Lines))
+	}
+	if entry.Lines[0].Debit != 121.99 || entry.Lines[1].Credit != 109.99 || entry.Lines[2].Credit != 12 {
+		t.Errorf("unexpected invoice lines %+v", entry.Lines)
+	}
+}
+
+func TestFromAllocation(t *testing.T) {
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	inv, err := invoice.New(1, currency.EurCode, date, date.AddDate(0, 0, 30), "", []*invoice.Line{
+		{Description: "Consulting", Quantity: 1, UnitPrice: 100},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The receivable was posted at 1.10 USD per EUR and is settled at 1.08: 2 USD loss.
+	a := &invoice.Allocation{ID: 3, InvoiceID: inv.ID, PaymentID: 5, Amount: 100, Date: date.AddDate(0, 0, 20)}
+	entry, err := journalentry.FromAllocation(a, inv, 12, 40, 70, 1.10, 1.08)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entry.Lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(entry.Lines))
+	}
+	if entry.Lines[0].Debit != 108 || entry.Lines[1].Credit != 110 {
+		t.Errorf("unexpected allocation lines %+v", entry.Lines[:2])
+	}
+	if entry.Lines[2].AccountID != 70 || entry.Lines[2].Debit != 2 {
+		t.Errorf("expected exchange loss to be debited, got %+v", entry.Lines[2])
+	}
+}
+
+func TestFromBillPayment(t *testing.T) {
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	b, err := bill.New("S-1", 1, 2, currency.UsdCode, 80, date, date.AddDate(0, 0, 10), "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Approve(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Schedule(3, date); err != nil {
+		t.Fatal(err)
+	}
+	paidAt := date.AddDate(0, 0, 5)
+	if err := b.MarkPaid(4, paidAt); err != nil {
+		t.Fatal(err)
+	}
+	approval, err := journalentry.FromBill(b, 50, 21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settlement, err := journalentry.FromBillPayment(b, 21, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !settlement.Date.Equal(paidAt) || settlement.SourceType != journalentry.SourceBillPayment {
+		t.Errorf("unexpected settlement entry %+v", settlement)
+	}
+	if approval.Lines[1].Credit != settlement.Lines[0].Debit {
+		t.Errorf("settlement should clear the payable, got %+v and %+v", approval.Lines, settlement.Lines)
+	}
+}
+
+func TestFromInventoryWriteOff(t *testing.T) {
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	entry, err := journalentry.FromInventoryWriteOff(date, "Inventory check #7", 7, 150, 60, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.SourceType != journalentry.SourceInventoryWriteOff || entry.SourceID != 7 {
+		t.Errorf("unexpected source %s #%d", entry.SourceType, entry.SourceID)
+	}
+	if entry.Lines[0].AccountID != 60 || entry.Lines[0].Debit != 150 || entry.Lines[1].AccountID != 13 || entry.Lines[1].Credit != 150 {
+		t.Errorf("unexpected write-off lines %+v", entry.Lines)
+	}
+	if _, err := journalentry.FromInventoryWriteOff(date, "", 7, 0, 60, 13); err == nil {
+		t.Error("expected an empty write-off to be rejected")
+	}
+}
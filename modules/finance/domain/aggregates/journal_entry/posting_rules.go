@@ -0,0 +1,233 @@
+package journalentry
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
+)
+
+// FromPayment debits the money account the payment was received on and credits the income account.
+func FromPayment(p payment.Payment, cashAccountID, incomeAccountID uint) (*Entry, error) {
+	return New(
+		p.TransactionDate(),
+		p.AccountingPeriod(),
+		p.Comment(),
+		SourcePayment,
+		p.ID(),
+		DebitLine(cashAccountID, p.Amount()),
+		CreditLine(incomeAccountID, p.Amount()),
+	)
+}
+
+// FromExpense debits the expense category account and credits the money account the expense was paid from.
+func FromExpense(e *expense.Expense, expenseAccountID, cashAccountID uint) (*Entry, error) {
+	return New(
+		e.Date,
+		e.AccountingPeriod,
+		e.Comment,
+		SourceExpense,
+		e.ID,
+		DebitLine(expenseAccountID, e.Amount),
+		CreditLine(cashAccountID, e.Amount),
+	)
+}
+
+// FromTransaction moves the transaction amount from the origin account to the destination account.
+// It is used for transfers between money accounts and for opening balances.
+func FromTransaction(t *transaction.Transaction, originAccountID, destinationAccountID uint) (*Entry, error) {
+	if originAccountID == destinationAccountID {
+		return nil, ErrInvalidEntry
+	}
+	amount := math.Abs(t.Amount)
+	return New(
+		t.TransactionDate,
+		t.AccountingPeriod,
+		t.Comment,
+		SourceTransaction,
+		t.ID,
+		DebitLine(destinationAccountID, amount),
+		CreditLine(originAccountID, amount),
+	)
+}
+
+// FromExchange posts a transfer between money accounts held in different currencies.
+// Both sides are restated in the base currency with their own rates and the difference
+// between them is booked on the exchange differences account.
+func FromExchange(
+	t *transaction.Transaction,
+	originAccountID, destinationAccountID, exchangeAccountID uint,
+	originRate, destinationRate float64,
+) (*Entry, error) {
+	if originAccountID == destinationAccountID {
+		return nil, ErrInvalidEntry
+	}
+	credit := roundCents(math.Abs(t.Amount) * originRate)
+	debit := roundCents(math.Abs(t.ReceivedAmount()) * destinationRate)
+	lines := []Line{
+		DebitLine(destinationAccountID, debit),
+		CreditLine(originAccountID, credit),
+	}
+	switch diff := roundCents(debit - credit); {
+	case diff > 0:
+		lines = append(lines, CreditLine(exchangeAccountID, diff))
+	case diff < 0:
+		lines = append(lines, DebitLine(exchangeAccountID, -diff))
+	}
+	return New(
+		t.TransactionDate,
+		t.AccountingPeriod,
+		t.Comment,
+		SourceTransaction,
+		t.ID,
+		lines...,
+	)
+}
+
+// FromInvoice recognizes the revenue of a sent invoice: the receivable is debited with the total,
+// revenue is credited with the net amount and VAT payable with the tax. Amounts are restated with rate.
+func FromInvoice(inv *invoice.Invoice, receivableAccountID, revenueAccountID, vatAccountID uint, rate float64) (*Entry, error) {
+	net := roundCents(inv.Net() * rate)
+	tax := roundCents(inv.Tax() * rate)
+	lines := []Line{
+		DebitLine(receivableAccountID, roundCents(net+tax)),
+		CreditLine(revenueAccountID, net),
+	}
+	if tax > 0 {
+		lines = append(lines, CreditLine(vatAccountID, tax))
+	}
+	return New(
+		inv.IssueDate,
+		inv.IssueDate,
+		"Invoice "+inv.Number,
+		SourceInvoice,
+		inv.ID,
+		lines...,
+	)
+}
+
+// FromAllocation settles the receivable of an invoice with a payment. The payment was booked as revenue
+// when received, so the allocated amount is moved from revenue to the receivable. Revenue is restated with
+// the settlement rate and the receivable with the rate it was recognized at, the difference between them
+// is booked on the exchange differences account.
+func FromAllocation(
+	a *invoice.Allocation,
+	inv *invoice.Invoice,
+	receivableAccountID, revenueAccountID, exchangeAccountID uint,
+	receivableRate, settlementRate float64,
+) (*Entry, error) {
+	debit := roundCents(a.Amount * settlementRate)
+	credit := roundCents(a.Amount * receivableRate)
+	lines := []Line{
+		DebitLine(revenueAccountID, debit),
+		CreditLine(receivableAccountID, credit),
+	}
+	switch diff := roundCents(debit - credit); {
+	case diff > 0:
+		lines = append(lines, CreditLine(exchangeAccountID, diff))
+	case diff < 0:
+		lines = append(lines, DebitLine(exchangeAccountID, -diff))
+	}
+	return New(
+		a.Date,
+		a.Date,
+		"Payment allocated to invoice "+inv.Number,
+		SourceAllocation,
+		a.ID,
+		lines...,
+	)
+}
+
+// FromBill recognizes the expense of an approved supplier bill against accounts payable.
+func FromBill(b *bill.Bill, expenseAccountID, payableAccountID uint) (*Entry, error) {
+	return New(
+		b.BillDate,
+		b.BillDate,
+		"Bill "+b.Number,
+		SourceBill,
+		b.ID,
+		DebitLine(expenseAccountID, b.Amount),
+		CreditLine(payableAccountID, b.Amount),
+	)
+}
+
+// FromBillPayment settles the payable of a paid bill. The payment itself is posted as an expense,
+// so the amount is moved from the expense account back to accounts payable.
+func FromBillPayment(b *bill.Bill, payableAccountID, expenseAccountID uint) (*Entry, error) {
+	date := b.BillDate
+	if b.PaidAt != nil {
+		date = *b.PaidAt
+	}
+	return New(
+		date,
+		date,
+		"Payment of bill "+b.Number,
+		SourceBillPayment,
+		b.ID,
+		DebitLine(payableAccountID, b.Amount),
+		CreditLine(expenseAccountID, b.Amount),
+	)
+}
+
+// FromInventoryWriteOff books the value of the products an inventory check found missing
+// as an expense, taking it off the inventory account.
+func FromInventoryWriteOff(
+	date time.Time, description string, checkID uint, amount float64, expenseAccountID, inventoryAccountID uint,
+) (*Entry, error) {
+	return New(
+		date,
+		date,
+		description,
+		SourceInventoryWriteOff,
+		checkID,
+		DebitLine(expenseAccountID, amount),
+		CreditLine(inventoryAccountID, amount),
+	)
+}
+
+// RevaluationSourceID identifies the revaluation entry of the month containing period, e.g. 202401.
+func RevaluationSourceID(period time.Time) uint {
+	return uint(period.Year()*100 + int(period.Month()))
+}
+
+// FromRevaluation brings the base currency balances of foreign currency accounts to the closing rate.
+// Adjustments are keyed by ledger account; the net amount is an unrealized exchange gain or loss.
+func FromRevaluation(date time.Time, exchangeAccountID uint, adjustments map[uint]float64) (*Entry, error) {
+	accountIDs := make([]uint, 0, len(adjustments))
+	for id := range adjustments {
+		accountIDs = append(accountIDs, id)
+	}
+	slices.Sort(accountIDs)
+
+	var lines []Line
+	var net float64
+	for _, id := range accountIDs {
+		amount := roundCents(adjustments[id])
+		switch {
+		case amount > 0:
+			lines = append(lines, DebitLine(id, amount))
+		case amount < 0:
+			lines = append(lines, CreditLine(id, -amount))
+		}
+		net += amount
+	}
+	switch net = roundCents(net); {
+	case net > 0:
+		lines = append(lines, CreditLine(exchangeAccountID, net))
+	case net < 0:
+		lines = append(lines, DebitLine(exchangeAccountID, -net))
+	}
+	return New(
+		date,
+		date,
+		"FX revaluation "+date.Format("2006-01"),
+		SourceRevaluation,
+		RevaluationSourceID(date),
+		lines...,
+	)
+}
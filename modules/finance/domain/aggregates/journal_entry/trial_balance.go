@@ -0,0 +1,40 @@
+package journalentry
+
+import (
+	ledgeraccount "github.com/iota-uz/iota-sdk/modules/finance/domain/entities/ledger_account"
+)
+
+type TrialBalanceLine struct {
+	Account *ledgeraccount.Account
+	Debit   float64
+	Credit  float64
+}
+
+// Balance returns the balance of the line in the normal direction of its account.
+func (l *TrialBalanceLine) Balance() float64 {
+	return l.Account.Balance(l.Debit, l.Credit)
+}
+
+type TrialBalance struct {
+	Lines []*TrialBalanceLine
+}
+
+func (t *TrialBalance) TotalDebit() float64 {
+	var total float64
+	for _, l := range t.Lines {
+		total += l.Debit
+	}
+	return total
+}
+
+func (t *TrialBalance) TotalCredit() float64 {
+	var total float64
+	for _, l := range t.Lines {
+		total += l.Credit
+	}
+	return total
+}
+
+func (t *TrialBalance) IsBalanced() bool {
+	return toCents(t.TotalDebit()) == toCents(t.TotalCredit())
+}
@@ -0,0 +1,37 @@
+package journalentry
+
+import "fmt"
+
+// SourceType identifies the document an entry was posted from.
+type SourceType string
+
+const (
+	SourcePayment     SourceType = "PAYMENT"
+	SourceExpense     SourceType = "EXPENSE"
+	SourceTransaction SourceType = "TRANSACTION"
+	SourceManual      SourceType = "MANUAL"
+	SourceRevaluation SourceType = "REVALUATION"
+	SourceInvoice     SourceType = "INVOICE"
+	SourceAllocation  SourceType = "INVOICE_ALLOCATION"
+	SourceBill        SourceType = "BILL"
+	SourceBillPayment SourceType = "BILL_PAYMENT"
+	// SourceInventoryWriteOff entries are keyed by the ID of the approved warehouse inventory check.
+	SourceInventoryWriteOff SourceType = "INVENTORY_WRITE_OFF"
+)
+
+func (s SourceType) IsValid() bool {
+	switch s {
+	case SourcePayment, SourceExpense, SourceTransaction, SourceManual, SourceRevaluation, SourceInvoice, SourceAllocation,
+		SourceBill, SourceBillPayment, SourceInventoryWriteOff:
+		return true
+	}
+	return false
+}
+
+func NewSourceType(value string) (SourceType, error) {
+	s := SourceType(value)
+	if !s.IsValid() {
+		return "", fmt.Errorf("invalid source type: %s", value)
+	}
+	return s, nil
+}
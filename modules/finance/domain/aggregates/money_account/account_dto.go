@@ -4,7 +4,9 @@ import (
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
 	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
 	"time"
 )
 
@@ -24,6 +26,16 @@ type UpdateDTO struct {
 	Description   string
 }
 
+type TransferDTO struct {
+	OriginAccountID      uint            `validate:"required"`
+	DestinationAccountID uint            `validate:"required,nefield=OriginAccountID"`
+	Amount               float64         `validate:"required,gt=0"`
+	DestinationAmount    float64         `validate:"omitempty,gt=0"` // when the bank quoted its own rate
+	Date                 shared.DateOnly `validate:"required"`
+	AccountingPeriod     shared.DateOnly `validate:"required"`
+	Comment              string
+}
+
 func (p *CreateDTO) Ok(l ut.Translator) (map[string]string, bool) {
 	errors := map[string]string{}
 	errs := constants.Validate.Struct(p)
@@ -56,3 +68,30 @@ func (p *CreateDTO) ToEntity() (*Account, error) {
 		UpdatedAt:   time.Now(),
 	}, nil
 }
+
+func (p *TransferDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors := map[string]string{}
+	errs := constants.Validate.Struct(p)
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (p *TransferDTO) ToEntity() *transaction.Transaction {
+	entity := transaction.NewTransfer(
+		p.Amount,
+		p.OriginAccountID,
+		p.DestinationAccountID,
+		time.Time(p.Date),
+		time.Time(p.AccountingPeriod),
+		p.Comment,
+	)
+	if p.DestinationAmount != 0 {
+		entity.WithExchange(p.DestinationAmount, p.DestinationAmount/p.Amount)
+	}
+	return entity
+}
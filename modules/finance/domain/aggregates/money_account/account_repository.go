@@ -2,6 +2,7 @@ package moneyaccount
 
 import (
 	"context"
+	"time"
 )
 
 type DateRange struct {
@@ -24,6 +25,8 @@ type Repository interface {
 	GetPaginated(ctx context.Context, params *FindParams) ([]*Account, error)
 	GetByID(ctx context.Context, id uint) (*Account, error)
 	RecalculateBalance(ctx context.Context, id uint) error
+	// GetBalanceAt returns the balance of the account from transactions dated on or before at.
+	GetBalanceAt(ctx context.Context, id uint, at time.Time) (float64, error)
 	Create(ctx context.Context, data *Account) (*Account, error)
 	Update(ctx context.Context, data *Account) error
 	Delete(ctx context.Context, id uint) error
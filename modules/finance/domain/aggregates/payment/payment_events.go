@@ -2,11 +2,28 @@ package payment
 
 import (
 	"context"
+
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/events"
 )
 
+// NewSaved is the outbox payload enqueued once the payment is created or changed.
+func NewSaved(p Payment) events.PaymentSaved {
+	saved := events.PaymentSaved{
+		PaymentID:       p.ID(),
+		CounterpartyID:  p.CounterpartyID(),
+		Amount:          p.Amount(),
+		Comment:         p.Comment(),
+		TransactionDate: p.TransactionDate(),
+	}
+	if p.Account() != nil {
+		saved.Currency = string(p.Account().Currency.Code)
+	}
+	return saved
+}
+
 func NewCreatedEvent(ctx context.Context, data CreateDTO, result Payment) (*Created, error) {
 	sender, err := composables.UseUser(ctx)
 	if err != nil {
@@ -0,0 +1,140 @@
+package recurring
+
+import "time"
+
+// Template describes a payment or an expense that repeats on a schedule, such as rent, salaries
+// or subscriptions. The scheduler materializes every occurrence from the start date up to the
+// optional end date exactly once and keeps track of the next one that is due.
+type Template struct {
+	ID             uint
+	Kind           Kind
+	Name           string
+	Schedule       Schedule
+	Amount         float64
+	AccountID      uint
+	CategoryID     *uint // expenses only
+	CounterpartyID *uint // payments only
+	Comment        string
+	StartDate      time.Time
+	EndDate        *time.Time
+	LastRunDate    *time.Time
+	NextRunDate    *time.Time // nil once the schedule is over
+	CreatedBy      uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Occurrence records a materialized occurrence of a template and what was created for it.
+type Occurrence struct {
+	ID         uint
+	TemplateID uint
+	Date       time.Time
+	PaymentID  *uint
+	ExpenseID  *uint
+	CreatedAt  time.Time
+}
+
+func New(
+	kind Kind,
+	name string,
+	schedule Schedule,
+	amount float64,
+	accountID, categoryID, counterpartyID uint,
+	comment string,
+	startDate time.Time,
+	endDate *time.Time,
+	createdBy uint,
+) (*Template, error) {
+	t := &Template{
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	if err := t.Update(
+		kind, name, schedule, amount, accountID, categoryID, counterpartyID, comment, startDate, endDate,
+	); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Update changes the template. Occurrences that were already materialized are not repeated.
+func (t *Template) Update(
+	kind Kind,
+	name string,
+	schedule Schedule,
+	amount float64,
+	accountID, categoryID, counterpartyID uint,
+	comment string,
+	startDate time.Time,
+	endDate *time.Time,
+) error {
+	startDate = dateOf(startDate)
+	if endDate != nil {
+		end := dateOf(*endDate)
+		if end.Before(startDate) {
+			return ErrInvalidEndDate
+		}
+		endDate = &end
+	}
+	t.CategoryID, t.CounterpartyID = nil, nil
+	switch kind {
+	case Expense:
+		if categoryID == 0 {
+			return ErrCategoryRequired
+		}
+		t.CategoryID = &categoryID
+	case Payment:
+		if counterpartyID == 0 {
+			return ErrCounterpartyRequired
+		}
+		t.CounterpartyID = &counterpartyID
+	}
+	t.Kind = kind
+	t.Name = name
+	t.Schedule = schedule
+	t.Amount = amount
+	t.AccountID = accountID
+	t.Comment = comment
+	t.StartDate = startDate
+	t.EndDate = endDate
+	t.UpdatedAt = time.Now()
+	t.reschedule()
+	return nil
+}
+
+// IsDue reports whether an occurrence is due on or before the given day.
+func (t *Template) IsDue(at time.Time) bool {
+	return t.NextRunDate != nil && !t.NextRunDate.After(dateOf(at))
+}
+
+// Advance marks the next occurrence as materialized and moves on to the following one.
+func (t *Template) Advance() {
+	if t.NextRunDate == nil {
+		return
+	}
+	last := *t.NextRunDate
+	t.LastRunDate = &last
+	t.UpdatedAt = time.Now()
+	t.reschedule()
+}
+
+// Upcoming lists the next n occurrences that have not been materialized yet.
+func (t *Template) Upcoming(n int) []time.Time {
+	if t.NextRunDate == nil {
+		return nil
+	}
+	return t.Schedule.Occurrences(t.StartDate, *t.NextRunDate, t.EndDate, n)
+}
+
+func (t *Template) reschedule() {
+	from := t.StartDate
+	if t.LastRunDate != nil && !t.LastRunDate.Before(from) {
+		from = t.LastRunDate.AddDate(0, 0, 1)
+	}
+	next := t.Schedule.Next(t.StartDate, from)
+	if t.EndDate != nil && next.After(*t.EndDate) {
+		t.NextRunDate = nil
+		return
+	}
+	t.NextRunDate = &next
+}
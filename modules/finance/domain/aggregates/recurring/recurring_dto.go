@@ -0,0 +1,104 @@
+package recurring
+
+import (
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// SaveDTO is used both to create a template and to change it. EndDate is optional.
+type SaveDTO struct {
+	Kind           string  `validate:"required,oneof=PAYMENT EXPENSE"`
+	Name           string  `validate:"required,max=255"`
+	Schedule       string  `validate:"required"`
+	Amount         float64 `validate:"required,gt=0"`
+	AccountID      uint    `validate:"required"`
+	CategoryID     uint
+	CounterpartyID uint
+	Comment        string
+	StartDate      shared.DateOnly `validate:"required"`
+	EndDate        shared.DateOnly
+}
+
+func (d *SaveDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors := map[string]string{}
+	if errs := constants.Validate.Struct(d); errs != nil {
+		for _, err := range errs.(validator.ValidationErrors) {
+			errors[err.Field()] = err.Translate(l)
+		}
+	}
+	if _, ok := errors["Schedule"]; !ok {
+		if _, err := ParseSchedule(d.Schedule); err != nil {
+			errors["Schedule"] = err.Error()
+		}
+	}
+	switch {
+	case Kind(d.Kind) == Expense && d.CategoryID == 0:
+		errors["CategoryID"] = ErrCategoryRequired.Error()
+	case Kind(d.Kind) == Payment && d.CounterpartyID == 0:
+		errors["CounterpartyID"] = ErrCounterpartyRequired.Error()
+	}
+	if end := d.endDate(); end != nil && end.Before(time.Time(d.StartDate)) {
+		errors["EndDate"] = ErrInvalidEndDate.Error()
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *SaveDTO) endDate() *time.Time {
+	end := time.Time(d.EndDate)
+	if end.IsZero() {
+		return nil
+	}
+	return &end
+}
+
+func (d *SaveDTO) ToEntity(createdBy uint) (*Template, error) {
+	kind, err := NewKind(d.Kind)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := ParseSchedule(d.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	return New(
+		kind,
+		d.Name,
+		schedule,
+		d.Amount,
+		d.AccountID,
+		d.CategoryID,
+		d.CounterpartyID,
+		d.Comment,
+		time.Time(d.StartDate),
+		d.endDate(),
+		createdBy,
+	)
+}
+
+// Apply changes a template with the content of the DTO.
+func (d *SaveDTO) Apply(entity *Template) error {
+	kind, err := NewKind(d.Kind)
+	if err != nil {
+		return err
+	}
+	schedule, err := ParseSchedule(d.Schedule)
+	if err != nil {
+		return err
+	}
+	return entity.Update(
+		kind,
+		d.Name,
+		schedule,
+		d.Amount,
+		d.AccountID,
+		d.CategoryID,
+		d.CounterpartyID,
+		d.Comment,
+		time.Time(d.StartDate),
+		d.endDate(),
+	)
+}
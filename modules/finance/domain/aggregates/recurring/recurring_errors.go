@@ -0,0 +1,10 @@
+package recurring
+
+import "errors"
+
+var (
+	ErrInvalidSchedule      = errors.New("invalid recurrence rule")
+	ErrInvalidEndDate       = errors.New("end date is before the start date")
+	ErrCategoryRequired     = errors.New("recurring expenses need an expense category")
+	ErrCounterpartyRequired = errors.New("recurring payments need a counterparty")
+)
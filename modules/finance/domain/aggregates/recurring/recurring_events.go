@@ -0,0 +1,76 @@
+package recurring
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// Event carries who changed the template, the session it was done in and the template after the change.
+type Event struct {
+	Sender  user.User
+	Session session.Session
+	Result  Template
+}
+
+func newEvent(ctx context.Context, result Template) (Event, error) {
+	sender, err := composables.UseUser(ctx)
+	if err != nil {
+		return Event{}, err
+	}
+	sess, err := composables.UseSession(ctx)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		Sender:  sender,
+		Session: *sess,
+		Result:  result,
+	}, nil
+}
+
+type CreatedEvent struct{ Event }
+
+type UpdatedEvent struct{ Event }
+
+type DeletedEvent struct{ Event }
+
+// MaterializedEvent is published when the scheduler has created the payment or expense of an occurrence.
+type MaterializedEvent struct {
+	Event
+	Occurrence Occurrence
+}
+
+func NewCreatedEvent(ctx context.Context, result Template) (*CreatedEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedEvent{e}, nil
+}
+
+func NewUpdatedEvent(ctx context.Context, result Template) (*UpdatedEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &UpdatedEvent{e}, nil
+}
+
+func NewDeletedEvent(ctx context.Context, result Template) (*DeletedEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &DeletedEvent{e}, nil
+}
+
+func NewMaterializedEvent(ctx context.Context, result Template, occurrence Occurrence) (*MaterializedEvent, error) {
+	e, err := newEvent(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return &MaterializedEvent{Event: e, Occurrence: occurrence}, nil
+}
@@ -0,0 +1,30 @@
+package recurring
+
+import (
+	"context"
+	"time"
+)
+
+type FindParams struct {
+	Kind   Kind
+	Limit  int
+	Offset int
+	SortBy []string
+}
+
+type Repository interface {
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Template, error)
+	GetByID(ctx context.Context, id uint) (*Template, error)
+	// GetDue returns the templates that have an occurrence due on or before the given day.
+	GetDue(ctx context.Context, at time.Time) ([]*Template, error)
+	// GetForUpdate loads a template and locks it until the transaction ends.
+	GetForUpdate(ctx context.Context, id uint) (*Template, error)
+	Create(ctx context.Context, data *Template) error
+	Update(ctx context.Context, data *Template) error
+	Delete(ctx context.Context, id uint) error
+	GetOccurrences(ctx context.Context, templateID uint, limit int) ([]*Occurrence, error)
+	// CreateOccurrence records an occurrence and reports false if it was recorded before.
+	CreateOccurrence(ctx context.Context, data *Occurrence) (bool, error)
+	UpdateOccurrence(ctx context.Context, data *Occurrence) error
+}
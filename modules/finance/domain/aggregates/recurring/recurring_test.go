@@ -0,0 +1,141 @@
+package recurring_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDates(dates []time.Time) []string {
+	result := make([]string, 0, len(dates))
+	for _, d := range dates {
+		result = append(result, d.Format(time.DateOnly))
+	}
+	return result
+}
+
+func TestSchedule_Occurrences(t *testing.T) {
+	tests := []struct {
+		rule     string
+		start    time.Time
+		expected []string
+	}{
+		{
+			"FREQ=DAILY;INTERVAL=10",
+			date(2024, time.January, 25),
+			[]string{"2024-01-25", "2024-02-04", "2024-02-14"},
+		},
+		{
+			"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			date(2024, time.January, 3), // Wednesday
+			[]string{"2024-01-04", "2024-01-15", "2024-01-18", "2024-01-29"},
+		},
+		{
+			"FREQ=MONTHLY",
+			date(2024, time.January, 31),
+			[]string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"},
+		},
+		{
+			"RRULE:FREQ=MONTHLY;BYMONTHDAY=1,15",
+			date(2024, time.January, 10),
+			[]string{"2024-01-15", "2024-02-01", "2024-02-15"},
+		},
+		{
+			"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=-1",
+			date(2024, time.January, 1),
+			[]string{"2024-01-31", "2024-04-30", "2024-07-31"},
+		},
+		{
+			"FREQ=YEARLY",
+			date(2024, time.February, 29),
+			[]string{"2024-02-29", "2025-02-28", "2026-02-28"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			s, err := recurring.ParseSchedule(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := formatDates(s.Occurrences(tt.start, tt.start, nil, len(tt.expected)))
+			for i := range tt.expected {
+				if i >= len(got) || got[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;COUNT=3",
+	} {
+		if _, err := recurring.ParseSchedule(rule); !errors.Is(err, recurring.ErrInvalidSchedule) {
+			t.Errorf("%q: expected ErrInvalidSchedule, got %v", rule, err)
+		}
+	}
+}
+
+func TestTemplate_Advance(t *testing.T) {
+	s, err := recurring.ParseSchedule("FREQ=MONTHLY;BYMONTHDAY=5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	end := date(2024, time.March, 10)
+	tmpl, err := recurring.New(
+		recurring.Expense, "Rent", s, 1000, 1, 2, 0, "", date(2024, time.January, 1), &end, 1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.IsDue(date(2024, time.January, 4)) || !tmpl.IsDue(date(2024, time.January, 5)) {
+		t.Fatalf("unexpected next run date %v", tmpl.NextRunDate)
+	}
+	if got := formatDates(tmpl.Upcoming(5)); len(got) != 3 || got[2] != "2024-03-05" {
+		t.Fatalf("unexpected upcoming occurrences %v", got)
+	}
+	var runs []string
+	for tmpl.IsDue(date(2024, time.December, 31)) {
+		runs = append(runs, tmpl.NextRunDate.Format(time.DateOnly))
+		tmpl.Advance()
+	}
+	if len(runs) != 3 || tmpl.NextRunDate != nil {
+		t.Fatalf("expected 3 runs and no next run, got %v and %v", runs, tmpl.NextRunDate)
+	}
+	if err := tmpl.Update(
+		recurring.Expense, "Rent", s, 1000, 1, 2, 0, "", date(2024, time.January, 1), nil,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.NextRunDate == nil || tmpl.NextRunDate.Format(time.DateOnly) != "2024-04-05" {
+		t.Fatalf("expected the schedule to resume after the last run, got %v", tmpl.NextRunDate)
+	}
+}
+
+func TestNew_RequiresKindReference(t *testing.T) {
+	s, _ := recurring.ParseSchedule("FREQ=DAILY")
+	start := date(2024, time.January, 1)
+	if _, err := recurring.New(recurring.Expense, "x", s, 1, 1, 0, 5, "", start, nil, 1); !errors.Is(err, recurring.ErrCategoryRequired) {
+		t.Errorf("expected ErrCategoryRequired, got %v", err)
+	}
+	if _, err := recurring.New(recurring.Payment, "x", s, 1, 1, 5, 0, "", start, nil, 1); !errors.Is(err, recurring.ErrCounterpartyRequired) {
+		t.Errorf("expected ErrCounterpartyRequired, got %v", err)
+	}
+	before := start.AddDate(0, 0, -1)
+	if _, err := recurring.New(recurring.Payment, "x", s, 1, 1, 0, 5, "", start, &before, 1); !errors.Is(err, recurring.ErrInvalidEndDate) {
+		t.Errorf("expected ErrInvalidEndDate, got %v", err)
+	}
+}
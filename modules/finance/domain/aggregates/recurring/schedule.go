@@ -0,0 +1,215 @@
+package recurring
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Schedule is the subset of the iCalendar recurrence rule (RFC 5545) that recurring transactions
+// need: FREQ, INTERVAL, BYDAY for weekly and BYMONTHDAY for monthly rules, for example
+// "FREQ=MONTHLY;BYMONTHDAY=1" or "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+// Unlike RRULE a month day past the end of a month falls on its last day, so a rule for the 31st
+// still runs in February; BYMONTHDAY=-1 is the last day of every month.
+// Without BYDAY or BYMONTHDAY the rule repeats on the weekday or day of month of the start date.
+type Schedule struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+}
+
+func ParseSchedule(value string) (Schedule, error) {
+	s := Schedule{Interval: 1}
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return Schedule{}, fmt.Errorf("%w: %s", ErrInvalidSchedule, part)
+		}
+		switch key {
+		case "FREQ":
+			s.Frequency = Frequency(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return Schedule{}, fmt.Errorf("%w: INTERVAL=%s", ErrInvalidSchedule, val)
+			}
+			s.Interval = n
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				wd, ok := weekdays[d]
+				if !ok {
+					return Schedule{}, fmt.Errorf("%w: BYDAY=%s", ErrInvalidSchedule, val)
+				}
+				s.ByDay = append(s.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(d)
+				if err != nil || n == 0 || n < -1 || n > 31 {
+					return Schedule{}, fmt.Errorf("%w: BYMONTHDAY=%s", ErrInvalidSchedule, val)
+				}
+				s.ByMonthDay = append(s.ByMonthDay, n)
+			}
+		default:
+			return Schedule{}, fmt.Errorf("%w: unsupported part %s", ErrInvalidSchedule, key)
+		}
+	}
+	if !s.Frequency.IsValid() {
+		return Schedule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidSchedule)
+	}
+	if len(s.ByDay) > 0 && s.Frequency != Weekly {
+		return Schedule{}, fmt.Errorf("%w: BYDAY is only supported for weekly rules", ErrInvalidSchedule)
+	}
+	if len(s.ByMonthDay) > 0 && s.Frequency != Monthly {
+		return Schedule{}, fmt.Errorf("%w: BYMONTHDAY is only supported for monthly rules", ErrInvalidSchedule)
+	}
+	return s, nil
+}
+
+func (s Schedule) String() string {
+	parts := []string{"FREQ=" + string(s.Frequency)}
+	if s.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(s.Interval))
+	}
+	if len(s.ByDay) > 0 {
+		days := make([]string, 0, len(s.ByDay))
+		for _, wd := range s.ByDay {
+			for k, v := range weekdays {
+				if v == wd {
+					days = append(days, k)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(s.ByMonthDay) > 0 {
+		days := make([]string, 0, len(s.ByMonthDay))
+		for _, d := range s.ByMonthDay {
+			days = append(days, strconv.Itoa(d))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence on or after from of the schedule anchored at start.
+func (s Schedule) Next(start, from time.Time) time.Time {
+	start, from = dateOf(start), dateOf(from)
+	if from.Before(start) {
+		from = start
+	}
+	interval := max(s.Interval, 1)
+	for p := s.firstPeriod(start, from, interval); ; p++ {
+		for _, d := range s.candidates(start, p*interval) {
+			if !d.Before(from) {
+				return d
+			}
+		}
+	}
+}
+
+// Occurrences lists up to n occurrences on or after from, stopping after end when it is set.
+func (s Schedule) Occurrences(start, from time.Time, end *time.Time, n int) []time.Time {
+	result := make([]time.Time, 0, n)
+	for len(result) < n {
+		d := s.Next(start, from)
+		if end != nil && d.After(dateOf(*end)) {
+			break
+		}
+		result = append(result, d)
+		from = d.AddDate(0, 0, 1)
+	}
+	return result
+}
+
+// firstPeriod is the index of the period that from falls in, counted in intervals since start.
+func (s Schedule) firstPeriod(start, from time.Time, interval int) int {
+	var elapsed int
+	switch s.Frequency {
+	case Daily:
+		elapsed = daysBetween(start, from)
+	case Weekly:
+		elapsed = daysBetween(weekStart(start), weekStart(from)) / 7
+	case Monthly:
+		elapsed = monthIndex(from) - monthIndex(start)
+	case Yearly:
+		elapsed = from.Year() - start.Year()
+	}
+	return elapsed / interval
+}
+
+// candidates returns the sorted occurrences of the period offset periods after start's,
+// skipping the ones before start.
+func (s Schedule) candidates(start time.Time, offset int) []time.Time {
+	var dates []time.Time
+	switch s.Frequency {
+	case Daily:
+		dates = append(dates, start.AddDate(0, 0, offset))
+	case Weekly:
+		days := s.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		monday := weekStart(start).AddDate(0, 0, offset*7)
+		for _, wd := range days {
+			dates = append(dates, monday.AddDate(0, 0, (int(wd)+6)%7))
+		}
+	case Monthly:
+		days := s.ByMonthDay
+		if len(days) == 0 {
+			days = []int{start.Day()}
+		}
+		first := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		for _, d := range days {
+			dates = append(dates, dayOfMonth(first, d))
+		}
+	case Yearly:
+		first := time.Date(start.Year()+offset, start.Month(), 1, 0, 0, 0, 0, time.UTC)
+		dates = append(dates, dayOfMonth(first, start.Day()))
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	dates = slices.CompactFunc(dates, func(a, b time.Time) bool { return a.Equal(b) })
+	return slices.DeleteFunc(dates, func(d time.Time) bool { return d.Before(start) })
+}
+
+// dayOfMonth resolves a month day of the month starting at first, clamping it to the last day.
+func dayOfMonth(first time.Time, day int) time.Time {
+	last := first.AddDate(0, 1, -1).Day()
+	if day == -1 || day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
+
+func weekStart(t time.Time) time.Time {
+	return t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+}
+
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
@@ -0,0 +1,44 @@
+package recurring
+
+import "fmt"
+
+// Kind is what a template materializes into.
+type Kind string
+
+const (
+	Payment Kind = "PAYMENT"
+	Expense Kind = "EXPENSE"
+)
+
+func (k Kind) IsValid() bool {
+	switch k {
+	case Payment, Expense:
+		return true
+	}
+	return false
+}
+
+func NewKind(value string) (Kind, error) {
+	k := Kind(value)
+	if !k.IsValid() {
+		return "", fmt.Errorf("invalid recurring transaction kind: %s", value)
+	}
+	return k, nil
+}
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+func (f Frequency) IsValid() bool {
+	switch f {
+	case Daily, Weekly, Monthly, Yearly:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package ledgeraccount
+
+import (
+	"fmt"
+	"time"
+
+	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+)
+
+// Account is an account of the chart of accounts.
+// Money accounts and expense categories are mirrored by accounts of type Asset and Expense respectively.
+type Account struct {
+	ID                uint
+	Code              string
+	Name              string
+	Type              Type
+	MoneyAccountID    *uint
+	ExpenseCategoryID *uint
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func New(code, name string, accountType Type) *Account {
+	return &Account{
+		ID:        0,
+		Code:      code,
+		Name:      name,
+		Type:      accountType,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func NewForMoneyAccount(account *moneyaccount.Account) *Account {
+	id := account.ID
+	entity := New(fmt.Sprintf("%s-%d", MoneyAccountCodePrefix, id), account.Name, Asset)
+	entity.MoneyAccountID = &id
+	return entity
+}
+
+func NewForExpenseCategory(expenseCategory category.ExpenseCategory) *Account {
+	id := expenseCategory.ID()
+	entity := New(fmt.Sprintf("%s-%d", ExpenseCategoryCodePrefix, id), expenseCategory.Name(), Expense)
+	entity.ExpenseCategoryID = &id
+	return entity
+}
+
+// Balance returns the balance of the account in its normal direction.
+func (a *Account) Balance(debit, credit float64) float64 {
+	if a.Type.IsDebitNormal() {
+		return debit - credit
+	}
+	return credit - debit
+}
@@ -0,0 +1,63 @@
+package ledgeraccount
+
+import (
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+type CreateDTO struct {
+	Code string `validate:"required,max=32"`
+	Name string `validate:"required,max=255"`
+	Type string `validate:"required"`
+}
+
+type UpdateDTO struct {
+	Code string `validate:"max=32"`
+	Name string `validate:"max=255"`
+}
+
+func (d *CreateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *CreateDTO) ToEntity() (*Account, error) {
+	t, err := NewType(d.Type)
+	if err != nil {
+		return nil, err
+	}
+	return New(d.Code, d.Name, t), nil
+}
+
+func (d *UpdateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *UpdateDTO) Apply(entity *Account) *Account {
+	if d.Code != "" {
+		entity.Code = d.Code
+	}
+	if d.Name != "" {
+		entity.Name = d.Name
+	}
+	entity.UpdatedAt = time.Now()
+	return entity
+}
@@ -0,0 +1,25 @@
+package ledgeraccount
+
+import (
+	"context"
+)
+
+type FindParams struct {
+	Limit  int
+	Offset int
+	SortBy []string
+	Type   Type
+}
+
+type Repository interface {
+	Count(ctx context.Context) (int64, error)
+	GetAll(ctx context.Context) ([]*Account, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Account, error)
+	GetByID(ctx context.Context, id uint) (*Account, error)
+	GetByCode(ctx context.Context, code string) (*Account, error)
+	GetByMoneyAccountID(ctx context.Context, id uint) (*Account, error)
+	GetByExpenseCategoryID(ctx context.Context, id uint) (*Account, error)
+	Create(ctx context.Context, data *Account) error
+	Update(ctx context.Context, data *Account) error
+	Delete(ctx context.Context, id uint) error
+}
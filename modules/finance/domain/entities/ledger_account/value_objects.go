@@ -0,0 +1,51 @@
+package ledgeraccount
+
+import "fmt"
+
+type Type string
+
+const (
+	Asset     Type = "ASSET"
+	Liability Type = "LIABILITY"
+	Equity    Type = "EQUITY"
+	Income    Type = "INCOME"
+	Expense   Type = "EXPENSE"
+)
+
+func (t Type) IsValid() bool {
+	switch t {
+	case Asset, Liability, Equity, Income, Expense:
+		return true
+	}
+	return false
+}
+
+// IsDebitNormal reports whether accounts of this type grow on the debit side.
+func (t Type) IsDebitNormal() bool {
+	return t == Asset || t == Expense
+}
+
+func NewType(value string) (Type, error) {
+	t := Type(value)
+	if !t.IsValid() {
+		return "", fmt.Errorf("invalid ledger account type: %s", value)
+	}
+	return t, nil
+}
+
+// Codes of the system accounts created by the finance migration.
+const (
+	AccountsReceivableCode   = "1200"
+	InventoryCode            = "1300"
+	AccountsPayableCode      = "2100"
+	VATPayableCode           = "2200"
+	OpeningBalanceEquityCode = "3000"
+	RevenueCode              = "4000"
+	ExchangeDifferencesCode  = "7000"
+)
+
+// Code prefixes of the accounts opened for money accounts and expense categories.
+const (
+	MoneyAccountCodePrefix    = "1010"
+	ExpenseCategoryCodePrefix = "6000"
+)
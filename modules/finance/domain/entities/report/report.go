@@ -0,0 +1,91 @@
+package report
+
+import (
+	"time"
+)
+
+// Column is the date range a report column covers. Balance reports take their snapshot at To.
+type Column struct {
+	From time.Time
+	To   time.Time
+}
+
+// Columns splits [from, to] into calendar months when byMonth is set, otherwise returns a single column.
+func Columns(from, to time.Time, byMonth bool) []Column {
+	if !byMonth {
+		return []Column{{From: from, To: to}}
+	}
+	var columns []Column
+	start := from
+	for !start.After(to) {
+		end := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()).AddDate(0, 1, -1)
+		if end.After(to) {
+			end = to
+		}
+		columns = append(columns, Column{From: start, To: end})
+		start = end.AddDate(0, 0, 1)
+	}
+	return columns
+}
+
+type RowType int
+
+const (
+	// RowAccount holds the figures of a single ledger account; Label is the account name.
+	RowAccount RowType = iota
+	// RowSection starts a group of rows; Label is a translation key and Values is empty.
+	RowSection
+	// RowTotal sums the rows above it; Label is a translation key.
+	RowTotal
+	// RowComputed is a derived line shown among account rows; Label is a translation key.
+	RowComputed
+)
+
+type Row struct {
+	Type   RowType
+	Label  string
+	Values []float64
+}
+
+func (r *Row) Total() float64 {
+	var total float64
+	for _, v := range r.Values {
+		total += v
+	}
+	return total
+}
+
+// IsZero reports whether every value of the row rounds to zero cents.
+func (r *Row) IsZero() bool {
+	for _, v := range r.Values {
+		if v >= 0.005 || v <= -0.005 {
+			return false
+		}
+	}
+	return true
+}
+
+type Report struct {
+	Kind     Kind
+	From     time.Time
+	To       time.Time
+	Currency string
+	Columns  []Column
+	Rows     []*Row
+}
+
+// HasTotal reports whether the columns of the report can be summed.
+// Flows over a period can, balance snapshots can not.
+func (r *Report) HasTotal() bool {
+	return r.Kind != Balance && len(r.Columns) > 1
+}
+
+func (r *Report) Section(label string) {
+	r.Rows = append(r.Rows, &Row{Type: RowSection, Label: label})
+}
+
+func (r *Report) Add(rowType RowType, label string, values []float64) *Row {
+	row := &Row{Type: rowType, Label: label, Values: values}
+	r.Rows = append(r.Rows, row)
+	return row
+}
@@ -0,0 +1,32 @@
+package report_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/report"
+)
+
+func TestColumns(t *testing.T) {
+	from := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	if got := report.Columns(from, to, false); len(got) != 1 || !got[0].From.Equal(from) || !got[0].To.Equal(to) {
+		t.Errorf("expected a single column, got %+v", got)
+	}
+
+	got := report.Columns(from, to, true)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 columns, got %d", len(got))
+	}
+	if !got[0].From.Equal(from) || !got[0].To.Equal(time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected first column %+v", got[0])
+	}
+	if !got[1].From.Equal(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)) ||
+		!got[1].To.Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected second column %+v", got[1])
+	}
+	if !got[2].To.Equal(to) {
+		t.Errorf("expected last column to end on %s, got %s", to, got[2].To)
+	}
+}
@@ -0,0 +1,27 @@
+package report
+
+import "fmt"
+
+type Kind string
+
+const (
+	ProfitAndLoss Kind = "profit-and-loss"
+	CashFlow      Kind = "cash-flow"
+	Balance       Kind = "balance"
+)
+
+func (k Kind) IsValid() bool {
+	switch k {
+	case ProfitAndLoss, CashFlow, Balance:
+		return true
+	}
+	return false
+}
+
+func NewKind(value string) (Kind, error) {
+	k := Kind(value)
+	if !k.IsValid() {
+		return "", fmt.Errorf("invalid report kind: %s", value)
+	}
+	return k, nil
+}
@@ -7,12 +7,15 @@ import (
 type Transaction struct {
 	ID                   uint
 	Amount               float64
+	DestinationAmount    float64
+	ExchangeRate         float64
 	OriginAccountID      *uint
 	DestinationAccountID *uint
 	TransactionDate      time.Time
 	AccountingPeriod     time.Time
 	TransactionType      Type
 	Comment              string
+	ReconciledAt         *time.Time
 	CreatedAt            time.Time
 }
 
@@ -73,3 +76,45 @@ func NewWithdrawal(
 		CreatedAt:            time.Now(),
 	}
 }
+
+func NewTransfer(
+	amount float64,
+	originAccount,
+	destinationAccount uint,
+	date time.Time,
+	accountingPeriod time.Time,
+	comment string,
+) *Transaction {
+	return &Transaction{
+		ID:                   0,
+		Amount:               amount,
+		OriginAccountID:      &originAccount,
+		DestinationAccountID: &destinationAccount,
+		TransactionType:      Transfer,
+		TransactionDate:      date,
+		AccountingPeriod:     accountingPeriod,
+		Comment:              comment,
+		CreatedAt:            time.Now(),
+	}
+}
+
+// WithExchange records the amount credited to the destination account of a transfer
+// between accounts in different currencies, together with the rate used.
+func (t *Transaction) WithExchange(destinationAmount, rate float64) *Transaction {
+	t.DestinationAmount = destinationAmount
+	t.ExchangeRate = rate
+	return t
+}
+
+// ReceivedAmount is the amount credited to the destination account in its own currency.
+func (t *Transaction) ReceivedAmount() float64 {
+	if t.DestinationAmount != 0 {
+		return t.DestinationAmount
+	}
+	return t.Amount
+}
+
+// IsReconciled reports whether the transaction is confirmed by a bank statement line.
+func (t *Transaction) IsReconciled() bool {
+	return t.ReconciledAt != nil
+}
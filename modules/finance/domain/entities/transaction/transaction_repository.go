@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"context"
+	"time"
 )
 
 type DateRange struct {
@@ -14,6 +15,8 @@ type FindParams struct {
 	Offset    int
 	SortBy    []string
 	CreatedAt DateRange
+	// AccountID keeps the transactions moving money from or to the money account
+	AccountID uint
 }
 
 type Repository interface {
@@ -24,4 +27,6 @@ type Repository interface {
 	Create(ctx context.Context, upload *Transaction) error
 	Update(ctx context.Context, upload *Transaction) error
 	Delete(ctx context.Context, id uint) error
+	// SetReconciled marks the transaction as reconciled at the given time, or clears the mark when nil.
+	SetReconciled(ctx context.Context, id uint, reconciledAt *time.Time) error
 }
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+const (
+	BillSubmittedNotification = "finance.bill_submitted"
+	BillApprovedNotification  = "finance.bill_approved"
+	BillRejectedNotification  = "finance.bill_rejected"
+)
+
+// BillNotificationHandler asks the approvers to review submitted bills and tells the
+// submitter whether the bill was approved or rejected.
+type BillNotificationHandler struct {
+	notificationService *coreservices.NotificationService
+}
+
+func RegisterBillNotificationHandler(app application.Application) *BillNotificationHandler {
+	notificationService := app.Service(coreservices.NotificationService{}).(*coreservices.NotificationService)
+	notificationService.RegisterTypes(
+		notification.Type{
+			Name:       BillSubmittedNotification,
+			Defaults:   []notification.Channel{notification.InApp, notification.Email},
+			Permission: permissions.BillApprove,
+		},
+		notification.Type{
+			Name:     BillApprovedNotification,
+			Defaults: []notification.Channel{notification.InApp},
+		},
+		notification.Type{
+			Name:     BillRejectedNotification,
+			Defaults: []notification.Channel{notification.InApp, notification.Email},
+		},
+	)
+	handler := &BillNotificationHandler{
+		notificationService: notificationService,
+	}
+	eventbus.Subscribe(app.Outbox(), events.BillStatusChangedTopic, "finance.bill_notification", handler.onStatusChanged)
+	return handler
+}
+
+func (h *BillNotificationHandler) onStatusChanged(ctx context.Context, payload events.BillStatusChanged) error {
+	data := notification.SendDTO{
+		Data: map[string]interface{}{
+			"Number":   payload.Number,
+			"Amount":   fmt.Sprintf("%.2f", payload.Amount),
+			"Currency": payload.Currency,
+			"Reason":   payload.Reason,
+		},
+		Link: fmt.Sprintf("/finance/bills/%d", payload.BillID),
+	}
+	switch bill.Status(payload.Status) {
+	case bill.Submitted:
+		data.Type = BillSubmittedNotification
+	case bill.Approved:
+		data.Type = BillApprovedNotification
+		data.UserIDs = []uint{payload.SubmittedBy}
+	case bill.Rejected:
+		data.Type = BillRejectedNotification
+		data.UserIDs = []uint{payload.SubmittedBy}
+	default:
+		return nil
+	}
+	return h.notificationService.Send(ctx, data)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/tax"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+// dealInvoiceDueDays is how long after it is drafted an invoice made from a deal is due.
+const dealInvoiceDueDays = 14
+
+// DealInvoiceHandler drafts an invoice for the deals converted into invoices. The invoice is
+// addressed to the counterparty named after the client, which is created when there is none.
+type DealInvoiceHandler struct {
+	invoiceRepo      invoice.Repository
+	counterpartyRepo counterparty.Repository
+	taxRate          float64
+}
+
+func RegisterDealInvoiceHandler(
+	app application.Application,
+	invoiceRepo invoice.Repository,
+	counterpartyRepo counterparty.Repository,
+) *DealInvoiceHandler {
+	handler := &DealInvoiceHandler{
+		invoiceRepo:      invoiceRepo,
+		counterpartyRepo: counterpartyRepo,
+		taxRate:          configuration.Use().VatTax,
+	}
+	eventbus.Subscribe(app.Outbox(), events.DealConvertedTopic, "finance.deal_invoice", handler.onDealConverted)
+	return handler
+}
+
+func (h *DealInvoiceHandler) counterpartyFor(ctx context.Context, name string) (counterparty.Counterparty, error) {
+	matches, err := h.counterpartyRepo.GetPaginated(ctx, &counterparty.FindParams{
+		Query: name,
+		Field: "name",
+		Limit: 20,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range matches {
+		if strings.EqualFold(c.Name(), name) {
+			return c, nil
+		}
+	}
+	return h.counterpartyRepo.Create(ctx, counterparty.New(
+		tax.NilTin,
+		name,
+		counterparty.Customer,
+		counterparty.SP,
+		"",
+	))
+}
+
+func (h *DealInvoiceHandler) onDealConverted(ctx context.Context, payload events.DealConverted) error {
+	if payload.Target != "invoice" {
+		return nil
+	}
+	code, err := currency.NewCode(payload.Currency)
+	if err != nil {
+		return err
+	}
+	cp, err := h.counterpartyFor(ctx, payload.ClientName)
+	if err != nil {
+		return err
+	}
+	issueDate := time.Now()
+	entity, err := invoice.New(
+		cp.ID(),
+		code,
+		issueDate,
+		issueDate.AddDate(0, 0, dealInvoiceDueDays),
+		fmt.Sprintf("CRM deal #%d", payload.DealID),
+		[]*invoice.Line{{
+			Description: payload.Title,
+			Quantity:    1,
+			UnitPrice:   payload.Amount,
+			TaxRate:     h.taxRate,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if err := h.invoiceRepo.Create(ctx, entity); err != nil {
+		return err
+	}
+	return eventbus.Enqueue(ctx, events.DealInvoicedTopic, events.DealInvoiced{
+		DealID:         payload.DealID,
+		ClientID:       payload.ClientID,
+		CounterpartyID: cp.ID(),
+	})
+}
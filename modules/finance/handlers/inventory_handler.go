@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+// InventoryWriteOffCategory is the expense category the write-offs of inventory checks are posted to,
+// it is created on the first write-off.
+const InventoryWriteOffCategory = "Inventory write-offs"
+
+// InventoryWriteOffHandler posts the value of the products written off by approved inventory checks.
+type InventoryWriteOffHandler struct {
+	categoryRepo  category.Repository
+	ledgerService *services.LedgerService
+	baseCurrency  currency.Code
+}
+
+func RegisterInventoryWriteOffHandler(
+	app application.Application,
+	categoryRepo category.Repository,
+	ledgerService *services.LedgerService,
+) *InventoryWriteOffHandler {
+	handler := &InventoryWriteOffHandler{
+		categoryRepo:  categoryRepo,
+		ledgerService: ledgerService,
+		baseCurrency:  currency.Code(configuration.Use().BaseCurrency),
+	}
+	eventbus.Subscribe(app.Outbox(), events.InventoryApprovedTopic, "finance.inventory_write_off", handler.onInventoryApproved)
+	return handler
+}
+
+func (h *InventoryWriteOffHandler) writeOffCategory(ctx context.Context) (category.ExpenseCategory, error) {
+	matches, err := h.categoryRepo.GetPaginated(ctx, &category.FindParams{
+		Query: InventoryWriteOffCategory,
+		Field: "name",
+		Limit: 20,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range matches {
+		if strings.EqualFold(c.Name(), InventoryWriteOffCategory) {
+			return c, nil
+		}
+	}
+	baseCurrency := currency.USD
+	for _, c := range currency.Currencies {
+		if c.Code == h.baseCurrency {
+			baseCurrency = c
+		}
+	}
+	return h.categoryRepo.Create(ctx, category.New(
+		InventoryWriteOffCategory,
+		"Products found missing by warehouse inventory checks",
+		0,
+		&baseCurrency,
+	))
+}
+
+func (h *InventoryWriteOffHandler) onInventoryApproved(ctx context.Context, payload events.InventoryApproved) error {
+	if payload.WriteOff <= 0 {
+		return nil
+	}
+	c, err := h.writeOffCategory(ctx)
+	if err != nil {
+		return err
+	}
+	return h.ledgerService.PostInventoryWriteOff(
+		ctx,
+		payload.CheckID,
+		payload.ApprovedAt,
+		fmt.Sprintf("Inventory check #%d %s", payload.CheckID, payload.Name),
+		payload.WriteOff,
+		c.ID(),
+	)
+}
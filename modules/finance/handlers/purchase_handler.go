@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+// PurchaseSupplierHandler describes the supplier counterparty of every purchase order placed.
+type PurchaseSupplierHandler struct {
+	counterpartyRepo counterparty.Repository
+}
+
+func RegisterPurchaseSupplierHandler(
+	app application.Application,
+	counterpartyRepo counterparty.Repository,
+) *PurchaseSupplierHandler {
+	handler := &PurchaseSupplierHandler{
+		counterpartyRepo: counterpartyRepo,
+	}
+	eventbus.Subscribe(app.Outbox(), events.PurchaseCreatedTopic, "finance.purchase_supplier", handler.onPurchaseCreated)
+	return handler
+}
+
+func (h *PurchaseSupplierHandler) onPurchaseCreated(ctx context.Context, payload events.PurchaseCreated) error {
+	c, err := h.counterpartyRepo.GetByID(ctx, payload.SupplierID)
+	if err != nil {
+		return err
+	}
+	supplier := events.PurchaseSupplier{
+		CounterpartyID: c.ID(),
+		Name:           c.Name(),
+	}
+	if c.Tin() != nil {
+		supplier.Tin = c.Tin().Value()
+	}
+	return eventbus.Enqueue(ctx, events.PurchaseSupplierTopic, supplier)
+}
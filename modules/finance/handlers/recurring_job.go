@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const (
+	recurringCheckInterval = 10 * time.Minute
+	recurringUserAgent     = "recurring-scheduler"
+)
+
+// RecurringJob materializes the recurring payments and expenses that are due. Every template runs
+// in its own transaction on behalf of the user who created it, so one failing template does not
+// hold back the others and is retried on the next tick.
+type RecurringJob struct {
+	pool             *pgxpool.Pool
+	recurringService *services.RecurringService
+	userRepo         user.Repository
+}
+
+func RegisterRecurringJob(app application.Application, recurringService *services.RecurringService) *RecurringJob {
+	job := &RecurringJob{
+		pool:             app.DB(),
+		recurringService: recurringService,
+		userRepo:         corepersistence.NewUserRepository(),
+	}
+	app.RegisterJobs(job)
+	return job
+}
+
+func (j *RecurringJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(recurringCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.runDue(ctx, now)
+		}
+	}
+}
+
+func (j *RecurringJob) runDue(ctx context.Context, now time.Time) {
+	ctx = composables.WithPool(ctx, j.pool)
+	templates, err := j.recurringService.GetDue(ctx, now)
+	if err != nil {
+		log.Printf("Error loading due recurring transactions: %v", err)
+		return
+	}
+	for _, t := range templates {
+		if err := j.runTemplate(ctx, t, now); err != nil {
+			log.Printf("Error running recurring transaction %d: %v", t.ID, err)
+		}
+	}
+}
+
+func (j *RecurringJob) runTemplate(ctx context.Context, t *recurring.Template, now time.Time) error {
+	owner, err := j.userRepo.GetByID(ctx, t.CreatedBy)
+	if err != nil {
+		return err
+	}
+	ctx = composables.WithUser(ctx, owner)
+	ctx = composables.WithSession(ctx, &session.Session{
+		UserID:    owner.ID(),
+		UserAgent: recurringUserAgent,
+		ExpiresAt: now,
+		CreatedAt: now,
+	})
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := j.recurringService.Run(composables.WithTx(ctx, tx), t.ID, now); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			log.Printf("Error rolling back recurring transaction: %v", rbErr)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const revaluationCheckInterval = time.Hour
+
+// RevaluationJob posts the month-end FX revaluation once a month is over. It checks right after startup
+// and then every hour. Months that already have a revaluation entry or were closed in the books are skipped.
+type RevaluationJob struct {
+	pool          *pgxpool.Pool
+	ledgerService *services.LedgerService
+}
+
+func RegisterRevaluationJob(app application.Application, ledgerService *services.LedgerService) *RevaluationJob {
+	job := &RevaluationJob{
+		pool:          app.DB(),
+		ledgerService: ledgerService,
+	}
+	app.RegisterJobs(job)
+	return job
+}
+
+func (j *RevaluationJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(revaluationCheckInterval)
+	defer ticker.Stop()
+	now := time.Now()
+	for {
+		period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+		if err := j.revalue(ctx, period); err != nil && !errors.Is(err, accountingperiod.ErrPeriodClosed) {
+			log.Printf("Error revaluing %s: %v", period.Format("2006-01"), err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case now = <-ticker.C:
+		}
+	}
+}
+
+func (j *RevaluationJob) revalue(ctx context.Context, period time.Time) error {
+	ctx = composables.WithPool(ctx, j.pool)
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	txCtx := composables.WithTx(ctx, tx)
+	_, err = j.ledgerService.GetEntryBySource(txCtx, journalentry.SourceRevaluation, journalentry.RevaluationSourceID(period))
+	if err == nil {
+		return tx.Rollback(ctx)
+	}
+	if errors.Is(err, persistence.ErrJournalEntryNotFound) {
+		err = j.ledgerService.Revalue(txCtx, period)
+	}
+	if err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			log.Printf("Error rolling back revaluation: %v", rbErr)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,166 @@
+// Package documents renders printable finance documents.
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+)
+
+const (
+	fontFamily  = "DejaVu"
+	regularFont = "DejaVuSans.ttf"
+	boldFont    = "DejaVuSans-Bold.ttf"
+)
+
+// ErrFontsNotFound is returned when the DejaVu fonts cannot be loaded. The core PDF fonts are Latin-only,
+// so rendering without them would garble Cyrillic and Uzbek text.
+var ErrFontsNotFound = errors.New("PDF fonts not found")
+
+// InvoiceLabels are the translated captions printed on an invoice.
+type InvoiceLabels struct {
+	Title       string
+	IssueDate   string
+	DueDate     string
+	BillTo      string
+	Tin         string
+	Description string
+	Quantity    string
+	UnitPrice   string
+	TaxRate     string
+	Amount      string
+	Net         string
+	Tax         string
+	Total       string
+	Paid        string
+	Outstanding string
+}
+
+type InvoiceDocument struct {
+	Invoice      *invoice.Invoice
+	Counterparty counterparty.Counterparty
+	Labels       InvoiceLabels
+}
+
+// PDFRenderer renders documents with the DejaVu fonts found in fontsPath, so that Cyrillic and
+// Uzbek text is printed correctly.
+type PDFRenderer struct {
+	fontsPath string
+}
+
+func NewPDFRenderer(fontsPath string) *PDFRenderer {
+	return &PDFRenderer{fontsPath: fontsPath}
+}
+
+func (r *PDFRenderer) newDocument() (*fpdf.Fpdf, error) {
+	regular, err := os.ReadFile(filepath.Join(r.fontsPath, regularFont))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFontsNotFound, err)
+	}
+	bold, err := os.ReadFile(filepath.Join(r.fontsPath, boldFont))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFontsNotFound, err)
+	}
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", regular)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", bold)
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFontsNotFound, err)
+	}
+	return pdf, nil
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// RenderInvoice writes the invoice as a single A4 PDF document.
+func (r *PDFRenderer) RenderInvoice(w io.Writer, doc *InvoiceDocument) error {
+	inv := doc.Invoice
+	labels := doc.Labels
+	pdf, err := r.newDocument()
+	if err != nil {
+		return err
+	}
+	pdf.AddPage()
+
+	pdf.SetFont(fontFamily, "B", 18)
+	pdf.CellFormat(0, 10, fmt.Sprintf("%s %s", labels.Title, inv.Number), "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("%s: %s", labels.IssueDate, inv.IssueDate.Format("2006-01-02")), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("%s: %s", labels.DueDate, inv.DueDate.Format("2006-01-02")), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont(fontFamily, "B", 11)
+	pdf.CellFormat(0, 6, labels.BillTo, "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	if doc.Counterparty != nil {
+		pdf.CellFormat(0, 6, doc.Counterparty.Name(), "", 1, "L", false, 0, "")
+		if tin := doc.Counterparty.Tin(); tin != nil && tin.Value() != "" {
+			pdf.CellFormat(0, 6, fmt.Sprintf("%s: %s", labels.Tin, tin.Value()), "", 1, "L", false, 0, "")
+		}
+		if address := doc.Counterparty.LegalAddress(); address != "" {
+			pdf.MultiCell(0, 6, address, "", "L", false)
+		}
+	}
+	pdf.Ln(6)
+
+	widths := []float64{80, 20, 28, 20, 32}
+	header := []string{labels.Description, labels.Quantity, labels.UnitPrice, labels.TaxRate, labels.Amount}
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.SetFillColor(235, 235, 235)
+	for i, h := range header {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(widths[i], 8, h, "1", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont(fontFamily, "", 10)
+	for _, l := range inv.Lines {
+		pdf.CellFormat(widths[0], 7, l.Description, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 7, strconv.FormatFloat(l.Quantity, 'f', -1, 64), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[2], 7, formatAmount(l.UnitPrice), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 7, strconv.FormatFloat(l.TaxRate, 'f', -1, 64)+"%", "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 7, formatAmount(l.Total()), "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+	pdf.Ln(4)
+
+	totals := []struct {
+		label  string
+		amount float64
+		bold   bool
+	}{
+		{labels.Net, inv.Net(), false},
+		{labels.Tax, inv.Tax(), false},
+		{labels.Total, inv.Total(), true},
+		{labels.Paid, inv.PaidAmount, false},
+		{labels.Outstanding, inv.Outstanding(), true},
+	}
+	labelWidth := widths[0] + widths[1] + widths[2] + widths[3]
+	for _, t := range totals {
+		style := ""
+		if t.bold {
+			style = "B"
+		}
+		pdf.SetFont(fontFamily, style, 10)
+		pdf.CellFormat(labelWidth, 6, t.label, "", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 6, fmt.Sprintf("%s %s", formatAmount(t.amount), inv.Currency), "", 1, "R", false, 0, "")
+	}
+	if inv.Comment != "" {
+		pdf.Ln(6)
+		pdf.SetFont(fontFamily, "", 9)
+		pdf.MultiCell(0, 5, inv.Comment, "", "L", false)
+	}
+	return pdf.Output(w)
+}
@@ -0,0 +1,88 @@
+package documents_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/documents"
+)
+
+const fontsPath = "/usr/share/fonts/truetype/dejavu"
+
+// pdfText inflates the compressed streams of a PDF document and returns them joined together.
+func pdfText(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var text bytes.Buffer
+	for {
+		start := bytes.Index(data, []byte("stream\n"))
+		if start == -1 {
+			return text.Bytes()
+		}
+		data = data[start+len("stream\n"):]
+		end := bytes.Index(data, []byte("\nendstream"))
+		if end == -1 {
+			t.Fatal("unterminated stream")
+		}
+		if r, err := zlib.NewReader(bytes.NewReader(data[:end])); err == nil {
+			if _, err := io.Copy(&text, r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		data = data[end+len("\nendstream"):]
+	}
+}
+
+func TestPDFRenderer_RenderInvoice(t *testing.T) {
+	inv, err := invoice.New(
+		1,
+		currency.UsdCode,
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		"Thank you",
+		[]*invoice.Line{{Description: "Консультация", Quantity: 2, UnitPrice: 50, TaxRate: 12}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv.Number = invoice.NumberFor(1)
+	doc := &documents.InvoiceDocument{
+		Invoice: inv,
+		Labels:  documents.InvoiceLabels{Title: "Oʻzbekcha hisob-faktura"},
+	}
+
+	var buf bytes.Buffer
+	if err := documents.NewPDFRenderer(fontsPath).RenderInvoice(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Fatal("output is not a PDF document")
+	}
+	// Text set in a UTF-8 font is written as UTF-16BE code points.
+	text := pdfText(t, buf.Bytes())
+	encoder := unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewEncoder()
+	for _, s := range []string{"Консультация", "Oʻzbekcha"} {
+		encoded, err := encoder.Bytes([]byte(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(text, encoded) {
+			t.Errorf("expected %q to be rendered", s)
+		}
+	}
+
+	buf.Reset()
+	if err := documents.NewPDFRenderer(t.TempDir()).RenderInvoice(&buf, doc); !errors.Is(err, documents.ErrFontsNotFound) {
+		t.Errorf("expected %v, got %v", documents.ErrFontsNotFound, err)
+	}
+	if buf.Len() != 0 {
+		t.Error("expected nothing to be written without fonts")
+	}
+}
@@ -0,0 +1,212 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-faster/errors"
+	accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrAccountingPeriodNotFound = errors.New("accounting period not found")
+)
+
+const (
+	accountingPeriodFindQuery = `
+		SELECT id, start_date, status, closed_at, created_at, updated_at
+		FROM accounting_periods`
+	accountingPeriodCountQuery  = `SELECT COUNT(*) as count FROM accounting_periods`
+	accountingPeriodInsertQuery = `
+		INSERT INTO accounting_periods (start_date, status, closed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	accountingPeriodUpdateQuery = `
+		UPDATE accounting_periods
+		SET status = $1, closed_at = $2, updated_at = $3
+		WHERE id = $4`
+	accountingPeriodBalancesFindQuery = `
+		SELECT period_id, money_account_id, balance, currency_id
+		FROM accounting_period_balances
+		WHERE period_id = ANY($1)
+		ORDER BY money_account_id`
+	accountingPeriodBalancesDeleteQuery = `DELETE FROM accounting_period_balances WHERE period_id = $1`
+	accountingPeriodBalanceInsertQuery  = `
+		INSERT INTO accounting_period_balances (period_id, money_account_id, balance, currency_id)
+		VALUES ($1, $2, $3, $4)`
+)
+
+type GormAccountingPeriodRepository struct{}
+
+func NewAccountingPeriodRepository() accountingperiod.Repository {
+	return &GormAccountingPeriodRepository{}
+}
+
+func (g *GormAccountingPeriodRepository) GetPaginated(
+	ctx context.Context, params *accountingperiod.FindParams,
+) ([]*accountingperiod.Period, error) {
+	q := repo.Join(
+		accountingPeriodFindQuery,
+		"ORDER BY start_date DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryPeriods(ctx, q)
+}
+
+func (g *GormAccountingPeriodRepository) Count(ctx context.Context) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, accountingPeriodCountQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormAccountingPeriodRepository) GetByID(ctx context.Context, id uint) (*accountingperiod.Period, error) {
+	return g.getOne(ctx, "WHERE id = $1", id)
+}
+
+func (g *GormAccountingPeriodRepository) GetByMonth(ctx context.Context, date time.Time) (*accountingperiod.Period, error) {
+	return g.getOne(ctx, "WHERE start_date = $1", accountingperiod.MonthStart(date))
+}
+
+func (g *GormAccountingPeriodRepository) Create(ctx context.Context, data *accountingperiod.Period) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbPeriod, _ := toDBAccountingPeriod(data)
+	if err := tx.QueryRow(
+		ctx,
+		accountingPeriodInsertQuery,
+		dbPeriod.StartDate,
+		dbPeriod.Status,
+		dbPeriod.ClosedAt,
+		dbPeriod.CreatedAt,
+		dbPeriod.UpdatedAt,
+	).Scan(&data.ID); err != nil {
+		return errors.Wrap(err, "failed to create accounting period")
+	}
+	return g.saveBalances(ctx, data)
+}
+
+func (g *GormAccountingPeriodRepository) Update(ctx context.Context, data *accountingperiod.Period) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbPeriod, _ := toDBAccountingPeriod(data)
+	if _, err := tx.Exec(
+		ctx,
+		accountingPeriodUpdateQuery,
+		dbPeriod.Status,
+		dbPeriod.ClosedAt,
+		dbPeriod.UpdatedAt,
+		dbPeriod.ID,
+	); err != nil {
+		return errors.Wrap(err, "failed to update accounting period")
+	}
+	return g.saveBalances(ctx, data)
+}
+
+func (g *GormAccountingPeriodRepository) saveBalances(ctx context.Context, data *accountingperiod.Period) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, accountingPeriodBalancesDeleteQuery, data.ID); err != nil {
+		return err
+	}
+	_, dbBalances := toDBAccountingPeriod(data)
+	for _, b := range dbBalances {
+		if _, err := tx.Exec(
+			ctx,
+			accountingPeriodBalanceInsertQuery,
+			b.PeriodID,
+			b.MoneyAccountID,
+			b.Balance,
+			b.CurrencyID,
+		); err != nil {
+			return errors.Wrap(err, "failed to save closing balance")
+		}
+	}
+	return nil
+}
+
+func (g *GormAccountingPeriodRepository) getOne(
+	ctx context.Context, where string, args ...interface{},
+) (*accountingperiod.Period, error) {
+	periods, err := g.queryPeriods(ctx, repo.Join(accountingPeriodFindQuery, where), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get accounting period")
+	}
+	if len(periods) == 0 {
+		return nil, ErrAccountingPeriodNotFound
+	}
+	return periods[0], nil
+}
+
+func (g *GormAccountingPeriodRepository) queryPeriods(
+	ctx context.Context, query string, args ...interface{},
+) ([]*accountingperiod.Period, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var dbPeriods []*models.AccountingPeriod
+	for rows.Next() {
+		r := &models.AccountingPeriod{}
+		if err := rows.Scan(&r.ID, &r.StartDate, &r.Status, &r.ClosedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		dbPeriods = append(dbPeriods, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(dbPeriods) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, 0, len(dbPeriods))
+	for _, p := range dbPeriods {
+		ids = append(ids, p.ID)
+	}
+	balanceRows, err := tx.Query(ctx, accountingPeriodBalancesFindQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer balanceRows.Close()
+	balancesByPeriod := make(map[uint][]*models.AccountingPeriodBalance, len(dbPeriods))
+	for balanceRows.Next() {
+		b := &models.AccountingPeriodBalance{}
+		if err := balanceRows.Scan(&b.PeriodID, &b.MoneyAccountID, &b.Balance, &b.CurrencyID); err != nil {
+			return nil, err
+		}
+		balancesByPeriod[b.PeriodID] = append(balancesByPeriod[b.PeriodID], b)
+	}
+	if err := balanceRows.Err(); err != nil {
+		return nil, err
+	}
+
+	periods := make([]*accountingperiod.Period, 0, len(dbPeriods))
+	for _, p := range dbPeriods {
+		entity, err := toDomainAccountingPeriod(p, balancesByPeriod[p.ID])
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, entity)
+	}
+	return periods, nil
+}
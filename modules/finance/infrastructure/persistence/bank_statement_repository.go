@@ -0,0 +1,348 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrBankStatementNotFound     = errors.New("bank statement not found")
+	ErrBankStatementLineNotFound = errors.New("bank statement line not found")
+)
+
+const (
+	bankStatementFindQuery = `
+		SELECT id, money_account_id, format, file_name, created_at
+		FROM bank_statements`
+	bankStatementCountQuery  = `SELECT COUNT(*) as count FROM bank_statements`
+	bankStatementInsertQuery = `
+		INSERT INTO bank_statements (money_account_id, format, file_name, created_at)
+		VALUES ($1, $2, $3, $4) RETURNING id`
+	bankStatementDeleteQuery = `DELETE FROM bank_statements WHERE id = $1`
+	bankLineFindQuery        = `
+		SELECT id,
+			statement_id,
+			money_account_id,
+			line_date,
+			amount,
+			currency,
+			description,
+			counterparty_name,
+			counterparty_tin,
+			reference,
+			status,
+			transaction_id,
+			created_at
+		FROM bank_statement_lines`
+	bankLineInsertQuery = `
+		INSERT INTO bank_statement_lines (
+			statement_id,
+			money_account_id,
+			line_date,
+			amount,
+			currency,
+			description,
+			counterparty_name,
+			counterparty_tin,
+			reference,
+			status,
+			transaction_id,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
+	bankLineUpdateQuery = `
+		UPDATE bank_statement_lines
+		SET status = $1, transaction_id = $2
+		WHERE id = $3`
+	bankLineExistsQuery = `
+		SELECT EXISTS(
+			SELECT 1 FROM bank_statement_lines
+			WHERE money_account_id = $1 AND line_date = $2 AND amount = $3 AND reference = $4
+		)`
+	// Amounts are signed from the point of view of the money account, like bank lines.
+	bankCandidatesQuery = `
+		SELECT t.id,
+			t.transaction_date,
+			CASE WHEN t.destination_account_id = $1 THEN COALESCE(t.destination_amount, t.amount) ELSE -t.amount END,
+			c.tin
+		FROM transactions t
+			LEFT JOIN payments p ON p.transaction_id = t.id
+			LEFT JOIN counterparty c ON c.id = p.counterparty_id
+		WHERE (t.origin_account_id = $1 OR t.destination_account_id = $1)
+			AND t.reconciled_at IS NULL
+			AND t.transaction_date BETWEEN $2 AND $3
+			AND NOT EXISTS (
+				SELECT 1 FROM bank_statement_lines l
+				WHERE l.transaction_id = t.id AND l.status IN ('MATCHED', 'RECONCILED')
+			)
+		ORDER BY t.transaction_date, t.id`
+)
+
+type GormBankStatementRepository struct{}
+
+func NewBankStatementRepository() bankstatement.Repository {
+	return &GormBankStatementRepository{}
+}
+
+func bankStatementWhere(params *bankstatement.FindParams) ([]string, []interface{}) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if params.MoneyAccountID != 0 {
+		args = append(args, params.MoneyAccountID)
+		where = append(where, fmt.Sprintf("money_account_id = $%d", len(args)))
+	}
+	return where, args
+}
+
+func (g *GormBankStatementRepository) Count(ctx context.Context, params *bankstatement.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := bankStatementWhere(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(bankStatementCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormBankStatementRepository) GetPaginated(
+	ctx context.Context, params *bankstatement.FindParams,
+) ([]*bankstatement.Statement, error) {
+	where, args := bankStatementWhere(params)
+	q := repo.Join(
+		bankStatementFindQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryStatements(ctx, q, args...)
+}
+
+func (g *GormBankStatementRepository) GetByID(ctx context.Context, id uint) (*bankstatement.Statement, error) {
+	statements, err := g.queryStatements(ctx, repo.Join(bankStatementFindQuery, "WHERE id = $1"), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get bank statement")
+	}
+	if len(statements) == 0 {
+		return nil, ErrBankStatementNotFound
+	}
+	statement := statements[0]
+	statement.Lines, err = g.GetLines(ctx, &bankstatement.LineFindParams{StatementID: id})
+	if err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+func (g *GormBankStatementRepository) GetLines(
+	ctx context.Context, params *bankstatement.LineFindParams,
+) ([]*bankstatement.Line, error) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if params.StatementID != 0 {
+		args = append(args, params.StatementID)
+		where = append(where, fmt.Sprintf("statement_id = $%d", len(args)))
+	}
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	q := repo.Join(bankLineFindQuery, repo.JoinWhere(where...), "ORDER BY line_date, id")
+	return g.queryLines(ctx, q, args...)
+}
+
+func (g *GormBankStatementRepository) GetLineByID(ctx context.Context, id uint) (*bankstatement.Line, error) {
+	lines, err := g.queryLines(ctx, repo.Join(bankLineFindQuery, "WHERE id = $1"), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get bank statement line")
+	}
+	if len(lines) == 0 {
+		return nil, ErrBankStatementLineNotFound
+	}
+	return lines[0], nil
+}
+
+func (g *GormBankStatementRepository) LineExists(ctx context.Context, line *bankstatement.Line) (bool, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	var exists bool
+	if err := tx.QueryRow(
+		ctx,
+		bankLineExistsQuery,
+		line.MoneyAccountID,
+		line.Date,
+		line.Amount,
+		line.Reference,
+	).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (g *GormBankStatementRepository) Candidates(
+	ctx context.Context, moneyAccountID uint, from, to time.Time,
+) ([]*bankstatement.Candidate, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, bankCandidatesQuery, moneyAccountID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbCandidates []*models.BankMatchCandidate
+	for rows.Next() {
+		c := &models.BankMatchCandidate{}
+		if err := rows.Scan(&c.TransactionID, &c.TransactionDate, &c.Amount, &c.CounterpartyTIN); err != nil {
+			return nil, err
+		}
+		dbCandidates = append(dbCandidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbCandidates, toDomainBankMatchCandidate)
+}
+
+func (g *GormBankStatementRepository) Create(ctx context.Context, data *bankstatement.Statement) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbStatement := toDBBankStatement(data)
+	if err := tx.QueryRow(
+		ctx,
+		bankStatementInsertQuery,
+		dbStatement.MoneyAccountID,
+		dbStatement.Format,
+		dbStatement.FileName,
+		dbStatement.CreatedAt,
+	).Scan(&data.ID); err != nil {
+		return errors.Wrap(err, "failed to create bank statement")
+	}
+	for _, line := range data.Lines {
+		line.StatementID = data.ID
+		dbLine := toDBBankStatementLine(line)
+		if err := tx.QueryRow(
+			ctx,
+			bankLineInsertQuery,
+			dbLine.StatementID,
+			dbLine.MoneyAccountID,
+			dbLine.LineDate,
+			dbLine.Amount,
+			dbLine.Currency,
+			dbLine.Description,
+			dbLine.CounterpartyName,
+			dbLine.CounterpartyTIN,
+			dbLine.Reference,
+			dbLine.Status,
+			dbLine.TransactionID,
+			dbLine.CreatedAt,
+		).Scan(&line.ID); err != nil {
+			return errors.Wrap(err, "failed to create bank statement line")
+		}
+	}
+	return nil
+}
+
+func (g *GormBankStatementRepository) UpdateLine(ctx context.Context, data *bankstatement.Line) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbLine := toDBBankStatementLine(data)
+	if _, err := tx.Exec(ctx, bankLineUpdateQuery, dbLine.Status, dbLine.TransactionID, dbLine.ID); err != nil {
+		return errors.Wrap(err, "failed to update bank statement line")
+	}
+	return nil
+}
+
+func (g *GormBankStatementRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, bankStatementDeleteQuery, id); err != nil {
+		return errors.Wrap(err, "failed to delete bank statement")
+	}
+	return nil
+}
+
+func (g *GormBankStatementRepository) queryStatements(
+	ctx context.Context, query string, args ...interface{},
+) ([]*bankstatement.Statement, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbStatements []*models.BankStatement
+	for rows.Next() {
+		s := &models.BankStatement{}
+		if err := rows.Scan(&s.ID, &s.MoneyAccountID, &s.Format, &s.FileName, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		dbStatements = append(dbStatements, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbStatements, toDomainBankStatement)
+}
+
+func (g *GormBankStatementRepository) queryLines(
+	ctx context.Context, query string, args ...interface{},
+) ([]*bankstatement.Line, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbLines []*models.BankStatementLine
+	for rows.Next() {
+		l := &models.BankStatementLine{}
+		if err := rows.Scan(
+			&l.ID,
+			&l.StatementID,
+			&l.MoneyAccountID,
+			&l.LineDate,
+			&l.Amount,
+			&l.Currency,
+			&l.Description,
+			&l.CounterpartyName,
+			&l.CounterpartyTIN,
+			&l.Reference,
+			&l.Status,
+			&l.TransactionID,
+			&l.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbLines = append(dbLines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbLines, toDomainBankStatementLine)
+}
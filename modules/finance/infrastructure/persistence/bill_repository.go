@@ -0,0 +1,263 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrBillNotFound = errors.New("bill not found")
+)
+
+const (
+	billFindQuery = `
+		SELECT b.id,
+			b.number,
+			b.counterparty_id,
+			b.category_id,
+			b.currency_id,
+			b.amount,
+			b.bill_date,
+			b.due_date,
+			b.description,
+			b.status,
+			b.submitted_by,
+			b.approved_by,
+			b.approved_at,
+			b.rejection_reason,
+			b.scheduled_date,
+			b.money_account_id,
+			b.expense_id,
+			b.paid_at,
+			b.created_at,
+			b.updated_at
+		FROM bills b`
+	billCountQuery  = `SELECT COUNT(*) as count FROM bills b`
+	billInsertQuery = `
+		INSERT INTO bills (
+			number,
+			counterparty_id,
+			category_id,
+			currency_id,
+			amount,
+			bill_date,
+			due_date,
+			description,
+			status,
+			submitted_by,
+			created_at,
+			updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
+	billUpdateQuery = `
+		UPDATE bills
+		SET number = $1,
+			counterparty_id = $2,
+			category_id = $3,
+			currency_id = $4,
+			amount = $5,
+			bill_date = $6,
+			due_date = $7,
+			description = $8,
+			status = $9,
+			approved_by = $10,
+			approved_at = $11,
+			rejection_reason = $12,
+			scheduled_date = $13,
+			money_account_id = $14,
+			expense_id = $15,
+			paid_at = $16,
+			updated_at = $17
+		WHERE id = $18`
+	billDeleteQuery = `DELETE FROM bills WHERE id = $1`
+)
+
+type GormBillRepository struct{}
+
+func NewBillRepository() bill.Repository {
+	return &GormBillRepository{}
+}
+
+func billWhere(params *bill.FindParams) ([]string, []interface{}) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if params.CounterpartyID != 0 {
+		args = append(args, params.CounterpartyID)
+		where = append(where, fmt.Sprintf("b.counterparty_id = $%d", len(args)))
+	}
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		where = append(where, fmt.Sprintf("b.status = $%d", len(args)))
+	}
+	return where, args
+}
+
+func (g *GormBillRepository) Count(ctx context.Context, params *bill.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := billWhere(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(billCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormBillRepository) GetPaginated(ctx context.Context, params *bill.FindParams) ([]*bill.Bill, error) {
+	sortFields := []string{}
+	for _, f := range params.SortBy {
+		switch f {
+		case "bill_date", "due_date", "created_at", "id":
+			sortFields = append(sortFields, "b."+f)
+		default:
+			return nil, fmt.Errorf("unknown sort field: %s", f)
+		}
+	}
+	if len(sortFields) == 0 {
+		sortFields = append(sortFields, "b.id")
+	}
+	where, args := billWhere(params)
+	q := repo.Join(
+		billFindQuery,
+		repo.JoinWhere(where...),
+		repo.OrderBy(sortFields, false),
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryBills(ctx, q, args...)
+}
+
+func (g *GormBillRepository) GetByID(ctx context.Context, id uint) (*bill.Bill, error) {
+	bills, err := g.queryBills(ctx, repo.Join(billFindQuery, "WHERE b.id = $1"), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get bill")
+	}
+	if len(bills) == 0 {
+		return nil, ErrBillNotFound
+	}
+	return bills[0], nil
+}
+
+func (g *GormBillRepository) Create(ctx context.Context, data *bill.Bill) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbBill := toDBBill(data)
+	if err := tx.QueryRow(
+		ctx,
+		billInsertQuery,
+		dbBill.Number,
+		dbBill.CounterpartyID,
+		dbBill.CategoryID,
+		dbBill.CurrencyID,
+		dbBill.Amount,
+		dbBill.BillDate,
+		dbBill.DueDate,
+		dbBill.Description,
+		dbBill.Status,
+		dbBill.SubmittedBy,
+		dbBill.CreatedAt,
+		dbBill.UpdatedAt,
+	).Scan(&data.ID); err != nil {
+		return errors.Wrap(err, "failed to create bill")
+	}
+	return nil
+}
+
+func (g *GormBillRepository) Update(ctx context.Context, data *bill.Bill) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbBill := toDBBill(data)
+	if _, err := tx.Exec(
+		ctx,
+		billUpdateQuery,
+		dbBill.Number,
+		dbBill.CounterpartyID,
+		dbBill.CategoryID,
+		dbBill.CurrencyID,
+		dbBill.Amount,
+		dbBill.BillDate,
+		dbBill.DueDate,
+		dbBill.Description,
+		dbBill.Status,
+		dbBill.ApprovedBy,
+		dbBill.ApprovedAt,
+		dbBill.RejectionReason,
+		dbBill.ScheduledDate,
+		dbBill.MoneyAccountID,
+		dbBill.ExpenseID,
+		dbBill.PaidAt,
+		dbBill.UpdatedAt,
+		dbBill.ID,
+	); err != nil {
+		return errors.Wrap(err, "failed to update bill")
+	}
+	return nil
+}
+
+func (g *GormBillRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, billDeleteQuery, id); err != nil {
+		return errors.Wrap(err, "failed to delete bill")
+	}
+	return nil
+}
+
+func (g *GormBillRepository) queryBills(ctx context.Context, query string, args ...interface{}) ([]*bill.Bill, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbBills []*models.Bill
+	for rows.Next() {
+		b := &models.Bill{}
+		if err := rows.Scan(
+			&b.ID,
+			&b.Number,
+			&b.CounterpartyID,
+			&b.CategoryID,
+			&b.CurrencyID,
+			&b.Amount,
+			&b.BillDate,
+			&b.DueDate,
+			&b.Description,
+			&b.Status,
+			&b.SubmittedBy,
+			&b.ApprovedBy,
+			&b.ApprovedAt,
+			&b.RejectionReason,
+			&b.ScheduledDate,
+			&b.MoneyAccountID,
+			&b.ExpenseID,
+			&b.PaidAt,
+			&b.CreatedAt,
+			&b.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbBills = append(dbBills, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbBills, toDomainBill)
+}
@@ -0,0 +1,247 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/budget"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrBudgetNotFound = errors.New("budget not found")
+)
+
+const (
+	budgetFindQuery = `
+		SELECT b.id,
+			b.category_id,
+			b.project_id,
+			b.periodicity,
+			b.period_start,
+			b.amount,
+			b.currency_id,
+			b.created_at,
+			b.updated_at
+		FROM budgets b`
+	budgetCountQuery  = `SELECT COUNT(*) as count FROM budgets b`
+	budgetPeriodEnd   = `b.period_start + CASE b.periodicity WHEN 'MONTHLY' THEN INTERVAL '1 month' WHEN 'QUARTERLY' THEN INTERVAL '3 months' ELSE INTERVAL '1 year' END`
+	budgetInsertQuery = `
+		INSERT INTO budgets (category_id, project_id, periodicity, period_start, amount, currency_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	budgetUpdateQuery = `
+		UPDATE budgets
+		SET category_id = $1,
+			project_id = $2,
+			periodicity = $3,
+			period_start = $4,
+			amount = $5,
+			currency_id = $6,
+			updated_at = $7
+		WHERE id = $8`
+	budgetDeleteQuery = `DELETE FROM budgets WHERE id = $1`
+	// budgetActualsQuery sums the expenses of a category within an accounting period
+	// by the currency of the money account they were paid from.
+	budgetActualsQuery = `
+		SELECT ma.balance_currency_id, COALESCE(SUM(-t.amount), 0)
+		FROM expenses ex
+			JOIN transactions t ON t.id = ex.transaction_id
+			JOIN money_accounts ma ON ma.id = t.origin_account_id`
+)
+
+type GormBudgetRepository struct{}
+
+func NewBudgetRepository() budget.Repository {
+	return &GormBudgetRepository{}
+}
+
+func budgetWhere(params *budget.FindParams) ([]string, []interface{}) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if params.CategoryID != 0 {
+		args = append(args, params.CategoryID)
+		where = append(where, fmt.Sprintf("b.category_id = $%d", len(args)))
+	}
+	if params.Periodicity != "" {
+		args = append(args, string(params.Periodicity))
+		where = append(where, fmt.Sprintf("b.periodicity = $%d", len(args)))
+	}
+	if !params.Date.IsZero() {
+		args = append(args, params.Date)
+		where = append(where, fmt.Sprintf("b.period_start <= $%d AND %s > $%d", len(args), budgetPeriodEnd, len(args)))
+	}
+	return where, args
+}
+
+func (g *GormBudgetRepository) Count(ctx context.Context, params *budget.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := budgetWhere(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(budgetCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormBudgetRepository) GetPaginated(ctx context.Context, params *budget.FindParams) ([]*budget.Budget, error) {
+	sortFields := []string{}
+	for _, f := range params.SortBy {
+		switch f {
+		case "period_start", "category_id", "created_at", "id":
+			sortFields = append(sortFields, "b."+f)
+		default:
+			return nil, fmt.Errorf("unknown sort field: %s", f)
+		}
+	}
+	if len(sortFields) == 0 {
+		sortFields = append(sortFields, "b.id")
+	}
+	where, args := budgetWhere(params)
+	q := repo.Join(
+		budgetFindQuery,
+		repo.JoinWhere(where...),
+		repo.OrderBy(sortFields, false),
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryBudgets(ctx, q, args...)
+}
+
+func (g *GormBudgetRepository) GetByID(ctx context.Context, id uint) (*budget.Budget, error) {
+	budgets, err := g.queryBudgets(ctx, repo.Join(budgetFindQuery, "WHERE b.id = $1"), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get budget")
+	}
+	if len(budgets) == 0 {
+		return nil, ErrBudgetNotFound
+	}
+	return budgets[0], nil
+}
+
+func (g *GormBudgetRepository) GetActuals(ctx context.Context, b *budget.Budget) ([]*budget.Actual, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where := []string{"ex.category_id = $1", "t.accounting_period >= $2", "t.accounting_period < $3"}
+	args := []interface{}{b.CategoryID, b.PeriodStart, b.PeriodEnd()}
+	if b.ProjectID != nil {
+		args = append(args, *b.ProjectID)
+		where = append(where, fmt.Sprintf("ex.project_id = $%d", len(args)))
+	}
+	q := repo.Join(budgetActualsQuery, repo.JoinWhere(where...), "GROUP BY ma.balance_currency_id")
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get budget actuals")
+	}
+	defer rows.Close()
+	var dbActuals []*models.BudgetActual
+	for rows.Next() {
+		a := &models.BudgetActual{}
+		if err := rows.Scan(&a.CurrencyID, &a.Amount); err != nil {
+			return nil, err
+		}
+		dbActuals = append(dbActuals, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbActuals, toDomainBudgetActual)
+}
+
+func (g *GormBudgetRepository) Create(ctx context.Context, data *budget.Budget) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbBudget := toDBBudget(data)
+	if err := tx.QueryRow(
+		ctx,
+		budgetInsertQuery,
+		dbBudget.CategoryID,
+		dbBudget.ProjectID,
+		dbBudget.Periodicity,
+		dbBudget.PeriodStart,
+		dbBudget.Amount,
+		dbBudget.CurrencyID,
+		dbBudget.CreatedAt,
+		dbBudget.UpdatedAt,
+	).Scan(&data.ID); err != nil {
+		return errors.Wrap(err, "failed to create budget")
+	}
+	return nil
+}
+
+func (g *GormBudgetRepository) Update(ctx context.Context, data *budget.Budget) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbBudget := toDBBudget(data)
+	if _, err := tx.Exec(
+		ctx,
+		budgetUpdateQuery,
+		dbBudget.CategoryID,
+		dbBudget.ProjectID,
+		dbBudget.Periodicity,
+		dbBudget.PeriodStart,
+		dbBudget.Amount,
+		dbBudget.CurrencyID,
+		dbBudget.UpdatedAt,
+		dbBudget.ID,
+	); err != nil {
+		return errors.Wrap(err, "failed to update budget")
+	}
+	return nil
+}
+
+func (g *GormBudgetRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, budgetDeleteQuery, id); err != nil {
+		return errors.Wrap(err, "failed to delete budget")
+	}
+	return nil
+}
+
+func (g *GormBudgetRepository) queryBudgets(ctx context.Context, query string, args ...interface{}) ([]*budget.Budget, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbBudgets []*models.Budget
+	for rows.Next() {
+		b := &models.Budget{}
+		if err := rows.Scan(
+			&b.ID,
+			&b.CategoryID,
+			&b.ProjectID,
+			&b.Periodicity,
+			&b.PeriodStart,
+			&b.Amount,
+			&b.CurrencyID,
+			&b.CreatedAt,
+			&b.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbBudgets = append(dbBudgets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbBudgets, toDomainBudget)
+}
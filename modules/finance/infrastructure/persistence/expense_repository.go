@@ -50,8 +50,12 @@ func (g *GormExpenseRepository) GetPaginated(
 		where, args = append(where, fmt.Sprintf("ex.%s::VARCHAR ILIKE $%d", params.Field, len(args)+1)), append(args, "%"+params.Query+"%")
 	}
 
+	if params.AccountID != 0 {
+		where, args = append(where, fmt.Sprintf("(tr.origin_account_id = $%d OR tr.destination_account_id = $%d)", len(args)+1, len(args)+1)), append(args, params.AccountID)
+	}
+
 	rows, err := pool.Query(ctx, `
-		SELECT ex.id, ex.transaction_id, ex.category_id, ex.created_at, ex.updated_at,
+		SELECT ex.id, ex.transaction_id, ex.category_id, ex.project_id, ex.created_at, ex.updated_at,
 		tr.amount, tr.transaction_date, tr.accounting_period, tr.transaction_type, tr.comment,
 		tr.origin_account_id, tr.destination_account_id
 		FROM expenses ex LEFT JOIN transactions tr on tr.id = ex.transaction_id
@@ -72,6 +76,7 @@ func (g *GormExpenseRepository) GetPaginated(
 			&dbExpense.ID,
 			&dbExpense.TransactionID,
 			&dbExpense.CategoryID,
+			&dbExpense.ProjectID,
 			&dbExpense.CreatedAt,
 			&dbExpense.UpdatedAt,
 			&dbTransaction.Amount,
@@ -146,12 +151,12 @@ func (g *GormExpenseRepository) Create(ctx context.Context, data *expense.Expens
 		return err
 	}
 	if err := tx.QueryRow(ctx, `
-		INSERT INTO expenses (transaction_id, category_id)
-		VALUES ($1, $2)
-	`, transactionRow.ID, expenseRow.CategoryID).Scan(&data.ID); err != nil {
+		INSERT INTO expenses (transaction_id, category_id, project_id)
+		VALUES ($1, $2, $3) RETURNING id
+	`, transactionRow.ID, expenseRow.CategoryID, expenseRow.ProjectID).Scan(&data.ID); err != nil {
 		return err
 	}
-	expenseRow.TransactionID = transactionRow.ID
+	data.TransactionID = transactionRow.ID
 	return nil
 }
 
@@ -167,9 +172,9 @@ func (g *GormExpenseRepository) Update(ctx context.Context, data *expense.Expens
 	expenseRow.TransactionID = transactionRow.ID
 	if _, err := tx.Exec(ctx, `
 		UPDATE expenses
-		SET transaction_id = $1, category_id = 2
-		WHERE id = $3
-	`, expenseRow.TransactionID, expenseRow.CategoryID); err != nil {
+		SET transaction_id = $1, category_id = $2, project_id = $3, updated_at = $4
+		WHERE id = $5
+	`, expenseRow.TransactionID, expenseRow.CategoryID, expenseRow.ProjectID, expenseRow.UpdatedAt, expenseRow.ID); err != nil {
 		return err
 	}
 	return nil
@@ -3,14 +3,23 @@ package persistence
 import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/country"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/tax"
 	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	coremodels "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
+	accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/budget"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
 	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
 	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	ledgeraccount "github.com/iota-uz/iota-sdk/modules/finance/domain/entities/ledger_account"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
 	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
@@ -21,12 +30,15 @@ func toDBTransaction(entity *transaction.Transaction) *models.Transaction {
 	return &models.Transaction{
 		ID:                   entity.ID,
 		Amount:               entity.Amount,
+		DestinationAmount:    mapping.Pointer(entity.DestinationAmount),
+		ExchangeRate:         mapping.Pointer(entity.ExchangeRate),
 		Comment:              entity.Comment,
 		AccountingPeriod:     entity.AccountingPeriod,
 		TransactionDate:      entity.TransactionDate,
 		DestinationAccountID: entity.DestinationAccountID,
 		OriginAccountID:      entity.OriginAccountID,
 		TransactionType:      string(entity.TransactionType),
+		ReconciledAt:         entity.ReconciledAt,
 		CreatedAt:            entity.CreatedAt,
 	}
 }
@@ -40,12 +52,15 @@ func toDomainTransaction(dbTransaction *models.Transaction) (*transaction.Transa
 	return &transaction.Transaction{
 		ID:                   dbTransaction.ID,
 		Amount:               dbTransaction.Amount,
+		DestinationAmount:    mapping.Value(dbTransaction.DestinationAmount),
+		ExchangeRate:         mapping.Value(dbTransaction.ExchangeRate),
 		TransactionType:      _type,
 		Comment:              dbTransaction.Comment,
 		AccountingPeriod:     dbTransaction.AccountingPeriod,
 		TransactionDate:      dbTransaction.TransactionDate,
 		DestinationAccountID: dbTransaction.DestinationAccountID,
 		OriginAccountID:      dbTransaction.OriginAccountID,
+		ReconciledAt:         dbTransaction.ReconciledAt,
 		CreatedAt:            dbTransaction.CreatedAt,
 	}, nil
 }
@@ -182,6 +197,7 @@ func toDomainExpense(dbExpense *models.Expense, dbTransaction *models.Transactio
 			dbExpense.CreatedAt,
 			dbExpense.UpdatedAt,
 		),
+		ProjectID:        dbExpense.ProjectID,
 		Comment:          dbTransaction.Comment,
 		TransactionID:    dbExpense.TransactionID,
 		AccountingPeriod: dbTransaction.AccountingPeriod,
@@ -206,6 +222,7 @@ func toDBExpense(entity *expense.Expense) (*models.Expense, *transaction.Transac
 	dbExpense := &models.Expense{
 		ID:            entity.ID,
 		CategoryID:    entity.Category.ID(),
+		ProjectID:     entity.ProjectID,
 		TransactionID: entity.TransactionID,
 		CreatedAt:     entity.CreatedAt,
 		UpdatedAt:     entity.UpdatedAt,
@@ -250,3 +267,498 @@ func toDBCounterparty(entity counterparty.Counterparty) (*models.Counterparty, e
 		UpdatedAt:    entity.UpdatedAt(),
 	}, nil
 }
+
+func toDomainLedgerAccount(dbAccount *models.LedgerAccount) (*ledgeraccount.Account, error) {
+	accountType, err := ledgeraccount.NewType(dbAccount.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &ledgeraccount.Account{
+		ID:                dbAccount.ID,
+		Code:              dbAccount.Code,
+		Name:              dbAccount.Name,
+		Type:              accountType,
+		MoneyAccountID:    dbAccount.MoneyAccountID,
+		ExpenseCategoryID: dbAccount.ExpenseCategoryID,
+		CreatedAt:         dbAccount.CreatedAt,
+		UpdatedAt:         dbAccount.UpdatedAt,
+	}, nil
+}
+
+func toDBLedgerAccount(entity *ledgeraccount.Account) *models.LedgerAccount {
+	return &models.LedgerAccount{
+		ID:                entity.ID,
+		Code:              entity.Code,
+		Name:              entity.Name,
+		Type:              string(entity.Type),
+		MoneyAccountID:    entity.MoneyAccountID,
+		ExpenseCategoryID: entity.ExpenseCategoryID,
+		CreatedAt:         entity.CreatedAt,
+		UpdatedAt:         entity.UpdatedAt,
+	}
+}
+
+func toDomainJournalEntry(dbEntry *models.JournalEntry, dbLines []*models.JournalLine) (*journalentry.Entry, error) {
+	sourceType, err := journalentry.NewSourceType(dbEntry.SourceType)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]journalentry.Line, 0, len(dbLines))
+	for _, l := range dbLines {
+		lines = append(lines, journalentry.Line{
+			ID:        l.ID,
+			AccountID: l.AccountID,
+			Debit:     l.Debit,
+			Credit:    l.Credit,
+			Comment:   l.Comment,
+		})
+	}
+	var sourceID uint
+	if dbEntry.SourceID != nil {
+		sourceID = *dbEntry.SourceID
+	}
+	return &journalentry.Entry{
+		ID:               dbEntry.ID,
+		Date:             dbEntry.EntryDate,
+		AccountingPeriod: dbEntry.AccountingPeriod,
+		Description:      dbEntry.Description,
+		SourceType:       sourceType,
+		SourceID:         sourceID,
+		Lines:            lines,
+		CreatedAt:        dbEntry.CreatedAt,
+	}, nil
+}
+
+func toDBJournalEntry(entity *journalentry.Entry) (*models.JournalEntry, []*models.JournalLine) {
+	var sourceID *uint
+	if entity.SourceID != 0 {
+		sourceID = &entity.SourceID
+	}
+	dbEntry := &models.JournalEntry{
+		ID:               entity.ID,
+		EntryDate:        entity.Date,
+		AccountingPeriod: entity.AccountingPeriod,
+		Description:      entity.Description,
+		SourceType:       string(entity.SourceType),
+		SourceID:         sourceID,
+		CreatedAt:        entity.CreatedAt,
+	}
+	dbLines := make([]*models.JournalLine, 0, len(entity.Lines))
+	for _, l := range entity.Lines {
+		dbLines = append(dbLines, &models.JournalLine{
+			ID:        l.ID,
+			EntryID:   entity.ID,
+			AccountID: l.AccountID,
+			Debit:     l.Debit,
+			Credit:    l.Credit,
+			Comment:   l.Comment,
+		})
+	}
+	return dbEntry, dbLines
+}
+
+func toDBAccountingPeriod(entity *accountingperiod.Period) (*models.AccountingPeriod, []*models.AccountingPeriodBalance) {
+	balances := make([]*models.AccountingPeriodBalance, 0, len(entity.Balances))
+	for _, b := range entity.Balances {
+		balances = append(balances, &models.AccountingPeriodBalance{
+			PeriodID:       entity.ID,
+			MoneyAccountID: b.MoneyAccountID,
+			Balance:        b.Balance,
+			CurrencyID:     b.CurrencyCode,
+		})
+	}
+	return &models.AccountingPeriod{
+		ID:        entity.ID,
+		StartDate: entity.Start,
+		Status:    string(entity.Status),
+		ClosedAt:  entity.ClosedAt,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}, balances
+}
+
+func toDomainAccountingPeriod(
+	dbPeriod *models.AccountingPeriod, dbBalances []*models.AccountingPeriodBalance,
+) (*accountingperiod.Period, error) {
+	status, err := accountingperiod.NewStatus(dbPeriod.Status)
+	if err != nil {
+		return nil, err
+	}
+	var balances []accountingperiod.Balance
+	for _, b := range dbBalances {
+		balances = append(balances, accountingperiod.Balance{
+			MoneyAccountID: b.MoneyAccountID,
+			Balance:        b.Balance,
+			CurrencyCode:   b.CurrencyID,
+		})
+	}
+	return &accountingperiod.Period{
+		ID:        dbPeriod.ID,
+		Start:     dbPeriod.StartDate,
+		Status:    status,
+		ClosedAt:  dbPeriod.ClosedAt,
+		Balances:  balances,
+		CreatedAt: dbPeriod.CreatedAt,
+		UpdatedAt: dbPeriod.UpdatedAt,
+	}, nil
+}
+
+func toDBBankStatement(entity *bankstatement.Statement) *models.BankStatement {
+	return &models.BankStatement{
+		ID:             entity.ID,
+		MoneyAccountID: entity.MoneyAccountID,
+		Format:         string(entity.Format),
+		FileName:       entity.FileName,
+		CreatedAt:      entity.CreatedAt,
+	}
+}
+
+func toDomainBankStatement(dbStatement *models.BankStatement) (*bankstatement.Statement, error) {
+	format, err := bankstatement.NewFormat(dbStatement.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &bankstatement.Statement{
+		ID:             dbStatement.ID,
+		MoneyAccountID: dbStatement.MoneyAccountID,
+		Format:         format,
+		FileName:       dbStatement.FileName,
+		CreatedAt:      dbStatement.CreatedAt,
+	}, nil
+}
+
+func toDBBankStatementLine(entity *bankstatement.Line) *models.BankStatementLine {
+	return &models.BankStatementLine{
+		ID:               entity.ID,
+		StatementID:      entity.StatementID,
+		MoneyAccountID:   entity.MoneyAccountID,
+		LineDate:         entity.Date,
+		Amount:           entity.Amount,
+		Currency:         entity.Currency,
+		Description:      entity.Description,
+		CounterpartyName: entity.CounterpartyName,
+		CounterpartyTIN:  entity.CounterpartyTIN,
+		Reference:        entity.Reference,
+		Status:           string(entity.Status),
+		TransactionID:    entity.TransactionID,
+		CreatedAt:        entity.CreatedAt,
+	}
+}
+
+func toDomainBankStatementLine(dbLine *models.BankStatementLine) (*bankstatement.Line, error) {
+	status, err := bankstatement.NewStatus(dbLine.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &bankstatement.Line{
+		ID:               dbLine.ID,
+		StatementID:      dbLine.StatementID,
+		MoneyAccountID:   dbLine.MoneyAccountID,
+		Date:             dbLine.LineDate,
+		Amount:           dbLine.Amount,
+		Currency:         dbLine.Currency,
+		Description:      dbLine.Description,
+		CounterpartyName: dbLine.CounterpartyName,
+		CounterpartyTIN:  dbLine.CounterpartyTIN,
+		Reference:        dbLine.Reference,
+		Status:           status,
+		TransactionID:    dbLine.TransactionID,
+		CreatedAt:        dbLine.CreatedAt,
+	}, nil
+}
+
+func toDomainBankMatchCandidate(dbCandidate *models.BankMatchCandidate) (*bankstatement.Candidate, error) {
+	return &bankstatement.Candidate{
+		TransactionID:   dbCandidate.TransactionID,
+		Date:            dbCandidate.TransactionDate,
+		Amount:          dbCandidate.Amount,
+		CounterpartyTIN: mapping.Value(dbCandidate.CounterpartyTIN),
+	}, nil
+}
+
+func toDBInvoice(entity *invoice.Invoice) (*models.Invoice, []*models.InvoiceLine) {
+	var number *string
+	if entity.Number != "" {
+		number = &entity.Number
+	}
+	dbInvoice := &models.Invoice{
+		ID:             entity.ID,
+		Number:         number,
+		CounterpartyID: entity.CounterpartyID,
+		CurrencyID:     string(entity.Currency),
+		IssueDate:      entity.IssueDate,
+		DueDate:        entity.DueDate,
+		Status:         string(entity.Status),
+		Comment:        entity.Comment,
+		PaidAmount:     entity.PaidAmount,
+		SentAt:         entity.SentAt,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+	}
+	dbLines := make([]*models.InvoiceLine, 0, len(entity.Lines))
+	for _, l := range entity.Lines {
+		dbLines = append(dbLines, &models.InvoiceLine{
+			ID:          l.ID,
+			InvoiceID:   entity.ID,
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			TaxRate:     l.TaxRate,
+		})
+	}
+	return dbInvoice, dbLines
+}
+
+func toDomainInvoice(dbInvoice *models.Invoice, dbLines []*models.InvoiceLine) (*invoice.Invoice, error) {
+	status, err := invoice.NewStatus(dbInvoice.Status)
+	if err != nil {
+		return nil, err
+	}
+	code, err := currency.NewCode(dbInvoice.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]*invoice.Line, 0, len(dbLines))
+	for _, l := range dbLines {
+		lines = append(lines, &invoice.Line{
+			ID:          l.ID,
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			TaxRate:     l.TaxRate,
+		})
+	}
+	return &invoice.Invoice{
+		ID:             dbInvoice.ID,
+		Number:         mapping.Value(dbInvoice.Number),
+		CounterpartyID: dbInvoice.CounterpartyID,
+		Currency:       code,
+		IssueDate:      dbInvoice.IssueDate,
+		DueDate:        dbInvoice.DueDate,
+		Status:         status,
+		Lines:          lines,
+		PaidAmount:     dbInvoice.PaidAmount,
+		Comment:        dbInvoice.Comment,
+		SentAt:         dbInvoice.SentAt,
+		CreatedAt:      dbInvoice.CreatedAt,
+		UpdatedAt:      dbInvoice.UpdatedAt,
+	}, nil
+}
+
+func toDBInvoiceAllocation(entity *invoice.Allocation) *models.InvoiceAllocation {
+	return &models.InvoiceAllocation{
+		ID:             entity.ID,
+		InvoiceID:      entity.InvoiceID,
+		PaymentID:      entity.PaymentID,
+		Amount:         entity.Amount,
+		AllocationDate: entity.Date,
+		CreatedAt:      entity.CreatedAt,
+	}
+}
+
+func toDomainInvoiceAllocation(dbAllocation *models.InvoiceAllocation) (*invoice.Allocation, error) {
+	return &invoice.Allocation{
+		ID:        dbAllocation.ID,
+		InvoiceID: dbAllocation.InvoiceID,
+		PaymentID: dbAllocation.PaymentID,
+		Amount:    dbAllocation.Amount,
+		Date:      dbAllocation.AllocationDate,
+		CreatedAt: dbAllocation.CreatedAt,
+	}, nil
+}
+
+func toDomainInvoicePaymentBalance(dbBalance *models.InvoicePaymentBalance) (*invoice.PaymentBalance, error) {
+	return &invoice.PaymentBalance{
+		PaymentID:      dbBalance.PaymentID,
+		CounterpartyID: dbBalance.CounterpartyID,
+		Date:           dbBalance.TransactionDate,
+		Amount:         dbBalance.Amount,
+		CurrencyCode:   dbBalance.CurrencyID,
+		Allocated:      dbBalance.Allocated,
+	}, nil
+}
+
+func toDBBill(entity *bill.Bill) *models.Bill {
+	var submittedBy *uint
+	if entity.SubmittedBy != 0 {
+		submittedBy = &entity.SubmittedBy
+	}
+	return &models.Bill{
+		ID:              entity.ID,
+		Number:          entity.Number,
+		CounterpartyID:  entity.CounterpartyID,
+		CategoryID:      entity.CategoryID,
+		CurrencyID:      string(entity.Currency),
+		Amount:          entity.Amount,
+		BillDate:        entity.BillDate,
+		DueDate:         entity.DueDate,
+		Description:     entity.Description,
+		Status:          string(entity.Status),
+		SubmittedBy:     submittedBy,
+		ApprovedBy:      entity.ApprovedBy,
+		ApprovedAt:      entity.ApprovedAt,
+		RejectionReason: entity.RejectionReason,
+		ScheduledDate:   entity.ScheduledDate,
+		MoneyAccountID:  entity.MoneyAccountID,
+		ExpenseID:       entity.ExpenseID,
+		PaidAt:          entity.PaidAt,
+		CreatedAt:       entity.CreatedAt,
+		UpdatedAt:       entity.UpdatedAt,
+	}
+}
+
+func toDomainBill(dbBill *models.Bill) (*bill.Bill, error) {
+	status, err := bill.NewStatus(dbBill.Status)
+	if err != nil {
+		return nil, err
+	}
+	code, err := currency.NewCode(dbBill.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+	return &bill.Bill{
+		ID:              dbBill.ID,
+		Number:          dbBill.Number,
+		CounterpartyID:  dbBill.CounterpartyID,
+		CategoryID:      dbBill.CategoryID,
+		Currency:        code,
+		Amount:          dbBill.Amount,
+		BillDate:        dbBill.BillDate,
+		DueDate:         dbBill.DueDate,
+		Description:     dbBill.Description,
+		Status:          status,
+		SubmittedBy:     mapping.Value(dbBill.SubmittedBy),
+		ApprovedBy:      dbBill.ApprovedBy,
+		ApprovedAt:      dbBill.ApprovedAt,
+		RejectionReason: dbBill.RejectionReason,
+		ScheduledDate:   dbBill.ScheduledDate,
+		MoneyAccountID:  dbBill.MoneyAccountID,
+		ExpenseID:       dbBill.ExpenseID,
+		PaidAt:          dbBill.PaidAt,
+		CreatedAt:       dbBill.CreatedAt,
+		UpdatedAt:       dbBill.UpdatedAt,
+	}, nil
+}
+
+func toDBBudget(entity *budget.Budget) *models.Budget {
+	return &models.Budget{
+		ID:          entity.ID,
+		CategoryID:  entity.CategoryID,
+		ProjectID:   entity.ProjectID,
+		Periodicity: string(entity.Periodicity),
+		PeriodStart: entity.PeriodStart,
+		Amount:      entity.Amount,
+		CurrencyID:  string(entity.Currency),
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+func toDomainBudget(dbBudget *models.Budget) (*budget.Budget, error) {
+	periodicity, err := budget.NewPeriodicity(dbBudget.Periodicity)
+	if err != nil {
+		return nil, err
+	}
+	code, err := currency.NewCode(dbBudget.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+	return &budget.Budget{
+		ID:          dbBudget.ID,
+		CategoryID:  dbBudget.CategoryID,
+		ProjectID:   dbBudget.ProjectID,
+		Periodicity: periodicity,
+		PeriodStart: dbBudget.PeriodStart,
+		Amount:      dbBudget.Amount,
+		Currency:    code,
+		CreatedAt:   dbBudget.CreatedAt,
+		UpdatedAt:   dbBudget.UpdatedAt,
+	}, nil
+}
+
+func toDomainBudgetActual(dbActual *models.BudgetActual) (*budget.Actual, error) {
+	code, err := currency.NewCode(dbActual.CurrencyID)
+	if err != nil {
+		return nil, err
+	}
+	return &budget.Actual{
+		Currency: code,
+		Amount:   dbActual.Amount,
+	}, nil
+}
+
+func toDBRecurringTransaction(entity *recurring.Template) *models.RecurringTransaction {
+	var createdBy *uint
+	if entity.CreatedBy != 0 {
+		createdBy = &entity.CreatedBy
+	}
+	return &models.RecurringTransaction{
+		ID:             entity.ID,
+		Kind:           string(entity.Kind),
+		Name:           entity.Name,
+		Schedule:       entity.Schedule.String(),
+		Amount:         entity.Amount,
+		AccountID:      entity.AccountID,
+		CategoryID:     entity.CategoryID,
+		CounterpartyID: entity.CounterpartyID,
+		Comment:        entity.Comment,
+		StartDate:      entity.StartDate,
+		EndDate:        entity.EndDate,
+		LastRunDate:    entity.LastRunDate,
+		NextRunDate:    entity.NextRunDate,
+		CreatedBy:      createdBy,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+	}
+}
+
+func toDomainRecurringTransaction(dbTemplate *models.RecurringTransaction) (*recurring.Template, error) {
+	kind, err := recurring.NewKind(dbTemplate.Kind)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := recurring.ParseSchedule(dbTemplate.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	return &recurring.Template{
+		ID:             dbTemplate.ID,
+		Kind:           kind,
+		Name:           dbTemplate.Name,
+		Schedule:       schedule,
+		Amount:         dbTemplate.Amount,
+		AccountID:      dbTemplate.AccountID,
+		CategoryID:     dbTemplate.CategoryID,
+		CounterpartyID: dbTemplate.CounterpartyID,
+		Comment:        dbTemplate.Comment,
+		StartDate:      dbTemplate.StartDate,
+		EndDate:        dbTemplate.EndDate,
+		LastRunDate:    dbTemplate.LastRunDate,
+		NextRunDate:    dbTemplate.NextRunDate,
+		CreatedBy:      mapping.Value(dbTemplate.CreatedBy),
+		CreatedAt:      dbTemplate.CreatedAt,
+		UpdatedAt:      dbTemplate.UpdatedAt,
+	}, nil
+}
+
+func toDBRecurringOccurrence(entity *recurring.Occurrence) *models.RecurringOccurrence {
+	return &models.RecurringOccurrence{
+		ID:             entity.ID,
+		TemplateID:     entity.TemplateID,
+		OccurrenceDate: entity.Date,
+		PaymentID:      entity.PaymentID,
+		ExpenseID:      entity.ExpenseID,
+		CreatedAt:      entity.CreatedAt,
+	}
+}
+
+func toDomainRecurringOccurrence(dbOccurrence *models.RecurringOccurrence) (*recurring.Occurrence, error) {
+	return &recurring.Occurrence{
+		ID:         dbOccurrence.ID,
+		TemplateID: dbOccurrence.TemplateID,
+		Date:       dbOccurrence.OccurrenceDate,
+		PaymentID:  dbOccurrence.PaymentID,
+		ExpenseID:  dbOccurrence.ExpenseID,
+		CreatedAt:  dbOccurrence.CreatedAt,
+	}, nil
+}
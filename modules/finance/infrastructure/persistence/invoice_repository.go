@@ -0,0 +1,463 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrInvoiceNotFound    = errors.New("invoice not found")
+	ErrAllocationNotFound = errors.New("invoice allocation not found")
+)
+
+const (
+	invoiceFindQuery = `
+		SELECT i.id,
+			i.number,
+			i.counterparty_id,
+			i.currency_id,
+			i.issue_date,
+			i.due_date,
+			i.status,
+			i.comment,
+			COALESCE((SELECT SUM(a.amount) FROM invoice_allocations a WHERE a.invoice_id = i.id), 0),
+			i.sent_at,
+			i.created_at,
+			i.updated_at
+		FROM invoices i`
+	invoiceCountQuery  = `SELECT COUNT(*) as count FROM invoices i`
+	invoiceInsertQuery = `
+		INSERT INTO invoices (
+			counterparty_id,
+			currency_id,
+			issue_date,
+			due_date,
+			status,
+			comment,
+			sent_at,
+			created_at,
+			updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	invoiceSetNumberQuery = `UPDATE invoices SET number = $1 WHERE id = $2`
+	invoiceUpdateQuery    = `
+		UPDATE invoices
+		SET counterparty_id = $1,
+			currency_id = $2,
+			issue_date = $3,
+			due_date = $4,
+			status = $5,
+			comment = $6,
+			sent_at = $7,
+			updated_at = $8
+		WHERE id = $9`
+	invoiceDeleteQuery    = `DELETE FROM invoices WHERE id = $1`
+	invoiceLinesFindQuery = `
+		SELECT id, invoice_id, description, quantity, unit_price, tax_rate
+		FROM invoice_lines`
+	invoiceLineInsertQuery = `
+		INSERT INTO invoice_lines (invoice_id, description, quantity, unit_price, tax_rate)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	invoiceLinesDeleteQuery    = `DELETE FROM invoice_lines WHERE invoice_id = $1`
+	invoiceAllocationFindQuery = `
+		SELECT id, invoice_id, payment_id, amount, allocation_date, created_at
+		FROM invoice_allocations`
+	invoiceAllocationInsertQuery = `
+		INSERT INTO invoice_allocations (invoice_id, payment_id, amount, allocation_date, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	invoiceAllocationDeleteQuery = `DELETE FROM invoice_allocations WHERE id = $1`
+	invoicePaymentBalanceQuery   = `
+		SELECT p.id,
+			p.counterparty_id,
+			t.transaction_date,
+			COALESCE(t.destination_amount, t.amount),
+			ma.balance_currency_id,
+			COALESCE(SUM(a.amount), 0)
+		FROM payments p
+			JOIN transactions t ON t.id = p.transaction_id
+			JOIN money_accounts ma ON ma.id = t.destination_account_id
+			LEFT JOIN invoice_allocations a ON a.payment_id = p.id`
+	invoicePaymentBalanceGroupBy = `GROUP BY p.id, p.counterparty_id, t.transaction_date, t.destination_amount, t.amount, ma.balance_currency_id`
+)
+
+type GormInvoiceRepository struct{}
+
+func NewInvoiceRepository() invoice.Repository {
+	return &GormInvoiceRepository{}
+}
+
+func invoiceWhere(params *invoice.FindParams) ([]string, []interface{}) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if params.CounterpartyID != 0 {
+		args = append(args, params.CounterpartyID)
+		where = append(where, fmt.Sprintf("i.counterparty_id = $%d", len(args)))
+	}
+	switch params.Status {
+	case "":
+	case invoice.Overdue:
+		where = append(where, "i.status IN ('SENT', 'PARTIALLY_PAID') AND i.due_date < CURRENT_DATE")
+	default:
+		args = append(args, string(params.Status))
+		where = append(where, fmt.Sprintf("i.status = $%d", len(args)))
+	}
+	return where, args
+}
+
+func (g *GormInvoiceRepository) Count(ctx context.Context, params *invoice.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := invoiceWhere(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(invoiceCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormInvoiceRepository) GetPaginated(ctx context.Context, params *invoice.FindParams) ([]*invoice.Invoice, error) {
+	sortFields := []string{}
+	for _, f := range params.SortBy {
+		switch f {
+		case "issue_date", "due_date", "created_at", "id":
+			sortFields = append(sortFields, "i."+f)
+		default:
+			return nil, fmt.Errorf("unknown sort field: %s", f)
+		}
+	}
+	if len(sortFields) == 0 {
+		sortFields = append(sortFields, "i.id")
+	}
+	where, args := invoiceWhere(params)
+	q := repo.Join(
+		invoiceFindQuery,
+		repo.JoinWhere(where...),
+		repo.OrderBy(sortFields, false),
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryInvoices(ctx, q, args...)
+}
+
+func (g *GormInvoiceRepository) GetByID(ctx context.Context, id uint) (*invoice.Invoice, error) {
+	invoices, err := g.queryInvoices(ctx, repo.Join(invoiceFindQuery, "WHERE i.id = $1"), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get invoice")
+	}
+	if len(invoices) == 0 {
+		return nil, ErrInvoiceNotFound
+	}
+	return invoices[0], nil
+}
+
+func (g *GormInvoiceRepository) GetOutstanding(ctx context.Context) ([]*invoice.Invoice, error) {
+	return g.queryInvoices(
+		ctx,
+		repo.Join(invoiceFindQuery, "WHERE i.status IN ('SENT', 'PARTIALLY_PAID')", "ORDER BY i.due_date, i.id"),
+	)
+}
+
+func (g *GormInvoiceRepository) Create(ctx context.Context, data *invoice.Invoice) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbInvoice, _ := toDBInvoice(data)
+	if err := tx.QueryRow(
+		ctx,
+		invoiceInsertQuery,
+		dbInvoice.CounterpartyID,
+		dbInvoice.CurrencyID,
+		dbInvoice.IssueDate,
+		dbInvoice.DueDate,
+		dbInvoice.Status,
+		dbInvoice.Comment,
+		dbInvoice.SentAt,
+		dbInvoice.CreatedAt,
+		dbInvoice.UpdatedAt,
+	).Scan(&data.ID); err != nil {
+		return errors.Wrap(err, "failed to create invoice")
+	}
+	data.Number = invoice.NumberFor(data.ID)
+	if _, err := tx.Exec(ctx, invoiceSetNumberQuery, data.Number, data.ID); err != nil {
+		return errors.Wrap(err, "failed to number invoice")
+	}
+	return g.createLines(ctx, data)
+}
+
+func (g *GormInvoiceRepository) Update(ctx context.Context, data *invoice.Invoice) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbInvoice, _ := toDBInvoice(data)
+	if _, err := tx.Exec(
+		ctx,
+		invoiceUpdateQuery,
+		dbInvoice.CounterpartyID,
+		dbInvoice.CurrencyID,
+		dbInvoice.IssueDate,
+		dbInvoice.DueDate,
+		dbInvoice.Status,
+		dbInvoice.Comment,
+		dbInvoice.SentAt,
+		dbInvoice.UpdatedAt,
+		dbInvoice.ID,
+	); err != nil {
+		return errors.Wrap(err, "failed to update invoice")
+	}
+	if _, err := tx.Exec(ctx, invoiceLinesDeleteQuery, data.ID); err != nil {
+		return errors.Wrap(err, "failed to delete invoice lines")
+	}
+	return g.createLines(ctx, data)
+}
+
+func (g *GormInvoiceRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, invoiceDeleteQuery, id); err != nil {
+		return errors.Wrap(err, "failed to delete invoice")
+	}
+	return nil
+}
+
+func (g *GormInvoiceRepository) GetAllocations(
+	ctx context.Context, params *invoice.AllocationFindParams,
+) ([]*invoice.Allocation, error) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if params.InvoiceID != 0 {
+		args = append(args, params.InvoiceID)
+		where = append(where, fmt.Sprintf("invoice_id = $%d", len(args)))
+	}
+	if params.PaymentID != 0 {
+		args = append(args, params.PaymentID)
+		where = append(where, fmt.Sprintf("payment_id = $%d", len(args)))
+	}
+	q := repo.Join(invoiceAllocationFindQuery, repo.JoinWhere(where...), "ORDER BY allocation_date, id")
+	return g.queryAllocations(ctx, q, args...)
+}
+
+func (g *GormInvoiceRepository) GetAllocationByID(ctx context.Context, id uint) (*invoice.Allocation, error) {
+	allocations, err := g.queryAllocations(ctx, repo.Join(invoiceAllocationFindQuery, "WHERE id = $1"), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get invoice allocation")
+	}
+	if len(allocations) == 0 {
+		return nil, ErrAllocationNotFound
+	}
+	return allocations[0], nil
+}
+
+func (g *GormInvoiceRepository) CreateAllocation(ctx context.Context, data *invoice.Allocation) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbAllocation := toDBInvoiceAllocation(data)
+	if err := tx.QueryRow(
+		ctx,
+		invoiceAllocationInsertQuery,
+		dbAllocation.InvoiceID,
+		dbAllocation.PaymentID,
+		dbAllocation.Amount,
+		dbAllocation.AllocationDate,
+		dbAllocation.CreatedAt,
+	).Scan(&data.ID); err != nil {
+		return errors.Wrap(err, "failed to create invoice allocation")
+	}
+	return nil
+}
+
+func (g *GormInvoiceRepository) DeleteAllocation(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, invoiceAllocationDeleteQuery, id); err != nil {
+		return errors.Wrap(err, "failed to delete invoice allocation")
+	}
+	return nil
+}
+
+func (g *GormInvoiceRepository) GetPaymentBalances(
+	ctx context.Context, counterpartyID uint,
+) ([]*invoice.PaymentBalance, error) {
+	q := repo.Join(
+		invoicePaymentBalanceQuery,
+		"WHERE p.counterparty_id = $1",
+		invoicePaymentBalanceGroupBy,
+		"ORDER BY t.transaction_date, p.id",
+	)
+	return g.queryPaymentBalances(ctx, q, counterpartyID)
+}
+
+func (g *GormInvoiceRepository) GetPaymentBalance(ctx context.Context, paymentID uint) (*invoice.PaymentBalance, error) {
+	q := repo.Join(invoicePaymentBalanceQuery, "WHERE p.id = $1", invoicePaymentBalanceGroupBy)
+	balances, err := g.queryPaymentBalances(ctx, q, paymentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get payment balance")
+	}
+	if len(balances) == 0 {
+		return nil, ErrPaymentNotFound
+	}
+	return balances[0], nil
+}
+
+func (g *GormInvoiceRepository) createLines(ctx context.Context, data *invoice.Invoice) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, dbLines := toDBInvoice(data)
+	for i, dbLine := range dbLines {
+		if err := tx.QueryRow(
+			ctx,
+			invoiceLineInsertQuery,
+			data.ID,
+			dbLine.Description,
+			dbLine.Quantity,
+			dbLine.UnitPrice,
+			dbLine.TaxRate,
+		).Scan(&data.Lines[i].ID); err != nil {
+			return errors.Wrap(err, "failed to create invoice line")
+		}
+	}
+	return nil
+}
+
+func (g *GormInvoiceRepository) queryInvoices(
+	ctx context.Context, query string, args ...interface{},
+) ([]*invoice.Invoice, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbInvoices []*models.Invoice
+	for rows.Next() {
+		i := &models.Invoice{}
+		if err := rows.Scan(
+			&i.ID,
+			&i.Number,
+			&i.CounterpartyID,
+			&i.CurrencyID,
+			&i.IssueDate,
+			&i.DueDate,
+			&i.Status,
+			&i.Comment,
+			&i.PaidAmount,
+			&i.SentAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbInvoices = append(dbInvoices, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	invoices := make([]*invoice.Invoice, 0, len(dbInvoices))
+	for _, dbInvoice := range dbInvoices {
+		dbLines, err := g.queryLines(ctx, dbInvoice.ID)
+		if err != nil {
+			return nil, err
+		}
+		entity, err := toDomainInvoice(dbInvoice, dbLines)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, entity)
+	}
+	return invoices, nil
+}
+
+func (g *GormInvoiceRepository) queryLines(ctx context.Context, invoiceID uint) ([]*models.InvoiceLine, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, repo.Join(invoiceLinesFindQuery, "WHERE invoice_id = $1", "ORDER BY id"), invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbLines []*models.InvoiceLine
+	for rows.Next() {
+		l := &models.InvoiceLine{}
+		if err := rows.Scan(&l.ID, &l.InvoiceID, &l.Description, &l.Quantity, &l.UnitPrice, &l.TaxRate); err != nil {
+			return nil, err
+		}
+		dbLines = append(dbLines, l)
+	}
+	return dbLines, rows.Err()
+}
+
+func (g *GormInvoiceRepository) queryAllocations(
+	ctx context.Context, query string, args ...interface{},
+) ([]*invoice.Allocation, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbAllocations []*models.InvoiceAllocation
+	for rows.Next() {
+		a := &models.InvoiceAllocation{}
+		if err := rows.Scan(&a.ID, &a.InvoiceID, &a.PaymentID, &a.Amount, &a.AllocationDate, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		dbAllocations = append(dbAllocations, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbAllocations, toDomainInvoiceAllocation)
+}
+
+func (g *GormInvoiceRepository) queryPaymentBalances(
+	ctx context.Context, query string, args ...interface{},
+) ([]*invoice.PaymentBalance, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbBalances []*models.InvoicePaymentBalance
+	for rows.Next() {
+		b := &models.InvoicePaymentBalance{}
+		if err := rows.Scan(&b.PaymentID, &b.CounterpartyID, &b.TransactionDate, &b.Amount, &b.CurrencyID, &b.Allocated); err != nil {
+			return nil, err
+		}
+		dbBalances = append(dbBalances, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbBalances, toDomainInvoicePaymentBalance)
+}
@@ -0,0 +1,273 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrJournalEntryNotFound = errors.New("journal entry not found")
+)
+
+const (
+	journalEntryFindQuery = `
+		SELECT je.id,
+			je.entry_date,
+			je.accounting_period,
+			je.description,
+			je.source_type,
+			je.source_id,
+			je.created_at
+		FROM journal_entries je`
+	journalEntryCountQuery  = `SELECT COUNT(*) as count FROM journal_entries`
+	journalEntryInsertQuery = `
+		INSERT INTO journal_entries (
+			entry_date,
+			accounting_period,
+			description,
+			source_type,
+			source_id,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	journalLineFindQuery = `
+		SELECT id, entry_id, account_id, debit, credit, comment
+		FROM journal_lines
+		WHERE entry_id = ANY($1)
+		ORDER BY id`
+	journalLineInsertQuery = `
+		INSERT INTO journal_lines (
+			entry_id,
+			account_id,
+			debit,
+			credit,
+			comment
+		)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	journalTurnoversQuery = `
+		SELECT jl.account_id, COALESCE(SUM(jl.debit), 0), COALESCE(SUM(jl.credit), 0)
+		FROM journal_lines jl JOIN journal_entries je ON je.id = jl.entry_id
+		WHERE je.entry_date BETWEEN $1 AND $2
+		GROUP BY jl.account_id`
+	journalEntryDeleteQuery         = `DELETE FROM journal_entries WHERE id = $1`
+	journalEntryDeleteBySourceQuery = `DELETE FROM journal_entries WHERE source_type = $1 AND source_id = $2`
+)
+
+type GormJournalEntryRepository struct{}
+
+func NewJournalEntryRepository() journalentry.Repository {
+	return &GormJournalEntryRepository{}
+}
+
+func (g *GormJournalEntryRepository) GetPaginated(ctx context.Context, params *journalentry.FindParams) ([]*journalentry.Entry, error) {
+	var args []interface{}
+	where := []string{"1 = 1"}
+	if !params.Date.From.IsZero() && !params.Date.To.IsZero() {
+		args = append(args, params.Date.From, params.Date.To)
+		where = append(where, fmt.Sprintf("je.entry_date BETWEEN $%d AND $%d", len(args)-1, len(args)))
+	}
+	if params.AccountID != 0 {
+		args = append(args, params.AccountID)
+		where = append(where, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM journal_lines jl WHERE jl.entry_id = je.id AND jl.account_id = $%d)", len(args),
+		))
+	}
+	q := repo.Join(
+		journalEntryFindQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY je.entry_date DESC, je.id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryEntries(ctx, q, args...)
+}
+
+func (g *GormJournalEntryRepository) Count(ctx context.Context) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, journalEntryCountQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormJournalEntryRepository) GetByID(ctx context.Context, id uint) (*journalentry.Entry, error) {
+	entries, err := g.queryEntries(ctx, repo.Join(journalEntryFindQuery, "WHERE je.id = $1"), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get journal entry by id")
+	}
+	if len(entries) == 0 {
+		return nil, ErrJournalEntryNotFound
+	}
+	return entries[0], nil
+}
+
+func (g *GormJournalEntryRepository) GetBySource(
+	ctx context.Context, sourceType journalentry.SourceType, sourceID uint,
+) (*journalentry.Entry, error) {
+	entries, err := g.queryEntries(
+		ctx,
+		repo.Join(journalEntryFindQuery, "WHERE je.source_type = $1 AND je.source_id = $2"),
+		sourceType,
+		sourceID,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get journal entry by source")
+	}
+	if len(entries) == 0 {
+		return nil, ErrJournalEntryNotFound
+	}
+	return entries[0], nil
+}
+
+func (g *GormJournalEntryRepository) Turnovers(ctx context.Context, from, to time.Time) ([]*journalentry.AccountTurnover, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, journalTurnoversQuery, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var turnovers []*journalentry.AccountTurnover
+	for rows.Next() {
+		t := &journalentry.AccountTurnover{}
+		if err := rows.Scan(&t.AccountID, &t.Debit, &t.Credit); err != nil {
+			return nil, err
+		}
+		turnovers = append(turnovers, t)
+	}
+	return turnovers, rows.Err()
+}
+
+func (g *GormJournalEntryRepository) Create(ctx context.Context, data *journalentry.Entry) error {
+	if err := data.Validate(); err != nil {
+		return err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbEntry, dbLines := toDBJournalEntry(data)
+	if err := tx.QueryRow(
+		ctx,
+		journalEntryInsertQuery,
+		dbEntry.EntryDate,
+		dbEntry.AccountingPeriod,
+		dbEntry.Description,
+		dbEntry.SourceType,
+		dbEntry.SourceID,
+		dbEntry.CreatedAt,
+	).Scan(&data.ID); err != nil {
+		return errors.Wrap(err, "failed to create journal entry")
+	}
+	for i, line := range dbLines {
+		if err := tx.QueryRow(
+			ctx,
+			journalLineInsertQuery,
+			data.ID,
+			line.AccountID,
+			line.Debit,
+			line.Credit,
+			line.Comment,
+		).Scan(&data.Lines[i].ID); err != nil {
+			return errors.Wrap(err, "failed to create journal line")
+		}
+	}
+	return nil
+}
+
+func (g *GormJournalEntryRepository) Delete(ctx context.Context, id uint) error {
+	return g.execQuery(ctx, journalEntryDeleteQuery, id)
+}
+
+func (g *GormJournalEntryRepository) DeleteBySource(
+	ctx context.Context, sourceType journalentry.SourceType, sourceID uint,
+) error {
+	return g.execQuery(ctx, journalEntryDeleteBySourceQuery, sourceType, sourceID)
+}
+
+func (g *GormJournalEntryRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]*journalentry.Entry, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var dbEntries []*models.JournalEntry
+	for rows.Next() {
+		r := &models.JournalEntry{}
+		if err := rows.Scan(
+			&r.ID,
+			&r.EntryDate,
+			&r.AccountingPeriod,
+			&r.Description,
+			&r.SourceType,
+			&r.SourceID,
+			&r.CreatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		dbEntries = append(dbEntries, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(dbEntries) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, 0, len(dbEntries))
+	for _, e := range dbEntries {
+		ids = append(ids, e.ID)
+	}
+	lineRows, err := tx.Query(ctx, journalLineFindQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer lineRows.Close()
+	linesByEntry := make(map[uint][]*models.JournalLine, len(dbEntries))
+	for lineRows.Next() {
+		l := &models.JournalLine{}
+		if err := lineRows.Scan(&l.ID, &l.EntryID, &l.AccountID, &l.Debit, &l.Credit, &l.Comment); err != nil {
+			return nil, err
+		}
+		linesByEntry[l.EntryID] = append(linesByEntry[l.EntryID], l)
+	}
+	if err := lineRows.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*journalentry.Entry, 0, len(dbEntries))
+	for _, e := range dbEntries {
+		entity, err := toDomainJournalEntry(e, linesByEntry[e.ID])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entity)
+	}
+	return entries, nil
+}
+
+func (g *GormJournalEntryRepository) execQuery(ctx context.Context, query string, args ...interface{}) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
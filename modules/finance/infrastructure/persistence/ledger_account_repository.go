@@ -0,0 +1,189 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-faster/errors"
+	ledgeraccount "github.com/iota-uz/iota-sdk/modules/finance/domain/entities/ledger_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrLedgerAccountNotFound = errors.New("ledger account not found")
+)
+
+const (
+	ledgerAccountFindQuery = `
+		SELECT id,
+			code,
+			name,
+			type,
+			money_account_id,
+			expense_category_id,
+			created_at,
+			updated_at
+		FROM ledger_accounts`
+	ledgerAccountCountQuery  = `SELECT COUNT(*) as count FROM ledger_accounts`
+	ledgerAccountInsertQuery = `
+		INSERT INTO ledger_accounts (
+			code,
+			name,
+			type,
+			money_account_id,
+			expense_category_id,
+			created_at,
+			updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	ledgerAccountUpdateQuery = `
+		UPDATE ledger_accounts
+		SET code = $1, name = $2, updated_at = $3
+		WHERE id = $4`
+	ledgerAccountDeleteQuery = `DELETE FROM ledger_accounts WHERE id = $1`
+)
+
+type GormLedgerAccountRepository struct{}
+
+func NewLedgerAccountRepository() ledgeraccount.Repository {
+	return &GormLedgerAccountRepository{}
+}
+
+func (g *GormLedgerAccountRepository) GetPaginated(ctx context.Context, params *ledgeraccount.FindParams) ([]*ledgeraccount.Account, error) {
+	var args []interface{}
+	where := []string{"1 = 1"}
+	if params.Type != "" {
+		args = append(args, params.Type)
+		where = append(where, fmt.Sprintf("type = $%d", len(args)))
+	}
+	q := repo.Join(
+		ledgerAccountFindQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY code",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryAccounts(ctx, q, args...)
+}
+
+func (g *GormLedgerAccountRepository) Count(ctx context.Context) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, ledgerAccountCountQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormLedgerAccountRepository) GetAll(ctx context.Context) ([]*ledgeraccount.Account, error) {
+	return g.queryAccounts(ctx, repo.Join(ledgerAccountFindQuery, "ORDER BY code"))
+}
+
+func (g *GormLedgerAccountRepository) GetByID(ctx context.Context, id uint) (*ledgeraccount.Account, error) {
+	return g.getOne(ctx, "WHERE id = $1", id)
+}
+
+func (g *GormLedgerAccountRepository) GetByCode(ctx context.Context, code string) (*ledgeraccount.Account, error) {
+	return g.getOne(ctx, "WHERE code = $1", code)
+}
+
+func (g *GormLedgerAccountRepository) GetByMoneyAccountID(ctx context.Context, id uint) (*ledgeraccount.Account, error) {
+	return g.getOne(ctx, "WHERE money_account_id = $1", id)
+}
+
+func (g *GormLedgerAccountRepository) GetByExpenseCategoryID(ctx context.Context, id uint) (*ledgeraccount.Account, error) {
+	return g.getOne(ctx, "WHERE expense_category_id = $1", id)
+}
+
+func (g *GormLedgerAccountRepository) Create(ctx context.Context, data *ledgeraccount.Account) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbAccount := toDBLedgerAccount(data)
+	return tx.QueryRow(
+		ctx,
+		ledgerAccountInsertQuery,
+		dbAccount.Code,
+		dbAccount.Name,
+		dbAccount.Type,
+		dbAccount.MoneyAccountID,
+		dbAccount.ExpenseCategoryID,
+		dbAccount.CreatedAt,
+		dbAccount.UpdatedAt,
+	).Scan(&data.ID)
+}
+
+func (g *GormLedgerAccountRepository) Update(ctx context.Context, data *ledgeraccount.Account) error {
+	dbAccount := toDBLedgerAccount(data)
+	return g.execQuery(
+		ctx,
+		ledgerAccountUpdateQuery,
+		dbAccount.Code,
+		dbAccount.Name,
+		dbAccount.UpdatedAt,
+		dbAccount.ID,
+	)
+}
+
+func (g *GormLedgerAccountRepository) Delete(ctx context.Context, id uint) error {
+	return g.execQuery(ctx, ledgerAccountDeleteQuery, id)
+}
+
+func (g *GormLedgerAccountRepository) getOne(ctx context.Context, where string, args ...interface{}) (*ledgeraccount.Account, error) {
+	accounts, err := g.queryAccounts(ctx, repo.Join(ledgerAccountFindQuery, where), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ledger account")
+	}
+	if len(accounts) == 0 {
+		return nil, ErrLedgerAccountNotFound
+	}
+	return accounts[0], nil
+}
+
+func (g *GormLedgerAccountRepository) queryAccounts(ctx context.Context, query string, args ...interface{}) ([]*ledgeraccount.Account, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbRows []*models.LedgerAccount
+	for rows.Next() {
+		r := &models.LedgerAccount{}
+		if err := rows.Scan(
+			&r.ID,
+			&r.Code,
+			&r.Name,
+			&r.Type,
+			&r.MoneyAccountID,
+			&r.ExpenseCategoryID,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbRows = append(dbRows, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbRows, toDomainLedgerAccount)
+}
+
+func (g *GormLedgerAccountRepository) execQuery(ctx context.Context, query string, args ...interface{}) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
@@ -31,12 +31,15 @@ type MoneyAccount struct {
 type Transaction struct {
 	ID                   uint
 	Amount               float64
+	DestinationAmount    *float64
+	ExchangeRate         *float64
 	OriginAccountID      *uint
 	DestinationAccountID *uint
 	TransactionDate      time.Time
 	AccountingPeriod     time.Time
 	TransactionType      string
 	Comment              string
+	ReconciledAt         *time.Time
 	CreatedAt            time.Time
 }
 
@@ -44,6 +47,7 @@ type Expense struct {
 	ID            uint
 	TransactionID uint
 	CategoryID    uint
+	ProjectID     *uint
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -66,3 +70,190 @@ type Counterparty struct {
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
+
+type LedgerAccount struct {
+	ID                uint
+	Code              string
+	Name              string
+	Type              string
+	MoneyAccountID    *uint
+	ExpenseCategoryID *uint
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type JournalEntry struct {
+	ID               uint
+	EntryDate        time.Time
+	AccountingPeriod time.Time
+	Description      string
+	SourceType       string
+	SourceID         *uint
+	CreatedAt        time.Time
+}
+
+type JournalLine struct {
+	ID        uint
+	EntryID   uint
+	AccountID uint
+	Debit     float64
+	Credit    float64
+	Comment   string
+}
+
+type AccountingPeriod struct {
+	ID        uint
+	StartDate time.Time
+	Status    string
+	ClosedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type AccountingPeriodBalance struct {
+	PeriodID       uint
+	MoneyAccountID uint
+	Balance        float64
+	CurrencyID     string
+}
+
+type BankStatement struct {
+	ID             uint
+	MoneyAccountID uint
+	Format         string
+	FileName       string
+	CreatedAt      time.Time
+}
+
+type BankStatementLine struct {
+	ID               uint
+	StatementID      uint
+	MoneyAccountID   uint
+	LineDate         time.Time
+	Amount           float64
+	Currency         string
+	Description      string
+	CounterpartyName string
+	CounterpartyTIN  string
+	Reference        string
+	Status           string
+	TransactionID    *uint
+	CreatedAt        time.Time
+}
+
+type BankMatchCandidate struct {
+	TransactionID   uint
+	TransactionDate time.Time
+	Amount          float64
+	CounterpartyTIN *string
+}
+
+type Invoice struct {
+	ID             uint
+	Number         *string
+	CounterpartyID uint
+	CurrencyID     string
+	IssueDate      time.Time
+	DueDate        time.Time
+	Status         string
+	Comment        string
+	PaidAmount     float64
+	SentAt         *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type InvoiceLine struct {
+	ID          uint
+	InvoiceID   uint
+	Description string
+	Quantity    float64
+	UnitPrice   float64
+	TaxRate     float64
+}
+
+type InvoiceAllocation struct {
+	ID             uint
+	InvoiceID      uint
+	PaymentID      uint
+	Amount         float64
+	AllocationDate time.Time
+	CreatedAt      time.Time
+}
+
+type InvoicePaymentBalance struct {
+	PaymentID       uint
+	CounterpartyID  uint
+	TransactionDate time.Time
+	Amount          float64
+	CurrencyID      string
+	Allocated       float64
+}
+
+type Bill struct {
+	ID              uint
+	Number          string
+	CounterpartyID  uint
+	CategoryID      uint
+	CurrencyID      string
+	Amount          float64
+	BillDate        time.Time
+	DueDate         time.Time
+	Description     string
+	Status          string
+	SubmittedBy     *uint
+	ApprovedBy      *uint
+	ApprovedAt      *time.Time
+	RejectionReason string
+	ScheduledDate   *time.Time
+	MoneyAccountID  *uint
+	ExpenseID       *uint
+	PaidAt          *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type Budget struct {
+	ID          uint
+	CategoryID  uint
+	ProjectID   *uint
+	Periodicity string
+	PeriodStart time.Time
+	Amount      float64
+	CurrencyID  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type RecurringTransaction struct {
+	ID             uint
+	Kind           string
+	Name           string
+	Schedule       string
+	Amount         float64
+	AccountID      uint
+	CategoryID     *uint
+	CounterpartyID *uint
+	Comment        string
+	StartDate      time.Time
+	EndDate        *time.Time
+	LastRunDate    *time.Time
+	NextRunDate    *time.Time
+	CreatedBy      *uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type RecurringOccurrence struct {
+	ID             uint
+	TemplateID     uint
+	OccurrenceDate time.Time
+	PaymentID      *uint
+	ExpenseID      *uint
+	CreatedAt      time.Time
+}
+
+type BudgetActual struct {
+	CurrencyID string
+	Amount     float64
+}
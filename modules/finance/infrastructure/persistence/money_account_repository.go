@@ -3,6 +3,8 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/go-faster/errors"
 	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
@@ -33,11 +35,23 @@ const (
 			c.created_at,
 			c.updated_at
 		FROM money_accounts ma LEFT JOIN currencies c ON c.code = ma.balance_currency_id`
-	countQuery              = `SELECT COUNT(*) as count FROM money_accounts`
+	countQuery        = `SELECT COUNT(*) as count FROM money_accounts`
+	balanceExpression = `
+		COALESCE(sum(CASE
+			WHEN t.transaction_type != 'TRANSFER' THEN t.amount
+			WHEN t.origin_account_id = $1 THEN -t.amount
+			ELSE COALESCE(t.destination_amount, t.amount) END), 0)`
 	recalculateBalanceQuery = `
 		UPDATE money_accounts
-		SET balance = (SELECT sum(t.amount) FROM transactions t WHERE origin_account_id = $1 OR destination_account_id = $2)
+		SET balance = (
+			SELECT ` + balanceExpression + `
+			FROM transactions t WHERE origin_account_id = $1 OR destination_account_id = $2
+		)
 		WHERE id = $3`
+	balanceAtQuery = `
+		SELECT ` + balanceExpression + `
+		FROM transactions t
+		WHERE (origin_account_id = $1 OR destination_account_id = $1) AND transaction_date <= $2`
 	insertQuery = `
 		INSERT INTO money_accounts (
 			name,
@@ -117,6 +131,18 @@ func (g *GormMoneyAccountRepository) RecalculateBalance(ctx context.Context, id
 	return nil
 }
 
+func (g *GormMoneyAccountRepository) GetBalanceAt(ctx context.Context, id uint, at time.Time) (float64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var balance float64
+	if err := tx.QueryRow(ctx, balanceAtQuery, id, at).Scan(&balance); err != nil {
+		return 0, errors.Wrap(err, "failed to get balance")
+	}
+	return balance, nil
+}
+
 func (g *GormMoneyAccountRepository) Create(ctx context.Context, data *moneyaccount.Account) (*moneyaccount.Account, error) {
 	entity := toDBMoneyAccount(data)
 	tx, err := composables.UseTx(ctx)
@@ -0,0 +1,353 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrRecurringTransactionNotFound = errors.New("recurring transaction not found")
+)
+
+const (
+	recurringFindQuery = `
+		SELECT rt.id,
+			rt.kind,
+			rt.name,
+			rt.schedule,
+			rt.amount,
+			rt.account_id,
+			rt.category_id,
+			rt.counterparty_id,
+			rt.comment,
+			rt.start_date,
+			rt.end_date,
+			rt.last_run_date,
+			rt.next_run_date,
+			rt.created_by,
+			rt.created_at,
+			rt.updated_at
+		FROM recurring_transactions rt`
+	recurringCountQuery  = `SELECT COUNT(*) as count FROM recurring_transactions rt`
+	recurringInsertQuery = `
+		INSERT INTO recurring_transactions (
+			kind, name, schedule, amount, account_id, category_id, counterparty_id, comment,
+			start_date, end_date, last_run_date, next_run_date, created_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id`
+	recurringUpdateQuery = `
+		UPDATE recurring_transactions
+		SET kind = $1,
+			name = $2,
+			schedule = $3,
+			amount = $4,
+			account_id = $5,
+			category_id = $6,
+			counterparty_id = $7,
+			comment = $8,
+			start_date = $9,
+			end_date = $10,
+			last_run_date = $11,
+			next_run_date = $12,
+			updated_at = $13
+		WHERE id = $14`
+	recurringDeleteQuery          = `DELETE FROM recurring_transactions WHERE id = $1`
+	recurringOccurrencesFindQuery = `
+		SELECT ro.id,
+			ro.template_id,
+			ro.occurrence_date,
+			ro.payment_id,
+			ro.expense_id,
+			ro.created_at
+		FROM recurring_occurrences ro`
+	recurringOccurrenceInsertQuery = `
+		INSERT INTO recurring_occurrences (template_id, occurrence_date, payment_id, expense_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (template_id, occurrence_date) DO NOTHING
+		RETURNING id`
+	recurringOccurrenceUpdateQuery = `UPDATE recurring_occurrences SET payment_id = $1, expense_id = $2 WHERE id = $3`
+)
+
+type GormRecurringRepository struct{}
+
+func NewRecurringRepository() recurring.Repository {
+	return &GormRecurringRepository{}
+}
+
+func recurringWhere(params *recurring.FindParams) ([]string, []interface{}) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if params.Kind != "" {
+		args = append(args, string(params.Kind))
+		where = append(where, fmt.Sprintf("rt.kind = $%d", len(args)))
+	}
+	return where, args
+}
+
+func (g *GormRecurringRepository) Count(ctx context.Context, params *recurring.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := recurringWhere(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(recurringCountQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormRecurringRepository) GetPaginated(
+	ctx context.Context, params *recurring.FindParams,
+) ([]*recurring.Template, error) {
+	sortFields := []string{}
+	for _, f := range params.SortBy {
+		switch f {
+		case "name", "next_run_date", "created_at", "id":
+			sortFields = append(sortFields, "rt."+f)
+		default:
+			return nil, fmt.Errorf("unknown sort field: %s", f)
+		}
+	}
+	if len(sortFields) == 0 {
+		sortFields = append(sortFields, "rt.id")
+	}
+	where, args := recurringWhere(params)
+	q := repo.Join(
+		recurringFindQuery,
+		repo.JoinWhere(where...),
+		repo.OrderBy(sortFields, false),
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryTemplates(ctx, q, args...)
+}
+
+func (g *GormRecurringRepository) GetByID(ctx context.Context, id uint) (*recurring.Template, error) {
+	return g.getOne(ctx, repo.Join(recurringFindQuery, "WHERE rt.id = $1"), id)
+}
+
+func (g *GormRecurringRepository) GetForUpdate(ctx context.Context, id uint) (*recurring.Template, error) {
+	return g.getOne(ctx, repo.Join(recurringFindQuery, "WHERE rt.id = $1 FOR UPDATE"), id)
+}
+
+func (g *GormRecurringRepository) GetDue(ctx context.Context, at time.Time) ([]*recurring.Template, error) {
+	q := repo.Join(recurringFindQuery, "WHERE rt.next_run_date <= $1", "ORDER BY rt.next_run_date, rt.id")
+	return g.queryTemplates(ctx, q, at)
+}
+
+func (g *GormRecurringRepository) Create(ctx context.Context, data *recurring.Template) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbTemplate := toDBRecurringTransaction(data)
+	if err := tx.QueryRow(
+		ctx,
+		recurringInsertQuery,
+		dbTemplate.Kind,
+		dbTemplate.Name,
+		dbTemplate.Schedule,
+		dbTemplate.Amount,
+		dbTemplate.AccountID,
+		dbTemplate.CategoryID,
+		dbTemplate.CounterpartyID,
+		dbTemplate.Comment,
+		dbTemplate.StartDate,
+		dbTemplate.EndDate,
+		dbTemplate.LastRunDate,
+		dbTemplate.NextRunDate,
+		dbTemplate.CreatedBy,
+		dbTemplate.CreatedAt,
+		dbTemplate.UpdatedAt,
+	).Scan(&data.ID); err != nil {
+		return errors.Wrap(err, "failed to create recurring transaction")
+	}
+	return nil
+}
+
+func (g *GormRecurringRepository) Update(ctx context.Context, data *recurring.Template) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbTemplate := toDBRecurringTransaction(data)
+	if _, err := tx.Exec(
+		ctx,
+		recurringUpdateQuery,
+		dbTemplate.Kind,
+		dbTemplate.Name,
+		dbTemplate.Schedule,
+		dbTemplate.Amount,
+		dbTemplate.AccountID,
+		dbTemplate.CategoryID,
+		dbTemplate.CounterpartyID,
+		dbTemplate.Comment,
+		dbTemplate.StartDate,
+		dbTemplate.EndDate,
+		dbTemplate.LastRunDate,
+		dbTemplate.NextRunDate,
+		dbTemplate.UpdatedAt,
+		dbTemplate.ID,
+	); err != nil {
+		return errors.Wrap(err, "failed to update recurring transaction")
+	}
+	return nil
+}
+
+func (g *GormRecurringRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, recurringDeleteQuery, id); err != nil {
+		return errors.Wrap(err, "failed to delete recurring transaction")
+	}
+	return nil
+}
+
+func (g *GormRecurringRepository) GetOccurrences(
+	ctx context.Context, templateID uint, limit int,
+) ([]*recurring.Occurrence, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := repo.Join(
+		recurringOccurrencesFindQuery,
+		"WHERE ro.template_id = $1",
+		"ORDER BY ro.occurrence_date DESC",
+		repo.FormatLimitOffset(limit, 0),
+	)
+	rows, err := tx.Query(ctx, q, templateID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get recurring occurrences")
+	}
+	defer rows.Close()
+	var dbOccurrences []*models.RecurringOccurrence
+	for rows.Next() {
+		o := &models.RecurringOccurrence{}
+		if err := rows.Scan(
+			&o.ID,
+			&o.TemplateID,
+			&o.OccurrenceDate,
+			&o.PaymentID,
+			&o.ExpenseID,
+			&o.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbOccurrences = append(dbOccurrences, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbOccurrences, toDomainRecurringOccurrence)
+}
+
+func (g *GormRecurringRepository) CreateOccurrence(ctx context.Context, data *recurring.Occurrence) (bool, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	dbOccurrence := toDBRecurringOccurrence(data)
+	rows, err := tx.Query(
+		ctx,
+		recurringOccurrenceInsertQuery,
+		dbOccurrence.TemplateID,
+		dbOccurrence.OccurrenceDate,
+		dbOccurrence.PaymentID,
+		dbOccurrence.ExpenseID,
+		dbOccurrence.CreatedAt,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create recurring occurrence")
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return false, rows.Err()
+	}
+	if err := rows.Scan(&data.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (g *GormRecurringRepository) UpdateOccurrence(ctx context.Context, data *recurring.Occurrence) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbOccurrence := toDBRecurringOccurrence(data)
+	if _, err := tx.Exec(
+		ctx,
+		recurringOccurrenceUpdateQuery,
+		dbOccurrence.PaymentID,
+		dbOccurrence.ExpenseID,
+		dbOccurrence.ID,
+	); err != nil {
+		return errors.Wrap(err, "failed to update recurring occurrence")
+	}
+	return nil
+}
+
+func (g *GormRecurringRepository) getOne(ctx context.Context, query string, args ...interface{}) (*recurring.Template, error) {
+	templates, err := g.queryTemplates(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get recurring transaction")
+	}
+	if len(templates) == 0 {
+		return nil, ErrRecurringTransactionNotFound
+	}
+	return templates[0], nil
+}
+
+func (g *GormRecurringRepository) queryTemplates(
+	ctx context.Context, query string, args ...interface{},
+) ([]*recurring.Template, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var dbTemplates []*models.RecurringTransaction
+	for rows.Next() {
+		t := &models.RecurringTransaction{}
+		if err := rows.Scan(
+			&t.ID,
+			&t.Kind,
+			&t.Name,
+			&t.Schedule,
+			&t.Amount,
+			&t.AccountID,
+			&t.CategoryID,
+			&t.CounterpartyID,
+			&t.Comment,
+			&t.StartDate,
+			&t.EndDate,
+			&t.LastRunDate,
+			&t.NextRunDate,
+			&t.CreatedBy,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		dbTemplates = append(dbTemplates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return mapping.MapDBModels(dbTemplates, toDomainRecurringTransaction)
+}
@@ -64,12 +64,15 @@ CREATE TABLE transactions
 (
     id                     SERIAL PRIMARY KEY,
     amount                 NUMERIC(9, 2) NOT NULL,
+    destination_amount     NUMERIC(9, 2),           -- amount credited in the destination account currency
+    exchange_rate          NUMERIC(18, 8),          -- origin to destination currency rate
     origin_account_id      INT REFERENCES money_accounts (id) ON DELETE RESTRICT,
     destination_account_id INT REFERENCES money_accounts (id) ON DELETE RESTRICT,
     transaction_date       DATE          NOT NULL   DEFAULT CURRENT_DATE,
     accounting_period      DATE          NOT NULL   DEFAULT CURRENT_DATE,
     transaction_type       VARCHAR(255)  NOT NULL, -- income, expense, transfer
     comment                TEXT,
+    reconciled_at          TIMESTAMP WITH TIME ZONE, -- set once confirmed by a bank statement line
     created_at             TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
@@ -78,6 +81,7 @@ CREATE TABLE expenses
     id             SERIAL PRIMARY KEY,
     transaction_id INT NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
     category_id    INT NOT NULL REFERENCES expense_categories (id) ON DELETE CASCADE,
+    project_id     INT, -- optional core project the expense is charged to
     created_at     TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
     updated_at     TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
@@ -91,8 +95,193 @@ CREATE TABLE payments
     updated_at      TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
+CREATE TABLE ledger_accounts
+(
+    id                  SERIAL PRIMARY KEY,
+    code                VARCHAR(32)  NOT NULL UNIQUE,
+    name                VARCHAR(255) NOT NULL,
+    type                VARCHAR(32)  NOT NULL, -- asset, liability, equity, income, expense
+    money_account_id    INT UNIQUE REFERENCES money_accounts (id) ON DELETE SET NULL,
+    expense_category_id INT UNIQUE REFERENCES expense_categories (id) ON DELETE SET NULL,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE journal_entries
+(
+    id                SERIAL PRIMARY KEY,
+    entry_date        DATE        NOT NULL DEFAULT CURRENT_DATE,
+    accounting_period DATE        NOT NULL DEFAULT CURRENT_DATE,
+    description       TEXT        NOT NULL DEFAULT '',
+    source_type       VARCHAR(32) NOT NULL, -- payment, expense, transaction, manual, invoice
+    source_id         INT,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    UNIQUE (source_type, source_id)
+);
+
+CREATE TABLE journal_lines
+(
+    id         SERIAL PRIMARY KEY,
+    entry_id   INT           NOT NULL REFERENCES journal_entries (id) ON DELETE CASCADE,
+    account_id INT           NOT NULL REFERENCES ledger_accounts (id) ON DELETE RESTRICT,
+    debit      NUMERIC(9, 2) NOT NULL DEFAULT 0,
+    credit     NUMERIC(9, 2) NOT NULL DEFAULT 0,
+    comment    TEXT          NOT NULL DEFAULT '',
+    CHECK (debit >= 0 AND credit >= 0 AND (debit = 0 OR credit = 0))
+);
+
+CREATE TABLE accounting_periods
+(
+    id         SERIAL PRIMARY KEY,
+    start_date DATE        NOT NULL UNIQUE, -- first day of the month
+    status     VARCHAR(16) NOT NULL DEFAULT 'OPEN', -- OPEN, CLOSED, LOCKED
+    closed_at  TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE accounting_period_balances
+(
+    period_id        INT           NOT NULL REFERENCES accounting_periods (id) ON DELETE CASCADE,
+    money_account_id INT           NOT NULL REFERENCES money_accounts (id) ON DELETE CASCADE,
+    balance          NUMERIC(9, 2) NOT NULL,
+    currency_id      VARCHAR(3)    NOT NULL REFERENCES currencies (code) ON DELETE CASCADE,
+    PRIMARY KEY (period_id, money_account_id)
+);
+
+CREATE TABLE bank_statements
+(
+    id               SERIAL PRIMARY KEY,
+    money_account_id INT          NOT NULL REFERENCES money_accounts (id) ON DELETE CASCADE,
+    format           VARCHAR(16)  NOT NULL, -- CSV, MT940, CAMT053
+    file_name        VARCHAR(255) NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE bank_statement_lines
+(
+    id                SERIAL PRIMARY KEY,
+    statement_id      INT           NOT NULL REFERENCES bank_statements (id) ON DELETE CASCADE,
+    money_account_id  INT           NOT NULL REFERENCES money_accounts (id) ON DELETE CASCADE,
+    line_date         DATE          NOT NULL,
+    amount            NUMERIC(9, 2) NOT NULL, -- positive for money received, negative for money paid
+    currency          VARCHAR(3)    NOT NULL DEFAULT '',
+    description       TEXT          NOT NULL DEFAULT '',
+    counterparty_name VARCHAR(255)  NOT NULL DEFAULT '',
+    counterparty_tin  VARCHAR(20)   NOT NULL DEFAULT '',
+    reference         VARCHAR(255)  NOT NULL DEFAULT '',
+    status            VARCHAR(16)   NOT NULL DEFAULT 'UNMATCHED', -- UNMATCHED, MATCHED, RECONCILED, IGNORED
+    transaction_id    INT REFERENCES transactions (id) ON DELETE SET NULL,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE invoices
+(
+    id              SERIAL PRIMARY KEY,
+    number          VARCHAR(32) UNIQUE, -- assigned right after insert
+    counterparty_id INT         NOT NULL REFERENCES counterparty (id) ON DELETE RESTRICT,
+    currency_id     VARCHAR(3)  NOT NULL REFERENCES currencies (code) ON DELETE RESTRICT,
+    issue_date      DATE        NOT NULL,
+    due_date        DATE        NOT NULL,
+    status          VARCHAR(16) NOT NULL DEFAULT 'DRAFT', -- DRAFT, SENT, PARTIALLY_PAID, PAID
+    comment         TEXT        NOT NULL DEFAULT '',
+    sent_at         TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE invoice_lines
+(
+    id          SERIAL PRIMARY KEY,
+    invoice_id  INT            NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    description TEXT           NOT NULL,
+    quantity    NUMERIC(12, 3) NOT NULL,
+    unit_price  NUMERIC(12, 2) NOT NULL,
+    tax_rate    NUMERIC(5, 2)  NOT NULL DEFAULT 0 -- percent
+);
+
+CREATE TABLE invoice_allocations
+(
+    id              SERIAL PRIMARY KEY,
+    invoice_id      INT           NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    payment_id      INT           NOT NULL REFERENCES payments (id) ON DELETE RESTRICT,
+    amount          NUMERIC(9, 2) NOT NULL CHECK (amount > 0),
+    allocation_date DATE          NOT NULL DEFAULT CURRENT_DATE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE bills
+(
+    id               SERIAL PRIMARY KEY,
+    number           VARCHAR(64)   NOT NULL, -- supplier's invoice number
+    counterparty_id  INT           NOT NULL REFERENCES counterparty (id) ON DELETE RESTRICT,
+    category_id      INT           NOT NULL REFERENCES expense_categories (id) ON DELETE RESTRICT,
+    currency_id      VARCHAR(3)    NOT NULL REFERENCES currencies (code) ON DELETE RESTRICT,
+    amount           NUMERIC(9, 2) NOT NULL CHECK (amount > 0),
+    bill_date        DATE          NOT NULL,
+    due_date         DATE          NOT NULL,
+    description      TEXT          NOT NULL DEFAULT '',
+    status           VARCHAR(16)   NOT NULL DEFAULT 'SUBMITTED', -- SUBMITTED, APPROVED, REJECTED, SCHEDULED, PAID
+    submitted_by     INT REFERENCES users (id) ON DELETE SET NULL,
+    approved_by      INT REFERENCES users (id) ON DELETE SET NULL,
+    approved_at      TIMESTAMP WITH TIME ZONE,
+    rejection_reason TEXT          NOT NULL DEFAULT '',
+    scheduled_date   DATE,
+    money_account_id INT REFERENCES money_accounts (id) ON DELETE RESTRICT,
+    expense_id       INT REFERENCES expenses (id) ON DELETE SET NULL,
+    paid_at          TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at       TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    UNIQUE (counterparty_id, number)
+);
+
+CREATE TABLE budgets
+(
+    id           SERIAL PRIMARY KEY,
+    category_id  INT           NOT NULL REFERENCES expense_categories (id) ON DELETE CASCADE,
+    project_id   INT, -- limits the budget to the expenses charged to a core project
+    periodicity  VARCHAR(16)   NOT NULL, -- MONTHLY, QUARTERLY, YEARLY
+    period_start DATE          NOT NULL,
+    amount       NUMERIC(9, 2) NOT NULL CHECK (amount > 0),
+    currency_id  VARCHAR(3)    NOT NULL REFERENCES currencies (code) ON DELETE RESTRICT,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE recurring_transactions
+(
+    id              SERIAL PRIMARY KEY,
+    kind            VARCHAR(16)   NOT NULL, -- PAYMENT, EXPENSE
+    name            VARCHAR(255)  NOT NULL,
+    schedule        VARCHAR(255)  NOT NULL, -- RRULE subset, e.g. FREQ=MONTHLY;BYMONTHDAY=1
+    amount          NUMERIC(9, 2) NOT NULL CHECK (amount > 0),
+    account_id      INT           NOT NULL REFERENCES money_accounts (id) ON DELETE CASCADE,
+    category_id     INT REFERENCES expense_categories (id) ON DELETE CASCADE,
+    counterparty_id INT REFERENCES counterparty (id) ON DELETE CASCADE,
+    comment         TEXT          NOT NULL DEFAULT '',
+    start_date      DATE          NOT NULL,
+    end_date        DATE,
+    last_run_date   DATE,
+    next_run_date   DATE, -- NULL once the schedule is over
+    created_by      INT REFERENCES users (id) ON DELETE SET NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE recurring_occurrences
+(
+    id              SERIAL PRIMARY KEY,
+    template_id     INT  NOT NULL REFERENCES recurring_transactions (id) ON DELETE CASCADE,
+    occurrence_date DATE NOT NULL,
+    payment_id      INT REFERENCES payments (id) ON DELETE SET NULL,
+    expense_id      INT REFERENCES expenses (id) ON DELETE SET NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    UNIQUE (template_id, occurrence_date)
+);
+
 CREATE INDEX expenses_category_id_idx ON expenses (category_id);
 CREATE INDEX expenses_transaction_id_idx ON expenses (transaction_id);
+CREATE INDEX expenses_project_id_idx ON expenses (project_id);
 
 CREATE INDEX payments_counterparty_id_idx ON payments (counterparty_id);
 CREATE INDEX payments_transaction_id_idx ON payments (transaction_id);
@@ -108,7 +297,52 @@ CREATE INDEX inventory_currency_id_idx ON inventory (currency_id);
 
 CREATE INDEX money_accounts_balance_currency_id_idx ON money_accounts (balance_currency_id);
 
+CREATE INDEX bank_statements_money_account_id_idx ON bank_statements (money_account_id);
+CREATE INDEX bank_statement_lines_statement_id_idx ON bank_statement_lines (statement_id);
+CREATE INDEX bank_statement_lines_account_date_idx ON bank_statement_lines (money_account_id, line_date);
+CREATE INDEX bank_statement_lines_transaction_id_idx ON bank_statement_lines (transaction_id);
+
+CREATE INDEX invoices_counterparty_id_idx ON invoices (counterparty_id);
+CREATE INDEX invoices_status_due_date_idx ON invoices (status, due_date);
+CREATE INDEX invoice_lines_invoice_id_idx ON invoice_lines (invoice_id);
+CREATE INDEX invoice_allocations_invoice_id_idx ON invoice_allocations (invoice_id);
+CREATE INDEX invoice_allocations_payment_id_idx ON invoice_allocations (payment_id);
+
+CREATE INDEX bills_counterparty_id_idx ON bills (counterparty_id);
+CREATE INDEX bills_status_due_date_idx ON bills (status, due_date);
+
+CREATE INDEX budgets_category_id_period_start_idx ON budgets (category_id, period_start);
+
+CREATE INDEX recurring_transactions_next_run_date_idx ON recurring_transactions (next_run_date);
+
+CREATE INDEX journal_entries_entry_date_idx ON journal_entries (entry_date);
+CREATE INDEX journal_lines_entry_id_idx ON journal_lines (entry_id);
+CREATE INDEX journal_lines_account_id_idx ON journal_lines (account_id);
+
+INSERT INTO ledger_accounts (code, name, type)
+VALUES ('1200', 'Accounts receivable', 'ASSET'),
+       ('1300', 'Inventory', 'ASSET'),
+       ('2100', 'Accounts payable', 'LIABILITY'),
+       ('2200', 'VAT payable', 'LIABILITY'),
+       ('3000', 'Opening balance equity', 'EQUITY'),
+       ('4000', 'Revenue', 'INCOME'),
+       ('7000', 'Foreign exchange gains and losses', 'INCOME');
+
 -- +migrate Down
+DROP TABLE IF EXISTS recurring_occurrences;
+DROP TABLE IF EXISTS recurring_transactions;
+DROP TABLE IF EXISTS budgets;
+DROP TABLE IF EXISTS bills;
+DROP TABLE IF EXISTS invoice_allocations;
+DROP TABLE IF EXISTS invoice_lines;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS bank_statement_lines;
+DROP TABLE IF EXISTS bank_statements;
+DROP TABLE IF EXISTS accounting_period_balances;
+DROP TABLE IF EXISTS accounting_periods;
+DROP TABLE IF EXISTS journal_lines;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
 DROP TABLE IF EXISTS payments;
 DROP TABLE IF EXISTS expenses;
 DROP TABLE IF EXISTS transactions;
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
 	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
@@ -19,12 +21,15 @@ const (
 	transactionFindQuery = `
 		SELECT id,
 			amount,
+			destination_amount,
+			exchange_rate,
 			origin_account_id,
 			destination_account_id,
 			transaction_date,
 			accounting_period,
 			transaction_type,
 			comment,
+			reconciled_at,
 			created_at
 		FROM transactions`
 	transactionCountQuery  = `SELECT COUNT(*) as count FROM transactions`
@@ -36,9 +41,11 @@ const (
 			transaction_date,
 			accounting_period,
 			transaction_type,
-			comment
+			comment,
+			destination_amount,
+			exchange_rate
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 	transactionUpdateQuery = `
 		UPDATE transactions
 		SET amount = $1,
@@ -47,9 +54,12 @@ const (
 			transaction_date = $4,
 			accounting_period = $5,
 			transaction_type = $6,
-			comment = $7
-		WHERE id = $8`
-	transactionDeleteQuery = `DELETE FROM transactions WHERE id = $1`
+			comment = $7,
+			destination_amount = $8,
+			exchange_rate = $9
+		WHERE id = $10`
+	transactionDeleteQuery        = `DELETE FROM transactions WHERE id = $1`
+	transactionSetReconciledQuery = `UPDATE transactions SET reconciled_at = $1 WHERE id = $2`
 )
 
 type GormTransactionRepository struct{}
@@ -62,9 +72,13 @@ func (g *GormTransactionRepository) GetPaginated(ctx context.Context, params *tr
 	where := []string{"1 = 1"}
 	var args []interface{}
 	if params.CreatedAt.To != "" && params.CreatedAt.From != "" {
-		where = append(where, fmt.Sprintf("created_at BETWEEN $%d and $%d", len(where), len(where)+1))
+		where = append(where, fmt.Sprintf("created_at BETWEEN $%d and $%d", len(args)+1, len(args)+2))
 		args = append(args, params.CreatedAt.From, params.CreatedAt.To)
 	}
+	if params.AccountID != 0 {
+		where = append(where, fmt.Sprintf("(origin_account_id = $%d OR destination_account_id = $%d)", len(args)+1, len(args)+1))
+		args = append(args, params.AccountID)
+	}
 	q := repo.Join(
 		transactionFindQuery,
 		repo.JoinWhere(where...),
@@ -115,6 +129,8 @@ func (g *GormTransactionRepository) Create(ctx context.Context, data *transactio
 		entity.AccountingPeriod,
 		entity.TransactionType,
 		entity.Comment,
+		entity.DestinationAmount,
+		entity.ExchangeRate,
 	}
 	return tx.QueryRow(ctx, transactionInsertQuery, args...).Scan(&data.ID)
 }
@@ -129,6 +145,8 @@ func (g *GormTransactionRepository) Update(ctx context.Context, data *transactio
 		dbTransaction.AccountingPeriod,
 		dbTransaction.TransactionType,
 		dbTransaction.Comment,
+		dbTransaction.DestinationAmount,
+		dbTransaction.ExchangeRate,
 		dbTransaction.ID,
 	}
 	return g.execQuery(ctx, transactionUpdateQuery, args...)
@@ -138,6 +156,10 @@ func (g *GormTransactionRepository) Delete(ctx context.Context, id uint) error {
 	return g.execQuery(ctx, transactionDeleteQuery, id)
 }
 
+func (g *GormTransactionRepository) SetReconciled(ctx context.Context, id uint, reconciledAt *time.Time) error {
+	return g.execQuery(ctx, transactionSetReconciledQuery, reconciledAt, id)
+}
+
 func (g *GormTransactionRepository) queryTransactions(ctx context.Context, query string, args ...interface{}) ([]*transaction.Transaction, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
@@ -155,12 +177,15 @@ func (g *GormTransactionRepository) queryTransactions(ctx context.Context, query
 		if err := rows.Scan(
 			&r.ID,
 			&r.Amount,
+			&r.DestinationAmount,
+			&r.ExchangeRate,
 			&r.OriginAccountID,
 			&r.DestinationAccountID,
 			&r.TransactionDate,
 			&r.AccountingPeriod,
 			&r.TransactionType,
 			&r.Comment,
+			&r.ReconciledAt,
 			&r.CreatedAt,
 		); err != nil {
 			return nil, err
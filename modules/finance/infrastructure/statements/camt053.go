@@ -0,0 +1,125 @@
+package statements
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+)
+
+// camtDocument is the subset of ISO 20022 camt.053 needed for bank lines.
+type camtDocument struct {
+	Statements []struct {
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtParty struct {
+	Name string `xml:"Nm"`
+	// Organisation identifiers, e.g. the TIN, are reported as "other" ids.
+	OrgIDs     []string `xml:"Id>OrgId>Othr>Id"`
+	PrivateIDs []string `xml:"Id>PrvtId>Othr>Id"`
+}
+
+func (p *camtParty) tin() string {
+	for _, ids := range [][]string{p.OrgIDs, p.PrivateIDs} {
+		for _, id := range ids {
+			if id = strings.TrimSpace(id); id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+type camtEntry struct {
+	Amount struct {
+		Value    string `xml:",chardata"`
+		Currency string `xml:"Ccy,attr"`
+	} `xml:"Amt"`
+	CreditDebit string `xml:"CdtDbtInd"`
+	Reversal    bool   `xml:"RvslInd"`
+	BookingDate struct {
+		Date     string `xml:"Dt"`
+		DateTime string `xml:"DtTm"`
+	} `xml:"BookgDt"`
+	ServicerReference string `xml:"AcctSvcrRef"`
+	AdditionalInfo    string `xml:"AddtlNtryInf"`
+	Transactions      []struct {
+		EndToEndID   string    `xml:"Refs>EndToEndId"`
+		Debtor       camtParty `xml:"RltdPties>Dbtr"`
+		Creditor     camtParty `xml:"RltdPties>Cdtr"`
+		Unstructured []string  `xml:"RmtInf>Ustrd"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+// ParseCamt053 reads an ISO 20022 bank-to-customer statement. Namespaces of any
+// camt.053 version are accepted.
+func ParseCamt053(r io.Reader) ([]*bankstatement.Line, error) {
+	var doc camtDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("reading camt.053: %w", err)
+	}
+	var lines []*bankstatement.Line
+	for _, stmt := range doc.Statements {
+		for _, entry := range stmt.Entries {
+			line, err := camtLine(&entry)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+func camtLine(entry *camtEntry) (*bankstatement.Line, error) {
+	amount, err := parseAmount(entry.Amount.Value)
+	if err != nil {
+		return nil, err
+	}
+	debit := entry.CreditDebit == "DBIT"
+	if entry.Reversal {
+		debit = !debit
+	}
+	if debit {
+		amount = -amount
+	}
+	dateValue := entry.BookingDate.Date
+	if dateValue == "" {
+		dateValue = entry.BookingDate.DateTime
+	}
+	date, err := parseDate(dateValue)
+	if err != nil {
+		return nil, err
+	}
+	line := &bankstatement.Line{
+		Date:        date,
+		Amount:      amount,
+		Currency:    entry.Amount.Currency,
+		Description: strings.TrimSpace(entry.AdditionalInfo),
+		Reference:   strings.TrimSpace(entry.ServicerReference),
+	}
+	if len(entry.Transactions) > 0 {
+		tx := entry.Transactions[0]
+		// The counterparty is the debtor of money received and the creditor of money paid.
+		party := tx.Debtor
+		if debit {
+			party = tx.Creditor
+		}
+		line.CounterpartyName = strings.TrimSpace(party.Name)
+		line.CounterpartyTIN = party.tin()
+		if info := strings.TrimSpace(strings.Join(tx.Unstructured, " ")); info != "" {
+			line.Description = info
+		}
+		if line.Reference == "" && tx.EndToEndID != "NOTPROVIDED" {
+			line.Reference = strings.TrimSpace(tx.EndToEndID)
+		}
+	}
+	if line.CounterpartyTIN == "" {
+		line.CounterpartyTIN = findTIN(line.Description)
+	}
+	return line, nil
+}
@@ -0,0 +1,153 @@
+package statements
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+)
+
+// csvColumns maps the lower-cased header names banks use to the fields of a line.
+var csvColumns = map[string]string{
+	"date":               "date",
+	"booking date":       "date",
+	"value date":         "date",
+	"дата":               "date",
+	"sana":               "date",
+	"amount":             "amount",
+	"сумма":              "amount",
+	"summa":              "amount",
+	"credit":             "credit",
+	"приход":             "credit",
+	"кредит":             "credit",
+	"kirim":              "credit",
+	"debit":              "debit",
+	"расход":             "debit",
+	"дебет":              "debit",
+	"chiqim":             "debit",
+	"currency":           "currency",
+	"валюта":             "currency",
+	"description":        "description",
+	"details":            "description",
+	"purpose":            "description",
+	"назначение":         "description",
+	"назначение платежа": "description",
+	"maqsad":             "description",
+	"counterparty":       "counterparty",
+	"name":               "counterparty",
+	"контрагент":         "counterparty",
+	"kontragent":         "counterparty",
+	"tin":                "tin",
+	"inn":                "tin",
+	"инн":                "tin",
+	"stir":               "tin",
+	"reference":          "reference",
+	"document":           "reference",
+	"номер документа":    "reference",
+	"hujjat raqami":      "reference",
+}
+
+// ParseCSV reads a statement with a header row. Either a signed "amount" column or separate
+// "credit" and "debit" columns are required; the delimiter may be a comma or a semicolon.
+func ParseCSV(r io.Reader) ([]*bankstatement.Line, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimPrefix(string(content), "\ufeff")
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := strings.Cut(text, "\n"); strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		if field, ok := csvColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			if _, exists := index[field]; !exists {
+				index[field] = i
+			}
+		}
+	}
+	if _, ok := index["date"]; !ok {
+		return nil, errors.New("csv statement has no date column")
+	}
+	_, hasAmount := index["amount"]
+	_, hasCredit := index["credit"]
+	_, hasDebit := index["debit"]
+	if !hasAmount && !hasCredit && !hasDebit {
+		return nil, errors.New("csv statement has no amount column")
+	}
+
+	var lines []*bankstatement.Line
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		get := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+		line, err := csvLine(get)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+func csvLine(get func(field string) string) (*bankstatement.Line, error) {
+	date, err := parseDate(get("date"))
+	if err != nil {
+		return nil, err
+	}
+	amount, err := parseAmount(get("amount"))
+	if err != nil {
+		return nil, err
+	}
+	credit, err := parseAmount(get("credit"))
+	if err != nil {
+		return nil, err
+	}
+	debit, err := parseAmount(get("debit"))
+	if err != nil {
+		return nil, err
+	}
+	amount += credit - debit
+	if amount == 0 {
+		return nil, errors.New("amount is zero")
+	}
+	description := get("description")
+	tin := get("tin")
+	if tin == "" {
+		tin = findTIN(description)
+	}
+	return &bankstatement.Line{
+		Date:             date,
+		Amount:           amount,
+		Currency:         strings.ToUpper(get("currency")),
+		Description:      description,
+		CounterpartyName: get("counterparty"),
+		CounterpartyTIN:  tin,
+		Reference:        get("reference"),
+	}, nil
+}
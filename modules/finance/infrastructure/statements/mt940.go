@@ -0,0 +1,141 @@
+package statements
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+)
+
+var (
+	mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	// :61: value date, optional entry date, debit/credit mark, optional funds code, amount,
+	// transaction type, customer reference and optional bank reference.
+	mt940Line     = regexp.MustCompile(`^(\d{6})(\d{4})?(R?[CD])([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})([^/]*)(?://(.*))?$`)
+	mt940Balance  = regexp.MustCompile(`^[CD]\d{6}([A-Z]{3})`)
+	mt940Subfield = regexp.MustCompile(`\?(\d{2})`)
+)
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+func mt940Fields(r io.Reader) ([]mt940Field, error) {
+	var fields []mt940Field
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if m := mt940Tag.FindStringSubmatch(text); m != nil {
+			fields = append(fields, mt940Field{tag: m[1], value: m[2]})
+			continue
+		}
+		// Continuation lines belong to the previous tag; block delimiters and headers are skipped.
+		if len(fields) == 0 || text == "-" || text == "" || strings.HasPrefix(text, "{") {
+			continue
+		}
+		fields[len(fields)-1].value += "\n" + text
+	}
+	return fields, scanner.Err()
+}
+
+// ParseMT940 reads a SWIFT MT940 customer statement.
+func ParseMT940(r io.Reader) ([]*bankstatement.Line, error) {
+	fields, err := mt940Fields(r)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		lines    []*bankstatement.Line
+		currency string
+		last     *bankstatement.Line
+	)
+	for _, f := range fields {
+		switch f.tag {
+		case "60F", "60M":
+			if m := mt940Balance.FindStringSubmatch(f.value); m != nil {
+				currency = m[1]
+			}
+		case "61":
+			line, err := mt940StatementLine(f.value)
+			if err != nil {
+				return nil, err
+			}
+			line.Currency = currency
+			lines = append(lines, line)
+			last = line
+		case "86":
+			if last != nil {
+				mt940Information(last, f.value)
+				last = nil
+			}
+		}
+	}
+	return lines, nil
+}
+
+func mt940StatementLine(value string) (*bankstatement.Line, error) {
+	first, _, _ := strings.Cut(value, "\n")
+	m := mt940Line.FindStringSubmatch(first)
+	if m == nil {
+		return nil, fmt.Errorf("invalid mt940 statement line %q", first)
+	}
+	date, err := time.Parse("060102", m[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid mt940 date %q", m[1])
+	}
+	amount, err := parseAmount(m[5])
+	if err != nil {
+		return nil, err
+	}
+	// Reversals flip the direction of the booking.
+	if m[3] == "D" || m[3] == "RC" {
+		amount = -amount
+	}
+	reference := strings.TrimSpace(m[8])
+	if reference == "" || reference == "NONREF" {
+		reference = strings.TrimSpace(m[7])
+	}
+	if reference == "NONREF" {
+		reference = ""
+	}
+	return &bankstatement.Line{
+		Date:      date,
+		Amount:    amount,
+		Reference: reference,
+	}, nil
+}
+
+// mt940Information fills the line from the :86: field. Structured fields (?20-?29 purpose,
+// ?32-?33 counterparty name) are used when present, otherwise the text is the description.
+func mt940Information(line *bankstatement.Line, value string) {
+	text := strings.ReplaceAll(value, "\n", "")
+	if !strings.Contains(text, "?") {
+		line.Description = strings.TrimSpace(strings.ReplaceAll(value, "\n", " "))
+		line.CounterpartyTIN = findTIN(line.Description)
+		return
+	}
+	var description, name []string
+	indexes := mt940Subfield.FindAllStringSubmatchIndex(text, -1)
+	for i, idx := range indexes {
+		end := len(text)
+		if i+1 < len(indexes) {
+			end = indexes[i+1][0]
+		}
+		code := text[idx[2]:idx[3]]
+		content := strings.TrimSpace(text[idx[1]:end])
+		switch {
+		case code >= "20" && code <= "29", code >= "60" && code <= "63":
+			description = append(description, content)
+		case code == "32" || code == "33":
+			name = append(name, content)
+		}
+	}
+	line.Description = strings.Join(description, " ")
+	line.CounterpartyName = strings.Join(name, " ")
+	line.CounterpartyTIN = findTIN(line.Description)
+}
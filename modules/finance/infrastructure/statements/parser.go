@@ -0,0 +1,78 @@
+// Package statements parses bank statement files into bank statement lines.
+package statements
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+)
+
+// Parse reads a statement in the given format. Lines are returned in file order with
+// Amount positive for credits to the account and negative for debits.
+func Parse(format bankstatement.Format, r io.Reader) ([]*bankstatement.Line, error) {
+	switch format {
+	case bankstatement.CSV:
+		return ParseCSV(r)
+	case bankstatement.MT940:
+		return ParseMT940(r)
+	case bankstatement.Camt053:
+		return ParseCamt053(r)
+	}
+	return nil, fmt.Errorf("unsupported bank statement format: %s", format)
+}
+
+var tinPattern = regexp.MustCompile(`(?i)(?:INN|TIN|ИНН)[\s:/№#-]*(\d{9,12})`)
+
+// findTIN extracts a taxpayer identification number mentioned in free text, e.g. "INN 123456789".
+func findTIN(text string) string {
+	m := tinPattern.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// parseAmount accepts both "1 234,56" and "1,234.56" styles.
+func parseAmount(value string) (float64, error) {
+	v := strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(strings.TrimSpace(value))
+	if v == "" {
+		return 0, nil
+	}
+	lastComma, lastDot := strings.LastIndex(v, ","), strings.LastIndex(v, ".")
+	switch {
+	case lastComma > lastDot:
+		v = strings.ReplaceAll(v, ".", "")
+		v = strings.Replace(v, ",", ".", 1)
+	case lastDot > lastComma:
+		v = strings.ReplaceAll(v, ",", "")
+	}
+	amount, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+var dateLayouts = []string{
+	time.DateOnly,
+	"02.01.2006",
+	"02/01/2006",
+	"2006/01/02",
+	"20060102",
+	time.RFC3339,
+}
+
+func parseDate(value string) (time.Time, error) {
+	v := strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
@@ -0,0 +1,153 @@
+package statements_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/statements"
+)
+
+type expectedLine struct {
+	date      string
+	amount    float64
+	currency  string
+	name      string
+	tin       string
+	reference string
+}
+
+func assertLines(t *testing.T, lines []*bankstatement.Line, expected []expectedLine) {
+	t.Helper()
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d", len(expected), len(lines))
+	}
+	for i, e := range expected {
+		l := lines[i]
+		if l.Date.Format(time.DateOnly) != e.date {
+			t.Errorf("line %d: expected date %s, got %s", i, e.date, l.Date.Format(time.DateOnly))
+		}
+		if l.Amount != e.amount {
+			t.Errorf("line %d: expected amount %.2f, got %.2f", i, e.amount, l.Amount)
+		}
+		if l.Currency != e.currency {
+			t.Errorf("line %d: expected currency %q, got %q", i, e.currency, l.Currency)
+		}
+		if l.CounterpartyName != e.name {
+			t.Errorf("line %d: expected counterparty %q, got %q", i, e.name, l.CounterpartyName)
+		}
+		if l.CounterpartyTIN != e.tin {
+			t.Errorf("line %d: expected tin %q, got %q", i, e.tin, l.CounterpartyTIN)
+		}
+		if l.Reference != e.reference {
+			t.Errorf("line %d: expected reference %q, got %q", i, e.reference, l.Reference)
+		}
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Run("signed amount", func(t *testing.T) {
+		content := "Date,Amount,Currency,Counterparty,TIN,Description,Reference\n" +
+			"2024-03-01,\"1,250.00\",usd,Acme LLC,123456789,Invoice 12,A1\n" +
+			"2024-03-02,-40.5,USD,Bank,,Service fee,A2\n"
+		lines, err := statements.ParseCSV(strings.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertLines(t, lines, []expectedLine{
+			{"2024-03-01", 1250, "USD", "Acme LLC", "123456789", "A1"},
+			{"2024-03-02", -40.5, "USD", "Bank", "", "A2"},
+		})
+	})
+
+	t.Run("credit and debit columns", func(t *testing.T) {
+		content := "\ufeffДата;Приход;Расход;Контрагент;Назначение платежа\n" +
+			"05.03.2024;1 000,50;;ООО Ромашка;Оплата по договору ИНН 305123456\n" +
+			"06.03.2024;;200,00;ИП Иванов;Аренда\n"
+		lines, err := statements.ParseCSV(strings.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertLines(t, lines, []expectedLine{
+			{"2024-03-05", 1000.5, "", "ООО Ромашка", "305123456", ""},
+			{"2024-03-06", -200, "", "ИП Иванов", "", ""},
+		})
+	})
+
+	t.Run("missing columns", func(t *testing.T) {
+		if _, err := statements.ParseCSV(strings.NewReader("Foo,Bar\n1,2\n")); err == nil {
+			t.Error("expected error for a file without date and amount columns")
+		}
+	})
+}
+
+func TestParseMT940(t *testing.T) {
+	content := `{1:F01BANKUZ2XAXXX0000000000}{4:
+:20:STMT240301
+:25:20208000900123456001
+:28C:1/1
+:60F:C240229UZS1000000,00
+:61:2403010301C500000,00NTRFREF001//BANK001
+:86:?20Payment for invoice 42?21INN 301234567?32OOO SAVDO?33 GROUP
+:61:240302D1500,00NCHGNONREF
+:86:Monthly service fee
+:62F:C240302UZS1498500,00
+-}`
+	lines, err := statements.ParseMT940(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, lines, []expectedLine{
+		{"2024-03-01", 500000, "UZS", "OOO SAVDO GROUP", "301234567", "BANK001"},
+		{"2024-03-02", -1500, "UZS", "", "", ""},
+	})
+	if lines[0].Description != "Payment for invoice 42 INN 301234567" {
+		t.Errorf("unexpected description %q", lines[0].Description)
+	}
+	if lines[1].Description != "Monthly service fee" {
+		t.Errorf("unexpected description %q", lines[1].Description)
+	}
+}
+
+func TestParseCamt053(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="EUR">250.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2024-03-04</Dt></BookgDt>
+        <AcctSvcrRef>SVC-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties>
+            <Dbtr><Nm>Acme GmbH</Nm><Id><OrgId><Othr><Id>123456789</Id></Othr></OrgId></Id></Dbtr>
+          </RltdPties>
+          <RmtInf><Ustrd>Invoice 7</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">80.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <BookgDt><DtTm>2024-03-05T10:00:00+01:00</DtTm></BookgDt>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>E2E-9</EndToEndId></Refs>
+          <RltdPties><Cdtr><Nm>Office Supplies</Nm></Cdtr></RltdPties>
+        </TxDtls></NtryDtls>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+	lines, err := statements.Parse(bankstatement.Camt053, strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, lines, []expectedLine{
+		{"2024-03-04", 250, "EUR", "Acme GmbH", "123456789", "SVC-1"},
+		{"2024-03-05", -80, "EUR", "Office Supplies", "", "E2E-9"},
+	})
+	if lines[0].Description != "Invoice 7" {
+		t.Errorf("unexpected description %q", lines[0].Description)
+	}
+}
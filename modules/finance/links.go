@@ -2,6 +2,8 @@ package finance
 
 import (
 	icons "github.com/iota-uz/icons/phosphor"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	corepermissions "github.com/iota-uz/iota-sdk/modules/core/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/types"
 )
 
@@ -30,6 +32,60 @@ var (
 		Permissions: nil,
 		Children:    nil,
 	}
+	LedgerItem = types.NavigationItem{
+		Name:        "NavigationLinks.Ledger",
+		Href:        "/finance/ledger",
+		Permissions: nil,
+		Children:    nil,
+	}
+	PeriodsItem = types.NavigationItem{
+		Name:        "NavigationLinks.Periods",
+		Href:        "/finance/periods",
+		Permissions: nil,
+		Children:    nil,
+	}
+	ExchangeRatesItem = types.NavigationItem{
+		Name:        "NavigationLinks.ExchangeRates",
+		Href:        "/finance/exchange-rates",
+		Permissions: []*permission.Permission{corepermissions.ExchangeRateRead},
+		Children:    nil,
+	}
+	InvoicesItem = types.NavigationItem{
+		Name:        "NavigationLinks.Invoices",
+		Href:        "/finance/invoices",
+		Permissions: nil,
+		Children:    nil,
+	}
+	BillsItem = types.NavigationItem{
+		Name:        "NavigationLinks.Bills",
+		Href:        "/finance/bills",
+		Permissions: nil,
+		Children:    nil,
+	}
+	BudgetsItem = types.NavigationItem{
+		Name:        "NavigationLinks.Budgets",
+		Href:        "/finance/budgets",
+		Permissions: nil,
+		Children:    nil,
+	}
+	RecurringTransactionsItem = types.NavigationItem{
+		Name:        "NavigationLinks.RecurringTransactions",
+		Href:        "/finance/recurring",
+		Permissions: nil,
+		Children:    nil,
+	}
+	BankStatementsItem = types.NavigationItem{
+		Name:        "NavigationLinks.BankStatements",
+		Href:        "/finance/bank-statements",
+		Permissions: nil,
+		Children:    nil,
+	}
+	ReportsItem = types.NavigationItem{
+		Name:        "NavigationLinks.Reports",
+		Href:        "/finance/reports",
+		Permissions: nil,
+		Children:    nil,
+	}
 )
 
 var FinanceItem = types.NavigationItem{
@@ -40,7 +96,16 @@ var FinanceItem = types.NavigationItem{
 		ExpenseCategoriesItem,
 		PaymentsItem,
 		ExpensesItem,
+		BudgetsItem,
+		RecurringTransactionsItem,
+		InvoicesItem,
+		BillsItem,
 		AccountsItem,
+		LedgerItem,
+		PeriodsItem,
+		ExchangeRatesItem,
+		BankStatementsItem,
+		ReportsItem,
 	},
 }
 
@@ -4,11 +4,16 @@ import (
 	"embed"
 
 	icons "github.com/iota-uz/icons/phosphor"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/settings"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/handlers"
 	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
 	"github.com/iota-uz/iota-sdk/modules/finance/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/finance/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
 )
 
@@ -26,30 +31,107 @@ type Module struct {
 }
 
 func (m *Module) Register(app application.Application) error {
+	moneyAccountRepo := persistence.NewMoneyAccountRepository()
+	transactionRepo := persistence.NewTransactionRepository()
+	categoryRepo := persistence.NewExpenseCategoryRepository()
+	currencyService := app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService)
+	periodService := services.NewAccountingPeriodService(
+		persistence.NewAccountingPeriodRepository(),
+		moneyAccountRepo,
+		app.EventPublisher(),
+	)
+	ledgerService := services.NewLedgerService(
+		persistence.NewLedgerAccountRepository(),
+		persistence.NewJournalEntryRepository(),
+		moneyAccountRepo,
+		categoryRepo,
+		currencyService,
+		periodService,
+		currency.Code(configuration.Use().BaseCurrency),
+		app.EventPublisher(),
+	)
+	expenseRepo := persistence.NewExpenseRepository(categoryRepo, transactionRepo)
 	moneyAccountService := services.NewMoneyAccountService(
-		persistence.NewMoneyAccountRepository(),
-		persistence.NewTransactionRepository(),
+		moneyAccountRepo,
+		transactionRepo,
+		expenseRepo,
 		app.EventPublisher(),
+		ledgerService,
+		currencyService,
+		periodService,
 	)
-	transactionRepo := persistence.NewTransactionRepository()
-	categoryRepo := persistence.NewExpenseCategoryRepository()
+	reportService := services.NewReportService(
+		persistence.NewLedgerAccountRepository(),
+		persistence.NewJournalEntryRepository(),
+		ledgerService,
+	)
+	paymentService := services.NewPaymentService(
+		persistence.NewPaymentRepository(),
+		app.EventPublisher(),
+		moneyAccountService,
+		ledgerService,
+		periodService,
+	)
+	budgetService := services.NewBudgetService(
+		persistence.NewBudgetRepository(),
+		moneyAccountRepo,
+		currencyService,
+		app.EventPublisher(),
+	)
+	expenseService := services.NewExpenseService(
+		expenseRepo,
+		app.EventPublisher(),
+		moneyAccountService,
+		ledgerService,
+		periodService,
+		budgetService,
+	)
+	recurringService := services.NewRecurringService(
+		persistence.NewRecurringRepository(),
+		paymentService,
+		expenseService,
+		app.EventPublisher(),
+	)
+	counterpartyRepo := persistence.NewCounterpartyRepository()
+	invoiceRepo := persistence.NewInvoiceRepository()
 	app.RegisterServices(
-		services.NewPaymentService(
-			persistence.NewPaymentRepository(),
-			app.EventPublisher(),
-			moneyAccountService,
-		),
+		paymentService,
 		services.NewExpenseCategoryService(
 			categoryRepo,
 			app.EventPublisher(),
 		),
-		services.NewExpenseService(
-			persistence.NewExpenseRepository(categoryRepo, transactionRepo),
+		expenseService,
+		budgetService,
+		recurringService,
+		moneyAccountService,
+		ledgerService,
+		periodService,
+		reportService,
+		services.NewCounterpartyService(counterpartyRepo),
+		services.NewBankStatementService(
+			persistence.NewBankStatementRepository(),
+			transactionRepo,
+			counterpartyRepo,
+			paymentService,
+			expenseService,
+			app.EventPublisher(),
+		),
+		services.NewInvoiceService(
+			invoiceRepo,
+			ledgerService,
+			periodService,
+			&settings.Settings{VatTax: configuration.Use().VatTax},
+			app.EventPublisher(),
+		),
+		services.NewBillService(
+			persistence.NewBillRepository(),
+			counterpartyRepo,
+			moneyAccountRepo,
+			expenseService,
+			ledgerService,
+			periodService,
 			app.EventPublisher(),
-			moneyAccountService,
 		),
-		moneyAccountService,
-		services.NewCounterpartyService(persistence.NewCounterpartyRepository()),
 	)
 
 	app.RegisterControllers(
@@ -58,12 +140,29 @@ func (m *Module) Register(app application.Application) error {
 		controllers.NewExpenseCategoriesController(app),
 		controllers.NewPaymentsController(app),
 		controllers.NewCounterpartiesController(app),
+		controllers.NewLedgerController(app),
+		controllers.NewAccountingPeriodsController(app),
+		controllers.NewExchangeRatesController(app),
+		controllers.NewReportController(app),
+		controllers.NewBankStatementController(app),
+		controllers.NewInvoiceController(app),
+		controllers.NewBillController(app),
+		controllers.NewBudgetController(app),
+		controllers.NewRecurringTransactionController(app),
 	)
 	app.Spotlight().Register(
 		spotlight.NewItem(nil, ExpenseCategoriesItem.Name, ExpenseCategoriesItem.Href),
 		spotlight.NewItem(nil, PaymentsItem.Name, PaymentsItem.Href),
 		spotlight.NewItem(nil, ExpensesItem.Name, ExpensesItem.Href),
 		spotlight.NewItem(nil, AccountsItem.Name, AccountsItem.Href),
+		spotlight.NewItem(nil, LedgerItem.Name, LedgerItem.Href),
+		spotlight.NewItem(nil, PeriodsItem.Name, PeriodsItem.Href),
+		spotlight.NewItem(nil, InvoicesItem.Name, InvoicesItem.Href),
+		spotlight.NewItem(nil, BillsItem.Name, BillsItem.Href),
+		spotlight.NewItem(nil, BudgetsItem.Name, BudgetsItem.Href),
+		spotlight.NewItem(nil, RecurringTransactionsItem.Name, RecurringTransactionsItem.Href),
+		spotlight.NewItem(nil, BankStatementsItem.Name, BankStatementsItem.Href),
+		spotlight.NewItem(nil, ReportsItem.Name, ReportsItem.Href),
 		spotlight.NewItem(
 			icons.PlusCircle(icons.Props{Size: "24"}),
 			"Expenses.List.New",
@@ -79,6 +178,11 @@ func (m *Module) Register(app application.Application) error {
 			"Payments.List.New",
 			"/finance/payments/new",
 		),
+		spotlight.NewItem(
+			icons.PlusCircle(icons.Props{Size: "24"}),
+			"Invoices.List.New",
+			"/finance/invoices/new",
+		),
 		spotlight.NewItem(
 			icons.PlusCircle(icons.Props{Size: "24"}),
 			"ExpenseCategories.List.New",
@@ -86,6 +190,13 @@ func (m *Module) Register(app application.Application) error {
 		),
 	)
 
+	handlers.RegisterRevaluationJob(app, ledgerService)
+	handlers.RegisterRecurringJob(app, recurringService)
+	handlers.RegisterBillNotificationHandler(app)
+	handlers.RegisterDealInvoiceHandler(app, invoiceRepo, counterpartyRepo)
+	handlers.RegisterInventoryWriteOffHandler(app, categoryRepo, ledgerService)
+	handlers.RegisterPurchaseSupplierHandler(app, counterpartyRepo)
+
 	app.RBAC().Register(permissions.Permissions...)
 	app.RegisterLocaleFiles(&localeFiles)
 	app.RegisterMigrationDirs(&migrationFiles)
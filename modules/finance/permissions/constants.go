@@ -9,6 +9,17 @@ const (
 	ResourceExpense         permission.Resource = "expense"
 	ResourcePayment         permission.Resource = "payment"
 	ResourceExpenseCategory permission.Resource = "expense_category"
+	ResourceLedger          permission.Resource = "ledger"
+	ResourcePeriod          permission.Resource = "accounting_period"
+	ResourceReport          permission.Resource = "financial_report"
+	ResourceBankStatement   permission.Resource = "bank_statement"
+	ResourceInvoice         permission.Resource = "invoice"
+	ResourceBill            permission.Resource = "bill"
+	ResourceBillApproval    permission.Resource = "bill_approval"
+	ResourceBillPayment     permission.Resource = "bill_payment"
+	ResourceBudget          permission.Resource = "budget"
+	ResourceRecurring       permission.Resource = "recurring_transaction"
+	ResourceTransfer        permission.Resource = "transfer"
 )
 
 var (
@@ -96,6 +107,195 @@ var (
 		Action:   permission.ActionDelete,
 		Modifier: permission.ModifierAll,
 	}
+	LedgerRead = &permission.Permission{
+		ID:       uuid.MustParse("7f1c2a0e-5b8d-4c3e-9a61-2d4f8b0c6e13"),
+		Name:     "Ledger.Read",
+		Resource: ResourceLedger,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	LedgerUpdate = &permission.Permission{
+		ID:       uuid.MustParse("b2e9d4a7-1c6f-4f08-8e35-9a7c3d2b5f40"),
+		Name:     "Ledger.Update",
+		Resource: ResourceLedger,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	PeriodRead = &permission.Permission{
+		ID:       uuid.MustParse("d340838e-d685-4ca4-b086-695c369456e9"),
+		Name:     "Period.Read",
+		Resource: ResourcePeriod,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	PeriodClose = &permission.Permission{
+		ID:       uuid.MustParse("f69f1825-1d6d-4b93-8e1c-30e820bb288c"),
+		Name:     "Period.Close",
+		Resource: ResourcePeriod,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	ReportRead = &permission.Permission{
+		ID:       uuid.MustParse("4e52abd4-d7c9-4c6b-8e09-3e53b4ac0c93"),
+		Name:     "Report.Read",
+		Resource: ResourceReport,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	BankStatementRead = &permission.Permission{
+		ID:       uuid.MustParse("4c94d42a-cae8-439c-9851-9f8929928116"),
+		Name:     "BankStatement.Read",
+		Resource: ResourceBankStatement,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	BankStatementImport = &permission.Permission{
+		ID:       uuid.MustParse("e617eaa2-aa36-41b3-94b6-8a5c7a776a22"),
+		Name:     "BankStatement.Import",
+		Resource: ResourceBankStatement,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	BankStatementReconcile = &permission.Permission{
+		ID:       uuid.MustParse("3a9e2030-d4c6-420d-aab2-8c4c8c5fe3ce"),
+		Name:     "BankStatement.Reconcile",
+		Resource: ResourceBankStatement,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	InvoiceCreate = &permission.Permission{
+		ID:       uuid.MustParse("b67dadc2-a9b9-4752-9251-6973dd1b6433"),
+		Name:     "Invoice.Create",
+		Resource: ResourceInvoice,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	InvoiceRead = &permission.Permission{
+		ID:       uuid.MustParse("f964bc9c-c540-43a5-ba73-08d011164cd6"),
+		Name:     "Invoice.Read",
+		Resource: ResourceInvoice,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	InvoiceUpdate = &permission.Permission{
+		ID:       uuid.MustParse("7bf4b576-804e-4e22-8c03-411115b5d9f2"),
+		Name:     "Invoice.Update",
+		Resource: ResourceInvoice,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	InvoiceDelete = &permission.Permission{
+		ID:       uuid.MustParse("289a5bd0-f6b3-4837-8e24-0286130c83d6"),
+		Name:     "Invoice.Delete",
+		Resource: ResourceInvoice,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	BillCreate = &permission.Permission{
+		ID:       uuid.MustParse("2dfb6f73-bd77-440c-8b86-ec8cddd372a2"),
+		Name:     "Bill.Create",
+		Resource: ResourceBill,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	BillRead = &permission.Permission{
+		ID:       uuid.MustParse("88ecdc33-d987-4e7a-8c94-80a52e7f6067"),
+		Name:     "Bill.Read",
+		Resource: ResourceBill,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	BillUpdate = &permission.Permission{
+		ID:       uuid.MustParse("f967ceab-c309-43c0-a53a-d879f4136a7f"),
+		Name:     "Bill.Update",
+		Resource: ResourceBill,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	BillDelete = &permission.Permission{
+		ID:       uuid.MustParse("6f5c3390-71bb-4c7a-a959-141f483c2ba8"),
+		Name:     "Bill.Delete",
+		Resource: ResourceBill,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	BillApprove = &permission.Permission{
+		ID:       uuid.MustParse("ec483d73-aad6-452b-b64e-a913f02bfb5f"),
+		Name:     "Bill.Approve",
+		Resource: ResourceBillApproval,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	BillPay = &permission.Permission{
+		ID:       uuid.MustParse("3d6ea6bf-d6f7-478c-a38c-39aae34c4855"),
+		Name:     "Bill.Pay",
+		Resource: ResourceBillPayment,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	BudgetCreate = &permission.Permission{
+		ID:       uuid.MustParse("74acd75b-b155-420e-a12b-36302e06077e"),
+		Name:     "Budget.Create",
+		Resource: ResourceBudget,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	BudgetRead = &permission.Permission{
+		ID:       uuid.MustParse("a8e80a10-ec8f-40dc-ad6b-fae81c58033d"),
+		Name:     "Budget.Read",
+		Resource: ResourceBudget,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	BudgetUpdate = &permission.Permission{
+		ID:       uuid.MustParse("5d307228-60c6-4065-9f4b-67bf113efbd5"),
+		Name:     "Budget.Update",
+		Resource: ResourceBudget,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	BudgetDelete = &permission.Permission{
+		ID:       uuid.MustParse("16e43069-ff57-46a7-a903-ae0db568d79b"),
+		Name:     "Budget.Delete",
+		Resource: ResourceBudget,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	RecurringCreate = &permission.Permission{
+		ID:       uuid.MustParse("935c7828-e89f-4fc3-a679-905d2084704d"),
+		Name:     "RecurringTransaction.Create",
+		Resource: ResourceRecurring,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	RecurringRead = &permission.Permission{
+		ID:       uuid.MustParse("87bec59e-f032-414e-83d6-b8ce0c04db72"),
+		Name:     "RecurringTransaction.Read",
+		Resource: ResourceRecurring,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	RecurringUpdate = &permission.Permission{
+		ID:       uuid.MustParse("08674614-98d2-49d5-a912-b98e279c94c1"),
+		Name:     "RecurringTransaction.Update",
+		Resource: ResourceRecurring,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	RecurringDelete = &permission.Permission{
+		ID:       uuid.MustParse("88f6a50c-982a-4c83-a452-5afb795ad9e1"),
+		Name:     "RecurringTransaction.Delete",
+		Resource: ResourceRecurring,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	TransferCreate = &permission.Permission{
+		ID:       uuid.MustParse("e99dfded-c50b-47f9-8fc5-78c6b2001c38"),
+		Name:     "Transfer.Create",
+		Resource: ResourceTransfer,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
 )
 
 var Permissions = []*permission.Permission{
@@ -111,4 +311,31 @@ var Permissions = []*permission.Permission{
 	ExpenseCategoryRead,
 	ExpenseCategoryUpdate,
 	ExpenseCategoryDelete,
+	LedgerRead,
+	LedgerUpdate,
+	PeriodRead,
+	PeriodClose,
+	ReportRead,
+	BankStatementRead,
+	BankStatementImport,
+	BankStatementReconcile,
+	InvoiceCreate,
+	InvoiceRead,
+	InvoiceUpdate,
+	InvoiceDelete,
+	BillCreate,
+	BillRead,
+	BillUpdate,
+	BillDelete,
+	BillApprove,
+	BillPay,
+	BudgetCreate,
+	BudgetRead,
+	BudgetUpdate,
+	BudgetDelete,
+	RecurringCreate,
+	RecurringRead,
+	RecurringUpdate,
+	RecurringDelete,
+	TransferCreate,
 }
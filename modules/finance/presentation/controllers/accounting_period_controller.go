@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/periods"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// periodsShown is the number of most recent months listed on the periods page.
+const periodsShown = 12
+
+type AccountingPeriodsController struct {
+	app           application.Application
+	periodService *services.AccountingPeriodService
+	basePath      string
+}
+
+func NewAccountingPeriodsController(app application.Application) application.Controller {
+	return &AccountingPeriodsController{
+		app:           app,
+		periodService: app.Service(services.AccountingPeriodService{}).(*services.AccountingPeriodService),
+		basePath:      "/finance/periods",
+	}
+}
+
+func (c *AccountingPeriodsController) Key() string {
+	return c.basePath
+}
+
+func (c *AccountingPeriodsController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("/{month:[0-9]{4}-[0-9]{2}}/close", c.transition(c.periodService.Close)).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{month:[0-9]{4}-[0-9]{2}}/reopen", c.transition(c.periodService.Reopen)).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{month:[0-9]{4}-[0-9]{2}}/lock", c.transition(c.periodService.Lock)).Methods(http.MethodPost)
+}
+
+func (c *AccountingPeriodsController) viewModelPeriods(r *http.Request) ([]*viewmodels.AccountingPeriod, error) {
+	current := accountingperiod.MonthStart(time.Now())
+	result := make([]*viewmodels.AccountingPeriod, 0, periodsShown)
+	for i := 0; i < periodsShown; i++ {
+		period, err := c.periodService.GetByMonth(r.Context(), current.AddDate(0, -i, 0))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, mappers.AccountingPeriodToViewModel(period))
+	}
+	return result, nil
+}
+
+func (c *AccountingPeriodsController) List(w http.ResponseWriter, r *http.Request) {
+	viewModels, err := c.viewModelPeriods(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &periods.IndexPageProps{
+		Periods:  viewModels,
+		BasePath: c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(periods.PeriodsTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(periods.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *AccountingPeriodsController) transition(
+	apply func(ctx context.Context, date time.Time) (*accountingperiod.Period, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		month, err := time.Parse("2006-01", mux.Vars(r)["month"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, err := apply(r.Context(), month); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		c.List(w, r)
+	}
+}
@@ -0,0 +1,332 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/bankstatements"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// maxStatementSize limits the size of uploaded statement files.
+const maxStatementSize = 16 << 20
+
+type BankStatementController struct {
+	app                    application.Application
+	bankStatementService   *services.BankStatementService
+	moneyAccountService    *services.MoneyAccountService
+	counterpartyService    *services.CounterpartyService
+	expenseCategoryService *services.ExpenseCategoryService
+	basePath               string
+}
+
+func NewBankStatementController(app application.Application) application.Controller {
+	return &BankStatementController{
+		app:                    app,
+		bankStatementService:   app.Service(services.BankStatementService{}).(*services.BankStatementService),
+		moneyAccountService:    app.Service(services.MoneyAccountService{}).(*services.MoneyAccountService),
+		counterpartyService:    app.Service(services.CounterpartyService{}).(*services.CounterpartyService),
+		expenseCategoryService: app.Service(services.ExpenseCategoryService{}).(*services.ExpenseCategoryService),
+		basePath:               "/finance/bank-statements",
+	}
+}
+
+func (c *BankStatementController) Key() string {
+	return c.basePath
+}
+
+func (c *BankStatementController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.Show).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}/lines/{lineID:[0-9]+}/create", c.GetCreateTransaction).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Import).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/match", c.AutoMatch).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/lines/{lineID:[0-9]+}/confirm", c.lineAction(c.bankStatementService.Confirm)).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/lines/{lineID:[0-9]+}/ignore", c.lineAction(c.bankStatementService.Ignore)).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/lines/{lineID:[0-9]+}/reset", c.lineAction(c.bankStatementService.Reset)).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/lines/{lineID:[0-9]+}/create", c.CreateTransaction).Methods(http.MethodPost)
+}
+
+func (c *BankStatementController) viewModelAccounts(r *http.Request) ([]*viewmodels.MoneyAccount, error) {
+	accounts, err := c.moneyAccountService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving money accounts")
+	}
+	return mapping.MapViewModels(accounts, mappers.MoneyAccountToViewModel), nil
+}
+
+func (c *BankStatementController) renderList(w http.ResponseWriter, r *http.Request, errorsMap map[string]string) {
+	accounts, err := c.viewModelAccounts(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	paginationParams := composables.UsePaginated(r)
+	entities, err := c.bankStatementService.GetPaginated(r.Context(), &bankstatement.FindParams{
+		Limit:  paginationParams.Limit,
+		Offset: paginationParams.Offset,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &bankstatements.IndexPageProps{
+		Statements: mapping.MapViewModels(entities, mappers.BankStatementToViewModel),
+		Accounts:   accounts,
+		BasePath:   c.basePath,
+		Errors:     errorsMap,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(bankstatements.ImportForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(bankstatements.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *BankStatementController) List(w http.ResponseWriter, r *http.Request) {
+	c.renderList(w, r, map[string]string{})
+}
+
+func (c *BankStatementController) Import(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(maxStatementSize); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto := &bankstatement.ImportDTO{}
+	if err := shared.Decoder.Decode(dto, r.MultipartForm.Value); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderList(w, r, errorsMap)
+		return
+	}
+	file, header, err := r.FormFile("File")
+	if err != nil {
+		c.renderList(w, r, map[string]string{"File": err.Error()})
+		return
+	}
+	defer func(file multipart.File) {
+		if err := file.Close(); err != nil {
+			log.Println(err)
+		}
+	}(file)
+
+	entity, err := c.bankStatementService.Import(r.Context(), dto, header.Filename, file)
+	if err != nil {
+		c.renderList(w, r, map[string]string{"File": err.Error()})
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, entity.ID))
+}
+
+func (c *BankStatementController) renderStatement(w http.ResponseWriter, r *http.Request, id uint) {
+	entity, err := c.bankStatementService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &bankstatements.ShowPageProps{
+		Statement: mappers.BankStatementToViewModel(entity),
+		BasePath:  c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(bankstatements.LinesTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(bankstatements.Show(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *BankStatementController) Show(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.renderStatement(w, r, id)
+}
+
+func (c *BankStatementController) AutoMatch(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.bankStatementService.AutoMatch(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderStatement(w, r, id)
+}
+
+func (c *BankStatementController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.bankStatementService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *BankStatementController) lineAction(
+	apply func(ctx context.Context, lineID uint) (*bankstatement.Line, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := shared.ParseID(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		lineID, err := parseLineID(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, err := apply(r.Context(), lineID); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		c.renderStatement(w, r, id)
+	}
+}
+
+func parseLineID(r *http.Request) (uint, error) {
+	lineID, err := strconv.Atoi(mux.Vars(r)["lineID"])
+	if err != nil {
+		return 0, errors.Wrap(err, "Error parsing line id")
+	}
+	return uint(lineID), nil
+}
+
+func (c *BankStatementController) renderCreateTransaction(
+	w http.ResponseWriter, r *http.Request, line *bankstatement.Line, dto *bankstatement.CreateTransactionDTO, errorsMap map[string]string,
+) {
+	props := &bankstatements.CreateTransactionPageProps{
+		Line:        mappers.BankStatementLineToViewModel(line),
+		StatementID: fmt.Sprintf("%d", line.StatementID),
+		BasePath:    c.basePath,
+		Comment:     dto.Comment,
+		Errors:      errorsMap,
+	}
+	if dto.CounterpartyID != 0 {
+		props.CounterpartyID = fmt.Sprintf("%d", dto.CounterpartyID)
+	}
+	if dto.CategoryID != 0 {
+		props.CategoryID = fmt.Sprintf("%d", dto.CategoryID)
+	}
+	if line.IsInflow() {
+		counterparties, err := c.counterpartyService.GetAll(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Counterparties = mapping.MapViewModels(counterparties, mappers.CounterpartyToViewModel)
+	} else {
+		categories, err := c.expenseCategoryService.GetAll(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Categories = mapping.MapViewModels(categories, mappers.ExpenseCategoryToViewModel)
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(bankstatements.CreateTransactionForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(bankstatements.CreateTransaction(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *BankStatementController) GetCreateTransaction(w http.ResponseWriter, r *http.Request) {
+	lineID, err := parseLineID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	line, err := c.bankStatementService.GetLineByID(r.Context(), lineID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	dto := &bankstatement.CreateTransactionDTO{Comment: line.Description}
+	suggested, err := c.bankStatementService.SuggestCounterparty(r.Context(), line)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if suggested != nil {
+		dto.CounterpartyID = suggested.ID()
+	}
+	c.renderCreateTransaction(w, r, line, dto, map[string]string{})
+}
+
+func (c *BankStatementController) CreateTransaction(w http.ResponseWriter, r *http.Request) {
+	lineID, err := parseLineID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&bankstatement.CreateTransactionDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	line, err := c.bankStatementService.GetLineByID(r.Context(), lineID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(line, uniTranslator); !ok {
+		c.renderCreateTransaction(w, r, line, dto, errorsMap)
+		return
+	}
+	if _, err := c.bankStatementService.CreateTransaction(r.Context(), lineID, dto); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, line.StatementID))
+}
@@ -0,0 +1,502 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/bills"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type BillController struct {
+	app                 application.Application
+	billService         *services.BillService
+	counterpartyService *services.CounterpartyService
+	categoryService     *services.ExpenseCategoryService
+	moneyAccountService *services.MoneyAccountService
+	currencyService     *coreservices.CurrencyService
+	basePath            string
+}
+
+type BillListQuery struct {
+	Status string
+}
+
+func NewBillController(app application.Application) application.Controller {
+	return &BillController{
+		app:                 app,
+		billService:         app.Service(services.BillService{}).(*services.BillService),
+		counterpartyService: app.Service(services.CounterpartyService{}).(*services.CounterpartyService),
+		categoryService:     app.Service(services.ExpenseCategoryService{}).(*services.ExpenseCategoryService),
+		moneyAccountService: app.Service(services.MoneyAccountService{}).(*services.MoneyAccountService),
+		currencyService:     app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService),
+		basePath:            "/finance/bills",
+	}
+}
+
+func (c *BillController) Key() string {
+	return c.basePath
+}
+
+func (c *BillController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.Show).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}/edit", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/approve", c.Approve).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/reject", c.Reject).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/schedule", c.Schedule).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/pay", c.Pay).Methods(http.MethodPost)
+}
+
+// names maps counterparty and expense category ids to names for the bill view models.
+func (c *BillController) names(r *http.Request) (map[uint]string, map[uint]string, error) {
+	counterparties, err := c.counterpartyService.GetAll(r.Context())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error retrieving counterparties")
+	}
+	categories, err := c.categoryService.GetAll(r.Context())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error retrieving expense categories")
+	}
+	counterpartyNames := make(map[uint]string, len(counterparties))
+	for _, cp := range counterparties {
+		counterpartyNames[cp.ID()] = cp.Name()
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, cat := range categories {
+		categoryNames[cat.ID()] = cat.Name()
+	}
+	return counterpartyNames, categoryNames, nil
+}
+
+func (c *BillController) toViewModel(r *http.Request, entity *bill.Bill) (*viewmodels.Bill, error) {
+	counterpartyNames, categoryNames, err := c.names(r)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.BillToViewModel(entity, counterpartyNames[entity.CounterpartyID], categoryNames[entity.CategoryID]), nil
+}
+
+func (c *BillController) List(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&BillListQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := &bill.FindParams{SortBy: []string{"id"}}
+	if query.Status != "" {
+		params.Status, err = bill.NewStatus(query.Status)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	paginationParams := composables.UsePaginated(r)
+	params.Limit = paginationParams.Limit
+	params.Offset = paginationParams.Offset
+	entities, err := c.billService.GetPaginated(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	total, err := c.billService.Count(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	counterpartyNames, categoryNames, err := c.names(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	viewBills := make([]*viewmodels.Bill, 0, len(entities))
+	for _, entity := range entities {
+		viewBills = append(viewBills, mappers.BillToViewModel(
+			entity, counterpartyNames[entity.CounterpartyID], categoryNames[entity.CategoryID],
+		))
+	}
+	props := &bills.IndexPageProps{
+		Bills:           viewBills,
+		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
+		Status:          query.Status,
+		BasePath:        c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(bills.BillsTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(bills.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *BillController) formProps(
+	r *http.Request, vm *viewmodels.Bill, action string, errorsMap map[string]string,
+) (*bills.FormPageProps, error) {
+	counterparties, err := c.counterpartyService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving counterparties")
+	}
+	var suppliers []*viewmodels.Counterparty
+	for _, cp := range counterparties {
+		if cp.Type() == counterparty.Supplier {
+			suppliers = append(suppliers, mappers.CounterpartyToViewModel(cp))
+		}
+	}
+	categories, err := c.categoryService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving expense categories")
+	}
+	currencies, err := c.currencyService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving currencies")
+	}
+	return &bills.FormPageProps{
+		Bill:       vm,
+		Suppliers:  suppliers,
+		Categories: mapping.MapViewModels(categories, mappers.ExpenseCategoryToViewModel),
+		Currencies: mapping.MapViewModels(currencies, coremappers.CurrencyToViewModel),
+		Action:     action,
+		Errors:     errorsMap,
+	}, nil
+}
+
+func (c *BillController) renderForm(
+	w http.ResponseWriter, r *http.Request, vm *viewmodels.Bill, action string, errorsMap map[string]string,
+) {
+	props, err := c.formProps(r, vm, action, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	switch {
+	case shared.IsHxRequest(r):
+		templ.Handler(bills.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+	case vm.ID != "":
+		templ.Handler(bills.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+	default:
+		templ.Handler(bills.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// billDTOToViewModel keeps the submitted values when the form is rendered again with errors.
+func billDTOToViewModel(id string, dto *bill.SaveDTO) *viewmodels.Bill {
+	vm := &viewmodels.Bill{
+		ID:           id,
+		Number:       dto.Number,
+		CurrencyCode: dto.CurrencyCode,
+		Amount:       fmt.Sprintf("%.2f", dto.Amount),
+		BillDate:     time.Time(dto.BillDate).Format(time.DateOnly),
+		DueDate:      time.Time(dto.DueDate).Format(time.DateOnly),
+		Description:  dto.Description,
+	}
+	if dto.CounterpartyID != 0 {
+		vm.CounterpartyID = strconv.FormatUint(uint64(dto.CounterpartyID), 10)
+	}
+	if dto.CategoryID != 0 {
+		vm.CategoryID = strconv.FormatUint(uint64(dto.CategoryID), 10)
+	}
+	return vm
+}
+
+// billFormError reports the workflow errors the submitter can correct on the form.
+func billFormError(err error) (map[string]string, bool) {
+	switch {
+	case errors.Is(err, bill.ErrNotSupplier):
+		return map[string]string{"CounterpartyID": err.Error()}, true
+	case errors.Is(err, bill.ErrInvalidDueDate):
+		return map[string]string{"DueDate": err.Error()}, true
+	case errors.Is(err, bill.ErrInvalidTransition):
+		return map[string]string{"Bill": err.Error()}, true
+	}
+	return nil, false
+}
+
+func (c *BillController) GetNew(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	vm := &viewmodels.Bill{
+		BillDate: now.Format(time.DateOnly),
+		DueDate:  now.AddDate(0, 0, 14).Format(time.DateOnly),
+	}
+	c.renderForm(w, r, vm, c.basePath, map[string]string{})
+}
+
+func (c *BillController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&bill.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderForm(w, r, billDTOToViewModel("", dto), c.basePath, errorsMap)
+		return
+	}
+	entity, err := c.billService.Create(r.Context(), dto)
+	if errorsMap, ok := billFormError(err); ok {
+		c.renderForm(w, r, billDTOToViewModel("", dto), c.basePath, errorsMap)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, entity.ID))
+}
+
+func (c *BillController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.billService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !entity.IsEditable() {
+		shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+		return
+	}
+	c.renderForm(w, r, mappers.BillToViewModel(entity, "", ""), fmt.Sprintf("%s/%d", c.basePath, id), map[string]string{})
+}
+
+func (c *BillController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&bill.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	action := fmt.Sprintf("%s/%d", c.basePath, id)
+	vmID := strconv.FormatUint(uint64(id), 10)
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderForm(w, r, billDTOToViewModel(vmID, dto), action, errorsMap)
+		return
+	}
+	_, err = c.billService.Update(r.Context(), id, dto)
+	if errorsMap, ok := billFormError(err); ok {
+		c.renderForm(w, r, billDTOToViewModel(vmID, dto), action, errorsMap)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, action)
+}
+
+func (c *BillController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.billService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *BillController) showProps(
+	r *http.Request, entity *bill.Bill, errorsMap map[string]string,
+) (*bills.ShowPageProps, error) {
+	vm, err := c.toViewModel(r, entity)
+	if err != nil {
+		return nil, err
+	}
+	props := &bills.ShowPageProps{
+		Bill:     vm,
+		BasePath: c.basePath,
+		Errors:   errorsMap,
+	}
+	if !vm.CanSchedule() {
+		return props, nil
+	}
+	accounts, err := c.moneyAccountService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving accounts")
+	}
+	for _, a := range accounts {
+		if a.Currency.Code == entity.Currency {
+			props.MoneyAccounts = append(props.MoneyAccounts, mappers.MoneyAccountToViewModel(a))
+		}
+	}
+	return props, nil
+}
+
+func (c *BillController) Show(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.billService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(bills.Show(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// renderActions re-renders the workflow forms of the bill with the errors of a failed action.
+func (c *BillController) renderActions(w http.ResponseWriter, r *http.Request, id uint, errorsMap map[string]string) {
+	entity, err := c.billService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(bills.Actions(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// billActionError reports the workflow errors that are shown next to the actions of the bill.
+func billActionError(err error) (map[string]string, bool) {
+	switch {
+	case errors.Is(err, bill.ErrInvalidTransition),
+		errors.Is(err, bill.ErrSelfApproval),
+		errors.Is(err, bill.ErrNotApprover):
+		return map[string]string{"Bill": err.Error()}, true
+	case errors.Is(err, bill.ErrCurrencyMismatch):
+		return map[string]string{"MoneyAccountID": err.Error()}, true
+	}
+	return nil, false
+}
+
+func (c *BillController) handleAction(w http.ResponseWriter, r *http.Request, id uint, err error) {
+	if errorsMap, ok := billActionError(err); ok {
+		c.renderActions(w, r, id, errorsMap)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+}
+
+func (c *BillController) Approve(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, err = c.billService.Approve(r.Context(), id)
+	c.handleAction(w, r, id, err)
+}
+
+func (c *BillController) Reject(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&bill.RejectDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderActions(w, r, id, errorsMap)
+		return
+	}
+	_, err = c.billService.Reject(r.Context(), id, dto)
+	c.handleAction(w, r, id, err)
+}
+
+func (c *BillController) Schedule(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&bill.ScheduleDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderActions(w, r, id, errorsMap)
+		return
+	}
+	_, err = c.billService.Schedule(r.Context(), id, dto)
+	c.handleAction(w, r, id, err)
+}
+
+func (c *BillController) Pay(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, err = c.billService.Pay(r.Context(), id)
+	c.handleAction(w, r, id, err)
+}
@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/budget"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/budgets"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type BudgetController struct {
+	app                    application.Application
+	budgetService          *services.BudgetService
+	expenseCategoryService *services.ExpenseCategoryService
+	currencyService        *coreservices.CurrencyService
+	basePath               string
+}
+
+type BudgetListQuery struct {
+	Periodicity string
+	Date        shared.DateOnly
+}
+
+func NewBudgetController(app application.Application) application.Controller {
+	return &BudgetController{
+		app:                    app,
+		budgetService:          app.Service(services.BudgetService{}).(*services.BudgetService),
+		expenseCategoryService: app.Service(services.ExpenseCategoryService{}).(*services.ExpenseCategoryService),
+		currencyService:        app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService),
+		basePath:               "/finance/budgets",
+	}
+}
+
+func (c *BudgetController) Key() string {
+	return c.basePath
+}
+
+func (c *BudgetController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+}
+
+// categoryNames maps expense category ids to names for the budget view models.
+func (c *BudgetController) categoryNames(r *http.Request) (map[uint]string, error) {
+	categories, err := c.expenseCategoryService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving categories")
+	}
+	names := make(map[uint]string, len(categories))
+	for _, cat := range categories {
+		names[cat.ID()] = cat.Name()
+	}
+	return names, nil
+}
+
+func (c *BudgetController) List(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&BudgetListQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := &budget.FindParams{
+		Date:   time.Time(query.Date),
+		SortBy: []string{"period_start", "id"},
+	}
+	if query.Periodicity != "" {
+		params.Periodicity, err = budget.NewPeriodicity(query.Periodicity)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	paginationParams := composables.UsePaginated(r)
+	params.Limit = paginationParams.Limit
+	params.Offset = paginationParams.Offset
+	entities, err := c.budgetService.GetPaginated(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	total, err := c.budgetService.Count(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	names, err := c.categoryNames(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	viewBudgets := make([]*viewmodels.Budget, 0, len(entities))
+	for _, entity := range entities {
+		variance, err := c.budgetService.Variance(r.Context(), entity)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		viewBudgets = append(viewBudgets, mappers.BudgetToViewModel(entity, variance, names[entity.CategoryID]))
+	}
+	date := ""
+	if !params.Date.IsZero() {
+		date = params.Date.Format(time.DateOnly)
+	}
+	props := &budgets.IndexPageProps{
+		Budgets:         viewBudgets,
+		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
+		Periodicity:     query.Periodicity,
+		Date:            date,
+		BasePath:        c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(budgets.BudgetsTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(budgets.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *BudgetController) renderForm(
+	w http.ResponseWriter, r *http.Request, vm *viewmodels.Budget, action string, errorsMap map[string]string,
+) {
+	categories, err := c.expenseCategoryService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving categories").Error(), http.StatusInternalServerError)
+		return
+	}
+	currencies, err := c.currencyService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving currencies").Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &budgets.FormPageProps{
+		Budget:     vm,
+		Categories: mapping.MapViewModels(categories, mappers.ExpenseCategoryToViewModel),
+		Currencies: mapping.MapViewModels(currencies, coremappers.CurrencyToViewModel),
+		Action:     action,
+		BasePath:   c.basePath,
+		Errors:     errorsMap,
+	}
+	switch {
+	case shared.IsHxRequest(r):
+		templ.Handler(budgets.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+	case vm.ID != "":
+		templ.Handler(budgets.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+	default:
+		templ.Handler(budgets.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// budgetDTOToViewModel keeps the submitted values when the form is rendered again with errors.
+func budgetDTOToViewModel(id string, dto *budget.SaveDTO) *viewmodels.Budget {
+	vm := &viewmodels.Budget{
+		ID:           id,
+		Periodicity:  dto.Periodicity,
+		Amount:       strconv.FormatFloat(dto.Amount, 'f', 2, 64),
+		CurrencyCode: dto.CurrencyCode,
+	}
+	if dto.CategoryID != 0 {
+		vm.CategoryID = strconv.FormatUint(uint64(dto.CategoryID), 10)
+	}
+	if dto.ProjectID != 0 {
+		vm.ProjectID = strconv.FormatUint(uint64(dto.ProjectID), 10)
+	}
+	if start := time.Time(dto.PeriodStart); !start.IsZero() {
+		vm.PeriodStart = start.Format(time.DateOnly)
+	}
+	return vm
+}
+
+// budgetFormError reports the errors the planner can correct on the form.
+func budgetFormError(err error) (map[string]string, bool) {
+	if errors.Is(err, budget.ErrDuplicate) {
+		return map[string]string{"CategoryID": err.Error()}, true
+	}
+	return nil, false
+}
+
+func (c *BudgetController) GetNew(w http.ResponseWriter, r *http.Request) {
+	vm := &viewmodels.Budget{
+		Periodicity: string(budget.Monthly),
+		PeriodStart: budget.Monthly.Start(time.Now()).Format(time.DateOnly),
+	}
+	c.renderForm(w, r, vm, c.basePath, map[string]string{})
+}
+
+func (c *BudgetController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&budget.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderForm(w, r, budgetDTOToViewModel("", dto), c.basePath, errorsMap)
+		return
+	}
+	if _, err := c.budgetService.Create(r.Context(), dto); err != nil {
+		if errorsMap, ok := budgetFormError(err); ok {
+			c.renderForm(w, r, budgetDTOToViewModel("", dto), c.basePath, errorsMap)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *BudgetController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.budgetService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderForm(w, r, mappers.BudgetToViewModel(entity, nil, ""), fmt.Sprintf("%s/%d", c.basePath, id), map[string]string{})
+}
+
+func (c *BudgetController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&budget.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	action := fmt.Sprintf("%s/%d", c.basePath, id)
+	vmID := strconv.FormatUint(uint64(id), 10)
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderForm(w, r, budgetDTOToViewModel(vmID, dto), action, errorsMap)
+		return
+	}
+	if _, err := c.budgetService.Update(r.Context(), id, dto); err != nil {
+		if errorsMap, ok := budgetFormError(err); ok {
+			c.renderForm(w, r, budgetDTOToViewModel(vmID, dto), action, errorsMap)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *BudgetController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.budgetService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/exchangerates"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// ratesShown is the number of most recent exchange rates listed on the exchange rates page.
+const ratesShown = 50
+
+type ExchangeRatesController struct {
+	app             application.Application
+	currencyService *coreservices.CurrencyService
+	basePath        string
+}
+
+func NewExchangeRatesController(app application.Application) application.Controller {
+	return &ExchangeRatesController{
+		app:             app,
+		currencyService: app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService),
+		basePath:        "/finance/exchange-rates",
+	}
+}
+
+func (c *ExchangeRatesController) Key() string {
+	return c.basePath
+}
+
+func (c *ExchangeRatesController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+}
+
+// newRate is the blank form, the quote defaults to the base currency amounts are restated in.
+func newRate() *viewmodels.ExchangeRate {
+	return &viewmodels.ExchangeRate{
+		Date:  time.Now().Format(time.DateOnly),
+		Quote: configuration.Use().BaseCurrency,
+	}
+}
+
+func (c *ExchangeRatesController) render(
+	w http.ResponseWriter, r *http.Request, rate *viewmodels.ExchangeRate, errorsMap map[string]string,
+) {
+	rates, err := c.currencyService.GetRates(r.Context(), &exchangerate.FindParams{Limit: ratesShown})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	currencies, err := c.currencyService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &exchangerates.IndexPageProps{
+		Rates:      mapping.MapViewModels(rates, mappers.ExchangeRateToViewModel),
+		Currencies: mapping.MapViewModels(currencies, coremappers.CurrencyToViewModel),
+		Rate:       rate,
+		BasePath:   c.basePath,
+		Errors:     errorsMap,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(exchangerates.Content(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(exchangerates.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *ExchangeRatesController) List(w http.ResponseWriter, r *http.Request) {
+	c.render(w, r, newRate(), map[string]string{})
+}
+
+func (c *ExchangeRatesController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&exchangerate.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.render(w, r, rateDTOToViewModel(dto), errorsMap)
+		return
+	}
+	if _, err := c.currencyService.SetRate(r.Context(), dto); err != nil {
+		if errors.Is(err, exchangerate.ErrInvalidPair) {
+			c.render(w, r, rateDTOToViewModel(dto), map[string]string{"Quote": err.Error()})
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.render(w, r, newRate(), map[string]string{})
+}
+
+// rateDTOToViewModel keeps the submitted values when the form is rendered again with errors.
+func rateDTOToViewModel(dto *exchangerate.CreateDTO) *viewmodels.ExchangeRate {
+	vm := &viewmodels.ExchangeRate{
+		Base:  dto.Base,
+		Quote: dto.Quote,
+	}
+	if date := time.Time(dto.Date); !date.IsZero() {
+		vm.Date = date.Format(time.DateOnly)
+	}
+	if dto.Rate != 0 {
+		vm.Rate = strconv.FormatFloat(dto.Rate, 'f', -1, 64)
+	}
+	return vm
+}
+
+// rateFormError turns a missing exchange rate into a localized error of the money account field,
+// any other error is returned as is.
+func rateFormError(ctx context.Context, err error) (map[string]string, error) {
+	var rateErr *exchangerate.ErrRateNotFound
+	if !errors.As(err, &rateErr) {
+		return nil, err
+	}
+	localizer, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		return nil, composables.ErrNoLocalizer
+	}
+	return map[string]string{"AccountID": rateErr.Localize(localizer)}, nil
+}
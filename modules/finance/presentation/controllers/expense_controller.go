@@ -197,35 +197,44 @@ func (c *ExpenseController) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if errorsMap, ok := dto.Ok(uniLocalizer); !ok {
-		entity, err := c.expenseService.GetByID(r.Context(), id)
-		if err != nil {
-			http.Error(w, "Error retrieving expense", http.StatusInternalServerError)
-			return
-		}
-		accounts, err := c.viewModelAccounts(r)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		categories, err := c.viewModelCategories(r)
+		c.renderEditForm(w, r, id, errorsMap)
+		return
+	}
+	if err := c.expenseService.Update(r.Context(), id, &dto); err != nil {
+		errorsMap, err := rateFormError(r.Context(), err)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		props := &expenses2.EditPageProps{
-			Expense:    mappers.ExpenseToViewModel(entity),
-			Accounts:   accounts,
-			Categories: categories,
-			Errors:     errorsMap,
-		}
-		templ.Handler(expenses2.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		c.renderEditForm(w, r, id, errorsMap)
 		return
 	}
-	if err := c.expenseService.Update(r.Context(), id, &dto); err != nil {
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *ExpenseController) renderEditForm(w http.ResponseWriter, r *http.Request, id uint, errorsMap map[string]string) {
+	entity, err := c.expenseService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Error retrieving expense", http.StatusInternalServerError)
+		return
+	}
+	accounts, err := c.viewModelAccounts(r)
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	shared.Redirect(w, r, c.basePath)
+	categories, err := c.viewModelCategories(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &expenses2.EditPageProps{
+		Expense:    mappers.ExpenseToViewModel(entity),
+		Accounts:   accounts,
+		Categories: categories,
+		Errors:     errorsMap,
+	}
+	templ.Handler(expenses2.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
 func (c *ExpenseController) GetNew(w http.ResponseWriter, r *http.Request) {
@@ -268,35 +277,46 @@ func (c *ExpenseController) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if errorsMap, ok := dto.Ok(uniLocalizer); !ok {
-		accounts, err := c.viewModelAccounts(r)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		entity, err := dto.ToEntity()
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		categories, err := c.viewModelCategories(r)
+		c.renderCreateForm(w, r, &dto, errorsMap)
+		return
+	}
+
+	if _, err := c.expenseService.Create(r.Context(), &dto); err != nil {
+		errorsMap, err := rateFormError(r.Context(), err)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		props := &expenses2.CreatePageProps{
-			Accounts:   accounts,
-			Errors:     errorsMap,
-			Categories: categories,
-			Expense:    mappers.ExpenseToViewModel(entity),
-		}
-		templ.Handler(expenses2.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		c.renderCreateForm(w, r, &dto, errorsMap)
 		return
 	}
 
-	if err := c.expenseService.Create(r.Context(), &dto); err != nil {
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *ExpenseController) renderCreateForm(
+	w http.ResponseWriter, r *http.Request, dto *expense.CreateDTO, errorsMap map[string]string,
+) {
+	accounts, err := c.viewModelAccounts(r)
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-
-	shared.Redirect(w, r, c.basePath)
+	entity, err := dto.ToEntity()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	categories, err := c.viewModelCategories(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &expenses2.CreatePageProps{
+		Accounts:   accounts,
+		Errors:     errorsMap,
+		Categories: categories,
+		Expense:    mappers.ExpenseToViewModel(entity),
+	}
+	templ.Handler(expenses2.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
@@ -0,0 +1,559 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/documents"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/invoices"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/export"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type InvoiceController struct {
+	app                 application.Application
+	invoiceService      *services.InvoiceService
+	counterpartyService *services.CounterpartyService
+	currencyService     *coreservices.CurrencyService
+	pdfRenderer         *documents.PDFRenderer
+	basePath            string
+}
+
+type InvoiceListQuery struct {
+	Status string
+}
+
+type AgingQuery struct {
+	Date   shared.DateOnly
+	Format export.Format
+}
+
+func NewInvoiceController(app application.Application) application.Controller {
+	return &InvoiceController{
+		app:                 app,
+		invoiceService:      app.Service(services.InvoiceService{}).(*services.InvoiceService),
+		counterpartyService: app.Service(services.CounterpartyService{}).(*services.CounterpartyService),
+		currencyService:     app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService),
+		pdfRenderer:         documents.NewPDFRenderer(configuration.Use().PdfFontsPath),
+		basePath:            "/finance/invoices",
+	}
+}
+
+func (c *InvoiceController) Key() string {
+	return c.basePath
+}
+
+func (c *InvoiceController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/aging", c.Aging).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.Show).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}/edit", c.GetEdit).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}/pdf", c.PDF).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/send", c.Send).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/allocations", c.Allocate).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/allocations/{allocationID:[0-9]+}", c.Deallocate).Methods(http.MethodDelete)
+}
+
+// counterpartyNames maps counterparty ids to names for the invoice view models.
+func (c *InvoiceController) counterpartyNames(r *http.Request) (map[uint]string, error) {
+	counterparties, err := c.counterpartyService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving counterparties")
+	}
+	names := make(map[uint]string, len(counterparties))
+	for _, cp := range counterparties {
+		names[cp.ID()] = cp.Name()
+	}
+	return names, nil
+}
+
+func (c *InvoiceController) List(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&InvoiceListQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := &invoice.FindParams{SortBy: []string{"id"}}
+	if query.Status != "" {
+		params.Status, err = invoice.NewStatus(query.Status)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	paginationParams := composables.UsePaginated(r)
+	params.Limit = paginationParams.Limit
+	params.Offset = paginationParams.Offset
+	entities, err := c.invoiceService.GetPaginated(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	total, err := c.invoiceService.Count(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	names, err := c.counterpartyNames(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	viewInvoices := make([]*viewmodels.Invoice, 0, len(entities))
+	for _, entity := range entities {
+		viewInvoices = append(viewInvoices, mappers.InvoiceToViewModel(entity, names[entity.CounterpartyID]))
+	}
+	props := &invoices.IndexPageProps{
+		Invoices:        viewInvoices,
+		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
+		Status:          query.Status,
+		BasePath:        c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(invoices.InvoicesTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(invoices.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *InvoiceController) formProps(
+	r *http.Request, vm *viewmodels.Invoice, action string, errorsMap map[string]string,
+) (*invoices.FormPageProps, error) {
+	counterparties, err := c.counterpartyService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving counterparties")
+	}
+	currencies, err := c.currencyService.GetAll(r.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving currencies")
+	}
+	return &invoices.FormPageProps{
+		Invoice:        vm,
+		Counterparties: mapping.MapViewModels(counterparties, mappers.CounterpartyToViewModel),
+		Currencies:     mapping.MapViewModels(currencies, coremappers.CurrencyToViewModel),
+		DefaultTaxRate: c.invoiceService.DefaultTaxRate(),
+		Action:         action,
+		Errors:         errorsMap,
+	}, nil
+}
+
+func (c *InvoiceController) renderForm(
+	w http.ResponseWriter, r *http.Request, vm *viewmodels.Invoice, action string, errorsMap map[string]string,
+) {
+	props, err := c.formProps(r, vm, action, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	switch {
+	case shared.IsHxRequest(r):
+		templ.Handler(invoices.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+	case vm.ID != "":
+		templ.Handler(invoices.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+	default:
+		templ.Handler(invoices.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// invoiceDTOToViewModel keeps the submitted values when the form is rendered again with errors.
+func invoiceDTOToViewModel(id string, dto *invoice.SaveDTO) *viewmodels.Invoice {
+	vm := &viewmodels.Invoice{
+		ID:           id,
+		CurrencyCode: dto.CurrencyCode,
+		IssueDate:    time.Time(dto.IssueDate).Format(time.DateOnly),
+		DueDate:      time.Time(dto.DueDate).Format(time.DateOnly),
+		Comment:      dto.Comment,
+	}
+	if dto.CounterpartyID != 0 {
+		vm.CounterpartyID = strconv.FormatUint(uint64(dto.CounterpartyID), 10)
+	}
+	for _, l := range dto.Lines {
+		vm.Lines = append(vm.Lines, &viewmodels.InvoiceLine{
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			TaxRate:     l.TaxRate,
+		})
+	}
+	return vm
+}
+
+func (c *InvoiceController) GetNew(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	vm := &viewmodels.Invoice{
+		IssueDate: now.Format(time.DateOnly),
+		DueDate:   now.AddDate(0, 0, 30).Format(time.DateOnly),
+	}
+	c.renderForm(w, r, vm, c.basePath, map[string]string{})
+}
+
+func (c *InvoiceController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&invoice.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderForm(w, r, invoiceDTOToViewModel("", dto), c.basePath, errorsMap)
+		return
+	}
+	entity, err := c.invoiceService.Create(r.Context(), dto)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, entity.ID))
+}
+
+func (c *InvoiceController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.invoiceService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !entity.IsDraft() {
+		shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+		return
+	}
+	c.renderForm(w, r, mappers.InvoiceToViewModel(entity, ""), fmt.Sprintf("%s/%d", c.basePath, id), map[string]string{})
+}
+
+func (c *InvoiceController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&invoice.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	action := fmt.Sprintf("%s/%d", c.basePath, id)
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderForm(w, r, invoiceDTOToViewModel(strconv.FormatUint(uint64(id), 10), dto), action, errorsMap)
+		return
+	}
+	if err := c.invoiceService.Update(r.Context(), id, dto); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, action)
+}
+
+func (c *InvoiceController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.invoiceService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *InvoiceController) Send(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.invoiceService.Send(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+}
+
+func (c *InvoiceController) showProps(
+	r *http.Request, entity *invoice.Invoice, errorsMap map[string]string,
+) (*invoices.ShowPageProps, error) {
+	cp, err := c.counterpartyService.GetByID(r.Context(), entity.CounterpartyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving counterparty")
+	}
+	props := &invoices.ShowPageProps{
+		Invoice:  mappers.InvoiceToViewModel(entity, cp.Name()),
+		BasePath: c.basePath,
+		Errors:   errorsMap,
+	}
+	if entity.IsDraft() {
+		return props, nil
+	}
+	allocations, err := c.invoiceService.GetAllocations(r.Context(), entity.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving allocations")
+	}
+	props.Allocations = mapping.MapViewModels(allocations, mappers.InvoiceAllocationToViewModel)
+	payments, err := c.invoiceService.GetUnallocatedPayments(r.Context(), entity.CounterpartyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving payments")
+	}
+	for _, p := range payments {
+		if p.CurrencyCode == string(entity.Currency) {
+			props.Payments = append(props.Payments, mappers.InvoicePaymentToViewModel(p))
+		}
+	}
+	return props, nil
+}
+
+func (c *InvoiceController) Show(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.invoiceService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(invoices.Show(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *InvoiceController) Allocate(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&invoice.AllocateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		_, err := c.invoiceService.Allocate(r.Context(), id, dto)
+		switch {
+		case err == nil:
+			shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+			return
+		case errors.Is(err, invoice.ErrOverallocated),
+			errors.Is(err, invoice.ErrPaymentExhausted),
+			errors.Is(err, invoice.ErrCurrencyMismatch),
+			errors.Is(err, invoice.ErrCounterpartyMismatch):
+			errorsMap["Amount"] = err.Error()
+		default:
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+	entity, err := c.invoiceService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(invoices.AllocationsSection(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *InvoiceController) Deallocate(w http.ResponseWriter, r *http.Request) {
+	allocationID, err := strconv.Atoi(mux.Vars(r)["allocationID"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.invoiceService.Deallocate(r.Context(), uint(allocationID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, entity.ID))
+}
+
+func (c *InvoiceController) PDF(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.invoiceService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cp, err := c.counterpartyService.GetByID(r.Context(), entity.CounterpartyID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	pageCtx := composables.UsePageCtx(r.Context())
+	doc := &documents.InvoiceDocument{
+		Invoice:      entity,
+		Counterparty: cp,
+		Labels: documents.InvoiceLabels{
+			Title:       pageCtx.T("Invoices.Document.Title"),
+			IssueDate:   pageCtx.T("Invoices.Single.IssueDate"),
+			DueDate:     pageCtx.T("Invoices.Single.DueDate"),
+			BillTo:      pageCtx.T("Invoices.Document.BillTo"),
+			Tin:         pageCtx.T("Invoices.Document.Tin"),
+			Description: pageCtx.T("Invoices.Lines.Description"),
+			Quantity:    pageCtx.T("Invoices.Lines.Quantity"),
+			UnitPrice:   pageCtx.T("Invoices.Lines.UnitPrice"),
+			TaxRate:     pageCtx.T("Invoices.Lines.TaxRate"),
+			Amount:      pageCtx.T("Invoices.Lines.Amount"),
+			Net:         pageCtx.T("Invoices.Totals.Net"),
+			Tax:         pageCtx.T("Invoices.Totals.Tax"),
+			Total:       pageCtx.T("Invoices.Totals.Total"),
+			Paid:        pageCtx.T("Invoices.Totals.Paid"),
+			Outstanding: pageCtx.T("Invoices.Totals.Outstanding"),
+		},
+	}
+	var buf bytes.Buffer
+	if err := c.pdfRenderer.RenderInvoice(&buf, doc); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", entity.Number+".pdf"))
+	if _, err := buf.WriteTo(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (c *InvoiceController) Aging(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&AgingQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	at := time.Time(query.Date)
+	if at.IsZero() {
+		at = time.Now()
+	}
+	rows, err := c.invoiceService.Aging(r.Context(), at)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	names, err := c.counterpartyNames(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if query.Format != "" {
+		if !query.Format.IsValid() {
+			http.Error(w, "unsupported format", http.StatusBadRequest)
+			return
+		}
+		filename := fmt.Sprintf("receivables_aging_%s", at.Format(time.DateOnly))
+		if err := export.Write(w, query.Format, filename, c.agingTable(r, rows, names)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	viewRows := make([]*viewmodels.InvoiceAgingRow, 0, len(rows))
+	for _, row := range rows {
+		viewRows = append(viewRows, mappers.InvoiceAgingRowToViewModel(row, names[row.CounterpartyID]))
+	}
+	props := &invoices.AgingPageProps{
+		Rows:     viewRows,
+		Date:     at.Format(time.DateOnly),
+		BasePath: c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(invoices.AgingTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(invoices.Aging(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *InvoiceController) agingTable(r *http.Request, rows []*invoice.AgingRow, names map[uint]string) *export.Table {
+	pageCtx := composables.UsePageCtx(r.Context())
+	table := &export.Table{
+		Header: []string{
+			pageCtx.T("Invoices.List.Counterparty"),
+			pageCtx.T("Invoices.Single.Currency"),
+			pageCtx.T("Invoices.Aging.Current"),
+			pageCtx.T("Invoices.Aging.Days1To30"),
+			pageCtx.T("Invoices.Aging.Days31To60"),
+			pageCtx.T("Invoices.Aging.Days61To90"),
+			pageCtx.T("Invoices.Aging.Over90"),
+			pageCtx.T("Invoices.Totals.Total"),
+		},
+	}
+	for _, row := range rows {
+		table.Append(
+			names[row.CounterpartyID],
+			row.Currency,
+			row.Current,
+			row.Days1To30,
+			row.Days31To60,
+			row.Days61To90,
+			row.Over90,
+			row.Total(),
+		)
+	}
+	return table
+}
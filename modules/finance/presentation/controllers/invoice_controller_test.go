@@ -0,0 +1,81 @@
+package controllers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/invoices"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/types"
+)
+
+var lineNamePattern = regexp.MustCompile("x-bind:name=\"`(Lines\\[\\$\\{index}]\\.\\w+)`\"")
+
+// TestInvoiceController_LineEditorForm posts two lines under the input names rendered by the line editor
+// and checks that the controller's form decoder reads both of them.
+func TestInvoiceController_LineEditorForm(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+	if _, err := bundle.LoadMessageFile("../locales/en.json"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := composables.WithPageCtx(context.Background(), &types.PageContext{
+		Locale:    language.English,
+		URL:       &url.URL{Path: "/finance/invoices/new"},
+		Localizer: i18n.NewLocalizer(bundle, "en"),
+	})
+	var html bytes.Buffer
+	props := &invoices.FormPageProps{Invoice: &viewmodels.Invoice{}, Errors: map[string]string{}}
+	if err := invoices.LineEditor(props).Render(ctx, &html); err != nil {
+		t.Fatal(err)
+	}
+	names := lineNamePattern.FindAllStringSubmatch(html.String(), -1)
+	if len(names) != 4 {
+		t.Fatalf("expected 4 line inputs, got %d", len(names))
+	}
+
+	lines := []map[string]string{
+		{"Description": "Consulting", "Quantity": "3", "UnitPrice": "100", "TaxRate": "12"},
+		{"Description": "Hosting", "Quantity": "1", "UnitPrice": "49.99", "TaxRate": "0"},
+	}
+	form := url.Values{
+		"CounterpartyID": {"1"},
+		"CurrencyCode":   {"USD"},
+		"IssueDate":      {"2024-03-01"},
+		"DueDate":        {"2024-03-31"},
+	}
+	for i, line := range lines {
+		for _, name := range names {
+			field := name[1][strings.LastIndex(name[1], ".")+1:]
+			form.Set(strings.Replace(name[1], "${index}", string(rune('0'+i)), 1), line[field])
+		}
+	}
+	r := httptest.NewRequest(http.MethodPost, "/finance/invoices", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	dto, err := composables.UseForm(&invoice.SaveDTO{}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dto.Lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(dto.Lines))
+	}
+	if dto.Lines[0].Description != "Consulting" || dto.Lines[0].Quantity != 3 || dto.Lines[0].TaxRate != 12 {
+		t.Errorf("unexpected first line %+v", dto.Lines[0])
+	}
+	if dto.Lines[1].Description != "Hosting" || dto.Lines[1].UnitPrice != 49.99 {
+		t.Errorf("unexpected second line %+v", dto.Lines[1])
+	}
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/ledger"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type LedgerController struct {
+	app           application.Application
+	ledgerService *services.LedgerService
+	basePath      string
+}
+
+type DateRangeQuery struct {
+	From shared.DateOnly
+	To   shared.DateOnly
+}
+
+// Range returns the requested range, defaulting to the beginning of the current year until today.
+func (q *DateRangeQuery) Range() (time.Time, time.Time) {
+	now := time.Now()
+	from, to := time.Time(q.From), time.Time(q.To)
+	if from.IsZero() {
+		from = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if to.IsZero() {
+		to = now
+	}
+	return from, to
+}
+
+func NewLedgerController(app application.Application) application.Controller {
+	return &LedgerController{
+		app:           app,
+		ledgerService: app.Service(services.LedgerService{}).(*services.LedgerService),
+		basePath:      "/finance/ledger",
+	}
+}
+
+func (c *LedgerController) Key() string {
+	return c.basePath
+}
+
+func (c *LedgerController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", c.TrialBalance).Methods(http.MethodGet)
+}
+
+func (c *LedgerController) TrialBalance(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&DateRangeQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to := query.Range()
+	trialBalance, err := c.ledgerService.TrialBalance(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &ledger.TrialBalancePageProps{
+		TrialBalance: mappers.TrialBalanceToViewModel(trialBalance),
+		From:         from.Format(time.DateOnly),
+		To:           to.Format(time.DateOnly),
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(ledger.TrialBalanceTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(ledger.TrialBalance(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
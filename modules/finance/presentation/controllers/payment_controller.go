@@ -197,25 +197,18 @@ func (c *PaymentsController) Update(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if errorsMap, ok := dto.Ok(uniLocalizer); !ok {
-			paymentViewModel, err := c.viewModelPayment(r)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			accounts, err := c.viewModelAccounts(r)
+			c.renderEditForm(w, r, errorsMap)
+			return
+		}
+		if err := c.paymentService.Update(r.Context(), id, &dto); err != nil {
+			errorsMap, err := rateFormError(r.Context(), err)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
 			}
-			props := &payments.EditPageProps{
-				Payment:  paymentViewModel,
-				Accounts: accounts,
-				Errors:   errorsMap,
-			}
-			templ.Handler(payments.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+			c.renderEditForm(w, r, errorsMap)
 			return
 		}
-		err = c.paymentService.Update(r.Context(), id, &dto)
 	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -259,24 +252,54 @@ func (c *PaymentsController) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if errorsMap, ok := dto.Ok(uniLocalizer); !ok {
-		accounts, err := c.viewModelAccounts(r)
+		c.renderCreateForm(w, r, dto, errorsMap)
+		return
+	}
+
+	if _, err := c.paymentService.Create(r.Context(), dto); err != nil {
+		errorsMap, err := rateFormError(r.Context(), err)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("%+v", err), http.StatusInternalServerError)
 			return
 		}
-		props := &payments.CreatePageProps{
-			Payment:  mappers.PaymentToViewModel(dto.ToEntity()),
-			Accounts: accounts,
-			Errors:   errorsMap,
-		}
-		templ.Handler(payments.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+		c.renderCreateForm(w, r, dto, errorsMap)
 		return
 	}
 
-	if err := c.paymentService.Create(r.Context(), dto); err != nil {
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *PaymentsController) renderCreateForm(
+	w http.ResponseWriter, r *http.Request, dto *payment.CreateDTO, errorsMap map[string]string,
+) {
+	accounts, err := c.viewModelAccounts(r)
+	if err != nil {
 		http.Error(w, fmt.Sprintf("%+v", err), http.StatusInternalServerError)
 		return
 	}
+	props := &payments.CreatePageProps{
+		Payment:  mappers.PaymentToViewModel(dto.ToEntity()),
+		Accounts: accounts,
+		Errors:   errorsMap,
+	}
+	templ.Handler(payments.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
 
-	shared.Redirect(w, r, c.basePath)
+func (c *PaymentsController) renderEditForm(w http.ResponseWriter, r *http.Request, errorsMap map[string]string) {
+	paymentViewModel, err := c.viewModelPayment(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	accounts, err := c.viewModelAccounts(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &payments.EditPageProps{
+		Payment:  paymentViewModel,
+		Accounts: accounts,
+		Errors:   errorsMap,
+	}
+	templ.Handler(payments.EditForm(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
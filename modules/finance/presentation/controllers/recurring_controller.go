@@ -0,0 +1,360 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/go-faster/errors"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	recurringtemplates "github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/recurring"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+const (
+	recurringPreviewSize = 6
+	recurringHistorySize = 12
+)
+
+type RecurringTransactionController struct {
+	app                    application.Application
+	recurringService       *services.RecurringService
+	moneyAccountService    *services.MoneyAccountService
+	expenseCategoryService *services.ExpenseCategoryService
+	counterpartyService    *services.CounterpartyService
+	basePath               string
+}
+
+type RecurringListQuery struct {
+	Kind string
+}
+
+func NewRecurringTransactionController(app application.Application) application.Controller {
+	return &RecurringTransactionController{
+		app:                    app,
+		recurringService:       app.Service(services.RecurringService{}).(*services.RecurringService),
+		moneyAccountService:    app.Service(services.MoneyAccountService{}).(*services.MoneyAccountService),
+		expenseCategoryService: app.Service(services.ExpenseCategoryService{}).(*services.ExpenseCategoryService),
+		counterpartyService:    app.Service(services.CounterpartyService{}).(*services.CounterpartyService),
+		basePath:               "/finance/recurring",
+	}
+}
+
+func (c *RecurringTransactionController) Key() string {
+	return c.basePath
+}
+
+func (c *RecurringTransactionController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/preview", c.Preview).Methods(http.MethodPost)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+}
+
+// names maps money account, expense category and counterparty ids to names for the view models.
+func (c *RecurringTransactionController) names(r *http.Request) (map[uint]string, map[uint]string, map[uint]string, error) {
+	accounts, err := c.moneyAccountService.GetAll(r.Context())
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "Error retrieving accounts")
+	}
+	categories, err := c.expenseCategoryService.GetAll(r.Context())
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "Error retrieving categories")
+	}
+	counterparties, err := c.counterpartyService.GetAll(r.Context())
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "Error retrieving counterparties")
+	}
+	accountNames := make(map[uint]string, len(accounts))
+	for _, a := range accounts {
+		accountNames[a.ID] = a.Name
+	}
+	categoryNames := make(map[uint]string, len(categories))
+	for _, cat := range categories {
+		categoryNames[cat.ID()] = cat.Name()
+	}
+	counterpartyNames := make(map[uint]string, len(counterparties))
+	for _, cp := range counterparties {
+		counterpartyNames[cp.ID()] = cp.Name()
+	}
+	return accountNames, categoryNames, counterpartyNames, nil
+}
+
+func (c *RecurringTransactionController) List(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&RecurringListQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := &recurring.FindParams{SortBy: []string{"name", "id"}}
+	if query.Kind != "" {
+		params.Kind, err = recurring.NewKind(query.Kind)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	paginationParams := composables.UsePaginated(r)
+	params.Limit = paginationParams.Limit
+	params.Offset = paginationParams.Offset
+	entities, err := c.recurringService.GetPaginated(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	total, err := c.recurringService.Count(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	accountNames, categoryNames, counterpartyNames, err := c.names(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	viewTransactions := make([]*viewmodels.RecurringTransaction, 0, len(entities))
+	for _, entity := range entities {
+		target := ""
+		switch {
+		case entity.CategoryID != nil:
+			target = categoryNames[*entity.CategoryID]
+		case entity.CounterpartyID != nil:
+			target = counterpartyNames[*entity.CounterpartyID]
+		}
+		viewTransactions = append(
+			viewTransactions,
+			mappers.RecurringTransactionToViewModel(entity, accountNames[entity.AccountID], target),
+		)
+	}
+	props := &recurringtemplates.IndexPageProps{
+		Transactions:    viewTransactions,
+		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
+		Kind:            query.Kind,
+		BasePath:        c.basePath,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(recurringtemplates.TransactionsTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(recurringtemplates.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *RecurringTransactionController) renderForm(
+	w http.ResponseWriter,
+	r *http.Request,
+	vm *viewmodels.RecurringTransaction,
+	action string,
+	errorsMap map[string]string,
+) {
+	accounts, err := c.moneyAccountService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving accounts").Error(), http.StatusInternalServerError)
+		return
+	}
+	categories, err := c.expenseCategoryService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving categories").Error(), http.StatusInternalServerError)
+		return
+	}
+	counterparties, err := c.counterpartyService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "Error retrieving counterparties").Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &recurringtemplates.FormPageProps{
+		Transaction:    vm,
+		Accounts:       mapping.MapViewModels(accounts, mappers.MoneyAccountToViewModel),
+		Categories:     mapping.MapViewModels(categories, mappers.ExpenseCategoryToViewModel),
+		Counterparties: mapping.MapViewModels(counterparties, mappers.CounterpartyToViewModel),
+		Action:         action,
+		BasePath:       c.basePath,
+		Errors:         errorsMap,
+	}
+	if vm.ID != "" {
+		id, err := strconv.ParseUint(vm.ID, 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		occurrences, err := c.recurringService.GetOccurrences(r.Context(), uint(id), recurringHistorySize)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "Error retrieving occurrences").Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Occurrences = mapping.MapViewModels(occurrences, mappers.RecurringOccurrenceToViewModel)
+	}
+	switch {
+	case shared.IsHxRequest(r):
+		templ.Handler(recurringtemplates.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+	case vm.ID != "":
+		templ.Handler(recurringtemplates.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
+	default:
+		templ.Handler(recurringtemplates.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// recurringDTOToViewModel keeps the submitted values when the form is rendered again with errors.
+func recurringDTOToViewModel(id string, dto *recurring.SaveDTO) *viewmodels.RecurringTransaction {
+	vm := &viewmodels.RecurringTransaction{
+		ID:       id,
+		Kind:     dto.Kind,
+		Name:     dto.Name,
+		Schedule: dto.Schedule,
+		Amount:   strconv.FormatFloat(dto.Amount, 'f', 2, 64),
+		Comment:  dto.Comment,
+	}
+	if dto.AccountID != 0 {
+		vm.AccountID = strconv.FormatUint(uint64(dto.AccountID), 10)
+	}
+	if dto.CategoryID != 0 {
+		vm.CategoryID = strconv.FormatUint(uint64(dto.CategoryID), 10)
+	}
+	if dto.CounterpartyID != 0 {
+		vm.CounterpartyID = strconv.FormatUint(uint64(dto.CounterpartyID), 10)
+	}
+	if start := time.Time(dto.StartDate); !start.IsZero() {
+		vm.StartDate = start.Format(time.DateOnly)
+	}
+	if end := time.Time(dto.EndDate); !end.IsZero() {
+		vm.EndDate = end.Format(time.DateOnly)
+	}
+	return vm
+}
+
+func (c *RecurringTransactionController) GetNew(w http.ResponseWriter, r *http.Request) {
+	vm := &viewmodels.RecurringTransaction{
+		Kind:      string(recurring.Expense),
+		Schedule:  "FREQ=MONTHLY",
+		StartDate: time.Now().Format(time.DateOnly),
+	}
+	c.renderForm(w, r, vm, c.basePath, map[string]string{})
+}
+
+// Preview renders the upcoming occurrences of the template being edited.
+func (c *RecurringTransactionController) Preview(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&recurring.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	props := &recurringtemplates.PreviewProps{}
+	dates, err := c.recurringService.Preview(dto, recurringPreviewSize)
+	if err != nil {
+		props.Error = err.Error()
+	}
+	for _, d := range dates {
+		props.Dates = append(props.Dates, d.Format(time.DateOnly))
+	}
+	templ.Handler(recurringtemplates.Preview(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *RecurringTransactionController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&recurring.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderForm(w, r, recurringDTOToViewModel("", dto), c.basePath, errorsMap)
+		return
+	}
+	if _, err := c.recurringService.Create(r.Context(), dto); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *RecurringTransactionController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.recurringService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderForm(
+		w, r,
+		mappers.RecurringTransactionToViewModel(entity, "", ""),
+		fmt.Sprintf("%s/%d", c.basePath, id),
+		map[string]string{},
+	)
+}
+
+func (c *RecurringTransactionController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&recurring.SaveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	action := fmt.Sprintf("%s/%d", c.basePath, id)
+	if errorsMap, ok := dto.Ok(uniTranslator); !ok {
+		c.renderForm(w, r, recurringDTOToViewModel(strconv.FormatUint(uint64(id), 10), dto), action, errorsMap)
+		return
+	}
+	if _, err := c.recurringService.Update(r.Context(), id, dto); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *RecurringTransactionController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.recurringService.Delete(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
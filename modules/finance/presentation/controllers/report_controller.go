@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/report"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/finance/presentation/templates/pages/reports"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/export"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type ReportController struct {
+	app           application.Application
+	reportService *services.ReportService
+	basePath      string
+}
+
+type ReportQuery struct {
+	DateRangeQuery
+	GroupBy string
+	Format  export.Format
+}
+
+func NewReportController(app application.Application) application.Controller {
+	return &ReportController{
+		app:           app,
+		reportService: app.Service(services.ReportService{}).(*services.ReportService),
+		basePath:      "/finance/reports",
+	}
+}
+
+func (c *ReportController) Key() string {
+	return c.basePath
+}
+
+func (c *ReportController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", c.Index).Methods(http.MethodGet)
+	router.HandleFunc("/{kind}", c.Show).Methods(http.MethodGet)
+}
+
+func (c *ReportController) Index(w http.ResponseWriter, r *http.Request) {
+	shared.Redirect(w, r, fmt.Sprintf("%s/%s", c.basePath, report.ProfitAndLoss))
+}
+
+func (c *ReportController) Show(w http.ResponseWriter, r *http.Request) {
+	kind, err := report.NewKind(mux.Vars(r)["kind"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	query, err := composables.UseQuery(&ReportQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to := query.Range()
+	if to.Before(from) {
+		http.Error(w, "invalid date range", http.StatusBadRequest)
+		return
+	}
+	byMonth := query.GroupBy == "month"
+	entity, err := c.reportService.Build(r.Context(), kind, from, to, byMonth)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if query.Format != "" {
+		if !query.Format.IsValid() {
+			http.Error(w, "unsupported format", http.StatusBadRequest)
+			return
+		}
+		filename := fmt.Sprintf("%s_%s_%s", kind, from.Format(time.DateOnly), to.Format(time.DateOnly))
+		if err := export.Write(w, query.Format, filename, c.toTable(r, entity)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	props := &reports.IndexPageProps{
+		Report:   mappers.ReportToViewModel(entity),
+		BasePath: c.basePath,
+		From:     from.Format(time.DateOnly),
+		To:       to.Format(time.DateOnly),
+		GroupBy:  query.GroupBy,
+	}
+	if shared.IsHxRequest(r) {
+		templ.Handler(reports.ReportTable(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(reports.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+func (c *ReportController) toTable(r *http.Request, entity *report.Report) *export.Table {
+	pageCtx := composables.UsePageCtx(r.Context())
+	table := &export.Table{
+		Header: []string{fmt.Sprintf("%s, %s", pageCtx.T(fmt.Sprintf("Reports.Kinds.%s", entity.Kind)), entity.Currency)},
+	}
+	for _, column := range entity.Columns {
+		table.Header = append(table.Header, mappers.ReportColumnLabel(column))
+	}
+	if entity.HasTotal() {
+		table.Header = append(table.Header, pageCtx.T("Reports.List.Total"))
+	}
+	for _, row := range entity.Rows {
+		label := row.Label
+		if row.Type != report.RowAccount {
+			label = pageCtx.T(row.Label)
+		}
+		cells := []any{label}
+		if row.Type != report.RowSection {
+			for _, v := range row.Values {
+				cells = append(cells, v)
+			}
+			if entity.HasTotal() {
+				cells = append(cells, row.Total())
+			}
+		}
+		table.Append(cells...)
+	}
+	return table
+}
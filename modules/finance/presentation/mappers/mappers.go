@@ -6,10 +6,20 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
+	accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/budget"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
 	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
 	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
+	ledgeraccount "github.com/iota-uz/iota-sdk/modules/finance/domain/entities/ledger_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/report"
 	"github.com/iota-uz/iota-sdk/modules/finance/presentation/viewmodels"
 )
 
@@ -97,3 +107,283 @@ func CounterpartyToViewModel(entity counterparty.Counterparty) *viewmodels.Count
 		UpdatedAt:    entity.UpdatedAt().Format(time.RFC3339),
 	}
 }
+
+func LedgerAccountToViewModel(entity *ledgeraccount.Account) *viewmodels.LedgerAccount {
+	return &viewmodels.LedgerAccount{
+		ID:   strconv.FormatUint(uint64(entity.ID), 10),
+		Code: entity.Code,
+		Name: entity.Name,
+		Type: string(entity.Type),
+	}
+}
+
+func TrialBalanceToViewModel(entity *journalentry.TrialBalance) *viewmodels.TrialBalance {
+	lines := make([]*viewmodels.TrialBalanceLine, 0, len(entity.Lines))
+	for _, l := range entity.Lines {
+		lines = append(lines, &viewmodels.TrialBalanceLine{
+			Account: LedgerAccountToViewModel(l.Account),
+			Debit:   fmt.Sprintf("%.2f", l.Debit),
+			Credit:  fmt.Sprintf("%.2f", l.Credit),
+			Balance: fmt.Sprintf("%.2f", l.Balance()),
+		})
+	}
+	return &viewmodels.TrialBalance{
+		Lines:       lines,
+		TotalDebit:  fmt.Sprintf("%.2f", entity.TotalDebit()),
+		TotalCredit: fmt.Sprintf("%.2f", entity.TotalCredit()),
+		IsBalanced:  entity.IsBalanced(),
+	}
+}
+
+func AccountingPeriodToViewModel(entity *accountingperiod.Period) *viewmodels.AccountingPeriod {
+	var closedAt string
+	if entity.ClosedAt != nil {
+		closedAt = entity.ClosedAt.Format(time.RFC3339)
+	}
+	return &viewmodels.AccountingPeriod{
+		Month:    entity.Start.Format("2006-01"),
+		Status:   string(entity.Status),
+		ClosedAt: closedAt,
+	}
+}
+
+func ExchangeRateToViewModel(entity *exchangerate.ExchangeRate) *viewmodels.ExchangeRate {
+	return &viewmodels.ExchangeRate{
+		ID:    strconv.FormatUint(uint64(entity.ID), 10),
+		Date:  entity.Date.Format(time.DateOnly),
+		Base:  string(entity.Base),
+		Quote: string(entity.Quote),
+		Rate:  strconv.FormatFloat(entity.Rate, 'f', -1, 64),
+	}
+}
+
+// ReportColumnLabel names a report column by its month, or by its date range when it spans several months.
+func ReportColumnLabel(column report.Column) string {
+	if column.From.Year() == column.To.Year() && column.From.Month() == column.To.Month() {
+		return column.From.Format("2006-01")
+	}
+	return fmt.Sprintf("%s – %s", column.From.Format(time.DateOnly), column.To.Format(time.DateOnly))
+}
+
+func ReportToViewModel(entity *report.Report) *viewmodels.Report {
+	columns := make([]string, 0, len(entity.Columns))
+	for _, c := range entity.Columns {
+		columns = append(columns, ReportColumnLabel(c))
+	}
+	rows := make([]*viewmodels.ReportRow, 0, len(entity.Rows))
+	for _, r := range entity.Rows {
+		values := make([]string, 0, len(r.Values))
+		for _, v := range r.Values {
+			values = append(values, fmt.Sprintf("%.2f", v))
+		}
+		row := &viewmodels.ReportRow{
+			Label:     r.Label,
+			Translate: r.Type != report.RowAccount,
+			IsSection: r.Type == report.RowSection,
+			IsTotal:   r.Type == report.RowTotal,
+			Values:    values,
+		}
+		if entity.HasTotal() && r.Type != report.RowSection {
+			row.Total = fmt.Sprintf("%.2f", r.Total())
+		}
+		rows = append(rows, row)
+	}
+	return &viewmodels.Report{
+		Kind:     string(entity.Kind),
+		Currency: entity.Currency,
+		Columns:  columns,
+		HasTotal: entity.HasTotal(),
+		Rows:     rows,
+	}
+}
+
+func BankStatementLineToViewModel(entity *bankstatement.Line) *viewmodels.BankStatementLine {
+	var transactionID string
+	if entity.TransactionID != nil {
+		transactionID = strconv.FormatUint(uint64(*entity.TransactionID), 10)
+	}
+	return &viewmodels.BankStatementLine{
+		ID:               strconv.FormatUint(uint64(entity.ID), 10),
+		Date:             entity.Date.Format(time.DateOnly),
+		Amount:           fmt.Sprintf("%.2f", entity.Amount),
+		Currency:         entity.Currency,
+		Description:      entity.Description,
+		CounterpartyName: entity.CounterpartyName,
+		CounterpartyTIN:  entity.CounterpartyTIN,
+		Reference:        entity.Reference,
+		Status:           string(entity.Status),
+		TransactionID:    transactionID,
+		IsInflow:         entity.IsInflow(),
+	}
+}
+
+func BankStatementToViewModel(entity *bankstatement.Statement) *viewmodels.BankStatement {
+	lines := make([]*viewmodels.BankStatementLine, 0, len(entity.Lines))
+	for _, l := range entity.Lines {
+		lines = append(lines, BankStatementLineToViewModel(l))
+	}
+	return &viewmodels.BankStatement{
+		ID:             strconv.FormatUint(uint64(entity.ID), 10),
+		MoneyAccountID: strconv.FormatUint(uint64(entity.MoneyAccountID), 10),
+		Format:         string(entity.Format),
+		FileName:       entity.FileName,
+		CreatedAt:      entity.CreatedAt.Format(time.RFC3339),
+		Lines:          lines,
+	}
+}
+
+// InvoiceToViewModel shows the invoice as of now, so unpaid invoices past due are reported as overdue.
+func InvoiceToViewModel(entity *invoice.Invoice, counterpartyName string) *viewmodels.Invoice {
+	lines := make([]*viewmodels.InvoiceLine, 0, len(entity.Lines))
+	for _, l := range entity.Lines {
+		lines = append(lines, &viewmodels.InvoiceLine{
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			TaxRate:     l.TaxRate,
+			Total:       fmt.Sprintf("%.2f", l.Total()),
+		})
+	}
+	return &viewmodels.Invoice{
+		ID:               strconv.FormatUint(uint64(entity.ID), 10),
+		Number:           entity.Number,
+		CounterpartyID:   strconv.FormatUint(uint64(entity.CounterpartyID), 10),
+		CounterpartyName: counterpartyName,
+		CurrencyCode:     string(entity.Currency),
+		IssueDate:        entity.IssueDate.Format(time.DateOnly),
+		DueDate:          entity.DueDate.Format(time.DateOnly),
+		Status:           string(entity.StatusAt(time.Now())),
+		Comment:          entity.Comment,
+		Net:              fmt.Sprintf("%.2f", entity.Net()),
+		Tax:              fmt.Sprintf("%.2f", entity.Tax()),
+		Total:            fmt.Sprintf("%.2f", entity.Total()),
+		Paid:             fmt.Sprintf("%.2f", entity.PaidAmount),
+		Outstanding:      fmt.Sprintf("%.2f", entity.Outstanding()),
+		Lines:            lines,
+		CreatedAt:        entity.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func InvoiceAllocationToViewModel(entity *invoice.Allocation) *viewmodels.InvoiceAllocation {
+	return &viewmodels.InvoiceAllocation{
+		ID:        strconv.FormatUint(uint64(entity.ID), 10),
+		PaymentID: strconv.FormatUint(uint64(entity.PaymentID), 10),
+		Amount:    fmt.Sprintf("%.2f", entity.Amount),
+		Date:      entity.Date.Format(time.DateOnly),
+	}
+}
+
+func InvoicePaymentToViewModel(entity *invoice.PaymentBalance) *viewmodels.InvoicePayment {
+	return &viewmodels.InvoicePayment{
+		PaymentID:   strconv.FormatUint(uint64(entity.PaymentID), 10),
+		Date:        entity.Date.Format(time.DateOnly),
+		Amount:      fmt.Sprintf("%.2f", entity.Amount),
+		Unallocated: fmt.Sprintf("%.2f", entity.Unallocated()),
+		Currency:    entity.CurrencyCode,
+	}
+}
+
+func InvoiceAgingRowToViewModel(entity *invoice.AgingRow, counterpartyName string) *viewmodels.InvoiceAgingRow {
+	return &viewmodels.InvoiceAgingRow{
+		CounterpartyName: counterpartyName,
+		Currency:         entity.Currency,
+		Current:          fmt.Sprintf("%.2f", entity.Current),
+		Days1To30:        fmt.Sprintf("%.2f", entity.Days1To30),
+		Days31To60:       fmt.Sprintf("%.2f", entity.Days31To60),
+		Days61To90:       fmt.Sprintf("%.2f", entity.Days61To90),
+		Over90:           fmt.Sprintf("%.2f", entity.Over90),
+		Total:            fmt.Sprintf("%.2f", entity.Total()),
+	}
+}
+
+func formatOptionalDate(t *time.Time, layout string) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+func formatOptionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+func BillToViewModel(entity *bill.Bill, counterpartyName, categoryName string) *viewmodels.Bill {
+	return &viewmodels.Bill{
+		ID:               strconv.FormatUint(uint64(entity.ID), 10),
+		Number:           entity.Number,
+		CounterpartyID:   strconv.FormatUint(uint64(entity.CounterpartyID), 10),
+		CounterpartyName: counterpartyName,
+		CategoryID:       strconv.FormatUint(uint64(entity.CategoryID), 10),
+		CategoryName:     categoryName,
+		CurrencyCode:     string(entity.Currency),
+		Amount:           fmt.Sprintf("%.2f", entity.Amount),
+		BillDate:         entity.BillDate.Format(time.DateOnly),
+		DueDate:          entity.DueDate.Format(time.DateOnly),
+		Description:      entity.Description,
+		Status:           string(entity.Status),
+		Overdue:          entity.IsOverdue(time.Now()),
+		RejectionReason:  entity.RejectionReason,
+		ApprovedAt:       formatOptionalDate(entity.ApprovedAt, time.DateTime),
+		ScheduledDate:    formatOptionalDate(entity.ScheduledDate, time.DateOnly),
+		MoneyAccountID:   formatOptionalID(entity.MoneyAccountID),
+		ExpenseID:        formatOptionalID(entity.ExpenseID),
+		PaidAt:           formatOptionalDate(entity.PaidAt, time.DateTime),
+		CreatedAt:        entity.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// BudgetToViewModel maps a budget along with its actual spending; variance may be nil when it is not needed.
+func BudgetToViewModel(entity *budget.Budget, variance *budget.Variance, categoryName string) *viewmodels.Budget {
+	vm := &viewmodels.Budget{
+		ID:           strconv.FormatUint(uint64(entity.ID), 10),
+		CategoryID:   strconv.FormatUint(uint64(entity.CategoryID), 10),
+		CategoryName: categoryName,
+		ProjectID:    formatOptionalID(entity.ProjectID),
+		Periodicity:  string(entity.Periodicity),
+		PeriodStart:  entity.PeriodStart.Format(time.DateOnly),
+		PeriodEnd:    entity.PeriodEnd().AddDate(0, 0, -1).Format(time.DateOnly),
+		Amount:       fmt.Sprintf("%.2f", entity.Amount),
+		CurrencyCode: string(entity.Currency),
+	}
+	if variance != nil {
+		vm.Actual = fmt.Sprintf("%.2f", variance.Actual)
+		vm.Remaining = fmt.Sprintf("%.2f", variance.Remaining())
+		vm.Utilization = variance.Utilization()
+		vm.Exceeded = variance.IsExceeded()
+	}
+	return vm
+}
+
+func RecurringTransactionToViewModel(
+	entity *recurring.Template, accountName, targetName string,
+) *viewmodels.RecurringTransaction {
+	return &viewmodels.RecurringTransaction{
+		ID:             strconv.FormatUint(uint64(entity.ID), 10),
+		Kind:           string(entity.Kind),
+		Name:           entity.Name,
+		Schedule:       entity.Schedule.String(),
+		Amount:         fmt.Sprintf("%.2f", entity.Amount),
+		AccountID:      strconv.FormatUint(uint64(entity.AccountID), 10),
+		AccountName:    accountName,
+		CategoryID:     formatOptionalID(entity.CategoryID),
+		CounterpartyID: formatOptionalID(entity.CounterpartyID),
+		TargetName:     targetName,
+		Comment:        entity.Comment,
+		StartDate:      entity.StartDate.Format(time.DateOnly),
+		EndDate:        formatOptionalDate(entity.EndDate, time.DateOnly),
+		LastRunDate:    formatOptionalDate(entity.LastRunDate, time.DateOnly),
+		NextRunDate:    formatOptionalDate(entity.NextRunDate, time.DateOnly),
+	}
+}
+
+func RecurringOccurrenceToViewModel(entity *recurring.Occurrence) *viewmodels.RecurringOccurrence {
+	return &viewmodels.RecurringOccurrence{
+		Date:      entity.Date.Format(time.DateOnly),
+		PaymentID: formatOptionalID(entity.PaymentID),
+		ExpenseID: formatOptionalID(entity.ExpenseID),
+		CreatedAt: entity.CreatedAt.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,21 @@
+package viewmodels
+
+import accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+
+type AccountingPeriod struct {
+	Month    string
+	Status   string
+	ClosedAt string
+}
+
+func (p *AccountingPeriod) CanClose() bool {
+	return p.Status == string(accountingperiod.Open)
+}
+
+func (p *AccountingPeriod) CanReopen() bool {
+	return p.Status == string(accountingperiod.Closed)
+}
+
+func (p *AccountingPeriod) CanLock() bool {
+	return p.Status == string(accountingperiod.Closed)
+}
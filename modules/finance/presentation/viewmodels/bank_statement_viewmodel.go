@@ -0,0 +1,42 @@
+package viewmodels
+
+import bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+
+type BankStatement struct {
+	ID             string
+	MoneyAccountID string
+	Format         string
+	FileName       string
+	CreatedAt      string
+	Lines          []*BankStatementLine
+}
+
+type BankStatementLine struct {
+	ID               string
+	Date             string
+	Amount           string
+	Currency         string
+	Description      string
+	CounterpartyName string
+	CounterpartyTIN  string
+	Reference        string
+	Status           string
+	TransactionID    string
+	IsInflow         bool
+}
+
+func (l *BankStatementLine) CanConfirm() bool {
+	return l.Status == string(bankstatement.Matched)
+}
+
+func (l *BankStatementLine) CanCreate() bool {
+	return l.Status == string(bankstatement.Unmatched)
+}
+
+func (l *BankStatementLine) CanIgnore() bool {
+	return l.Status == string(bankstatement.Unmatched) || l.Status == string(bankstatement.Matched)
+}
+
+func (l *BankStatementLine) CanReset() bool {
+	return l.Status != string(bankstatement.Unmatched)
+}
@@ -0,0 +1,46 @@
+package viewmodels
+
+import "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+
+type Bill struct {
+	ID               string
+	Number           string
+	CounterpartyID   string
+	CounterpartyName string
+	CategoryID       string
+	CategoryName     string
+	CurrencyCode     string
+	Amount           string
+	BillDate         string
+	DueDate          string
+	Description      string
+	Status           string
+	Overdue          bool
+	RejectionReason  string
+	ApprovedAt       string
+	ScheduledDate    string
+	MoneyAccountID   string
+	ExpenseID        string
+	PaidAt           string
+	CreatedAt        string
+}
+
+func (b *Bill) IsEditable() bool {
+	return b.Status == string(bill.Submitted) || b.Status == string(bill.Rejected)
+}
+
+func (b *Bill) CanApprove() bool {
+	return b.Status == string(bill.Submitted)
+}
+
+func (b *Bill) CanReject() bool {
+	return b.Status == string(bill.Submitted) || b.Status == string(bill.Approved)
+}
+
+func (b *Bill) CanSchedule() bool {
+	return b.Status == string(bill.Approved) || b.Status == string(bill.Scheduled)
+}
+
+func (b *Bill) CanPay() bool {
+	return b.Status == string(bill.Scheduled)
+}
@@ -0,0 +1,28 @@
+package viewmodels
+
+import "fmt"
+
+type Budget struct {
+	ID           string
+	CategoryID   string
+	CategoryName string
+	ProjectID    string
+	Periodicity  string
+	PeriodStart  string
+	PeriodEnd    string
+	Amount       string
+	Actual       string
+	Remaining    string
+	Utilization  float64
+	Exceeded     bool
+	CurrencyCode string
+}
+
+// UtilizationWidth is the width of the utilization bar, capped at the full bar.
+func (b *Budget) UtilizationWidth() string {
+	width := b.Utilization
+	if width > 100 {
+		width = 100
+	}
+	return fmt.Sprintf("width: %.0f%%", width)
+}
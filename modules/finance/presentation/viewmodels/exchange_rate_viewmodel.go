@@ -0,0 +1,9 @@
+package viewmodels
+
+type ExchangeRate struct {
+	ID    string
+	Date  string
+	Base  string
+	Quote string
+	Rate  string
+}
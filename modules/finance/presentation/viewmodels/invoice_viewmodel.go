@@ -0,0 +1,67 @@
+package viewmodels
+
+import "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+
+type Invoice struct {
+	ID               string
+	Number           string
+	CounterpartyID   string
+	CounterpartyName string
+	CurrencyCode     string
+	IssueDate        string
+	DueDate          string
+	Status           string
+	Comment          string
+	Net              string
+	Tax              string
+	Total            string
+	Paid             string
+	Outstanding      string
+	Lines            []*InvoiceLine
+	CreatedAt        string
+}
+
+// InvoiceLine keeps numbers as plain decimals so that it can seed the line editor of the invoice form.
+type InvoiceLine struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unitPrice"`
+	TaxRate     float64 `json:"taxRate"`
+	Total       string  `json:"-"`
+}
+
+func (i *Invoice) IsDraft() bool {
+	return i.Status == string(invoice.Draft)
+}
+
+func (i *Invoice) CanAllocate() bool {
+	return i.Status == string(invoice.Sent) ||
+		i.Status == string(invoice.PartiallyPaid) ||
+		i.Status == string(invoice.Overdue)
+}
+
+type InvoiceAllocation struct {
+	ID        string
+	PaymentID string
+	Amount    string
+	Date      string
+}
+
+type InvoicePayment struct {
+	PaymentID   string
+	Date        string
+	Amount      string
+	Unallocated string
+	Currency    string
+}
+
+type InvoiceAgingRow struct {
+	CounterpartyName string
+	Currency         string
+	Current          string
+	Days1To30        string
+	Days31To60       string
+	Days61To90       string
+	Over90           string
+	Total            string
+}
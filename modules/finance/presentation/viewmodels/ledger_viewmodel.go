@@ -0,0 +1,22 @@
+package viewmodels
+
+type LedgerAccount struct {
+	ID   string
+	Code string
+	Name string
+	Type string
+}
+
+type TrialBalanceLine struct {
+	Account *LedgerAccount
+	Debit   string
+	Credit  string
+	Balance string
+}
+
+type TrialBalance struct {
+	Lines       []*TrialBalanceLine
+	TotalDebit  string
+	TotalCredit string
+	IsBalanced  bool
+}
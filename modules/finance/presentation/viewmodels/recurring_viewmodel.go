@@ -0,0 +1,32 @@
+package viewmodels
+
+import "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
+
+type RecurringTransaction struct {
+	ID             string
+	Kind           string
+	Name           string
+	Schedule       string
+	Amount         string
+	AccountID      string
+	AccountName    string
+	CategoryID     string
+	CounterpartyID string
+	TargetName     string // expense category or counterparty the occurrences are booked against
+	Comment        string
+	StartDate      string
+	EndDate        string
+	LastRunDate    string
+	NextRunDate    string
+}
+
+func (r *RecurringTransaction) IsExpense() bool {
+	return r.Kind == string(recurring.Expense)
+}
+
+type RecurringOccurrence struct {
+	Date      string
+	PaymentID string
+	ExpenseID string
+	CreatedAt string
+}
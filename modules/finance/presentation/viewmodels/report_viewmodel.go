@@ -0,0 +1,19 @@
+package viewmodels
+
+type ReportRow struct {
+	Label string
+	// Translate is set when Label is a translation key rather than an account name.
+	Translate bool
+	IsSection bool
+	IsTotal   bool
+	Values    []string
+	Total     string
+}
+
+type Report struct {
+	Kind     string
+	Currency string
+	Columns  []string
+	HasTotal bool
+	Rows     []*ReportRow
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-faster/errors"
+	accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// AccountingPeriodService closes months of the books and guards closed months against changes.
+type AccountingPeriodService struct {
+	repo             accountingperiod.Repository
+	moneyAccountRepo moneyaccount.Repository
+	publisher        eventbus.EventBus
+}
+
+func NewAccountingPeriodService(
+	repo accountingperiod.Repository,
+	moneyAccountRepo moneyaccount.Repository,
+	publisher eventbus.EventBus,
+) *AccountingPeriodService {
+	return &AccountingPeriodService{
+		repo:             repo,
+		moneyAccountRepo: moneyAccountRepo,
+		publisher:        publisher,
+	}
+}
+
+func (s *AccountingPeriodService) GetPaginated(
+	ctx context.Context, params *accountingperiod.FindParams,
+) ([]*accountingperiod.Period, error) {
+	if err := composables.CanUser(ctx, permissions.PeriodRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+// GetByMonth returns the period of the month containing date. Months that were never closed are open.
+func (s *AccountingPeriodService) GetByMonth(ctx context.Context, date time.Time) (*accountingperiod.Period, error) {
+	period, err := s.repo.GetByMonth(ctx, date)
+	if errors.Is(err, persistence.ErrAccountingPeriodNotFound) {
+		return accountingperiod.New(date), nil
+	}
+	return period, err
+}
+
+// EnsureOpen returns accountingperiod.ErrPeriodClosed if any of the dates falls into a closed or locked period.
+// Zero dates are ignored.
+func (s *AccountingPeriodService) EnsureOpen(ctx context.Context, dates ...time.Time) error {
+	for _, date := range dates {
+		if date.IsZero() {
+			continue
+		}
+		period, err := s.GetByMonth(ctx, date)
+		if err != nil {
+			return err
+		}
+		if !period.IsOpen() {
+			return errors.Wrap(accountingperiod.ErrPeriodClosed, period.Start.Format("2006-01"))
+		}
+	}
+	return nil
+}
+
+// Close closes the month containing date and snapshots the balances of all money accounts at its end.
+func (s *AccountingPeriodService) Close(ctx context.Context, date time.Time) (*accountingperiod.Period, error) {
+	if err := composables.CanUser(ctx, permissions.PeriodClose); err != nil {
+		return nil, err
+	}
+	period, err := s.GetByMonth(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if period.ID == 0 {
+		if err := s.repo.Create(ctx, period); err != nil {
+			return nil, err
+		}
+	}
+	accounts, err := s.moneyAccountRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]accountingperiod.Balance, 0, len(accounts))
+	for _, a := range accounts {
+		balance, err := s.moneyAccountRepo.GetBalanceAt(ctx, a.ID, period.End())
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, accountingperiod.Balance{
+			MoneyAccountID: a.ID,
+			Balance:        balance,
+			CurrencyCode:   string(a.Currency.Code),
+		})
+	}
+	if err := period.Close(balances); err != nil {
+		return nil, err
+	}
+	return period, s.save(ctx, period)
+}
+
+// Reopen opens a closed month again. Locked months cannot be reopened.
+func (s *AccountingPeriodService) Reopen(ctx context.Context, date time.Time) (*accountingperiod.Period, error) {
+	return s.transition(ctx, date, (*accountingperiod.Period).Reopen)
+}
+
+// Lock makes a closed month permanent.
+func (s *AccountingPeriodService) Lock(ctx context.Context, date time.Time) (*accountingperiod.Period, error) {
+	return s.transition(ctx, date, (*accountingperiod.Period).Lock)
+}
+
+func (s *AccountingPeriodService) transition(
+	ctx context.Context, date time.Time, apply func(*accountingperiod.Period) error,
+) (*accountingperiod.Period, error) {
+	if err := composables.CanUser(ctx, permissions.PeriodClose); err != nil {
+		return nil, err
+	}
+	period, err := s.repo.GetByMonth(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(period); err != nil {
+		return nil, err
+	}
+	return period, s.save(ctx, period)
+}
+
+func (s *AccountingPeriodService) save(ctx context.Context, period *accountingperiod.Period) error {
+	if err := s.repo.Update(ctx, period); err != nil {
+		return err
+	}
+	event, err := accountingperiod.NewStatusChangedEvent(ctx, *period)
+	if err != nil {
+		return err
+	}
+	s.publisher.Publish(event)
+	return nil
+}
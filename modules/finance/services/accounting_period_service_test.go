@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	accountingperiod "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/accounting_period"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+func TestAccountingPeriodService_Close(t *testing.T) {
+	t.Parallel()
+	f := setupTest(t,
+		permissions.PaymentCreate,
+		permissions.PeriodClose,
+	)
+	setupTestData(f.ctx, t, f)
+	periodService := f.app.Service(services.AccountingPeriodService{}).(*services.AccountingPeriodService)
+
+	closedDate := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	openDate := time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC)
+	period, err := periodService.Close(f.ctx, closedDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if period.IsOpen() {
+		t.Fatal("expected period to be closed")
+	}
+
+	t.Run("EnsureOpen", func(t *testing.T) {
+		if err := periodService.EnsureOpen(f.ctx, openDate); err != nil {
+			t.Fatalf("expected %s to be open, got %v", openDate.Format("2006-01"), err)
+		}
+		err := periodService.EnsureOpen(f.ctx, openDate, closedDate)
+		if !errors.Is(err, accountingperiod.ErrPeriodClosed) {
+			t.Fatalf("expected ErrPeriodClosed, got %v", err)
+		}
+	})
+
+	t.Run("RejectsPayment", func(t *testing.T) {
+		_, err := f.paymentsService.Create(f.ctx, &payment.CreateDTO{
+			Amount:           100,
+			AccountID:        1,
+			TransactionDate:  shared.DateOnly(closedDate),
+			AccountingPeriod: shared.DateOnly(closedDate),
+			CounterpartyID:   1,
+		})
+		if !errors.Is(err, accountingperiod.ErrPeriodClosed) {
+			t.Fatalf("expected ErrPeriodClosed, got %v", err)
+		}
+		count, err := f.paymentsService.Count(f.ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Fatalf("expected no payments, got %d", count)
+		}
+	})
+
+	t.Run("AcceptsPaymentInOpenPeriod", func(t *testing.T) {
+		if _, err := f.paymentsService.Create(f.ctx, &payment.CreateDTO{
+			Amount:           100,
+			AccountID:        1,
+			TransactionDate:  shared.DateOnly(openDate),
+			AccountingPeriod: shared.DateOnly(openDate),
+			CounterpartyID:   1,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
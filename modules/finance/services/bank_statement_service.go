@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/go-faster/errors"
+	bankstatement "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bank_statement"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/statements"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// BankStatementService imports bank statements and reconciles their lines with the transactions of money accounts.
+type BankStatementService struct {
+	repo             bankstatement.Repository
+	transactionRepo  transaction.Repository
+	counterpartyRepo counterparty.Repository
+	paymentService   *PaymentService
+	expenseService   *ExpenseService
+	publisher        eventbus.EventBus
+}
+
+func NewBankStatementService(
+	repo bankstatement.Repository,
+	transactionRepo transaction.Repository,
+	counterpartyRepo counterparty.Repository,
+	paymentService *PaymentService,
+	expenseService *ExpenseService,
+	publisher eventbus.EventBus,
+) *BankStatementService {
+	return &BankStatementService{
+		repo:             repo,
+		transactionRepo:  transactionRepo,
+		counterpartyRepo: counterpartyRepo,
+		paymentService:   paymentService,
+		expenseService:   expenseService,
+		publisher:        publisher,
+	}
+}
+
+func (s *BankStatementService) GetPaginated(
+	ctx context.Context, params *bankstatement.FindParams,
+) ([]*bankstatement.Statement, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *BankStatementService) Count(ctx context.Context, params *bankstatement.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *BankStatementService) GetByID(ctx context.Context, id uint) (*bankstatement.Statement, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *BankStatementService) GetLineByID(ctx context.Context, id uint) (*bankstatement.Line, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetLineByID(ctx, id)
+}
+
+// Import parses a statement file, stages the lines not imported before and auto-matches them.
+func (s *BankStatementService) Import(
+	ctx context.Context, data *bankstatement.ImportDTO, fileName string, content io.Reader,
+) (*bankstatement.Statement, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementImport); err != nil {
+		return nil, err
+	}
+	format, err := bankstatement.NewFormat(data.Format)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := statements.Parse(format, content)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]*bankstatement.Line, 0, len(parsed))
+	for _, line := range parsed {
+		line.MoneyAccountID = data.MoneyAccountID
+		exists, err := s.repo.LineExists(ctx, line)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			lines = append(lines, line)
+		}
+	}
+	entity, err := bankstatement.New(data.MoneyAccountID, format, fileName, lines)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := s.autoMatch(ctx, entity); err != nil {
+		return nil, err
+	}
+	importedEvent, err := bankstatement.NewImportedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(importedEvent)
+	return entity, nil
+}
+
+// AutoMatch suggests transactions for the unmatched lines of a statement.
+func (s *BankStatementService) AutoMatch(ctx context.Context, statementID uint) (*bankstatement.Statement, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementReconcile); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, statementID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.autoMatch(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *BankStatementService) autoMatch(ctx context.Context, entity *bankstatement.Statement) error {
+	var unmatched []*bankstatement.Line
+	var from, to time.Time
+	for _, line := range entity.Lines {
+		if line.Status != bankstatement.Unmatched {
+			continue
+		}
+		if from.IsZero() || line.Date.Before(from) {
+			from = line.Date
+		}
+		if to.IsZero() || line.Date.After(to) {
+			to = line.Date
+		}
+		unmatched = append(unmatched, line)
+	}
+	if len(unmatched) == 0 {
+		return nil
+	}
+	candidates, err := s.repo.Candidates(
+		ctx, entity.MoneyAccountID, from.Add(-bankstatement.MatchWindow), to.Add(bankstatement.MatchWindow),
+	)
+	if err != nil {
+		return err
+	}
+	taken := make(map[uint]bool, len(unmatched))
+	for _, line := range unmatched {
+		candidate := bankstatement.BestMatch(line, candidates, taken)
+		if candidate == nil {
+			continue
+		}
+		if err := line.Match(candidate.TransactionID); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateLine(ctx, line); err != nil {
+			return err
+		}
+		taken[candidate.TransactionID] = true
+	}
+	return nil
+}
+
+// Confirm accepts the transaction suggested for a line and marks it as reconciled.
+func (s *BankStatementService) Confirm(ctx context.Context, lineID uint) (*bankstatement.Line, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementReconcile); err != nil {
+		return nil, err
+	}
+	line, err := s.repo.GetLineByID(ctx, lineID)
+	if err != nil {
+		return nil, err
+	}
+	if err := line.Confirm(); err != nil {
+		return nil, err
+	}
+	return line, s.reconcile(ctx, line)
+}
+
+// CreateTransaction books an unmatched line as a payment when money was received or as an expense
+// when it was paid, and reconciles the line with the new transaction.
+func (s *BankStatementService) CreateTransaction(
+	ctx context.Context, lineID uint, data *bankstatement.CreateTransactionDTO,
+) (*bankstatement.Line, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementReconcile); err != nil {
+		return nil, err
+	}
+	line, err := s.repo.GetLineByID(ctx, lineID)
+	if err != nil {
+		return nil, err
+	}
+	if line.Status == bankstatement.Reconciled {
+		return nil, bankstatement.ErrAlreadyReconciled
+	}
+	accountingPeriod := time.Time(data.AccountingPeriod)
+	if accountingPeriod.IsZero() {
+		accountingPeriod = line.Date
+	}
+	comment := data.Comment
+	if comment == "" {
+		comment = line.Description
+	}
+
+	var transactionID uint
+	if line.IsInflow() {
+		u, err := composables.UseUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		created, err := s.paymentService.Create(ctx, &payment.CreateDTO{
+			Amount:           line.Amount,
+			AccountID:        line.MoneyAccountID,
+			TransactionDate:  shared.DateOnly(line.Date),
+			AccountingPeriod: shared.DateOnly(accountingPeriod),
+			CounterpartyID:   data.CounterpartyID,
+			UserID:           u.ID(),
+			Comment:          comment,
+		})
+		if err != nil {
+			return nil, err
+		}
+		transactionID = created.TransactionID()
+	} else {
+		created, err := s.expenseService.Create(ctx, &expense.CreateDTO{
+			Amount:           -line.Amount,
+			AccountID:        line.MoneyAccountID,
+			CategoryID:       data.CategoryID,
+			Comment:          comment,
+			AccountingPeriod: accountingPeriod,
+			Date:             line.Date,
+		})
+		if err != nil {
+			return nil, err
+		}
+		transactionID = created.TransactionID
+	}
+	if err := line.Reconcile(transactionID); err != nil {
+		return nil, err
+	}
+	return line, s.reconcile(ctx, line)
+}
+
+func (s *BankStatementService) reconcile(ctx context.Context, line *bankstatement.Line) error {
+	if err := s.repo.UpdateLine(ctx, line); err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := s.transactionRepo.SetReconciled(ctx, *line.TransactionID, &now); err != nil {
+		return err
+	}
+	reconciledEvent, err := bankstatement.NewLineReconciledEvent(ctx, *line)
+	if err != nil {
+		return err
+	}
+	s.publisher.Publish(reconciledEvent)
+	return nil
+}
+
+func (s *BankStatementService) Ignore(ctx context.Context, lineID uint) (*bankstatement.Line, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementReconcile); err != nil {
+		return nil, err
+	}
+	line, err := s.repo.GetLineByID(ctx, lineID)
+	if err != nil {
+		return nil, err
+	}
+	if err := line.Ignore(); err != nil {
+		return nil, err
+	}
+	return line, s.repo.UpdateLine(ctx, line)
+}
+
+// Reset drops the match or the ignore mark of a line. A reconciled transaction becomes unreconciled.
+func (s *BankStatementService) Reset(ctx context.Context, lineID uint) (*bankstatement.Line, error) {
+	if err := composables.CanUser(ctx, permissions.BankStatementReconcile); err != nil {
+		return nil, err
+	}
+	line, err := s.repo.GetLineByID(ctx, lineID)
+	if err != nil {
+		return nil, err
+	}
+	if line.Status == bankstatement.Reconciled && line.TransactionID != nil {
+		if err := s.transactionRepo.SetReconciled(ctx, *line.TransactionID, nil); err != nil {
+			return nil, err
+		}
+	}
+	line.Reset()
+	return line, s.repo.UpdateLine(ctx, line)
+}
+
+// SuggestCounterparty finds the counterparty with the TIN of a line, if there is one.
+func (s *BankStatementService) SuggestCounterparty(ctx context.Context, line *bankstatement.Line) (counterparty.Counterparty, error) {
+	if line.CounterpartyTIN == "" {
+		return nil, nil
+	}
+	found, err := s.counterpartyRepo.GetPaginated(ctx, &counterparty.FindParams{
+		Field: "tin",
+		Query: line.CounterpartyTIN,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find counterparty by tin")
+	}
+	for _, c := range found {
+		if c.Tin() != nil && c.Tin().Value() == line.CounterpartyTIN {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *BankStatementService) Delete(ctx context.Context, id uint) error {
+	if err := composables.CanUser(ctx, permissions.BankStatementImport); err != nil {
+		return err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, line := range entity.Lines {
+		if line.Status == bankstatement.Reconciled && line.TransactionID != nil {
+			if err := s.transactionRepo.SetReconciled(ctx, *line.TransactionID, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return s.repo.Delete(ctx, id)
+}
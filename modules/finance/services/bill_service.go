@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+// BillService runs supplier bills through approval and payment.
+// Approved bills are posted to accounts payable; paying a bill records an expense
+// together with its withdrawal transaction and settles the payable.
+type BillService struct {
+	repo             bill.Repository
+	counterpartyRepo counterparty.Repository
+	moneyAccountRepo moneyaccount.Repository
+	expenseService   *ExpenseService
+	ledgerService    *LedgerService
+	periodService    *AccountingPeriodService
+	publisher        eventbus.EventBus
+}
+
+func NewBillService(
+	repo bill.Repository,
+	counterpartyRepo counterparty.Repository,
+	moneyAccountRepo moneyaccount.Repository,
+	expenseService *ExpenseService,
+	ledgerService *LedgerService,
+	periodService *AccountingPeriodService,
+	publisher eventbus.EventBus,
+) *BillService {
+	return &BillService{
+		repo:             repo,
+		counterpartyRepo: counterpartyRepo,
+		moneyAccountRepo: moneyAccountRepo,
+		expenseService:   expenseService,
+		ledgerService:    ledgerService,
+		periodService:    periodService,
+		publisher:        publisher,
+	}
+}
+
+func (s *BillService) GetByID(ctx context.Context, id uint) (*bill.Bill, error) {
+	if err := composables.CanUser(ctx, permissions.BillRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *BillService) GetPaginated(ctx context.Context, params *bill.FindParams) ([]*bill.Bill, error) {
+	if err := composables.CanUser(ctx, permissions.BillRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *BillService) Count(ctx context.Context, params *bill.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+// Create submits a new bill for approval on behalf of the current user.
+func (s *BillService) Create(ctx context.Context, data *bill.SaveDTO) (*bill.Bill, error) {
+	if err := composables.CanUser(ctx, permissions.BillCreate); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureSupplier(ctx, data.CounterpartyID); err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity(u.ID())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	submittedEvent, err := bill.NewSubmittedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(submittedEvent)
+	if err := eventbus.Enqueue(ctx, events.BillStatusChangedTopic, bill.NewStatusChanged(*entity)); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Update corrects a submitted or rejected bill and submits it again.
+func (s *BillService) Update(ctx context.Context, id uint, data *bill.SaveDTO) (*bill.Bill, error) {
+	if err := composables.CanUser(ctx, permissions.BillUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureSupplier(ctx, data.CounterpartyID); err != nil {
+		return nil, err
+	}
+	if err := data.Apply(entity); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	submittedEvent, err := bill.NewSubmittedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(submittedEvent)
+	if err := eventbus.Enqueue(ctx, events.BillStatusChangedTopic, bill.NewStatusChanged(*entity)); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Delete removes a bill that has not been approved yet.
+func (s *BillService) Delete(ctx context.Context, id uint) (*bill.Bill, error) {
+	if err := composables.CanUser(ctx, permissions.BillDelete); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.IsEditable() {
+		return nil, bill.ErrInvalidTransition
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	deletedEvent, err := bill.NewDeletedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(deletedEvent)
+	return entity, nil
+}
+
+// Approve accepts a submitted bill and posts its payable. Only users whose role grants
+// the Bill.Approve permission may approve, and never their own bills.
+func (s *BillService) Approve(ctx context.Context, id uint) (*bill.Bill, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !u.Can(permissions.BillApprove) {
+		return nil, bill.ErrNotApprover
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.periodService.EnsureOpen(ctx, entity.BillDate); err != nil {
+		return nil, err
+	}
+	if err := entity.Approve(u.ID()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.PostBill(ctx, entity); err != nil {
+		return nil, err
+	}
+	approvedEvent, err := bill.NewApprovedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(approvedEvent)
+	if err := eventbus.Enqueue(ctx, events.BillStatusChangedTopic, bill.NewStatusChanged(*entity)); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Reject sends a bill back to the submitter, reversing its payable if it was already approved.
+func (s *BillService) Reject(ctx context.Context, id uint, data *bill.RejectDTO) (*bill.Bill, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !u.Can(permissions.BillApprove) {
+		return nil, bill.ErrNotApprover
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	wasPosted := entity.IsPosted()
+	if wasPosted {
+		if err := s.periodService.EnsureOpen(ctx, entity.BillDate); err != nil {
+			return nil, err
+		}
+	}
+	if err := entity.Reject(data.Reason); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	if wasPosted {
+		if err := s.ledgerService.Unpost(ctx, journalentry.SourceBill, entity.ID); err != nil {
+			return nil, err
+		}
+	}
+	rejectedEvent, err := bill.NewRejectedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(rejectedEvent)
+	if err := eventbus.Enqueue(ctx, events.BillStatusChangedTopic, bill.NewStatusChanged(*entity)); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Schedule plans the payment of an approved bill from a money account in the bill currency.
+func (s *BillService) Schedule(ctx context.Context, id uint, data *bill.ScheduleDTO) (*bill.Bill, error) {
+	if err := composables.CanUser(ctx, permissions.BillPay); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.moneyAccountRepo.GetByID(ctx, data.MoneyAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Currency.Code != entity.Currency {
+		return nil, bill.ErrCurrencyMismatch
+	}
+	if err := entity.Schedule(account.ID, time.Time(data.Date)); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	scheduledEvent, err := bill.NewScheduledEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(scheduledEvent)
+	return entity, nil
+}
+
+// Pay pays a scheduled bill from its money account today. The payment is recorded as an expense,
+// which withdraws the amount from the account, and the payable of the bill is settled.
+func (s *BillService) Pay(ctx context.Context, id uint) (*bill.Bill, error) {
+	if err := composables.CanUser(ctx, permissions.BillPay); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.Status != bill.Scheduled {
+		return nil, bill.ErrInvalidTransition
+	}
+	now := time.Now()
+	e, err := s.expenseService.Create(ctx, &expense.CreateDTO{
+		Amount:           entity.Amount,
+		AccountID:        *entity.MoneyAccountID,
+		CategoryID:       entity.CategoryID,
+		Comment:          "Bill " + entity.Number,
+		AccountingPeriod: now,
+		Date:             now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.MarkPaid(e.ID, now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.PostBillPayment(ctx, entity); err != nil {
+		return nil, err
+	}
+	paidEvent, err := bill.NewPaidEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(paidEvent)
+	return entity, nil
+}
+
+func (s *BillService) ensureSupplier(ctx context.Context, counterpartyID uint) error {
+	party, err := s.counterpartyRepo.GetByID(ctx, counterpartyID)
+	if err != nil {
+		return err
+	}
+	if party.Type() != counterparty.Supplier {
+		return bill.ErrNotSupplier
+	}
+	return nil
+}
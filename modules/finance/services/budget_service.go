@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/budget"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// BudgetService plans spending per expense category and period and compares it with the
+// expenses actually recorded. Expenses paid in other currencies are converted to the budget
+// currency at the rate of the last day of the period, or of today for the current period.
+type BudgetService struct {
+	repo             budget.Repository
+	moneyAccountRepo moneyaccount.Repository
+	currencyService  *coreservices.CurrencyService
+	publisher        eventbus.EventBus
+}
+
+func NewBudgetService(
+	repo budget.Repository,
+	moneyAccountRepo moneyaccount.Repository,
+	currencyService *coreservices.CurrencyService,
+	publisher eventbus.EventBus,
+) *BudgetService {
+	return &BudgetService{
+		repo:             repo,
+		moneyAccountRepo: moneyAccountRepo,
+		currencyService:  currencyService,
+		publisher:        publisher,
+	}
+}
+
+func (s *BudgetService) GetByID(ctx context.Context, id uint) (*budget.Budget, error) {
+	if err := composables.CanUser(ctx, permissions.BudgetRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *BudgetService) GetPaginated(ctx context.Context, params *budget.FindParams) ([]*budget.Budget, error) {
+	if err := composables.CanUser(ctx, permissions.BudgetRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *BudgetService) Count(ctx context.Context, params *budget.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *BudgetService) Create(ctx context.Context, data *budget.SaveDTO) (*budget.Budget, error) {
+	if err := composables.CanUser(ctx, permissions.BudgetCreate); err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUnique(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	createdEvent, err := budget.NewCreatedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(createdEvent)
+	return entity, nil
+}
+
+func (s *BudgetService) Update(ctx context.Context, id uint, data *budget.SaveDTO) (*budget.Budget, error) {
+	if err := composables.CanUser(ctx, permissions.BudgetUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := data.Apply(entity); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUnique(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	updatedEvent, err := budget.NewUpdatedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(updatedEvent)
+	return entity, nil
+}
+
+func (s *BudgetService) Delete(ctx context.Context, id uint) (*budget.Budget, error) {
+	if err := composables.CanUser(ctx, permissions.BudgetDelete); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	deletedEvent, err := budget.NewDeletedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(deletedEvent)
+	return entity, nil
+}
+
+// Variance computes the actual spending of a budget in the budget currency.
+func (s *BudgetService) Variance(ctx context.Context, b *budget.Budget) (*budget.Variance, error) {
+	if err := composables.CanUser(ctx, permissions.BudgetRead); err != nil {
+		return nil, err
+	}
+	actuals, err := s.repo.GetActuals(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	at := rateDate(b)
+	var total float64
+	for _, a := range actuals {
+		amount, _, err := s.currencyService.Convert(ctx, a.Amount, a.Currency, b.Currency, at)
+		if err != nil {
+			return nil, err
+		}
+		total += amount
+	}
+	return &budget.Variance{Budget: b, Actual: total}, nil
+}
+
+// CheckExpense publishes a budget.ExceededEvent for every budget the new expense took over its limit.
+// A budget whose spending can't be converted for lack of an exchange rate is skipped, the expense
+// is never rejected by the check.
+func (s *BudgetService) CheckExpense(ctx context.Context, e *expense.Expense) error {
+	budgets, err := s.repo.GetPaginated(ctx, &budget.FindParams{
+		CategoryID: e.Category.ID(),
+		Date:       e.AccountingPeriod,
+	})
+	if err != nil {
+		return err
+	}
+	if len(budgets) == 0 {
+		return nil
+	}
+	account, err := s.moneyAccountRepo.GetByID(ctx, e.Account.ID)
+	if err != nil {
+		return err
+	}
+	for _, b := range budgets {
+		if b.ProjectID != nil && (e.ProjectID == nil || *e.ProjectID != *b.ProjectID) {
+			continue
+		}
+		variance, err := s.Variance(ctx, b)
+		if skipBudget(b, err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		amount, _, err := s.currencyService.Convert(ctx, e.Amount, account.Currency.Code, b.Currency, rateDate(b))
+		if skipBudget(b, err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !variance.ExceededBy(amount) {
+			continue
+		}
+		exceededEvent, err := budget.NewExceededEvent(ctx, *variance, *e)
+		if err != nil {
+			return err
+		}
+		s.publisher.Publish(exceededEvent)
+	}
+	return nil
+}
+
+// skipBudget reports a budget left out of the check because an exchange rate is missing.
+func skipBudget(b *budget.Budget, err error) bool {
+	var rateErr *exchangerate.ErrRateNotFound
+	if !errors.As(err, &rateErr) {
+		return false
+	}
+	log.Printf("Skipping the check of budget %d: %v", b.ID, err)
+	return true
+}
+
+func (s *BudgetService) ensureUnique(ctx context.Context, entity *budget.Budget) error {
+	existing, err := s.repo.GetPaginated(ctx, &budget.FindParams{
+		CategoryID:  entity.CategoryID,
+		Periodicity: entity.Periodicity,
+		Date:        entity.PeriodStart,
+	})
+	if err != nil {
+		return err
+	}
+	for _, b := range existing {
+		if b.ID != entity.ID && b.SameScope(entity) {
+			return budget.ErrDuplicate
+		}
+	}
+	return nil
+}
+
+// rateDate is the date the actuals of a budget are converted at.
+func rateDate(b *budget.Budget) time.Time {
+	last := b.PeriodEnd().AddDate(0, 0, -1)
+	if now := time.Now(); now.Before(last) {
+		return now
+	}
+	return last
+}
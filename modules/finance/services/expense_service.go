@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
 	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
@@ -13,17 +14,26 @@ type ExpenseService struct {
 	repo           expense.Repository
 	publisher      eventbus.EventBus
 	accountService *MoneyAccountService
+	ledgerService  *LedgerService
+	periodService  *AccountingPeriodService
+	budgetService  *BudgetService
 }
 
 func NewExpenseService(
 	repo expense.Repository,
 	publisher eventbus.EventBus,
 	accountService *MoneyAccountService,
+	ledgerService *LedgerService,
+	periodService *AccountingPeriodService,
+	budgetService *BudgetService,
 ) *ExpenseService {
 	return &ExpenseService{
 		repo:           repo,
 		publisher:      publisher,
 		accountService: accountService,
+		ledgerService:  ledgerService,
+		periodService:  periodService,
+		budgetService:  budgetService,
 	}
 }
 
@@ -50,36 +60,54 @@ func (s *ExpenseService) GetPaginated(
 	return s.repo.GetPaginated(ctx, params)
 }
 
-func (s *ExpenseService) Create(ctx context.Context, data *expense.CreateDTO) error {
+func (s *ExpenseService) Create(ctx context.Context, data *expense.CreateDTO) (*expense.Expense, error) {
 	if err := composables.CanUser(ctx, permissions.ExpenseCreate); err != nil {
-		return err
+		return nil, err
 	}
 	entity, err := data.ToEntity()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.periodService.EnsureOpen(ctx, entity.Date, entity.AccountingPeriod); err != nil {
+		return nil, err
 	}
 	if err := s.repo.Create(ctx, entity); err != nil {
-		return err
+		return nil, err
 	}
 	createdEvent, err := expense.NewCreatedEvent(ctx, *data, *entity)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if err := s.accountService.RecalculateBalance(ctx, entity.Account.ID); err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.ledgerService.PostExpense(ctx, entity); err != nil {
+		return nil, err
 	}
 	s.publisher.Publish(createdEvent)
-	return nil
+	if err := s.budgetService.CheckExpense(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
 }
 
 func (s *ExpenseService) Update(ctx context.Context, id uint, data *expense.UpdateDTO) error {
 	if err := composables.CanUser(ctx, permissions.ExpenseUpdate); err != nil {
 		return err
 	}
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
 	entity, err := data.ToEntity(id)
 	if err != nil {
 		return err
 	}
+	if err := s.periodService.EnsureOpen(
+		ctx, existing.Date, existing.AccountingPeriod, entity.Date, entity.AccountingPeriod,
+	); err != nil {
+		return err
+	}
 	if err := s.repo.Update(ctx, entity); err != nil {
 		return err
 	}
@@ -90,6 +118,9 @@ func (s *ExpenseService) Update(ctx context.Context, id uint, data *expense.Upda
 	if err := s.accountService.RecalculateBalance(ctx, entity.Account.ID); err != nil {
 		return err
 	}
+	if err := s.ledgerService.PostExpense(ctx, entity); err != nil {
+		return err
+	}
 	s.publisher.Publish(updatedEvent)
 	return nil
 }
@@ -102,6 +133,12 @@ func (s *ExpenseService) Delete(ctx context.Context, id uint) (*expense.Expense,
 	if err != nil {
 		return nil, err
 	}
+	if err := s.periodService.EnsureOpen(ctx, entity.Date, entity.AccountingPeriod); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.Unpost(ctx, journalentry.SourceExpense, id); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/settings"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// InvoiceService issues invoices to counterparties and settles them with incoming payments.
+// Sent invoices are posted to accounts receivable; drafts stay off the ledger.
+type InvoiceService struct {
+	repo          invoice.Repository
+	ledgerService *LedgerService
+	periodService *AccountingPeriodService
+	settings      *settings.Settings
+	publisher     eventbus.EventBus
+}
+
+func NewInvoiceService(
+	repo invoice.Repository,
+	ledgerService *LedgerService,
+	periodService *AccountingPeriodService,
+	settings *settings.Settings,
+	publisher eventbus.EventBus,
+) *InvoiceService {
+	return &InvoiceService{
+		repo:          repo,
+		ledgerService: ledgerService,
+		periodService: periodService,
+		settings:      settings,
+		publisher:     publisher,
+	}
+}
+
+// DefaultTaxRate is the VAT rate suggested for new invoice lines.
+func (s *InvoiceService) DefaultTaxRate() float64 {
+	return s.settings.VatTax
+}
+
+func (s *InvoiceService) GetByID(ctx context.Context, id uint) (*invoice.Invoice, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *InvoiceService) GetPaginated(ctx context.Context, params *invoice.FindParams) ([]*invoice.Invoice, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *InvoiceService) Count(ctx context.Context, params *invoice.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+func (s *InvoiceService) Create(ctx context.Context, data *invoice.SaveDTO) (*invoice.Invoice, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceCreate); err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	createdEvent, err := invoice.NewCreatedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(createdEvent)
+	return entity, nil
+}
+
+// Update changes a draft invoice.
+func (s *InvoiceService) Update(ctx context.Context, id uint, data *invoice.SaveDTO) error {
+	if err := composables.CanUser(ctx, permissions.InvoiceUpdate); err != nil {
+		return err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := data.Apply(entity); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, entity)
+}
+
+// Delete removes a draft invoice. Sent invoices are part of the ledger and cannot be deleted.
+func (s *InvoiceService) Delete(ctx context.Context, id uint) (*invoice.Invoice, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceDelete); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.IsDraft() {
+		return nil, invoice.ErrNotDraft
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Send issues a draft invoice and posts its receivable to the ledger.
+func (s *InvoiceService) Send(ctx context.Context, id uint) (*invoice.Invoice, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.periodService.EnsureOpen(ctx, entity.IssueDate); err != nil {
+		return nil, err
+	}
+	if err := entity.Send(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.PostInvoice(ctx, entity); err != nil {
+		return nil, err
+	}
+	sentEvent, err := invoice.NewSentEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(sentEvent)
+	return entity, nil
+}
+
+func (s *InvoiceService) GetAllocations(ctx context.Context, invoiceID uint) ([]*invoice.Allocation, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAllocations(ctx, &invoice.AllocationFindParams{InvoiceID: invoiceID})
+}
+
+// GetUnallocatedPayments returns the payments of a counterparty that still have an amount left to allocate.
+func (s *InvoiceService) GetUnallocatedPayments(
+	ctx context.Context, counterpartyID uint,
+) ([]*invoice.PaymentBalance, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceRead); err != nil {
+		return nil, err
+	}
+	balances, err := s.repo.GetPaymentBalances(ctx, counterpartyID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*invoice.PaymentBalance, 0, len(balances))
+	for _, b := range balances {
+		if b.Unallocated() > 0 {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+// Allocate applies a part of an incoming payment to the invoice.
+func (s *InvoiceService) Allocate(ctx context.Context, id uint, data *invoice.AllocateDTO) (*invoice.Allocation, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.repo.GetPaymentBalance(ctx, data.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	allocation, err := entity.Allocate(balance, data.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.periodService.EnsureOpen(ctx, allocation.Date); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateAllocation(ctx, allocation); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.PostAllocation(ctx, allocation, entity); err != nil {
+		return nil, err
+	}
+	allocatedEvent, err := invoice.NewPaymentAllocatedEvent(ctx, *entity, *allocation)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(allocatedEvent)
+	return allocation, nil
+}
+
+// Deallocate reverses an allocation and returns the invoice it belonged to.
+func (s *InvoiceService) Deallocate(ctx context.Context, allocationID uint) (*invoice.Invoice, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceUpdate); err != nil {
+		return nil, err
+	}
+	allocation, err := s.repo.GetAllocationByID(ctx, allocationID)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, allocation.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.periodService.EnsureOpen(ctx, allocation.Date); err != nil {
+		return nil, err
+	}
+	if err := entity.Deallocate(allocation); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.Unpost(ctx, journalentry.SourceAllocation, allocation.ID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteAllocation(ctx, allocation.ID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Aging buckets the outstanding invoices by counterparty and days past due at the given date.
+func (s *InvoiceService) Aging(ctx context.Context, at time.Time) ([]*invoice.AgingRow, error) {
+	if err := composables.CanUser(ctx, permissions.InvoiceRead); err != nil {
+		return nil, err
+	}
+	invoices, err := s.repo.GetOutstanding(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return invoice.Aging(invoices, at), nil
+}
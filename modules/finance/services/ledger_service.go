@@ -0,0 +1,514 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/invoice"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	ledgeraccount "github.com/iota-uz/iota-sdk/modules/finance/domain/entities/ledger_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// LedgerService keeps the double-entry general ledger in sync with payments, expenses and transactions.
+// The ledger is kept in a single base currency; amounts of foreign currency accounts are restated
+// with the exchange rate effective on the posting date.
+type LedgerService struct {
+	accountRepo      ledgeraccount.Repository
+	entryRepo        journalentry.Repository
+	moneyAccountRepo moneyaccount.Repository
+	categoryRepo     category.Repository
+	currencyService  *coreservices.CurrencyService
+	periodService    *AccountingPeriodService
+	baseCurrency     currency.Code
+	publisher        eventbus.EventBus
+}
+
+func NewLedgerService(
+	accountRepo ledgeraccount.Repository,
+	entryRepo journalentry.Repository,
+	moneyAccountRepo moneyaccount.Repository,
+	categoryRepo category.Repository,
+	currencyService *coreservices.CurrencyService,
+	periodService *AccountingPeriodService,
+	baseCurrency currency.Code,
+	publisher eventbus.EventBus,
+) *LedgerService {
+	return &LedgerService{
+		accountRepo:      accountRepo,
+		entryRepo:        entryRepo,
+		moneyAccountRepo: moneyAccountRepo,
+		categoryRepo:     categoryRepo,
+		currencyService:  currencyService,
+		periodService:    periodService,
+		baseCurrency:     baseCurrency,
+		publisher:        publisher,
+	}
+}
+
+func (s *LedgerService) BaseCurrency() currency.Code {
+	return s.baseCurrency
+}
+
+func (s *LedgerService) GetAccounts(ctx context.Context) ([]*ledgeraccount.Account, error) {
+	if err := composables.CanUser(ctx, permissions.LedgerRead); err != nil {
+		return nil, err
+	}
+	return s.accountRepo.GetAll(ctx)
+}
+
+func (s *LedgerService) GetAccountByID(ctx context.Context, id uint) (*ledgeraccount.Account, error) {
+	if err := composables.CanUser(ctx, permissions.LedgerRead); err != nil {
+		return nil, err
+	}
+	return s.accountRepo.GetByID(ctx, id)
+}
+
+func (s *LedgerService) CreateAccount(ctx context.Context, data *ledgeraccount.CreateDTO) (*ledgeraccount.Account, error) {
+	if err := composables.CanUser(ctx, permissions.LedgerUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accountRepo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *LedgerService) GetEntries(ctx context.Context, params *journalentry.FindParams) ([]*journalentry.Entry, error) {
+	if err := composables.CanUser(ctx, permissions.LedgerRead); err != nil {
+		return nil, err
+	}
+	return s.entryRepo.GetPaginated(ctx, params)
+}
+
+func (s *LedgerService) GetEntryBySource(
+	ctx context.Context, sourceType journalentry.SourceType, sourceID uint,
+) (*journalentry.Entry, error) {
+	return s.entryRepo.GetBySource(ctx, sourceType, sourceID)
+}
+
+// PostManual records a manual journal entry prepared by an accountant.
+func (s *LedgerService) PostManual(ctx context.Context, entry *journalentry.Entry) error {
+	if err := composables.CanUser(ctx, permissions.LedgerUpdate); err != nil {
+		return err
+	}
+	if err := s.periodService.EnsureOpen(ctx, entry.Date, entry.AccountingPeriod); err != nil {
+		return err
+	}
+	entry.SourceType = journalentry.SourceManual
+	entry.SourceID = 0
+	return s.entryRepo.Create(ctx, entry)
+}
+
+// PostPayment (re)posts the journal entry of a payment.
+func (s *LedgerService) PostPayment(ctx context.Context, p payment.Payment) error {
+	cash, err := s.MoneyAccountLedgerAccount(ctx, p.Account().ID)
+	if err != nil {
+		return err
+	}
+	income, err := s.accountRepo.GetByCode(ctx, ledgeraccount.RevenueCode)
+	if err != nil {
+		return errors.Wrap(err, "revenue account")
+	}
+	rate, err := s.moneyAccountRate(ctx, p.Account().ID, p.TransactionDate())
+	if err != nil {
+		return err
+	}
+	entry, err := journalentry.FromPayment(p, cash.ID, income.ID)
+	if err != nil {
+		return err
+	}
+	if err := entry.Convert(rate); err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// PostExpense (re)posts the journal entry of an expense.
+func (s *LedgerService) PostExpense(ctx context.Context, e *expense.Expense) error {
+	expenseAccount, err := s.ExpenseCategoryLedgerAccount(ctx, e.Category.ID())
+	if err != nil {
+		return err
+	}
+	cash, err := s.MoneyAccountLedgerAccount(ctx, e.Account.ID)
+	if err != nil {
+		return err
+	}
+	rate, err := s.moneyAccountRate(ctx, e.Account.ID, e.Date)
+	if err != nil {
+		return err
+	}
+	entry, err := journalentry.FromExpense(e, expenseAccount.ID, cash.ID)
+	if err != nil {
+		return err
+	}
+	if err := entry.Convert(rate); err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// PostInvoice posts the receivable, revenue and VAT of a sent invoice, restated at the rate of its issue date.
+func (s *LedgerService) PostInvoice(ctx context.Context, inv *invoice.Invoice) error {
+	receivable, err := s.accountRepo.GetByCode(ctx, ledgeraccount.AccountsReceivableCode)
+	if err != nil {
+		return errors.Wrap(err, "accounts receivable account")
+	}
+	revenue, err := s.accountRepo.GetByCode(ctx, ledgeraccount.RevenueCode)
+	if err != nil {
+		return errors.Wrap(err, "revenue account")
+	}
+	vat, err := s.accountRepo.GetByCode(ctx, ledgeraccount.VATPayableCode)
+	if err != nil {
+		return errors.Wrap(err, "VAT payable account")
+	}
+	rate, err := s.currencyService.GetRate(ctx, inv.Currency, s.baseCurrency, inv.IssueDate)
+	if err != nil {
+		return err
+	}
+	entry, err := journalentry.FromInvoice(inv, receivable.ID, revenue.ID, vat.ID, rate.Rate)
+	if err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// PostAllocation posts the settlement of an invoice receivable by a payment. The receivable is cleared at
+// the rate of the issue date it was posted with, revenue is restated at the rate of the allocation date
+// and the difference is booked as an exchange gain or loss.
+func (s *LedgerService) PostAllocation(ctx context.Context, a *invoice.Allocation, inv *invoice.Invoice) error {
+	receivable, err := s.accountRepo.GetByCode(ctx, ledgeraccount.AccountsReceivableCode)
+	if err != nil {
+		return errors.Wrap(err, "accounts receivable account")
+	}
+	revenue, err := s.accountRepo.GetByCode(ctx, ledgeraccount.RevenueCode)
+	if err != nil {
+		return errors.Wrap(err, "revenue account")
+	}
+	exchange, err := s.accountRepo.GetByCode(ctx, ledgeraccount.ExchangeDifferencesCode)
+	if err != nil {
+		return errors.Wrap(err, "exchange differences account")
+	}
+	issueRate, err := s.currencyService.GetRate(ctx, inv.Currency, s.baseCurrency, inv.IssueDate)
+	if err != nil {
+		return err
+	}
+	settlementRate, err := s.currencyService.GetRate(ctx, inv.Currency, s.baseCurrency, a.Date)
+	if err != nil {
+		return err
+	}
+	entry, err := journalentry.FromAllocation(a, inv, receivable.ID, revenue.ID, exchange.ID, issueRate.Rate, settlementRate.Rate)
+	if err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// PostBill posts the expense and payable of an approved bill, restated at the rate of the bill date.
+func (s *LedgerService) PostBill(ctx context.Context, b *bill.Bill) error {
+	expenseAccount, err := s.ExpenseCategoryLedgerAccount(ctx, b.CategoryID)
+	if err != nil {
+		return err
+	}
+	payable, err := s.accountRepo.GetByCode(ctx, ledgeraccount.AccountsPayableCode)
+	if err != nil {
+		return errors.Wrap(err, "accounts payable account")
+	}
+	rate, err := s.currencyService.GetRate(ctx, b.Currency, s.baseCurrency, b.BillDate)
+	if err != nil {
+		return err
+	}
+	entry, err := journalentry.FromBill(b, expenseAccount.ID, payable.ID)
+	if err != nil {
+		return err
+	}
+	if err := entry.Convert(rate.Rate); err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// PostBillPayment posts the settlement of a paid bill, restated at the rate of the payment date.
+func (s *LedgerService) PostBillPayment(ctx context.Context, b *bill.Bill) error {
+	expenseAccount, err := s.ExpenseCategoryLedgerAccount(ctx, b.CategoryID)
+	if err != nil {
+		return err
+	}
+	payable, err := s.accountRepo.GetByCode(ctx, ledgeraccount.AccountsPayableCode)
+	if err != nil {
+		return errors.Wrap(err, "accounts payable account")
+	}
+	entry, err := journalentry.FromBillPayment(b, payable.ID, expenseAccount.ID)
+	if err != nil {
+		return err
+	}
+	rate, err := s.currencyService.GetRate(ctx, b.Currency, s.baseCurrency, entry.Date)
+	if err != nil {
+		return err
+	}
+	if err := entry.Convert(rate.Rate); err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// PostInventoryWriteOff posts the value of the products an inventory check wrote off to an expense category.
+// The amount is in the base currency, products are valued by the warehouse when the check is approved.
+func (s *LedgerService) PostInventoryWriteOff(
+	ctx context.Context, checkID uint, date time.Time, description string, amount float64, categoryID uint,
+) error {
+	expenseAccount, err := s.ExpenseCategoryLedgerAccount(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	inventoryAccount, err := s.accountRepo.GetByCode(ctx, ledgeraccount.InventoryCode)
+	if err != nil {
+		return errors.Wrap(err, "inventory account")
+	}
+	entry, err := journalentry.FromInventoryWriteOff(date, description, checkID, amount, expenseAccount.ID, inventoryAccount.ID)
+	if err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// PostTransaction (re)posts a transaction that is not backed by a payment or an expense,
+// i.e. a transfer between money accounts or an opening balance.
+// A missing origin or destination account is substituted with the opening balance equity account.
+// The difference between the base currency values of both sides of a transfer is booked as an exchange gain or loss.
+func (s *LedgerService) PostTransaction(ctx context.Context, t *transaction.Transaction) error {
+	if t.Amount == 0 {
+		return s.Unpost(ctx, journalentry.SourceTransaction, t.ID)
+	}
+	origin, err := s.transactionSide(ctx, t.OriginAccountID)
+	if err != nil {
+		return err
+	}
+	destination, err := s.transactionSide(ctx, t.DestinationAccountID)
+	if err != nil {
+		return err
+	}
+	if t.OriginAccountID == nil || t.DestinationAccountID == nil {
+		accountID := t.DestinationAccountID
+		if accountID == nil {
+			accountID = t.OriginAccountID
+		}
+		rate, err := s.moneyAccountRate(ctx, *accountID, t.TransactionDate)
+		if err != nil {
+			return err
+		}
+		entry, err := journalentry.FromTransaction(t, origin.ID, destination.ID)
+		if err != nil {
+			return err
+		}
+		if err := entry.Convert(rate); err != nil {
+			return err
+		}
+		return s.replace(ctx, entry)
+	}
+	originRate, err := s.moneyAccountRate(ctx, *t.OriginAccountID, t.TransactionDate)
+	if err != nil {
+		return err
+	}
+	destinationRate, err := s.moneyAccountRate(ctx, *t.DestinationAccountID, t.TransactionDate)
+	if err != nil {
+		return err
+	}
+	exchange, err := s.accountRepo.GetByCode(ctx, ledgeraccount.ExchangeDifferencesCode)
+	if err != nil {
+		return errors.Wrap(err, "exchange differences account")
+	}
+	entry, err := journalentry.FromExchange(t, origin.ID, destination.ID, exchange.ID, originRate, destinationRate)
+	if err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// Unpost removes the journal entry of a deleted source document.
+func (s *LedgerService) Unpost(ctx context.Context, sourceType journalentry.SourceType, sourceID uint) error {
+	return s.entryRepo.DeleteBySource(ctx, sourceType, sourceID)
+}
+
+// TrialBalance sums debits and credits per ledger account for entries dated within [from, to].
+func (s *LedgerService) TrialBalance(ctx context.Context, from, to time.Time) (*journalentry.TrialBalance, error) {
+	if err := composables.CanUser(ctx, permissions.LedgerRead); err != nil {
+		return nil, err
+	}
+	accounts, err := s.accountRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	turnovers, err := s.entryRepo.Turnovers(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byAccount := make(map[uint]*journalentry.AccountTurnover, len(turnovers))
+	for _, t := range turnovers {
+		byAccount[t.AccountID] = t
+	}
+	result := &journalentry.TrialBalance{}
+	for _, a := range accounts {
+		t, ok := byAccount[a.ID]
+		if !ok {
+			continue
+		}
+		result.Lines = append(result.Lines, &journalentry.TrialBalanceLine{
+			Account: a,
+			Debit:   t.Debit,
+			Credit:  t.Credit,
+		})
+	}
+	return result, nil
+}
+
+// Revalue posts the unrealized exchange differences of foreign currency money accounts for the month
+// containing period. The balance of each account at the end of the month is restated at the closing rate
+// and compared with its base currency balance in the ledger. Running it again replaces the month's entry.
+func (s *LedgerService) Revalue(ctx context.Context, period time.Time) error {
+	start := time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+	if err := s.periodService.EnsureOpen(ctx, start); err != nil {
+		return err
+	}
+	if err := s.Unpost(ctx, journalentry.SourceRevaluation, journalentry.RevaluationSourceID(start)); err != nil {
+		return err
+	}
+	moneyAccounts, err := s.moneyAccountRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	turnovers, err := s.entryRepo.Turnovers(ctx, time.Time{}, end)
+	if err != nil {
+		return err
+	}
+	byAccount := make(map[uint]*journalentry.AccountTurnover, len(turnovers))
+	for _, t := range turnovers {
+		byAccount[t.AccountID] = t
+	}
+	adjustments := make(map[uint]float64)
+	for _, ma := range moneyAccounts {
+		if ma.Currency.Code == s.baseCurrency {
+			continue
+		}
+		account, err := s.MoneyAccountLedgerAccount(ctx, ma.ID)
+		if err != nil {
+			return err
+		}
+		balance, err := s.moneyAccountRepo.GetBalanceAt(ctx, ma.ID, end)
+		if err != nil {
+			return err
+		}
+		revalued, _, err := s.currencyService.Convert(ctx, balance, ma.Currency.Code, s.baseCurrency, end)
+		if err != nil {
+			return err
+		}
+		var booked float64
+		if t, ok := byAccount[account.ID]; ok {
+			booked = account.Balance(t.Debit, t.Credit)
+		}
+		if diff := revalued - booked; math.Abs(diff) >= 0.005 {
+			adjustments[account.ID] = diff
+		}
+	}
+	if len(adjustments) == 0 {
+		return nil
+	}
+	exchange, err := s.accountRepo.GetByCode(ctx, ledgeraccount.ExchangeDifferencesCode)
+	if err != nil {
+		return errors.Wrap(err, "exchange differences account")
+	}
+	entry, err := journalentry.FromRevaluation(end, exchange.ID, adjustments)
+	if err != nil {
+		return err
+	}
+	return s.replace(ctx, entry)
+}
+
+// MoneyAccountLedgerAccount returns the asset account mirroring a money account, opening it on first use.
+func (s *LedgerService) MoneyAccountLedgerAccount(ctx context.Context, moneyAccountID uint) (*ledgeraccount.Account, error) {
+	account, err := s.accountRepo.GetByMoneyAccountID(ctx, moneyAccountID)
+	if err == nil {
+		return account, nil
+	}
+	if !errors.Is(err, persistence.ErrLedgerAccountNotFound) {
+		return nil, err
+	}
+	moneyAccount, err := s.moneyAccountRepo.GetByID(ctx, moneyAccountID)
+	if err != nil {
+		return nil, err
+	}
+	account = ledgeraccount.NewForMoneyAccount(moneyAccount)
+	if err := s.accountRepo.Create(ctx, account); err != nil {
+		return nil, errors.Wrap(err, "failed to open money account ledger account")
+	}
+	return account, nil
+}
+
+// ExpenseCategoryLedgerAccount returns the expense account mirroring an expense category, opening it on first use.
+func (s *LedgerService) ExpenseCategoryLedgerAccount(ctx context.Context, categoryID uint) (*ledgeraccount.Account, error) {
+	account, err := s.accountRepo.GetByExpenseCategoryID(ctx, categoryID)
+	if err == nil {
+		return account, nil
+	}
+	if !errors.Is(err, persistence.ErrLedgerAccountNotFound) {
+		return nil, err
+	}
+	expenseCategory, err := s.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	account = ledgeraccount.NewForExpenseCategory(expenseCategory)
+	if err := s.accountRepo.Create(ctx, account); err != nil {
+		return nil, errors.Wrap(err, "failed to open expense category ledger account")
+	}
+	return account, nil
+}
+
+// moneyAccountRate returns the rate restating amounts of a money account in the base currency.
+func (s *LedgerService) moneyAccountRate(ctx context.Context, moneyAccountID uint, at time.Time) (float64, error) {
+	moneyAccount, err := s.moneyAccountRepo.GetByID(ctx, moneyAccountID)
+	if err != nil {
+		return 0, err
+	}
+	rate, err := s.currencyService.GetRate(ctx, moneyAccount.Currency.Code, s.baseCurrency, at)
+	if err != nil {
+		return 0, err
+	}
+	return rate.Rate, nil
+}
+
+func (s *LedgerService) transactionSide(ctx context.Context, moneyAccountID *uint) (*ledgeraccount.Account, error) {
+	if moneyAccountID == nil {
+		return s.accountRepo.GetByCode(ctx, ledgeraccount.OpeningBalanceEquityCode)
+	}
+	return s.MoneyAccountLedgerAccount(ctx, *moneyAccountID)
+}
+
+func (s *LedgerService) replace(ctx context.Context, entry *journalentry.Entry) error {
+	if err := s.entryRepo.DeleteBySource(ctx, entry.SourceType, entry.SourceID); err != nil {
+		return err
+	}
+	if err := s.entryRepo.Create(ctx, entry); err != nil {
+		return err
+	}
+	s.publisher.Publish(&journalentry.Posted{Entry: entry})
+	return nil
+}
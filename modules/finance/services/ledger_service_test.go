@@ -0,0 +1,164 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exchangerate"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
+	ledgeraccount "github.com/iota-uz/iota-sdk/modules/finance/domain/entities/ledger_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// lineAmount returns the debit and credit an entry posts to the account.
+func lineAmount(entry *journalentry.Entry, accountID uint) (float64, float64) {
+	var debit, credit float64
+	for _, l := range entry.Lines {
+		if l.AccountID == accountID {
+			debit += l.Debit
+			credit += l.Credit
+		}
+	}
+	return debit, credit
+}
+
+func TestLedgerService_PostTransaction(t *testing.T) {
+	t.Parallel()
+	f := setupTest(t,
+		permissions.LedgerRead,
+		permissions.TransferCreate,
+	)
+	setupTestData(f.ctx, t, f)
+	ledgerService := f.app.Service(services.LedgerService{}).(*services.LedgerService)
+
+	if err := corepersistence.NewCurrencyRepository().Create(f.ctx, &currency.EUR); err != nil {
+		t.Fatal(err)
+	}
+	rate, err := exchangerate.New(
+		time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		currency.EurCode,
+		currency.UsdCode,
+		1.1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := corepersistence.NewExchangeRateRepository().Upsert(f.ctx, rate); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.accountService.Create(f.ctx, &moneyaccount.CreateDTO{
+		Name:          "Euro",
+		AccountNumber: "456",
+		Balance:       100,
+		CurrencyCode:  string(currency.EurCode),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	usdAccount, err := ledgerService.MoneyAccountLedgerAccount(f.ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eurAccount, err := ledgerService.MoneyAccountLedgerAccount(f.ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountRepository := persistence.NewLedgerAccountRepository()
+	equityAccount, err := accountRepository.GetByCode(f.ctx, ledgeraccount.OpeningBalanceEquityCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchangeAccount, err := accountRepository.GetByCode(f.ctx, ledgeraccount.ExchangeDifferencesCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("OpeningBalance", func(t *testing.T) {
+		entries, err := ledgerService.GetEntries(f.ctx, &journalentry.FindParams{AccountID: eurAccount.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 entry, got %d", len(entries))
+		}
+		entry := entries[0]
+		if entry.TotalDebit() != entry.TotalCredit() {
+			t.Fatalf("expected a balanced entry, got debit %f and credit %f", entry.TotalDebit(), entry.TotalCredit())
+		}
+		if debit, _ := lineAmount(entry, eurAccount.ID); debit != 110 {
+			t.Fatalf("expected 110 debited in base currency, got %f", debit)
+		}
+		if _, credit := lineAmount(entry, equityAccount.ID); credit != 110 {
+			t.Fatalf("expected 110 credited to opening balance equity, got %f", credit)
+		}
+	})
+
+	t.Run("TransferAtStoredRate", func(t *testing.T) {
+		transfer, err := f.accountService.Transfer(f.ctx, &moneyaccount.TransferDTO{
+			OriginAccountID:      2,
+			DestinationAccountID: 1,
+			Amount:               50,
+			Date:                 shared.DateOnly(time.Now()),
+			AccountingPeriod:     shared.DateOnly(time.Now()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		usd, err := f.accountService.GetByID(f.ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if usd.Balance != 155 {
+			t.Fatalf("expected balance to be 155, got %f", usd.Balance)
+		}
+		entry, err := ledgerService.GetEntryBySource(f.ctx, journalentry.SourceTransaction, transfer.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entry.Lines) != 2 {
+			t.Fatalf("expected 2 lines, got %d", len(entry.Lines))
+		}
+		if debit, _ := lineAmount(entry, usdAccount.ID); debit != 55 {
+			t.Fatalf("expected 55 debited to the USD account, got %f", debit)
+		}
+		if _, credit := lineAmount(entry, eurAccount.ID); credit != 55 {
+			t.Fatalf("expected 55 credited to the EUR account, got %f", credit)
+		}
+	})
+
+	t.Run("TransferWithExchangeDifference", func(t *testing.T) {
+		transfer, err := f.accountService.Transfer(f.ctx, &moneyaccount.TransferDTO{
+			OriginAccountID:      2,
+			DestinationAccountID: 1,
+			Amount:               50,
+			DestinationAmount:    54,
+			Date:                 shared.DateOnly(time.Now()),
+			AccountingPeriod:     shared.DateOnly(time.Now()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, err := ledgerService.GetEntryBySource(f.ctx, journalentry.SourceTransaction, transfer.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.TotalDebit() != entry.TotalCredit() {
+			t.Fatalf("expected a balanced entry, got debit %f and credit %f", entry.TotalDebit(), entry.TotalCredit())
+		}
+		if debit, _ := lineAmount(entry, usdAccount.ID); debit != 54 {
+			t.Fatalf("expected 54 debited to the USD account, got %f", debit)
+		}
+		if _, credit := lineAmount(entry, eurAccount.ID); credit != 55 {
+			t.Fatalf("expected 55 credited to the EUR account, got %f", credit)
+		}
+		if debit, _ := lineAmount(entry, exchangeAccount.ID); debit != 1 {
+			t.Fatalf("expected an exchange loss of 1, got %f", debit)
+		}
+	})
+}
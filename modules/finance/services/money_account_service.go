@@ -2,28 +2,46 @@ package services
 
 import (
 	"context"
-	"github.com/go-faster/errors"
+	"time"
 
+	"github.com/go-faster/errors"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
 	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/transaction"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 )
 
 type MoneyAccountService struct {
 	repo            moneyaccount.Repository
 	transactionRepo transaction.Repository
+	expenseRepo     expense.Repository
 	publisher       eventbus.EventBus
+	ledgerService   *LedgerService
+	currencyService *coreservices.CurrencyService
+	periodService   *AccountingPeriodService
 }
 
 func NewMoneyAccountService(
 	repo moneyaccount.Repository,
 	transactionRepo transaction.Repository,
+	expenseRepo expense.Repository,
 	publisher eventbus.EventBus,
+	ledgerService *LedgerService,
+	currencyService *coreservices.CurrencyService,
+	periodService *AccountingPeriodService,
 ) *MoneyAccountService {
 	return &MoneyAccountService{
 		repo:            repo,
 		transactionRepo: transactionRepo,
+		expenseRepo:     expenseRepo,
 		publisher:       publisher,
+		ledgerService:   ledgerService,
+		currencyService: currencyService,
+		periodService:   periodService,
 	}
 }
 
@@ -45,18 +63,67 @@ func (s *MoneyAccountService) RecalculateBalance(ctx context.Context, id uint) e
 	return s.repo.RecalculateBalance(ctx, id)
 }
 
+// Transfer moves money between two accounts. When the accounts are held in different currencies
+// the credited amount is converted with the stored exchange rate unless the DTO specifies it.
+func (s *MoneyAccountService) Transfer(ctx context.Context, data *moneyaccount.TransferDTO) (*transaction.Transaction, error) {
+	if err := composables.CanUser(ctx, permissions.TransferCreate); err != nil {
+		return nil, err
+	}
+	entity := data.ToEntity()
+	if err := s.periodService.EnsureOpen(ctx, entity.TransactionDate, entity.AccountingPeriod); err != nil {
+		return nil, err
+	}
+	origin, err := s.repo.GetByID(ctx, data.OriginAccountID)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := s.repo.GetByID(ctx, data.DestinationAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if origin.Currency.Code != destination.Currency.Code && entity.ExchangeRate == 0 {
+		amount, rate, err := s.currencyService.Convert(
+			ctx, entity.Amount, origin.Currency.Code, destination.Currency.Code, entity.TransactionDate,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "currencyService.Convert")
+		}
+		entity.WithExchange(amount, rate.Rate)
+	}
+	if err := s.transactionRepo.Create(ctx, entity); err != nil {
+		return nil, errors.Wrap(err, "transactionRepo.Create")
+	}
+	if err := s.repo.RecalculateBalance(ctx, data.OriginAccountID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RecalculateBalance(ctx, data.DestinationAccountID); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.PostTransaction(ctx, entity); err != nil {
+		return nil, errors.Wrap(err, "ledgerService.PostTransaction")
+	}
+	return entity, nil
+}
+
 func (s *MoneyAccountService) Create(ctx context.Context, data *moneyaccount.CreateDTO) error {
 	entity, err := data.ToEntity()
 	if err != nil {
 		return err
 	}
+	if err := s.periodService.EnsureOpen(ctx, entity.CreatedAt); err != nil {
+		return err
+	}
 	createdEntity, err := s.repo.Create(ctx, entity)
 	if err != nil {
 		return errors.Wrap(err, "accountRepo.Create")
 	}
-	if err := s.transactionRepo.Create(ctx, createdEntity.InitialTransaction()); err != nil {
+	initialTransaction := createdEntity.InitialTransaction()
+	if err := s.transactionRepo.Create(ctx, initialTransaction); err != nil {
 		return errors.Wrap(err, "transactionRepo.Create")
 	}
+	if err := s.ledgerService.PostTransaction(ctx, initialTransaction); err != nil {
+		return errors.Wrap(err, "ledgerService.PostTransaction")
+	}
 	createdEvent, err := moneyaccount.NewCreatedEvent(ctx, *data, *createdEntity)
 	if err != nil {
 		return err
@@ -81,11 +148,41 @@ func (s *MoneyAccountService) Update(ctx context.Context, id uint, data *moneyac
 	return nil
 }
 
+// Delete removes the account together with its transactions and their journal entries, which is refused when the earliest of them
+// falls into a closed period.
 func (s *MoneyAccountService) Delete(ctx context.Context, id uint) (*moneyaccount.Account, error) {
 	entity, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	transactions, err := s.transactionRepo.GetPaginated(ctx, &transaction.FindParams{AccountID: id})
+	if err != nil {
+		return nil, err
+	}
+	var earliest time.Time
+	for _, t := range transactions {
+		if earliest.IsZero() || t.TransactionDate.Before(earliest) {
+			earliest = t.TransactionDate
+		}
+	}
+	if err := s.periodService.EnsureOpen(ctx, earliest); err != nil {
+		return nil, err
+	}
+	// the transactions and the expenses paid with them are deleted along with the account, so are their entries
+	expenses, err := s.expenseRepo.GetPaginated(ctx, &expense.FindParams{AccountID: id})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range expenses {
+		if err := s.ledgerService.Unpost(ctx, journalentry.SourceExpense, e.ID); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range transactions {
+		if err := s.ledgerService.Unpost(ctx, journalentry.SourceTransaction, t.ID); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return nil, err
 	}
@@ -3,27 +3,35 @@ package services
 import (
 	"context"
 
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
 	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
 	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
 )
 
 type PaymentService struct {
 	repo           payment.Repository
 	publisher      eventbus.EventBus
 	accountService *MoneyAccountService
+	ledgerService  *LedgerService
+	periodService  *AccountingPeriodService
 }
 
 func NewPaymentService(
 	repo payment.Repository,
 	publisher eventbus.EventBus,
 	accountService *MoneyAccountService,
+	ledgerService *LedgerService,
+	periodService *AccountingPeriodService,
 ) *PaymentService {
 	return &PaymentService{
 		repo:           repo,
 		publisher:      publisher,
 		accountService: accountService,
+		ledgerService:  ledgerService,
+		periodService:  periodService,
 	}
 }
 
@@ -50,30 +58,62 @@ func (s *PaymentService) GetPaginated(
 	return s.repo.GetPaginated(ctx, params)
 }
 
-func (s *PaymentService) Create(ctx context.Context, data *payment.CreateDTO) error {
+func (s *PaymentService) Create(ctx context.Context, data *payment.CreateDTO) (payment.Payment, error) {
 	if err := composables.CanUser(ctx, permissions.PaymentCreate); err != nil {
-		return err
+		return nil, err
 	}
-	createdEntity, err := s.repo.Create(ctx, data.ToEntity())
+	entity := data.ToEntity()
+	if err := s.periodService.EnsureOpen(ctx, entity.TransactionDate(), entity.AccountingPeriod()); err != nil {
+		return nil, err
+	}
+	createdEntity, err := s.repo.Create(ctx, entity)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	createdEvent, err := payment.NewCreatedEvent(ctx, *data, createdEntity)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if err := s.accountService.RecalculateBalance(ctx, createdEntity.Account().ID); err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.ledgerService.PostPayment(ctx, createdEntity); err != nil {
+		return nil, err
+	}
+	if err := s.enqueueSaved(ctx, createdEntity.ID()); err != nil {
+		return nil, err
 	}
 	s.publisher.Publish(createdEvent)
-	return nil
+	return createdEntity, nil
+}
+
+// enqueueSaved announces the payment as stored, with its account loaded for the currency.
+func (s *PaymentService) enqueueSaved(ctx context.Context, id uint) error {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return eventbus.Enqueue(ctx, events.PaymentSavedTopic, payment.NewSaved(entity))
 }
 
 func (s *PaymentService) Update(ctx context.Context, id uint, data *payment.UpdateDTO) error {
 	if err := composables.CanUser(ctx, permissions.PaymentUpdate); err != nil {
 		return err
 	}
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
 	entity := data.ToEntity(id)
+	if err := s.periodService.EnsureOpen(
+		ctx,
+		existing.TransactionDate(),
+		existing.AccountingPeriod(),
+		entity.TransactionDate(),
+		entity.AccountingPeriod(),
+	); err != nil {
+		return err
+	}
 	if err := s.repo.Update(ctx, entity); err != nil {
 		return err
 	}
@@ -84,6 +124,12 @@ func (s *PaymentService) Update(ctx context.Context, id uint, data *payment.Upda
 	if err := s.accountService.RecalculateBalance(ctx, entity.Account().ID); err != nil {
 		return err
 	}
+	if err := s.ledgerService.PostPayment(ctx, entity); err != nil {
+		return err
+	}
+	if err := s.enqueueSaved(ctx, id); err != nil {
+		return err
+	}
 	s.publisher.Publish(updatedEvent)
 	return nil
 }
@@ -96,12 +142,21 @@ func (s *PaymentService) Delete(ctx context.Context, id uint) (payment.Payment,
 	if err != nil {
 		return nil, err
 	}
+	if err := s.periodService.EnsureOpen(ctx, entity.TransactionDate(), entity.AccountingPeriod()); err != nil {
+		return nil, err
+	}
+	if err := s.ledgerService.Unpost(ctx, journalentry.SourcePayment, id); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return nil, err
 	}
 	if err := s.accountService.RecalculateBalance(ctx, entity.Account().ID); err != nil {
 		return nil, err
 	}
+	if err := eventbus.Enqueue(ctx, events.PaymentRemovedTopic, events.PaymentRemoved{PaymentID: id}); err != nil {
+		return nil, err
+	}
 	deletedEvent, err := payment.NewDeletedEvent(ctx, entity)
 	if err != nil {
 		return nil, err
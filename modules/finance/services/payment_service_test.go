@@ -31,6 +31,7 @@ type testFixtures struct {
 	ctx             context.Context
 	pool            *pgxpool.Pool
 	publisher       eventbus.EventBus
+	app             application.Application
 	paymentsService *services.PaymentService
 	accountService  *services.MoneyAccountService
 }
@@ -65,6 +66,7 @@ func setupTest(t *testing.T, permissions ...*permission.Permission) *testFixture
 		ctx:             ctx,
 		pool:            pool,
 		publisher:       publisher,
+		app:             app,
 		paymentsService: app.Service(services.PaymentService{}).(*services.PaymentService),
 		accountService:  app.Service(services.MoneyAccountService{}).(*services.MoneyAccountService),
 	}
@@ -132,7 +134,7 @@ func TestPaymentsService_CRUD(t *testing.T) {
 	)
 	setupTestData(f.ctx, t, f)
 	accountRepository := persistence.NewMoneyAccountRepository()
-	if err := f.paymentsService.Create(
+	if _, err := f.paymentsService.Create(
 		f.ctx, &payment.CreateDTO{
 			Amount:           100,
 			AccountID:        1,
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/payment"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+// RecurringService keeps the templates of recurring payments and expenses and materializes their
+// occurrences through the payment and expense services, so they are posted like manual entries.
+type RecurringService struct {
+	repo           recurring.Repository
+	paymentService *PaymentService
+	expenseService *ExpenseService
+	publisher      eventbus.EventBus
+}
+
+func NewRecurringService(
+	repo recurring.Repository,
+	paymentService *PaymentService,
+	expenseService *ExpenseService,
+	publisher eventbus.EventBus,
+) *RecurringService {
+	return &RecurringService{
+		repo:           repo,
+		paymentService: paymentService,
+		expenseService: expenseService,
+		publisher:      publisher,
+	}
+}
+
+func (s *RecurringService) GetByID(ctx context.Context, id uint) (*recurring.Template, error) {
+	if err := composables.CanUser(ctx, permissions.RecurringRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *RecurringService) GetPaginated(ctx context.Context, params *recurring.FindParams) ([]*recurring.Template, error) {
+	if err := composables.CanUser(ctx, permissions.RecurringRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *RecurringService) Count(ctx context.Context, params *recurring.FindParams) (int64, error) {
+	return s.repo.Count(ctx, params)
+}
+
+// GetOccurrences returns the latest occurrences materialized for a template.
+func (s *RecurringService) GetOccurrences(ctx context.Context, id uint, limit int) ([]*recurring.Occurrence, error) {
+	if err := composables.CanUser(ctx, permissions.RecurringRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetOccurrences(ctx, id, limit)
+}
+
+// GetDue returns the templates with an occurrence due on or before the given day.
+func (s *RecurringService) GetDue(ctx context.Context, at time.Time) ([]*recurring.Template, error) {
+	return s.repo.GetDue(ctx, at)
+}
+
+// Preview lists the next n occurrences of a template that has not been saved yet.
+func (s *RecurringService) Preview(data *recurring.SaveDTO, n int) ([]time.Time, error) {
+	entity, err := data.ToEntity(0)
+	if err != nil {
+		return nil, err
+	}
+	return entity.Upcoming(n), nil
+}
+
+// Create saves a template on behalf of the current user; its occurrences are created as that user.
+func (s *RecurringService) Create(ctx context.Context, data *recurring.SaveDTO) (*recurring.Template, error) {
+	if err := composables.CanUser(ctx, permissions.RecurringCreate); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity(u.ID())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	createdEvent, err := recurring.NewCreatedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(createdEvent)
+	return entity, nil
+}
+
+func (s *RecurringService) Update(ctx context.Context, id uint, data *recurring.SaveDTO) (*recurring.Template, error) {
+	if err := composables.CanUser(ctx, permissions.RecurringUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := data.Apply(entity); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	updatedEvent, err := recurring.NewUpdatedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(updatedEvent)
+	return entity, nil
+}
+
+// Delete removes a template. Payments and expenses it already created are kept.
+func (s *RecurringService) Delete(ctx context.Context, id uint) (*recurring.Template, error) {
+	if err := composables.CanUser(ctx, permissions.RecurringDelete); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	deletedEvent, err := recurring.NewDeletedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(deletedEvent)
+	return entity, nil
+}
+
+// Run materializes the occurrences of a template that are due on or before the given day and
+// returns how many were created. The template stays locked until the transaction ends and every
+// occurrence is recorded before its payment or expense is created, so running a template twice,
+// concurrently or after a failure, never creates an occurrence twice.
+func (s *RecurringService) Run(ctx context.Context, id uint, at time.Time) (int, error) {
+	entity, err := s.repo.GetForUpdate(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	created := 0
+	for entity.IsDue(at) {
+		occurrence := &recurring.Occurrence{
+			TemplateID: entity.ID,
+			Date:       *entity.NextRunDate,
+			CreatedAt:  time.Now(),
+		}
+		isNew, err := s.repo.CreateOccurrence(ctx, occurrence)
+		if err != nil {
+			return 0, err
+		}
+		if isNew {
+			if err := s.materialize(ctx, entity, occurrence); err != nil {
+				return 0, err
+			}
+			if err := s.repo.UpdateOccurrence(ctx, occurrence); err != nil {
+				return 0, err
+			}
+			materializedEvent, err := recurring.NewMaterializedEvent(ctx, *entity, *occurrence)
+			if err != nil {
+				return 0, err
+			}
+			s.publisher.Publish(materializedEvent)
+			created++
+		}
+		entity.Advance()
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
+func (s *RecurringService) materialize(
+	ctx context.Context, entity *recurring.Template, occurrence *recurring.Occurrence,
+) error {
+	comment := entity.Comment
+	if comment == "" {
+		comment = entity.Name
+	}
+	switch entity.Kind {
+	case recurring.Payment:
+		created, err := s.paymentService.Create(ctx, &payment.CreateDTO{
+			Amount:           entity.Amount,
+			AccountID:        entity.AccountID,
+			TransactionDate:  shared.DateOnly(occurrence.Date),
+			AccountingPeriod: shared.DateOnly(occurrence.Date),
+			CounterpartyID:   *entity.CounterpartyID,
+			UserID:           entity.CreatedBy,
+			Comment:          comment,
+		})
+		if err != nil {
+			return err
+		}
+		paymentID := created.ID()
+		occurrence.PaymentID = &paymentID
+	case recurring.Expense:
+		created, err := s.expenseService.Create(ctx, &expense.CreateDTO{
+			Amount:           entity.Amount,
+			AccountID:        entity.AccountID,
+			CategoryID:       *entity.CategoryID,
+			Comment:          comment,
+			AccountingPeriod: occurrence.Date,
+			Date:             occurrence.Date,
+		})
+		if err != nil {
+			return err
+		}
+		occurrence.ExpenseID = &created.ID
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/recurring"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+func TestRecurringService_Run(t *testing.T) {
+	t.Parallel()
+	f := setupTest(t,
+		permissions.PaymentCreate,
+		permissions.RecurringCreate,
+		permissions.RecurringRead,
+	)
+	setupTestData(f.ctx, t, f)
+	recurringService := f.app.Service(services.RecurringService{}).(*services.RecurringService)
+
+	// Materialized payments are authored by the template creator
+	if _, err := corepersistence.NewUserRepository().Create(f.ctx, user.New(
+		"John",
+		"Doe",
+		"",
+		"",
+		"test@gmail.com",
+		nil,
+		0,
+		user.UILanguageEN,
+		nil,
+	)); err != nil {
+		t.Fatal(err)
+	}
+
+	template, err := recurringService.Create(f.ctx, &recurring.SaveDTO{
+		Kind:           string(recurring.Payment),
+		Name:           "Rent",
+		Schedule:       "FREQ=MONTHLY",
+		Amount:         10,
+		AccountID:      1,
+		CounterpartyID: 1,
+		StartDate:      shared.DateOnly(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC)
+	created, err := recurringService.Run(f.ctx, template.ID, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", created)
+	}
+
+	created, err = recurringService.Run(f.ctx, template.ID, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 0 {
+		t.Fatalf("expected the second run to create nothing, got %d", created)
+	}
+
+	occurrences, err := recurringService.GetOccurrences(f.ctx, template.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occurrences) != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", len(occurrences))
+	}
+	for _, o := range occurrences {
+		if o.PaymentID == nil {
+			t.Fatalf("expected occurrence of %s to reference a payment", o.Date.Format(time.DateOnly))
+		}
+	}
+
+	count, err := f.paymentsService.Count(f.ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 payments, got %d", count)
+	}
+
+	account, err := f.accountService.GetByID(f.ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Balance != 130 {
+		t.Fatalf("expected balance to be 130, got %f", account.Balance)
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	ledgeraccount "github.com/iota-uz/iota-sdk/modules/finance/domain/entities/ledger_account"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/report"
+	"github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// ReportService builds financial statements from the general ledger.
+// All figures are in the base currency of the ledger.
+type ReportService struct {
+	accountRepo   ledgeraccount.Repository
+	entryRepo     journalentry.Repository
+	ledgerService *LedgerService
+}
+
+func NewReportService(
+	accountRepo ledgeraccount.Repository,
+	entryRepo journalentry.Repository,
+	ledgerService *LedgerService,
+) *ReportService {
+	return &ReportService{
+		accountRepo:   accountRepo,
+		entryRepo:     entryRepo,
+		ledgerService: ledgerService,
+	}
+}
+
+// balances maps ledger account ids to their debit and credit turnovers.
+type balances map[uint]*journalentry.AccountTurnover
+
+func (b balances) of(account *ledgeraccount.Account) float64 {
+	t, ok := b[account.ID]
+	if !ok {
+		return 0
+	}
+	return account.Balance(t.Debit, t.Credit)
+}
+
+func (s *ReportService) Build(
+	ctx context.Context, kind report.Kind, from, to time.Time, byMonth bool,
+) (*report.Report, error) {
+	if err := composables.CanUser(ctx, permissions.ReportRead); err != nil {
+		return nil, err
+	}
+	accounts, err := s.accountRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &report.Report{
+		Kind:     kind,
+		From:     from,
+		To:       to,
+		Currency: string(s.ledgerService.BaseCurrency()),
+		Columns:  report.Columns(from, to, byMonth),
+	}
+	switch kind {
+	case report.ProfitAndLoss:
+		err = s.profitAndLoss(ctx, result, accounts)
+	case report.CashFlow:
+		err = s.cashFlow(ctx, result, accounts)
+	case report.Balance:
+		err = s.balance(ctx, result, accounts)
+	default:
+		_, err = report.NewKind(string(kind))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// turnovers returns the turnovers of every column, either within the column or accumulated until its end.
+func (s *ReportService) turnovers(ctx context.Context, columns []report.Column, cumulative bool) ([]balances, error) {
+	result := make([]balances, len(columns))
+	for i, c := range columns {
+		from := c.From
+		if cumulative {
+			from = time.Time{}
+		}
+		turnovers, err := s.entryRepo.Turnovers(ctx, from, c.To)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = make(balances, len(turnovers))
+		for _, t := range turnovers {
+			result[i][t.AccountID] = t
+		}
+	}
+	return result, nil
+}
+
+// accountRows adds a row per account with non-zero figures and returns the column totals.
+func accountRows(
+	r *report.Report, accounts []*ledgeraccount.Account, values func(a *ledgeraccount.Account, column int) float64,
+) []float64 {
+	totals := make([]float64, len(r.Columns))
+	for _, a := range accounts {
+		row := &report.Row{Type: report.RowAccount, Label: a.Name, Values: make([]float64, len(r.Columns))}
+		for i := range r.Columns {
+			row.Values[i] = values(a, i)
+			totals[i] += row.Values[i]
+		}
+		if !row.IsZero() {
+			r.Rows = append(r.Rows, row)
+		}
+	}
+	return totals
+}
+
+func ofType(accounts []*ledgeraccount.Account, accountType ledgeraccount.Type) []*ledgeraccount.Account {
+	var result []*ledgeraccount.Account
+	for _, a := range accounts {
+		if a.Type == accountType {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+func combine(a, b []float64, op func(x, y float64) float64) []float64 {
+	result := make([]float64, len(a))
+	for i := range a {
+		result[i] = op(a[i], b[i])
+	}
+	return result
+}
+
+func subtract(x, y float64) float64 { return x - y }
+
+func add(x, y float64) float64 { return x + y }
+
+// profitAndLoss lists income accounts and expense accounts, i.e. expense categories, and the net result.
+func (s *ReportService) profitAndLoss(ctx context.Context, r *report.Report, accounts []*ledgeraccount.Account) error {
+	columns, err := s.turnovers(ctx, r.Columns, false)
+	if err != nil {
+		return err
+	}
+	value := func(a *ledgeraccount.Account, i int) float64 {
+		return columns[i].of(a)
+	}
+	r.Section("Reports.Rows.Income")
+	income := accountRows(r, ofType(accounts, ledgeraccount.Income), value)
+	r.Add(report.RowTotal, "Reports.Rows.TotalIncome", income)
+	r.Section("Reports.Rows.Expenses")
+	expenses := accountRows(r, ofType(accounts, ledgeraccount.Expense), value)
+	r.Add(report.RowTotal, "Reports.Rows.TotalExpenses", expenses)
+	r.Add(report.RowTotal, "Reports.Rows.NetProfit", combine(income, expenses, subtract))
+	return nil
+}
+
+// cashFlow lists money received and paid per money account between the opening and closing cash balances.
+func (s *ReportService) cashFlow(ctx context.Context, r *report.Report, accounts []*ledgeraccount.Account) error {
+	var cashAccounts []*ledgeraccount.Account
+	for _, a := range accounts {
+		if a.MoneyAccountID != nil {
+			cashAccounts = append(cashAccounts, a)
+		}
+	}
+	columns, err := s.turnovers(ctx, r.Columns, false)
+	if err != nil {
+		return err
+	}
+	openingColumns := make([]report.Column, len(r.Columns))
+	for i, c := range r.Columns {
+		openingColumns[i] = report.Column{From: c.From, To: c.From.AddDate(0, 0, -1)}
+	}
+	opening, err := s.turnovers(ctx, openingColumns, true)
+	if err != nil {
+		return err
+	}
+
+	openingTotals := make([]float64, len(r.Columns))
+	for i := range r.Columns {
+		for _, a := range cashAccounts {
+			openingTotals[i] += opening[i].of(a)
+		}
+	}
+	r.Add(report.RowTotal, "Reports.Rows.OpeningBalance", openingTotals)
+	r.Section("Reports.Rows.Inflows")
+	inflows := accountRows(r, cashAccounts, func(a *ledgeraccount.Account, i int) float64 {
+		if t, ok := columns[i][a.ID]; ok {
+			return t.Debit
+		}
+		return 0
+	})
+	r.Add(report.RowTotal, "Reports.Rows.TotalInflows", inflows)
+	r.Section("Reports.Rows.Outflows")
+	outflows := accountRows(r, cashAccounts, func(a *ledgeraccount.Account, i int) float64 {
+		if t, ok := columns[i][a.ID]; ok {
+			return t.Credit
+		}
+		return 0
+	})
+	r.Add(report.RowTotal, "Reports.Rows.TotalOutflows", outflows)
+	net := combine(inflows, outflows, subtract)
+	r.Add(report.RowTotal, "Reports.Rows.NetCashFlow", net)
+	r.Add(report.RowTotal, "Reports.Rows.ClosingBalance", combine(openingTotals, net, add))
+	return nil
+}
+
+// balance lists assets, liabilities and equity at the end of every column.
+// Income and expenses not yet closed to equity are shown as retained earnings.
+func (s *ReportService) balance(ctx context.Context, r *report.Report, accounts []*ledgeraccount.Account) error {
+	columns, err := s.turnovers(ctx, r.Columns, true)
+	if err != nil {
+		return err
+	}
+	value := func(a *ledgeraccount.Account, i int) float64 {
+		return columns[i].of(a)
+	}
+	r.Section("Reports.Rows.Assets")
+	assets := accountRows(r, ofType(accounts, ledgeraccount.Asset), value)
+	r.Add(report.RowTotal, "Reports.Rows.TotalAssets", assets)
+	r.Section("Reports.Rows.Liabilities")
+	liabilities := accountRows(r, ofType(accounts, ledgeraccount.Liability), value)
+	r.Add(report.RowTotal, "Reports.Rows.TotalLiabilities", liabilities)
+	r.Section("Reports.Rows.Equity")
+	equity := accountRows(r, ofType(accounts, ledgeraccount.Equity), value)
+	earnings := make([]float64, len(r.Columns))
+	for i := range r.Columns {
+		for _, a := range ofType(accounts, ledgeraccount.Income) {
+			earnings[i] += columns[i].of(a)
+		}
+		for _, a := range ofType(accounts, ledgeraccount.Expense) {
+			earnings[i] -= columns[i].of(a)
+		}
+	}
+	r.Add(report.RowComputed, "Reports.Rows.RetainedEarnings", earnings)
+	equity = combine(equity, earnings, add)
+	r.Add(report.RowTotal, "Reports.Rows.TotalEquity", equity)
+	r.Add(report.RowTotal, "Reports.Rows.TotalLiabilitiesAndEquity", combine(liabilities, equity, add))
+	return nil
+}
@@ -10,6 +10,10 @@ type Order interface {
 	ID() uint
 	Type() Type
 	Status() Status
+	// WarehouseID is the warehouse the order receives into or ships from, zero when not set
+	WarehouseID() uint
+	// LocationID is the destination of in and transfer orders
+	LocationID() uint
 	Items() []Item
 	CreatedAt() time.Time
 
@@ -6,15 +6,19 @@ import (
 )
 
 type CreateDTO struct {
-	Type       string
-	Status     string
-	ProductIDs []uint
+	Type        string
+	Status      string
+	WarehouseID uint
+	LocationID  uint
+	ProductIDs  []uint
 }
 
 type UpdateDTO struct {
-	Type       string
-	Status     string
-	ProductIDs []uint
+	Type        string
+	Status      string
+	WarehouseID uint
+	LocationID  uint
+	ProductIDs  []uint
 }
 
 func (d *CreateDTO) ToEntity() (Order, error) {
@@ -26,7 +30,7 @@ func (d *CreateDTO) ToEntity() (Order, error) {
 	if err != nil {
 		return nil, err
 	}
-	entity := New(t, s)
+	entity := New(t, s, d.WarehouseID, d.LocationID)
 	for _, id := range d.ProductIDs {
 		if err := entity.AddItem(&position.Position{}, &product.Product{ID: id}); err != nil {
 			return nil, err
@@ -44,7 +48,7 @@ func (d *UpdateDTO) ToEntity(id uint) (Order, error) {
 	if err != nil {
 		return nil, err
 	}
-	entity := New(t, s)
+	entity := New(t, s, d.WarehouseID, d.LocationID)
 	for _, productID := range d.ProductIDs {
 		if err := entity.AddItem(&position.Position{}, &product.Product{ID: productID}); err != nil {
 			return nil, err
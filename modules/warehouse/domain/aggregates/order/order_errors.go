@@ -57,3 +57,50 @@ func (e *ErrProductIsShipped) Localize(l *i18n.Localizer) string {
 		},
 	})
 }
+
+type ErrLocationRequired struct {
+	serrors.BaseError
+	Type Type
+}
+
+func NewErrLocationRequired(orderType Type) *ErrLocationRequired {
+	return &ErrLocationRequired{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_ORDER_LOCATION_REQUIRED",
+			Message: "order has no destination location",
+		},
+		Type: orderType,
+	}
+}
+
+func (e *ErrLocationRequired) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Type": e.Type,
+		},
+	})
+}
+
+type ErrLocationNotInWarehouse struct {
+	serrors.BaseError
+}
+
+func NewErrLocationNotInWarehouse() *ErrLocationNotInWarehouse {
+	return &ErrLocationNotInWarehouse{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_ORDER_LOCATION_NOT_IN_WAREHOUSE",
+			Message: "location does not belong to the order warehouse",
+		},
+	}
+}
+
+func (e *ErrLocationNotInWarehouse) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package order
+
+import "github.com/iota-uz/iota-sdk/pkg/events"
+
+// NewCreated is the outbox payload enqueued once the order is created.
+func NewCreated(o Order) events.OrderCreated {
+	return events.OrderCreated{
+		OrderID: o.ID(),
+		Type:    string(o.Type()),
+		Status:  string(o.Status()),
+	}
+}
+
+// NewCompleted is the outbox payload enqueued once the order is completed.
+func NewCompleted(o Order) events.OrderCompleted {
+	quantity := 0
+	for _, item := range o.Items() {
+		quantity += item.Quantity()
+	}
+	return events.OrderCompleted{
+		OrderID:  o.ID(),
+		Type:     string(o.Type()),
+		Quantity: quantity,
+	}
+}
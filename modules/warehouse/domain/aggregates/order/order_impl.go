@@ -6,31 +6,43 @@ import (
 	"time"
 )
 
-func New(orderType Type, status Status) Order {
+func New(orderType Type, status Status, warehouseID, locationID uint) Order {
 	return &orderImpl{
-		_type:     orderType,
-		status:    status,
-		items:     make([]Item, 0),
-		createdAt: time.Now(),
+		_type:       orderType,
+		status:      status,
+		warehouseID: warehouseID,
+		locationID:  locationID,
+		items:       make([]Item, 0),
+		createdAt:   time.Now(),
 	}
 }
 
-func NewWithID(id uint, orderType Type, status Status, createdAt time.Time) Order {
+func NewWithID(
+	id uint,
+	orderType Type,
+	status Status,
+	warehouseID, locationID uint,
+	createdAt time.Time,
+) Order {
 	return &orderImpl{
-		id:        id,
-		_type:     orderType,
-		status:    status,
-		items:     make([]Item, 0),
-		createdAt: createdAt,
+		id:          id,
+		_type:       orderType,
+		status:      status,
+		warehouseID: warehouseID,
+		locationID:  locationID,
+		items:       make([]Item, 0),
+		createdAt:   createdAt,
 	}
 }
 
 type orderImpl struct {
-	id        uint
-	_type     Type
-	status    Status
-	items     []Item
-	createdAt time.Time
+	id          uint
+	_type       Type
+	status      Status
+	warehouseID uint
+	locationID  uint
+	items       []Item
+	createdAt   time.Time
 }
 
 func (o *orderImpl) SetID(id uint) {
@@ -49,6 +61,14 @@ func (o *orderImpl) Status() Status {
 	return o.status
 }
 
+func (o *orderImpl) WarehouseID() uint {
+	return o.warehouseID
+}
+
+func (o *orderImpl) LocationID() uint {
+	return o.locationID
+}
+
 func (o *orderImpl) Items() []Item {
 	return o.items
 }
@@ -74,15 +94,21 @@ func (o *orderImpl) Complete() error {
 	if o.status == Complete {
 		return NewErrOrderIsComplete(o.status)
 	}
-	var status product.Status
-	if o._type == TypeIn {
-		status = product.InStock
-	} else {
-		status = product.Approved
+	if o._type != TypeOut && o.locationID == 0 {
+		return NewErrLocationRequired(o._type)
 	}
 	for _, item := range o.items {
 		for _, p := range item.Products() {
-			p.Status = status
+			switch o._type {
+			case TypeIn:
+				p.Status = product.InStock
+				p.LocationID = o.locationID
+			case TypeOut:
+				p.Status = product.Approved
+				p.LocationID = 0
+			case TypeTransfer:
+				p.LocationID = o.locationID
+			}
 		}
 	}
 	o.status = Complete
@@ -8,14 +8,15 @@ type DateRange struct {
 }
 
 type FindParams struct {
-	Limit     int
-	Offset    int
-	SortBy    []string
-	Query     string
-	Field     string
-	Status    string
-	Type      string
-	CreatedAt DateRange
+	Limit       int
+	Offset      int
+	SortBy      []string
+	Query       string
+	Field       string
+	Status      string
+	Type        string
+	WarehouseID uint
+	CreatedAt   DateRange
 }
 
 type Repository interface {
@@ -0,0 +1,53 @@
+package order_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+)
+
+func TestOrder_Complete(t *testing.T) {
+	received := &product.Product{ID: 1, Status: product.InDevelopment}
+	in := order.New(order.TypeIn, order.Pending, 1, 10)
+	if err := in.AddItem(&position.Position{}, received); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.Complete(); err != nil {
+		t.Fatalf("expected the in order to complete, got %v", err)
+	}
+	if received.Status != product.InStock || received.LocationID != 10 {
+		t.Errorf("expected the product in stock at 10, got %s at %d", received.Status, received.LocationID)
+	}
+
+	moved := &product.Product{ID: 2, Status: product.InStock, LocationID: 10}
+	transfer := order.New(order.TypeTransfer, order.Pending, 1, 11)
+	if err := transfer.AddItem(&position.Position{}, moved); err != nil {
+		t.Fatal(err)
+	}
+	if err := transfer.Complete(); err != nil {
+		t.Fatalf("expected the transfer to complete, got %v", err)
+	}
+	if moved.Status != product.InStock || moved.LocationID != 11 {
+		t.Errorf("expected the product to keep its status at 11, got %s at %d", moved.Status, moved.LocationID)
+	}
+
+	shipped := &product.Product{ID: 3, Status: product.InStock, LocationID: 11}
+	out := order.New(order.TypeOut, order.Pending, 1, 0)
+	if err := out.AddItem(&position.Position{}, shipped); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Complete(); err != nil {
+		t.Fatalf("expected the out order to complete, got %v", err)
+	}
+	if shipped.LocationID != 0 {
+		t.Errorf("expected the product to leave its location, got %d", shipped.LocationID)
+	}
+
+	var locationErr *order.ErrLocationRequired
+	if err := order.New(order.TypeTransfer, order.Pending, 1, 0).Complete(); !errors.As(err, &locationErr) {
+		t.Errorf("expected a transfer without destination to fail, got %v", err)
+	}
+}
@@ -26,12 +26,13 @@ func NewStatus(value string) (Status, error) {
 type Type string
 
 const (
-	TypeIn  Type = "in"
-	TypeOut Type = "out"
+	TypeIn       Type = "in"
+	TypeOut      Type = "out"
+	TypeTransfer Type = "transfer"
 )
 
 func (t Type) IsValid() bool {
-	return t == TypeIn || t == TypeOut
+	return t == TypeIn || t == TypeOut || t == TypeTransfer
 }
 
 func NewType(value string) (Type, error) {
@@ -2,6 +2,7 @@ package product
 
 import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
 	"time"
 )
 
@@ -19,9 +20,12 @@ func New(rfid string, positionID uint, status Status, position *position.Positio
 type Product struct {
 	ID         uint
 	PositionID uint
+	// LocationID is zero while the product is not placed in a warehouse
+	LocationID uint
 	Rfid       string
 	Status     Status
 	Position   *position.Position
+	Location   *location.Location
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
@@ -9,12 +9,14 @@ import (
 
 type CreateDTO struct {
 	PositionID uint
+	LocationID uint
 	Rfid       string
 	Status     string
 }
 
 type UpdateDTO struct {
 	PositionID uint
+	LocationID uint
 	Rfid       string
 	Status     string
 }
@@ -57,6 +59,7 @@ func (d *CreateDTO) ToEntity() (*Product, error) {
 	return &Product{
 		ID:         0,
 		PositionID: d.PositionID,
+		LocationID: d.LocationID,
 		Rfid:       d.Rfid,
 		Status:     s,
 		CreatedAt:  time.Now(),
@@ -72,6 +75,7 @@ func (d *UpdateDTO) ToEntity(id uint) (*Product, error) {
 	return &Product{
 		ID:         id,
 		PositionID: d.PositionID,
+		LocationID: d.LocationID,
 		Rfid:       d.Rfid,
 		Status:     s,
 		CreatedAt:  time.Now(),
@@ -18,18 +18,25 @@ type FindParams struct {
 	CreatedAt  DateRange
 	Rfids      []string
 	OrderID    uint
+	// WarehouseID and LocationID narrow the products down to a warehouse or a location subtree
+	WarehouseID uint
+	LocationID  uint
 }
 
 type FindByPositionParams struct {
-	Limit      int
-	SortBy     []string
-	PositionID uint
-	Status     Status
+	Limit       int
+	SortBy      []string
+	PositionID  uint
+	Status      Status
+	WarehouseID uint
+	LocationID  uint
 }
 
 type CountParams struct {
-	PositionID uint
-	Status     Status
+	PositionID  uint
+	Status      Status
+	WarehouseID uint
+	LocationID  uint
 }
 
 type Repository interface {
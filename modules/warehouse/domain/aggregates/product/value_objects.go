@@ -7,6 +7,7 @@ const (
 	InStock       Status = "in_stock"
 	InDevelopment Status = "in_development"
 	Approved      Status = "approved"
+	WrittenOff    Status = "written_off"
 )
 
 type Status string
@@ -21,7 +22,7 @@ func NewStatus(l string) (Status, error) {
 
 func (l Status) IsValid() bool {
 	switch l {
-	case InStock, InDevelopment, Approved, Shipped:
+	case InStock, InDevelopment, Approved, Shipped, WrittenOff:
 		return true
 	}
 	return false
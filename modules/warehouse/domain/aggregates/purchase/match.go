@@ -0,0 +1,59 @@
+package purchase
+
+import "math"
+
+// LineMatch compares the ordered and received quantity of a line.
+type LineMatch struct {
+	Line             *Line
+	QuantityMismatch bool
+}
+
+// Match is the three-way match of a purchase order: what was ordered, what was received
+// and what the supplier billed.
+type Match struct {
+	Lines    []*LineMatch
+	Ordered  float64
+	Received float64
+	// Billed is zero while no bill is linked
+	Billed float64
+	Bill   *Bill
+	// QuantityMismatch flags orders received in other quantities than ordered
+	QuantityMismatch bool
+	// PriceMismatch flags bills whose amount or currency differs from the received value
+	PriceMismatch bool
+}
+
+// HasBill reports whether a bill the finance did not reject is linked.
+func (m *Match) HasBill() bool {
+	return m.Bill != nil && m.Bill.Status != BillRejected
+}
+
+// Matched reports whether the order is billed for exactly what was ordered and received.
+func (m *Match) Matched() bool {
+	return m.HasBill() && !m.QuantityMismatch && !m.PriceMismatch
+}
+
+// Match compares the order with its receipts and bill. Quantities are only flagged once the order
+// is closed or billed, before that an open line is simply still awaited.
+func (o *Order) Match() *Match {
+	m := &Match{
+		Lines:    make([]*LineMatch, 0, len(o.Lines)),
+		Ordered:  o.Total(),
+		Received: o.ReceivedTotal(),
+		Bill:     o.Bill,
+	}
+	settled := o.Status == Received || m.HasBill()
+	for _, line := range o.Lines {
+		lm := &LineMatch{
+			Line:             line,
+			QuantityMismatch: settled && line.Received != line.Quantity,
+		}
+		m.QuantityMismatch = m.QuantityMismatch || lm.QuantityMismatch
+		m.Lines = append(m.Lines, lm)
+	}
+	if m.HasBill() {
+		m.Billed = o.Bill.Amount
+		m.PriceMismatch = o.Bill.Currency != o.Currency || math.Abs(m.Billed-m.Received) > PriceTolerance
+	}
+	return m
+}
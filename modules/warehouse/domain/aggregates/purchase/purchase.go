@@ -0,0 +1,210 @@
+package purchase
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+)
+
+// Order is a purchase order placed with a supplier. The supplier is a finance counterparty,
+// its goods are received in one or more receipts and it is billed by a finance supplier bill.
+type Order struct {
+	ID          uint
+	SupplierID  uint
+	Supplier    *Supplier
+	WarehouseID uint
+	Currency    string
+	Status      Status
+	Comment     string
+	Lines       []*Line
+	Receipts    []*Receipt
+	// BillID is zero until the supplier bill is linked
+	BillID      uint
+	Bill        *Bill
+	CreatedByID uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func New(supplierID, warehouseID uint, currency, comment string, createdByID uint) *Order {
+	return &Order{
+		SupplierID:  supplierID,
+		WarehouseID: warehouseID,
+		Currency:    currency,
+		Status:      Open,
+		Comment:     comment,
+		CreatedByID: createdByID,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// Line is the expected quantity and unit price of a position.
+type Line struct {
+	ID         uint
+	PositionID uint
+	Position   *position.Position
+	Quantity   int
+	UnitPrice  float64
+	Received   int
+}
+
+// Outstanding is the quantity still to be received.
+func (l *Line) Outstanding() int {
+	return max(l.Quantity-l.Received, 0)
+}
+
+func (l *Line) Total() float64 {
+	return float64(l.Quantity) * l.UnitPrice
+}
+
+func (l *Line) ReceivedTotal() float64 {
+	return float64(l.Received) * l.UnitPrice
+}
+
+// Receipt is a delivery of goods against the order. Its products are brought in by an in-order.
+type Receipt struct {
+	ID              uint
+	PurchaseOrderID uint
+	OrderID         uint
+	LocationID      uint
+	Lines           []*ReceiptLine
+	CreatedByID     uint
+	CreatedAt       time.Time
+}
+
+type ReceiptLine struct {
+	LineID   uint
+	Quantity int
+}
+
+func (r *Receipt) Quantity() int {
+	quantity := 0
+	for _, line := range r.Lines {
+		quantity += line.Quantity
+	}
+	return quantity
+}
+
+// Supplier mirrors the finance counterparty a purchase order is placed with.
+type Supplier struct {
+	ID        uint
+	Name      string
+	TIN       string
+	UpdatedAt time.Time
+}
+
+// Bill mirrors a supplier bill recorded in finance.
+type Bill struct {
+	ID         uint
+	SupplierID uint
+	Number     string
+	Amount     float64
+	Currency   string
+	Status     string
+	UpdatedAt  time.Time
+}
+
+func (o *Order) AddLine(positionID uint, quantity int, unitPrice float64) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	for _, line := range o.Lines {
+		if line.PositionID == positionID {
+			return ErrDuplicatePosition
+		}
+	}
+	o.Lines = append(o.Lines, &Line{
+		PositionID: positionID,
+		Quantity:   quantity,
+		UnitPrice:  unitPrice,
+	})
+	return nil
+}
+
+func (o *Order) Line(id uint) *Line {
+	for _, line := range o.Lines {
+		if line.ID == id {
+			return line
+		}
+	}
+	return nil
+}
+
+// IsClosed reports whether the order takes no more goods.
+func (o *Order) IsClosed() bool {
+	return o.Status == Received || o.Status == Cancelled
+}
+
+func (o *Order) Total() float64 {
+	total := 0.0
+	for _, line := range o.Lines {
+		total += line.Total()
+	}
+	return total
+}
+
+// ReceivedTotal is the value of the goods received so far at the ordered prices.
+func (o *Order) ReceivedTotal() float64 {
+	total := 0.0
+	for _, line := range o.Lines {
+		total += line.ReceivedTotal()
+	}
+	return total
+}
+
+// Receive books a receipt against the lines of the order, a line cannot receive more than is outstanding.
+func (o *Order) Receive(receipt *Receipt) error {
+	if o.IsClosed() {
+		return NewErrOrderClosed()
+	}
+	if receipt.Quantity() == 0 {
+		return NewErrEmptyReceipt()
+	}
+	for _, received := range receipt.Lines {
+		line := o.Line(received.LineID)
+		if line == nil {
+			return ErrLineNotFound
+		}
+		if received.Quantity > line.Outstanding() {
+			title := ""
+			if line.Position != nil {
+				title = line.Position.Title
+			}
+			return NewErrOverReceipt(title, line.Outstanding(), received.Quantity)
+		}
+	}
+	for _, received := range receipt.Lines {
+		o.Line(received.LineID).Received += received.Quantity
+	}
+	o.Receipts = append(o.Receipts, receipt)
+	o.Status = Received
+	for _, line := range o.Lines {
+		if line.Outstanding() > 0 {
+			o.Status = PartiallyReceived
+		}
+	}
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// Cancel closes an order nothing was received for.
+func (o *Order) Cancel() error {
+	if o.Status != Open {
+		return NewErrOrderClosed()
+	}
+	o.Status = Cancelled
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// LinkBill links the supplier bill the order is matched against.
+func (o *Order) LinkBill(b *Bill) error {
+	if b.SupplierID != o.SupplierID {
+		return NewErrBillSupplier()
+	}
+	o.BillID = b.ID
+	o.Bill = b
+	o.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,104 @@
+package purchase
+
+import (
+	"strings"
+	"unicode"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+type LineDTO struct {
+	PositionID uint    `validate:"required"`
+	Quantity   int     `validate:"gt=0"`
+	UnitPrice  float64 `validate:"gte=0"`
+}
+
+type CreateDTO struct {
+	SupplierID  uint   `validate:"required"`
+	WarehouseID uint   `validate:"required"`
+	Currency    string `validate:"required,len=3"`
+	Comment     string
+	Lines       []*LineDTO `validate:"required,min=1,dive"`
+}
+
+func (d *CreateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[err.Field()] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) ToEntity(createdByID uint) (*Order, error) {
+	entity := New(d.SupplierID, d.WarehouseID, strings.ToUpper(d.Currency), strings.TrimSpace(d.Comment), createdByID)
+	for _, line := range d.Lines {
+		if err := entity.AddLine(line.PositionID, line.Quantity, line.UnitPrice); err != nil {
+			return nil, err
+		}
+	}
+	return entity, nil
+}
+
+// ReceiveLineDTO lists the RFID tags of the products received for a line.
+type ReceiveLineDTO struct {
+	LineID uint
+	Tags   string
+}
+
+// TagList splits the tags on commas, semicolons and whitespace, dropping duplicates.
+func (d *ReceiveLineDTO) TagList() []string {
+	fields := strings.FieldsFunc(d.Tags, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+	tags := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, tag := range fields {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+type ReceiveDTO struct {
+	LocationID uint `validate:"required"`
+	Lines      []*ReceiveLineDTO
+}
+
+func (d *ReceiveDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[err.Field()] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+type LinkBillDTO struct {
+	BillID uint `validate:"required"`
+}
+
+func (d *LinkBillDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[err.Field()] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
@@ -0,0 +1,109 @@
+package purchase
+
+import (
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+var (
+	ErrInvalidQuantity   = errors.New("ordered quantity must be positive")
+	ErrDuplicatePosition = errors.New("position is already ordered in another line")
+	ErrLineNotFound      = errors.New("purchase order line not found")
+)
+
+type ErrOrderClosed struct {
+	serrors.BaseError
+}
+
+func NewErrOrderClosed() *ErrOrderClosed {
+	return &ErrOrderClosed{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_PURCHASE_ORDER_CLOSED",
+			Message: "purchase order is already received or cancelled",
+		},
+	}
+}
+
+func (e *ErrOrderClosed) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+	})
+}
+
+type ErrEmptyReceipt struct {
+	serrors.BaseError
+}
+
+func NewErrEmptyReceipt() *ErrEmptyReceipt {
+	return &ErrEmptyReceipt{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_PURCHASE_RECEIPT_EMPTY",
+			Message: "goods receipt has no products",
+		},
+	}
+}
+
+func (e *ErrEmptyReceipt) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+	})
+}
+
+type ErrOverReceipt struct {
+	serrors.BaseError
+	Position    string
+	Outstanding int
+	Quantity    int
+}
+
+func NewErrOverReceipt(position string, outstanding, quantity int) *ErrOverReceipt {
+	return &ErrOverReceipt{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_PURCHASE_OVER_RECEIPT",
+			Message: "more products are received than outstanding on the line",
+		},
+		Position:    position,
+		Outstanding: outstanding,
+		Quantity:    quantity,
+	}
+}
+
+func (e *ErrOverReceipt) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Position":    e.Position,
+			"Outstanding": e.Outstanding,
+			"Quantity":    e.Quantity,
+		},
+	})
+}
+
+type ErrBillSupplier struct {
+	serrors.BaseError
+}
+
+func NewErrBillSupplier() *ErrBillSupplier {
+	return &ErrBillSupplier{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_PURCHASE_BILL_SUPPLIER",
+			Message: "bill is issued by another supplier than the purchase order",
+		},
+	}
+}
+
+func (e *ErrBillSupplier) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+	})
+}
@@ -0,0 +1,13 @@
+package purchase
+
+import "github.com/iota-uz/iota-sdk/pkg/events"
+
+// NewCreated is the outbox payload enqueued once the order is placed.
+func NewCreated(o *Order) events.PurchaseCreated {
+	return events.PurchaseCreated{
+		PurchaseOrderID: o.ID,
+		SupplierID:      o.SupplierID,
+		Currency:        o.Currency,
+		Total:           o.Total(),
+	}
+}
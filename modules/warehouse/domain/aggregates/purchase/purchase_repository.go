@@ -0,0 +1,29 @@
+package purchase
+
+import "context"
+
+type FindParams struct {
+	Limit       int
+	Offset      int
+	SupplierID  uint
+	WarehouseID uint
+	Status      Status
+}
+
+type Repository interface {
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Order, error)
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetByID(ctx context.Context, id uint) (*Order, error)
+	Create(ctx context.Context, data *Order) error
+	// Update saves the status, comment, bill and received quantities of an order.
+	Update(ctx context.Context, data *Order) error
+	Delete(ctx context.Context, id uint) error
+	CreateReceipt(ctx context.Context, data *Receipt) error
+
+	GetSupplier(ctx context.Context, id uint) (*Supplier, error)
+	SaveSupplier(ctx context.Context, data *Supplier) error
+	// Bills returns the mirrored bills of a supplier, latest first.
+	Bills(ctx context.Context, supplierID uint) ([]*Bill, error)
+	GetBill(ctx context.Context, id uint) (*Bill, error)
+	SaveBill(ctx context.Context, data *Bill) error
+}
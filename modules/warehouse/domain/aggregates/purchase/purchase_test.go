@@ -0,0 +1,98 @@
+package purchase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/purchase"
+)
+
+func newOrder(t *testing.T) *purchase.Order {
+	t.Helper()
+	o := purchase.New(7, 1, "USD", "", 1)
+	if err := o.AddLine(3, 10, 2.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.AddLine(4, 2, 100); err != nil {
+		t.Fatal(err)
+	}
+	o.Lines[0].ID, o.Lines[1].ID = 1, 2
+	return o
+}
+
+func TestOrder_Receive(t *testing.T) {
+	o := newOrder(t)
+	if err := o.AddLine(3, 1, 1); !errors.Is(err, purchase.ErrDuplicatePosition) {
+		t.Errorf("expected a duplicate position error, got %v", err)
+	}
+
+	err := o.Receive(&purchase.Receipt{Lines: []*purchase.ReceiptLine{{LineID: 2, Quantity: 3}}})
+	var overReceipt *purchase.ErrOverReceipt
+	if !errors.As(err, &overReceipt) || overReceipt.Outstanding != 2 {
+		t.Fatalf("expected an over receipt of 2 outstanding, got %v", err)
+	}
+	if o.Lines[1].Received != 0 {
+		t.Errorf("expected a rejected receipt to receive nothing, got %d", o.Lines[1].Received)
+	}
+
+	if err := o.Receive(&purchase.Receipt{Lines: []*purchase.ReceiptLine{{LineID: 1, Quantity: 4}, {LineID: 2, Quantity: 2}}}); err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != purchase.PartiallyReceived || o.Lines[0].Outstanding() != 6 {
+		t.Errorf("expected 6 outstanding on a partially received order, got %d on %s", o.Lines[0].Outstanding(), o.Status)
+	}
+	if err := o.Receive(&purchase.Receipt{Lines: []*purchase.ReceiptLine{{LineID: 1, Quantity: 6}}}); err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != purchase.Received || len(o.Receipts) != 2 {
+		t.Errorf("expected a received order with 2 receipts, got %d receipts on %s", len(o.Receipts), o.Status)
+	}
+	var closed *purchase.ErrOrderClosed
+	if err := o.Receive(&purchase.Receipt{Lines: []*purchase.ReceiptLine{{LineID: 1, Quantity: 1}}}); !errors.As(err, &closed) {
+		t.Errorf("expected a received order to be closed, got %v", err)
+	}
+	if err := o.Cancel(); !errors.As(err, &closed) {
+		t.Errorf("expected a received order not to be cancelled, got %v", err)
+	}
+}
+
+func TestOrder_Match(t *testing.T) {
+	o := newOrder(t)
+	if err := o.Receive(&purchase.Receipt{Lines: []*purchase.ReceiptLine{{LineID: 1, Quantity: 10}, {LineID: 2, Quantity: 1}}}); err != nil {
+		t.Fatal(err)
+	}
+	m := o.Match()
+	if m.QuantityMismatch || m.PriceMismatch || m.Matched() {
+		t.Errorf("expected an unbilled open order to be neither flagged nor matched, got %+v", m)
+	}
+
+	if err := o.LinkBill(&purchase.Bill{ID: 5, SupplierID: 8}); err == nil {
+		t.Error("expected a bill of another supplier to be refused")
+	}
+	if err := o.LinkBill(&purchase.Bill{ID: 5, SupplierID: 7, Amount: 125, Currency: "USD"}); err != nil {
+		t.Fatal(err)
+	}
+	m = o.Match()
+	if !m.QuantityMismatch || m.PriceMismatch {
+		t.Errorf("expected a billed short delivery to flag quantities only, got %+v", m)
+	}
+	if !m.Lines[1].QuantityMismatch || m.Lines[0].QuantityMismatch {
+		t.Errorf("expected only the second line to be flagged, got %v and %v", m.Lines[0].QuantityMismatch, m.Lines[1].QuantityMismatch)
+	}
+
+	if err := o.Receive(&purchase.Receipt{Lines: []*purchase.ReceiptLine{{LineID: 2, Quantity: 1}}}); err != nil {
+		t.Fatal(err)
+	}
+	m = o.Match()
+	if m.QuantityMismatch || !m.PriceMismatch {
+		t.Errorf("expected a bill of 125 for 225 received to flag the price, got %+v", m)
+	}
+	o.Bill.Amount = 225
+	if m = o.Match(); !m.Matched() {
+		t.Errorf("expected the order to match, got %+v", m)
+	}
+	o.Bill.Currency = "EUR"
+	if m = o.Match(); !m.PriceMismatch {
+		t.Error("expected a bill in another currency to flag the price")
+	}
+}
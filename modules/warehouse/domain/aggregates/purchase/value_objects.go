@@ -0,0 +1,39 @@
+package purchase
+
+import "errors"
+
+type Status string
+
+const (
+	// Open orders wait for the goods
+	Open Status = "open"
+	// PartiallyReceived orders have some of their goods received
+	PartiallyReceived Status = "partially_received"
+	// Received orders have all of their goods received
+	Received Status = "received"
+	// Cancelled orders were closed before any goods were received
+	Cancelled Status = "cancelled"
+)
+
+func NewStatus(value string) (Status, error) {
+	s := Status(value)
+	if !s.IsValid() {
+		return "", errors.New("invalid purchase order status")
+	}
+	return s, nil
+}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Open, PartiallyReceived, Received, Cancelled:
+		return true
+	}
+	return false
+}
+
+// BillRejected is the status finance gives to bills sent back to the submitter, such bills
+// are not matched against purchase orders.
+const BillRejected = "REJECTED"
+
+// PriceTolerance is how far the billed amount may be from the received value and still match.
+const PriceTolerance = 0.01
@@ -0,0 +1,45 @@
+package sales
+
+import "github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+
+// Commitments is what the open sales orders of a warehouse hold of its stock: the ordered quantities
+// per position and the products already picked.
+type Commitments struct {
+	Reserved map[uint]int
+	Picked   map[uint]bool
+}
+
+// Move checks that none of the products is picked for an open sales order, a picked product stays
+// where the picker left it until the order ships.
+func (c *Commitments) Move(products []*product.Product) error {
+	for _, p := range products {
+		if c.Picked[p.ID] {
+			return NewErrProductAlreadyPicked(p.Rfid)
+		}
+	}
+	return nil
+}
+
+// Release checks that the products can leave the warehouse: none of them is picked and the stock
+// left of their positions still covers the reservations. onHand is the stock of the positions in
+// the warehouse before the products leave.
+func (c *Commitments) Release(products []*product.Product, onHand map[uint]int) error {
+	if err := c.Move(products); err != nil {
+		return err
+	}
+	leaving := make(map[uint]int)
+	titles := make(map[uint]string)
+	for _, p := range products {
+		leaving[p.PositionID]++
+		if p.Position != nil {
+			titles[p.PositionID] = p.Position.Title
+		}
+	}
+	for positionID, quantity := range leaving {
+		available := max(onHand[positionID]-c.Reserved[positionID], 0)
+		if quantity > available {
+			return NewErrInsufficientStock(titles[positionID], available, quantity)
+		}
+	}
+	return nil
+}
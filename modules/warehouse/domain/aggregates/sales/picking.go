@@ -0,0 +1,73 @@
+package sales
+
+import "github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+
+// Availability is how many unpicked products of a position are in stock at a location.
+type Availability struct {
+	PositionID uint
+	LocationID uint
+	Location   *location.Location
+	Quantity   int
+}
+
+type PickingItem struct {
+	Line     *Line
+	Quantity int
+}
+
+// PickingStop is a location the picker visits and what to take there.
+type PickingStop struct {
+	LocationID uint
+	Location   *location.Location
+	Items      []*PickingItem
+}
+
+// PickingList routes the picker through the locations holding the outstanding products.
+type PickingList struct {
+	Stops []*PickingStop
+	// Shortages are the quantities no location holds
+	Shortages []*PickingItem
+}
+
+// PickingList allocates the outstanding quantity of every line to the locations in stock,
+// visiting them in the order given.
+func (o *Order) PickingList(stock []*Availability) *PickingList {
+	list := &PickingList{
+		Stops:     make([]*PickingStop, 0),
+		Shortages: make([]*PickingItem, 0),
+	}
+	stops := make(map[uint]*PickingStop)
+	for _, a := range stock {
+		if _, ok := stops[a.LocationID]; !ok {
+			stops[a.LocationID] = &PickingStop{LocationID: a.LocationID, Location: a.Location}
+		}
+	}
+	for _, line := range o.Lines {
+		remaining := o.Outstanding(line)
+		for _, a := range stock {
+			if remaining == 0 {
+				break
+			}
+			if a.PositionID != line.PositionID || a.Quantity <= 0 {
+				continue
+			}
+			take := min(a.Quantity, remaining)
+			stop := stops[a.LocationID]
+			stop.Items = append(stop.Items, &PickingItem{Line: line, Quantity: take})
+			remaining -= take
+		}
+		if remaining > 0 {
+			list.Shortages = append(list.Shortages, &PickingItem{Line: line, Quantity: remaining})
+		}
+	}
+	seen := make(map[uint]bool, len(stops))
+	for _, a := range stock {
+		stop := stops[a.LocationID]
+		if seen[a.LocationID] || len(stop.Items) == 0 {
+			continue
+		}
+		seen[a.LocationID] = true
+		list.Stops = append(list.Stops, stop)
+	}
+	return list
+}
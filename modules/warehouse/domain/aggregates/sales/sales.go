@@ -0,0 +1,209 @@
+package sales
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+)
+
+// Order is a sales order shipped from a warehouse. It reserves quantities of positions rather than
+// products, the products are chosen when they are picked and leave with an out order.
+type Order struct {
+	ID          uint
+	WarehouseID uint
+	Customer    string
+	Status      Status
+	Comment     string
+	Lines       []*Line
+	Picks       []*Pick
+	// OrderID is the out order the sales order shipped with, zero until it is shipped
+	OrderID     uint
+	CreatedByID uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func New(warehouseID uint, customer, comment string, createdByID uint) *Order {
+	return &Order{
+		WarehouseID: warehouseID,
+		Customer:    customer,
+		Status:      Reserved,
+		Comment:     comment,
+		CreatedByID: createdByID,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// Line is the quantity of a position ordered.
+type Line struct {
+	ID         uint
+	PositionID uint
+	Position   *position.Position
+	Quantity   int
+}
+
+func (l *Line) title() string {
+	if l.Position == nil {
+		return ""
+	}
+	return l.Position.Title
+}
+
+// Pick is a product confirmed as taken off the shelf for a line.
+type Pick struct {
+	ID           uint
+	SalesOrderID uint
+	LineID       uint
+	ProductID    uint
+	Rfid         string
+	// LocationID is where the product was picked from, zero when it was not placed
+	LocationID uint
+	PickedByID uint
+	PickedAt   time.Time
+}
+
+func (o *Order) AddLine(positionID uint, quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if o.LineFor(positionID) != nil {
+		return ErrDuplicatePosition
+	}
+	o.Lines = append(o.Lines, &Line{
+		PositionID: positionID,
+		Quantity:   quantity,
+	})
+	return nil
+}
+
+func (o *Order) Line(id uint) *Line {
+	for _, line := range o.Lines {
+		if line.ID == id {
+			return line
+		}
+	}
+	return nil
+}
+
+// LineFor returns the line of a position, nil when the position is not ordered.
+func (o *Order) LineFor(positionID uint) *Line {
+	for _, line := range o.Lines {
+		if line.PositionID == positionID {
+			return line
+		}
+	}
+	return nil
+}
+
+// Picked is how many products were picked for a line.
+func (o *Order) Picked(lineID uint) int {
+	picked := 0
+	for _, p := range o.Picks {
+		if p.LineID == lineID {
+			picked++
+		}
+	}
+	return picked
+}
+
+// Outstanding is how many products of a line are still to be picked.
+func (o *Order) Outstanding(line *Line) int {
+	return max(line.Quantity-o.Picked(line.ID), 0)
+}
+
+// PickedProductIDs returns the products picked for a line.
+func (o *Order) PickedProductIDs(lineID uint) []uint {
+	ids := make([]uint, 0)
+	for _, p := range o.Picks {
+		if p.LineID == lineID {
+			ids = append(ids, p.ProductID)
+		}
+	}
+	return ids
+}
+
+// IsClosed reports whether the order takes no more picks.
+func (o *Order) IsClosed() bool {
+	return o.Status == Shipped || o.Status == Cancelled
+}
+
+// Reserve checks that the warehouse can serve every line. onHand is the stock of the positions in
+// the warehouse and reserved what other open sales orders already hold of it.
+func (o *Order) Reserve(onHand, reserved map[uint]int) error {
+	for _, line := range o.Lines {
+		available := max(onHand[line.PositionID]-reserved[line.PositionID], 0)
+		if line.Quantity > available {
+			return NewErrInsufficientStock(line.title(), available, line.Quantity)
+		}
+	}
+	return nil
+}
+
+// Pick confirms a product for the line of its position. The product must be in stock in the
+// warehouse of the order and not picked already.
+func (o *Order) Pick(p *product.Product, pickedByID uint) (*Pick, error) {
+	if o.IsClosed() {
+		return nil, NewErrOrderClosed()
+	}
+	if p.Status != product.InStock || p.Location == nil || p.Location.WarehouseID != o.WarehouseID {
+		return nil, NewErrProductNotAvailable(p.Rfid)
+	}
+	for _, picked := range o.Picks {
+		if picked.ProductID == p.ID {
+			return nil, NewErrProductAlreadyPicked(p.Rfid)
+		}
+	}
+	line := o.LineFor(p.PositionID)
+	if line == nil || o.Outstanding(line) == 0 {
+		return nil, NewErrProductNotOrdered(p.Rfid)
+	}
+	pick := &Pick{
+		SalesOrderID: o.ID,
+		LineID:       line.ID,
+		ProductID:    p.ID,
+		Rfid:         p.Rfid,
+		LocationID:   p.LocationID,
+		PickedByID:   pickedByID,
+		PickedAt:     time.Now(),
+	}
+	o.Picks = append(o.Picks, pick)
+	o.Status = Picking
+	o.UpdatedAt = time.Now()
+	return pick, nil
+}
+
+// ReadyToShip reports why the order cannot ship yet, every line must be picked in full.
+func (o *Order) ReadyToShip() error {
+	if o.IsClosed() {
+		return NewErrOrderClosed()
+	}
+	for _, line := range o.Lines {
+		if outstanding := o.Outstanding(line); outstanding > 0 {
+			return NewErrPickingIncomplete(line.title(), outstanding)
+		}
+	}
+	return nil
+}
+
+// Ship closes a fully picked order with the out order its products left with.
+func (o *Order) Ship(orderID uint) error {
+	if err := o.ReadyToShip(); err != nil {
+		return err
+	}
+	o.OrderID = orderID
+	o.Status = Shipped
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// Cancel releases the reservation, the picked products stay where they are.
+func (o *Order) Cancel() error {
+	if o.IsClosed() {
+		return NewErrOrderClosed()
+	}
+	o.Status = Cancelled
+	o.UpdatedAt = time.Now()
+	return nil
+}
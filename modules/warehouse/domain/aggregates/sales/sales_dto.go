@@ -0,0 +1,62 @@
+package sales
+
+import (
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+type LineDTO struct {
+	PositionID uint `validate:"required"`
+	Quantity   int  `validate:"gt=0"`
+}
+
+type CreateDTO struct {
+	WarehouseID uint   `validate:"required"`
+	Customer    string `validate:"required"`
+	Comment     string
+	Lines       []*LineDTO `validate:"required,min=1,dive"`
+}
+
+func (d *CreateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[err.Field()] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) ToEntity(createdByID uint) (*Order, error) {
+	entity := New(d.WarehouseID, strings.TrimSpace(d.Customer), strings.TrimSpace(d.Comment), createdByID)
+	for _, line := range d.Lines {
+		if err := entity.AddLine(line.PositionID, line.Quantity); err != nil {
+			return nil, err
+		}
+	}
+	return entity, nil
+}
+
+// PickDTO is an RFID tag or a position barcode scanned by the picker.
+type PickDTO struct {
+	Code string `validate:"required"`
+}
+
+func (d *PickDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[err.Field()] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
@@ -0,0 +1,199 @@
+package sales
+
+import (
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+var (
+	ErrInvalidQuantity   = errors.New("ordered quantity must be positive")
+	ErrDuplicatePosition = errors.New("position is already ordered in another line")
+)
+
+type ErrOrderClosed struct {
+	serrors.BaseError
+}
+
+func NewErrOrderClosed() *ErrOrderClosed {
+	return &ErrOrderClosed{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_SALES_ORDER_CLOSED",
+			Message: "sales order is already shipped or cancelled",
+		},
+	}
+}
+
+func (e *ErrOrderClosed) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+	})
+}
+
+type ErrInsufficientStock struct {
+	serrors.BaseError
+	Position  string
+	Available int
+	Requested int
+}
+
+func NewErrInsufficientStock(position string, available, requested int) *ErrInsufficientStock {
+	return &ErrInsufficientStock{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_SALES_INSUFFICIENT_STOCK",
+			Message: "not enough unreserved stock for the ordered quantity",
+		},
+		Position:  position,
+		Available: available,
+		Requested: requested,
+	}
+}
+
+func (e *ErrInsufficientStock) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Position":  e.Position,
+			"Available": e.Available,
+			"Requested": e.Requested,
+		},
+	})
+}
+
+type ErrProductNotAvailable struct {
+	serrors.BaseError
+	Rfid string
+}
+
+func NewErrProductNotAvailable(rfid string) *ErrProductNotAvailable {
+	return &ErrProductNotAvailable{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_SALES_PRODUCT_NOT_AVAILABLE",
+			Message: "product is not in stock in the warehouse of the sales order",
+		},
+		Rfid: rfid,
+	}
+}
+
+func (e *ErrProductNotAvailable) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Rfid": e.Rfid,
+		},
+	})
+}
+
+type ErrProductAlreadyPicked struct {
+	serrors.BaseError
+	Rfid string
+}
+
+func NewErrProductAlreadyPicked(rfid string) *ErrProductAlreadyPicked {
+	return &ErrProductAlreadyPicked{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_SALES_PRODUCT_ALREADY_PICKED",
+			Message: "product is already picked for a sales order",
+		},
+		Rfid: rfid,
+	}
+}
+
+func (e *ErrProductAlreadyPicked) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Rfid": e.Rfid,
+		},
+	})
+}
+
+type ErrProductNotOrdered struct {
+	serrors.BaseError
+	Rfid string
+}
+
+func NewErrProductNotOrdered(rfid string) *ErrProductNotOrdered {
+	return &ErrProductNotOrdered{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_SALES_PRODUCT_NOT_ORDERED",
+			Message: "position of the product is not ordered or already picked in full",
+		},
+		Rfid: rfid,
+	}
+}
+
+func (e *ErrProductNotOrdered) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Rfid": e.Rfid,
+		},
+	})
+}
+
+type ErrCodeNotFound struct {
+	serrors.BaseError
+	Code string
+}
+
+func NewErrCodeNotFound(code string) *ErrCodeNotFound {
+	return &ErrCodeNotFound{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_SALES_CODE_NOT_FOUND",
+			Message: "no product or position matches the scanned code",
+		},
+		Code: code,
+	}
+}
+
+func (e *ErrCodeNotFound) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.BaseError.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Code": e.Code,
+		},
+	})
+}
+
+type ErrPickingIncomplete struct {
+	serrors.BaseError
+	Position    string
+	Outstanding int
+}
+
+func NewErrPickingIncomplete(position string, outstanding int) *ErrPickingIncomplete {
+	return &ErrPickingIncomplete{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_SALES_PICKING_INCOMPLETE",
+			Message: "every line must be picked in full before the order ships",
+		},
+		Position:    position,
+		Outstanding: outstanding,
+	}
+}
+
+func (e *ErrPickingIncomplete) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Position":    e.Position,
+			"Outstanding": e.Outstanding,
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package sales
+
+import "context"
+
+type FindParams struct {
+	Limit       int
+	Offset      int
+	WarehouseID uint
+	Status      Status
+}
+
+type Repository interface {
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Order, error)
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetByID(ctx context.Context, id uint) (*Order, error)
+	Create(ctx context.Context, data *Order) error
+	// Update saves the status, comment and out order of a sales order.
+	Update(ctx context.Context, data *Order) error
+	Delete(ctx context.Context, id uint) error
+	CreatePick(ctx context.Context, data *Pick) error
+	// Reserved sums the ordered quantities per position of the open sales orders of a warehouse,
+	// leaving out the order excludeID.
+	Reserved(ctx context.Context, warehouseID, excludeID uint) (map[uint]int, error)
+	// PickedProductIDs returns the products picked for the open sales orders of a warehouse.
+	PickedProductIDs(ctx context.Context, warehouseID uint) (map[uint]bool, error)
+	// Commitments returns the reservations and the picks of the open sales orders of a warehouse.
+	Commitments(ctx context.Context, warehouseID uint) (*Commitments, error)
+}
@@ -0,0 +1,139 @@
+package sales_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+)
+
+func newOrder(t *testing.T) *sales.Order {
+	t.Helper()
+	o := sales.New(1, "ACME", "", 1)
+	if err := o.AddLine(3, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.AddLine(4, 1); err != nil {
+		t.Fatal(err)
+	}
+	o.Lines[0].ID, o.Lines[1].ID = 1, 2
+	return o
+}
+
+func newProduct(id, positionID, warehouseID uint) *product.Product {
+	return &product.Product{
+		ID:         id,
+		PositionID: positionID,
+		LocationID: 10,
+		Rfid:       "TAG",
+		Status:     product.InStock,
+		Location:   &location.Location{ID: 10, WarehouseID: warehouseID},
+	}
+}
+
+func TestOrder_Reserve(t *testing.T) {
+	o := newOrder(t)
+	if err := o.AddLine(3, 1); !errors.Is(err, sales.ErrDuplicatePosition) {
+		t.Errorf("expected a duplicate position error, got %v", err)
+	}
+	if err := o.Reserve(map[uint]int{3: 5, 4: 1}, map[uint]int{3: 3}); err != nil {
+		t.Fatal(err)
+	}
+	var insufficient *sales.ErrInsufficientStock
+	err := o.Reserve(map[uint]int{3: 5, 4: 1}, map[uint]int{3: 4})
+	if !errors.As(err, &insufficient) || insufficient.Available != 1 || insufficient.Requested != 2 {
+		t.Errorf("expected 1 of 2 available, got %v", err)
+	}
+}
+
+func TestCommitments_Release(t *testing.T) {
+	c := &sales.Commitments{
+		Reserved: map[uint]int{3: 2},
+		Picked:   map[uint]bool{1: true},
+	}
+	var picked *sales.ErrProductAlreadyPicked
+	if err := c.Move([]*product.Product{newProduct(1, 3, 1)}); !errors.As(err, &picked) {
+		t.Errorf("expected a picked product to stay, got %v", err)
+	}
+	if err := c.Release([]*product.Product{newProduct(2, 3, 1), newProduct(5, 4, 1)}, map[uint]int{3: 3, 4: 1}); err != nil {
+		t.Fatal(err)
+	}
+	var insufficient *sales.ErrInsufficientStock
+	err := c.Release([]*product.Product{newProduct(2, 3, 1), newProduct(6, 3, 1)}, map[uint]int{3: 3})
+	if !errors.As(err, &insufficient) || insufficient.Available != 1 || insufficient.Requested != 2 {
+		t.Errorf("expected 1 of 2 unreserved, got %v", err)
+	}
+}
+
+func TestOrder_Pick(t *testing.T) {
+	o := newOrder(t)
+	var notAvailable *sales.ErrProductNotAvailable
+	if _, err := o.Pick(newProduct(1, 3, 2), 1); !errors.As(err, &notAvailable) {
+		t.Errorf("expected a product of another warehouse to be refused, got %v", err)
+	}
+	var notOrdered *sales.ErrProductNotOrdered
+	if _, err := o.Pick(newProduct(1, 5, 1), 1); !errors.As(err, &notOrdered) {
+		t.Errorf("expected a product of another position to be refused, got %v", err)
+	}
+
+	if _, err := o.Pick(newProduct(1, 3, 1), 1); err != nil {
+		t.Fatal(err)
+	}
+	var alreadyPicked *sales.ErrProductAlreadyPicked
+	if _, err := o.Pick(newProduct(1, 3, 1), 1); !errors.As(err, &alreadyPicked) {
+		t.Errorf("expected a product to be picked once, got %v", err)
+	}
+	if o.Status != sales.Picking || o.Outstanding(o.Lines[0]) != 1 {
+		t.Errorf("expected 1 outstanding on a picking order, got %d on %s", o.Outstanding(o.Lines[0]), o.Status)
+	}
+
+	var incomplete *sales.ErrPickingIncomplete
+	if err := o.Ship(9); !errors.As(err, &incomplete) || incomplete.Outstanding != 1 {
+		t.Fatalf("expected shipping to wait for 1 outstanding, got %v", err)
+	}
+	if _, err := o.Pick(newProduct(2, 3, 1), 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.Pick(newProduct(3, 3, 1), 1); !errors.As(err, &notOrdered) {
+		t.Errorf("expected a fully picked line to refuse more, got %v", err)
+	}
+	if _, err := o.Pick(newProduct(4, 4, 1), 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Ship(9); err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != sales.Shipped || o.OrderID != 9 {
+		t.Errorf("expected a shipped order with out order 9, got %d on %s", o.OrderID, o.Status)
+	}
+	var closed *sales.ErrOrderClosed
+	if err := o.Cancel(); !errors.As(err, &closed) {
+		t.Errorf("expected a shipped order not to be cancelled, got %v", err)
+	}
+}
+
+func TestOrder_PickingList(t *testing.T) {
+	o := newOrder(t)
+	list := o.PickingList([]*sales.Availability{
+		{PositionID: 3, LocationID: 20, Quantity: 1},
+		{PositionID: 4, LocationID: 10, Quantity: 5},
+		{PositionID: 3, LocationID: 10, Quantity: 5},
+		{PositionID: 5, LocationID: 30, Quantity: 5},
+	})
+	if len(list.Stops) != 2 || list.Stops[0].LocationID != 20 || list.Stops[1].LocationID != 10 {
+		t.Fatalf("expected stops at 20 then 10, got %d stops", len(list.Stops))
+	}
+	if len(list.Stops[1].Items) != 2 || list.Stops[1].Items[0].Quantity != 1 || list.Stops[1].Items[1].Quantity != 1 {
+		t.Errorf("expected one of each position at 10, got %d items", len(list.Stops[1].Items))
+	}
+	if len(list.Shortages) != 0 {
+		t.Errorf("expected no shortages, got %d", len(list.Shortages))
+	}
+
+	list = o.PickingList([]*sales.Availability{{PositionID: 3, LocationID: 10, Quantity: 1}})
+	if len(list.Shortages) != 2 || list.Shortages[0].Quantity != 1 || list.Shortages[1].Quantity != 1 {
+		t.Errorf("expected a shortage of 1 on both lines, got %d", len(list.Shortages))
+	}
+}
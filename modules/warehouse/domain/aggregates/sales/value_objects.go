@@ -0,0 +1,37 @@
+package sales
+
+import "errors"
+
+type Status string
+
+const (
+	// Reserved orders hold stock of their positions, no product is picked yet
+	Reserved Status = "reserved"
+	// Picking orders have some of their products picked
+	Picking Status = "picking"
+	// Shipped orders left the warehouse with an out order
+	Shipped Status = "shipped"
+	// Cancelled orders released their reservation
+	Cancelled Status = "cancelled"
+)
+
+func NewStatus(value string) (Status, error) {
+	s := Status(value)
+	if !s.IsValid() {
+		return "", errors.New("invalid sales order status")
+	}
+	return s, nil
+}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Reserved, Picking, Shipped, Cancelled:
+		return true
+	}
+	return false
+}
+
+// Holds reports whether orders of the status keep their stock reserved.
+func (s Status) Holds() bool {
+	return s == Reserved || s == Picking
+}
@@ -18,6 +18,11 @@ type Check struct {
 	CreatedBy    user.User
 	FinishedBy   user.User
 	FinishedByID uint
+	ApprovedAt   time.Time
+	ApprovedByID uint
+	ApprovedBy   user.User
+	// WarehouseID is the warehouse counted by the check, zero for a check of every warehouse
+	WarehouseID uint
 }
 
 func (c *Check) AddResult(positionID uint, expected, actual int) {
@@ -30,6 +35,23 @@ func (c *Check) AddResult(positionID uint, expected, actual int) {
 	})
 }
 
+// IsLocked reports whether the check was approved and can no longer be changed.
+func (c *Check) IsLocked() bool {
+	return c.Status == Approved
+}
+
+// Approve locks the check once its adjustments are applied to the stock.
+func (c *Check) Approve(approvedBy user.User) error {
+	if c.IsLocked() {
+		return NewErrCheckLocked()
+	}
+	c.Status = Approved
+	c.ApprovedAt = time.Now()
+	c.ApprovedBy = approvedBy
+	c.ApprovedByID = approvedBy.ID()
+	return nil
+}
+
 type Position struct {
 	ID       uint
 	Title    string
@@ -46,3 +68,13 @@ type CheckResult struct {
 	Difference       int
 	CreatedAt        time.Time
 }
+
+// Missing is the number of products expected but not found.
+func (r *CheckResult) Missing() int {
+	return max(r.Difference, 0)
+}
+
+// Surplus is the number of products found but not expected.
+func (r *CheckResult) Surplus() int {
+	return max(-r.Difference, 0)
+}
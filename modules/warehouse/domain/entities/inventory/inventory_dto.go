@@ -2,7 +2,9 @@ package inventory
 
 import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"strings"
 	"time"
+	"unicode"
 
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
@@ -15,8 +17,10 @@ type PositionCheckDTO struct {
 }
 
 type CreateCheckDTO struct {
-	Name      string `validate:"required"`
-	Positions []*PositionCheckDTO
+	Name string `validate:"required"`
+	// WarehouseID is the warehouse counted, zero counts every warehouse
+	WarehouseID uint
+	Positions   []*PositionCheckDTO
 }
 
 type UpdateCheckDTO struct {
@@ -66,6 +70,7 @@ func (d *CreateCheckDTO) ToEntity(createdBy user.User) (*Check, error) {
 		ID:          0,
 		Status:      s,
 		Name:        d.Name,
+		WarehouseID: d.WarehouseID,
 		Results:     results,
 		CreatedAt:   time.Now(),
 		CreatedBy:   createdBy,
@@ -80,3 +85,57 @@ func (d *UpdateCheckDTO) ToEntity(id uint) (*Check, error) {
 	}
 	return check, nil
 }
+
+// ApproveResultDTO values the missing products of a result and tags its surplus products.
+type ApproveResultDTO struct {
+	ResultID uint
+	UnitCost float64 `validate:"gte=0"`
+	// Tags are the RFID tags of the surplus products separated by commas or whitespace
+	Tags string
+}
+
+type ApproveCheckDTO struct {
+	// LocationID is where the surplus products are registered, zero leaves them unplaced
+	LocationID uint
+	Results    []*ApproveResultDTO `validate:"dive"`
+}
+
+// Ok keys the errors of results by their form name, e.g. Results[0].UnitCost.
+func (d *ApproveCheckDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[strings.TrimPrefix(err.Namespace(), "ApproveCheckDTO.")] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+// Result returns the approval input of a check result, results left out of the form are zero.
+func (d *ApproveCheckDTO) Result(resultID uint) *ApproveResultDTO {
+	for _, r := range d.Results {
+		if r != nil && r.ResultID == resultID {
+			return r
+		}
+	}
+	return &ApproveResultDTO{ResultID: resultID}
+}
+
+// TagList splits the surplus tags, dropping duplicates.
+func (d *ApproveResultDTO) TagList() []string {
+	fields := strings.FieldsFunc(d.Tags, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+	tags := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, tag := range fields {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
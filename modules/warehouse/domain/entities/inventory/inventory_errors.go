@@ -1 +1,111 @@
 package inventory
+
+import (
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type ErrCheckLocked struct {
+	serrors.BaseError
+}
+
+func NewErrCheckLocked() *ErrCheckLocked {
+	return &ErrCheckLocked{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_INVENTORY_CHECK_LOCKED",
+			Message: "inventory check is approved and can no longer be changed",
+		},
+	}
+}
+
+func (e *ErrCheckLocked) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+	})
+}
+
+type ErrSurplusTags struct {
+	serrors.BaseError
+	Position string
+	Expected int
+	Actual   int
+}
+
+func NewErrSurplusTags(position string, expected, actual int) *ErrSurplusTags {
+	return &ErrSurplusTags{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_INVENTORY_SURPLUS_TAGS",
+			Message: "every surplus product needs its own new tag",
+		},
+		Position: position,
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+func (e *ErrSurplusTags) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Position": e.Position,
+			"Expected": e.Expected,
+			"Actual":   e.Actual,
+		},
+	})
+}
+
+type ErrStockChanged struct {
+	serrors.BaseError
+	Position string
+}
+
+func NewErrStockChanged(position string) *ErrStockChanged {
+	return &ErrStockChanged{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_INVENTORY_STOCK_CHANGED",
+			Message: "fewer products are in stock than the check expected",
+		},
+		Position: position,
+	}
+}
+
+func (e *ErrStockChanged) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Position": e.Position,
+		},
+	})
+}
+
+type ErrTagTaken struct {
+	serrors.BaseError
+	Rfid string
+}
+
+func NewErrTagTaken(rfid string) *ErrTagTaken {
+	return &ErrTagTaken{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_INVENTORY_TAG_TAKEN",
+			Message: "the tag of a surplus product already belongs to another product",
+		},
+		Rfid: rfid,
+	}
+}
+
+func (e *ErrTagTaken) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Rfid": e.Rfid,
+		},
+	})
+}
@@ -26,9 +26,12 @@ type Repository interface {
 	GetAll(ctx context.Context) ([]*Check, error)
 	GetPaginated(ctx context.Context, params *FindParams) ([]*Check, error)
 	GetByID(ctx context.Context, id uint) (*Check, error)
-	Positions(ctx context.Context) ([]*Position, error)
+	GetForUpdate(ctx context.Context, id uint) (*Check, error)
+	// Positions counts the products in stock by position, in a single warehouse unless warehouseID is zero.
+	Positions(ctx context.Context, warehouseID uint) ([]*Position, error)
 	GetByIDWithDifference(ctx context.Context, id uint) (*Check, error)
 	Create(ctx context.Context, upload *Check) error
 	Update(ctx context.Context, upload *Check) error
+	Approve(ctx context.Context, check *Check) error
 	Delete(ctx context.Context, id uint) error
 }
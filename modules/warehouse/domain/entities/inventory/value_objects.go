@@ -9,10 +9,12 @@ const (
 	Success    Status = "success"
 	Incomplete Status = "incomplete"
 	Failed     Status = "failed"
+	// Approved checks have adjusted the stock and can no longer be changed
+	Approved Status = "approved"
 )
 
 func (s Status) IsValid() bool {
-	return s == Success || s == Incomplete || s == Failed
+	return s == Success || s == Incomplete || s == Failed || s == Approved
 }
 
 func NewStatus(value string) (Status, error) {
@@ -0,0 +1,43 @@
+package location
+
+import (
+	"strings"
+	"time"
+)
+
+type Location struct {
+	ID          uint
+	WarehouseID uint
+	// ParentID is zero for the top level locations of a warehouse
+	ParentID uint
+	Kind     Kind
+	Code     string
+	Name     string
+	// Path joins the warehouse code with the codes of all ancestors, e.g. MAIN/A/R01/S2/B05
+	Path      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Attach places the location under parent (nil for a top level location) and derives its path.
+func (l *Location) Attach(warehouseCode string, parent *Location) error {
+	if parent == nil {
+		l.ParentID = 0
+		l.Path = warehouseCode + "/" + l.Code
+		return nil
+	}
+	if parent.WarehouseID != l.WarehouseID {
+		return ErrParentInAnotherWarehouse
+	}
+	if !parent.Kind.CanContain(l.Kind) {
+		return NewErrKindNotBelowParent(parent.Kind, l.Kind)
+	}
+	l.ParentID = parent.ID
+	l.Path = parent.Path + "/" + l.Code
+	return nil
+}
+
+// Depth is the number of ancestors of the location.
+func (l *Location) Depth() int {
+	return strings.Count(l.Path, "/") - 1
+}
@@ -0,0 +1,47 @@
+package location
+
+import (
+	"strings"
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+type CreateDTO struct {
+	ParentID uint
+	Kind     string `validate:"required"`
+	Code     string `validate:"required"`
+	Name     string
+}
+
+func (d *CreateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[err.Field()] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CreateDTO) ToEntity(warehouseID uint) (*Location, error) {
+	kind, err := NewKind(d.Kind)
+	if err != nil {
+		return nil, err
+	}
+	return &Location{
+		ID:          0,
+		WarehouseID: warehouseID,
+		ParentID:    d.ParentID,
+		Kind:        kind,
+		Code:        strings.ToUpper(strings.TrimSpace(d.Code)),
+		Name:        d.Name,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package location
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+var (
+	ErrInvalidKind              = errors.New("invalid kind")
+	ErrParentInAnotherWarehouse = errors.New("parent location belongs to another warehouse")
+)
+
+type ErrKindNotBelowParent struct {
+	serrors.BaseError
+	Parent Kind
+	Child  Kind
+}
+
+func NewErrKindNotBelowParent(parent, child Kind) *ErrKindNotBelowParent {
+	return &ErrKindNotBelowParent{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_LOCATION_KIND_NOT_BELOW_PARENT",
+			Message: fmt.Sprintf("%s can not be placed in %s", child, parent),
+		},
+		Parent: parent,
+		Child:  child,
+	}
+}
+
+func (e *ErrKindNotBelowParent) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Parent": l.MustLocalize(&i18n.LocalizeConfig{MessageID: "WarehouseLocations.Kinds." + string(e.Parent)}), //nolint:exhaustruct
+			"Child":  l.MustLocalize(&i18n.LocalizeConfig{MessageID: "WarehouseLocations.Kinds." + string(e.Child)}),  //nolint:exhaustruct
+		},
+	})
+}
+
+type ErrDuplicateCode struct {
+	serrors.BaseError
+	LocationCode string
+}
+
+func NewErrDuplicateCode(code string) *ErrDuplicateCode {
+	return &ErrDuplicateCode{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_DUPLICATE_LOCATION_CODE",
+			Message: fmt.Sprintf("location with code %s already exists in the warehouse", code),
+		},
+		LocationCode: code,
+	}
+}
+
+func (e *ErrDuplicateCode) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Code": e.LocationCode,
+		},
+	})
+}
+
+// ErrNotEmpty is returned when a location that still holds nested locations or products is deleted.
+type ErrNotEmpty struct {
+	serrors.BaseError
+}
+
+func NewErrNotEmpty() *ErrNotEmpty {
+	return &ErrNotEmpty{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_LOCATION_NOT_EMPTY",
+			Message: "location still has nested locations or products",
+		},
+	}
+}
+
+func (e *ErrNotEmpty) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+	})
+}
@@ -0,0 +1,21 @@
+package location
+
+import "context"
+
+type FindParams struct {
+	WarehouseID uint
+	Limit       int
+	Offset      int
+}
+
+type Repository interface {
+	// GetPaginated returns locations ordered by path, so parents always precede their children.
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Location, error)
+	GetAll(ctx context.Context) ([]*Location, error)
+	GetByID(ctx context.Context, id uint) (*Location, error)
+	GetByCode(ctx context.Context, warehouseID uint, code string) (*Location, error)
+	// IsEmpty reports whether the location has neither nested locations nor products.
+	IsEmpty(ctx context.Context, id uint) (bool, error)
+	Create(ctx context.Context, data *Location) error
+	Delete(ctx context.Context, id uint) error
+}
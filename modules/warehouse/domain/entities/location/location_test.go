@@ -0,0 +1,48 @@
+package location_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+)
+
+func TestLocation_Attach(t *testing.T) {
+	zone := &location.Location{ID: 1, WarehouseID: 1, Kind: location.Zone, Code: "A"}
+	if err := zone.Attach("MAIN", nil); err != nil {
+		t.Fatalf("expected a top level zone, got %v", err)
+	}
+	if zone.Path != "MAIN/A" || zone.Depth() != 0 {
+		t.Errorf("expected MAIN/A at depth 0, got %s at depth %d", zone.Path, zone.Depth())
+	}
+
+	bin := &location.Location{WarehouseID: 1, Kind: location.Bin, Code: "B05"}
+	if err := bin.Attach("MAIN", zone); err != nil {
+		t.Fatalf("expected a bin in the zone, got %v", err)
+	}
+	if bin.ParentID != zone.ID || bin.Path != "MAIN/A/B05" || bin.Depth() != 1 {
+		t.Errorf("expected MAIN/A/B05 under the zone, got %s under %d", bin.Path, bin.ParentID)
+	}
+
+	rack := &location.Location{WarehouseID: 1, Kind: location.Rack, Code: "R01"}
+	var kindErr *location.ErrKindNotBelowParent
+	if err := rack.Attach("MAIN", bin); !errors.As(err, &kindErr) {
+		t.Errorf("expected a rack not to fit in a bin, got %v", err)
+	}
+	shelf := &location.Location{WarehouseID: 2, Kind: location.Shelf, Code: "S1"}
+	if err := shelf.Attach("SECOND", zone); !errors.Is(err, location.ErrParentInAnotherWarehouse) {
+		t.Errorf("expected the parent to be rejected, got %v", err)
+	}
+}
+
+func TestKind_CanContain(t *testing.T) {
+	if !location.Zone.CanContain(location.Shelf) {
+		t.Error("expected a zone to contain shelves")
+	}
+	if location.Shelf.CanContain(location.Shelf) || location.Bin.CanContain(location.Zone) {
+		t.Error("expected kinds to nest strictly outside-in")
+	}
+	if _, err := location.NewKind("pallet"); !errors.Is(err, location.ErrInvalidKind) {
+		t.Errorf("expected pallet to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package location
+
+const (
+	Zone  Kind = "zone"
+	Rack  Kind = "rack"
+	Shelf Kind = "shelf"
+	Bin   Kind = "bin"
+)
+
+// Kinds lists the location kinds from the outermost to the innermost.
+var Kinds = []Kind{Zone, Rack, Shelf, Bin}
+
+type Kind string
+
+func NewKind(value string) (Kind, error) {
+	kind := Kind(value)
+	if !kind.IsValid() {
+		return "", ErrInvalidKind
+	}
+	return kind, nil
+}
+
+func (k Kind) IsValid() bool {
+	return k.level() > 0
+}
+
+// CanContain reports whether a location of kind child may be nested in a location of kind k.
+func (k Kind) CanContain(child Kind) bool {
+	return child.IsValid() && k.level() < child.level()
+}
+
+func (k Kind) level() int {
+	for i, kind := range Kinds {
+		if kind == k {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,31 @@
+package movement
+
+import (
+	"time"
+)
+
+func New(productID, orderID, fromLocationID, toLocationID, movedByID uint) *Movement {
+	return &Movement{
+		ProductID:      productID,
+		OrderID:        orderID,
+		FromLocationID: fromLocationID,
+		ToLocationID:   toLocationID,
+		MovedByID:      movedByID,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// Movement records a product changing its location. A zero FromLocationID means the product
+// was placed for the first time, a zero ToLocationID means it left the warehouse.
+type Movement struct {
+	ID             uint
+	ProductID      uint
+	OrderID        uint
+	FromLocationID uint
+	// FromPath and ToPath keep the location paths as they were at the time of the movement
+	FromPath     string
+	ToLocationID uint
+	ToPath       string
+	MovedByID    uint
+	CreatedAt    time.Time
+}
@@ -0,0 +1,18 @@
+package movement
+
+import "context"
+
+type FindParams struct {
+	Limit     int
+	Offset    int
+	ProductID uint
+	OrderID   uint
+	// LocationID matches movements into or out of the location
+	LocationID uint
+}
+
+type Repository interface {
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Movement, error)
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	Create(ctx context.Context, data *Movement) error
+}
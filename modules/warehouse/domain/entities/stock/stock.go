@@ -0,0 +1,148 @@
+package stock
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+)
+
+// Movement is an append-only ledger entry changing the quantity of a position at a location.
+// Positive quantities add stock, negative ones take it away. A zero LocationID is stock that is not placed.
+type Movement struct {
+	ID               uint
+	PositionID       uint
+	WarehouseID      uint
+	LocationID       uint
+	Quantity         int
+	Reason           Reason
+	OrderID          uint
+	InventoryCheckID uint
+	Comment          string
+	CreatedByID      uint
+	CreatedAt        time.Time
+}
+
+// Level is the quantity of a position at a location at some point in time.
+type Level struct {
+	PositionID  uint
+	WarehouseID uint
+	LocationID  uint
+	Quantity    int64
+}
+
+// Report is the movement report of a position for a period.
+type Report struct {
+	PositionID uint
+	From       time.Time
+	To         time.Time
+	Opening    int64
+	Movements  []*Movement
+}
+
+// Closing is the quantity at the end of the period.
+func (r *Report) Closing() int64 {
+	closing := r.Opening
+	for _, m := range r.Movements {
+		closing += int64(m.Quantity)
+	}
+	return closing
+}
+
+type ledgerKey struct {
+	positionID  uint
+	warehouseID uint
+	locationID  uint
+	reason      Reason
+}
+
+// ledger sums quantities per position, location and reason keeping the order the keys were first added in.
+type ledger struct {
+	quantities map[ledgerKey]int
+	keys       []ledgerKey
+}
+
+func (l *ledger) add(key ledgerKey, quantity int) {
+	if l.quantities == nil {
+		l.quantities = make(map[ledgerKey]int)
+	}
+	if _, ok := l.quantities[key]; !ok {
+		l.keys = append(l.keys, key)
+	}
+	l.quantities[key] += quantity
+}
+
+func (l *ledger) movements(createdByID uint) []*Movement {
+	now := time.Now()
+	movements := make([]*Movement, 0, len(l.keys))
+	for _, key := range l.keys {
+		if l.quantities[key] == 0 {
+			continue
+		}
+		movements = append(movements, &Movement{
+			PositionID:  key.positionID,
+			WarehouseID: key.warehouseID,
+			LocationID:  key.locationID,
+			Quantity:    l.quantities[key],
+			Reason:      key.reason,
+			CreatedByID: createdByID,
+			CreatedAt:   now,
+		})
+	}
+	return movements
+}
+
+// FromOrder builds the ledger entries of a completed order, one per position and location.
+// previous maps product IDs to the locations the products were taken from and
+// destinationWarehouseID is the warehouse of the order location, which differs from
+// the order warehouse for transfers between warehouses.
+func FromOrder(o order.Order, previous map[uint]uint, destinationWarehouseID, createdByID uint) []*Movement {
+	var l ledger
+	for _, item := range o.Items() {
+		for _, p := range item.Products() {
+			positionID := p.PositionID
+			if positionID == 0 {
+				positionID = item.Position().ID
+			}
+			switch o.Type() {
+			case order.TypeIn:
+				l.add(ledgerKey{positionID, o.WarehouseID(), o.LocationID(), ReasonOrderIn}, 1)
+			case order.TypeOut:
+				l.add(ledgerKey{positionID, o.WarehouseID(), previous[p.ID], ReasonOrderOut}, -1)
+			case order.TypeTransfer:
+				l.add(ledgerKey{positionID, o.WarehouseID(), previous[p.ID], ReasonTransferOut}, -1)
+				l.add(ledgerKey{positionID, destinationWarehouseID, o.LocationID(), ReasonTransferIn}, 1)
+			}
+		}
+	}
+	movements := l.movements(createdByID)
+	for _, m := range movements {
+		m.OrderID = o.ID()
+	}
+	return movements
+}
+
+// FromInventoryCheck builds the adjustment entries of an approved inventory check, one per position
+// and location: written off products are taken away and the registered surplus products added.
+func FromInventoryCheck(checkID uint, writtenOff, registered []*product.Product, createdByID uint) []*Movement {
+	var l ledger
+	placement := func(p *product.Product) (uint, uint) {
+		if p.Location == nil {
+			return 0, p.LocationID
+		}
+		return p.Location.WarehouseID, p.LocationID
+	}
+	for _, p := range writtenOff {
+		warehouseID, locationID := placement(p)
+		l.add(ledgerKey{p.PositionID, warehouseID, locationID, ReasonInventoryAdjustment}, -1)
+	}
+	for _, p := range registered {
+		warehouseID, locationID := placement(p)
+		l.add(ledgerKey{p.PositionID, warehouseID, locationID, ReasonInventoryAdjustment}, 1)
+	}
+	movements := l.movements(createdByID)
+	for _, m := range movements {
+		m.InventoryCheckID = checkID
+	}
+	return movements
+}
@@ -0,0 +1,48 @@
+package stock
+
+import (
+	"strings"
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+// CorrectionDTO manually corrects the quantity of a position, e.g. to enter an opening balance.
+type CorrectionDTO struct {
+	PositionID  uint `validate:"required"`
+	WarehouseID uint `validate:"required"`
+	LocationID  uint
+	Quantity    int    `validate:"required"`
+	Comment     string `validate:"required"`
+}
+
+func (d *CorrectionDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[err.Field()] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *CorrectionDTO) ToEntity(createdByID uint) (*Movement, error) {
+	if d.Quantity == 0 {
+		return nil, ErrZeroQuantity
+	}
+	return &Movement{
+		PositionID:  d.PositionID,
+		WarehouseID: d.WarehouseID,
+		LocationID:  d.LocationID,
+		Quantity:    d.Quantity,
+		Reason:      ReasonCorrection,
+		Comment:     strings.TrimSpace(d.Comment),
+		CreatedByID: createdByID,
+		CreatedAt:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,8 @@
+package stock
+
+import "errors"
+
+var (
+	ErrInvalidReason = errors.New("invalid stock movement reason")
+	ErrZeroQuantity  = errors.New("stock movement quantity must not be zero")
+)
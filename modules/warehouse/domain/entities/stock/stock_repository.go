@@ -0,0 +1,34 @@
+package stock
+
+import (
+	"context"
+	"time"
+)
+
+type FindParams struct {
+	Limit       int
+	Offset      int
+	PositionID  uint
+	WarehouseID uint
+	// LocationID matches the location together with its nested locations
+	LocationID uint
+	Reason     Reason
+	From       time.Time
+	To         time.Time
+}
+
+type LevelParams struct {
+	// At is the moment the levels are computed for, zero means now
+	At          time.Time
+	PositionID  uint
+	WarehouseID uint
+	LocationID  uint
+}
+
+type Repository interface {
+	GetPaginated(context.Context, *FindParams) ([]*Movement, error)
+	Count(context.Context, *FindParams) (int64, error)
+	OnHand(context.Context, *LevelParams) (int64, error)
+	Levels(context.Context, *LevelParams) ([]*Level, error)
+	Create(context.Context, ...*Movement) error
+}
@@ -0,0 +1,96 @@
+package stock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
+)
+
+func TestFromOrder(t *testing.T) {
+	transfer := order.NewWithID(7, order.TypeTransfer, order.Pending, 1, 20, time.Now())
+	pos := &position.Position{ID: 3}
+	for _, p := range []*product.Product{
+		{ID: 1, PositionID: 3, LocationID: 10, Status: product.InStock},
+		{ID: 2, PositionID: 3, LocationID: 10, Status: product.InStock},
+		{ID: 3, PositionID: 3, LocationID: 11, Status: product.InStock},
+	} {
+		if err := transfer.AddItem(pos, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	movements := stock.FromOrder(transfer, map[uint]uint{1: 10, 2: 10, 3: 11}, 2, 5)
+	if len(movements) != 3 {
+		t.Fatalf("expected 3 ledger entries, got %d", len(movements))
+	}
+	expected := []struct {
+		warehouseID, locationID uint
+		quantity                int
+		reason                  stock.Reason
+	}{
+		{1, 10, -2, stock.ReasonTransferOut},
+		{2, 20, 3, stock.ReasonTransferIn},
+		{1, 11, -1, stock.ReasonTransferOut},
+	}
+	for i, e := range expected {
+		m := movements[i]
+		if m.WarehouseID != e.warehouseID || m.LocationID != e.locationID || m.Quantity != e.quantity || m.Reason != e.reason {
+			t.Errorf("entry %d: expected %+v, got %+v", i, e, m)
+		}
+		if m.PositionID != 3 || m.OrderID != 7 || m.CreatedByID != 5 {
+			t.Errorf("entry %d: expected position 3, order 7 and user 5, got %+v", i, m)
+		}
+	}
+}
+
+func TestReport_Closing(t *testing.T) {
+	report := &stock.Report{
+		Opening: 4,
+		Movements: []*stock.Movement{
+			{Quantity: 3, Reason: stock.ReasonOrderIn},
+			{Quantity: -5, Reason: stock.ReasonOrderOut},
+		},
+	}
+	if closing := report.Closing(); closing != 2 {
+		t.Errorf("expected closing quantity 2, got %d", closing)
+	}
+}
+
+func TestFromInventoryCheck(t *testing.T) {
+	shelf := &location.Location{ID: 10, WarehouseID: 1}
+	writtenOff := []*product.Product{
+		{ID: 1, PositionID: 3, LocationID: 10, Location: shelf},
+		{ID: 2, PositionID: 3, LocationID: 10, Location: shelf},
+		{ID: 3, PositionID: 4},
+	}
+	registered := []*product.Product{
+		{PositionID: 5, LocationID: 10, Location: shelf},
+	}
+
+	movements := stock.FromInventoryCheck(9, writtenOff, registered, 2)
+	expected := []struct {
+		positionID, warehouseID, locationID uint
+		quantity                            int
+	}{
+		{3, 1, 10, -2},
+		{4, 0, 0, -1},
+		{5, 1, 10, 1},
+	}
+	if len(movements) != len(expected) {
+		t.Fatalf("expected %d ledger entries, got %d", len(expected), len(movements))
+	}
+	for i, e := range expected {
+		m := movements[i]
+		if m.PositionID != e.positionID || m.WarehouseID != e.warehouseID || m.LocationID != e.locationID || m.Quantity != e.quantity {
+			t.Errorf("entry %d: expected %+v, got %+v", i, e, m)
+		}
+		if m.Reason != stock.ReasonInventoryAdjustment || m.InventoryCheckID != 9 || m.CreatedByID != 2 {
+			t.Errorf("entry %d: expected an adjustment of check 9 by user 2, got %+v", i, m)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package stock
+
+type Reason string
+
+const (
+	ReasonOrderIn             Reason = "order_in"
+	ReasonOrderOut            Reason = "order_out"
+	ReasonTransferOut         Reason = "transfer_out"
+	ReasonTransferIn          Reason = "transfer_in"
+	ReasonInventoryAdjustment Reason = "inventory_adjustment"
+	ReasonCorrection          Reason = "correction"
+)
+
+var Reasons = []Reason{
+	ReasonOrderIn,
+	ReasonOrderOut,
+	ReasonTransferOut,
+	ReasonTransferIn,
+	ReasonInventoryAdjustment,
+	ReasonCorrection,
+}
+
+func NewReason(value string) (Reason, error) {
+	r := Reason(value)
+	if !r.IsValid() {
+		return "", ErrInvalidReason
+	}
+	return r, nil
+}
+
+func (r Reason) IsValid() bool {
+	for _, reason := range Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
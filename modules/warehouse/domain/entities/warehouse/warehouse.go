@@ -0,0 +1,14 @@
+package warehouse
+
+import (
+	"time"
+)
+
+type Warehouse struct {
+	ID        uint
+	Name      string
+	Code      string
+	Address   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
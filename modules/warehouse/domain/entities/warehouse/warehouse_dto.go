@@ -0,0 +1,69 @@
+package warehouse
+
+import (
+	"strings"
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+type CreateDTO struct {
+	Name    string `validate:"required"`
+	Code    string `validate:"required"`
+	Address string
+}
+
+type UpdateDTO struct {
+	Name    string `validate:"required"`
+	Code    string `validate:"required"`
+	Address string
+}
+
+func (d *CreateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errorMessages := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errorMessages, true
+	}
+
+	for _, err := range errs.(validator.ValidationErrors) {
+		errorMessages[err.Field()] = err.Translate(l)
+	}
+	return errorMessages, len(errorMessages) == 0
+}
+
+func (d *UpdateDTO) Ok(l ut.Translator) (map[string]string, bool) {
+	errors := map[string]string{}
+	errs := constants.Validate.Struct(d)
+	if errs == nil {
+		return errors, true
+	}
+	for _, err := range errs.(validator.ValidationErrors) {
+		errors[err.Field()] = err.Translate(l)
+	}
+	return errors, len(errors) == 0
+}
+
+func (d *CreateDTO) ToEntity() (*Warehouse, error) {
+	return &Warehouse{
+		ID:        0,
+		Name:      d.Name,
+		Code:      strings.ToUpper(strings.TrimSpace(d.Code)),
+		Address:   d.Address,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (d *UpdateDTO) ToEntity(id uint) (*Warehouse, error) {
+	return &Warehouse{
+		ID:        id,
+		Name:      d.Name,
+		Code:      strings.ToUpper(strings.TrimSpace(d.Code)),
+		Address:   d.Address,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package warehouse
+
+import (
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type ErrDuplicateCode struct {
+	serrors.BaseError
+	WarehouseCode string
+}
+
+func NewErrDuplicateCode(code string) *ErrDuplicateCode {
+	return &ErrDuplicateCode{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_DUPLICATE_WAREHOUSE_CODE",
+			Message: fmt.Sprintf("warehouse with code %s already exists", code),
+		},
+		WarehouseCode: code,
+	}
+}
+
+func (e *ErrDuplicateCode) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+		TemplateData: map[string]interface{}{
+			"Code": e.WarehouseCode,
+		},
+	})
+}
+
+// ErrNotEmpty is returned when a warehouse that still stores products is deleted.
+type ErrNotEmpty struct {
+	serrors.BaseError
+}
+
+func NewErrNotEmpty() *ErrNotEmpty {
+	return &ErrNotEmpty{
+		BaseError: serrors.BaseError{
+			Code:    "ERR_WAREHOUSE_NOT_EMPTY",
+			Message: "warehouse still has products in its locations",
+		},
+	}
+}
+
+func (e *ErrNotEmpty) Localize(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{ //nolint:exhaustruct
+		DefaultMessage: &i18n.Message{ //nolint:exhaustruct
+			ID: "Errors." + e.Code,
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package warehouse
+
+import "context"
+
+type FindParams struct {
+	Limit  int
+	Offset int
+	SortBy []string
+}
+
+type Repository interface {
+	Count(ctx context.Context) (uint, error)
+	GetAll(ctx context.Context) ([]*Warehouse, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]*Warehouse, error)
+	GetByID(ctx context.Context, id uint) (*Warehouse, error)
+	GetByCode(ctx context.Context, code string) (*Warehouse, error)
+	// CountProducts returns how many products are placed at the locations of the warehouse.
+	CountProducts(ctx context.Context, id uint) (int64, error)
+	Create(ctx context.Context, data *Warehouse) error
+	Update(ctx context.Context, data *Warehouse) error
+	Delete(ctx context.Context, id uint) error
+	// Lock holds the warehouses until the transaction ends, so that the stock of a warehouse is
+	// checked against its sales orders and changed by one transaction at a time.
+	Lock(ctx context.Context, ids ...uint) error
+}
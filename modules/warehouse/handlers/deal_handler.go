@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+// DealOrderHandler opens a pending out order for the deals converted into orders, the
+// warehouse staff adds the products to it when the order is picked.
+type DealOrderHandler struct {
+	orderRepo order.Repository
+}
+
+func RegisterDealOrderHandler(app application.Application, orderRepo order.Repository) *DealOrderHandler {
+	handler := &DealOrderHandler{
+		orderRepo: orderRepo,
+	}
+	eventbus.Subscribe(app.Outbox(), events.DealConvertedTopic, "warehouse.deal_order", handler.onDealConverted)
+	return handler
+}
+
+func (h *DealOrderHandler) onDealConverted(ctx context.Context, payload events.DealConverted) error {
+	if payload.Target != "order" {
+		return nil
+	}
+	entity := order.New(order.TypeOut, order.Pending, 0, 0)
+	if err := h.orderRepo.Create(ctx, entity); err != nil {
+		return err
+	}
+	created := order.NewCreated(entity)
+	created.ClientID = payload.ClientID
+	created.DealID = payload.DealID
+	return eventbus.Enqueue(ctx, events.OrderCreatedTopic, created)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/notification"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+const OrderCompletedNotification = "warehouse.order_completed"
+
+type NotificationHandler struct {
+	notificationService *coreservices.NotificationService
+}
+
+func RegisterNotificationHandler(app application.Application) *NotificationHandler {
+	notificationService := app.Service(coreservices.NotificationService{}).(*coreservices.NotificationService)
+	notificationService.RegisterTypes(notification.Type{
+		Name:       OrderCompletedNotification,
+		Defaults:   []notification.Channel{notification.InApp},
+		Permission: permissions.OrderRead,
+	})
+	handler := &NotificationHandler{
+		notificationService: notificationService,
+	}
+	eventbus.Subscribe(app.Outbox(), events.OrderCompletedTopic, "warehouse.order_notification", handler.onOrderCompleted)
+	return handler
+}
+
+func (h *NotificationHandler) onOrderCompleted(ctx context.Context, payload events.OrderCompleted) error {
+	return h.notificationService.Send(ctx, notification.SendDTO{
+		Type: OrderCompletedNotification,
+		Data: map[string]interface{}{
+			"ID":       payload.OrderID,
+			"Quantity": payload.Quantity,
+		},
+		Link: fmt.Sprintf("/warehouse/orders/%d", payload.OrderID),
+	})
+}
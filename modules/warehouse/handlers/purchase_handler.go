@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/purchase"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+// PurchaseHandler keeps the copies of the suppliers and supplier bills purchase orders are matched against.
+type PurchaseHandler struct {
+	purchaseRepo purchase.Repository
+}
+
+func RegisterPurchaseHandler(app application.Application, purchaseRepo purchase.Repository) *PurchaseHandler {
+	handler := &PurchaseHandler{
+		purchaseRepo: purchaseRepo,
+	}
+	eventbus.Subscribe(app.Outbox(), events.PurchaseSupplierTopic, "warehouse.purchase_supplier", handler.onSupplier)
+	eventbus.Subscribe(app.Outbox(), events.BillStatusChangedTopic, "warehouse.purchase_bill", handler.onBillStatusChanged)
+	return handler
+}
+
+func (h *PurchaseHandler) onSupplier(ctx context.Context, payload events.PurchaseSupplier) error {
+	return h.purchaseRepo.SaveSupplier(ctx, &purchase.Supplier{
+		ID:        payload.CounterpartyID,
+		Name:      payload.Name,
+		TIN:       payload.Tin,
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (h *PurchaseHandler) onBillStatusChanged(ctx context.Context, payload events.BillStatusChanged) error {
+	if payload.CounterpartyID == 0 {
+		// enqueued before bills carried their counterparty
+		return nil
+	}
+	return h.purchaseRepo.SaveBill(ctx, &purchase.Bill{
+		ID:         payload.BillID,
+		SupplierID: payload.CounterpartyID,
+		Number:     payload.Number,
+		Amount:     payload.Amount,
+		Currency:   payload.Currency,
+		Status:     payload.Status,
+		UpdatedAt:  time.Now(),
+	})
+}
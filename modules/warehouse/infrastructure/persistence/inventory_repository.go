@@ -15,6 +15,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/jackc/pgx/v5"
 )
 
 var (
@@ -58,7 +59,8 @@ func (g *GormInventoryRepository) GetPaginated(
 	}
 
 	rows, err := pool.Query(ctx, `
-		SELECT ic.id, status, name, ic.created_at, ic.finished_at, ic.created_by_id, ic.finished_by_id
+		SELECT ic.id, status, name, ic.warehouse_id, ic.created_at, ic.finished_at, ic.created_by_id, ic.finished_by_id,
+		       ic.approved_at, ic.approved_by_id
 		FROM inventory_checks ic
 		WHERE `+strings.Join(where, " AND ")+`
 		ORDER BY id DESC
@@ -72,20 +74,27 @@ func (g *GormInventoryRepository) GetPaginated(
 	checks := make([]*inventory.Check, 0)
 	for rows.Next() {
 		var check models.InventoryCheck
-		var finishedAt sql.NullTime
-		var finishedByID sql.NullInt32
+		var finishedAt, approvedAt sql.NullTime
+		var warehouseID, finishedByID, approvedByID sql.NullInt32
 		if err := rows.Scan(
 			&check.ID,
 			&check.Status,
 			&check.Name,
+			&warehouseID,
 			&check.CreatedAt,
 			&finishedAt,
 			&check.CreatedByID,
 			&finishedByID,
+			&approvedAt,
+			&approvedByID,
 		); err != nil {
 			return nil, err
 		}
 
+		if warehouseID.Valid {
+			check.WarehouseID = mapping.Pointer(uint(warehouseID.Int32))
+		}
+
 		if finishedAt.Valid {
 			check.FinishedAt = &finishedAt.Time
 		}
@@ -93,6 +102,14 @@ func (g *GormInventoryRepository) GetPaginated(
 		if finishedByID.Valid {
 			check.FinishedByID = mapping.Pointer(uint(finishedByID.Int32))
 		}
+
+		if approvedAt.Valid {
+			check.ApprovedAt = &approvedAt.Time
+		}
+
+		if approvedByID.Valid {
+			check.ApprovedByID = mapping.Pointer(uint(approvedByID.Int32))
+		}
 		domainCheck, err := mappers.ToDomainInventoryCheck(&check)
 		if err != nil {
 			return nil, err
@@ -105,6 +122,11 @@ func (g *GormInventoryRepository) GetPaginated(
 				return nil, err
 			}
 		}
+		if domainCheck.ApprovedByID != 0 {
+			if domainCheck.ApprovedBy, err = g.userRepo.GetByID(ctx, domainCheck.ApprovedByID); err != nil {
+				return nil, err
+			}
+		}
 
 		if params.AttachResults {
 			if domainCheck.Results, err = g.getCheckResults(ctx, &findCheckResultsParams{
@@ -123,7 +145,7 @@ func (g *GormInventoryRepository) GetPaginated(
 	return checks, nil
 }
 
-func (g *GormInventoryRepository) Positions(ctx context.Context) ([]*inventory.Position, error) {
+func (g *GormInventoryRepository) Positions(ctx context.Context, warehouseID uint) ([]*inventory.Position, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
 		return nil, err
@@ -132,9 +154,11 @@ func (g *GormInventoryRepository) Positions(ctx context.Context) ([]*inventory.P
 	sql := `
 	SELECT warehouse_positions.id, warehouse_positions.title, COUNT(warehouse_products.id) quantity, array_agg(warehouse_products.rfid) rfid_tags
 	FROM warehouse_positions JOIN warehouse_products ON warehouse_positions.id = warehouse_products.position_id
+	LEFT JOIN warehouse_locations ON warehouse_locations.id = warehouse_products.location_id
+	WHERE warehouse_products.status = 'in_stock' AND ($1 = 0 OR warehouse_locations.warehouse_id = $1)
 	GROUP BY warehouse_positions.id;
 	`
-	rows, err := tx.Query(ctx, sql)
+	rows, err := tx.Query(ctx, sql, warehouseID)
 	if err != nil {
 		return nil, err
 	}
@@ -219,9 +243,9 @@ func (g *GormInventoryRepository) Create(ctx context.Context, data *inventory.Ch
 		return err
 	}
 	if err := tx.QueryRow(ctx, `
-		INSERT INTO inventory_checks (status, name, created_by_id) 
-		VALUES ($1, $2, $3) RETURNING id
-	`, dbRow.Status, dbRow.Name, dbRow.CreatedByID).Scan(&data.ID); err != nil {
+		INSERT INTO inventory_checks (status, name, warehouse_id, created_by_id) 
+		VALUES ($1, $2, $3, $4) RETURNING id
+	`, dbRow.Status, dbRow.Name, dbRow.WarehouseID, dbRow.CreatedByID).Scan(&data.ID); err != nil {
 		return err
 	}
 
@@ -255,6 +279,41 @@ func (g *GormInventoryRepository) Update(ctx context.Context, data *inventory.Ch
 	return nil
 }
 
+func (g *GormInventoryRepository) Approve(ctx context.Context, data *inventory.Check) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbRow, err := mappers.ToDBInventoryCheck(data)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE inventory_checks SET status = $1, approved_at = $2, approved_by_id = $3
+		WHERE id = $4
+	`, dbRow.Status, dbRow.ApprovedAt, dbRow.ApprovedByID, dbRow.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetForUpdate locks the row of a check until the transaction ends and reads it.
+func (g *GormInventoryRepository) GetForUpdate(ctx context.Context, id uint) (*inventory.Check, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var lockedID uint
+	err = tx.QueryRow(ctx, `SELECT id FROM inventory_checks WHERE id = $1 FOR UPDATE`, id).Scan(&lockedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInventoryCheckNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g.GetByID(ctx, id)
+}
+
 func (g *GormInventoryRepository) Delete(ctx context.Context, id uint) error {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
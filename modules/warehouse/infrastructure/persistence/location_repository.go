@@ -0,0 +1,171 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrLocationNotFound = errors.New("location not found")
+)
+
+const (
+	selectLocationsQuery = `
+		SELECT id, warehouse_id, parent_id, kind, code, name, path, created_at, updated_at
+		FROM warehouse_locations wl`
+
+	insertLocationQuery = `
+		INSERT INTO warehouse_locations (warehouse_id, parent_id, kind, code, name, path, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	deleteLocationQuery = `DELETE FROM warehouse_locations WHERE id = $1`
+
+	locationIsEmptyQuery = `
+		SELECT NOT EXISTS (SELECT FROM warehouse_locations WHERE parent_id = $1)
+		   AND NOT EXISTS (SELECT FROM warehouse_products WHERE location_id = $1)`
+)
+
+type GormLocationRepository struct{}
+
+func NewLocationRepository() location.Repository {
+	return &GormLocationRepository{}
+}
+
+func (g *GormLocationRepository) GetPaginated(ctx context.Context, params *location.FindParams) ([]*location.Location, error) {
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if params.WarehouseID != 0 {
+		where, args = append(where, fmt.Sprintf("wl.warehouse_id = $%d", len(args)+1)), append(args, params.WarehouseID)
+	}
+	return g.queryLocations(
+		ctx,
+		repo.Join(
+			selectLocationsQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY wl.path",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (g *GormLocationRepository) GetAll(ctx context.Context) ([]*location.Location, error) {
+	return g.queryLocations(ctx, selectLocationsQuery+" ORDER BY wl.path")
+}
+
+func (g *GormLocationRepository) GetByID(ctx context.Context, id uint) (*location.Location, error) {
+	locations, err := g.queryLocations(ctx, selectLocationsQuery+" WHERE wl.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, ErrLocationNotFound
+	}
+	return locations[0], nil
+}
+
+func (g *GormLocationRepository) GetByCode(ctx context.Context, warehouseID uint, code string) (*location.Location, error) {
+	locations, err := g.queryLocations(
+		ctx,
+		selectLocationsQuery+" WHERE wl.warehouse_id = $1 AND wl.code = $2",
+		warehouseID,
+		code,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, ErrLocationNotFound
+	}
+	return locations[0], nil
+}
+
+func (g *GormLocationRepository) IsEmpty(ctx context.Context, id uint) (bool, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	var empty bool
+	if err := tx.QueryRow(ctx, locationIsEmptyQuery, id).Scan(&empty); err != nil {
+		return false, err
+	}
+	return empty, nil
+}
+
+func (g *GormLocationRepository) Create(ctx context.Context, data *location.Location) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbRow := mappers.ToDBLocation(data)
+	return tx.QueryRow(
+		ctx,
+		insertLocationQuery,
+		dbRow.WarehouseID,
+		dbRow.ParentID,
+		dbRow.Kind,
+		dbRow.Code,
+		dbRow.Name,
+		dbRow.Path,
+		dbRow.CreatedAt,
+	).Scan(&data.ID)
+}
+
+func (g *GormLocationRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, deleteLocationQuery, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g *GormLocationRepository) queryLocations(ctx context.Context, query string, args ...interface{}) ([]*location.Location, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := make([]*location.Location, 0)
+	for rows.Next() {
+		var l models.WarehouseLocation
+		if err := rows.Scan(
+			&l.ID,
+			&l.WarehouseID,
+			&l.ParentID,
+			&l.Kind,
+			&l.Code,
+			&l.Name,
+			&l.Path,
+			&l.CreatedAt,
+			&l.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := mappers.ToDomainLocation(&l)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, entity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
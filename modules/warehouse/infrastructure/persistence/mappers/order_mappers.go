@@ -3,6 +3,7 @@ package mappers
 import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
 )
 
 func ToDBOrder(entity order.Order) (*models.WarehouseOrder, []*models.WarehouseProduct, error) {
@@ -18,10 +19,12 @@ func ToDBOrder(entity order.Order) (*models.WarehouseOrder, []*models.WarehouseP
 	}
 
 	dbOrder := &models.WarehouseOrder{
-		ID:        entity.ID(),
-		Status:    string(entity.Status()),
-		Type:      string(entity.Type()),
-		CreatedAt: entity.CreatedAt(),
+		ID:          entity.ID(),
+		Status:      string(entity.Status()),
+		Type:        string(entity.Type()),
+		WarehouseID: mapping.ValueToSQLNullInt32(int32(entity.WarehouseID())),
+		LocationID:  mapping.ValueToSQLNullInt32(int32(entity.LocationID())),
+		CreatedAt:   entity.CreatedAt(),
 	}
 	return dbOrder, dbProducts, nil
 }
@@ -35,5 +38,12 @@ func ToDomainOrder(dbOrder *models.WarehouseOrder) (order.Order, error) {
 	if err != nil {
 		return nil, err
 	}
-	return order.NewWithID(dbOrder.ID, orderType, status, dbOrder.CreatedAt), nil
+	return order.NewWithID(
+		dbOrder.ID,
+		orderType,
+		status,
+		uint(dbOrder.WarehouseID.Int32),
+		uint(dbOrder.LocationID.Int32),
+		dbOrder.CreatedAt,
+	), nil
 }
@@ -5,8 +5,14 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/purchase"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/inventory"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/unit"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 )
@@ -35,6 +41,7 @@ func ToDBProduct(entity *product.Product) (*models.WarehouseProduct, error) {
 	return &models.WarehouseProduct{
 		ID:         entity.ID,
 		PositionID: entity.PositionID,
+		LocationID: mapping.ValueToSQLNullInt32(int32(entity.LocationID)),
 		Rfid:       mapping.ValueToSQLNullString(entity.Rfid),
 		Status:     string(entity.Status),
 		CreatedAt:  entity.CreatedAt,
@@ -42,10 +49,12 @@ func ToDBProduct(entity *product.Product) (*models.WarehouseProduct, error) {
 	}, nil
 }
 
+// ToDomainProduct maps a product row, dbLocation is nil for products that are not placed anywhere.
 func ToDomainProduct(
 	dbProduct *models.WarehouseProduct,
 	dbPosition *models.WarehousePosition,
 	dbUnit *models.WarehouseUnit,
+	dbLocation *models.WarehouseLocation,
 ) (*product.Product, error) {
 	status, err := product.NewStatus(dbProduct.Status)
 	if err != nil {
@@ -55,14 +64,140 @@ func ToDomainProduct(
 	if err != nil {
 		return nil, err
 	}
-	return &product.Product{
+	entity := &product.Product{
 		ID:         dbProduct.ID,
 		PositionID: dbProduct.PositionID,
+		LocationID: uint(dbProduct.LocationID.Int32),
 		Rfid:       dbProduct.Rfid.String,
 		Position:   pos,
 		Status:     status,
 		CreatedAt:  dbProduct.CreatedAt,
 		UpdatedAt:  dbProduct.UpdatedAt,
+	}
+	if dbLocation != nil {
+		entity.Location, err = ToDomainLocation(dbLocation)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entity, nil
+}
+
+func ToDBWarehouse(entity *warehouse.Warehouse) *models.Warehouse {
+	return &models.Warehouse{
+		ID:        entity.ID,
+		Name:      entity.Name,
+		Code:      entity.Code,
+		Address:   mapping.ValueToSQLNullString(entity.Address),
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+func ToDomainWarehouse(dbWarehouse *models.Warehouse) *warehouse.Warehouse {
+	return &warehouse.Warehouse{
+		ID:        dbWarehouse.ID,
+		Name:      dbWarehouse.Name,
+		Code:      dbWarehouse.Code,
+		Address:   dbWarehouse.Address.String,
+		CreatedAt: dbWarehouse.CreatedAt,
+		UpdatedAt: dbWarehouse.UpdatedAt,
+	}
+}
+
+func ToDBLocation(entity *location.Location) *models.WarehouseLocation {
+	return &models.WarehouseLocation{
+		ID:          entity.ID,
+		WarehouseID: entity.WarehouseID,
+		ParentID:    mapping.ValueToSQLNullInt32(int32(entity.ParentID)),
+		Kind:        string(entity.Kind),
+		Code:        entity.Code,
+		Name:        mapping.ValueToSQLNullString(entity.Name),
+		Path:        entity.Path,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+func ToDomainLocation(dbLocation *models.WarehouseLocation) (*location.Location, error) {
+	kind, err := location.NewKind(dbLocation.Kind)
+	if err != nil {
+		return nil, err
+	}
+	return &location.Location{
+		ID:          dbLocation.ID,
+		WarehouseID: dbLocation.WarehouseID,
+		ParentID:    uint(dbLocation.ParentID.Int32),
+		Kind:        kind,
+		Code:        dbLocation.Code,
+		Name:        dbLocation.Name.String,
+		Path:        dbLocation.Path,
+		CreatedAt:   dbLocation.CreatedAt,
+		UpdatedAt:   dbLocation.UpdatedAt,
+	}, nil
+}
+
+func ToDBMovement(entity *movement.Movement) *models.WarehouseProductMovement {
+	return &models.WarehouseProductMovement{
+		ID:             entity.ID,
+		ProductID:      entity.ProductID,
+		OrderID:        mapping.ValueToSQLNullInt32(int32(entity.OrderID)),
+		FromLocationID: mapping.ValueToSQLNullInt32(int32(entity.FromLocationID)),
+		FromPath:       mapping.ValueToSQLNullString(entity.FromPath),
+		ToLocationID:   mapping.ValueToSQLNullInt32(int32(entity.ToLocationID)),
+		ToPath:         mapping.ValueToSQLNullString(entity.ToPath),
+		MovedByID:      mapping.ValueToSQLNullInt32(int32(entity.MovedByID)),
+		CreatedAt:      entity.CreatedAt,
+	}
+}
+
+func ToDomainMovement(dbMovement *models.WarehouseProductMovement) *movement.Movement {
+	return &movement.Movement{
+		ID:             dbMovement.ID,
+		ProductID:      dbMovement.ProductID,
+		OrderID:        uint(dbMovement.OrderID.Int32),
+		FromLocationID: uint(dbMovement.FromLocationID.Int32),
+		FromPath:       dbMovement.FromPath.String,
+		ToLocationID:   uint(dbMovement.ToLocationID.Int32),
+		ToPath:         dbMovement.ToPath.String,
+		MovedByID:      uint(dbMovement.MovedByID.Int32),
+		CreatedAt:      dbMovement.CreatedAt,
+	}
+}
+
+func ToDBStockMovement(entity *stock.Movement) *models.WarehouseStockMovement {
+	return &models.WarehouseStockMovement{
+		ID:               entity.ID,
+		PositionID:       entity.PositionID,
+		WarehouseID:      mapping.ValueToSQLNullInt32(int32(entity.WarehouseID)),
+		LocationID:       mapping.ValueToSQLNullInt32(int32(entity.LocationID)),
+		Quantity:         entity.Quantity,
+		Reason:           string(entity.Reason),
+		OrderID:          mapping.ValueToSQLNullInt32(int32(entity.OrderID)),
+		InventoryCheckID: mapping.ValueToSQLNullInt32(int32(entity.InventoryCheckID)),
+		Comment:          mapping.ValueToSQLNullString(entity.Comment),
+		CreatedByID:      mapping.ValueToSQLNullInt32(int32(entity.CreatedByID)),
+		CreatedAt:        entity.CreatedAt,
+	}
+}
+
+func ToDomainStockMovement(dbMovement *models.WarehouseStockMovement) (*stock.Movement, error) {
+	reason, err := stock.NewReason(dbMovement.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &stock.Movement{
+		ID:               dbMovement.ID,
+		PositionID:       dbMovement.PositionID,
+		WarehouseID:      uint(dbMovement.WarehouseID.Int32),
+		LocationID:       uint(dbMovement.LocationID.Int32),
+		Quantity:         dbMovement.Quantity,
+		Reason:           reason,
+		OrderID:          uint(dbMovement.OrderID.Int32),
+		InventoryCheckID: uint(dbMovement.InventoryCheckID.Int32),
+		Comment:          dbMovement.Comment.String,
+		CreatedByID:      uint(dbMovement.CreatedByID.Int32),
+		CreatedAt:        dbMovement.CreatedAt,
 	}, nil
 }
 
@@ -127,11 +262,14 @@ func ToDomainInventoryCheck(dbInventoryCheck *models.InventoryCheck) (*inventory
 		ID:           dbInventoryCheck.ID,
 		Status:       status,
 		Name:         dbInventoryCheck.Name,
+		WarehouseID:  mapping.Value(dbInventoryCheck.WarehouseID),
 		Results:      results,
 		CreatedAt:    dbInventoryCheck.CreatedAt,
 		FinishedAt:   mapping.Value(dbInventoryCheck.FinishedAt),
 		FinishedByID: mapping.Value(dbInventoryCheck.FinishedByID),
 		CreatedByID:  dbInventoryCheck.CreatedByID,
+		ApprovedAt:   mapping.Value(dbInventoryCheck.ApprovedAt),
+		ApprovedByID: mapping.Value(dbInventoryCheck.ApprovedByID),
 	}
 	if dbInventoryCheck.CreatedBy != nil {
 		check.CreatedBy, err = persistence.ToDomainUser(dbInventoryCheck.CreatedBy, nil, nil)
@@ -184,10 +322,207 @@ func ToDBInventoryCheck(check *inventory.Check) (*models.InventoryCheck, error)
 		ID:           check.ID,
 		Status:       string(check.Status),
 		Name:         check.Name,
+		WarehouseID:  mapping.Pointer(check.WarehouseID),
 		Results:      results,
 		CreatedAt:    check.CreatedAt,
 		FinishedAt:   mapping.Pointer(check.FinishedAt),
 		FinishedByID: mapping.Pointer(check.FinishedByID),
 		CreatedByID:  check.CreatedByID,
+		ApprovedAt:   mapping.Pointer(check.ApprovedAt),
+		ApprovedByID: mapping.Pointer(check.ApprovedByID),
 	}, nil
 }
+
+func ToDBSupplier(entity *purchase.Supplier) *models.WarehouseSupplier {
+	return &models.WarehouseSupplier{
+		ID:        entity.ID,
+		Name:      entity.Name,
+		Tin:       mapping.ValueToSQLNullString(entity.TIN),
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+func ToDomainSupplier(dbSupplier *models.WarehouseSupplier) *purchase.Supplier {
+	return &purchase.Supplier{
+		ID:        dbSupplier.ID,
+		Name:      dbSupplier.Name,
+		TIN:       dbSupplier.Tin.String,
+		UpdatedAt: dbSupplier.UpdatedAt,
+	}
+}
+
+func ToDBSupplierBill(entity *purchase.Bill) *models.WarehouseSupplierBill {
+	return &models.WarehouseSupplierBill{
+		ID:         entity.ID,
+		SupplierID: entity.SupplierID,
+		Number:     entity.Number,
+		Amount:     entity.Amount,
+		Currency:   entity.Currency,
+		Status:     entity.Status,
+		UpdatedAt:  entity.UpdatedAt,
+	}
+}
+
+func ToDomainSupplierBill(dbBill *models.WarehouseSupplierBill) *purchase.Bill {
+	return &purchase.Bill{
+		ID:         dbBill.ID,
+		SupplierID: dbBill.SupplierID,
+		Number:     dbBill.Number,
+		Amount:     dbBill.Amount,
+		Currency:   dbBill.Currency,
+		Status:     dbBill.Status,
+		UpdatedAt:  dbBill.UpdatedAt,
+	}
+}
+
+func ToDBPurchaseOrder(entity *purchase.Order) (*models.WarehousePurchaseOrder, []*models.WarehousePurchaseOrderLine) {
+	lines := make([]*models.WarehousePurchaseOrderLine, 0, len(entity.Lines))
+	for _, line := range entity.Lines {
+		lines = append(lines, &models.WarehousePurchaseOrderLine{
+			ID:              line.ID,
+			PurchaseOrderID: entity.ID,
+			PositionID:      line.PositionID,
+			Quantity:        line.Quantity,
+			UnitPrice:       line.UnitPrice,
+			Received:        line.Received,
+		})
+	}
+	return &models.WarehousePurchaseOrder{
+		ID:          entity.ID,
+		SupplierID:  entity.SupplierID,
+		WarehouseID: entity.WarehouseID,
+		Currency:    entity.Currency,
+		Status:      string(entity.Status),
+		Comment:     mapping.ValueToSQLNullString(entity.Comment),
+		BillID:      mapping.ValueToSQLNullInt32(int32(entity.BillID)),
+		CreatedByID: mapping.ValueToSQLNullInt32(int32(entity.CreatedByID)),
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}, lines
+}
+
+func ToDomainPurchaseOrder(dbOrder *models.WarehousePurchaseOrder) (*purchase.Order, error) {
+	status, err := purchase.NewStatus(dbOrder.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &purchase.Order{
+		ID:          dbOrder.ID,
+		SupplierID:  dbOrder.SupplierID,
+		WarehouseID: dbOrder.WarehouseID,
+		Currency:    dbOrder.Currency,
+		Status:      status,
+		Comment:     dbOrder.Comment.String,
+		BillID:      uint(dbOrder.BillID.Int32),
+		CreatedByID: uint(dbOrder.CreatedByID.Int32),
+		CreatedAt:   dbOrder.CreatedAt,
+		UpdatedAt:   dbOrder.UpdatedAt,
+	}, nil
+}
+
+func ToDomainPurchaseOrderLine(dbLine *models.WarehousePurchaseOrderLine) *purchase.Line {
+	return &purchase.Line{
+		ID:         dbLine.ID,
+		PositionID: dbLine.PositionID,
+		Quantity:   dbLine.Quantity,
+		UnitPrice:  dbLine.UnitPrice,
+		Received:   dbLine.Received,
+	}
+}
+
+func ToDBPurchaseReceipt(entity *purchase.Receipt) *models.WarehousePurchaseReceipt {
+	return &models.WarehousePurchaseReceipt{
+		ID:              entity.ID,
+		PurchaseOrderID: entity.PurchaseOrderID,
+		OrderID:         mapping.ValueToSQLNullInt32(int32(entity.OrderID)),
+		LocationID:      mapping.ValueToSQLNullInt32(int32(entity.LocationID)),
+		CreatedByID:     mapping.ValueToSQLNullInt32(int32(entity.CreatedByID)),
+		CreatedAt:       entity.CreatedAt,
+	}
+}
+
+func ToDomainPurchaseReceipt(dbReceipt *models.WarehousePurchaseReceipt) *purchase.Receipt {
+	return &purchase.Receipt{
+		ID:              dbReceipt.ID,
+		PurchaseOrderID: dbReceipt.PurchaseOrderID,
+		OrderID:         uint(dbReceipt.OrderID.Int32),
+		LocationID:      uint(dbReceipt.LocationID.Int32),
+		CreatedByID:     uint(dbReceipt.CreatedByID.Int32),
+		CreatedAt:       dbReceipt.CreatedAt,
+	}
+}
+
+func ToDBSalesOrder(entity *sales.Order) (*models.WarehouseSalesOrder, []*models.WarehouseSalesOrderLine) {
+	lines := make([]*models.WarehouseSalesOrderLine, 0, len(entity.Lines))
+	for _, line := range entity.Lines {
+		lines = append(lines, &models.WarehouseSalesOrderLine{
+			ID:           line.ID,
+			SalesOrderID: entity.ID,
+			PositionID:   line.PositionID,
+			Quantity:     line.Quantity,
+		})
+	}
+	return &models.WarehouseSalesOrder{
+		ID:          entity.ID,
+		WarehouseID: entity.WarehouseID,
+		Customer:    entity.Customer,
+		Status:      string(entity.Status),
+		Comment:     mapping.ValueToSQLNullString(entity.Comment),
+		OrderID:     mapping.ValueToSQLNullInt32(int32(entity.OrderID)),
+		CreatedByID: mapping.ValueToSQLNullInt32(int32(entity.CreatedByID)),
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}, lines
+}
+
+func ToDomainSalesOrder(dbOrder *models.WarehouseSalesOrder) (*sales.Order, error) {
+	status, err := sales.NewStatus(dbOrder.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &sales.Order{
+		ID:          dbOrder.ID,
+		WarehouseID: dbOrder.WarehouseID,
+		Customer:    dbOrder.Customer,
+		Status:      status,
+		Comment:     dbOrder.Comment.String,
+		OrderID:     uint(dbOrder.OrderID.Int32),
+		CreatedByID: uint(dbOrder.CreatedByID.Int32),
+		CreatedAt:   dbOrder.CreatedAt,
+		UpdatedAt:   dbOrder.UpdatedAt,
+	}, nil
+}
+
+func ToDomainSalesOrderLine(dbLine *models.WarehouseSalesOrderLine) *sales.Line {
+	return &sales.Line{
+		ID:         dbLine.ID,
+		PositionID: dbLine.PositionID,
+		Quantity:   dbLine.Quantity,
+	}
+}
+
+func ToDBSalesOrderPick(entity *sales.Pick) *models.WarehouseSalesOrderPick {
+	return &models.WarehouseSalesOrderPick{
+		ID:           entity.ID,
+		SalesOrderID: entity.SalesOrderID,
+		LineID:       entity.LineID,
+		ProductID:    entity.ProductID,
+		Rfid:         entity.Rfid,
+		LocationID:   mapping.ValueToSQLNullInt32(int32(entity.LocationID)),
+		PickedByID:   mapping.ValueToSQLNullInt32(int32(entity.PickedByID)),
+		PickedAt:     entity.PickedAt,
+	}
+}
+
+func ToDomainSalesOrderPick(dbPick *models.WarehouseSalesOrderPick) *sales.Pick {
+	return &sales.Pick{
+		ID:           dbPick.ID,
+		SalesOrderID: dbPick.SalesOrderID,
+		LineID:       dbPick.LineID,
+		ProductID:    dbPick.ProductID,
+		Rfid:         dbPick.Rfid,
+		LocationID:   uint(dbPick.LocationID.Int32),
+		PickedByID:   uint(dbPick.PickedByID.Int32),
+		PickedAt:     dbPick.PickedAt,
+	}
+}
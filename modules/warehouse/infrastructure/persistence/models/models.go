@@ -19,6 +19,7 @@ type InventoryCheck struct {
 	ID           uint
 	Status       string
 	Name         string
+	WarehouseID  *uint
 	Results      []*InventoryCheckResult `gorm:"foreignKey:InventoryCheckID"`
 	CreatedAt    time.Time
 	FinishedAt   *time.Time
@@ -26,6 +27,8 @@ type InventoryCheck struct {
 	CreatedBy    *coremodels.User `gorm:"foreignKey:CreatedByID"`
 	FinishedByID *uint
 	FinishedBy   *coremodels.User `gorm:"foreignKey:FinishedByID"`
+	ApprovedAt   *time.Time
+	ApprovedByID *uint
 }
 
 type InventoryPosition struct {
@@ -47,10 +50,12 @@ type InventoryCheckResult struct {
 }
 
 type WarehouseOrder struct {
-	ID        uint
-	Type      string
-	Status    string
-	CreatedAt time.Time
+	ID          uint
+	Type        string
+	Status      string
+	WarehouseID sql.NullInt32
+	LocationID  sql.NullInt32
+	CreatedAt   time.Time
 }
 
 type WarehouseOrderItem struct {
@@ -71,6 +76,7 @@ type WarehousePosition struct {
 type WarehouseProduct struct {
 	ID         uint
 	PositionID uint
+	LocationID sql.NullInt32
 	Rfid       sql.NullString
 	Status     string
 	CreatedAt  time.Time
@@ -81,3 +87,128 @@ type WarehousePositionImage struct {
 	UploadID            uint
 	WarehousePositionID uint
 }
+
+type Warehouse struct {
+	ID        uint
+	Name      string
+	Code      string
+	Address   sql.NullString
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type WarehouseLocation struct {
+	ID          uint
+	WarehouseID uint
+	ParentID    sql.NullInt32
+	Kind        string
+	Code        string
+	Name        sql.NullString
+	Path        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type WarehouseProductMovement struct {
+	ID             uint
+	ProductID      uint
+	OrderID        sql.NullInt32
+	FromLocationID sql.NullInt32
+	FromPath       sql.NullString
+	ToLocationID   sql.NullInt32
+	ToPath         sql.NullString
+	MovedByID      sql.NullInt32
+	CreatedAt      time.Time
+}
+
+type WarehouseStockMovement struct {
+	ID               uint
+	PositionID       uint
+	WarehouseID      sql.NullInt32
+	LocationID       sql.NullInt32
+	Quantity         int
+	Reason           string
+	OrderID          sql.NullInt32
+	InventoryCheckID sql.NullInt32
+	Comment          sql.NullString
+	CreatedByID      sql.NullInt32
+	CreatedAt        time.Time
+}
+
+type WarehouseSupplier struct {
+	ID        uint
+	Name      string
+	Tin       sql.NullString
+	UpdatedAt time.Time
+}
+
+type WarehouseSupplierBill struct {
+	ID         uint
+	SupplierID uint
+	Number     string
+	Amount     float64
+	Currency   string
+	Status     string
+	UpdatedAt  time.Time
+}
+
+type WarehousePurchaseOrder struct {
+	ID          uint
+	SupplierID  uint
+	WarehouseID uint
+	Currency    string
+	Status      string
+	Comment     sql.NullString
+	BillID      sql.NullInt32
+	CreatedByID sql.NullInt32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type WarehousePurchaseOrderLine struct {
+	ID              uint
+	PurchaseOrderID uint
+	PositionID      uint
+	Quantity        int
+	UnitPrice       float64
+	Received        int
+}
+
+type WarehousePurchaseReceipt struct {
+	ID              uint
+	PurchaseOrderID uint
+	OrderID         sql.NullInt32
+	LocationID      sql.NullInt32
+	CreatedByID     sql.NullInt32
+	CreatedAt       time.Time
+}
+
+type WarehouseSalesOrder struct {
+	ID          uint
+	WarehouseID uint
+	Customer    string
+	Status      string
+	Comment     sql.NullString
+	OrderID     sql.NullInt32
+	CreatedByID sql.NullInt32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type WarehouseSalesOrderLine struct {
+	ID           uint
+	SalesOrderID uint
+	PositionID   uint
+	Quantity     int
+}
+
+type WarehouseSalesOrderPick struct {
+	ID           uint
+	SalesOrderID uint
+	LineID       uint
+	ProductID    uint
+	Rfid         string
+	LocationID   sql.NullInt32
+	PickedByID   sql.NullInt32
+	PickedAt     time.Time
+}
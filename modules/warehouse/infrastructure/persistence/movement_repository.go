@@ -0,0 +1,142 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const (
+	selectMovementsQuery = `
+		SELECT id, product_id, order_id, from_location_id, from_path, to_location_id, to_path, moved_by_id, created_at
+		FROM warehouse_product_movements wm`
+
+	countMovementsQuery = `SELECT COUNT(*) FROM warehouse_product_movements wm`
+
+	// insertMovementQuery snapshots the location paths so the history survives renamed or deleted locations
+	insertMovementQuery = `
+		INSERT INTO warehouse_product_movements (
+			product_id, order_id, from_location_id, from_path, to_location_id, to_path, moved_by_id, created_at
+		)
+		VALUES (
+			$1, $2,
+			$3, (SELECT path FROM warehouse_locations WHERE id = $3),
+			$4, (SELECT path FROM warehouse_locations WHERE id = $4),
+			$5, $6
+		)
+		RETURNING id, from_path, to_path`
+)
+
+type GormMovementRepository struct{}
+
+func NewMovementRepository() movement.Repository {
+	return &GormMovementRepository{}
+}
+
+func (g *GormMovementRepository) GetPaginated(ctx context.Context, params *movement.FindParams) ([]*movement.Movement, error) {
+	where, args := g.filters(params)
+	return g.queryMovements(
+		ctx,
+		repo.Join(
+			selectMovementsQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY wm.created_at DESC, wm.id DESC",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (g *GormMovementRepository) Count(ctx context.Context, params *movement.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := g.filters(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countMovementsQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormMovementRepository) Create(ctx context.Context, data *movement.Movement) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbRow := mappers.ToDBMovement(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertMovementQuery,
+		dbRow.ProductID,
+		dbRow.OrderID,
+		dbRow.FromLocationID,
+		dbRow.ToLocationID,
+		dbRow.MovedByID,
+		dbRow.CreatedAt,
+	).Scan(&dbRow.ID, &dbRow.FromPath, &dbRow.ToPath); err != nil {
+		return err
+	}
+	data.ID = dbRow.ID
+	data.FromPath = dbRow.FromPath.String
+	data.ToPath = dbRow.ToPath.String
+	return nil
+}
+
+func (g *GormMovementRepository) filters(params *movement.FindParams) ([]string, []interface{}) {
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if params.ProductID != 0 {
+		where, args = append(where, fmt.Sprintf("wm.product_id = $%d", len(args)+1)), append(args, params.ProductID)
+	}
+	if params.OrderID != 0 {
+		where, args = append(where, fmt.Sprintf("wm.order_id = $%d", len(args)+1)), append(args, params.OrderID)
+	}
+	if params.LocationID != 0 {
+		where = append(where, fmt.Sprintf("(wm.from_location_id = $%d OR wm.to_location_id = $%d)", len(args)+1, len(args)+1))
+		args = append(args, params.LocationID)
+	}
+	return where, args
+}
+
+func (g *GormMovementRepository) queryMovements(ctx context.Context, query string, args ...interface{}) ([]*movement.Movement, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := make([]*movement.Movement, 0)
+	for rows.Next() {
+		var m models.WarehouseProductMovement
+		if err := rows.Scan(
+			&m.ID,
+			&m.ProductID,
+			&m.OrderID,
+			&m.FromLocationID,
+			&m.FromPath,
+			&m.ToLocationID,
+			&m.ToPath,
+			&m.MovedByID,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		movements = append(movements, mappers.ToDomainMovement(&m))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
@@ -18,7 +18,7 @@ var (
 
 const (
 	orderFindQuery = `
-		SELECT id, type, status, created_at 
+		SELECT id, type, status, warehouse_id, location_id, created_at 
 		FROM warehouse_orders wo`
 
 	orderCountQuery = `
@@ -26,8 +26,8 @@ const (
 		FROM warehouse_orders`
 
 	orderInsertQuery = `
-		INSERT INTO warehouse_orders (type, status, created_at) 
-		VALUES ($1, $2, $3) 
+		INSERT INTO warehouse_orders (type, status, warehouse_id, location_id, created_at) 
+		VALUES ($1, $2, $3, $4, $5) 
 		RETURNING id`
 
 	orderItemInsertQuery = `
@@ -39,8 +39,10 @@ const (
 		UPDATE warehouse_orders wo 
 		SET 
 		type = COALESCE(NULLIF($1, ''), wo.type),
-		status = COALESCE(NULLIF($2, ''), wo.status)
-		WHERE wo.id = $3`
+		status = COALESCE(NULLIF($2, ''), wo.status),
+		warehouse_id = COALESCE($3, wo.warehouse_id),
+		location_id = COALESCE($4, wo.location_id)
+		WHERE wo.id = $5`
 
 	orderItemsDeleteQuery = `
 		DELETE FROM warehouse_order_items 
@@ -50,38 +52,15 @@ const (
 		DELETE FROM warehouse_orders 
 		WHERE id = $1`
 
-	selectOrderProductsQuery = `
-		SELECT 
-			wp.id, 
-			wp.position_id,
-			wp.rfid,
-			wp.status,
-			wp.created_at, 
-			wp.updated_at,
-			p.id,
-			p.title,
-			p.barcode,
-			p.unit_id,
-			p.created_at,
-			p.updated_at,
-			wu.id,
-			wu.title,
-			wu.short_title,
-			wu.created_at,
-			wu.updated_at
-		FROM warehouse_products wp
-		LEFT JOIN warehouse_positions p ON p.id = wp.position_id
-		LEFT JOIN warehouse_units wu ON wu.id = p.unit_id`
-
 	insertOrderProductsQuery = `
-		INSERT INTO warehouse_products (position_id, rfid, status, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO warehouse_products (position_id, location_id, rfid, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id`
 
 	updateOrderProductsQuery = `
 		UPDATE warehouse_products 
-		SET position_id = $1, rfid = $2, status = $3
-		WHERE id = $4`
+		SET position_id = $1, location_id = $2, rfid = $3, status = $4
+		WHERE id = $5`
 )
 
 type GormOrderRepository struct {
@@ -108,6 +87,9 @@ func (g *GormOrderRepository) GetPaginated(ctx context.Context, params *order.Fi
 	if params.Type != "" {
 		where, args = append(where, fmt.Sprintf("wo.type = $%d", len(args)+1)), append(args, params.Type)
 	}
+	if params.WarehouseID != 0 {
+		where, args = append(where, fmt.Sprintf("wo.warehouse_id = $%d", len(args)+1)), append(args, params.WarehouseID)
+	}
 
 	q := repo.Join(
 		orderFindQuery,
@@ -160,16 +142,24 @@ func (g *GormOrderRepository) Create(ctx context.Context, data order.Order) erro
 		orderInsertQuery,
 		dbOrder.Type,
 		dbOrder.Status,
+		dbOrder.WarehouseID,
+		dbOrder.LocationID,
 		dbOrder.CreatedAt,
 	).Scan(&dbOrder.ID); err != nil {
 		return err
 	}
+	data.SetID(dbOrder.ID)
 
 	for _, p := range dbProducts {
+		// products already in the warehouse are only linked to the order
+		if p.ID != 0 {
+			continue
+		}
 		if err := tx.QueryRow(
 			ctx,
 			insertOrderProductsQuery,
 			p.PositionID,
+			p.LocationID,
 			p.Rfid,
 			p.Status,
 			p.CreatedAt,
@@ -207,6 +197,8 @@ func (g *GormOrderRepository) Update(ctx context.Context, data order.Order) erro
 		orderUpdateQuery,
 		dbOrder.Type,
 		dbOrder.Status,
+		dbOrder.WarehouseID,
+		dbOrder.LocationID,
 		dbOrder.ID,
 	); err != nil {
 		return err
@@ -232,6 +224,7 @@ func (g *GormOrderRepository) Update(ctx context.Context, data order.Order) erro
 			ctx,
 			updateOrderProductsQuery,
 			product.PositionID,
+			product.LocationID,
 			product.Rfid,
 			product.Status,
 			product.ID,
@@ -254,61 +247,6 @@ func (g *GormOrderRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (g *GormOrderRepository) queryProducts(ctx context.Context, query string, args ...interface{}) ([]*product.Product, error) {
-	tx, err := composables.UseTx(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	rows, err := tx.Query(ctx, query, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var products []*product.Product
-
-	for rows.Next() {
-		var wp models.WarehouseProduct
-		var pos models.WarehousePosition
-		var wu models.WarehouseUnit
-
-		if err := rows.Scan(
-			&wp.ID,
-			&wp.PositionID,
-			&wp.Rfid,
-			&wp.Status,
-			&wp.CreatedAt,
-			&wp.UpdatedAt,
-			&pos.ID,
-			&pos.Title,
-			&pos.Barcode,
-			&pos.UnitID,
-			&pos.CreatedAt,
-			&pos.UpdatedAt,
-			&wu.ID,
-			&wu.Title,
-			&wu.ShortTitle,
-			&wu.CreatedAt,
-			&wu.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-
-		entity, err := mappers.ToDomainProduct(&wp, &pos, &wu)
-		if err != nil {
-			return nil, err
-		}
-		products = append(products, entity)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return products, nil
-}
-
 func (g *GormOrderRepository) queryOrders(ctx context.Context, query string, args ...interface{}) ([]order.Order, error) {
 	pool, err := composables.UseTx(ctx)
 	if err != nil {
@@ -328,6 +266,8 @@ func (g *GormOrderRepository) queryOrders(ctx context.Context, query string, arg
 			&o.ID,
 			&o.Type,
 			&o.Status,
+			&o.WarehouseID,
+			&o.LocationID,
 			&o.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -341,13 +281,9 @@ func (g *GormOrderRepository) queryOrders(ctx context.Context, query string, arg
 	}
 
 	for _, domainOrder := range orders {
-		domainProducts, err := g.queryProducts(ctx,
-			repo.Join(
-				selectOrderProductsQuery,
-				"WHERE wp.id IN (SELECT warehouse_product_id FROM warehouse_order_items WHERE warehouse_order_id = $1)",
-			),
-			domainOrder.ID(),
-		)
+		domainProducts, err := g.productRepo.GetPaginated(ctx, &product.FindParams{
+			OrderID: domainOrder.ID(),
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -42,7 +42,7 @@ func TestGormOrderRepository_CRUD(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	orderEntity := order.New(order.TypeIn, order.Pending)
+	orderEntity := order.New(order.TypeIn, order.Pending, 0, 0)
 	if err := orderEntity.AddItem(
 		positionEntity,
 		product.New("EPS:242323", 1, product.Approved, positionEntity),
@@ -21,6 +21,7 @@ const (
 		SELECT 
 			wp.id, 
 			wp.position_id,
+			wp.location_id,
 			wp.rfid,
 			wp.status,
 			wp.created_at, 
@@ -35,23 +36,30 @@ const (
 			wu.title,
 			wu.short_title,
 			wu.created_at,
-			wu.updated_at
+			wu.updated_at,
+			COALESCE(wl.warehouse_id, 0),
+			wl.parent_id,
+			COALESCE(wl.kind, ''),
+			COALESCE(wl.code, ''),
+			wl.name,
+			COALESCE(wl.path, '')
 		FROM warehouse_products wp
 		LEFT JOIN warehouse_positions p ON p.id = wp.position_id
-		LEFT JOIN warehouse_units wu ON wu.id = p.unit_id`
+		LEFT JOIN warehouse_units wu ON wu.id = p.unit_id
+		LEFT JOIN warehouse_locations wl ON wl.id = wp.location_id`
 
 	productCountQuery = `
 		SELECT COUNT(DISTINCT wp.id) FROM warehouse_products wp`
 
 	productInsertQuery = `
-		INSERT INTO warehouse_products (position_id, rfid, status, created_at) 
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO warehouse_products (position_id, location_id, rfid, status, created_at) 
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id`
 
 	productUpdateQuery = `
 		UPDATE warehouse_products 
-		SET position_id = $1, rfid = $2, status = $3
-		WHERE id = $4`
+		SET position_id = $1, location_id = $2, rfid = $3, status = $4, updated_at = $5
+		WHERE id = $6`
 
 	productUpdateStatusQuery = `
 		UPDATE warehouse_products 
@@ -74,9 +82,36 @@ func NewProductRepository() product.Repository {
 	return &GormProductRepository{}
 }
 
+// locationFilters narrows products down to a warehouse and to a location together with its nested locations.
+func locationFilters(where []string, args []interface{}, warehouseID, locationID uint) ([]string, []interface{}) {
+	if warehouseID != 0 {
+		where = append(where, fmt.Sprintf(
+			"EXISTS (SELECT FROM warehouse_locations l WHERE l.id = wp.location_id AND l.warehouse_id = $%d)",
+			len(args)+1,
+		))
+		args = append(args, warehouseID)
+	}
+	if locationID != 0 {
+		where = append(where, fmt.Sprintf(
+			"EXISTS (SELECT FROM warehouse_locations l JOIN warehouse_locations root ON root.id = $%d "+
+				"WHERE l.id = wp.location_id AND (l.id = root.id OR l.path LIKE root.path || '/%%'))",
+			len(args)+1,
+		))
+		args = append(args, locationID)
+	}
+	return where, args
+}
+
 func (g *GormProductRepository) GetPaginated(ctx context.Context, params *product.FindParams) ([]*product.Product, error) {
 	where, args := []string{"1 = 1"}, []interface{}{}
 
+	if params.PositionID != 0 {
+		where = append(where, fmt.Sprintf("wp.position_id = $%d", len(args)+1))
+		args = append(args, params.PositionID)
+	}
+
+	where, args = locationFilters(where, args, params.WarehouseID, params.LocationID)
+
 	if params.OrderID != 0 {
 		where = append(where, fmt.Sprintf(
 			"EXISTS (SELECT FROM warehouse_order_items WHERE warehouse_product_id = wp.id AND warehouse_order_id = $%d)",
@@ -143,6 +178,8 @@ func (g *GormProductRepository) Count(ctx context.Context, opts *product.CountPa
 		args = append(args, opts.Status)
 	}
 
+	where, args = locationFilters(where, args, opts.WarehouseID, opts.LocationID)
+
 	query := productCountQuery + "\nWHERE " + strings.Join(where, " AND ")
 
 	tx, err := composables.UseTx(ctx)
@@ -159,9 +196,12 @@ func (g *GormProductRepository) Count(ctx context.Context, opts *product.CountPa
 
 func (g *GormProductRepository) FindByPositionID(ctx context.Context, opts *product.FindByPositionParams) ([]*product.Product, error) {
 	return g.GetPaginated(ctx, &product.FindParams{
-		PositionID: opts.PositionID,
-		Status:     string(opts.Status),
-		SortBy:     opts.SortBy,
+		Limit:       opts.Limit,
+		PositionID:  opts.PositionID,
+		Status:      string(opts.Status),
+		SortBy:      opts.SortBy,
+		WarehouseID: opts.WarehouseID,
+		LocationID:  opts.LocationID,
 	})
 }
 
@@ -212,6 +252,7 @@ func (g *GormProductRepository) Create(ctx context.Context, data *product.Produc
 		ctx,
 		productInsertQuery,
 		dbProduct.PositionID,
+		dbProduct.LocationID,
 		dbProduct.Rfid,
 		dbProduct.Status,
 		dbProduct.CreatedAt,
@@ -250,6 +291,7 @@ func (g *GormProductRepository) Update(ctx context.Context, data *product.Produc
 		ctx,
 		productUpdateQuery,
 		dbProduct.PositionID,
+		dbProduct.LocationID,
 		dbProduct.Rfid,
 		dbProduct.Status,
 		dbProduct.UpdatedAt,
@@ -287,10 +329,12 @@ func (g *GormProductRepository) queryProducts(ctx context.Context, query string,
 		var wp models.WarehouseProduct
 		var pos models.WarehousePosition
 		var wu models.WarehouseUnit
+		var wl models.WarehouseLocation
 
 		if err := rows.Scan(
 			&wp.ID,
 			&wp.PositionID,
+			&wp.LocationID,
 			&wp.Rfid,
 			&wp.Status,
 			&wp.CreatedAt,
@@ -306,11 +350,22 @@ func (g *GormProductRepository) queryProducts(ctx context.Context, query string,
 			&wu.ShortTitle,
 			&wu.CreatedAt,
 			&wu.UpdatedAt,
+			&wl.WarehouseID,
+			&wl.ParentID,
+			&wl.Kind,
+			&wl.Code,
+			&wl.Name,
+			&wl.Path,
 		); err != nil {
 			return nil, err
 		}
 
-		entity, err := mappers.ToDomainProduct(&wp, &pos, &wu)
+		var dbLocation *models.WarehouseLocation
+		if wp.LocationID.Valid {
+			wl.ID = uint(wp.LocationID.Int32)
+			dbLocation = &wl
+		}
+		entity, err := mappers.ToDomainProduct(&wp, &pos, &wu, dbLocation)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,492 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/purchase"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrSupplierNotFound      = errors.New("supplier not found")
+	ErrSupplierBillNotFound  = errors.New("supplier bill not found")
+)
+
+const (
+	selectPurchaseOrdersQuery = `
+		SELECT id, supplier_id, warehouse_id, currency, status, comment, bill_id, created_by_id, created_at, updated_at
+		FROM warehouse_purchase_orders po`
+
+	countPurchaseOrdersQuery = `SELECT COUNT(*) FROM warehouse_purchase_orders po`
+
+	insertPurchaseOrderQuery = `
+		INSERT INTO warehouse_purchase_orders (
+			supplier_id, warehouse_id, currency, status, comment, bill_id, created_by_id, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	updatePurchaseOrderQuery = `
+		UPDATE warehouse_purchase_orders
+		SET status = $1, comment = $2, bill_id = $3, updated_at = $4
+		WHERE id = $5`
+
+	deletePurchaseOrderQuery = `DELETE FROM warehouse_purchase_orders WHERE id = $1`
+
+	selectPurchaseOrderLinesQuery = `
+		SELECT id, purchase_order_id, position_id, quantity, unit_price, received
+		FROM warehouse_purchase_order_lines
+		WHERE purchase_order_id = $1
+		ORDER BY id`
+
+	insertPurchaseOrderLineQuery = `
+		INSERT INTO warehouse_purchase_order_lines (purchase_order_id, position_id, quantity, unit_price, received)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	updatePurchaseOrderLineQuery = `UPDATE warehouse_purchase_order_lines SET received = $1 WHERE id = $2`
+
+	selectPurchaseReceiptsQuery = `
+		SELECT id, purchase_order_id, order_id, location_id, created_by_id, created_at
+		FROM warehouse_purchase_receipts
+		WHERE purchase_order_id = $1
+		ORDER BY created_at, id`
+
+	selectPurchaseReceiptLinesQuery = `
+		SELECT rl.line_id, rl.quantity
+		FROM warehouse_purchase_receipt_lines rl
+		WHERE rl.receipt_id = $1
+		ORDER BY rl.line_id`
+
+	insertPurchaseReceiptQuery = `
+		INSERT INTO warehouse_purchase_receipts (purchase_order_id, order_id, location_id, created_by_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	insertPurchaseReceiptLineQuery = `
+		INSERT INTO warehouse_purchase_receipt_lines (receipt_id, line_id, quantity)
+		VALUES ($1, $2, $3)`
+
+	selectSupplierQuery = `SELECT id, name, tin, updated_at FROM warehouse_suppliers WHERE id = $1`
+
+	upsertSupplierQuery = `
+		INSERT INTO warehouse_suppliers (id, name, tin, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, tin = EXCLUDED.tin, updated_at = EXCLUDED.updated_at`
+
+	selectSupplierBillsQuery = `
+		SELECT id, supplier_id, number, amount, currency, status, updated_at
+		FROM warehouse_supplier_bills`
+
+	upsertSupplierBillQuery = `
+		INSERT INTO warehouse_supplier_bills (id, supplier_id, number, amount, currency, status, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE SET
+			supplier_id = EXCLUDED.supplier_id,
+			number = EXCLUDED.number,
+			amount = EXCLUDED.amount,
+			currency = EXCLUDED.currency,
+			status = EXCLUDED.status,
+			updated_at = EXCLUDED.updated_at`
+)
+
+type GormPurchaseRepository struct {
+	positionRepo position.Repository
+}
+
+func NewPurchaseRepository(positionRepo position.Repository) purchase.Repository {
+	return &GormPurchaseRepository{
+		positionRepo: positionRepo,
+	}
+}
+
+func (g *GormPurchaseRepository) filters(params *purchase.FindParams) ([]string, []interface{}) {
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if params.SupplierID != 0 {
+		where, args = append(where, fmt.Sprintf("po.supplier_id = $%d", len(args)+1)), append(args, params.SupplierID)
+	}
+	if params.WarehouseID != 0 {
+		where, args = append(where, fmt.Sprintf("po.warehouse_id = $%d", len(args)+1)), append(args, params.WarehouseID)
+	}
+	if params.Status != "" {
+		where, args = append(where, fmt.Sprintf("po.status = $%d", len(args)+1)), append(args, params.Status)
+	}
+	return where, args
+}
+
+func (g *GormPurchaseRepository) GetPaginated(ctx context.Context, params *purchase.FindParams) ([]*purchase.Order, error) {
+	where, args := g.filters(params)
+	return g.queryOrders(
+		ctx,
+		repo.Join(
+			selectPurchaseOrdersQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY po.id DESC",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (g *GormPurchaseRepository) Count(ctx context.Context, params *purchase.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := g.filters(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countPurchaseOrdersQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormPurchaseRepository) GetByID(ctx context.Context, id uint) (*purchase.Order, error) {
+	orders, err := g.queryOrders(ctx, repo.Join(selectPurchaseOrdersQuery, "WHERE po.id = $1"), id)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, ErrPurchaseOrderNotFound
+	}
+	return orders[0], nil
+}
+
+func (g *GormPurchaseRepository) Create(ctx context.Context, data *purchase.Order) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbOrder, dbLines := mappers.ToDBPurchaseOrder(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertPurchaseOrderQuery,
+		dbOrder.SupplierID,
+		dbOrder.WarehouseID,
+		dbOrder.Currency,
+		dbOrder.Status,
+		dbOrder.Comment,
+		dbOrder.BillID,
+		dbOrder.CreatedByID,
+		dbOrder.CreatedAt,
+		dbOrder.UpdatedAt,
+	).Scan(&data.ID); err != nil {
+		return err
+	}
+	for i, line := range dbLines {
+		if err := tx.QueryRow(
+			ctx,
+			insertPurchaseOrderLineQuery,
+			data.ID,
+			line.PositionID,
+			line.Quantity,
+			line.UnitPrice,
+			line.Received,
+		).Scan(&data.Lines[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GormPurchaseRepository) Update(ctx context.Context, data *purchase.Order) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbOrder, dbLines := mappers.ToDBPurchaseOrder(data)
+	if _, err := tx.Exec(
+		ctx,
+		updatePurchaseOrderQuery,
+		dbOrder.Status,
+		dbOrder.Comment,
+		dbOrder.BillID,
+		dbOrder.UpdatedAt,
+		dbOrder.ID,
+	); err != nil {
+		return err
+	}
+	for _, line := range dbLines {
+		if _, err := tx.Exec(ctx, updatePurchaseOrderLineQuery, line.Received, line.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GormPurchaseRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, deletePurchaseOrderQuery, id)
+	return err
+}
+
+func (g *GormPurchaseRepository) CreateReceipt(ctx context.Context, data *purchase.Receipt) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbReceipt := mappers.ToDBPurchaseReceipt(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertPurchaseReceiptQuery,
+		dbReceipt.PurchaseOrderID,
+		dbReceipt.OrderID,
+		dbReceipt.LocationID,
+		dbReceipt.CreatedByID,
+		dbReceipt.CreatedAt,
+	).Scan(&data.ID); err != nil {
+		return err
+	}
+	for _, line := range data.Lines {
+		if line.Quantity == 0 {
+			continue
+		}
+		if _, err := tx.Exec(ctx, insertPurchaseReceiptLineQuery, data.ID, line.LineID, line.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GormPurchaseRepository) GetSupplier(ctx context.Context, id uint) (*purchase.Supplier, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var s models.WarehouseSupplier
+	if err := tx.QueryRow(ctx, selectSupplierQuery, id).Scan(&s.ID, &s.Name, &s.Tin, &s.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	return mappers.ToDomainSupplier(&s), nil
+}
+
+func (g *GormPurchaseRepository) SaveSupplier(ctx context.Context, data *purchase.Supplier) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbSupplier := mappers.ToDBSupplier(data)
+	_, err = tx.Exec(ctx, upsertSupplierQuery, dbSupplier.ID, dbSupplier.Name, dbSupplier.Tin, dbSupplier.UpdatedAt)
+	return err
+}
+
+func (g *GormPurchaseRepository) Bills(ctx context.Context, supplierID uint) ([]*purchase.Bill, error) {
+	return g.queryBills(ctx, repo.Join(selectSupplierBillsQuery, "WHERE supplier_id = $1", "ORDER BY id DESC"), supplierID)
+}
+
+func (g *GormPurchaseRepository) GetBill(ctx context.Context, id uint) (*purchase.Bill, error) {
+	bills, err := g.queryBills(ctx, repo.Join(selectSupplierBillsQuery, "WHERE id = $1"), id)
+	if err != nil {
+		return nil, err
+	}
+	if len(bills) == 0 {
+		return nil, ErrSupplierBillNotFound
+	}
+	return bills[0], nil
+}
+
+func (g *GormPurchaseRepository) SaveBill(ctx context.Context, data *purchase.Bill) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbBill := mappers.ToDBSupplierBill(data)
+	_, err = tx.Exec(
+		ctx,
+		upsertSupplierBillQuery,
+		dbBill.ID,
+		dbBill.SupplierID,
+		dbBill.Number,
+		dbBill.Amount,
+		dbBill.Currency,
+		dbBill.Status,
+		dbBill.UpdatedAt,
+	)
+	return err
+}
+
+func (g *GormPurchaseRepository) queryBills(ctx context.Context, query string, args ...interface{}) ([]*purchase.Bill, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bills := make([]*purchase.Bill, 0)
+	for rows.Next() {
+		var b models.WarehouseSupplierBill
+		if err := rows.Scan(&b.ID, &b.SupplierID, &b.Number, &b.Amount, &b.Currency, &b.Status, &b.UpdatedAt); err != nil {
+			return nil, err
+		}
+		bills = append(bills, mappers.ToDomainSupplierBill(&b))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return bills, nil
+}
+
+func (g *GormPurchaseRepository) queryOrders(ctx context.Context, query string, args ...interface{}) ([]*purchase.Order, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	dbOrders := make([]*models.WarehousePurchaseOrder, 0)
+	for rows.Next() {
+		var o models.WarehousePurchaseOrder
+		if err := rows.Scan(
+			&o.ID,
+			&o.SupplierID,
+			&o.WarehouseID,
+			&o.Currency,
+			&o.Status,
+			&o.Comment,
+			&o.BillID,
+			&o.CreatedByID,
+			&o.CreatedAt,
+			&o.UpdatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		dbOrders = append(dbOrders, &o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*purchase.Order, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		entity, err := mappers.ToDomainPurchaseOrder(dbOrder)
+		if err != nil {
+			return nil, err
+		}
+		if err := g.attach(ctx, entity); err != nil {
+			return nil, err
+		}
+		orders = append(orders, entity)
+	}
+	return orders, nil
+}
+
+// attach loads the lines, receipts, supplier and bill of an order.
+func (g *GormPurchaseRepository) attach(ctx context.Context, entity *purchase.Order) error {
+	var err error
+	if entity.Lines, err = g.lines(ctx, entity.ID); err != nil {
+		return err
+	}
+	if entity.Receipts, err = g.receipts(ctx, entity.ID); err != nil {
+		return err
+	}
+	entity.Supplier, err = g.GetSupplier(ctx, entity.SupplierID)
+	if errors.Is(err, ErrSupplierNotFound) {
+		// finance has not described the supplier yet
+		entity.Supplier, err = &purchase.Supplier{ID: entity.SupplierID}, nil
+	}
+	if err != nil {
+		return err
+	}
+	if entity.BillID != 0 {
+		if entity.Bill, err = g.GetBill(ctx, entity.BillID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GormPurchaseRepository) lines(ctx context.Context, orderID uint) ([]*purchase.Line, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectPurchaseOrderLinesQuery, orderID)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]*purchase.Line, 0)
+	for rows.Next() {
+		var l models.WarehousePurchaseOrderLine
+		if err := rows.Scan(&l.ID, &l.PurchaseOrderID, &l.PositionID, &l.Quantity, &l.UnitPrice, &l.Received); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lines = append(lines, mappers.ToDomainPurchaseOrderLine(&l))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		if line.Position, err = g.positionRepo.GetByID(ctx, line.PositionID); err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+func (g *GormPurchaseRepository) receipts(ctx context.Context, orderID uint) ([]*purchase.Receipt, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectPurchaseReceiptsQuery, orderID)
+	if err != nil {
+		return nil, err
+	}
+	receipts := make([]*purchase.Receipt, 0)
+	for rows.Next() {
+		var r models.WarehousePurchaseReceipt
+		if err := rows.Scan(&r.ID, &r.PurchaseOrderID, &r.OrderID, &r.LocationID, &r.CreatedByID, &r.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		receipts = append(receipts, mappers.ToDomainPurchaseReceipt(&r))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, receipt := range receipts {
+		lineRows, err := tx.Query(ctx, selectPurchaseReceiptLinesQuery, receipt.ID)
+		if err != nil {
+			return nil, err
+		}
+		for lineRows.Next() {
+			var line purchase.ReceiptLine
+			if err := lineRows.Scan(&line.LineID, &line.Quantity); err != nil {
+				lineRows.Close()
+				return nil, err
+			}
+			receipt.Lines = append(receipt.Lines, &line)
+		}
+		lineRows.Close()
+		if err := lineRows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return receipts, nil
+}
@@ -0,0 +1,413 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrSalesOrderNotFound = errors.New("sales order not found")
+)
+
+// uniqueViolationCode is the SQLSTATE of a unique constraint violation
+const uniqueViolationCode = "23505"
+
+const (
+	selectSalesOrdersQuery = `
+		SELECT id, warehouse_id, customer, status, comment, order_id, created_by_id, created_at, updated_at
+		FROM warehouse_sales_orders so`
+
+	countSalesOrdersQuery = `SELECT COUNT(*) FROM warehouse_sales_orders so`
+
+	insertSalesOrderQuery = `
+		INSERT INTO warehouse_sales_orders (
+			warehouse_id, customer, status, comment, order_id, created_by_id, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	updateSalesOrderQuery = `
+		UPDATE warehouse_sales_orders
+		SET status = $1, comment = $2, order_id = $3, updated_at = $4
+		WHERE id = $5`
+
+	deleteSalesOrderQuery = `DELETE FROM warehouse_sales_orders WHERE id = $1`
+
+	selectSalesOrderLinesQuery = `
+		SELECT id, sales_order_id, position_id, quantity
+		FROM warehouse_sales_order_lines
+		WHERE sales_order_id = $1
+		ORDER BY id`
+
+	insertSalesOrderLineQuery = `
+		INSERT INTO warehouse_sales_order_lines (sales_order_id, position_id, quantity)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	selectSalesOrderPicksQuery = `
+		SELECT sp.id, sp.sales_order_id, sp.line_id, sp.product_id, wp.rfid, sp.location_id, sp.picked_by_id, sp.picked_at
+		FROM warehouse_sales_order_picks sp
+		JOIN warehouse_products wp ON wp.id = sp.product_id
+		WHERE sp.sales_order_id = $1
+		ORDER BY sp.picked_at, sp.id`
+
+	insertSalesOrderPickQuery = `
+		INSERT INTO warehouse_sales_order_picks (sales_order_id, line_id, product_id, location_id, picked_by_id, picked_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	reservedSalesQuery = `
+		SELECT sl.position_id, SUM(sl.quantity)
+		FROM warehouse_sales_order_lines sl
+		JOIN warehouse_sales_orders so ON so.id = sl.sales_order_id
+		WHERE so.warehouse_id = $1 AND so.id <> $2 AND so.status IN ($3, $4)
+		GROUP BY sl.position_id`
+
+	pickedProductsQuery = `
+		SELECT sp.product_id
+		FROM warehouse_sales_order_picks sp
+		JOIN warehouse_sales_orders so ON so.id = sp.sales_order_id
+		WHERE so.warehouse_id = $1 AND sp.open`
+
+	closeSalesOrderPicksQuery = `UPDATE warehouse_sales_order_picks SET open = FALSE WHERE sales_order_id = $1`
+)
+
+type GormSalesRepository struct {
+	positionRepo position.Repository
+}
+
+func NewSalesRepository(positionRepo position.Repository) sales.Repository {
+	return &GormSalesRepository{
+		positionRepo: positionRepo,
+	}
+}
+
+func (g *GormSalesRepository) filters(params *sales.FindParams) ([]string, []interface{}) {
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if params.WarehouseID != 0 {
+		where, args = append(where, fmt.Sprintf("so.warehouse_id = $%d", len(args)+1)), append(args, params.WarehouseID)
+	}
+	if params.Status != "" {
+		where, args = append(where, fmt.Sprintf("so.status = $%d", len(args)+1)), append(args, params.Status)
+	}
+	return where, args
+}
+
+func (g *GormSalesRepository) GetPaginated(ctx context.Context, params *sales.FindParams) ([]*sales.Order, error) {
+	where, args := g.filters(params)
+	return g.queryOrders(
+		ctx,
+		repo.Join(
+			selectSalesOrdersQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY so.id DESC",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (g *GormSalesRepository) Count(ctx context.Context, params *sales.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := g.filters(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countSalesOrdersQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormSalesRepository) GetByID(ctx context.Context, id uint) (*sales.Order, error) {
+	orders, err := g.queryOrders(ctx, repo.Join(selectSalesOrdersQuery, "WHERE so.id = $1"), id)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, ErrSalesOrderNotFound
+	}
+	return orders[0], nil
+}
+
+func (g *GormSalesRepository) Create(ctx context.Context, data *sales.Order) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbOrder, dbLines := mappers.ToDBSalesOrder(data)
+	if err := tx.QueryRow(
+		ctx,
+		insertSalesOrderQuery,
+		dbOrder.WarehouseID,
+		dbOrder.Customer,
+		dbOrder.Status,
+		dbOrder.Comment,
+		dbOrder.OrderID,
+		dbOrder.CreatedByID,
+		dbOrder.CreatedAt,
+		dbOrder.UpdatedAt,
+	).Scan(&data.ID); err != nil {
+		return err
+	}
+	for i, line := range dbLines {
+		if err := tx.QueryRow(
+			ctx,
+			insertSalesOrderLineQuery,
+			data.ID,
+			line.PositionID,
+			line.Quantity,
+		).Scan(&data.Lines[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GormSalesRepository) Update(ctx context.Context, data *sales.Order) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbOrder, _ := mappers.ToDBSalesOrder(data)
+	if _, err := tx.Exec(
+		ctx,
+		updateSalesOrderQuery,
+		dbOrder.Status,
+		dbOrder.Comment,
+		dbOrder.OrderID,
+		dbOrder.UpdatedAt,
+		dbOrder.ID,
+	); err != nil {
+		return err
+	}
+	if !data.IsClosed() {
+		return nil
+	}
+	// the products of a shipped or cancelled order may be picked again
+	_, err = tx.Exec(ctx, closeSalesOrderPicksQuery, data.ID)
+	return err
+}
+
+func (g *GormSalesRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, deleteSalesOrderQuery, id)
+	return err
+}
+
+func (g *GormSalesRepository) CreatePick(ctx context.Context, data *sales.Pick) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbPick := mappers.ToDBSalesOrderPick(data)
+	err = tx.QueryRow(
+		ctx,
+		insertSalesOrderPickQuery,
+		dbPick.SalesOrderID,
+		dbPick.LineID,
+		dbPick.ProductID,
+		dbPick.LocationID,
+		dbPick.PickedByID,
+		dbPick.PickedAt,
+	).Scan(&data.ID)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return sales.NewErrProductAlreadyPicked(data.Rfid)
+	}
+	return err
+}
+
+func (g *GormSalesRepository) Reserved(ctx context.Context, warehouseID, excludeID uint) (map[uint]int, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, reservedSalesQuery, warehouseID, excludeID, sales.Reserved, sales.Picking)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reserved := make(map[uint]int)
+	for rows.Next() {
+		var positionID uint
+		var quantity int
+		if err := rows.Scan(&positionID, &quantity); err != nil {
+			return nil, err
+		}
+		reserved[positionID] = quantity
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return reserved, nil
+}
+
+func (g *GormSalesRepository) PickedProductIDs(ctx context.Context, warehouseID uint) (map[uint]bool, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, pickedProductsQuery, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	picked := make(map[uint]bool)
+	for rows.Next() {
+		var productID uint
+		if err := rows.Scan(&productID); err != nil {
+			return nil, err
+		}
+		picked[productID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return picked, nil
+}
+
+func (g *GormSalesRepository) Commitments(ctx context.Context, warehouseID uint) (*sales.Commitments, error) {
+	reserved, err := g.Reserved(ctx, warehouseID, 0)
+	if err != nil {
+		return nil, err
+	}
+	picked, err := g.PickedProductIDs(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	return &sales.Commitments{
+		Reserved: reserved,
+		Picked:   picked,
+	}, nil
+}
+
+func (g *GormSalesRepository) queryOrders(ctx context.Context, query string, args ...interface{}) ([]*sales.Order, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	dbOrders := make([]*models.WarehouseSalesOrder, 0)
+	for rows.Next() {
+		var o models.WarehouseSalesOrder
+		if err := rows.Scan(
+			&o.ID,
+			&o.WarehouseID,
+			&o.Customer,
+			&o.Status,
+			&o.Comment,
+			&o.OrderID,
+			&o.CreatedByID,
+			&o.CreatedAt,
+			&o.UpdatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		dbOrders = append(dbOrders, &o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*sales.Order, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		entity, err := mappers.ToDomainSalesOrder(dbOrder)
+		if err != nil {
+			return nil, err
+		}
+		if entity.Lines, err = g.lines(ctx, entity.ID); err != nil {
+			return nil, err
+		}
+		if entity.Picks, err = g.picks(ctx, entity.ID); err != nil {
+			return nil, err
+		}
+		orders = append(orders, entity)
+	}
+	return orders, nil
+}
+
+func (g *GormSalesRepository) lines(ctx context.Context, orderID uint) ([]*sales.Line, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectSalesOrderLinesQuery, orderID)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]*sales.Line, 0)
+	for rows.Next() {
+		var l models.WarehouseSalesOrderLine
+		if err := rows.Scan(&l.ID, &l.SalesOrderID, &l.PositionID, &l.Quantity); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lines = append(lines, mappers.ToDomainSalesOrderLine(&l))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		if line.Position, err = g.positionRepo.GetByID(ctx, line.PositionID); err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+func (g *GormSalesRepository) picks(ctx context.Context, orderID uint) ([]*sales.Pick, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectSalesOrderPicksQuery, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	picks := make([]*sales.Pick, 0)
+	for rows.Next() {
+		var p models.WarehouseSalesOrderPick
+		if err := rows.Scan(
+			&p.ID,
+			&p.SalesOrderID,
+			&p.LineID,
+			&p.ProductID,
+			&p.Rfid,
+			&p.LocationID,
+			&p.PickedByID,
+			&p.PickedAt,
+		); err != nil {
+			return nil, err
+		}
+		picks = append(picks, mappers.ToDomainSalesOrderPick(&p))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return picks, nil
+}
@@ -26,22 +26,53 @@ CREATE TABLE warehouse_position_images
     PRIMARY KEY (upload_id, warehouse_position_id)
 );
 
+CREATE TABLE warehouses
+(
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    code       VARCHAR(50)  NOT NULL UNIQUE,
+    address    TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE warehouse_locations
+(
+    id           SERIAL PRIMARY KEY,
+    warehouse_id INT          NOT NULL REFERENCES warehouses (id) ON DELETE CASCADE,
+    parent_id    INT REFERENCES warehouse_locations (id) ON DELETE RESTRICT,
+    kind         VARCHAR(50)  NOT NULL, -- zone, rack, shelf, bin
+    code         VARCHAR(50)  NOT NULL,
+    name         VARCHAR(255),
+    path         TEXT         NOT NULL, -- MAIN/A/R01/S2/B05
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    UNIQUE (warehouse_id, code)
+);
+
+CREATE INDEX warehouse_locations_parent_id_idx ON warehouse_locations (parent_id);
+
 CREATE TABLE warehouse_products
 (
     id          SERIAL PRIMARY KEY,
     position_id INT          NOT NULL REFERENCES warehouse_positions (id) ON DELETE CASCADE,
+    location_id INT          REFERENCES warehouse_locations (id) ON DELETE SET NULL,
     rfid        VARCHAR(255) NULL UNIQUE,
     status      VARCHAR(255) NOT NULL,
     created_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
     updated_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
+CREATE INDEX warehouse_products_location_id_idx ON warehouse_products (location_id);
+
 CREATE TABLE warehouse_orders
 (
-    id         SERIAL PRIMARY KEY,
-    type       VARCHAR(255) NOT NULL,
-    status     VARCHAR(255) NOT NULL,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+    id           SERIAL PRIMARY KEY,
+    type         VARCHAR(255) NOT NULL,
+    status       VARCHAR(255) NOT NULL,
+    warehouse_id INT REFERENCES warehouses (id) ON DELETE SET NULL,
+    location_id  INT REFERENCES warehouse_locations (id) ON DELETE SET NULL, -- destination of in and transfer orders
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
 CREATE TABLE warehouse_order_items
@@ -51,16 +82,34 @@ CREATE TABLE warehouse_order_items
     PRIMARY KEY (warehouse_order_id, warehouse_product_id)
 );
 
+CREATE TABLE warehouse_product_movements
+(
+    id               SERIAL PRIMARY KEY,
+    product_id       INT NOT NULL REFERENCES warehouse_products (id) ON DELETE CASCADE,
+    order_id         INT REFERENCES warehouse_orders (id) ON DELETE SET NULL,
+    from_location_id INT REFERENCES warehouse_locations (id) ON DELETE SET NULL,
+    from_path        TEXT,
+    to_location_id   INT REFERENCES warehouse_locations (id) ON DELETE SET NULL,
+    to_path          TEXT,
+    moved_by_id      INT REFERENCES users (id) ON DELETE SET NULL,
+    created_at       TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE INDEX warehouse_product_movements_product_id_idx ON warehouse_product_movements (product_id);
+
 CREATE TABLE inventory_checks
 (
     id             SERIAL PRIMARY KEY,
     status         VARCHAR(255) NOT NULL,
     name           VARCHAR(255) NOT NULL,
     type           VARCHAR(255) NOT NULL,
+    warehouse_id   INT REFERENCES warehouses (id) ON DELETE SET NULL, -- NULL counts every warehouse
     created_at     TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
     finished_at    TIMESTAMP WITH TIME ZONE,
     created_by_id  INT          NOT NULL REFERENCES users (id) ON DELETE CASCADE,
-    finished_by_id INT REFERENCES users (id) ON DELETE CASCADE
+    finished_by_id INT REFERENCES users (id) ON DELETE CASCADE,
+    approved_at    TIMESTAMP WITH TIME ZONE,
+    approved_by_id INT REFERENCES users (id) ON DELETE SET NULL
 );
 
 CREATE TABLE inventory_check_results
@@ -74,12 +123,150 @@ CREATE TABLE inventory_check_results
     created_at         TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
 );
 
+CREATE TABLE warehouse_stock_movements
+(
+    id                 SERIAL PRIMARY KEY,
+    position_id        INT          NOT NULL REFERENCES warehouse_positions (id) ON DELETE CASCADE,
+    warehouse_id       INT REFERENCES warehouses (id) ON DELETE SET NULL,
+    location_id        INT REFERENCES warehouse_locations (id) ON DELETE SET NULL,
+    quantity           INT          NOT NULL, -- signed, negative quantities take stock away
+    reason             VARCHAR(50)  NOT NULL, -- order_in, order_out, transfer_out, transfer_in, inventory_adjustment, correction
+    order_id           INT REFERENCES warehouse_orders (id) ON DELETE SET NULL,
+    inventory_check_id INT REFERENCES inventory_checks (id) ON DELETE SET NULL,
+    comment            TEXT,
+    created_by_id      INT REFERENCES users (id) ON DELETE SET NULL,
+    created_at         TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE INDEX warehouse_stock_movements_position_id_created_at_idx ON warehouse_stock_movements (position_id, created_at);
+CREATE INDEX warehouse_stock_movements_location_id_idx ON warehouse_stock_movements (location_id);
+
+-- finance counterparties purchase orders are placed with, mirrored from the finance module
+CREATE TABLE warehouse_suppliers
+(
+    id         INT PRIMARY KEY, -- counterparty id
+    name       VARCHAR(255) NOT NULL,
+    tin        VARCHAR(20),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+-- finance supplier bills, mirrored from the finance module
+CREATE TABLE warehouse_supplier_bills
+(
+    id          INT PRIMARY KEY, -- bill id
+    supplier_id INT            NOT NULL,
+    number      VARCHAR(255)   NOT NULL,
+    amount      NUMERIC(18, 2) NOT NULL,
+    currency    VARCHAR(3)     NOT NULL,
+    status      VARCHAR(50)    NOT NULL,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE INDEX warehouse_supplier_bills_supplier_id_idx ON warehouse_supplier_bills (supplier_id);
+
+CREATE TABLE warehouse_purchase_orders
+(
+    id            SERIAL PRIMARY KEY,
+    supplier_id   INT         NOT NULL, -- counterparty id, not a foreign key as the supplier is mirrored asynchronously
+    warehouse_id  INT         NOT NULL REFERENCES warehouses (id) ON DELETE RESTRICT,
+    currency      VARCHAR(3)  NOT NULL,
+    status        VARCHAR(50) NOT NULL, -- open, partially_received, received, cancelled
+    comment       TEXT,
+    bill_id       INT REFERENCES warehouse_supplier_bills (id) ON DELETE SET NULL,
+    created_by_id INT REFERENCES users (id) ON DELETE SET NULL,
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at    TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE INDEX warehouse_purchase_orders_supplier_id_idx ON warehouse_purchase_orders (supplier_id);
+
+CREATE TABLE warehouse_purchase_order_lines
+(
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INT            NOT NULL REFERENCES warehouse_purchase_orders (id) ON DELETE CASCADE,
+    position_id       INT            NOT NULL REFERENCES warehouse_positions (id) ON DELETE RESTRICT,
+    quantity          INT            NOT NULL,
+    unit_price        NUMERIC(18, 2) NOT NULL,
+    received          INT            NOT NULL DEFAULT 0,
+    UNIQUE (purchase_order_id, position_id)
+);
+
+CREATE TABLE warehouse_purchase_receipts
+(
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES warehouse_purchase_orders (id) ON DELETE CASCADE,
+    order_id          INT REFERENCES warehouse_orders (id) ON DELETE SET NULL,
+    location_id       INT REFERENCES warehouse_locations (id) ON DELETE SET NULL,
+    created_by_id     INT REFERENCES users (id) ON DELETE SET NULL,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE TABLE warehouse_purchase_receipt_lines
+(
+    receipt_id INT NOT NULL REFERENCES warehouse_purchase_receipts (id) ON DELETE CASCADE,
+    line_id    INT NOT NULL REFERENCES warehouse_purchase_order_lines (id) ON DELETE CASCADE,
+    quantity   INT NOT NULL,
+    PRIMARY KEY (receipt_id, line_id)
+);
+
+CREATE TABLE warehouse_sales_orders
+(
+    id            SERIAL PRIMARY KEY,
+    warehouse_id  INT          NOT NULL REFERENCES warehouses (id) ON DELETE RESTRICT,
+    customer      VARCHAR(255) NOT NULL,
+    status        VARCHAR(50)  NOT NULL, -- reserved, picking, shipped, cancelled
+    comment       TEXT,
+    order_id      INT REFERENCES warehouse_orders (id) ON DELETE SET NULL, -- out order the sales order shipped with
+    created_by_id INT REFERENCES users (id) ON DELETE SET NULL,
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    updated_at    TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp
+);
+
+CREATE INDEX warehouse_sales_orders_warehouse_id_status_idx ON warehouse_sales_orders (warehouse_id, status);
+
+CREATE TABLE warehouse_sales_order_lines
+(
+    id             SERIAL PRIMARY KEY,
+    sales_order_id INT NOT NULL REFERENCES warehouse_sales_orders (id) ON DELETE CASCADE,
+    position_id    INT NOT NULL REFERENCES warehouse_positions (id) ON DELETE RESTRICT,
+    quantity       INT NOT NULL,
+    UNIQUE (sales_order_id, position_id)
+);
+
+CREATE TABLE warehouse_sales_order_picks
+(
+    id             SERIAL PRIMARY KEY,
+    sales_order_id INT NOT NULL REFERENCES warehouse_sales_orders (id) ON DELETE CASCADE,
+    line_id        INT NOT NULL REFERENCES warehouse_sales_order_lines (id) ON DELETE CASCADE,
+    product_id     INT NOT NULL REFERENCES warehouse_products (id) ON DELETE CASCADE,
+    location_id    INT REFERENCES warehouse_locations (id) ON DELETE SET NULL,
+    picked_by_id   INT REFERENCES users (id) ON DELETE SET NULL,
+    picked_at      TIMESTAMP WITH TIME ZONE DEFAULT current_timestamp,
+    open           BOOLEAN NOT NULL DEFAULT TRUE, -- cleared once the sales order is shipped or cancelled
+    UNIQUE (sales_order_id, product_id)
+);
+
+CREATE UNIQUE INDEX warehouse_sales_order_picks_product_id_open_idx ON warehouse_sales_order_picks (product_id) WHERE open;
+
 -- +migrate Down
+DROP TABLE IF EXISTS warehouse_sales_order_picks CASCADE;
+DROP TABLE IF EXISTS warehouse_sales_order_lines CASCADE;
+DROP TABLE IF EXISTS warehouse_sales_orders CASCADE;
+DROP TABLE IF EXISTS warehouse_purchase_receipt_lines CASCADE;
+DROP TABLE IF EXISTS warehouse_purchase_receipts CASCADE;
+DROP TABLE IF EXISTS warehouse_purchase_order_lines CASCADE;
+DROP TABLE IF EXISTS warehouse_purchase_orders CASCADE;
+DROP TABLE IF EXISTS warehouse_supplier_bills CASCADE;
+DROP TABLE IF EXISTS warehouse_suppliers CASCADE;
 DROP TABLE IF EXISTS inventory_check_results CASCADE;
+DROP TABLE IF EXISTS warehouse_stock_movements CASCADE;
+DROP TABLE IF EXISTS warehouse_product_movements CASCADE;
 DROP TABLE IF EXISTS warehouse_order_items CASCADE;
 DROP TABLE IF EXISTS warehouse_orders CASCADE;
 DROP TABLE IF EXISTS inventory_checks CASCADE;
 DROP TABLE IF EXISTS warehouse_products CASCADE;
+DROP TABLE IF EXISTS warehouse_locations CASCADE;
+DROP TABLE IF EXISTS warehouses CASCADE;
 DROP TABLE IF EXISTS warehouse_positions CASCADE;
 DROP TABLE IF EXISTS warehouse_position_images CASCADE;
 DROP TABLE IF EXISTS warehouse_units CASCADE;
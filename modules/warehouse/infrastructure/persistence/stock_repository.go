@@ -0,0 +1,230 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const (
+	selectStockMovementsQuery = `
+		SELECT id, position_id, warehouse_id, location_id, quantity, reason, order_id, inventory_check_id, comment, created_by_id, created_at
+		FROM warehouse_stock_movements sm`
+
+	countStockMovementsQuery = `SELECT COUNT(*) FROM warehouse_stock_movements sm`
+
+	onHandQuery = `SELECT COALESCE(SUM(sm.quantity), 0) FROM warehouse_stock_movements sm`
+
+	levelsQuery = `
+		SELECT sm.position_id, COALESCE(sm.warehouse_id, 0), COALESCE(sm.location_id, 0), SUM(sm.quantity)
+		FROM warehouse_stock_movements sm`
+
+	insertStockMovementQuery = `
+		INSERT INTO warehouse_stock_movements (
+			position_id, warehouse_id, location_id, quantity, reason, order_id, inventory_check_id, comment, created_by_id, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+)
+
+type GormStockRepository struct{}
+
+func NewStockRepository() stock.Repository {
+	return &GormStockRepository{}
+}
+
+func (g *GormStockRepository) GetPaginated(ctx context.Context, params *stock.FindParams) ([]*stock.Movement, error) {
+	where, args := g.filters(params)
+	return g.queryMovements(
+		ctx,
+		repo.Join(
+			selectStockMovementsQuery,
+			repo.JoinWhere(where...),
+			"ORDER BY sm.created_at, sm.id",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+		args...,
+	)
+}
+
+func (g *GormStockRepository) Count(ctx context.Context, params *stock.FindParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := g.filters(params)
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countStockMovementsQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormStockRepository) OnHand(ctx context.Context, params *stock.LevelParams) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where, args := g.levelFilters(params)
+	var quantity int64
+	if err := tx.QueryRow(ctx, repo.Join(onHandQuery, repo.JoinWhere(where...)), args...).Scan(&quantity); err != nil {
+		return 0, err
+	}
+	return quantity, nil
+}
+
+func (g *GormStockRepository) Levels(ctx context.Context, params *stock.LevelParams) ([]*stock.Level, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args := g.levelFilters(params)
+	rows, err := tx.Query(
+		ctx,
+		repo.Join(
+			levelsQuery,
+			repo.JoinWhere(where...),
+			"GROUP BY sm.position_id, sm.warehouse_id, sm.location_id",
+			"HAVING SUM(sm.quantity) <> 0",
+			"ORDER BY sm.position_id, sm.warehouse_id, sm.location_id",
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := make([]*stock.Level, 0)
+	for rows.Next() {
+		var level stock.Level
+		if err := rows.Scan(&level.PositionID, &level.WarehouseID, &level.LocationID, &level.Quantity); err != nil {
+			return nil, err
+		}
+		levels = append(levels, &level)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
+
+func (g *GormStockRepository) Create(ctx context.Context, data ...*stock.Movement) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, entity := range data {
+		dbRow := mappers.ToDBStockMovement(entity)
+		if err := tx.QueryRow(
+			ctx,
+			insertStockMovementQuery,
+			dbRow.PositionID,
+			dbRow.WarehouseID,
+			dbRow.LocationID,
+			dbRow.Quantity,
+			dbRow.Reason,
+			dbRow.OrderID,
+			dbRow.InventoryCheckID,
+			dbRow.Comment,
+			dbRow.CreatedByID,
+			dbRow.CreatedAt,
+		).Scan(&entity.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GormStockRepository) placementFilters(
+	where []string, args []interface{}, positionID, warehouseID, locationID uint,
+) ([]string, []interface{}) {
+	if positionID != 0 {
+		where, args = append(where, fmt.Sprintf("sm.position_id = $%d", len(args)+1)), append(args, positionID)
+	}
+	if warehouseID != 0 {
+		where, args = append(where, fmt.Sprintf("sm.warehouse_id = $%d", len(args)+1)), append(args, warehouseID)
+	}
+	if locationID != 0 {
+		where = append(where, fmt.Sprintf(
+			"EXISTS (SELECT FROM warehouse_locations l JOIN warehouse_locations root ON root.id = $%d "+
+				"WHERE l.id = sm.location_id AND (l.id = root.id OR l.path LIKE root.path || '/%%'))",
+			len(args)+1,
+		))
+		args = append(args, locationID)
+	}
+	return where, args
+}
+
+func (g *GormStockRepository) filters(params *stock.FindParams) ([]string, []interface{}) {
+	where, args := g.placementFilters([]string{"1 = 1"}, []interface{}{}, params.PositionID, params.WarehouseID, params.LocationID)
+	if params.Reason != "" {
+		where, args = append(where, fmt.Sprintf("sm.reason = $%d", len(args)+1)), append(args, params.Reason)
+	}
+	if !params.From.IsZero() {
+		where, args = append(where, fmt.Sprintf("sm.created_at >= $%d", len(args)+1)), append(args, params.From)
+	}
+	if !params.To.IsZero() {
+		where, args = append(where, fmt.Sprintf("sm.created_at < $%d", len(args)+1)), append(args, params.To)
+	}
+	return where, args
+}
+
+func (g *GormStockRepository) levelFilters(params *stock.LevelParams) ([]string, []interface{}) {
+	where, args := g.placementFilters([]string{"1 = 1"}, []interface{}{}, params.PositionID, params.WarehouseID, params.LocationID)
+	at := params.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	where, args = append(where, fmt.Sprintf("sm.created_at < $%d", len(args)+1)), append(args, at)
+	return where, args
+}
+
+func (g *GormStockRepository) queryMovements(ctx context.Context, query string, args ...interface{}) ([]*stock.Movement, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := make([]*stock.Movement, 0)
+	for rows.Next() {
+		var m models.WarehouseStockMovement
+		if err := rows.Scan(
+			&m.ID,
+			&m.PositionID,
+			&m.WarehouseID,
+			&m.LocationID,
+			&m.Quantity,
+			&m.Reason,
+			&m.OrderID,
+			&m.InventoryCheckID,
+			&m.Comment,
+			&m.CreatedByID,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entity, err := mappers.ToDomainStockMovement(&m)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, entity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
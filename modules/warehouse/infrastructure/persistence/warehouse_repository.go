@@ -0,0 +1,210 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrWarehouseNotFound = errors.New("warehouse not found")
+)
+
+const (
+	selectWarehousesQuery = `SELECT id, name, code, address, created_at, updated_at FROM warehouses`
+	countWarehousesQuery  = `SELECT COUNT(*) FROM warehouses`
+	insertWarehouseQuery  = `INSERT INTO warehouses (name, code, address, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	updateWarehouseQuery  = `UPDATE warehouses SET name = $1, code = $2, address = $3, updated_at = $4 WHERE id = $5`
+	deleteWarehouseQuery  = `DELETE FROM warehouses WHERE id = $1`
+	// lockWarehousesQuery locks the rows in the order of their ids, so that two transactions locking
+	// the same warehouses do not deadlock
+	lockWarehousesQuery = `SELECT id FROM warehouses WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+
+	countWarehouseProductsQuery = `
+		SELECT COUNT(*) FROM warehouse_products wp
+		JOIN warehouse_locations wl ON wl.id = wp.location_id
+		WHERE wl.warehouse_id = $1`
+
+	// refreshLocationPathsQuery rebuilds the paths of all locations of a warehouse after its code changed
+	refreshLocationPathsQuery = `
+		WITH RECURSIVE paths AS (
+			SELECT wl.id, w.code || '/' || wl.code AS path
+			FROM warehouse_locations wl
+			JOIN warehouses w ON w.id = wl.warehouse_id
+			WHERE wl.warehouse_id = $1 AND wl.parent_id IS NULL
+			UNION ALL
+			SELECT wl.id, p.path || '/' || wl.code
+			FROM warehouse_locations wl
+			JOIN paths p ON wl.parent_id = p.id
+		)
+		UPDATE warehouse_locations wl SET path = paths.path
+		FROM paths
+		WHERE wl.id = paths.id`
+)
+
+type GormWarehouseRepository struct{}
+
+func NewWarehouseRepository() warehouse.Repository {
+	return &GormWarehouseRepository{}
+}
+
+func (g *GormWarehouseRepository) GetPaginated(ctx context.Context, params *warehouse.FindParams) ([]*warehouse.Warehouse, error) {
+	return g.queryWarehouses(
+		ctx,
+		repo.Join(
+			selectWarehousesQuery,
+			"ORDER BY name",
+			repo.FormatLimitOffset(params.Limit, params.Offset),
+		),
+	)
+}
+
+func (g *GormWarehouseRepository) Count(ctx context.Context) (uint, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count uint
+	if err := tx.QueryRow(ctx, countWarehousesQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormWarehouseRepository) CountProducts(ctx context.Context, id uint) (int64, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, countWarehouseProductsQuery, id).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *GormWarehouseRepository) GetAll(ctx context.Context) ([]*warehouse.Warehouse, error) {
+	return g.queryWarehouses(ctx, selectWarehousesQuery+" ORDER BY name")
+}
+
+func (g *GormWarehouseRepository) GetByID(ctx context.Context, id uint) (*warehouse.Warehouse, error) {
+	warehouses, err := g.queryWarehouses(ctx, selectWarehousesQuery+" WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(warehouses) == 0 {
+		return nil, ErrWarehouseNotFound
+	}
+	return warehouses[0], nil
+}
+
+func (g *GormWarehouseRepository) GetByCode(ctx context.Context, code string) (*warehouse.Warehouse, error) {
+	warehouses, err := g.queryWarehouses(ctx, selectWarehousesQuery+" WHERE code = $1", code)
+	if err != nil {
+		return nil, err
+	}
+	if len(warehouses) == 0 {
+		return nil, ErrWarehouseNotFound
+	}
+	return warehouses[0], nil
+}
+
+func (g *GormWarehouseRepository) Create(ctx context.Context, data *warehouse.Warehouse) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbRow := mappers.ToDBWarehouse(data)
+	return tx.QueryRow(
+		ctx,
+		insertWarehouseQuery,
+		dbRow.Name,
+		dbRow.Code,
+		dbRow.Address,
+		dbRow.CreatedAt,
+	).Scan(&data.ID)
+}
+
+func (g *GormWarehouseRepository) Update(ctx context.Context, data *warehouse.Warehouse) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	dbRow := mappers.ToDBWarehouse(data)
+	if _, err := tx.Exec(
+		ctx,
+		updateWarehouseQuery,
+		dbRow.Name,
+		dbRow.Code,
+		dbRow.Address,
+		dbRow.UpdatedAt,
+		dbRow.ID,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, refreshLocationPathsQuery, dbRow.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g *GormWarehouseRepository) Delete(ctx context.Context, id uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, deleteWarehouseQuery, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g *GormWarehouseRepository) Lock(ctx context.Context, ids ...uint) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, lockWarehousesQuery, ids); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (g *GormWarehouseRepository) queryWarehouses(ctx context.Context, query string, args ...interface{}) ([]*warehouse.Warehouse, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	warehouses := make([]*warehouse.Warehouse, 0)
+	for rows.Next() {
+		var w models.Warehouse
+		if err := rows.Scan(
+			&w.ID,
+			&w.Name,
+			&w.Code,
+			&w.Address,
+			&w.CreatedAt,
+			&w.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, mappers.ToDomainWarehouse(&w))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return warehouses, nil
+}
@@ -11,6 +11,12 @@ type CreateProductsFromTags struct {
 	Tags       []string `json:"tags"`
 }
 
+type InventoryAdjustment struct {
+	ResultID int64    `json:"resultId"`
+	UnitCost *float64 `json:"unitCost,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
 type InventoryItem struct {
 	PositionID int64 `json:"positionId"`
 	Found      int   `json:"found"`
@@ -26,11 +32,13 @@ type Mutation struct {
 }
 
 type Order struct {
-	ID        int64        `json:"id"`
-	Type      string       `json:"type"`
-	Status    string       `json:"status"`
-	Items     []*OrderItem `json:"items"`
-	CreatedAt time.Time    `json:"createdAt"`
+	ID          int64        `json:"id"`
+	Type        string       `json:"type"`
+	Status      string       `json:"status"`
+	WarehouseID *int64       `json:"warehouseId,omitempty"`
+	LocationID  *int64       `json:"locationId,omitempty"`
+	Items       []*OrderItem `json:"items"`
+	CreatedAt   time.Time    `json:"createdAt"`
 }
 
 type OrderItem struct {
@@ -40,11 +48,12 @@ type OrderItem struct {
 }
 
 type OrderQuery struct {
-	Type   *string  `json:"type,omitempty"`
-	Status *string  `json:"status,omitempty"`
-	Limit  int      `json:"limit"`
-	Offset int      `json:"offset"`
-	SortBy []string `json:"sortBy,omitempty"`
+	Type        *string  `json:"type,omitempty"`
+	Status      *string  `json:"status,omitempty"`
+	WarehouseID *int64   `json:"warehouseId,omitempty"`
+	Limit       int      `json:"limit"`
+	Offset      int      `json:"offset"`
+	SortBy      []string `json:"sortBy,omitempty"`
 }
 
 type PaginatedOrders struct {
@@ -57,29 +66,123 @@ type PaginatedProducts struct {
 	Total int64      `json:"total"`
 }
 
+type PaginatedSalesOrders struct {
+	Data  []*SalesOrder `json:"data"`
+	Total int64         `json:"total"`
+}
+
 type PaginatedWarehousePositions struct {
 	Data  []*WarehousePosition `json:"data"`
 	Total int64                `json:"total"`
 }
 
+type PickingItem struct {
+	Position *WarehousePosition `json:"position"`
+	Quantity int                `json:"quantity"`
+}
+
+type PickingList struct {
+	Stops     []*PickingStop `json:"stops"`
+	Shortages []*PickingItem `json:"shortages"`
+}
+
+type PickingStop struct {
+	Location *WarehouseLocation `json:"location"`
+	Items    []*PickingItem     `json:"items"`
+}
+
 type Product struct {
 	ID         int64              `json:"id"`
 	Position   *WarehousePosition `json:"position"`
 	PositionID int64              `json:"positionID"`
+	LocationID *int64             `json:"locationId,omitempty"`
 	Rfid       string             `json:"rfid"`
 	Status     string             `json:"status"`
 	CreatedAt  time.Time          `json:"createdAt"`
 	UpdatedAt  time.Time          `json:"updatedAt"`
 }
 
+type ProductMovement struct {
+	ID        int64     `json:"id"`
+	ProductID int64     `json:"productId"`
+	OrderID   *int64    `json:"orderId,omitempty"`
+	FromPath  string    `json:"fromPath"`
+	ToPath    string    `json:"toPath"`
+	MovedByID *int64    `json:"movedById,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type Query struct {
 }
 
+type SalesOrder struct {
+	ID          int64             `json:"id"`
+	WarehouseID int64             `json:"warehouseId"`
+	Customer    string            `json:"customer"`
+	Status      string            `json:"status"`
+	Comment     string            `json:"comment"`
+	OrderID     *int64            `json:"orderId,omitempty"`
+	Lines       []*SalesOrderLine `json:"lines"`
+	Picks       []*SalesOrderPick `json:"picks"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+type SalesOrderLine struct {
+	ID          int64              `json:"id"`
+	Position    *WarehousePosition `json:"position"`
+	Quantity    int                `json:"quantity"`
+	Picked      int                `json:"picked"`
+	Outstanding int                `json:"outstanding"`
+}
+
+type SalesOrderPick struct {
+	ID         int64     `json:"id"`
+	LineID     int64     `json:"lineId"`
+	ProductID  int64     `json:"productId"`
+	Rfid       string    `json:"rfid"`
+	LocationID *int64    `json:"locationId,omitempty"`
+	PickedAt   time.Time `json:"pickedAt"`
+}
+
+type SalesOrderQuery struct {
+	WarehouseID *int64  `json:"warehouseId,omitempty"`
+	Status      *string `json:"status,omitempty"`
+	Limit       int     `json:"limit"`
+	Offset      int     `json:"offset"`
+}
+
+type StockLevel struct {
+	PositionID  int64  `json:"positionId"`
+	WarehouseID *int64 `json:"warehouseId,omitempty"`
+	LocationID  *int64 `json:"locationId,omitempty"`
+	Quantity    int64  `json:"quantity"`
+}
+
 type ValidateProductsResult struct {
 	Valid   []string `json:"valid"`
 	Invalid []string `json:"invalid"`
 }
 
+type Warehouse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Code      string    `json:"code"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type WarehouseLocation struct {
+	ID          int64  `json:"id"`
+	WarehouseID int64  `json:"warehouseId"`
+	ParentID    *int64 `json:"parentId,omitempty"`
+	Kind        string `json:"kind"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+}
+
 type WarehousePosition struct {
 	ID        int64     `json:"id"`
 	Title     string    `json:"title"`
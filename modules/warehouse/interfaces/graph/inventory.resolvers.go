@@ -6,6 +6,7 @@ package graph
 
 import (
 	"context"
+	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/inventory"
@@ -15,7 +16,7 @@ import (
 )
 
 // CompleteInventoryCheck is the resolver for the completeInventoryCheck field.
-func (r *mutationResolver) CompleteInventoryCheck(ctx context.Context, items []*model.InventoryItem) (bool, error) {
+func (r *mutationResolver) CompleteInventoryCheck(ctx context.Context, items []*model.InventoryItem, warehouseID *int64) (bool, error) {
 	_, err := composables.UseUser(ctx)
 	if err != nil {
 		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
@@ -25,6 +26,9 @@ func (r *mutationResolver) CompleteInventoryCheck(ctx context.Context, items []*
 		Name:      "Inventory check",
 		Positions: make([]*inventory.PositionCheckDTO, 0, len(items)),
 	}
+	if warehouseID != nil {
+		dto.WarehouseID = uint(*warehouseID)
+	}
 	for _, item := range items {
 		dto.Positions = append(dto.Positions, &inventory.PositionCheckDTO{
 			PositionID: uint(item.PositionID),
@@ -37,6 +41,38 @@ func (r *mutationResolver) CompleteInventoryCheck(ctx context.Context, items []*
 	return true, nil
 }
 
+// ApproveInventoryCheck is the resolver for the approveInventoryCheck field.
+func (r *mutationResolver) ApproveInventoryCheck(ctx context.Context, id int64, locationID *int64, adjustments []*model.InventoryAdjustment) (bool, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return false, nil
+	}
+	dto := &inventory.ApproveCheckDTO{
+		Results: make([]*inventory.ApproveResultDTO, 0, len(adjustments)),
+	}
+	if locationID != nil {
+		dto.LocationID = uint(*locationID)
+	}
+	for _, adjustment := range adjustments {
+		result := &inventory.ApproveResultDTO{
+			ResultID: uint(adjustment.ResultID),
+			Tags:     strings.Join(adjustment.Tags, ","),
+		}
+		if adjustment.UnitCost != nil {
+			result.UnitCost = *adjustment.UnitCost
+		}
+		dto.Results = append(dto.Results, result)
+	}
+	if err := inTx(ctx, func(ctx context.Context) error {
+		_, err := r.inventoryService.Approve(ctx, uint(id), dto)
+		return err
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Inventory is the resolver for the inventory field.
 func (r *queryResolver) Inventory(ctx context.Context) ([]*model.InventoryPosition, error) {
 	_, err := composables.UseUser(ctx)
@@ -44,7 +80,7 @@ func (r *queryResolver) Inventory(ctx context.Context) ([]*model.InventoryPositi
 		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
 		return nil, nil
 	}
-	positions, err := r.inventoryService.Positions(ctx)
+	positions, err := r.inventoryService.Positions(ctx, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -18,10 +18,12 @@ func OrderItemsToGraphModel(item order.Item) *model.OrderItem {
 
 func OrderToGraphModel(o order.Order) *model.Order {
 	return &model.Order{
-		ID:        int64(o.ID()),
-		Type:      string(o.Type()),
-		Status:    string(o.Status()),
-		Items:     mapping.MapViewModels(o.Items(), OrderItemsToGraphModel),
-		CreatedAt: o.CreatedAt(),
+		ID:          int64(o.ID()),
+		Type:        string(o.Type()),
+		Status:      string(o.Status()),
+		WarehouseID: optionalID(o.WarehouseID()),
+		LocationID:  optionalID(o.LocationID()),
+		Items:       mapping.MapViewModels(o.Items(), OrderItemsToGraphModel),
+		CreatedAt:   o.CreatedAt(),
 	}
 }
@@ -16,6 +16,7 @@ func ProductToGraphModel(entity *product.Product) *model.Product {
 		Rfid:       entity.Rfid,
 		Position:   pos,
 		PositionID: int64(entity.PositionID),
+		LocationID: optionalID(entity.LocationID),
 		CreatedAt:  entity.CreatedAt,
 		UpdatedAt:  entity.UpdatedAt,
 	}
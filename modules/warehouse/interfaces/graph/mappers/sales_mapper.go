@@ -0,0 +1,71 @@
+package mappers
+
+import (
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
+	model "github.com/iota-uz/iota-sdk/modules/warehouse/interfaces/graph/gqlmodels"
+)
+
+func SalesOrderToGraphModel(entity *sales.Order) *model.SalesOrder {
+	lines := make([]*model.SalesOrderLine, 0, len(entity.Lines))
+	for _, line := range entity.Lines {
+		lines = append(lines, &model.SalesOrderLine{
+			ID:          int64(line.ID),
+			Position:    PositionToGraphModel(line.Position),
+			Quantity:    line.Quantity,
+			Picked:      entity.Picked(line.ID),
+			Outstanding: entity.Outstanding(line),
+		})
+	}
+	picks := make([]*model.SalesOrderPick, 0, len(entity.Picks))
+	for _, pick := range entity.Picks {
+		picks = append(picks, &model.SalesOrderPick{
+			ID:         int64(pick.ID),
+			LineID:     int64(pick.LineID),
+			ProductID:  int64(pick.ProductID),
+			Rfid:       pick.Rfid,
+			LocationID: optionalID(pick.LocationID),
+			PickedAt:   pick.PickedAt,
+		})
+	}
+	return &model.SalesOrder{
+		ID:          int64(entity.ID),
+		WarehouseID: int64(entity.WarehouseID),
+		Customer:    entity.Customer,
+		Status:      string(entity.Status),
+		Comment:     entity.Comment,
+		OrderID:     optionalID(entity.OrderID),
+		Lines:       lines,
+		Picks:       picks,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+func PickingItemToGraphModel(entity *sales.PickingItem) *model.PickingItem {
+	return &model.PickingItem{
+		Position: PositionToGraphModel(entity.Line.Position),
+		Quantity: entity.Quantity,
+	}
+}
+
+func PickingListToGraphModel(entity *sales.PickingList) *model.PickingList {
+	stops := make([]*model.PickingStop, 0, len(entity.Stops))
+	for _, stop := range entity.Stops {
+		items := make([]*model.PickingItem, 0, len(stop.Items))
+		for _, item := range stop.Items {
+			items = append(items, PickingItemToGraphModel(item))
+		}
+		stops = append(stops, &model.PickingStop{
+			Location: LocationToGraphModel(stop.Location),
+			Items:    items,
+		})
+	}
+	shortages := make([]*model.PickingItem, 0, len(entity.Shortages))
+	for _, item := range entity.Shortages {
+		shortages = append(shortages, PickingItemToGraphModel(item))
+	}
+	return &model.PickingList{
+		Stops:     stops,
+		Shortages: shortages,
+	}
+}
@@ -0,0 +1,82 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
+	model "github.com/iota-uz/iota-sdk/modules/warehouse/interfaces/graph/gqlmodels"
+)
+
+// optionalID maps a zero foreign key to null.
+func optionalID(id uint) *int64 {
+	if id == 0 {
+		return nil
+	}
+	v := int64(id)
+	return &v
+}
+
+func WarehouseToGraphModel(entity *warehouse.Warehouse) *model.Warehouse {
+	return &model.Warehouse{
+		ID:        int64(entity.ID),
+		Name:      entity.Name,
+		Code:      entity.Code,
+		Address:   entity.Address,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+func LocationToGraphModel(entity *location.Location) *model.WarehouseLocation {
+	return &model.WarehouseLocation{
+		ID:          int64(entity.ID),
+		WarehouseID: int64(entity.WarehouseID),
+		ParentID:    optionalID(entity.ParentID),
+		Kind:        string(entity.Kind),
+		Code:        entity.Code,
+		Name:        entity.Name,
+		Path:        entity.Path,
+	}
+}
+
+func MovementToGraphModel(entity *movement.Movement) *model.ProductMovement {
+	return &model.ProductMovement{
+		ID:        int64(entity.ID),
+		ProductID: int64(entity.ProductID),
+		OrderID:   optionalID(entity.OrderID),
+		FromPath:  entity.FromPath,
+		ToPath:    entity.ToPath,
+		MovedByID: optionalID(entity.MovedByID),
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+func StockLevelToGraphModel(entity *stock.Level) *model.StockLevel {
+	return &model.StockLevel{
+		PositionID:  int64(entity.PositionID),
+		WarehouseID: optionalID(entity.WarehouseID),
+		LocationID:  optionalID(entity.LocationID),
+		Quantity:    entity.Quantity,
+	}
+}
+
+// StockLevelParams maps the optional arguments of the stock queries.
+func StockLevelParams(positionID, warehouseID, locationID *int64, at *time.Time) *stock.LevelParams {
+	params := &stock.LevelParams{}
+	if positionID != nil {
+		params.PositionID = uint(*positionID)
+	}
+	if warehouseID != nil {
+		params.WarehouseID = uint(*warehouseID)
+	}
+	if locationID != nil {
+		params.LocationID = uint(*locationID)
+	}
+	if at != nil {
+		params.At = *at
+	}
+	return params
+}
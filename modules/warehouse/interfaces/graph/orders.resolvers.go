@@ -48,6 +48,9 @@ func (r *queryResolver) Orders(ctx context.Context, query model.OrderQuery) (*mo
 	if query.Status != nil {
 		params.Status = *query.Status
 	}
+	if query.WarehouseID != nil {
+		params.WarehouseID = uint(*query.WarehouseID)
+	}
 	orders, err := r.orderService.GetPaginated(ctx, params)
 	if err != nil {
 		return nil, err
@@ -64,7 +67,12 @@ func (r *queryResolver) Orders(ctx context.Context, query model.OrderQuery) (*mo
 
 // CompleteOrder is the resolver for the completeOrder field.
 func (r *queryResolver) CompleteOrder(ctx context.Context, id int64) (*model.Order, error) {
-	domainOrder, err := r.orderService.Complete(ctx, uint(id))
+	var domainOrder order.Order
+	err := inTx(ctx, func(ctx context.Context) error {
+		var err error
+		domainOrder, err = r.orderService.Complete(ctx, uint(id))
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
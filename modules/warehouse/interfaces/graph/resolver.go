@@ -1,11 +1,15 @@
 package graph
 
 import (
+	"context"
+
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/orderservice"
 	positionservice "github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/productservice"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/salesservice"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 )
 
 // This file will not be regenerated automatically.
@@ -18,6 +22,11 @@ type Resolver struct {
 	productService   *productservice.ProductService
 	positionService  *positionservice.PositionService
 	inventoryService *services.InventoryService
+	warehouseService *services.WarehouseService
+	locationService  *services.LocationService
+	movementService  *services.MovementService
+	stockService     *services.StockService
+	salesService     *salesservice.SalesService
 }
 
 func NewResolver(app application.Application) *Resolver {
@@ -27,5 +36,23 @@ func NewResolver(app application.Application) *Resolver {
 		productService:   app.Service(productservice.ProductService{}).(*productservice.ProductService),
 		positionService:  app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
 		inventoryService: app.Service(services.InventoryService{}).(*services.InventoryService),
+		warehouseService: app.Service(services.WarehouseService{}).(*services.WarehouseService),
+		locationService:  app.Service(services.LocationService{}).(*services.LocationService),
+		movementService:  app.Service(services.MovementService{}).(*services.MovementService),
+		stockService:     app.Service(services.StockService{}).(*services.StockService),
+		salesService:     app.Service(salesservice.SalesService{}).(*salesservice.SalesService),
+	}
+}
+
+// inTx runs fn in a transaction, the GraphQL handler does not open one per request like the controllers do.
+func inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := composables.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := fn(composables.WithTx(ctx, tx)); err != nil {
+		return err
 	}
+	return tx.Commit(ctx)
 }
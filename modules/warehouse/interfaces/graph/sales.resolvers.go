@@ -0,0 +1,121 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
+	model "github.com/iota-uz/iota-sdk/modules/warehouse/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// PickSalesOrder is the resolver for the pickSalesOrder field.
+func (r *mutationResolver) PickSalesOrder(ctx context.Context, id int64, code string) (*model.SalesOrder, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	var entity *sales.Order
+	err = inTx(ctx, func(ctx context.Context) error {
+		if _, err := r.salesService.Pick(ctx, uint(id), &sales.PickDTO{Code: code}); err != nil {
+			return err
+		}
+		entity, err = r.salesService.GetByID(ctx, uint(id))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mappers.SalesOrderToGraphModel(entity), nil
+}
+
+// ShipSalesOrder is the resolver for the shipSalesOrder field.
+func (r *mutationResolver) ShipSalesOrder(ctx context.Context, id int64) (*model.SalesOrder, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	var entity *sales.Order
+	err = inTx(ctx, func(ctx context.Context) error {
+		entity, err = r.salesService.Ship(ctx, uint(id))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mappers.SalesOrderToGraphModel(entity), nil
+}
+
+// SalesOrder is the resolver for the salesOrder field.
+func (r *queryResolver) SalesOrder(ctx context.Context, id int64) (*model.SalesOrder, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	entity, err := r.salesService.GetByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	return mappers.SalesOrderToGraphModel(entity), nil
+}
+
+// SalesOrders is the resolver for the salesOrders field.
+func (r *queryResolver) SalesOrders(ctx context.Context, query model.SalesOrderQuery) (*model.PaginatedSalesOrders, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	params := &sales.FindParams{
+		Offset: query.Offset,
+		Limit:  query.Limit,
+	}
+	if query.WarehouseID != nil {
+		params.WarehouseID = uint(*query.WarehouseID)
+	}
+	if query.Status != nil {
+		if params.Status, err = sales.NewStatus(*query.Status); err != nil {
+			return nil, err
+		}
+	}
+	entities, err := r.salesService.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.salesService.Count(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &model.PaginatedSalesOrders{
+		Data:  mapping.MapViewModels(entities, mappers.SalesOrderToGraphModel),
+		Total: total,
+	}, nil
+}
+
+// PickingList is the resolver for the pickingList field.
+func (r *queryResolver) PickingList(ctx context.Context, salesOrderID int64) (*model.PickingList, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	entity, err := r.salesService.GetByID(ctx, uint(salesOrderID))
+	if err != nil {
+		return nil, err
+	}
+	list, err := r.salesService.PickingList(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.PickingListToGraphModel(list), nil
+}
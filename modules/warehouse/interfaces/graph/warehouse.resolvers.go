@@ -0,0 +1,88 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.57
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
+	model "github.com/iota-uz/iota-sdk/modules/warehouse/interfaces/graph/gqlmodels"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// Warehouses is the resolver for the warehouses field.
+func (r *queryResolver) Warehouses(ctx context.Context) ([]*model.Warehouse, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	warehouses, err := r.warehouseService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapViewModels(warehouses, mappers.WarehouseToGraphModel), nil
+}
+
+// WarehouseLocations is the resolver for the warehouseLocations field.
+func (r *queryResolver) WarehouseLocations(ctx context.Context, warehouseID int64) ([]*model.WarehouseLocation, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	locations, err := r.locationService.GetByWarehouseID(ctx, uint(warehouseID))
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapViewModels(locations, mappers.LocationToGraphModel), nil
+}
+
+// ProductMovements is the resolver for the productMovements field.
+func (r *queryResolver) ProductMovements(ctx context.Context, productID int64, offset int, limit int) ([]*model.ProductMovement, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	movements, err := r.movementService.GetPaginated(ctx, &movement.FindParams{
+		ProductID: uint(productID),
+		Offset:    offset,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapViewModels(movements, mappers.MovementToGraphModel), nil
+}
+
+// StockOnHand is the resolver for the stockOnHand field.
+func (r *queryResolver) StockOnHand(ctx context.Context, positionID *int64, warehouseID *int64, locationID *int64, at *time.Time) (int64, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return 0, nil
+	}
+	return r.stockService.OnHand(ctx, mappers.StockLevelParams(positionID, warehouseID, locationID, at))
+}
+
+// StockLevels is the resolver for the stockLevels field.
+func (r *queryResolver) StockLevels(ctx context.Context, positionID *int64, warehouseID *int64, locationID *int64, at *time.Time) ([]*model.StockLevel, error) {
+	_, err := composables.UseUser(ctx)
+	if err != nil {
+		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
+		return nil, nil
+	}
+	levels, err := r.stockService.Levels(ctx, mappers.StockLevelParams(positionID, warehouseID, locationID, at))
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapViewModels(levels, mappers.StockLevelToGraphModel), nil
+}
@@ -38,6 +38,30 @@ var (
 		Permissions: []*permission.Permission{permissions.UnitRead},
 		Children:    nil,
 	}
+	WarehousesItem = types.NavigationItem{
+		Name:        "NavigationLinks.Warehouses",
+		Href:        "/warehouse/warehouses",
+		Permissions: []*permission.Permission{permissions.WarehouseRead},
+		Children:    nil,
+	}
+	StockItem = types.NavigationItem{
+		Name:        "NavigationLinks.Stock",
+		Href:        "/warehouse/stock",
+		Permissions: []*permission.Permission{permissions.StockRead},
+		Children:    nil,
+	}
+	PurchaseOrdersItem = types.NavigationItem{
+		Name:        "NavigationLinks.PurchaseOrders",
+		Href:        "/warehouse/purchase-orders",
+		Permissions: []*permission.Permission{permissions.PurchaseOrderRead},
+		Children:    nil,
+	}
+	SalesOrdersItem = types.NavigationItem{
+		Name:        "NavigationLinks.SalesOrders",
+		Href:        "/warehouse/sales-orders",
+		Permissions: []*permission.Permission{permissions.SalesOrderRead},
+		Children:    nil,
+	}
 	Item = types.NavigationItem{
 		Name: "NavigationLinks.Warehouse",
 		Icon: icons.Warehouse(icons.Props{Size: "20"}),
@@ -48,6 +72,10 @@ var (
 			OrdersItem,
 			UnitsItem,
 			InventoryItem,
+			WarehousesItem,
+			StockItem,
+			PurchaseOrdersItem,
+			SalesOrdersItem,
 		},
 	}
 )
@@ -3,6 +3,7 @@ package warehouse
 import (
 	"embed"
 	icons "github.com/iota-uz/icons/phosphor"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/handlers"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/interfaces/graph"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
@@ -12,6 +13,8 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/orderservice"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/productservice"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/purchaseservice"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/salesservice"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
 )
@@ -35,29 +38,62 @@ func (m *Module) Register(app application.Application) error {
 	unitRepo := persistence.NewUnitRepository()
 	positionRepo := persistence.NewPositionRepository()
 	productRepo := persistence.NewProductRepository()
+	orderRepo := persistence.NewOrderRepository(productRepo)
+	warehouseRepo := persistence.NewWarehouseRepository()
+	locationRepo := persistence.NewLocationRepository()
+	movementRepo := persistence.NewMovementRepository()
+	stockRepo := persistence.NewStockRepository()
+	purchaseRepo := persistence.NewPurchaseRepository(positionRepo)
+	salesRepo := persistence.NewSalesRepository(positionRepo)
 
 	unitService := services.NewUnitService(unitRepo, app.EventPublisher())
 	app.RegisterServices(unitService)
 
-	productService := productservice.NewProductService(productRepo, app.EventPublisher())
+	productService := productservice.NewProductService(productRepo, movementRepo, app.EventPublisher())
 	app.RegisterServices(productService)
 	app.RegisterServices(
 		services.NewUnitService(unitRepo, app.EventPublisher()),
-		productservice.NewProductService(productRepo, app.EventPublisher()),
+		productservice.NewProductService(productRepo, movementRepo, app.EventPublisher()),
+		services.NewWarehouseService(warehouseRepo, app.EventPublisher()),
+		services.NewLocationService(locationRepo, warehouseRepo),
+		services.NewMovementService(movementRepo),
+		services.NewStockService(stockRepo),
 	)
 
+	orderService := orderservice.NewOrderService(
+		app.EventPublisher(),
+		orderRepo,
+		productRepo,
+		locationRepo,
+		movementRepo,
+		stockRepo,
+		warehouseRepo,
+		salesRepo,
+	)
 	app.RegisterServices(
 		positionservice.NewPositionService(
 			positionRepo,
 			app.EventPublisher(),
 			app,
 		),
-		orderservice.NewOrderService(
+		orderService,
+		services.NewInventoryService(app.EventPublisher()),
+		purchaseservice.NewPurchaseService(
 			app.EventPublisher(),
-			persistence.NewOrderRepository(productRepo),
+			purchaseRepo,
+			orderRepo,
 			productRepo,
+			locationRepo,
+			orderService,
+		),
+		salesservice.NewSalesService(
+			salesRepo,
+			orderRepo,
+			productRepo,
+			positionRepo,
+			warehouseRepo,
+			orderService,
 		),
-		services.NewInventoryService(app.EventPublisher()),
 	)
 
 	app.RBAC().Register(
@@ -81,6 +117,20 @@ func (m *Module) Register(app application.Application) error {
 		permissions.InventoryRead,
 		permissions.InventoryUpdate,
 		permissions.InventoryDelete,
+		permissions.WarehouseCreate,
+		permissions.WarehouseRead,
+		permissions.WarehouseUpdate,
+		permissions.WarehouseDelete,
+		permissions.StockRead,
+		permissions.StockUpdate,
+		permissions.PurchaseOrderCreate,
+		permissions.PurchaseOrderRead,
+		permissions.PurchaseOrderUpdate,
+		permissions.PurchaseOrderDelete,
+		permissions.SalesOrderCreate,
+		permissions.SalesOrderRead,
+		permissions.SalesOrderUpdate,
+		permissions.SalesOrderDelete,
 	)
 	app.RegisterControllers(
 		controllers.NewProductsController(app),
@@ -88,7 +138,14 @@ func (m *Module) Register(app application.Application) error {
 		controllers.NewUnitsController(app),
 		controllers.NewOrdersController(app),
 		controllers.NewInventoryController(app),
+		controllers.NewWarehousesController(app),
+		controllers.NewStockController(app),
+		controllers.NewPurchaseOrdersController(app),
+		controllers.NewSalesOrdersController(app),
 	)
+	handlers.RegisterNotificationHandler(app)
+	handlers.RegisterDealOrderHandler(app, orderRepo)
+	handlers.RegisterPurchaseHandler(app, purchaseRepo)
 	app.RegisterLocaleFiles(&localeFiles)
 	app.RegisterMigrationDirs(&migrationFiles)
 	app.RegisterAssets(&assets.FS)
@@ -98,6 +155,10 @@ func (m *Module) Register(app application.Application) error {
 		spotlight.NewItem(nil, OrdersItem.Name, OrdersItem.Href),
 		spotlight.NewItem(nil, UnitsItem.Name, UnitsItem.Href),
 		spotlight.NewItem(nil, InventoryItem.Name, InventoryItem.Href),
+		spotlight.NewItem(nil, WarehousesItem.Name, WarehousesItem.Href),
+		spotlight.NewItem(nil, StockItem.Name, StockItem.Href),
+		spotlight.NewItem(nil, PurchaseOrdersItem.Name, PurchaseOrdersItem.Href),
+		spotlight.NewItem(nil, SalesOrdersItem.Name, SalesOrdersItem.Href),
 		spotlight.NewItem(
 			icons.PlusCircle(icons.Props{Size: "24"}),
 			"WarehousePositions.List.New",
@@ -118,6 +179,21 @@ func (m *Module) Register(app application.Application) error {
 			"WarehouseUnits.List.New",
 			"/warehouse/units/new",
 		),
+		spotlight.NewItem(
+			icons.PlusCircle(icons.Props{Size: "24"}),
+			"Warehouses.List.New",
+			"/warehouse/warehouses/new",
+		),
+		spotlight.NewItem(
+			icons.PlusCircle(icons.Props{Size: "24"}),
+			"PurchaseOrders.List.New",
+			"/warehouse/purchase-orders/new",
+		),
+		spotlight.NewItem(
+			icons.PlusCircle(icons.Props{Size: "24"}),
+			"SalesOrders.List.New",
+			"/warehouse/sales-orders/new",
+		),
 	)
 
 	app.RegisterGraphSchema(application.GraphSchema{
@@ -11,6 +11,11 @@ const (
 	ResourceOrder     permission.Resource = "order"
 	ResourceUnit      permission.Resource = "unit"
 	ResourceInventory permission.Resource = "inventory"
+	ResourceWarehouse permission.Resource = "warehouse"
+	ResourceStock     permission.Resource = "stock"
+
+	ResourcePurchaseOrder permission.Resource = "purchase_order"
+	ResourceSalesOrder    permission.Resource = "sales_order"
 )
 
 var (
@@ -154,6 +159,104 @@ var (
 		Action:   permission.ActionDelete,
 		Modifier: permission.ModifierAll,
 	}
+	WarehouseCreate = &permission.Permission{
+		ID:       uuid.MustParse("4f0a3c1e-2d7b-4b8e-9a61-5c3e8f7d2b10"),
+		Name:     "Warehouse.Create",
+		Resource: ResourceWarehouse,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	WarehouseRead = &permission.Permission{
+		ID:       uuid.MustParse("a8d2e6f4-61c9-4e3a-b5d7-0f9c2e1a7b34"),
+		Name:     "Warehouse.Read",
+		Resource: ResourceWarehouse,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	WarehouseUpdate = &permission.Permission{
+		ID:       uuid.MustParse("c3b7f1d9-8e2a-4c65-9d10-6a4e5b3f2c87"),
+		Name:     "Warehouse.Update",
+		Resource: ResourceWarehouse,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	WarehouseDelete = &permission.Permission{
+		ID:       uuid.MustParse("e91f4a2b-3c7d-4f86-8b05-d2a6c9e1f053"),
+		Name:     "Warehouse.Delete",
+		Resource: ResourceWarehouse,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	StockRead = &permission.Permission{
+		ID:       uuid.MustParse("4c8e2f17-9a3b-4d5e-a6f1-7b2c9d0e3a84"),
+		Name:     "Stock.Read",
+		Resource: ResourceStock,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	StockUpdate = &permission.Permission{
+		ID:       uuid.MustParse("a17d3e95-6b2f-4c80-9e4a-5f8b1c2d7e63"),
+		Name:     "Stock.Update",
+		Resource: ResourceStock,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	PurchaseOrderCreate = &permission.Permission{
+		ID:       uuid.MustParse("6b1e9d42-7c3a-4f58-a2d6-93e0f5b8c127"),
+		Name:     "PurchaseOrder.Create",
+		Resource: ResourcePurchaseOrder,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	PurchaseOrderRead = &permission.Permission{
+		ID:       uuid.MustParse("d24f8a6c-1e3b-4d97-b5c0-7a9e2f6d3b58"),
+		Name:     "PurchaseOrder.Read",
+		Resource: ResourcePurchaseOrder,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	PurchaseOrderUpdate = &permission.Permission{
+		ID:       uuid.MustParse("8e5c2b71-4a9d-4f03-9b6e-1d7f3a0c5e92"),
+		Name:     "PurchaseOrder.Update",
+		Resource: ResourcePurchaseOrder,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	PurchaseOrderDelete = &permission.Permission{
+		ID:       uuid.MustParse("f0a7d3e8-5b2c-4e61-8d94-c6b1e9a2f473"),
+		Name:     "PurchaseOrder.Delete",
+		Resource: ResourcePurchaseOrder,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
+	SalesOrderCreate = &permission.Permission{
+		ID:       uuid.MustParse("3c8f1a5e-9d27-4b6a-8e03-b4d7c2f91a56"),
+		Name:     "SalesOrder.Create",
+		Resource: ResourceSalesOrder,
+		Action:   permission.ActionCreate,
+		Modifier: permission.ModifierAll,
+	}
+	SalesOrderRead = &permission.Permission{
+		ID:       uuid.MustParse("a91d6e2b-5f48-4c3e-9b71-0e6c8d4f2a37"),
+		Name:     "SalesOrder.Read",
+		Resource: ResourceSalesOrder,
+		Action:   permission.ActionRead,
+		Modifier: permission.ModifierAll,
+	}
+	SalesOrderUpdate = &permission.Permission{
+		ID:       uuid.MustParse("5e2b7c94-1a6d-4f85-b3e0-c97a2d1f6b48"),
+		Name:     "SalesOrder.Update",
+		Resource: ResourceSalesOrder,
+		Action:   permission.ActionUpdate,
+		Modifier: permission.ModifierAll,
+	}
+	SalesOrderDelete = &permission.Permission{
+		ID:       uuid.MustParse("d7a4f0c3-8b19-4e62-a5d8-2f6e9c1b3a70"),
+		Name:     "SalesOrder.Delete",
+		Resource: ResourceSalesOrder,
+		Action:   permission.ActionDelete,
+		Modifier: permission.ModifierAll,
+	}
 )
 
 var Permissions = []*permission.Permission{
@@ -177,4 +280,18 @@ var Permissions = []*permission.Permission{
 	InventoryRead,
 	InventoryUpdate,
 	InventoryDelete,
+	WarehouseCreate,
+	WarehouseRead,
+	WarehouseUpdate,
+	WarehouseDelete,
+	StockRead,
+	StockUpdate,
+	PurchaseOrderCreate,
+	PurchaseOrderRead,
+	PurchaseOrderUpdate,
+	PurchaseOrderDelete,
+	SalesOrderCreate,
+	SalesOrderRead,
+	SalesOrderUpdate,
+	SalesOrderDelete,
 }
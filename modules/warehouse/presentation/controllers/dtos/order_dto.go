@@ -10,8 +10,13 @@ import (
 )
 
 type CreateOrderDTO struct {
-	PositionIDs []uint        `validate:"required"`
-	Quantity    map[uint]uint `validate:"required"`
+	WarehouseID uint `validate:"required"`
+	// LocationID is where in and transfer orders put their products
+	LocationID uint
+	// FromLocationID optionally narrows the products of out and transfer orders down to a location
+	FromLocationID uint
+	PositionIDs    []uint        `validate:"required"`
+	Quantity       map[uint]uint `validate:"required"`
 }
 
 type UpdateOrderDTO struct {
@@ -3,6 +3,7 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/a-h/templ"
 	"github.com/go-faster/errors"
@@ -11,6 +12,7 @@ import (
 	"github.com/iota-uz/iota-sdk/components/base/pagination"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/inventory"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/mappers"
 	inventory2 "github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/inventory"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/viewmodels"
@@ -20,6 +22,7 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
 	"github.com/iota-uz/iota-sdk/pkg/shared"
 )
 
@@ -27,6 +30,8 @@ type InventoryController struct {
 	app              application.Application
 	inventoryService *services.InventoryService
 	positionService  *positionservice.PositionService
+	locationService  *services.LocationService
+	warehouseService *services.WarehouseService
 	basePath         string
 }
 
@@ -41,6 +46,8 @@ func NewInventoryController(app application.Application) application.Controller
 		basePath:         "/warehouse/inventory",
 		inventoryService: app.Service(services.InventoryService{}).(*services.InventoryService),
 		positionService:  app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
+		locationService:  app.Service(services.LocationService{}).(*services.LocationService),
+		warehouseService: app.Service(services.WarehouseService{}).(*services.WarehouseService),
 	}
 }
 
@@ -72,6 +79,7 @@ func (c *InventoryController) Register(r *mux.Router) {
 	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
 	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
 	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/approve", c.Approve).Methods(http.MethodPost)
 }
 
 func (c *InventoryController) viewModelChecks(r *http.Request) (*InventoryCheckPaginatedResponse, error) {
@@ -119,10 +127,16 @@ func (c *InventoryController) List(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *InventoryController) GetNew(w http.ResponseWriter, r *http.Request) {
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	props := &inventory2.CreatePageProps{
-		Errors:  map[string]string{},
-		Check:   mappers.CheckToViewModel(&inventory.Check{}),
-		SaveURL: c.basePath,
+		Errors:     map[string]string{},
+		Check:      mappers.CheckToViewModel(&inventory.Check{}),
+		Warehouses: mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
+		SaveURL:    c.basePath,
 	}
 	templ.Handler(inventory2.New(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
@@ -179,9 +193,16 @@ func (c *InventoryController) Create(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		warehouses, err := c.warehouseService.GetAll(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		props := &inventory2.CreatePageProps{
-			Errors: errorsMap,
-			Check:  mappers.CheckToViewModel(entity),
+			Errors:     errorsMap,
+			Check:      mappers.CheckToViewModel(entity),
+			Warehouses: mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
+			SaveURL:    c.basePath,
 		}
 		templ.Handler(inventory2.CreateForm(props), templ.WithStreaming()).ServeHTTP(w, r)
 		return
@@ -213,6 +234,13 @@ func (c *InventoryController) GetEdit(w http.ResponseWriter, r *http.Request) {
 		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, entity.ID),
 		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, entity.ID),
 	}
+	if !entity.IsLocked() && composables.CanUser(r.Context(), permissions.InventoryUpdate) == nil {
+		props.Approval, err = c.approvalProps(r, entity, inventory2.ApprovalForm{}, map[string]string{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 	templ.Handler(inventory2.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
@@ -234,6 +262,13 @@ func (c *InventoryController) GetEditDifference(w http.ResponseWriter, r *http.R
 		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, entity.ID),
 		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, entity.ID),
 	}
+	if !entity.IsLocked() && composables.CanUser(r.Context(), permissions.InventoryUpdate) == nil {
+		props.Approval, err = c.approvalProps(r, entity, inventory2.ApprovalForm{}, map[string]string{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 	templ.Handler(inventory2.Edit(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
@@ -288,6 +323,94 @@ func (c *InventoryController) Update(w http.ResponseWriter, r *http.Request) {
 	shared.Redirect(w, r, c.basePath)
 }
 
+func (c *InventoryController) approvalProps(
+	r *http.Request,
+	entity *inventory.Check,
+	form inventory2.ApprovalForm,
+	errorsMap map[string]string,
+) (*inventory2.ApprovalProps, error) {
+	locations, err := c.locationService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving locations: %w", err)
+	}
+	results := make([]*viewmodels.CheckResult, 0, len(entity.Results))
+	for _, result := range entity.Results {
+		if result.Difference != 0 {
+			results = append(results, mappers.CheckResultToViewModel(result))
+		}
+	}
+	return &inventory2.ApprovalProps{
+		ApproveURL: fmt.Sprintf("%s/%d/approve", c.basePath, entity.ID),
+		Results:    results,
+		Locations:  mapping.MapViewModels(locations, mappers.LocationToViewModel),
+		Form:       form,
+		Errors:     errorsMap,
+	}, nil
+}
+
+func (c *InventoryController) Approve(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&inventory.ApproveCheckDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		_, err = c.inventoryService.Approve(r.Context(), id, dto)
+		if err == nil {
+			shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+			return
+		}
+		var vErr serrors.Base
+		if !errors.As(err, &vErr) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		errorsMap = map[string]string{"_form": vErr.Localize(localizer)}
+	}
+	entity, err := c.inventoryService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Error retrieving inventory check", http.StatusInternalServerError)
+		return
+	}
+	form := inventory2.ApprovalForm{
+		LocationID: idValue(dto.LocationID),
+		UnitCosts:  make(map[string]string, len(dto.Results)),
+		Tags:       make(map[string]string, len(dto.Results)),
+	}
+	for _, result := range dto.Results {
+		if result == nil {
+			continue
+		}
+		resultID := strconv.FormatUint(uint64(result.ResultID), 10)
+		if result.UnitCost != 0 {
+			form.UnitCosts[resultID] = strconv.FormatFloat(result.UnitCost, 'f', -1, 64)
+		}
+		form.Tags[resultID] = result.Tags
+	}
+	props, err := c.approvalProps(r, entity, form, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	templ.Handler(inventory2.Approval(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
 func (c *InventoryController) SearchPositions(w http.ResponseWriter, r *http.Request) {
 	paginated, err := c.viewModelPositions(r)
 	if err != nil {
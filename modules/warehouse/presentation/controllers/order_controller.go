@@ -14,7 +14,9 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/orders"
 	orderin "github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/orders/in"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/orders/out"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/orders/transfer"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/orderservice"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/productservice"
@@ -23,6 +25,7 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/fp"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
 	"github.com/iota-uz/iota-sdk/pkg/shared"
 	"net/http"
 	"strconv"
@@ -56,6 +59,18 @@ func OrderOutItemToViewModel(item OrderItem) orderout.OrderItem {
 	}
 }
 
+func OrderTransferItemToViewModel(item OrderItem) ordertransfer.OrderItem {
+	return ordertransfer.OrderItem{
+		PositionID:    strconv.FormatUint(uint64(item.PositionID), 10),
+		PositionTitle: item.PositionTitle,
+		Barcode:       item.Barcode,
+		Unit:          item.Unit,
+		InStock:       strconv.FormatUint(uint64(item.InStock), 10),
+		Quantity:      strconv.FormatUint(uint64(item.Quantity), 10),
+		Error:         item.Error,
+	}
+}
+
 func OrderInItemToViewModel(item OrderItem) orderin.OrderItem {
 	return orderin.OrderItem{
 		PositionID:    strconv.FormatUint(uint64(item.PositionID), 10),
@@ -69,11 +84,13 @@ func OrderInItemToViewModel(item OrderItem) orderin.OrderItem {
 }
 
 type OrdersController struct {
-	app             application.Application
-	orderService    *orderservice.OrderService
-	positionService *positionservice.PositionService
-	productService  *productservice.ProductService
-	basePath        string
+	app              application.Application
+	orderService     *orderservice.OrderService
+	positionService  *positionservice.PositionService
+	productService   *productservice.ProductService
+	warehouseService *services.WarehouseService
+	locationService  *services.LocationService
+	basePath         string
 }
 
 type OrderPaginatedResponse struct {
@@ -83,11 +100,13 @@ type OrderPaginatedResponse struct {
 
 func NewOrdersController(app application.Application) application.Controller {
 	return &OrdersController{
-		app:             app,
-		orderService:    app.Service(orderservice.OrderService{}).(*orderservice.OrderService),
-		positionService: app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
-		productService:  app.Service(productservice.ProductService{}).(*productservice.ProductService),
-		basePath:        "/warehouse/orders",
+		app:              app,
+		orderService:     app.Service(orderservice.OrderService{}).(*orderservice.OrderService),
+		positionService:  app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
+		productService:   app.Service(productservice.ProductService{}).(*productservice.ProductService),
+		warehouseService: app.Service(services.WarehouseService{}).(*services.WarehouseService),
+		locationService:  app.Service(services.LocationService{}).(*services.LocationService),
+		basePath:         "/warehouse/orders",
 	}
 }
 
@@ -112,12 +131,14 @@ func (c *OrdersController) Register(r *mux.Router) {
 	getRouter.HandleFunc("/{id:[0-9]+}", c.ViewOrder).Methods(http.MethodGet)
 	getRouter.HandleFunc("/in/new", c.NewInOrder).Methods(http.MethodGet)
 	getRouter.HandleFunc("/out/new", c.NewOutOrder).Methods(http.MethodGet)
+	getRouter.HandleFunc("/transfer/new", c.NewTransferOrder).Methods(http.MethodGet)
 
 	setRouter := r.PathPrefix(c.basePath).Subrouter()
 	setRouter.Use(commonMiddleware...)
 	setRouter.Use(middleware.WithTransaction())
 	setRouter.HandleFunc("/in", c.CreateInOrder).Methods(http.MethodPost)
 	setRouter.HandleFunc("/out", c.CreateOutOrder).Methods(http.MethodPost)
+	setRouter.HandleFunc("/transfer", c.CreateTransferOrder).Methods(http.MethodPost)
 	setRouter.HandleFunc("/items", c.OrderItems).Methods(http.MethodPost)
 	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
 }
@@ -140,12 +161,112 @@ func (c *OrdersController) viewModelOrders(r *http.Request) (*OrderPaginatedResp
 	if err != nil {
 		return nil, errors.Wrap(err, "Error counting orders")
 	}
+	viewOrders := OrdersToViewModels(entities)
+	if err := c.fillPlacement(r.Context(), viewOrders...); err != nil {
+		return nil, errors.Wrap(err, "Error retrieving warehouses")
+	}
 	return &OrderPaginatedResponse{
 		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
-		Orders:          OrdersToViewModels(entities),
+		Orders:          viewOrders,
 	}, nil
 }
 
+// placementOptions returns the warehouses and locations order forms choose from.
+func (c *OrdersController) placementOptions(ctx context.Context) ([]*viewmodels.Warehouse, []*viewmodels.Location, error) {
+	warehouses, err := c.warehouseService.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	locations, err := c.locationService.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
+		mapping.MapViewModels(locations, mappers.LocationToViewModel),
+		nil
+}
+
+// fillPlacement resolves the warehouse names and location paths of orders.
+func (c *OrdersController) fillPlacement(ctx context.Context, viewOrders ...*viewmodels.Order) error {
+	warehouses, locations, err := c.placementOptions(ctx)
+	if err != nil {
+		return err
+	}
+	warehouseNames := make(map[string]string, len(warehouses))
+	for _, w := range warehouses {
+		warehouseNames[w.ID] = w.Name
+	}
+	locationPaths := make(map[string]string, len(locations))
+	for _, l := range locations {
+		locationPaths[l.ID] = l.Path
+	}
+	for _, o := range viewOrders {
+		o.Warehouse = warehouseNames[o.WarehouseID]
+		o.Location = locationPaths[o.LocationID]
+	}
+	return nil
+}
+
+func formID(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// orderProducts picks the oldest products with the status for every item, marking the items that lack products.
+func (c *OrdersController) orderProducts(
+	ctx context.Context,
+	formDTO *dtos.CreateOrderDTO,
+	items []OrderItem,
+	status product.Status,
+	notEnoughMsg string,
+) ([]uint, bool, error) {
+	params := &product.FindByPositionParams{
+		SortBy: []string{"created_at asc"},
+		Status: status,
+	}
+	if status == product.InStock {
+		params.WarehouseID = formDTO.WarehouseID
+		params.LocationID = formDTO.FromLocationID
+	}
+	var ids []uint
+	var hasErrors bool
+	for i, item := range items {
+		params.PositionID = item.PositionID
+		params.Limit = int(formDTO.Quantity[item.PositionID])
+		products, err := c.orderService.FindByPositionID(ctx, params)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(products) < params.Limit {
+			hasErrors = true
+			items[i].Error = composables.MustT(ctx, notEnoughMsg)
+		}
+		for _, p := range products {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids, hasErrors, nil
+}
+
+// createOrder returns the errors of the form when the order service rejects the order.
+func (c *OrdersController) createOrder(ctx context.Context, dto order.CreateDTO) (map[string]string, error) {
+	err := c.orderService.Create(ctx, dto)
+	if err == nil {
+		return nil, nil
+	}
+	var vErr serrors.Base
+	if !errors.As(err, &vErr) {
+		return nil, err
+	}
+	localizer, ok := composables.UseLocalizer(ctx)
+	if !ok {
+		return nil, composables.ErrNoLocalizer
+	}
+	return map[string]string{"LocationID": vErr.Localize(localizer)}, nil
+}
+
 func (c *OrdersController) List(w http.ResponseWriter, r *http.Request) {
 	paginated, err := c.viewModelOrders(r)
 	if err != nil {
@@ -181,7 +302,7 @@ func (c *OrdersController) ViewOrder(w http.ResponseWriter, r *http.Request) {
 	switch entity.Type() {
 	case order.TypeIn:
 		status = product.InDevelopment
-	case order.TypeOut:
+	case order.TypeOut, order.TypeTransfer:
 		status = product.InStock
 	}
 	countByPositionID := make(map[uint]int)
@@ -198,6 +319,10 @@ func (c *OrdersController) ViewOrder(w http.ResponseWriter, r *http.Request) {
 	}
 
 	viewModel := mappers.OrderToViewModel(entity, countByPositionID)
+	if err := c.fillPlacement(r.Context(), viewModel); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	props := &orders.ViewPageProps{
 		Order:     viewModel,
 		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
@@ -206,24 +331,54 @@ func (c *OrdersController) ViewOrder(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *OrdersController) NewInOrder(w http.ResponseWriter, r *http.Request) {
+	warehouses, locations, err := c.placementOptions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	props := &orderin.PageProps{
-		Errors:   map[string]string{},
-		Items:    []orderin.OrderItem{},
-		SaveURL:  fmt.Sprintf("%s/in", c.basePath),
-		ItemsURL: fmt.Sprintf("%s/items?status=%s", c.basePath, product.InDevelopment),
+		Warehouses: warehouses,
+		Locations:  locations,
+		Errors:     map[string]string{},
+		Items:      []orderin.OrderItem{},
+		SaveURL:    fmt.Sprintf("%s/in", c.basePath),
+		ItemsURL:   fmt.Sprintf("%s/items?status=%s", c.basePath, product.InDevelopment),
 	}
 	templ.Handler(orderin.New(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
 func (c *OrdersController) NewOutOrder(w http.ResponseWriter, r *http.Request) {
+	warehouses, locations, err := c.placementOptions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	props := &orderout.PageProps{
-		Errors:   map[string]string{},
-		SaveURL:  fmt.Sprintf("%s/out", c.basePath),
-		ItemsURL: fmt.Sprintf("%s/items?status=%s", c.basePath, product.InStock),
+		Warehouses: warehouses,
+		Locations:  locations,
+		Errors:     map[string]string{},
+		SaveURL:    fmt.Sprintf("%s/out", c.basePath),
+		ItemsURL:   fmt.Sprintf("%s/items?status=%s", c.basePath, product.InStock),
 	}
 	templ.Handler(orderout.New(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
+func (c *OrdersController) NewTransferOrder(w http.ResponseWriter, r *http.Request) {
+	warehouses, locations, err := c.placementOptions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &ordertransfer.PageProps{
+		Warehouses: warehouses,
+		Locations:  locations,
+		Errors:     map[string]string{},
+		SaveURL:    fmt.Sprintf("%s/transfer", c.basePath),
+		ItemsURL:   fmt.Sprintf("%s/items?status=%s", c.basePath, product.InStock),
+	}
+	templ.Handler(ordertransfer.New(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
 func (c *OrdersController) CreateInOrder(w http.ResponseWriter, r *http.Request) {
 	formDTO, err := composables.UseForm(&dtos.CreateOrderDTO{}, r)
 	if err != nil {
@@ -236,55 +391,54 @@ func (c *OrdersController) CreateInOrder(w http.ResponseWriter, r *http.Request)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if errorsMap, ok := formDTO.Ok(r.Context()); !ok {
+	warehouses, locations, err := c.placementOptions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	renderForm := func(errorsMap map[string]string) {
 		props := &orderin.FormProps{
-			Errors: errorsMap,
-			Items:  mapping.MapViewModels(items, OrderInItemToViewModel),
+			Errors:      errorsMap,
+			Items:       mapping.MapViewModels(items, OrderInItemToViewModel),
+			Warehouses:  warehouses,
+			Locations:   locations,
+			WarehouseID: formID(formDTO.WarehouseID),
+			LocationID:  formID(formDTO.LocationID),
 		}
 		templ.Handler(orderin.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+	if errorsMap, ok := formDTO.Ok(r.Context()); !ok {
+		renderForm(errorsMap)
 		return
 	}
 
-	dto := order.CreateDTO{
-		Type:       string(order.TypeIn),
-		Status:     string(order.Pending),
-		ProductIDs: []uint{},
-	}
-	var hasErrors bool
-	for i, item := range items {
-		quantity := int(formDTO.Quantity[item.PositionID])
-		products, err := c.orderService.FindByPositionID(r.Context(), &product.FindByPositionParams{
-			PositionID: item.PositionID,
-			SortBy:     []string{"created_at asc"},
-			Limit:      quantity,
-			Status:     product.InDevelopment,
-		})
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if len(products) < quantity {
-			hasErrors = true
-			items[i].Error = composables.MustT(r.Context(), "Errors.ERR_NOT_ENOUGH_PRODUCTS_IN_DEVELOPMENT")
-		}
-		for _, p := range products {
-			dto.ProductIDs = append(dto.ProductIDs, p.ID)
-		}
+	productIDs, hasErrors, err := c.orderProducts(
+		r.Context(), formDTO, items, product.InDevelopment, "Errors.ERR_NOT_ENOUGH_PRODUCTS_IN_DEVELOPMENT",
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-
 	if hasErrors {
-		props := &orderin.FormProps{
-			Errors: map[string]string{},
-			Items:  mapping.MapViewModels(items, OrderInItemToViewModel),
-		}
-		templ.Handler(orderin.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+		renderForm(map[string]string{})
 		return
 	}
 
-	if err := c.orderService.Create(r.Context(), dto); err != nil {
+	errorsMap, err := c.createOrder(r.Context(), order.CreateDTO{
+		Type:        string(order.TypeIn),
+		Status:      string(order.Pending),
+		WarehouseID: formDTO.WarehouseID,
+		LocationID:  formDTO.LocationID,
+		ProductIDs:  productIDs,
+	})
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if errorsMap != nil {
+		renderForm(errorsMap)
+		return
+	}
 
 	shared.Redirect(w, r, c.basePath)
 }
@@ -301,54 +455,116 @@ func (c *OrdersController) CreateOutOrder(w http.ResponseWriter, r *http.Request
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if errorsMap, ok := formDTO.Ok(r.Context()); !ok {
+	warehouses, locations, err := c.placementOptions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	renderForm := func(errorsMap map[string]string) {
 		props := &orderout.FormProps{
-			Errors: errorsMap,
-			Items:  mapping.MapViewModels(items, OrderOutItemToViewModel),
+			Errors:      errorsMap,
+			Items:       mapping.MapViewModels(items, OrderOutItemToViewModel),
+			Warehouses:  warehouses,
+			Locations:   locations,
+			WarehouseID: formID(formDTO.WarehouseID),
 		}
 		templ.Handler(orderout.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+	if errorsMap, ok := formDTO.Ok(r.Context()); !ok {
+		renderForm(errorsMap)
 		return
 	}
 
-	dto := order.CreateDTO{
-		Type:       string(order.TypeOut),
-		Status:     string(order.Pending),
-		ProductIDs: []uint{},
+	productIDs, hasErrors, err := c.orderProducts(
+		r.Context(), formDTO, items, product.InStock, "Errors.ERR_NOT_ENOUGH_PRODUCTS_IN_STOCK",
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-	var hasErrors bool
-	for i, item := range items {
-		quantity := int(formDTO.Quantity[item.PositionID])
-		products, err := c.orderService.FindByPositionID(r.Context(), &product.FindByPositionParams{
-			PositionID: item.PositionID,
-			SortBy:     []string{"created_at asc"},
-			Limit:      quantity,
-			Status:     product.InStock,
-		})
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if len(products) < quantity {
-			hasErrors = true
-			items[i].Error = composables.MustT(r.Context(), "Errors.ERR_NOT_ENOUGH_PRODUCTS_IN_STOCK")
-		}
-		for _, p := range products {
-			dto.ProductIDs = append(dto.ProductIDs, p.ID)
+	if hasErrors {
+		renderForm(map[string]string{})
+		return
+	}
+
+	errorsMap, err := c.createOrder(r.Context(), order.CreateDTO{
+		Type:        string(order.TypeOut),
+		Status:      string(order.Pending),
+		WarehouseID: formDTO.WarehouseID,
+		ProductIDs:  productIDs,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if errorsMap != nil {
+		renderForm(errorsMap)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *OrdersController) CreateTransferOrder(w http.ResponseWriter, r *http.Request) {
+	formDTO, err := composables.UseForm(&dtos.CreateOrderDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := c.orderItems(r.Context(), formDTO, product.InStock)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	warehouses, locations, err := c.placementOptions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	renderForm := func(errorsMap map[string]string) {
+		props := &ordertransfer.FormProps{
+			Errors:         errorsMap,
+			Items:          mapping.MapViewModels(items, OrderTransferItemToViewModel),
+			Warehouses:     warehouses,
+			Locations:      locations,
+			WarehouseID:    formID(formDTO.WarehouseID),
+			FromLocationID: formID(formDTO.FromLocationID),
+			LocationID:     formID(formDTO.LocationID),
 		}
+		templ.Handler(ordertransfer.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+	if errorsMap, ok := formDTO.Ok(r.Context()); !ok {
+		renderForm(errorsMap)
+		return
+	}
+
+	productIDs, hasErrors, err := c.orderProducts(
+		r.Context(), formDTO, items, product.InStock, "Errors.ERR_NOT_ENOUGH_PRODUCTS_IN_STOCK",
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 	if hasErrors {
-		props := &orderout.FormProps{
-			Errors: map[string]string{},
-			Items:  mapping.MapViewModels(items, OrderOutItemToViewModel),
-		}
-		templ.Handler(orderout.Form(props), templ.WithStreaming()).ServeHTTP(w, r)
+		renderForm(map[string]string{})
 		return
 	}
 
-	if err := c.orderService.Create(r.Context(), dto); err != nil {
+	errorsMap, err := c.createOrder(r.Context(), order.CreateDTO{
+		Type:        string(order.TypeTransfer),
+		Status:      string(order.Pending),
+		WarehouseID: formDTO.WarehouseID,
+		LocationID:  formDTO.LocationID,
+		ProductIDs:  productIDs,
+	})
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if errorsMap != nil {
+		renderForm(errorsMap)
+		return
+	}
 	shared.Redirect(w, r, c.basePath)
 }
 
@@ -360,10 +576,16 @@ func (c *OrdersController) orderItems(ctx context.Context, dto *dtos.CreateOrder
 
 	items := make([]OrderItem, len(positionEntities))
 	for i, position := range positionEntities {
-		inStock, err := c.productService.CountInStock(ctx, &product.CountParams{
+		params := &product.CountParams{
 			PositionID: position.ID,
 			Status:     status,
-		})
+		}
+		// products being received are not placed yet, so only stock is counted per warehouse
+		if status == product.InStock {
+			params.WarehouseID = dto.WarehouseID
+			params.LocationID = dto.FromLocationID
+		}
+		inStock, err := c.productService.CountInStock(ctx, params)
 		if err != nil {
 			return nil, err
 		}
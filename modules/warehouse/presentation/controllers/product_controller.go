@@ -9,10 +9,14 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/mappers"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/products"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/productservice"
 	"github.com/iota-uz/iota-sdk/pkg/application"
@@ -24,10 +28,14 @@ import (
 )
 
 type ProductsController struct {
-	app             application.Application
-	productService  *productservice.ProductService
-	positionService *positionservice.PositionService
-	basePath        string
+	app              application.Application
+	productService   *productservice.ProductService
+	positionService  *positionservice.PositionService
+	warehouseService *services.WarehouseService
+	locationService  *services.LocationService
+	movementService  *services.MovementService
+	userService      *coreservices.UserService
+	basePath         string
 }
 
 type PaginatedResponse struct {
@@ -37,10 +45,14 @@ type PaginatedResponse struct {
 
 func NewProductsController(app application.Application) application.Controller {
 	return &ProductsController{
-		app:             app,
-		productService:  app.Service(productservice.ProductService{}).(*productservice.ProductService),
-		positionService: app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
-		basePath:        "/warehouse/products",
+		app:              app,
+		productService:   app.Service(productservice.ProductService{}).(*productservice.ProductService),
+		positionService:  app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
+		warehouseService: app.Service(services.WarehouseService{}).(*services.WarehouseService),
+		locationService:  app.Service(services.LocationService{}).(*services.LocationService),
+		movementService:  app.Service(services.MovementService{}).(*services.MovementService),
+		userService:      app.Service(coreservices.UserService{}).(*coreservices.UserService),
+		basePath:         "/warehouse/products",
 	}
 }
 
@@ -93,7 +105,10 @@ func (c *ProductsController) getViewModelProducts(r *http.Request) (*PaginatedRe
 
 	viewProducts := mapping.MapViewModels(productEntities, mappers.ProductToViewModel)
 
-	total, err := c.productService.Count(r.Context(), &product.CountParams{})
+	total, err := c.productService.Count(r.Context(), &product.CountParams{
+		WarehouseID: params.WarehouseID,
+		LocationID:  params.LocationID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error counting products: %w", err)
 	}
@@ -104,6 +119,53 @@ func (c *ProductsController) getViewModelProducts(r *http.Request) (*PaginatedRe
 	}, nil
 }
 
+func (c *ProductsController) viewModelLocations(r *http.Request) ([]*viewmodels.Location, error) {
+	locations, err := c.locationService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving locations: %w", err)
+	}
+	return mapping.MapViewModels(locations, mappers.LocationToViewModel), nil
+}
+
+func (c *ProductsController) viewModelMovements(r *http.Request, productID uint) ([]*viewmodels.Movement, error) {
+	movements, err := c.movementService.GetPaginated(r.Context(), &movement.FindParams{
+		ProductID: productID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving movements: %w", err)
+	}
+	users, err := c.userService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving users: %w", err)
+	}
+	names := make(map[uint]string, len(users))
+	for _, u := range users {
+		names[u.ID()] = coremappers.UserToViewModel(u).FullName()
+	}
+	viewMovements := make([]*viewmodels.Movement, 0, len(movements))
+	for _, m := range movements {
+		viewMovements = append(viewMovements, mappers.MovementToViewModel(m, names))
+	}
+	return viewMovements, nil
+}
+
+func (c *ProductsController) editProps(r *http.Request, entity *product.Product, errorsMap map[string]string) (*products.EditPageProps, error) {
+	locations, err := c.viewModelLocations(r)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := c.viewModelMovements(r, entity.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &products.EditPageProps{
+		Product:   mappers.ProductToViewModel(entity),
+		Locations: locations,
+		Movements: movements,
+		Errors:    errorsMap,
+	}, nil
+}
+
 func (c *ProductsController) renderTemplate(w http.ResponseWriter, r *http.Request, template templ.Component) {
 	templ.Handler(template, templ.WithStreaming()).ServeHTTP(w, r)
 }
@@ -115,9 +177,16 @@ func (c *ProductsController) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		c.handleError(w, fmt.Errorf("error retrieving warehouses: %w", err))
+		return
+	}
+
 	isHxRequest := len(r.Header.Get("Hx-Request")) > 0
 	props := &products.IndexPageProps{
 		Products:        paginated.Products,
+		Warehouses:      mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
 		PaginationState: paginated.PaginationState,
 	}
 	var template templ.Component
@@ -142,9 +211,10 @@ func (c *ProductsController) GetEdit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	props := &products.EditPageProps{
-		Product: mappers.ProductToViewModel(entity),
-		Errors:  map[string]string{},
+	props, err := c.editProps(r, entity, map[string]string{})
+	if err != nil {
+		c.handleError(w, err)
+		return
 	}
 	c.renderTemplate(w, r, products.Edit(props))
 }
@@ -173,9 +243,10 @@ func (c *ProductsController) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if errorsMap, ok := dto.Ok(uniLocalizer); !ok {
-		props := &products.EditPageProps{
-			Product: mappers.ProductToViewModel(entity),
-			Errors:  errorsMap,
+		props, err := c.editProps(r, entity, errorsMap)
+		if err != nil {
+			c.handleError(w, err)
+			return
 		}
 		c.renderTemplate(w, r, products.EditForm(props))
 		return
@@ -191,11 +262,12 @@ func (c *ProductsController) Update(w http.ResponseWriter, r *http.Request) {
 		var vErr serrors.Base
 		if errors.As(err, &vErr) {
 			entity.Rfid = dto.Rfid
-			props := &products.EditPageProps{
-				Errors: map[string]string{
-					"Rfid": vErr.Localize(localizer),
-				},
-				Product: mappers.ProductToViewModel(entity),
+			props, err := c.editProps(r, entity, map[string]string{
+				"Rfid": vErr.Localize(localizer),
+			})
+			if err != nil {
+				c.handleError(w, err)
+				return
 			}
 			c.renderTemplate(w, r, products.EditForm(props))
 			return
@@ -207,10 +279,16 @@ func (c *ProductsController) Update(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *ProductsController) GetNew(w http.ResponseWriter, r *http.Request) {
+	locations, err := c.viewModelLocations(r)
+	if err != nil {
+		c.handleError(w, err)
+		return
+	}
 	props := &products.CreatePageProps{
-		Errors:  map[string]string{},
-		Product: mappers.ProductToViewModel(&product.Product{}),
-		SaveURL: c.basePath,
+		Errors:    map[string]string{},
+		Product:   mappers.ProductToViewModel(&product.Product{}),
+		Locations: locations,
+		SaveURL:   c.basePath,
 	}
 	c.renderTemplate(w, r, products.New(props))
 }
@@ -227,6 +305,11 @@ func (c *ProductsController) Create(w http.ResponseWriter, r *http.Request) {
 		c.handleError(w, err)
 		return
 	}
+	locations, err := c.viewModelLocations(r)
+	if err != nil {
+		c.handleError(w, err)
+		return
+	}
 	uniLocalizer, err := composables.UseUniLocalizer(r.Context())
 	if err != nil {
 		c.handleError(w, fmt.Errorf("error retrieving localizer: %w", err))
@@ -234,9 +317,10 @@ func (c *ProductsController) Create(w http.ResponseWriter, r *http.Request) {
 	}
 	if errorsMap, ok := dto.Ok(uniLocalizer); !ok {
 		props := &products.CreatePageProps{
-			Errors:  errorsMap,
-			Product: mappers.ProductToViewModel(entity),
-			SaveURL: c.basePath,
+			Errors:    errorsMap,
+			Product:   mappers.ProductToViewModel(entity),
+			Locations: locations,
+			SaveURL:   c.basePath,
 		}
 		c.renderTemplate(w, r, products.CreateForm(props))
 		return
@@ -255,8 +339,9 @@ func (c *ProductsController) Create(w http.ResponseWriter, r *http.Request) {
 				Errors: map[string]string{
 					"Rfid": vErr.Localize(localizer),
 				},
-				Product: mappers.ProductToViewModel(entity),
-				SaveURL: c.basePath,
+				Product:   mappers.ProductToViewModel(entity),
+				Locations: locations,
+				SaveURL:   c.basePath,
 			}
 			c.renderTemplate(w, r, products.CreateForm(props))
 			return
@@ -0,0 +1,504 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/purchase"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/components"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/purchases"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/purchaseservice"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type PurchaseOrdersController struct {
+	app              application.Application
+	purchaseService  *purchaseservice.PurchaseService
+	positionService  *positionservice.PositionService
+	warehouseService *services.WarehouseService
+	locationService  *services.LocationService
+	currencyService  *coreservices.CurrencyService
+	basePath         string
+}
+
+func NewPurchaseOrdersController(app application.Application) application.Controller {
+	return &PurchaseOrdersController{
+		app:              app,
+		purchaseService:  app.Service(purchaseservice.PurchaseService{}).(*purchaseservice.PurchaseService),
+		positionService:  app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
+		warehouseService: app.Service(services.WarehouseService{}).(*services.WarehouseService),
+		locationService:  app.Service(services.LocationService{}).(*services.LocationService),
+		currencyService:  app.Service(coreservices.CurrencyService{}).(*coreservices.CurrencyService),
+		basePath:         "/warehouse/purchase-orders",
+	}
+}
+
+func (c *PurchaseOrdersController) Key() string {
+	return c.basePath
+}
+
+func (c *PurchaseOrdersController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.Show).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/receive", c.Receive).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/bill", c.LinkBill).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/cancel", c.Cancel).Methods(http.MethodPost)
+}
+
+func (c *PurchaseOrdersController) renderTemplate(w http.ResponseWriter, r *http.Request, template templ.Component) {
+	templ.Handler(template, templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *PurchaseOrdersController) warehouseNames(r *http.Request) (map[string]string, error) {
+	entities, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving warehouses: %w", err)
+	}
+	names := make(map[string]string, len(entities))
+	for _, e := range entities {
+		names[idValue(e.ID)] = e.Name
+	}
+	return names, nil
+}
+
+func (c *PurchaseOrdersController) List(w http.ResponseWriter, r *http.Request) {
+	paginationParams := composables.UsePaginated(r)
+	params := &purchase.FindParams{
+		Limit:  paginationParams.Limit,
+		Offset: paginationParams.Offset,
+	}
+	if status, err := purchase.NewStatus(r.URL.Query().Get("Status")); err == nil {
+		params.Status = status
+	}
+	entities, err := c.purchaseService.GetPaginated(r.Context(), params)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving purchase orders: %v", err), http.StatusInternalServerError)
+		return
+	}
+	total, err := c.purchaseService.Count(r.Context(), params)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error counting purchase orders: %v", err), http.StatusInternalServerError)
+		return
+	}
+	warehouseNames, err := c.warehouseNames(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	orders := mapping.MapViewModels(entities, mappers.PurchaseOrderToViewModel)
+	for _, o := range orders {
+		o.Warehouse = warehouseNames[o.WarehouseID]
+	}
+	props := &purchases.IndexPageProps{
+		PurchaseOrders:  orders,
+		Statuses:        []string{string(purchase.Open), string(purchase.PartiallyReceived), string(purchase.Received), string(purchase.Cancelled)},
+		Status:          string(params.Status),
+		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
+	}
+	if len(r.Header.Get("Hx-Request")) > 0 {
+		c.renderTemplate(w, r, purchases.PurchaseOrdersTable(props))
+	} else {
+		c.renderTemplate(w, r, purchases.Index(props))
+	}
+}
+
+func (c *PurchaseOrdersController) createProps(
+	r *http.Request,
+	dto *purchase.CreateDTO,
+	errorsMap map[string]string,
+) (*purchases.CreatePageProps, error) {
+	positions, err := c.positionService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving positions: %w", err)
+	}
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving warehouses: %w", err)
+	}
+	currencies, err := c.currencyService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving currencies: %w", err)
+	}
+	lines := make([]*components.LineForm, 0, len(dto.Lines))
+	for _, line := range dto.Lines {
+		if line == nil {
+			continue
+		}
+		lines = append(lines, &components.LineForm{
+			PositionID: idValue(line.PositionID),
+			Quantity:   line.Quantity,
+			UnitPrice:  line.UnitPrice,
+		})
+	}
+	return &purchases.CreatePageProps{
+		WarehouseID: idValue(dto.WarehouseID),
+		Currency:    dto.Currency,
+		Comment:     dto.Comment,
+		Lines:       lines,
+		Positions:   mapping.MapViewModels(positions, mappers.PositionToViewModel),
+		Warehouses:  mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
+		Currencies:  mapping.MapViewModels(currencies, coremappers.CurrencyToViewModel),
+		Errors:      errorsMap,
+		SaveURL:     c.basePath,
+	}, nil
+}
+
+func (c *PurchaseOrdersController) GetNew(w http.ResponseWriter, r *http.Request) {
+	props, err := c.createProps(r, &purchase.CreateDTO{}, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, purchases.New(props))
+}
+
+func (c *PurchaseOrdersController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&purchase.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		entity, err := c.purchaseService.Create(r.Context(), dto)
+		if err == nil {
+			shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, entity.ID))
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		var vErr serrors.Base
+		switch {
+		case errors.Is(err, purchase.ErrDuplicatePosition):
+			errorsMap = map[string]string{"_lines": localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "PurchaseOrders.Lines.DuplicatePosition",
+			})}
+		case errors.As(err, &vErr):
+			errorsMap = map[string]string{"_form": vErr.Localize(localizer)}
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	props, err := c.createProps(r, dto, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, purchases.CreateForm(props))
+}
+
+func (c *PurchaseOrdersController) receiptProps(
+	r *http.Request,
+	entity *purchase.Order,
+	form purchases.ReceiptForm,
+	errorsMap map[string]string,
+) (*purchases.ReceiptProps, error) {
+	locations, err := c.locationService.GetByWarehouseID(r.Context(), entity.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving locations: %w", err)
+	}
+	vm := mappers.PurchaseOrderToViewModel(entity)
+	lines := make([]*viewmodels.PurchaseOrderLine, 0, len(vm.Lines))
+	for _, line := range vm.Lines {
+		if line.Outstanding > 0 {
+			lines = append(lines, line)
+		}
+	}
+	if form.Tags == nil {
+		form.Tags = map[string]string{}
+	}
+	return &purchases.ReceiptProps{
+		ReceiveURL: fmt.Sprintf("%s/%d/receive", c.basePath, entity.ID),
+		Lines:      lines,
+		Locations:  mapping.MapViewModels(locations, mappers.LocationToViewModel),
+		Form:       form,
+		Errors:     errorsMap,
+	}, nil
+}
+
+func (c *PurchaseOrdersController) billProps(
+	r *http.Request,
+	entity *purchase.Order,
+	errorsMap map[string]string,
+) (*purchases.BillProps, error) {
+	bills, err := c.purchaseService.Bills(r.Context(), entity)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving supplier bills: %w", err)
+	}
+	return &purchases.BillProps{
+		LinkBillURL: fmt.Sprintf("%s/%d/bill", c.basePath, entity.ID),
+		Bills:       mapping.MapViewModels(bills, mappers.SupplierBillToViewModel),
+		BillID:      idValue(entity.BillID),
+		Errors:      errorsMap,
+	}, nil
+}
+
+func (c *PurchaseOrdersController) showProps(
+	r *http.Request,
+	entity *purchase.Order,
+	errorsMap map[string]string,
+) (*purchases.ShowPageProps, error) {
+	vm := mappers.PurchaseOrderToViewModel(entity)
+	wh, err := c.warehouseService.GetByID(r.Context(), entity.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving warehouse: %w", err)
+	}
+	vm.Warehouse = wh.Name
+	locations, err := c.locationService.GetByWarehouseID(r.Context(), entity.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving locations: %w", err)
+	}
+	paths := make(map[string]string, len(locations))
+	for _, l := range locations {
+		paths[idValue(l.ID)] = l.Path
+	}
+	for _, receipt := range vm.Receipts {
+		receipt.Location = paths[receipt.LocationID]
+	}
+
+	props := &purchases.ShowPageProps{
+		PurchaseOrder: vm,
+		Match:         mappers.PurchaseMatchToViewModel(entity.Match()),
+		Errors:        errorsMap,
+	}
+	if composables.CanUser(r.Context(), permissions.PurchaseOrderUpdate) == nil {
+		if !entity.IsClosed() {
+			if props.Receipt, err = c.receiptProps(r, entity, purchases.ReceiptForm{}, map[string]string{}); err != nil {
+				return nil, err
+			}
+		}
+		if entity.Status == purchase.Open {
+			props.CancelURL = fmt.Sprintf("%s/%d/cancel", c.basePath, entity.ID)
+		}
+		if entity.Status != purchase.Cancelled {
+			if props.Bill, err = c.billProps(r, entity, map[string]string{}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(entity.Receipts) == 0 && composables.CanUser(r.Context(), permissions.PurchaseOrderDelete) == nil {
+		props.DeleteURL = fmt.Sprintf("%s/%d", c.basePath, entity.ID)
+	}
+	return props, nil
+}
+
+func (c *PurchaseOrdersController) Show(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.purchaseService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving purchase order: %v", err), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, purchases.Show(props))
+}
+
+// renderContentError shows the purchase order again with the localized error of a failed action.
+func (c *PurchaseOrdersController) renderContentError(w http.ResponseWriter, r *http.Request, id uint, err error) {
+	var vErr serrors.Base
+	if !errors.As(err, &vErr) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	localizer, ok := composables.UseLocalizer(r.Context())
+	if !ok {
+		http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+		return
+	}
+	entity, err := c.purchaseService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving purchase order: %v", err), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, map[string]string{"_form": vErr.Localize(localizer)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, purchases.ShowContent(props))
+}
+
+func (c *PurchaseOrdersController) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.purchaseService.Cancel(r.Context(), id); err != nil {
+		c.renderContentError(w, r, id, err)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+}
+
+func (c *PurchaseOrdersController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.purchaseService.Delete(r.Context(), id); err != nil {
+		c.renderContentError(w, r, id, err)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *PurchaseOrdersController) Receive(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&purchase.ReceiveDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		_, err = c.purchaseService.Receive(r.Context(), id, dto)
+		if err == nil {
+			shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+			return
+		}
+		var vErr serrors.Base
+		if !errors.As(err, &vErr) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		errorsMap = map[string]string{"_form": vErr.Localize(localizer)}
+	}
+	entity, err := c.purchaseService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving purchase order: %v", err), http.StatusInternalServerError)
+		return
+	}
+	form := purchases.ReceiptForm{
+		LocationID: idValue(dto.LocationID),
+		Tags:       make(map[string]string, len(dto.Lines)),
+	}
+	for _, line := range dto.Lines {
+		if line != nil {
+			form.Tags[idValue(line.LineID)] = line.Tags
+		}
+	}
+	props, err := c.receiptProps(r, entity, form, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, purchases.Receipt(props))
+}
+
+func (c *PurchaseOrdersController) LinkBill(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&purchase.LinkBillDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		_, err = c.purchaseService.LinkBill(r.Context(), id, dto)
+		if err == nil {
+			shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+			return
+		}
+		var vErr serrors.Base
+		if !errors.As(err, &vErr) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		errorsMap = map[string]string{"_form": vErr.Localize(localizer)}
+	}
+	entity, err := c.purchaseService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving purchase order: %v", err), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.billProps(r, entity, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, purchases.Bill(props))
+}
@@ -0,0 +1,402 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/components"
+	salestemplates "github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/sales"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/salesservice"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type SalesOrdersController struct {
+	app              application.Application
+	salesService     *salesservice.SalesService
+	positionService  *positionservice.PositionService
+	warehouseService *services.WarehouseService
+	locationService  *services.LocationService
+	basePath         string
+}
+
+func NewSalesOrdersController(app application.Application) application.Controller {
+	return &SalesOrdersController{
+		app:              app,
+		salesService:     app.Service(salesservice.SalesService{}).(*salesservice.SalesService),
+		positionService:  app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
+		warehouseService: app.Service(services.WarehouseService{}).(*services.WarehouseService),
+		locationService:  app.Service(services.LocationService{}).(*services.LocationService),
+		basePath:         "/warehouse/sales-orders",
+	}
+}
+
+func (c *SalesOrdersController) Key() string {
+	return c.basePath
+}
+
+func (c *SalesOrdersController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.Show).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/pick", c.Pick).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/ship", c.Ship).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/cancel", c.Cancel).Methods(http.MethodPost)
+}
+
+func (c *SalesOrdersController) renderTemplate(w http.ResponseWriter, r *http.Request, template templ.Component) {
+	templ.Handler(template, templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *SalesOrdersController) List(w http.ResponseWriter, r *http.Request) {
+	paginationParams := composables.UsePaginated(r)
+	params := &sales.FindParams{
+		Limit:  paginationParams.Limit,
+		Offset: paginationParams.Offset,
+	}
+	if status, err := sales.NewStatus(r.URL.Query().Get("Status")); err == nil {
+		params.Status = status
+	}
+	entities, err := c.salesService.GetPaginated(r.Context(), params)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving sales orders: %v", err), http.StatusInternalServerError)
+		return
+	}
+	total, err := c.salesService.Count(r.Context(), params)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error counting sales orders: %v", err), http.StatusInternalServerError)
+		return
+	}
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving warehouses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	warehouseNames := make(map[string]string, len(warehouses))
+	for _, e := range warehouses {
+		warehouseNames[idValue(e.ID)] = e.Name
+	}
+	orders := mapping.MapViewModels(entities, mappers.SalesOrderToViewModel)
+	for _, o := range orders {
+		o.Warehouse = warehouseNames[o.WarehouseID]
+	}
+	props := &salestemplates.IndexPageProps{
+		SalesOrders:     orders,
+		Statuses:        []string{string(sales.Reserved), string(sales.Picking), string(sales.Shipped), string(sales.Cancelled)},
+		Status:          string(params.Status),
+		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
+	}
+	if len(r.Header.Get("Hx-Request")) > 0 {
+		c.renderTemplate(w, r, salestemplates.SalesOrdersTable(props))
+	} else {
+		c.renderTemplate(w, r, salestemplates.Index(props))
+	}
+}
+
+func (c *SalesOrdersController) createProps(
+	r *http.Request,
+	dto *sales.CreateDTO,
+	errorsMap map[string]string,
+) (*salestemplates.CreatePageProps, error) {
+	positions, err := c.positionService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving positions: %w", err)
+	}
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving warehouses: %w", err)
+	}
+	lines := make([]*components.LineForm, 0, len(dto.Lines))
+	for _, line := range dto.Lines {
+		if line == nil {
+			continue
+		}
+		lines = append(lines, &components.LineForm{
+			PositionID: idValue(line.PositionID),
+			Quantity:   line.Quantity,
+		})
+	}
+	return &salestemplates.CreatePageProps{
+		WarehouseID: idValue(dto.WarehouseID),
+		Customer:    dto.Customer,
+		Comment:     dto.Comment,
+		Lines:       lines,
+		Positions:   mapping.MapViewModels(positions, mappers.PositionToViewModel),
+		Warehouses:  mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
+		Errors:      errorsMap,
+		SaveURL:     c.basePath,
+	}, nil
+}
+
+func (c *SalesOrdersController) GetNew(w http.ResponseWriter, r *http.Request) {
+	props, err := c.createProps(r, &sales.CreateDTO{}, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, salestemplates.New(props))
+}
+
+func (c *SalesOrdersController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&sales.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		entity, err := c.salesService.Create(r.Context(), dto)
+		if err == nil {
+			shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, entity.ID))
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		var vErr serrors.Base
+		switch {
+		case errors.Is(err, sales.ErrDuplicatePosition):
+			errorsMap = map[string]string{"_lines": localizer.MustLocalize(&i18n.LocalizeConfig{
+				MessageID: "SalesOrders.Lines.DuplicatePosition",
+			})}
+		case errors.As(err, &vErr):
+			errorsMap = map[string]string{"_form": vErr.Localize(localizer)}
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	props, err := c.createProps(r, dto, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, salestemplates.CreateForm(props))
+}
+
+func (c *SalesOrdersController) showProps(
+	r *http.Request,
+	entity *sales.Order,
+	errorsMap map[string]string,
+) (*salestemplates.ShowPageProps, error) {
+	vm := mappers.SalesOrderToViewModel(entity)
+	wh, err := c.warehouseService.GetByID(r.Context(), entity.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving warehouse: %w", err)
+	}
+	vm.Warehouse = wh.Name
+	locations, err := c.locationService.GetByWarehouseID(r.Context(), entity.WarehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving locations: %w", err)
+	}
+	paths := make(map[string]string, len(locations))
+	for _, l := range locations {
+		paths[idValue(l.ID)] = l.Path
+	}
+	for _, pick := range vm.Picks {
+		pick.Location = paths[pick.LocationID]
+	}
+
+	props := &salestemplates.ShowPageProps{
+		SalesOrder: vm,
+		Errors:     errorsMap,
+	}
+	if !entity.IsClosed() {
+		list, err := c.salesService.PickingList(r.Context(), entity)
+		if err != nil {
+			return nil, fmt.Errorf("error building picking list: %w", err)
+		}
+		props.PickingList = mappers.PickingListToViewModel(list)
+		if composables.CanUser(r.Context(), permissions.SalesOrderUpdate) == nil {
+			props.Pick = &salestemplates.PickProps{
+				PickURL: fmt.Sprintf("%s/%d/pick", c.basePath, entity.ID),
+				Errors:  map[string]string{},
+			}
+			props.CancelURL = fmt.Sprintf("%s/%d/cancel", c.basePath, entity.ID)
+			if entity.ReadyToShip() == nil {
+				props.ShipURL = fmt.Sprintf("%s/%d/ship", c.basePath, entity.ID)
+			}
+		}
+	}
+	if entity.Status != sales.Shipped && composables.CanUser(r.Context(), permissions.SalesOrderDelete) == nil {
+		props.DeleteURL = fmt.Sprintf("%s/%d", c.basePath, entity.ID)
+	}
+	return props, nil
+}
+
+func (c *SalesOrdersController) Show(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.salesService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving sales order: %v", err), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, salestemplates.Show(props))
+}
+
+// renderContentError shows the sales order again with the localized error of a failed action.
+func (c *SalesOrdersController) renderContentError(w http.ResponseWriter, r *http.Request, id uint, err error) {
+	var vErr serrors.Base
+	if !errors.As(err, &vErr) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	localizer, ok := composables.UseLocalizer(r.Context())
+	if !ok {
+		http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+		return
+	}
+	entity, err := c.salesService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving sales order: %v", err), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, map[string]string{"_form": vErr.Localize(localizer)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, salestemplates.ShowContent(props))
+}
+
+// Pick confirms a scanned code and renders the order again, keeping the scan field focused for the next one.
+func (c *SalesOrdersController) Pick(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&sales.PickDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	code := dto.Code
+	if ok {
+		_, err = c.salesService.Pick(r.Context(), id, dto)
+		if err == nil {
+			code = ""
+		} else {
+			var vErr serrors.Base
+			if !errors.As(err, &vErr) {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			localizer, ok := composables.UseLocalizer(r.Context())
+			if !ok {
+				http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+				return
+			}
+			errorsMap = map[string]string{"_form": vErr.Localize(localizer)}
+		}
+	}
+	entity, err := c.salesService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving sales order: %v", err), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.showProps(r, entity, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if props.Pick != nil {
+		props.Pick.Code = code
+		props.Pick.Errors = errorsMap
+	}
+	c.renderTemplate(w, r, salestemplates.ShowContent(props))
+}
+
+func (c *SalesOrdersController) Ship(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.salesService.Ship(r.Context(), id); err != nil {
+		c.renderContentError(w, r, id, err)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+}
+
+func (c *SalesOrdersController) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.salesService.Cancel(r.Context(), id); err != nil {
+		c.renderContentError(w, r, id, err)
+		return
+	}
+	shared.Redirect(w, r, fmt.Sprintf("%s/%d", c.basePath, id))
+}
+
+func (c *SalesOrdersController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.salesService.Delete(r.Context(), id); err != nil {
+		c.renderContentError(w, r, id, err)
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
@@ -0,0 +1,319 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	coremappers "github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/mappers"
+	stocktemplates "github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/stock"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type StockController struct {
+	app              application.Application
+	stockService     *services.StockService
+	positionService  *positionservice.PositionService
+	warehouseService *services.WarehouseService
+	locationService  *services.LocationService
+	userService      *coreservices.UserService
+	basePath         string
+}
+
+type StockLevelsQuery struct {
+	At          shared.DateOnly
+	WarehouseID uint
+}
+
+type StockReportQuery struct {
+	From        shared.DateOnly
+	To          shared.DateOnly
+	WarehouseID uint
+}
+
+func NewStockController(app application.Application) application.Controller {
+	return &StockController{
+		app:              app,
+		stockService:     app.Service(services.StockService{}).(*services.StockService),
+		positionService:  app.Service(positionservice.PositionService{}).(*positionservice.PositionService),
+		warehouseService: app.Service(services.WarehouseService{}).(*services.WarehouseService),
+		locationService:  app.Service(services.LocationService{}).(*services.LocationService),
+		userService:      app.Service(coreservices.UserService{}).(*coreservices.UserService),
+		basePath:         "/warehouse/stock",
+	}
+}
+
+func (c *StockController) Key() string {
+	return c.basePath
+}
+
+func (c *StockController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/positions/{id:[0-9]+}", c.GetReport).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("/positions/{id:[0-9]+}/corrections", c.Correct).Methods(http.MethodPost)
+}
+
+func (c *StockController) renderTemplate(w http.ResponseWriter, r *http.Request, template templ.Component) {
+	templ.Handler(template, templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// endOfDay turns a date picked in a filter into the exclusive bound right after it.
+func endOfDay(d shared.DateOnly) time.Time {
+	t := time.Time(d)
+	if t.IsZero() {
+		return t
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+func dateValue(d shared.DateOnly) string {
+	if t := time.Time(d); !t.IsZero() {
+		return t.Format(time.DateOnly)
+	}
+	return ""
+}
+
+func idValue(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func (c *StockController) names(r *http.Request) (*mappers.StockNames, error) {
+	positions, err := c.positionService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving positions: %w", err)
+	}
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving warehouses: %w", err)
+	}
+	locations, err := c.locationService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving locations: %w", err)
+	}
+	users, err := c.userService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving users: %w", err)
+	}
+	names := &mappers.StockNames{
+		Positions:  make(map[uint]string, len(positions)),
+		Warehouses: make(map[uint]string, len(warehouses)),
+		Locations:  make(map[uint]string, len(locations)),
+		Users:      make(map[uint]string, len(users)),
+	}
+	for _, p := range positions {
+		names.Positions[p.ID] = p.Title
+	}
+	for _, w := range warehouses {
+		names.Warehouses[w.ID] = w.Name
+	}
+	for _, l := range locations {
+		names.Locations[l.ID] = l.Path
+	}
+	for _, u := range users {
+		names.Users[u.ID()] = coremappers.UserToViewModel(u).FullName()
+	}
+	return names, nil
+}
+
+func (c *StockController) List(w http.ResponseWriter, r *http.Request) {
+	query, err := composables.UseQuery(&StockLevelsQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	levels, err := c.stockService.Levels(r.Context(), &stock.LevelParams{
+		At:          endOfDay(query.At),
+		WarehouseID: query.WarehouseID,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving stock levels: %v", err), http.StatusInternalServerError)
+		return
+	}
+	names, err := c.names(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving warehouses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	viewLevels := make([]*viewmodels.StockLevel, 0, len(levels))
+	for _, level := range levels {
+		viewLevels = append(viewLevels, mappers.StockLevelToViewModel(level, names))
+	}
+	props := &stocktemplates.IndexPageProps{
+		Levels:      viewLevels,
+		Warehouses:  mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
+		At:          dateValue(query.At),
+		WarehouseID: idValue(query.WarehouseID),
+	}
+	if len(r.Header.Get("Hx-Request")) > 0 {
+		c.renderTemplate(w, r, stocktemplates.LevelsTable(props))
+	} else {
+		c.renderTemplate(w, r, stocktemplates.Index(props))
+	}
+}
+
+func (c *StockController) correctionProps(
+	r *http.Request,
+	positionID uint,
+	form stocktemplates.CorrectionForm,
+	errorsMap map[string]string,
+) (*stocktemplates.CorrectionProps, error) {
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving warehouses: %w", err)
+	}
+	locations, err := c.locationService.GetAll(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving locations: %w", err)
+	}
+	return &stocktemplates.CorrectionProps{
+		CorrectionURL: fmt.Sprintf("%s/positions/%d/corrections", c.basePath, positionID),
+		Warehouses:    mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
+		Locations:     mapping.MapViewModels(locations, mappers.LocationToViewModel),
+		Form:          form,
+		Errors:        errorsMap,
+	}, nil
+}
+
+func (c *StockController) GetReport(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query, err := composables.UseQuery(&StockReportQuery{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := c.stockService.Report(r.Context(), &stock.FindParams{
+		PositionID:  id,
+		WarehouseID: query.WarehouseID,
+		From:        time.Time(query.From),
+		To:          endOfDay(query.To),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving stock report: %v", err), http.StatusInternalServerError)
+		return
+	}
+	names, err := c.names(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	warehouses, err := c.warehouseService.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving warehouses: %v", err), http.StatusInternalServerError)
+		return
+	}
+	props := &stocktemplates.ReportPageProps{
+		ReportURL:   fmt.Sprintf("%s/positions/%d", c.basePath, id),
+		Report:      mappers.StockReportToViewModel(report, names),
+		Warehouses:  mapping.MapViewModels(warehouses, mappers.WarehouseToViewModel),
+		From:        dateValue(query.From),
+		To:          dateValue(query.To),
+		WarehouseID: idValue(query.WarehouseID),
+	}
+	if len(r.Header.Get("Hx-Request")) > 0 {
+		c.renderTemplate(w, r, stocktemplates.ReportTable(props))
+		return
+	}
+	if composables.CanUser(r.Context(), permissions.StockUpdate) == nil {
+		props.Correction, err = c.correctionProps(r, id, stocktemplates.CorrectionForm{}, map[string]string{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	c.renderTemplate(w, r, stocktemplates.Report(props))
+}
+
+func (c *StockController) Correct(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&stock.CorrectionDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto.PositionID = id
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		_, err = c.stockService.Correct(r.Context(), dto)
+		if err == nil {
+			shared.Redirect(w, r, fmt.Sprintf("%s/positions/%d", c.basePath, id))
+			return
+		}
+		var vErr serrors.Base
+		if !errors.As(err, &vErr) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		errorsMap = map[string]string{"Quantity": vErr.Localize(localizer)}
+	}
+	form := stocktemplates.CorrectionForm{
+		WarehouseID: idValue(dto.WarehouseID),
+		LocationID:  idValue(dto.LocationID),
+		Quantity:    strconv.Itoa(dto.Quantity),
+		Comment:     dto.Comment,
+	}
+	props, err := c.correctionProps(r, id, form, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, stocktemplates.Correction(props))
+}
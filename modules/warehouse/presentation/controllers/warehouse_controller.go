@@ -0,0 +1,400 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/components/base/pagination"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/templates/pages/warehouses"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type WarehousesController struct {
+	app              application.Application
+	warehouseService *services.WarehouseService
+	locationService  *services.LocationService
+	basePath         string
+}
+
+type WarehousePaginatedResponse struct {
+	Warehouses      []*viewmodels.Warehouse
+	PaginationState *pagination.State
+}
+
+func NewWarehousesController(app application.Application) application.Controller {
+	return &WarehousesController{
+		app:              app,
+		warehouseService: app.Service(services.WarehouseService{}).(*services.WarehouseService),
+		locationService:  app.Service(services.LocationService{}).(*services.LocationService),
+		basePath:         "/warehouse/warehouses",
+	}
+}
+
+func (c *WarehousesController) Key() string {
+	return c.basePath
+}
+
+func (c *WarehousesController) Register(r *mux.Router) {
+	commonMiddleware := []mux.MiddlewareFunc{
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.Tabs(),
+		middleware.WithLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	}
+
+	getRouter := r.PathPrefix(c.basePath).Subrouter()
+	getRouter.Use(commonMiddleware...)
+	getRouter.HandleFunc("", c.List).Methods(http.MethodGet)
+	getRouter.HandleFunc("/new", c.GetNew).Methods(http.MethodGet)
+	getRouter.HandleFunc("/{id:[0-9]+}", c.GetEdit).Methods(http.MethodGet)
+
+	setRouter := r.PathPrefix(c.basePath).Subrouter()
+	setRouter.Use(commonMiddleware...)
+	setRouter.Use(middleware.WithTransaction())
+	setRouter.HandleFunc("", c.Create).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}", c.Delete).Methods(http.MethodDelete)
+	setRouter.HandleFunc("/{id:[0-9]+}/locations", c.CreateLocation).Methods(http.MethodPost)
+	setRouter.HandleFunc("/{id:[0-9]+}/locations/{locationId:[0-9]+}", c.DeleteLocation).Methods(http.MethodDelete)
+}
+
+func (c *WarehousesController) viewModelWarehouses(r *http.Request) (*WarehousePaginatedResponse, error) {
+	paginationParams := composables.UsePaginated(r)
+	params, err := composables.UseQuery(&warehouse.FindParams{
+		Limit:  paginationParams.Limit,
+		Offset: paginationParams.Offset,
+		SortBy: []string{"code"},
+	}, r)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving query: %w", err)
+	}
+	entities, err := c.warehouseService.GetPaginated(r.Context(), params)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving warehouses: %w", err)
+	}
+	total, err := c.warehouseService.Count(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error counting warehouses: %w", err)
+	}
+	return &WarehousePaginatedResponse{
+		Warehouses:      mapping.MapViewModels(entities, mappers.WarehouseToViewModel),
+		PaginationState: pagination.New(c.basePath, paginationParams.Page, int(total), params.Limit),
+	}, nil
+}
+
+func (c *WarehousesController) locationsProps(
+	r *http.Request,
+	warehouseID uint,
+	form warehouses.LocationForm,
+	errorsMap map[string]string,
+) (*warehouses.LocationsProps, error) {
+	entities, err := c.locationService.GetByWarehouseID(r.Context(), warehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving locations: %w", err)
+	}
+	kinds := make([]string, 0, len(location.Kinds))
+	for _, k := range location.Kinds {
+		kinds = append(kinds, string(k))
+	}
+	return &warehouses.LocationsProps{
+		LocationsURL: fmt.Sprintf("%s/%d/locations", c.basePath, warehouseID),
+		Locations:    mapping.MapViewModels(entities, mappers.LocationToViewModel),
+		Kinds:        kinds,
+		Form:         form,
+		Errors:       errorsMap,
+	}, nil
+}
+
+func (c *WarehousesController) editProps(
+	r *http.Request,
+	entity *warehouse.Warehouse,
+	errorsMap map[string]string,
+) (*warehouses.EditPageProps, error) {
+	locations, err := c.locationsProps(r, entity.ID, warehouses.LocationForm{}, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	return &warehouses.EditPageProps{
+		Warehouse: mappers.WarehouseToViewModel(entity),
+		Locations: locations,
+		Errors:    errorsMap,
+		SaveURL:   fmt.Sprintf("%s/%d", c.basePath, entity.ID),
+		DeleteURL: fmt.Sprintf("%s/%d", c.basePath, entity.ID),
+	}, nil
+}
+
+func (c *WarehousesController) renderTemplate(w http.ResponseWriter, r *http.Request, template templ.Component) {
+	templ.Handler(template, templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *WarehousesController) List(w http.ResponseWriter, r *http.Request) {
+	paginated, err := c.viewModelWarehouses(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	isHxRequest := len(r.Header.Get("Hx-Request")) > 0
+	props := &warehouses.IndexPageProps{
+		Warehouses:      paginated.Warehouses,
+		PaginationState: paginated.PaginationState,
+	}
+	if isHxRequest {
+		c.renderTemplate(w, r, warehouses.WarehousesTable(props))
+	} else {
+		c.renderTemplate(w, r, warehouses.Index(props))
+	}
+}
+
+func (c *WarehousesController) GetEdit(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	entity, err := c.warehouseService.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error retrieving warehouse: %v", err), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.editProps(r, entity, map[string]string{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, warehouses.Edit(props))
+}
+
+func (c *WarehousesController) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.warehouseService.Delete(r.Context(), id); err != nil {
+		var vErr serrors.Base
+		if !errors.As(err, &vErr) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		entity, err := c.warehouseService.GetByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error retrieving warehouse: %v", err), http.StatusInternalServerError)
+			return
+		}
+		props, err := c.editProps(r, entity, map[string]string{"_form": vErr.Localize(localizer)})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		c.renderTemplate(w, r, warehouses.EditForm(props))
+		return
+	}
+	shared.Redirect(w, r, c.basePath)
+}
+
+func (c *WarehousesController) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&warehouse.UpdateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		err = c.warehouseService.Update(r.Context(), id, dto)
+		if err == nil {
+			shared.Redirect(w, r, c.basePath)
+			return
+		}
+		var vErr serrors.Base
+		if !errors.As(err, &vErr) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		errorsMap = map[string]string{"Code": vErr.Localize(localizer)}
+	}
+	entity, err := dto.ToEntity(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props, err := c.editProps(r, entity, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, warehouses.EditForm(props))
+}
+
+func (c *WarehousesController) GetNew(w http.ResponseWriter, r *http.Request) {
+	props := &warehouses.CreatePageProps{
+		Errors:    map[string]string{},
+		Warehouse: mappers.WarehouseToViewModel(&warehouse.Warehouse{}),
+		SaveURL:   c.basePath,
+	}
+	c.renderTemplate(w, r, warehouses.New(props))
+}
+
+func (c *WarehousesController) Create(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&warehouse.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		_, err = c.warehouseService.Create(r.Context(), dto)
+		if err == nil {
+			shared.Redirect(w, r, c.basePath)
+			return
+		}
+		var vErr serrors.Base
+		if !errors.As(err, &vErr) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		errorsMap = map[string]string{"Code": vErr.Localize(localizer)}
+	}
+	entity, err := dto.ToEntity()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	props := &warehouses.CreatePageProps{
+		Errors:    errorsMap,
+		Warehouse: mappers.WarehouseToViewModel(entity),
+		SaveURL:   c.basePath,
+	}
+	c.renderTemplate(w, r, warehouses.CreateForm(props))
+}
+
+func (c *WarehousesController) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dto, err := composables.UseForm(&location.CreateDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	uniTranslator, err := composables.UseUniLocalizer(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	form := warehouses.LocationForm{
+		Kind: dto.Kind,
+		Code: dto.Code,
+		Name: dto.Name,
+	}
+	if dto.ParentID != 0 {
+		form.ParentID = strconv.FormatUint(uint64(dto.ParentID), 10)
+	}
+	errorsMap, ok := dto.Ok(uniTranslator)
+	if ok {
+		_, err = c.locationService.Create(r.Context(), id, dto)
+		if err == nil {
+			form, errorsMap = warehouses.LocationForm{Kind: dto.Kind}, map[string]string{}
+		} else {
+			var vErr serrors.Base
+			if !errors.As(err, &vErr) {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			localizer, ok := composables.UseLocalizer(r.Context())
+			if !ok {
+				http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+				return
+			}
+			errorsMap = map[string]string{"_form": vErr.Localize(localizer)}
+		}
+	}
+	props, err := c.locationsProps(r, id, form, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, warehouses.Locations(props))
+}
+
+func (c *WarehousesController) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	locationID, err := strconv.ParseUint(mux.Vars(r)["locationId"], 10, 32)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	errorsMap := map[string]string{}
+	if _, err := c.locationService.Delete(r.Context(), id, uint(locationID)); err != nil {
+		var vErr serrors.Base
+		if !errors.As(err, &vErr) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		localizer, ok := composables.UseLocalizer(r.Context())
+		if !ok {
+			http.Error(w, "error retrieving localizer", http.StatusInternalServerError)
+			return
+		}
+		errorsMap["_form"] = vErr.Localize(localizer)
+	}
+	props, err := c.locationsProps(r, id, warehouses.LocationForm{}, errorsMap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.renderTemplate(w, r, warehouses.Locations(props))
+}
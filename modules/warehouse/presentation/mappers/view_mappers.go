@@ -1,6 +1,7 @@
 package mappers
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -9,8 +10,14 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/purchase"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/inventory"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/unit"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/viewmodels"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 )
@@ -20,12 +27,18 @@ func ProductToViewModel(entity *product.Product) *viewmodels.Product {
 	if entity.Position != nil {
 		pos = PositionToViewModel(entity.Position)
 	}
+	var loc *viewmodels.Location
+	if entity.Location != nil {
+		loc = LocationToViewModel(entity.Location)
+	}
 	return &viewmodels.Product{
 		ID:         strconv.FormatUint(uint64(entity.ID), 10),
 		Status:     string(entity.Status),
 		Rfid:       entity.Rfid,
 		PositionID: strconv.FormatUint(uint64(entity.PositionID), 10),
 		Position:   pos,
+		LocationID: strconv.FormatUint(uint64(entity.LocationID), 10),
+		Location:   loc,
 		CreatedAt:  entity.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:  entity.UpdatedAt.Format(time.RFC3339),
 	}
@@ -70,9 +83,11 @@ func OrderItemToViewModel(entity order.Item, inStock int) viewmodels.OrderItem {
 
 func OrderToViewModel(entity order.Order, inStockByPosition map[uint]int) *viewmodels.Order {
 	return &viewmodels.Order{
-		ID:     strconv.FormatUint(uint64(entity.ID()), 10),
-		Type:   string(entity.Type()),
-		Status: string(entity.Status()),
+		ID:          strconv.FormatUint(uint64(entity.ID()), 10),
+		Type:        string(entity.Type()),
+		Status:      string(entity.Status()),
+		WarehouseID: strconv.FormatUint(uint64(entity.WarehouseID()), 10),
+		LocationID:  strconv.FormatUint(uint64(entity.LocationID()), 10),
 		Items: mapping.MapViewModels(entity.Items(), func(e order.Item) viewmodels.OrderItem {
 			return OrderItemToViewModel(e, inStockByPosition[e.Position().ID])
 		}),
@@ -80,15 +95,121 @@ func OrderToViewModel(entity order.Order, inStockByPosition map[uint]int) *viewm
 	}
 }
 
+func WarehouseToViewModel(entity *warehouse.Warehouse) *viewmodels.Warehouse {
+	return &viewmodels.Warehouse{
+		ID:        strconv.FormatUint(uint64(entity.ID), 10),
+		Name:      entity.Name,
+		Code:      entity.Code,
+		Address:   entity.Address,
+		CreatedAt: entity.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: entity.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func LocationToViewModel(entity *location.Location) *viewmodels.Location {
+	return &viewmodels.Location{
+		ID:          strconv.FormatUint(uint64(entity.ID), 10),
+		WarehouseID: strconv.FormatUint(uint64(entity.WarehouseID), 10),
+		ParentID:    strconv.FormatUint(uint64(entity.ParentID), 10),
+		Kind:        string(entity.Kind),
+		Code:        entity.Code,
+		Name:        entity.Name,
+		Path:        entity.Path,
+		Depth:       entity.Depth(),
+		CreatedAt:   entity.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   entity.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// MovementToViewModel resolves the mover through users, which maps user IDs to full names.
+func MovementToViewModel(entity *movement.Movement, users map[uint]string) *viewmodels.Movement {
+	var orderID string
+	if entity.OrderID != 0 {
+		orderID = strconv.FormatUint(uint64(entity.OrderID), 10)
+	}
+	return &viewmodels.Movement{
+		ID:        strconv.FormatUint(uint64(entity.ID), 10),
+		ProductID: strconv.FormatUint(uint64(entity.ProductID), 10),
+		OrderID:   orderID,
+		FromPath:  entity.FromPath,
+		ToPath:    entity.ToPath,
+		MovedBy:   users[entity.MovedByID],
+		CreatedAt: entity.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// StockNames resolves the IDs of stock entries into display names.
+type StockNames struct {
+	Positions  map[uint]string
+	Warehouses map[uint]string
+	Locations  map[uint]string
+	Users      map[uint]string
+}
+
+func StockLevelToViewModel(entity *stock.Level, names *StockNames) *viewmodels.StockLevel {
+	return &viewmodels.StockLevel{
+		PositionID: strconv.FormatUint(uint64(entity.PositionID), 10),
+		Position:   names.Positions[entity.PositionID],
+		Warehouse:  names.Warehouses[entity.WarehouseID],
+		Location:   names.Locations[entity.LocationID],
+		Quantity:   strconv.FormatInt(entity.Quantity, 10),
+	}
+}
+
+func StockMovementToViewModel(entity *stock.Movement, names *StockNames) *viewmodels.StockMovement {
+	vm := &viewmodels.StockMovement{
+		ID:        strconv.FormatUint(uint64(entity.ID), 10),
+		Reason:    string(entity.Reason),
+		Warehouse: names.Warehouses[entity.WarehouseID],
+		Location:  names.Locations[entity.LocationID],
+		Quantity:  fmt.Sprintf("%+d", entity.Quantity),
+		Comment:   entity.Comment,
+		CreatedBy: names.Users[entity.CreatedByID],
+		CreatedAt: entity.CreatedAt.Format(time.RFC3339),
+	}
+	if entity.OrderID != 0 {
+		vm.OrderID = strconv.FormatUint(uint64(entity.OrderID), 10)
+	}
+	if entity.InventoryCheckID != 0 {
+		vm.InventoryCheckID = strconv.FormatUint(uint64(entity.InventoryCheckID), 10)
+	}
+	return vm
+}
+
+func StockReportToViewModel(entity *stock.Report, names *StockNames) *viewmodels.StockReport {
+	movements := make([]*viewmodels.StockMovement, 0, len(entity.Movements))
+	for _, m := range entity.Movements {
+		movements = append(movements, StockMovementToViewModel(m, names))
+	}
+	return &viewmodels.StockReport{
+		PositionID: strconv.FormatUint(uint64(entity.PositionID), 10),
+		Position:   names.Positions[entity.PositionID],
+		Opening:    strconv.FormatInt(entity.Opening, 10),
+		Closing:    strconv.FormatInt(entity.Closing(), 10),
+		Movements:  movements,
+	}
+}
+
 func CheckToViewModel(entity *inventory.Check) *viewmodels.Check {
+	finishedAt := ""
+	if !entity.FinishedAt.IsZero() {
+		finishedAt = entity.FinishedAt.Format(time.RFC3339)
+	}
+	warehouseID := ""
+	if entity.WarehouseID != 0 {
+		warehouseID = strconv.FormatUint(uint64(entity.WarehouseID), 10)
+	}
 	return &viewmodels.Check{
-		ID:         strconv.FormatUint(uint64(entity.ID), 10),
-		Name:       entity.Name,
-		Results:    mapping.MapViewModels(entity.Results, CheckResultToViewModel),
-		Status:     string(entity.Status),
-		CreatedAt:  entity.CreatedAt.Format(time.RFC3339),
-		CreatedBy:  mappers.UserToViewModel(entity.CreatedBy),
-		FinishedBy: mappers.UserToViewModel(entity.FinishedBy),
+		ID:          strconv.FormatUint(uint64(entity.ID), 10),
+		Name:        entity.Name,
+		WarehouseID: warehouseID,
+		Results:     mapping.MapViewModels(entity.Results, CheckResultToViewModel),
+		Status:      string(entity.Status),
+		CreatedAt:   entity.CreatedAt.Format(time.RFC3339),
+		FinishedAt:  finishedAt,
+		CreatedBy:   mappers.UserToViewModel(entity.CreatedBy),
+		FinishedBy:  mappers.UserToViewModel(entity.FinishedBy),
+		Locked:      entity.IsLocked(),
 	}
 }
 
@@ -103,7 +224,167 @@ func CheckResultToViewModel(entity *inventory.CheckResult) *viewmodels.CheckResu
 		Position:         pos,
 		ExpectedQuantity: strconv.FormatUint(uint64(entity.ExpectedQuantity), 10),
 		ActualQuantity:   strconv.FormatUint(uint64(entity.ActualQuantity), 10),
-		Difference:       strconv.FormatUint(uint64(entity.Difference), 10),
+		Difference:       strconv.Itoa(entity.Difference),
+		Missing:          entity.Missing(),
+		Surplus:          entity.Surplus(),
 		CreatedAt:        entity.CreatedAt.Format(time.RFC3339),
 	}
 }
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// PurchaseOrderToViewModel flags the lines the three-way match of the order finds mismatched.
+func PurchaseOrderToViewModel(entity *purchase.Order) *viewmodels.PurchaseOrder {
+	match := entity.Match()
+	lines := make([]*viewmodels.PurchaseOrderLine, 0, len(match.Lines))
+	for _, lm := range match.Lines {
+		vm := &viewmodels.PurchaseOrderLine{
+			ID:               strconv.FormatUint(uint64(lm.Line.ID), 10),
+			PositionID:       strconv.FormatUint(uint64(lm.Line.PositionID), 10),
+			Quantity:         lm.Line.Quantity,
+			UnitPrice:        formatAmount(lm.Line.UnitPrice),
+			Received:         lm.Line.Received,
+			Outstanding:      lm.Line.Outstanding(),
+			Total:            formatAmount(lm.Line.Total()),
+			ReceivedTotal:    formatAmount(lm.Line.ReceivedTotal()),
+			QuantityMismatch: lm.QuantityMismatch,
+		}
+		if lm.Line.Position != nil {
+			vm.Position = lm.Line.Position.Title
+		}
+		lines = append(lines, vm)
+	}
+	vm := &viewmodels.PurchaseOrder{
+		ID:            strconv.FormatUint(uint64(entity.ID), 10),
+		SupplierID:    strconv.FormatUint(uint64(entity.SupplierID), 10),
+		Supplier:      fmt.Sprintf("#%d", entity.SupplierID),
+		WarehouseID:   strconv.FormatUint(uint64(entity.WarehouseID), 10),
+		Currency:      entity.Currency,
+		Status:        string(entity.Status),
+		Comment:       entity.Comment,
+		Total:         formatAmount(entity.Total()),
+		ReceivedTotal: formatAmount(entity.ReceivedTotal()),
+		Lines:         lines,
+		Receipts:      mapping.MapViewModels(entity.Receipts, PurchaseReceiptToViewModel),
+		Closed:        entity.IsClosed(),
+		CreatedAt:     entity.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     entity.UpdatedAt.Format(time.RFC3339),
+	}
+	if entity.Supplier != nil && entity.Supplier.Name != "" {
+		vm.Supplier = entity.Supplier.Name
+		vm.SupplierTIN = entity.Supplier.TIN
+	}
+	if entity.Bill != nil {
+		vm.Bill = SupplierBillToViewModel(entity.Bill)
+	}
+	return vm
+}
+
+func PurchaseReceiptToViewModel(entity *purchase.Receipt) *viewmodels.PurchaseReceipt {
+	return &viewmodels.PurchaseReceipt{
+		ID:         strconv.FormatUint(uint64(entity.ID), 10),
+		OrderID:    strconv.FormatUint(uint64(entity.OrderID), 10),
+		LocationID: strconv.FormatUint(uint64(entity.LocationID), 10),
+		Quantity:   entity.Quantity(),
+		CreatedAt:  entity.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func SupplierBillToViewModel(entity *purchase.Bill) *viewmodels.SupplierBill {
+	return &viewmodels.SupplierBill{
+		ID:       strconv.FormatUint(uint64(entity.ID), 10),
+		Number:   entity.Number,
+		Amount:   formatAmount(entity.Amount),
+		Currency: entity.Currency,
+		Status:   entity.Status,
+	}
+}
+
+func PurchaseMatchToViewModel(entity *purchase.Match) *viewmodels.PurchaseMatch {
+	return &viewmodels.PurchaseMatch{
+		Ordered:          formatAmount(entity.Ordered),
+		Received:         formatAmount(entity.Received),
+		Billed:           formatAmount(entity.Billed),
+		HasBill:          entity.HasBill(),
+		QuantityMismatch: entity.QuantityMismatch,
+		PriceMismatch:    entity.PriceMismatch,
+		Matched:          entity.Matched(),
+	}
+}
+
+func SalesOrderToViewModel(entity *sales.Order) *viewmodels.SalesOrder {
+	vm := &viewmodels.SalesOrder{
+		ID:          strconv.FormatUint(uint64(entity.ID), 10),
+		WarehouseID: strconv.FormatUint(uint64(entity.WarehouseID), 10),
+		Customer:    entity.Customer,
+		Status:      string(entity.Status),
+		Comment:     entity.Comment,
+		Picked:      len(entity.Picks),
+		Lines:       make([]*viewmodels.SalesOrderLine, 0, len(entity.Lines)),
+		Picks:       make([]*viewmodels.SalesOrderPick, 0, len(entity.Picks)),
+		Closed:      entity.IsClosed(),
+		CreatedAt:   entity.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   entity.UpdatedAt.Format(time.RFC3339),
+	}
+	if entity.OrderID != 0 {
+		vm.OrderID = strconv.FormatUint(uint64(entity.OrderID), 10)
+	}
+	positions := make(map[uint]string, len(entity.Lines))
+	for _, line := range entity.Lines {
+		lineVM := &viewmodels.SalesOrderLine{
+			ID:          strconv.FormatUint(uint64(line.ID), 10),
+			PositionID:  strconv.FormatUint(uint64(line.PositionID), 10),
+			Quantity:    line.Quantity,
+			Picked:      entity.Picked(line.ID),
+			Outstanding: entity.Outstanding(line),
+		}
+		if line.Position != nil {
+			lineVM.Position = line.Position.Title
+			lineVM.Barcode = line.Position.Barcode
+		}
+		positions[line.ID] = lineVM.Position
+		vm.Quantity += line.Quantity
+		vm.Lines = append(vm.Lines, lineVM)
+	}
+	for _, pick := range entity.Picks {
+		vm.Picks = append(vm.Picks, &viewmodels.SalesOrderPick{
+			ID:         strconv.FormatUint(uint64(pick.ID), 10),
+			Rfid:       pick.Rfid,
+			Position:   positions[pick.LineID],
+			LocationID: strconv.FormatUint(uint64(pick.LocationID), 10),
+			PickedAt:   pick.PickedAt.Format(time.RFC3339),
+		})
+	}
+	return vm
+}
+
+func PickingItemToViewModel(entity *sales.PickingItem) *viewmodels.PickingItem {
+	vm := &viewmodels.PickingItem{
+		Quantity: entity.Quantity,
+	}
+	if entity.Line.Position != nil {
+		vm.Position = entity.Line.Position.Title
+		vm.Barcode = entity.Line.Position.Barcode
+	}
+	return vm
+}
+
+func PickingListToViewModel(entity *sales.PickingList) *viewmodels.PickingList {
+	stops := make([]*viewmodels.PickingStop, 0, len(entity.Stops))
+	for _, stop := range entity.Stops {
+		vm := &viewmodels.PickingStop{
+			LocationID: strconv.FormatUint(uint64(stop.LocationID), 10),
+			Items:      mapping.MapViewModels(stop.Items, PickingItemToViewModel),
+		}
+		if stop.Location != nil {
+			vm.Location = stop.Location.Path
+		}
+		stops = append(stops, vm)
+	}
+	return &viewmodels.PickingList{
+		Stops:     stops,
+		Shortages: mapping.MapViewModels(entity.Shortages, PickingItemToViewModel),
+	}
+}
@@ -0,0 +1,8 @@
+package components
+
+// LineForm is a position line of the order line editors.
+type LineForm struct {
+	PositionID string  `json:"positionId"`
+	Quantity   int     `json:"quantity"`
+	UnitPrice  float64 `json:"unitPrice"`
+}
@@ -17,6 +17,10 @@ type Check struct {
 	FinishedAt string
 	CreatedBy  *viewmodels.User
 	FinishedBy *viewmodels.User
+	// Locked checks are approved and can no longer be changed
+	Locked bool
+	// WarehouseID is empty for a check of every warehouse
+	WarehouseID string
 }
 
 type CheckResult struct {
@@ -26,6 +30,8 @@ type CheckResult struct {
 	ExpectedQuantity string
 	ActualQuantity   string
 	Difference       string
+	Missing          int
+	Surplus          int
 	CreatedAt        string
 }
 
@@ -8,12 +8,17 @@ import (
 )
 
 type Order struct {
-	ID        string
-	Type      string
-	Status    string
-	Items     []OrderItem
-	CreatedAt string
-	UpdatedAt string
+	ID          string
+	Type        string
+	Status      string
+	WarehouseID string
+	// Warehouse and Location are the warehouse name and destination path, filled in by the controller
+	Warehouse  string
+	LocationID string
+	Location   string
+	Items      []OrderItem
+	CreatedAt  string
+	UpdatedAt  string
 }
 
 func (o *Order) LocalizedTitle(l *i18n.Localizer) string {
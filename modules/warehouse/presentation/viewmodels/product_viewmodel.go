@@ -9,6 +9,8 @@ type Product struct {
 	ID         string
 	PositionID string
 	Position   *Position
+	LocationID string
+	Location   *Location
 	Rfid       string
 	Status     string
 	CreatedAt  string
@@ -22,3 +24,11 @@ func (p *Product) LocalizedStatus(l *i18n.Localizer) string {
 		},
 	})
 }
+
+// LocationPath is empty for products that are not placed in a warehouse.
+func (p *Product) LocationPath() string {
+	if p.Location == nil {
+		return ""
+	}
+	return p.Location.Path
+}
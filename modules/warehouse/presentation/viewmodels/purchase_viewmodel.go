@@ -0,0 +1,91 @@
+package viewmodels
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type PurchaseOrder struct {
+	ID          string
+	SupplierID  string
+	Supplier    string
+	SupplierTIN string
+	WarehouseID string
+	// Warehouse is the warehouse name, filled in by the controller
+	Warehouse     string
+	Currency      string
+	Status        string
+	Comment       string
+	Total         string
+	ReceivedTotal string
+	Lines         []*PurchaseOrderLine
+	Receipts      []*PurchaseReceipt
+	Bill          *SupplierBill
+	// Closed orders accept neither receipts nor cancellation
+	Closed    bool
+	CreatedAt string
+	UpdatedAt string
+}
+
+func (o *PurchaseOrder) LocalizedTitle(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID: "PurchaseOrders.View.Title",
+		},
+		TemplateData: map[string]interface{}{
+			"ID": o.ID,
+		},
+	})
+}
+
+func (o *PurchaseOrder) LocalizedStatus(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID: fmt.Sprintf("PurchaseOrders.Statuses.%s", o.Status),
+		},
+	})
+}
+
+type PurchaseOrderLine struct {
+	ID            string
+	PositionID    string
+	Position      string
+	Quantity      int
+	UnitPrice     string
+	Received      int
+	Outstanding   int
+	Total         string
+	ReceivedTotal string
+	// QuantityMismatch flags lines received in another quantity than ordered
+	QuantityMismatch bool
+}
+
+type PurchaseReceipt struct {
+	ID         string
+	OrderID    string
+	LocationID string
+	// Location is the path of the location, filled in by the controller
+	Location  string
+	Quantity  int
+	CreatedAt string
+}
+
+type SupplierBill struct {
+	ID       string
+	Number   string
+	Amount   string
+	Currency string
+	Status   string
+}
+
+// PurchaseMatch is the three-way match of an order, its receipts and its bill.
+type PurchaseMatch struct {
+	Ordered          string
+	Received         string
+	Billed           string
+	HasBill          bool
+	QuantityMismatch bool
+	PriceMismatch    bool
+	Matched          bool
+}
@@ -0,0 +1,83 @@
+package viewmodels
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type SalesOrder struct {
+	ID          string
+	WarehouseID string
+	// Warehouse is the warehouse name, filled in by the controller
+	Warehouse string
+	Customer  string
+	Status    string
+	Comment   string
+	Quantity  int
+	Picked    int
+	OrderID   string
+	Lines     []*SalesOrderLine
+	Picks     []*SalesOrderPick
+	// Closed orders take neither picks nor cancellation
+	Closed    bool
+	CreatedAt string
+	UpdatedAt string
+}
+
+func (o *SalesOrder) LocalizedTitle(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID: "SalesOrders.View.Title",
+		},
+		TemplateData: map[string]interface{}{
+			"ID": o.ID,
+		},
+	})
+}
+
+func (o *SalesOrder) LocalizedStatus(l *i18n.Localizer) string {
+	return l.MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID: fmt.Sprintf("SalesOrders.Statuses.%s", o.Status),
+		},
+	})
+}
+
+type SalesOrderLine struct {
+	ID          string
+	PositionID  string
+	Position    string
+	Barcode     string
+	Quantity    int
+	Picked      int
+	Outstanding int
+}
+
+type SalesOrderPick struct {
+	ID         string
+	Rfid       string
+	Position   string
+	LocationID string
+	// Location is the path of the location, filled in by the controller
+	Location string
+	PickedAt string
+}
+
+type PickingItem struct {
+	Position string
+	Barcode  string
+	Quantity int
+}
+
+// PickingStop is a location on the picking route and what to take there.
+type PickingStop struct {
+	LocationID string
+	Location   string
+	Items      []*PickingItem
+}
+
+type PickingList struct {
+	Stops     []*PickingStop
+	Shortages []*PickingItem
+}
@@ -0,0 +1,44 @@
+package viewmodels
+
+import (
+	"fmt"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type StockLevel struct {
+	PositionID string
+	Position   string
+	Warehouse  string
+	Location   string
+	Quantity   string
+}
+
+type StockMovement struct {
+	ID               string
+	Reason           string
+	Warehouse        string
+	Location         string
+	Quantity         string
+	OrderID          string
+	InventoryCheckID string
+	Comment          string
+	CreatedBy        string
+	CreatedAt        string
+}
+
+func (m *StockMovement) LocalizedReason(localizer *i18n.Localizer) string {
+	return localizer.MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID: fmt.Sprintf("Stock.Reasons.%s", m.Reason),
+		},
+	})
+}
+
+type StockReport struct {
+	PositionID string
+	Position   string
+	Opening    string
+	Closing    string
+	Movements  []*StockMovement
+}
@@ -0,0 +1,53 @@
+package viewmodels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type Warehouse struct {
+	ID        string
+	Name      string
+	Code      string
+	Address   string
+	CreatedAt string
+	UpdatedAt string
+}
+
+type Location struct {
+	ID          string
+	WarehouseID string
+	ParentID    string
+	Kind        string
+	Code        string
+	Name        string
+	Path        string
+	Depth       int
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+func (l *Location) LocalizedKind(localizer *i18n.Localizer) string {
+	return localizer.MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID: fmt.Sprintf("WarehouseLocations.Kinds.%s", l.Kind),
+		},
+	})
+}
+
+// Indent renders the nesting of the location in select options and tables.
+func (l *Location) Indent() string {
+	return strings.Repeat("  ", l.Depth)
+}
+
+type Movement struct {
+	ID        string
+	ProductID string
+	OrderID   string
+	FromPath  string
+	ToPath    string
+	MovedBy   string
+	CreatedAt string
+}
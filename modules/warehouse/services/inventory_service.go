@@ -6,28 +6,41 @@ import (
 	userpersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/inventory"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
 )
 
 type InventoryService struct {
-	repo         inventory.Repository
-	positionRepo position.Repository
-	productRepo  product.Repository
-	publisher    eventbus.EventBus
+	repo          inventory.Repository
+	positionRepo  position.Repository
+	productRepo   product.Repository
+	locationRepo  location.Repository
+	stockRepo     stock.Repository
+	warehouseRepo warehouse.Repository
+	salesRepo     sales.Repository
+	publisher     eventbus.EventBus
 }
 
 func NewInventoryService(publisher eventbus.EventBus) *InventoryService {
 	positionRepo := persistence.NewPositionRepository()
 	userRepo := userpersistence.NewUserRepository()
 	return &InventoryService{
-		repo:         persistence.NewInventoryRepository(userRepo, positionRepo),
-		productRepo:  persistence.NewProductRepository(),
-		positionRepo: positionRepo,
-		publisher:    publisher,
+		repo:          persistence.NewInventoryRepository(userRepo, positionRepo),
+		productRepo:   persistence.NewProductRepository(),
+		positionRepo:  positionRepo,
+		locationRepo:  persistence.NewLocationRepository(),
+		stockRepo:     persistence.NewStockRepository(),
+		warehouseRepo: persistence.NewWarehouseRepository(),
+		salesRepo:     persistence.NewSalesRepository(positionRepo),
+		publisher:     publisher,
 	}
 }
 
@@ -61,8 +74,8 @@ func (s *InventoryService) GetPaginated(
 	return s.repo.GetPaginated(ctx, params)
 }
 
-func (s *InventoryService) Positions(ctx context.Context) ([]*inventory.Position, error) {
-	return s.repo.Positions(ctx)
+func (s *InventoryService) Positions(ctx context.Context, warehouseID uint) ([]*inventory.Position, error) {
+	return s.repo.Positions(ctx, warehouseID)
 }
 
 func (s *InventoryService) Create(ctx context.Context, data *inventory.CreateCheckDTO) (*inventory.Check, error) {
@@ -81,7 +94,7 @@ func (s *InventoryService) Create(ctx context.Context, data *inventory.CreateChe
 	for _, pos := range data.Positions {
 		found[pos.PositionID] = pos.Found
 	}
-	positions, err := s.repo.Positions(ctx)
+	positions, err := s.repo.Positions(ctx, entity.WarehouseID)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +117,13 @@ func (s *InventoryService) Update(ctx context.Context, id uint, data *inventory.
 	if err := composables.CanUser(ctx, permissions.InventoryUpdate); err != nil {
 		return err
 	}
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current.IsLocked() {
+		return inventory.NewErrCheckLocked()
+	}
 	entity, err := data.ToEntity(id)
 	if err != nil {
 		return err
@@ -127,6 +147,9 @@ func (s *InventoryService) Delete(ctx context.Context, id uint) (*inventory.Chec
 	if err != nil {
 		return nil, err
 	}
+	if entity.IsLocked() {
+		return nil, inventory.NewErrCheckLocked()
+	}
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return nil, err
 	}
@@ -137,6 +160,207 @@ func (s *InventoryService) Delete(ctx context.Context, id uint) (*inventory.Chec
 	s.publisher.Publish(deletedEvent)
 	return entity, nil
 }
+
+// Approve applies the differences of a check to the stock and locks it: missing products of the checked
+// warehouse are written off, surplus products are registered under the tags given for them and the written
+// off value is enqueued so that finance can post it as an expense. Products picked for open sales orders are
+// not written off, neither is the stock reserved by them.
+func (s *InventoryService) Approve(ctx context.Context, id uint, data *inventory.ApproveCheckDTO) (*inventory.Check, error) {
+	if err := composables.CanUser(ctx, permissions.InventoryUpdate); err != nil {
+		return nil, err
+	}
+	user, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsLocked() {
+		return nil, inventory.NewErrCheckLocked()
+	}
+	var surplusLocation *location.Location
+	if data.LocationID != 0 {
+		if surplusLocation, err = s.locationRepo.GetByID(ctx, data.LocationID); err != nil {
+			return nil, err
+		}
+	}
+
+	commitments, err := s.lockCommitments(ctx, current.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	// the check is read again under its lock, a concurrent approval may have finished while waiting
+	entity, err := s.repo.GetForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Approve(user); err != nil {
+		return nil, err
+	}
+	writtenOff := make([]*product.Product, 0)
+	registered := make([]*product.Product, 0)
+	writeOff := 0.0
+	for _, result := range entity.Results {
+		input := data.Result(result.ID)
+		title := ""
+		if result.Position != nil {
+			title = result.Position.Title
+		}
+		if missing := result.Missing(); missing > 0 {
+			products, err := s.productRepo.FindByPositionID(ctx, &product.FindByPositionParams{
+				PositionID:  result.PositionID,
+				Status:      product.InStock,
+				WarehouseID: entity.WarehouseID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			// picked products were seen by the picker, so they are not the missing ones
+			unpicked := make([]*product.Product, 0, missing)
+			for _, p := range products {
+				if len(unpicked) < missing && !commitments[productWarehouseID(p)].Picked[p.ID] {
+					unpicked = append(unpicked, p)
+				}
+			}
+			if len(unpicked) < missing {
+				return nil, inventory.NewErrStockChanged(title)
+			}
+			writtenOff = append(writtenOff, unpicked...)
+			writeOff += float64(missing) * input.UnitCost
+		}
+		if surplus := result.Surplus(); surplus > 0 {
+			tags := input.TagList()
+			if len(tags) != surplus {
+				return nil, inventory.NewErrSurplusTags(title, surplus, len(tags))
+			}
+			existing, err := s.productRepo.GetByRfidMany(ctx, tags)
+			if err != nil {
+				return nil, err
+			}
+			if len(existing) > 0 {
+				return nil, inventory.NewErrTagTaken(existing[0].Rfid)
+			}
+			for _, tag := range tags {
+				p := product.New(tag, result.PositionID, product.InStock, result.Position)
+				if surplusLocation != nil {
+					p.LocationID = surplusLocation.ID
+					p.Location = surplusLocation
+				}
+				registered = append(registered, p)
+			}
+		}
+	}
+
+	if err := s.checkReservations(ctx, commitments, writtenOff); err != nil {
+		return nil, err
+	}
+	if len(writtenOff) > 0 {
+		ids := make([]uint, 0, len(writtenOff))
+		for _, p := range writtenOff {
+			ids = append(ids, p.ID)
+		}
+		if err := s.productRepo.UpdateStatus(ctx, ids, product.WrittenOff); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range registered {
+		if err := s.productRepo.Create(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.stockRepo.Create(ctx, stock.FromInventoryCheck(entity.ID, writtenOff, registered, user.ID())...); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Approve(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Enqueue(ctx, events.InventoryApprovedTopic, events.InventoryApproved{
+		CheckID:    entity.ID,
+		Name:       entity.Name,
+		WriteOff:   writeOff,
+		ApprovedAt: entity.ApprovedAt,
+	}); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// lockCommitments locks the checked warehouse, or every warehouse for a check of all of them, and returns
+// the commitments of their open sales orders by warehouse.
+func (s *InventoryService) lockCommitments(ctx context.Context, warehouseID uint) (map[uint]*sales.Commitments, error) {
+	ids := []uint{warehouseID}
+	if warehouseID == 0 {
+		warehouses, err := s.warehouseRepo.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = make([]uint, 0, len(warehouses))
+		for _, w := range warehouses {
+			ids = append(ids, w.ID)
+		}
+	}
+	if err := s.warehouseRepo.Lock(ctx, ids...); err != nil {
+		return nil, err
+	}
+	commitments := map[uint]*sales.Commitments{
+		0: {},
+	}
+	for _, id := range ids {
+		c, err := s.salesRepo.Commitments(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		commitments[id] = c
+	}
+	return commitments, nil
+}
+
+// checkReservations checks that the stock left in every warehouse after the write-off covers the
+// reservations of its open sales orders.
+func (s *InventoryService) checkReservations(
+	ctx context.Context,
+	commitments map[uint]*sales.Commitments,
+	writtenOff []*product.Product,
+) error {
+	byWarehouse := make(map[uint][]*product.Product)
+	for _, p := range writtenOff {
+		if warehouseID := productWarehouseID(p); warehouseID != 0 {
+			byWarehouse[warehouseID] = append(byWarehouse[warehouseID], p)
+		}
+	}
+	for warehouseID, products := range byWarehouse {
+		onHand := make(map[uint]int)
+		for _, p := range products {
+			if _, ok := onHand[p.PositionID]; ok {
+				continue
+			}
+			count, err := s.productRepo.Count(ctx, &product.CountParams{
+				PositionID:  p.PositionID,
+				Status:      product.InStock,
+				WarehouseID: warehouseID,
+			})
+			if err != nil {
+				return err
+			}
+			onHand[p.PositionID] = int(count)
+		}
+		if err := commitments[warehouseID].Release(products, onHand); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// productWarehouseID is the warehouse a product is placed in, zero when it is not placed.
+func productWarehouseID(p *product.Product) uint {
+	if p.Location == nil {
+		return 0
+	}
+	return p.Location.WarehouseID
+}
+
 func (s *InventoryService) Count(ctx context.Context) (uint, error) {
 	return s.repo.Count(ctx)
 }
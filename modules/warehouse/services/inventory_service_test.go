@@ -0,0 +1,151 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/inventory"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/unit"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
+)
+
+func TestInventoryService_Approve(t *testing.T) {
+	t.Parallel()
+	f := setupTest(t)
+
+	unitRepo := persistence.NewUnitRepository()
+	positionRepo := persistence.NewPositionRepository()
+	productRepo := persistence.NewProductRepository()
+	warehouseRepo := persistence.NewWarehouseRepository()
+	locationRepo := persistence.NewLocationRepository()
+	inventoryService := services.NewInventoryService(f.app.EventPublisher())
+
+	// the check is approved by the mocked user, so it has to exist
+	if _, err := corepersistence.NewUserRepository().Create(f.ctx, user.New(
+		"John",
+		"Doe",
+		"",
+		"",
+		"test@gmail.com",
+		nil,
+		0,
+		user.UILanguageEN,
+		nil,
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if err := unitRepo.Create(f.ctx, &unit.Unit{
+		ID:         1,
+		Title:      "Test Unit",
+		ShortTitle: "TU",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	positionEntity := &position.Position{
+		ID:        1,
+		Title:     "Test Position",
+		Barcode:   "1234567890",
+		UnitID:    1,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := positionRepo.Create(f.ctx, positionEntity); err != nil {
+		t.Fatal(err)
+	}
+
+	// two products are stored in the checked warehouse and one in another warehouse
+	bins := make([]*location.Location, 0, 2)
+	for _, code := range []string{"MAIN", "SPARE"} {
+		warehouseEntity := &warehouse.Warehouse{Name: code, Code: code, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		if err := warehouseRepo.Create(f.ctx, warehouseEntity); err != nil {
+			t.Fatal(err)
+		}
+		binEntity := &location.Location{WarehouseID: warehouseEntity.ID, Kind: location.Bin, Code: "B01"}
+		if err := binEntity.Attach(warehouseEntity.Code, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := locationRepo.Create(f.ctx, binEntity); err != nil {
+			t.Fatal(err)
+		}
+		bins = append(bins, binEntity)
+	}
+	products := make([]*product.Product, 0, 3)
+	for i, bin := range []*location.Location{bins[0], bins[0], bins[1]} {
+		p := product.New(fmt.Sprintf("EPS:%d", i), positionEntity.ID, product.InStock, positionEntity)
+		p.LocationID = bin.ID
+		if err := productRepo.Create(f.ctx, p); err != nil {
+			t.Fatal(err)
+		}
+		products = append(products, p)
+	}
+
+	check, err := inventoryService.Create(f.ctx, &inventory.CreateCheckDTO{
+		Name:        "Main",
+		WarehouseID: bins[0].WarehouseID,
+		Positions:   []*inventory.PositionCheckDTO{{PositionID: positionEntity.ID, Found: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(check.Results) != 1 || check.Results[0].ExpectedQuantity != 2 {
+		t.Fatalf("expected 2 products of the checked warehouse, got %+v", check.Results)
+	}
+
+	t.Run("Approve", func(t *testing.T) {
+		approved, err := inventoryService.Approve(f.ctx, check.ID, &inventory.ApproveCheckDTO{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if approved.Status != inventory.Approved {
+			t.Fatalf("expected %s, got %s", inventory.Approved, approved.Status)
+		}
+
+		stored, err := inventoryService.GetByID(f.ctx, check.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.ApprovedByID != 1 || stored.ApprovedAt.IsZero() {
+			t.Fatalf("expected the approval to be recorded, got %d at %v", stored.ApprovedByID, stored.ApprovedAt)
+		}
+		if !stored.FinishedAt.Equal(check.FinishedAt) {
+			t.Fatalf("expected the finish time to be kept, got %v", stored.FinishedAt)
+		}
+
+		writtenOff := 0
+		for _, p := range products {
+			stored, err := productRepo.GetByID(f.ctx, p.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != product.WrittenOff {
+				continue
+			}
+			if stored.LocationID != bins[0].ID {
+				t.Fatalf("expected only products of the checked warehouse to be written off, got %d", stored.ID)
+			}
+			writtenOff++
+		}
+		if writtenOff != 1 {
+			t.Fatalf("expected 1 product to be written off, got %d", writtenOff)
+		}
+	})
+
+	t.Run("ApproveTwice", func(t *testing.T) {
+		_, err := inventoryService.Approve(f.ctx, check.ID, &inventory.ApproveCheckDTO{})
+		var errLocked *inventory.ErrCheckLocked
+		if !errors.As(err, &errLocked) {
+			t.Fatalf("expected %T, got %v", errLocked, err)
+		}
+	})
+}
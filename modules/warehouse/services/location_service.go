@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// LocationService manages the storage layout of warehouses, so changes to it require Warehouse.Update.
+type LocationService struct {
+	repo          location.Repository
+	warehouseRepo warehouse.Repository
+}
+
+func NewLocationService(repo location.Repository, warehouseRepo warehouse.Repository) *LocationService {
+	return &LocationService{
+		repo:          repo,
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+func (s *LocationService) GetByID(ctx context.Context, id uint) (*location.Location, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *LocationService) GetAll(ctx context.Context) ([]*location.Location, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAll(ctx)
+}
+
+func (s *LocationService) GetByWarehouseID(ctx context.Context, warehouseID uint) ([]*location.Location, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, &location.FindParams{WarehouseID: warehouseID})
+}
+
+func (s *LocationService) Create(ctx context.Context, warehouseID uint, data *location.CreateDTO) (*location.Location, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseUpdate); err != nil {
+		return nil, err
+	}
+	w, err := s.warehouseRepo.GetByID(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity(w.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetByCode(ctx, w.ID, entity.Code)
+	if err != nil && !errors.Is(err, persistence.ErrLocationNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, location.NewErrDuplicateCode(entity.Code)
+	}
+	var parent *location.Location
+	if entity.ParentID != 0 {
+		parent, err = s.repo.GetByID(ctx, entity.ParentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := entity.Attach(w.Code, parent); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *LocationService) Delete(ctx context.Context, warehouseID, id uint) (*location.Location, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.WarehouseID != warehouseID {
+		return nil, persistence.ErrLocationNotFound
+	}
+	empty, err := s.repo.IsEmpty(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !empty {
+		return nil, location.NewErrNotEmpty()
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// MovementService reads the movement history, movements themselves are recorded by the product and order services.
+type MovementService struct {
+	repo movement.Repository
+}
+
+func NewMovementService(repo movement.Repository) *MovementService {
+	return &MovementService{
+		repo: repo,
+	}
+}
+
+func (s *MovementService) GetPaginated(ctx context.Context, params *movement.FindParams) ([]*movement.Movement, error) {
+	if err := composables.CanUser(ctx, permissions.ProductRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *MovementService) Count(ctx context.Context, params *movement.FindParams) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.ProductRead); err != nil {
+		return 0, err
+	}
+	return s.repo.Count(ctx, params)
+}
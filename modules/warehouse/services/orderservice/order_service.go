@@ -4,26 +4,47 @@ import (
 	"context"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
 )
 
 type OrderService struct {
-	repo        order.Repository
-	productRepo product.Repository
-	publisher   eventbus.EventBus
+	repo          order.Repository
+	productRepo   product.Repository
+	locationRepo  location.Repository
+	movementRepo  movement.Repository
+	stockRepo     stock.Repository
+	warehouseRepo warehouse.Repository
+	salesRepo     sales.Repository
+	publisher     eventbus.EventBus
 }
 
 func NewOrderService(
 	publisher eventbus.EventBus,
 	orderRepo order.Repository,
 	productRepo product.Repository,
+	locationRepo location.Repository,
+	movementRepo movement.Repository,
+	stockRepo stock.Repository,
+	warehouseRepo warehouse.Repository,
+	salesRepo sales.Repository,
 ) *OrderService {
 	return &OrderService{
-		repo:        orderRepo,
-		productRepo: productRepo,
-		publisher:   publisher,
+		repo:          orderRepo,
+		productRepo:   productRepo,
+		locationRepo:  locationRepo,
+		movementRepo:  movementRepo,
+		stockRepo:     stockRepo,
+		warehouseRepo: warehouseRepo,
+		salesRepo:     salesRepo,
+		publisher:     publisher,
 	}
 }
 
@@ -56,14 +77,30 @@ func (s *OrderService) Create(ctx context.Context, data order.CreateDTO) error {
 	if err := composables.CanUser(ctx, permissions.OrderCreate); err != nil {
 		return err
 	}
+	if data.Type != string(order.TypeOut) && data.LocationID == 0 {
+		return order.NewErrLocationRequired(order.Type(data.Type))
+	}
+	if data.LocationID != 0 {
+		destination, err := s.locationRepo.GetByID(ctx, data.LocationID)
+		if err != nil {
+			return err
+		}
+		// transfers take products from their warehouse and may move them into another one
+		if data.Type != string(order.TypeTransfer) && destination.WarehouseID != data.WarehouseID {
+			return order.NewErrLocationNotInWarehouse()
+		}
+	}
 	entity, err := data.ToEntity()
 	if err != nil {
 		return err
 	}
+	if err := s.checkCommitments(ctx, entity); err != nil {
+		return err
+	}
 	if err := s.repo.Create(ctx, entity); err != nil {
 		return err
 	}
-	return nil
+	return eventbus.Enqueue(ctx, events.OrderCreatedTopic, order.NewCreated(entity))
 }
 
 func (s *OrderService) Complete(ctx context.Context, id uint) (order.Order, error) {
@@ -74,12 +111,32 @@ func (s *OrderService) Complete(ctx context.Context, id uint) (order.Order, erro
 	if err != nil {
 		return nil, err
 	}
+	if entity.Status() != order.Complete {
+		if err := s.checkCommitments(ctx, entity); err != nil {
+			return nil, err
+		}
+	}
+	locations := make(map[uint]uint)
+	for _, item := range entity.Items() {
+		for _, p := range item.Products() {
+			locations[p.ID] = p.LocationID
+		}
+	}
 	if err := entity.Complete(); err != nil {
 		return nil, err
 	}
 	if err := s.repo.Update(ctx, entity); err != nil {
 		return nil, err
 	}
+	if err := s.recordMovements(ctx, entity, locations); err != nil {
+		return nil, err
+	}
+	if err := s.recordStock(ctx, entity, locations); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Enqueue(ctx, events.OrderCompletedTopic, order.NewCompleted(entity)); err != nil {
+		return nil, err
+	}
 	return entity, nil
 }
 
@@ -114,3 +171,102 @@ func (s *OrderService) Delete(ctx context.Context, id uint) (order.Order, error)
 func (s *OrderService) Count(ctx context.Context) (int64, error) {
 	return s.repo.Count(ctx)
 }
+
+// checkCommitments checks the products an out order or a transfer takes against the open sales orders
+// of its warehouse: picked products cannot be moved and the stock that leaves the warehouse must not
+// be reserved. The warehouse is locked until the transaction ends, so the check holds until the order
+// is saved.
+func (s *OrderService) checkCommitments(ctx context.Context, entity order.Order) error {
+	if entity.Type() == order.TypeIn {
+		return nil
+	}
+	products := make([]*product.Product, 0)
+	for _, item := range entity.Items() {
+		for _, p := range item.Products() {
+			// the products of a new order carry nothing but their id
+			stored, err := s.productRepo.GetByID(ctx, p.ID)
+			if err != nil {
+				return err
+			}
+			if stored.Status == product.InStock {
+				products = append(products, stored)
+			}
+		}
+	}
+	if len(products) == 0 {
+		return nil
+	}
+	if err := s.warehouseRepo.Lock(ctx, entity.WarehouseID()); err != nil {
+		return err
+	}
+	commitments, err := s.salesRepo.Commitments(ctx, entity.WarehouseID())
+	if err != nil {
+		return err
+	}
+	if entity.Type() == order.TypeTransfer && entity.LocationID() != 0 {
+		destination, err := s.locationRepo.GetByID(ctx, entity.LocationID())
+		if err != nil {
+			return err
+		}
+		if destination.WarehouseID == entity.WarehouseID() {
+			return commitments.Move(products)
+		}
+	}
+	onHand := make(map[uint]int)
+	for _, p := range products {
+		if _, ok := onHand[p.PositionID]; ok {
+			continue
+		}
+		count, err := s.productRepo.Count(ctx, &product.CountParams{
+			PositionID:  p.PositionID,
+			Status:      product.InStock,
+			WarehouseID: entity.WarehouseID(),
+		})
+		if err != nil {
+			return err
+		}
+		onHand[p.PositionID] = int(count)
+	}
+	return commitments.Release(products, onHand)
+}
+
+// recordMovements adds every product the order moved away from its previous location to the movement history.
+func (s *OrderService) recordMovements(ctx context.Context, entity order.Order, previous map[uint]uint) error {
+	var movedByID uint
+	if u, err := composables.UseUser(ctx); err == nil {
+		movedByID = u.ID()
+	}
+	for _, item := range entity.Items() {
+		for _, p := range item.Products() {
+			if previous[p.ID] == p.LocationID {
+				continue
+			}
+			m := movement.New(p.ID, entity.ID(), previous[p.ID], p.LocationID, movedByID)
+			if err := s.movementRepo.Create(ctx, m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordStock writes the stock ledger entries of a completed order.
+func (s *OrderService) recordStock(ctx context.Context, entity order.Order, previous map[uint]uint) error {
+	var createdByID uint
+	if u, err := composables.UseUser(ctx); err == nil {
+		createdByID = u.ID()
+	}
+	destinationWarehouseID := entity.WarehouseID()
+	if entity.Type() == order.TypeTransfer && entity.LocationID() != 0 {
+		destination, err := s.locationRepo.GetByID(ctx, entity.LocationID())
+		if err != nil {
+			return err
+		}
+		destinationWarehouseID = destination.WarehouseID
+	}
+	movements := stock.FromOrder(entity, previous, destinationWarehouseID, createdByID)
+	if len(movements) == 0 {
+		return nil
+	}
+	return s.stockRepo.Create(ctx, movements...)
+}
@@ -7,7 +7,11 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/unit"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/orderservice"
 )
@@ -20,7 +24,20 @@ func TestOrderService(t *testing.T) {
 	positionRepo := persistence.NewPositionRepository()
 	productRepo := persistence.NewProductRepository()
 	orderRepo := persistence.NewOrderRepository(productRepo)
-	orderService := orderservice.NewOrderService(f.app.EventPublisher(), orderRepo, productRepo)
+	warehouseRepo := persistence.NewWarehouseRepository()
+	locationRepo := persistence.NewLocationRepository()
+	movementRepo := persistence.NewMovementRepository()
+	stockRepo := persistence.NewStockRepository()
+	orderService := orderservice.NewOrderService(
+		f.app.EventPublisher(),
+		orderRepo,
+		productRepo,
+		locationRepo,
+		movementRepo,
+		stockRepo,
+		warehouseRepo,
+		persistence.NewSalesRepository(positionRepo),
+	)
 
 	if err := unitRepo.Create(f.ctx, &unit.Unit{
 		ID:         1,
@@ -45,7 +62,19 @@ func TestOrderService(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	domainOrder := order.New(order.TypeIn, order.Pending)
+	warehouseEntity := &warehouse.Warehouse{Name: "Main", Code: "MAIN", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := warehouseRepo.Create(f.ctx, warehouseEntity); err != nil {
+		t.Fatal(err)
+	}
+	binEntity := &location.Location{WarehouseID: warehouseEntity.ID, Kind: location.Bin, Code: "B01"}
+	if err := binEntity.Attach(warehouseEntity.Code, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := locationRepo.Create(f.ctx, binEntity); err != nil {
+		t.Fatal(err)
+	}
+
+	domainOrder := order.New(order.TypeIn, order.Pending, warehouseEntity.ID, binEntity.ID)
 	if err := domainOrder.AddItem(
 		positionEntity,
 		product.New("EPS:1234567890", 1, product.Approved, positionEntity),
@@ -75,4 +104,23 @@ func TestOrderService(t *testing.T) {
 	if item.Products()[0].Status != product.InStock {
 		t.Fatalf("expected %s, got %s", product.InStock, item.Products()[0].Status)
 	}
+	if item.Products()[0].LocationID != binEntity.ID {
+		t.Fatalf("expected location %d, got %d", binEntity.ID, item.Products()[0].LocationID)
+	}
+
+	movements, err := movementRepo.GetPaginated(f.ctx, &movement.FindParams{OrderID: orderEntity.ID()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movements) != 1 || movements[0].ToPath != "MAIN/B01" {
+		t.Fatalf("expected a movement into MAIN/B01, got %+v", movements)
+	}
+
+	onHand, err := stockRepo.OnHand(f.ctx, &stock.LevelParams{PositionID: positionEntity.ID, LocationID: binEntity.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if onHand != 1 {
+		t.Fatalf("expected 1 on hand, got %d", onHand)
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,7 +21,7 @@ import (
 )
 
 var (
-	TestFilePath = "test.xlsx"
+	TestFilePath string
 	Data         = []map[string]interface{}{
 		{"A1": "Наименование", "B1": "Код в справочнике", "C1": "Ед. изм.", "D1": "Количество"},
 		{"A2": "Дрель Молоток N.C.V (900W)", "B2": "3241324132", "C2": "шт", "D2": 10},
@@ -34,11 +35,16 @@ func TestMain(m *testing.M) {
 	if err := os.Chdir("../../../../"); err != nil {
 		panic(err)
 	}
+	dir, err := os.MkdirTemp("", "positionservice")
+	if err != nil {
+		panic(err)
+	}
+	TestFilePath = filepath.Join(dir, "test.xlsx")
 	if err := createTestFile(TestFilePath); err != nil {
 		panic(err)
 	}
 	code := m.Run()
-	if err := os.Remove(TestFilePath); err != nil {
+	if err := os.RemoveAll(dir); err != nil {
 		log.Println(err)
 	}
 	os.Exit(code)
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/movement"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
@@ -12,17 +13,20 @@ import (
 )
 
 type ProductService struct {
-	repo      product.Repository
-	publisher eventbus.EventBus
+	repo         product.Repository
+	movementRepo movement.Repository
+	publisher    eventbus.EventBus
 }
 
 func NewProductService(
 	repo product.Repository,
+	movementRepo movement.Repository,
 	publisher eventbus.EventBus,
 ) *ProductService {
 	return &ProductService{
-		repo:      repo,
-		publisher: publisher,
+		repo:         repo,
+		movementRepo: movementRepo,
+		publisher:    publisher,
 	}
 }
 
@@ -80,6 +84,9 @@ func (s *ProductService) Create(ctx context.Context, data *product.CreateDTO) er
 	if err := s.repo.Create(ctx, entity); err != nil {
 		return err
 	}
+	if err := s.recordMovement(ctx, entity.ID, 0, entity.LocationID); err != nil {
+		return err
+	}
 	createdEvent, err := product.NewCreatedEvent(ctx, *data, *entity)
 	if err != nil {
 		return err
@@ -148,6 +155,11 @@ func (s *ProductService) BulkCreate(ctx context.Context, data []*product.CreateD
 	if err := s.repo.BulkCreate(ctx, entities); err != nil {
 		return nil, err
 	}
+	for _, entity := range entities {
+		if err := s.recordMovement(ctx, entity.ID, 0, entity.LocationID); err != nil {
+			return nil, err
+		}
+	}
 	for i, d := range data {
 		createdEvent, err := product.NewCreatedEvent(ctx, *d, *entities[i])
 		if err != nil {
@@ -168,6 +180,10 @@ func (s *ProductService) Update(ctx context.Context, id uint, data *product.Upda
 	} else if err != nil && !errors.Is(err, persistence.ErrProductNotFound) {
 		return err
 	}
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
 	entity, err := data.ToEntity(id)
 	if err != nil {
 		return err
@@ -175,6 +191,9 @@ func (s *ProductService) Update(ctx context.Context, id uint, data *product.Upda
 	if err := s.repo.Update(ctx, entity); err != nil {
 		return err
 	}
+	if err := s.recordMovement(ctx, id, current.LocationID, entity.LocationID); err != nil {
+		return err
+	}
 	updatedEvent, err := product.NewUpdatedEvent(ctx, *data, *entity)
 	if err != nil {
 		return err
@@ -201,3 +220,15 @@ func (s *ProductService) Delete(ctx context.Context, id uint) (*product.Product,
 	s.publisher.Publish(deletedEvent)
 	return entity, nil
 }
+
+// recordMovement adds a manual movement to the history of the product if its location changed.
+func (s *ProductService) recordMovement(ctx context.Context, productID, from, to uint) error {
+	if from == to {
+		return nil
+	}
+	var movedByID uint
+	if u, err := composables.UseUser(ctx); err == nil {
+		movedByID = u.ID()
+	}
+	return s.movementRepo.Create(ctx, movement.New(productID, 0, from, to, movedByID))
+}
@@ -0,0 +1,259 @@
+package purchaseservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/purchase"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/orderservice"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/productservice"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+type PurchaseService struct {
+	repo         purchase.Repository
+	orderRepo    order.Repository
+	productRepo  product.Repository
+	locationRepo location.Repository
+	orderService *orderservice.OrderService
+	publisher    eventbus.EventBus
+}
+
+func NewPurchaseService(
+	publisher eventbus.EventBus,
+	purchaseRepo purchase.Repository,
+	orderRepo order.Repository,
+	productRepo product.Repository,
+	locationRepo location.Repository,
+	orderService *orderservice.OrderService,
+) *PurchaseService {
+	return &PurchaseService{
+		repo:         purchaseRepo,
+		orderRepo:    orderRepo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		orderService: orderService,
+		publisher:    publisher,
+	}
+}
+
+func (s *PurchaseService) GetByID(ctx context.Context, id uint) (*purchase.Order, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *PurchaseService) GetPaginated(ctx context.Context, params *purchase.FindParams) ([]*purchase.Order, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *PurchaseService) Count(ctx context.Context, params *purchase.FindParams) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderRead); err != nil {
+		return 0, err
+	}
+	return s.repo.Count(ctx, params)
+}
+
+// Bills returns the supplier bills of the order's supplier that can still be linked to it.
+func (s *PurchaseService) Bills(ctx context.Context, entity *purchase.Order) ([]*purchase.Bill, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderRead); err != nil {
+		return nil, err
+	}
+	bills, err := s.repo.Bills(ctx, entity.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*purchase.Bill, 0, len(bills))
+	for _, b := range bills {
+		if b.Status != purchase.BillRejected {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+func (s *PurchaseService) Create(ctx context.Context, data *purchase.CreateDTO) (*purchase.Order, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderCreate); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity(u.ID())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Enqueue(ctx, events.PurchaseCreatedTopic, purchase.NewCreated(entity)); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Receive registers the products delivered against a purchase order. Every tag becomes a product
+// of the line's position, the products are brought into the location by a completed in-order.
+func (s *PurchaseService) Receive(ctx context.Context, id uint, data *purchase.ReceiveDTO) (*purchase.Receipt, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderUpdate); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := s.locationRepo.GetByID(ctx, data.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	if destination.WarehouseID != entity.WarehouseID {
+		return nil, order.NewErrLocationNotInWarehouse()
+	}
+
+	receipt := &purchase.Receipt{
+		PurchaseOrderID: entity.ID,
+		LocationID:      destination.ID,
+		CreatedByID:     u.ID(),
+		CreatedAt:       time.Now(),
+	}
+	tags := make(map[uint][]string, len(data.Lines))
+	allTags := make([]string, 0)
+	for _, line := range data.Lines {
+		if line == nil {
+			continue
+		}
+		lineTags := line.TagList()
+		if len(lineTags) == 0 {
+			continue
+		}
+		if _, ok := tags[line.LineID]; !ok {
+			receipt.Lines = append(receipt.Lines, &purchase.ReceiptLine{LineID: line.LineID})
+		}
+		tags[line.LineID] = append(tags[line.LineID], lineTags...)
+		allTags = append(allTags, lineTags...)
+	}
+	for _, receiptLine := range receipt.Lines {
+		receiptLine.Quantity = len(tags[receiptLine.LineID])
+	}
+	seen := make(map[string]bool, len(allTags))
+	for _, tag := range allTags {
+		if seen[tag] {
+			return nil, productservice.NewErrDuplicateRfid(tag)
+		}
+		seen[tag] = true
+	}
+	if len(allTags) > 0 {
+		existing, err := s.productRepo.GetByRfidMany(ctx, allTags)
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			return nil, productservice.NewErrDuplicateRfid(existing[0].Rfid)
+		}
+	}
+	if err := entity.Receive(receipt); err != nil {
+		return nil, err
+	}
+
+	inOrder := order.New(order.TypeIn, order.Pending, entity.WarehouseID, destination.ID)
+	for _, receiptLine := range receipt.Lines {
+		line := entity.Line(receiptLine.LineID)
+		products := make([]*product.Product, 0, receiptLine.Quantity)
+		for _, tag := range tags[line.ID] {
+			products = append(products, product.New(tag, line.PositionID, product.InDevelopment, line.Position))
+		}
+		if err := inOrder.AddItem(line.Position, products...); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.orderRepo.Create(ctx, inOrder); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Enqueue(ctx, events.OrderCreatedTopic, order.NewCreated(inOrder)); err != nil {
+		return nil, err
+	}
+	if _, err := s.orderService.Complete(ctx, inOrder.ID()); err != nil {
+		return nil, err
+	}
+
+	receipt.OrderID = inOrder.ID()
+	if err := s.repo.CreateReceipt(ctx, receipt); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// LinkBill matches a purchase order against a supplier bill recorded in finance.
+func (s *PurchaseService) LinkBill(ctx context.Context, id uint, data *purchase.LinkBillDTO) (*purchase.Order, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.repo.GetBill(ctx, data.BillID)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.LinkBill(b); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *PurchaseService) Cancel(ctx context.Context, id uint) (*purchase.Order, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Delete removes a purchase order nothing has been received against yet.
+func (s *PurchaseService) Delete(ctx context.Context, id uint) (*purchase.Order, error) {
+	if err := composables.CanUser(ctx, permissions.PurchaseOrderDelete); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(entity.Receipts) > 0 {
+		return nil, purchase.NewErrOrderClosed()
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
@@ -0,0 +1,258 @@
+package purchaseservice_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/country"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/tax"
+	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/bill"
+	category "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
+	journalentry "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/journal_entry"
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/entities/counterparty"
+	financepersistence "github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
+	financepermissions "github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	financeservices "github.com/iota-uz/iota-sdk/modules/finance/services"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/purchase"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/location"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/unit"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/purchaseservice"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+func createUser(t *testing.T, f *testFixtures, email string) user.User {
+	t.Helper()
+	u, err := corepersistence.NewUserRepository().Create(f.ctx, user.New(
+		"John",
+		"Doe",
+		"",
+		"",
+		email,
+		nil,
+		0,
+		user.UILanguageEN,
+		nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestPurchaseService_Receive(t *testing.T) {
+	t.Parallel()
+	f := setupTest(t)
+
+	positionRepo := persistence.NewPositionRepository()
+	productRepo := persistence.NewProductRepository()
+	purchaseRepo := persistence.NewPurchaseRepository(positionRepo)
+	purchaseService := f.app.Service(purchaseservice.PurchaseService{}).(*purchaseservice.PurchaseService)
+	billService := f.app.Service(financeservices.BillService{}).(*financeservices.BillService)
+	ledgerService := f.app.Service(financeservices.LedgerService{}).(*financeservices.LedgerService)
+
+	// the mocked user submits the bill, another user approves it
+	createUser(t, f, "john@gmail.com")
+	approver := createUser(t, f, "jane@gmail.com")
+	if err := corepersistence.NewCurrencyRepository().Create(f.ctx, &currency.USD); err != nil {
+		t.Fatal(err)
+	}
+	tin, err := tax.NewTin("123456789", country.Uzbekistan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	supplier, err := financepersistence.NewCounterpartyRepository().Create(f.ctx, counterparty.New(
+		tin,
+		"Supplier",
+		counterparty.Supplier,
+		counterparty.LLC,
+		"",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expenseCategory, err := financepersistence.NewExpenseCategoryRepository().Create(f.ctx, category.New(
+		"Goods",
+		"",
+		0,
+		&currency.USD,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := persistence.NewUnitRepository().Create(f.ctx, &unit.Unit{
+		ID:         1,
+		Title:      "Test Unit",
+		ShortTitle: "TU",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	positionEntity := &position.Position{
+		ID:        1,
+		Title:     "Test Position",
+		Barcode:   "1234567890",
+		UnitID:    1,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := positionRepo.Create(f.ctx, positionEntity); err != nil {
+		t.Fatal(err)
+	}
+	warehouseEntity := &warehouse.Warehouse{Name: "MAIN", Code: "MAIN", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := persistence.NewWarehouseRepository().Create(f.ctx, warehouseEntity); err != nil {
+		t.Fatal(err)
+	}
+	bin := &location.Location{WarehouseID: warehouseEntity.ID, Kind: location.Bin, Code: "B01"}
+	if err := bin.Attach(warehouseEntity.Code, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := persistence.NewLocationRepository().Create(f.ctx, bin); err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := purchaseService.Create(f.ctx, &purchase.CreateDTO{
+		SupplierID:  supplier.ID(),
+		WarehouseID: warehouseEntity.ID,
+		Currency:    string(currency.UsdCode),
+		Lines:       []*purchase.LineDTO{{PositionID: positionEntity.ID, Quantity: 2, UnitPrice: 10}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lineID := order.Lines[0].ID
+
+	t.Run("Receive", func(t *testing.T) {
+		for i, tc := range []struct {
+			tag    string
+			status purchase.Status
+		}{
+			{"EPS:1", purchase.PartiallyReceived},
+			{"EPS:2", purchase.Received},
+		} {
+			if _, err := purchaseService.Receive(f.ctx, order.ID, &purchase.ReceiveDTO{
+				LocationID: bin.ID,
+				Lines:      []*purchase.ReceiveLineDTO{{LineID: lineID, Tags: tc.tag}},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			stored, err := purchaseService.GetByID(f.ctx, order.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != tc.status {
+				t.Fatalf("expected %s after receipt %d, got %s", tc.status, i+1, stored.Status)
+			}
+			if stored.Lines[0].Received != i+1 {
+				t.Fatalf("expected %d received, got %d", i+1, stored.Lines[0].Received)
+			}
+
+			p, err := productRepo.GetByRfid(f.ctx, tc.tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Status != product.InStock || p.LocationID != bin.ID {
+				t.Fatalf("expected %s to be in stock at %d, got %s at %d", tc.tag, bin.ID, p.Status, p.LocationID)
+			}
+		}
+
+		if _, err := purchaseService.Receive(f.ctx, order.ID, &purchase.ReceiveDTO{
+			LocationID: bin.ID,
+			Lines:      []*purchase.ReceiveLineDTO{{LineID: lineID, Tags: "EPS:3"}},
+		}); err == nil {
+			t.Fatal("expected a received order to reject more goods")
+		}
+	})
+
+	t.Run("Bill", func(t *testing.T) {
+		created, err := billService.Create(f.ctx, &bill.SaveDTO{
+			Number:         "INV-1",
+			CounterpartyID: supplier.ID(),
+			CategoryID:     expenseCategory.ID(),
+			CurrencyCode:   string(currency.UsdCode),
+			Amount:         20,
+			BillDate:       shared.DateOnly(time.Now()),
+			DueDate:        shared.DateOnly(time.Now()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := role.NewWithID(
+			1,
+			"approver",
+			"",
+			[]*permission.Permission{financepermissions.BillApprove},
+			time.Now(),
+			time.Now(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		approverCtx := composables.WithUser(f.ctx, user.NewWithID(
+			approver.ID(),
+			"",
+			"",
+			"",
+			"",
+			"",
+			nil,
+			0,
+			"",
+			"",
+			[]role.Role{r},
+			time.Now(),
+			time.Now(),
+			time.Now(),
+			time.Now(),
+		))
+		approved, err := billService.Approve(approverCtx, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry, err := ledgerService.GetEntryBySource(f.ctx, journalentry.SourceBill, approved.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.TotalDebit() != 20 || entry.TotalCredit() != 20 {
+			t.Fatalf("expected the payable of 20 to be posted, got debit %f and credit %f", entry.TotalDebit(), entry.TotalCredit())
+		}
+
+		// the outbox mirrors the bill into the warehouse once the transaction commits
+		payload := bill.NewStatusChanged(*approved)
+		if err := purchaseRepo.SaveBill(f.ctx, &purchase.Bill{
+			ID:         payload.BillID,
+			SupplierID: payload.CounterpartyID,
+			Number:     payload.Number,
+			Amount:     payload.Amount,
+			Currency:   payload.Currency,
+			Status:     payload.Status,
+			UpdatedAt:  time.Now(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		linked, err := purchaseService.LinkBill(f.ctx, order.ID, &purchase.LinkBillDTO{BillID: approved.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stored, err := purchaseService.GetByID(f.ctx, linked.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m := stored.Match(); !m.Matched() {
+			t.Fatalf("expected the order to match its bill, got %+v", m)
+		}
+	})
+}
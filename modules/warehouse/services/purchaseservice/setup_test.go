@@ -0,0 +1,73 @@
+package purchaseservice_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/modules"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	financepermissions "github.com/iota-uz/iota-sdk/modules/finance/permissions"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/testutils"
+)
+
+func TestMain(m *testing.M) {
+	if err := os.Chdir("../../../../"); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	os.Exit(code)
+}
+
+// testFixtures contains common test dependencies
+type testFixtures struct {
+	ctx  context.Context
+	pool *pgxpool.Pool
+	app  application.Application
+}
+
+// setupTest creates all necessary dependencies for tests
+func setupTest(t *testing.T) *testFixtures {
+	t.Helper()
+
+	testutils.CreateDB(t.Name())
+	pool := testutils.NewPool(testutils.DbOpts(t.Name()))
+
+	ctx := composables.WithUser(context.Background(), testutils.MockUser(
+		permissions.PurchaseOrderCreate,
+		permissions.PurchaseOrderRead,
+		permissions.PurchaseOrderUpdate,
+		financepermissions.BillCreate,
+		financepermissions.BillRead,
+	))
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatal(err)
+		}
+		pool.Close()
+	})
+
+	ctx = composables.WithTx(ctx, tx)
+	ctx = composables.WithSession(ctx, &session.Session{})
+
+	app, err := testutils.SetupApplication(pool, modules.BuiltInModules...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testFixtures{
+		ctx:  ctx,
+		pool: pool,
+		app:  app,
+	}
+}
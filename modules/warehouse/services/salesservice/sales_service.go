@@ -0,0 +1,349 @@
+package salesservice
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/order"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/position"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/sales"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/services/orderservice"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/events"
+)
+
+type SalesService struct {
+	repo          sales.Repository
+	orderRepo     order.Repository
+	productRepo   product.Repository
+	positionRepo  position.Repository
+	warehouseRepo warehouse.Repository
+	orderService  *orderservice.OrderService
+}
+
+func NewSalesService(
+	salesRepo sales.Repository,
+	orderRepo order.Repository,
+	productRepo product.Repository,
+	positionRepo position.Repository,
+	warehouseRepo warehouse.Repository,
+	orderService *orderservice.OrderService,
+) *SalesService {
+	return &SalesService{
+		repo:          salesRepo,
+		orderRepo:     orderRepo,
+		productRepo:   productRepo,
+		positionRepo:  positionRepo,
+		warehouseRepo: warehouseRepo,
+		orderService:  orderService,
+	}
+}
+
+func (s *SalesService) GetByID(ctx context.Context, id uint) (*sales.Order, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *SalesService) GetPaginated(ctx context.Context, params *sales.FindParams) ([]*sales.Order, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *SalesService) Count(ctx context.Context, params *sales.FindParams) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderRead); err != nil {
+		return 0, err
+	}
+	return s.repo.Count(ctx, params)
+}
+
+// Create reserves the ordered quantities against the stock of the warehouse that no other open
+// sales order holds. The warehouse is locked until the transaction ends, so concurrent orders
+// cannot reserve the same stock.
+func (s *SalesService) Create(ctx context.Context, data *sales.CreateDTO) (*sales.Order, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderCreate); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity(u.ID())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.warehouseRepo.Lock(ctx, entity.WarehouseID); err != nil {
+		return nil, err
+	}
+	onHand := make(map[uint]int, len(entity.Lines))
+	for _, line := range entity.Lines {
+		if line.Position, err = s.positionRepo.GetByID(ctx, line.PositionID); err != nil {
+			return nil, err
+		}
+		count, err := s.productRepo.Count(ctx, &product.CountParams{
+			PositionID:  line.PositionID,
+			Status:      product.InStock,
+			WarehouseID: entity.WarehouseID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		onHand[line.PositionID] = int(count)
+	}
+	reserved, err := s.repo.Reserved(ctx, entity.WarehouseID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Reserve(onHand, reserved); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// available lists the in stock products of a position in the warehouse that no open sales order
+// picked, ordered by the path of their location so pickers walk the aisles in sequence.
+func (s *SalesService) available(ctx context.Context, warehouseID, positionID uint, picked map[uint]bool) ([]*product.Product, error) {
+	products, err := s.productRepo.FindByPositionID(ctx, &product.FindByPositionParams{
+		PositionID:  positionID,
+		Status:      product.InStock,
+		WarehouseID: warehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*product.Product, 0, len(products))
+	for _, p := range products {
+		if !picked[p.ID] && p.Location != nil {
+			result = append(result, p)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Location.Path < result[j].Location.Path
+	})
+	return result, nil
+}
+
+// PickingList groups the outstanding quantities of an order by the locations to pick them from.
+func (s *SalesService) PickingList(ctx context.Context, entity *sales.Order) (*sales.PickingList, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderRead); err != nil {
+		return nil, err
+	}
+	picked, err := s.repo.PickedProductIDs(ctx, entity.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	stock := make([]*sales.Availability, 0)
+	for _, line := range entity.Lines {
+		if entity.IsClosed() || entity.Outstanding(line) == 0 {
+			continue
+		}
+		products, err := s.available(ctx, entity.WarehouseID, line.PositionID, picked)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range products {
+			n := len(stock)
+			if n > 0 && stock[n-1].PositionID == p.PositionID && stock[n-1].LocationID == p.LocationID {
+				stock[n-1].Quantity++
+				continue
+			}
+			stock = append(stock, &sales.Availability{
+				PositionID: p.PositionID,
+				LocationID: p.LocationID,
+				Location:   p.Location,
+				Quantity:   1,
+			})
+		}
+	}
+	sort.SliceStable(stock, func(i, j int) bool {
+		return stock[i].Location.Path < stock[j].Location.Path
+	})
+	return entity.PickingList(stock), nil
+}
+
+// resolve finds the product a scanned code stands for. The code is an RFID tag, or else the
+// barcode of a position, in which case the first unpicked product on the picking route is taken.
+func (s *SalesService) resolve(ctx context.Context, entity *sales.Order, code string) (*product.Product, error) {
+	picked, err := s.repo.PickedProductIDs(ctx, entity.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.productRepo.GetByRfid(ctx, code)
+	if err == nil {
+		if picked[p.ID] {
+			return nil, sales.NewErrProductAlreadyPicked(p.Rfid)
+		}
+		return p, nil
+	}
+	if !errors.Is(err, persistence.ErrProductNotFound) {
+		return nil, err
+	}
+	pos, err := s.positionRepo.GetByBarcode(ctx, code)
+	if errors.Is(err, persistence.ErrPositionNotFound) {
+		return nil, sales.NewErrCodeNotFound(code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if line := entity.LineFor(pos.ID); line == nil || entity.Outstanding(line) == 0 {
+		return nil, sales.NewErrProductNotOrdered(code)
+	}
+	products, err := s.available(ctx, entity.WarehouseID, pos.ID, picked)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, sales.NewErrProductNotAvailable(code)
+	}
+	return products[0], nil
+}
+
+// lockOrder locks the warehouse of a sales order and loads the order again, so that picks, shipments and
+// cancellations committed while waiting for the lock are seen.
+func (s *SalesService) lockOrder(ctx context.Context, id uint) (*sales.Order, error) {
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.warehouseRepo.Lock(ctx, entity.WarehouseID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// Pick confirms a product scanned by the picker for a sales order. The warehouse is locked until the
+// transaction ends, so two pickers cannot take the same product.
+func (s *SalesService) Pick(ctx context.Context, id uint, data *sales.PickDTO) (*sales.Pick, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderUpdate); err != nil {
+		return nil, err
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := s.lockOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.IsClosed() {
+		return nil, sales.NewErrOrderClosed()
+	}
+	p, err := s.resolve(ctx, entity, data.Code)
+	if err != nil {
+		return nil, err
+	}
+	pick, err := entity.Pick(p, u.ID())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreatePick(ctx, pick); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return pick, nil
+}
+
+// Ship sends the picked products out with a completed out order and marks them shipped.
+func (s *SalesService) Ship(ctx context.Context, id uint) (*sales.Order, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.lockOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.ReadyToShip(); err != nil {
+		return nil, err
+	}
+
+	outOrder := order.New(order.TypeOut, order.Pending, entity.WarehouseID, 0)
+	productIDs := make([]uint, 0, len(entity.Picks))
+	for _, line := range entity.Lines {
+		products := make([]*product.Product, 0, line.Quantity)
+		for _, productID := range entity.PickedProductIDs(line.ID) {
+			p, err := s.productRepo.GetByID(ctx, productID)
+			if err != nil {
+				return nil, err
+			}
+			// the product may have been moved out by another order since it was picked
+			if p.Status != product.InStock || p.Location == nil || p.Location.WarehouseID != entity.WarehouseID {
+				return nil, sales.NewErrProductNotAvailable(p.Rfid)
+			}
+			products = append(products, p)
+			productIDs = append(productIDs, p.ID)
+		}
+		if err := outOrder.AddItem(line.Position, products...); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.orderRepo.Create(ctx, outOrder); err != nil {
+		return nil, err
+	}
+	if err := eventbus.Enqueue(ctx, events.OrderCreatedTopic, order.NewCreated(outOrder)); err != nil {
+		return nil, err
+	}
+	// the sales order releases its reservation and picks before the out order is checked against them
+	if err := entity.Ship(outOrder.ID()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	if _, err := s.orderService.Complete(ctx, outOrder.ID()); err != nil {
+		return nil, err
+	}
+	if err := s.productRepo.UpdateStatus(ctx, productIDs, product.Shipped); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Cancel releases the reservation of an open sales order.
+func (s *SalesService) Cancel(ctx context.Context, id uint) (*sales.Order, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderUpdate); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// Delete removes a sales order that has not shipped.
+func (s *SalesService) Delete(ctx context.Context, id uint) (*sales.Order, error) {
+	if err := composables.CanUser(ctx, permissions.SalesOrderDelete); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity.Status == sales.Shipped {
+		return nil, sales.NewErrOrderClosed()
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
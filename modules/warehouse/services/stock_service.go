@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/stock"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// StockService reads the stock ledger and records manual corrections,
+// entries for orders and inventory checks are recorded by the services completing them.
+type StockService struct {
+	repo stock.Repository
+}
+
+func NewStockService(repo stock.Repository) *StockService {
+	return &StockService{
+		repo: repo,
+	}
+}
+
+// OnHand is the quantity matching params at params.At.
+func (s *StockService) OnHand(ctx context.Context, params *stock.LevelParams) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.StockRead); err != nil {
+		return 0, err
+	}
+	return s.repo.OnHand(ctx, params)
+}
+
+// Levels is the quantity of every position and location matching params at params.At.
+func (s *StockService) Levels(ctx context.Context, params *stock.LevelParams) ([]*stock.Level, error) {
+	if err := composables.CanUser(ctx, permissions.StockRead); err != nil {
+		return nil, err
+	}
+	return s.repo.Levels(ctx, params)
+}
+
+func (s *StockService) GetPaginated(ctx context.Context, params *stock.FindParams) ([]*stock.Movement, error) {
+	if err := composables.CanUser(ctx, permissions.StockRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *StockService) Count(ctx context.Context, params *stock.FindParams) (int64, error) {
+	if err := composables.CanUser(ctx, permissions.StockRead); err != nil {
+		return 0, err
+	}
+	return s.repo.Count(ctx, params)
+}
+
+// Report is the movement report of a position in [from, to) narrowed down by warehouse and location.
+func (s *StockService) Report(ctx context.Context, params *stock.FindParams) (*stock.Report, error) {
+	if err := composables.CanUser(ctx, permissions.StockRead); err != nil {
+		return nil, err
+	}
+	opening := int64(0)
+	if !params.From.IsZero() {
+		var err error
+		opening, err = s.repo.OnHand(ctx, &stock.LevelParams{
+			At:          params.From,
+			PositionID:  params.PositionID,
+			WarehouseID: params.WarehouseID,
+			LocationID:  params.LocationID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	movements, err := s.repo.GetPaginated(ctx, &stock.FindParams{
+		PositionID:  params.PositionID,
+		WarehouseID: params.WarehouseID,
+		LocationID:  params.LocationID,
+		From:        params.From,
+		To:          params.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stock.Report{
+		PositionID: params.PositionID,
+		From:       params.From,
+		To:         params.To,
+		Opening:    opening,
+		Movements:  movements,
+	}, nil
+}
+
+func (s *StockService) Correct(ctx context.Context, data *stock.CorrectionDTO) (*stock.Movement, error) {
+	if err := composables.CanUser(ctx, permissions.StockUpdate); err != nil {
+		return nil, err
+	}
+	var createdByID uint
+	if u, err := composables.UseUser(ctx); err == nil {
+		createdByID = u.ID()
+	}
+	entity, err := data.ToEntity(createdByID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/entities/warehouse"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+type WarehouseService struct {
+	repo      warehouse.Repository
+	publisher eventbus.EventBus
+}
+
+func NewWarehouseService(
+	repo warehouse.Repository,
+	publisher eventbus.EventBus,
+) *WarehouseService {
+	return &WarehouseService{
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+func (s *WarehouseService) GetByID(ctx context.Context, id uint) (*warehouse.Warehouse, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *WarehouseService) GetAll(ctx context.Context) ([]*warehouse.Warehouse, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAll(ctx)
+}
+
+func (s *WarehouseService) GetPaginated(
+	ctx context.Context, params *warehouse.FindParams,
+) ([]*warehouse.Warehouse, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPaginated(ctx, params)
+}
+
+func (s *WarehouseService) Count(ctx context.Context) (uint, error) {
+	return s.repo.Count(ctx)
+}
+
+func (s *WarehouseService) Create(ctx context.Context, data *warehouse.CreateDTO) (*warehouse.Warehouse, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseCreate); err != nil {
+		return nil, err
+	}
+	entity, err := data.ToEntity()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueCode(ctx, 0, entity.Code); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+	createdEvent, err := warehouse.NewCreatedEvent(ctx, *data, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(createdEvent)
+	return entity, nil
+}
+
+func (s *WarehouseService) Update(ctx context.Context, id uint, data *warehouse.UpdateDTO) error {
+	if err := composables.CanUser(ctx, permissions.WarehouseUpdate); err != nil {
+		return err
+	}
+	entity, err := data.ToEntity(id)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureUniqueCode(ctx, id, entity.Code); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return err
+	}
+	updatedEvent, err := warehouse.NewUpdatedEvent(ctx, *data, *entity)
+	if err != nil {
+		return err
+	}
+	s.publisher.Publish(updatedEvent)
+	return nil
+}
+
+func (s *WarehouseService) Delete(ctx context.Context, id uint) (*warehouse.Warehouse, error) {
+	if err := composables.CanUser(ctx, permissions.WarehouseDelete); err != nil {
+		return nil, err
+	}
+	entity, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.repo.CountProducts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stored > 0 {
+		return nil, warehouse.NewErrNotEmpty()
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	deletedEvent, err := warehouse.NewDeletedEvent(ctx, *entity)
+	if err != nil {
+		return nil, err
+	}
+	s.publisher.Publish(deletedEvent)
+	return entity, nil
+}
+
+func (s *WarehouseService) ensureUniqueCode(ctx context.Context, id uint, code string) error {
+	existing, err := s.repo.GetByCode(ctx, code)
+	if err != nil && !errors.Is(err, persistence.ErrWarehouseNotFound) {
+		return err
+	}
+	if existing != nil && existing.ID != id {
+		return warehouse.NewErrDuplicateCode(code)
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
 	"github.com/iota-uz/iota-sdk/pkg/types"
+	"github.com/iota-uz/iota-sdk/pkg/ws"
 )
 
 func translate(localizer *i18n.Localizer, items []types.NavigationItem) []types.NavigationItem {
@@ -69,10 +70,12 @@ func New(pool *pgxpool.Pool, eventPublisher eventbus.EventBus) Application {
 	return &application{
 		pool:           pool,
 		eventPublisher: eventPublisher,
+		outbox:         eventbus.NewOutbox(pool),
 		rbac:           permission.NewRbac(),
 		controllers:    make(map[string]Controller),
 		services:       make(map[reflect.Type]interface{}),
 		spotlight:      spotlight.New(),
+		websocket:      ws.NewHub(),
 		bundle:         bundle,
 	}
 }
@@ -81,9 +84,11 @@ func New(pool *pgxpool.Pool, eventPublisher eventbus.EventBus) Application {
 type application struct {
 	pool           *pgxpool.Pool
 	eventPublisher eventbus.EventBus
+	outbox         *eventbus.Outbox
 	rbac           permission.RBAC
 	services       map[reflect.Type]interface{}
 	controllers    map[string]Controller
+	jobs           []Job
 	middleware     []mux.MiddlewareFunc
 	hashFsAssets   []*hashfs.FS
 	assets         []*embed.FS
@@ -92,6 +97,7 @@ type application struct {
 	seedFuncs      []SeedFunc
 	bundle         *i18n.Bundle
 	spotlight      spotlight.Spotlight
+	websocket      ws.Hub
 	navItems       []types.NavigationItem
 }
 
@@ -99,6 +105,10 @@ func (app *application) Spotlight() spotlight.Spotlight {
 	return app.spotlight
 }
 
+func (app *application) Websocket() ws.Hub {
+	return app.websocket
+}
+
 func (app *application) NavItems(localizer *i18n.Localizer) []types.NavigationItem {
 	return translate(localizer, app.navItems)
 }
@@ -132,6 +142,14 @@ func (app *application) EventPublisher() eventbus.EventBus {
 	return app.eventPublisher
 }
 
+func (app *application) Outbox() *eventbus.Outbox {
+	return app.outbox
+}
+
+func (app *application) Jobs() []Job {
+	return app.jobs
+}
+
 func (app *application) Controllers() []Controller {
 	controllers := make([]Controller, 0, len(app.controllers))
 	for _, c := range app.controllers {
@@ -162,6 +180,10 @@ func (app *application) RegisterControllers(controllers ...Controller) {
 	}
 }
 
+func (app *application) RegisterJobs(jobs ...Job) {
+	app.jobs = append(app.jobs, jobs...)
+}
+
 func (app *application) RegisterMiddleware(middleware ...mux.MiddlewareFunc) {
 	app.middleware = append(app.middleware, middleware...)
 }
@@ -5,6 +5,7 @@ import (
 	"embed"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
+	"github.com/iota-uz/iota-sdk/pkg/ws"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -24,6 +25,8 @@ type GraphSchema struct {
 type Application interface {
 	DB() *pgxpool.Pool
 	EventPublisher() eventbus.EventBus
+	Outbox() *eventbus.Outbox
+	Jobs() []Job
 	Controllers() []Controller
 	Middleware() []mux.MiddlewareFunc
 	Assets() []*embed.FS
@@ -32,9 +35,11 @@ type Application interface {
 	Seed(ctx context.Context) error
 	RBAC() permission.RBAC
 	Spotlight() spotlight.Spotlight
+	Websocket() ws.Hub
 	NavItems(localizer *i18n.Localizer) []types.NavigationItem
 	RegisterNavItems(items ...types.NavigationItem)
 	RegisterControllers(controllers ...Controller)
+	RegisterJobs(jobs ...Job)
 	RegisterHashFsAssets(fs ...*hashfs.FS)
 	RegisterSeedFuncs(seedFuncs ...SeedFunc)
 	RegisterAssets(fs ...*embed.FS)
@@ -52,6 +57,11 @@ type Application interface {
 
 type SeedFunc func(ctx context.Context, app Application) error
 
+// Job is a background worker. Start blocks until ctx is cancelled.
+type Job interface {
+	Start(ctx context.Context)
+}
+
 type Controller interface {
 	Register(r *mux.Router)
 	Key() string
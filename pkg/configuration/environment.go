@@ -60,6 +60,12 @@ type Configuration struct {
 	PageSize           int           `env:"PAGE_SIZE" envDefault:"25"`
 	MaxPageSize        int           `env:"MAX_PAGE_SIZE" envDefault:"100"`
 	LogLevel           string        `env:"LOG_LEVEL" envDefault:"error"`
+	// Currency the general ledger is kept in
+	BaseCurrency string `env:"BASE_CURRENCY" envDefault:"USD"`
+	// VAT rate in percent applied to new invoice lines
+	VatTax float64 `env:"VAT_TAX" envDefault:"12"`
+	// Directory with DejaVuSans.ttf and DejaVuSans-Bold.ttf used for PDF documents
+	PdfFontsPath string `env:"PDF_FONTS_PATH" envDefault:"/usr/share/fonts/truetype/dejavu"`
 	// Session ID cookie key
 	SidCookieKey        string `env:"SID_COOKIE_KEY" envDefault:"sid"`
 	OauthStateCookieKey string `env:"OAUTH_STATE_COOKIE_KEY" envDefault:"oauthState"`
@@ -70,6 +76,36 @@ type Configuration struct {
 	TwilioPhoneNumber string `env:"TWILIO_PHONE_NUMBER"`
 
 	TelegramBotToken string `env:"TELEGRAM_BOT_TOKEN"`
+
+	// Mailbox notifications are emailed from, the email channel is available only when the address is given
+	SMTPAddress  string `env:"SMTP_ADDRESS"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	SMTPFrom     string `env:"SMTP_FROM"`
+
+	// Telegram bot clients chat with in the CRM, set up only when the token is given
+	CrmTelegramBotToken      string `env:"CRM_TELEGRAM_BOT_TOKEN"`
+	CrmTelegramWebhookURL    string `env:"CRM_TELEGRAM_WEBHOOK_URL"`
+	CrmTelegramWebhookSecret string `env:"CRM_TELEGRAM_WEBHOOK_SECRET"`
+
+	// WhatsApp Business Cloud API, set up only when the phone number id is given
+	WhatsAppPhoneNumberID string `env:"WHATSAPP_PHONE_NUMBER_ID"`
+	WhatsAppAccessToken   string `env:"WHATSAPP_ACCESS_TOKEN"`
+	WhatsAppAppSecret     string `env:"WHATSAPP_APP_SECRET"`
+	WhatsAppVerifyToken   string `env:"WHATSAPP_VERIFY_TOKEN"`
+
+	// Mailbox of the CRM email channel, set up only when the SMTP address is given
+	CrmSMTPAddress       string        `env:"CRM_SMTP_ADDRESS"`
+	CrmIMAPAddress       string        `env:"CRM_IMAP_ADDRESS"`
+	CrmEmailUsername     string        `env:"CRM_EMAIL_USERNAME"`
+	CrmEmailPassword     string        `env:"CRM_EMAIL_PASSWORD"`
+	CrmEmailFrom         string        `env:"CRM_EMAIL_FROM"`
+	CrmEmailSubject      string        `env:"CRM_EMAIL_SUBJECT" envDefault:"New message"`
+	CrmEmailPollInterval time.Duration `env:"CRM_EMAIL_POLL_INTERVAL" envDefault:"1m"`
+
+	// Time operators have to answer a client and to close a chat
+	CrmFirstResponseSLA time.Duration `env:"CRM_FIRST_RESPONSE_SLA" envDefault:"1h"`
+	CrmResolutionSLA    time.Duration `env:"CRM_RESOLUTION_SLA" envDefault:"24h"`
 }
 
 func (c *Configuration) LogrusLogLevel() logrus.Level {
@@ -0,0 +1,329 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+const (
+	outboxBatchSize    = 50
+	outboxPollInterval = 2 * time.Second
+	outboxMaxAttempts  = 10
+	outboxBaseBackoff  = 5 * time.Second
+	outboxMaxBackoff   = time.Hour
+	outboxLease        = 5 * time.Minute
+)
+
+const (
+	outboxInsertQuery = `INSERT INTO outbox_events (topic, payload) VALUES ($1, $2)`
+	outboxNextQuery   = `
+		SELECT id, topic, payload, attempts
+		FROM outbox_events
+		WHERE processed_at IS NULL AND failed_at IS NULL AND next_attempt_at <= $1
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+	outboxDeliveredQuery      = `SELECT subscriber FROM outbox_deliveries WHERE event_id = $1`
+	outboxDeliveryInsertQuery = `
+		INSERT INTO outbox_deliveries (event_id, subscriber) VALUES ($1, $2)
+		ON CONFLICT (event_id, subscriber) DO NOTHING`
+	outboxLeaseQuery     = `UPDATE outbox_events SET next_attempt_at = $1 WHERE id = $2`
+	outboxProcessedQuery = `UPDATE outbox_events SET processed_at = $1, last_error = NULL WHERE id = $2`
+	outboxRetryQuery     = `
+		UPDATE outbox_events
+		SET attempts = $1, next_attempt_at = $2, last_error = $3, failed_at = $4
+		WHERE id = $5`
+)
+
+// Topic names a durable event and the type of its payload. The payload is stored as JSON, so
+// it should carry identifiers and plain values rather than aggregates.
+type Topic[T any] struct {
+	Name string
+}
+
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{Name: name}
+}
+
+// Enqueue stores an event in the outbox using the transaction in ctx, so the event is recorded
+// if and only if the change that raised it is committed. It fails with composables.ErrNoTx when ctx
+// carries no transaction.
+func Enqueue[T any](ctx context.Context, topic Topic[T], payload T) error {
+	tx, ok := ctx.Value(constants.TxKey).(pgx.Tx)
+	if !ok {
+		return fmt.Errorf("failed to enqueue %s event: %w", topic.Name, composables.ErrNoTx)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", topic.Name, err)
+	}
+	if _, err := tx.Exec(ctx, outboxInsertQuery, topic.Name, data); err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", topic.Name, err)
+	}
+	return nil
+}
+
+type outboxHandler struct {
+	subscriber string
+	external   bool
+	handle     func(ctx context.Context, payload []byte) error
+}
+
+// Outbox delivers the enqueued events to their subscribers with at-least-once semantics.
+// Every event is dispatched in its own short transaction. A subscriber runs in a savepoint of it
+// with the transaction in its context, so the database work of a handler is committed together
+// with its delivery. External subscribers run after that transaction is committed, see SubscribeExternal.
+// A failing subscriber is retried with exponential backoff without running the subscribers
+// that already succeeded again; after outboxMaxAttempts the event is marked as failed.
+type Outbox struct {
+	pool     *pgxpool.Pool
+	mu       sync.RWMutex
+	handlers map[string][]outboxHandler
+}
+
+func NewOutbox(pool *pgxpool.Pool) *Outbox {
+	return &Outbox{
+		pool:     pool,
+		handlers: make(map[string][]outboxHandler),
+	}
+}
+
+// Subscribe registers a handler for a topic. The subscriber name identifies the handler in the
+// delivery log and must stay stable across releases, otherwise pending events are delivered again.
+func Subscribe[T any](o *Outbox, topic Topic[T], subscriber string, handler func(ctx context.Context, payload T) error) {
+	subscribe(o, topic, subscriber, false, handler)
+}
+
+// SubscribeExternal registers a handler that calls an external system, e.g. sends a message. It runs
+// outside of any transaction, with only the pool in its context, so a slow call does not hold the event
+// locked. The delivery is recorded after the handler returns, so the call is repeated if the process
+// stops in between.
+func SubscribeExternal[T any](o *Outbox, topic Topic[T], subscriber string, handler func(ctx context.Context, payload T) error) {
+	subscribe(o, topic, subscriber, true, handler)
+}
+
+func subscribe[T any](
+	o *Outbox, topic Topic[T], subscriber string, external bool, handler func(ctx context.Context, payload T) error,
+) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.handlers[topic.Name] = append(o.handlers[topic.Name], outboxHandler{
+		subscriber: subscriber,
+		external:   external,
+		handle: func(ctx context.Context, data []byte) error {
+			var payload T
+			if err := json.Unmarshal(data, &payload); err != nil {
+				return fmt.Errorf("failed to decode %s event: %w", topic.Name, err)
+			}
+			return handler(ctx, payload)
+		},
+	})
+}
+
+func (o *Outbox) subscribers(topic string) []outboxHandler {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.handlers[topic]
+}
+
+// Start dispatches pending events until ctx is cancelled.
+func (o *Outbox) Start(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := o.Dispatch(ctx)
+			if err != nil {
+				log.Printf("Error dispatching outbox events: %v", err)
+				break
+			}
+			if n < outboxBatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type outboxEvent struct {
+	id       uint
+	topic    string
+	payload  []byte
+	attempts int
+}
+
+// Dispatch delivers up to a batch of due events, each in its own transaction, and returns how many
+// events it handled.
+func (o *Outbox) Dispatch(ctx context.Context) (int, error) {
+	for n := 0; n < outboxBatchSize; n++ {
+		found, err := o.dispatchNext(ctx)
+		if err != nil {
+			return n, err
+		}
+		if !found {
+			return n, nil
+		}
+	}
+	return outboxBatchSize, nil
+}
+
+// dispatchNext delivers the next due event and reports false when there is none. The event is locked
+// while its transactional subscribers run. When external subscribers are left, the event is leased for
+// outboxLease instead, so other dispatchers skip it while they are called outside of the transaction.
+// Errors of the subscribers are recorded on the event; only database errors of the outbox itself are returned.
+func (o *Outbox) dispatchNext(ctx context.Context) (bool, error) {
+	var e *outboxEvent
+	var external []outboxHandler
+	var failure error
+	err := pgx.BeginFunc(ctx, o.pool, func(tx pgx.Tx) error {
+		var err error
+		e, err = o.next(ctx, tx)
+		if err != nil || e == nil {
+			return err
+		}
+		delivered, err := o.delivered(ctx, tx, e.id)
+		if err != nil {
+			return err
+		}
+		for _, h := range o.subscribers(e.topic) {
+			if delivered[h.subscriber] {
+				continue
+			}
+			if h.external {
+				external = append(external, h)
+				continue
+			}
+			if err := o.run(ctx, tx, e, h); err != nil {
+				log.Printf("Error delivering %s event %d to %s: %v", e.topic, e.id, h.subscriber, err)
+				failure = err
+			}
+		}
+		if len(external) == 0 {
+			return o.finish(ctx, tx, e, failure)
+		}
+		_, err = tx.Exec(ctx, outboxLeaseQuery, time.Now().Add(outboxLease), e.id)
+		return err
+	})
+	if err != nil || e == nil || len(external) == 0 {
+		return e != nil, err
+	}
+
+	var succeeded []string
+	for _, h := range external {
+		if err := o.call(composables.WithPool(ctx, o.pool), e, h); err != nil {
+			log.Printf("Error delivering %s event %d to %s: %v", e.topic, e.id, h.subscriber, err)
+			failure = err
+			continue
+		}
+		succeeded = append(succeeded, h.subscriber)
+	}
+	return true, pgx.BeginFunc(ctx, o.pool, func(tx pgx.Tx) error {
+		for _, subscriber := range succeeded {
+			if _, err := tx.Exec(ctx, outboxDeliveryInsertQuery, e.id, subscriber); err != nil {
+				return err
+			}
+		}
+		return o.finish(ctx, tx, e, failure)
+	})
+}
+
+func (o *Outbox) next(ctx context.Context, tx pgx.Tx) (*outboxEvent, error) {
+	e := &outboxEvent{}
+	err := tx.QueryRow(ctx, outboxNextQuery, time.Now()).Scan(&e.id, &e.topic, &e.payload, &e.attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (o *Outbox) delivered(ctx context.Context, tx pgx.Tx, eventID uint) (map[string]bool, error) {
+	rows, err := tx.Query(ctx, outboxDeliveredQuery, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	delivered := make(map[string]bool)
+	for rows.Next() {
+		var subscriber string
+		if err := rows.Scan(&subscriber); err != nil {
+			return nil, err
+		}
+		delivered[subscriber] = true
+	}
+	return delivered, rows.Err()
+}
+
+// finish marks the event as processed, or schedules the next attempt when a subscriber failed.
+func (o *Outbox) finish(ctx context.Context, tx pgx.Tx, e *outboxEvent, failure error) error {
+	if failure == nil {
+		_, err := tx.Exec(ctx, outboxProcessedQuery, time.Now(), e.id)
+		return err
+	}
+	attempts := e.attempts + 1
+	var failedAt *time.Time
+	if attempts >= outboxMaxAttempts {
+		now := time.Now()
+		failedAt = &now
+	}
+	_, err := tx.Exec(ctx, outboxRetryQuery, attempts, time.Now().Add(outboxBackoff(attempts)), failure.Error(), failedAt, e.id)
+	return err
+}
+
+func (o *Outbox) run(ctx context.Context, tx pgx.Tx, e *outboxEvent, h outboxHandler) (err error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+		if err != nil {
+			_ = savepoint.Rollback(ctx)
+		}
+	}()
+	if err := h.handle(composables.WithTx(ctx, savepoint), e.payload); err != nil {
+		return err
+	}
+	if _, err := savepoint.Exec(ctx, outboxDeliveryInsertQuery, e.id, h.subscriber); err != nil {
+		return err
+	}
+	return savepoint.Commit(ctx)
+}
+
+// call runs an external subscriber outside of any transaction.
+func (o *Outbox) call(ctx context.Context, e *outboxEvent, h outboxHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return h.handle(ctx, e.payload)
+}
+
+// outboxBackoff is the delay before the given attempt: it doubles from outboxBaseBackoff up to outboxMaxBackoff.
+func outboxBackoff(attempt int) time.Duration {
+	d := outboxBaseBackoff
+	for i := 1; i < attempt && d < outboxMaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, outboxMaxBackoff)
+}
@@ -0,0 +1,88 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type outboxPayload struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestOutbox_Subscribe(t *testing.T) {
+	outbox := NewOutbox(nil)
+	topic := NewTopic[outboxPayload]("test.created")
+	var got outboxPayload
+	Subscribe(outbox, topic, "test", func(ctx context.Context, payload outboxPayload) error {
+		got = payload
+		return nil
+	})
+	handlers := outbox.subscribers(topic.Name)
+	if len(handlers) != 1 {
+		t.Fatalf("expected 1 subscriber, got %d", len(handlers))
+	}
+	if handlers[0].subscriber != "test" {
+		t.Errorf("expected: %v, got: %v", "test", handlers[0].subscriber)
+	}
+	if err := handlers[0].handle(context.Background(), []byte(`{"id":7,"name":"invoice"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 7 || got.Name != "invoice" {
+		t.Errorf("unexpected payload: %+v", got)
+	}
+	if err := handlers[0].handle(context.Background(), []byte(`{"id":"x"}`)); err == nil {
+		t.Error("expected a decoding error")
+	}
+	if len(outbox.subscribers("test.deleted")) != 0 {
+		t.Error("expected no subscribers for another topic")
+	}
+}
+
+func TestOutbox_SubscribeExternal(t *testing.T) {
+	outbox := NewOutbox(nil)
+	topic := NewTopic[outboxPayload]("test.created")
+	handler := func(ctx context.Context, payload outboxPayload) error {
+		return nil
+	}
+	Subscribe(outbox, topic, "local", handler)
+	SubscribeExternal(outbox, topic, "external", handler)
+	handlers := outbox.subscribers(topic.Name)
+	if len(handlers) != 2 {
+		t.Fatalf("expected 2 subscribers, got %d", len(handlers))
+	}
+	if handlers[0].external || !handlers[1].external {
+		t.Errorf("unexpected subscribers: %+v", handlers)
+	}
+}
+
+func TestEnqueue_RequiresTx(t *testing.T) {
+	topic := NewTopic[outboxPayload]("test.created")
+	ctx := composables.WithPool(context.Background(), nil)
+	if err := Enqueue(ctx, topic, outboxPayload{ID: 1}); !errors.Is(err, composables.ErrNoTx) {
+		t.Errorf("expected %v, got %v", composables.ErrNoTx, err)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{3, 20 * time.Second},
+		{10, 2560 * time.Second},
+		{11, time.Hour},
+		{50, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := outboxBackoff(tt.attempt); got != tt.want {
+			t.Errorf("outboxBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
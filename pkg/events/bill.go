@@ -0,0 +1,18 @@
+package events
+
+import "github.com/iota-uz/iota-sdk/pkg/eventbus"
+
+// BillStatusChangedTopic is enqueued by the finance module when a bill is submitted, approved or
+// rejected, so its subscribers receive every step of the workflow at least once.
+var BillStatusChangedTopic = eventbus.NewTopic[BillStatusChanged]("finance.bill.status_changed")
+
+type BillStatusChanged struct {
+	BillID         uint    `json:"billId"`
+	CounterpartyID uint    `json:"counterpartyId"`
+	Number         string  `json:"number"`
+	Status         string  `json:"status"`
+	Amount         float64 `json:"amount"`
+	Currency       string  `json:"currency"`
+	SubmittedBy    uint    `json:"submittedBy"`
+	Reason         string  `json:"reason"`
+}
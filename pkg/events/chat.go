@@ -0,0 +1,41 @@
+package events
+
+import "github.com/iota-uz/iota-sdk/pkg/eventbus"
+
+// ChatMessageAddedTopic is enqueued by the CRM module for every message added to a chat, its subscribers
+// receive each message at least once.
+var ChatMessageAddedTopic = eventbus.NewTopic[ChatMessageAdded]("crm.chat.message_added")
+
+// ChatAssignedTopic is enqueued by the CRM module when a chat is given to another user or team.
+var ChatAssignedTopic = eventbus.NewTopic[ChatAssigned]("crm.chat.assigned")
+
+// ChatSLABreachedTopic is enqueued by the CRM module once for every SLA a chat misses.
+var ChatSLABreachedTopic = eventbus.NewTopic[ChatSLABreached]("crm.chat.sla_breached")
+
+// ChatMessageAdded is the payload of a message added to a chat.
+type ChatMessageAdded struct {
+	ChatID     uint   `json:"chatId"`
+	ClientID   uint   `json:"clientId"`
+	Channel    string `json:"channel"`
+	Message    string `json:"message"`
+	FromClient bool   `json:"fromClient"`
+	AssigneeID uint   `json:"assigneeId,omitempty"`
+	TeamID     uint   `json:"teamId,omitempty"`
+}
+
+// ChatAssigned is the payload of a chat assigned to a user or a team.
+type ChatAssigned struct {
+	ChatID     uint `json:"chatId"`
+	ClientID   uint `json:"clientId"`
+	AssigneeID uint `json:"assigneeId,omitempty"`
+	TeamID     uint `json:"teamId,omitempty"`
+}
+
+// ChatSLABreached is the payload of a chat that missed an SLA of the given kind.
+type ChatSLABreached struct {
+	ChatID     uint   `json:"chatId"`
+	ClientID   uint   `json:"clientId"`
+	Kind       string `json:"kind"`
+	AssigneeID uint   `json:"assigneeId,omitempty"`
+	TeamID     uint   `json:"teamId,omitempty"`
+}
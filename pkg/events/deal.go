@@ -0,0 +1,30 @@
+package events
+
+import "github.com/iota-uz/iota-sdk/pkg/eventbus"
+
+// DealConvertedTopic is enqueued by the CRM module when a won deal is converted,
+// finance drafts an invoice and warehouse opens an order depending on the target.
+var DealConvertedTopic = eventbus.NewTopic[DealConverted]("crm.deal.converted")
+
+// DealConverted is the payload of a won deal converted into an invoice or an order.
+type DealConverted struct {
+	DealID      uint    `json:"dealId"`
+	Target      string  `json:"target"`
+	Title       string  `json:"title"`
+	ClientID    uint    `json:"clientId"`
+	ClientName  string  `json:"clientName"`
+	ClientPhone string  `json:"clientPhone"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	ConvertedBy uint    `json:"convertedBy"`
+}
+
+// DealInvoicedTopic is enqueued by the finance module to tell the CRM module which counterparty the
+// invoice of a deal went to, so the payments of the counterparty show up on the client.
+var DealInvoicedTopic = eventbus.NewTopic[DealInvoiced]("finance.deal.invoiced")
+
+type DealInvoiced struct {
+	DealID         uint `json:"dealId"`
+	ClientID       uint `json:"clientId"`
+	CounterpartyID uint `json:"counterpartyId"`
+}
@@ -0,0 +1,4 @@
+// Package events defines the outbox topics that cross module boundaries together with their payloads,
+// so the module that enqueues an event and the modules that subscribe to it share a single definition.
+// Payloads carry identifiers and plain values only, the package must not import any module.
+package events
@@ -0,0 +1,19 @@
+package events
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// InventoryApprovedTopic is enqueued by the warehouse module once an inventory check is approved
+// and the stock is adjusted, finance posts the write-off as an expense.
+var InventoryApprovedTopic = eventbus.NewTopic[InventoryApproved]("warehouse.inventory.approved")
+
+// InventoryApproved carries the value of the products written off by the check.
+type InventoryApproved struct {
+	CheckID    uint      `json:"checkId"`
+	Name       string    `json:"name"`
+	WriteOff   float64   `json:"writeOff"`
+	ApprovedAt time.Time `json:"approvedAt"`
+}
@@ -0,0 +1,16 @@
+package events
+
+import "github.com/iota-uz/iota-sdk/pkg/eventbus"
+
+// NotificationDeliveryTopic is enqueued by the core module for notifications rendered for the email and
+// Telegram channels, so they are sent only after the change that raised them is committed and retried on failure.
+var NotificationDeliveryTopic = eventbus.NewTopic[NotificationDelivery]("core.notification_delivery")
+
+// NotificationDelivery is the payload of a notification sent to an address of a channel.
+type NotificationDelivery struct {
+	Channel string `json:"channel"`
+	To      string `json:"to"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	Link    string `json:"link"`
+}
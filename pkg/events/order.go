@@ -0,0 +1,24 @@
+package events
+
+import "github.com/iota-uz/iota-sdk/pkg/eventbus"
+
+// OrderCreatedTopic is enqueued by the warehouse module once an order is created.
+var OrderCreatedTopic = eventbus.NewTopic[OrderCreated]("warehouse.order.created")
+
+// OrderCreated carries the CRM client and deal of the orders opened for converted deals.
+type OrderCreated struct {
+	OrderID  uint   `json:"orderId"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	ClientID uint   `json:"clientId,omitempty"`
+	DealID   uint   `json:"dealId,omitempty"`
+}
+
+// OrderCompletedTopic is enqueued by the warehouse module once an order is completed and the stock is moved.
+var OrderCompletedTopic = eventbus.NewTopic[OrderCompleted]("warehouse.order.completed")
+
+type OrderCompleted struct {
+	OrderID  uint   `json:"orderId"`
+	Type     string `json:"type"`
+	Quantity int    `json:"quantity"`
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// PaymentSavedTopic is enqueued by the finance module when a payment is created or changed.
+var PaymentSavedTopic = eventbus.NewTopic[PaymentSaved]("finance.payment.saved")
+
+// PaymentRemovedTopic is enqueued by the finance module when a payment is deleted.
+var PaymentRemovedTopic = eventbus.NewTopic[PaymentRemoved]("finance.payment.removed")
+
+type PaymentSaved struct {
+	PaymentID       uint      `json:"paymentId"`
+	CounterpartyID  uint      `json:"counterpartyId"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	Comment         string    `json:"comment"`
+	TransactionDate time.Time `json:"transactionDate"`
+}
+
+type PaymentRemoved struct {
+	PaymentID uint `json:"paymentId"`
+}
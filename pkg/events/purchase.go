@@ -0,0 +1,24 @@
+package events
+
+import "github.com/iota-uz/iota-sdk/pkg/eventbus"
+
+// PurchaseCreatedTopic is enqueued by the warehouse module once a purchase order is placed,
+// finance answers it with PurchaseSupplierTopic.
+var PurchaseCreatedTopic = eventbus.NewTopic[PurchaseCreated]("warehouse.purchase.created")
+
+type PurchaseCreated struct {
+	PurchaseOrderID uint    `json:"purchaseOrderId"`
+	SupplierID      uint    `json:"supplierId"`
+	Currency        string  `json:"currency"`
+	Total           float64 `json:"total"`
+}
+
+// PurchaseSupplierTopic is enqueued by the finance module to tell the warehouse module who the supplier
+// of a purchase order is, so purchase orders show the counterparty without reading the finance tables.
+var PurchaseSupplierTopic = eventbus.NewTopic[PurchaseSupplier]("finance.purchase.supplier")
+
+type PurchaseSupplier struct {
+	CounterpartyID uint   `json:"counterpartyId"`
+	Name           string `json:"name"`
+	Tin            string `json:"tin"`
+}
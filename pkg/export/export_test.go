@@ -0,0 +1,48 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/pkg/export"
+	"github.com/xuri/excelize/v2"
+)
+
+func table() *export.Table {
+	t := &export.Table{Header: []string{"Account", "Jan", "Feb"}}
+	t.Append("Sales", 1200.5, 300.0)
+	t.Append("Rent, office", -50.0, nil)
+	return t
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := export.WriteCSV(&buf, table()); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Account,Jan,Feb\nSales,1200.50,300.00\n\"Rent, office\",-50.00,\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := export.WriteXLSX(&buf, table()); err != nil {
+		t.Fatal(err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	if rows[1][0] != "Sales" || rows[1][1] != "1200.5" {
+		t.Errorf("unexpected row: %v", rows[1])
+	}
+}
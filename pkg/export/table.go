@@ -0,0 +1,52 @@
+// Package export writes tabular data to downloadable file formats.
+package export
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// Table is a header followed by rows of cells. Cells may be strings or numbers;
+// numbers are kept as numbers in spreadsheets and printed with two decimals in CSV.
+type Table struct {
+	Header []string
+	Rows   [][]any
+}
+
+func (t *Table) Append(cells ...any) {
+	t.Rows = append(t.Rows, cells)
+}
+
+func (f Format) IsValid() bool {
+	return f == CSV || f == XLSX
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// Write sends the table as an attachment named filename with the extension of the format.
+func Write(w http.ResponseWriter, format Format, filename string, table *Table) error {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+"."+string(format)))
+	switch format {
+	case CSV:
+		return WriteCSV(w, table)
+	case XLSX:
+		return WriteXLSX(w, table)
+	}
+	return fmt.Errorf("unsupported export format: %s", format)
+}
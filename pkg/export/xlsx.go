@@ -0,0 +1,37 @@
+package export
+
+import (
+	"io"
+	"log"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const sheetName = "Sheet1"
+
+func WriteXLSX(w io.Writer, table *Table) error {
+	f := excelize.NewFile()
+	defer func(f *excelize.File) {
+		if err := f.Close(); err != nil {
+			log.Println(err)
+		}
+	}(f)
+	header := make([]any, len(table.Header))
+	for i, h := range table.Header {
+		header[i] = h
+	}
+	if err := f.SetSheetRow(sheetName, "A1", &header); err != nil {
+		return err
+	}
+	for i, row := range table.Rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := f.SetSheetRow(sheetName, cell, &row); err != nil {
+			return err
+		}
+	}
+	_, err := f.WriteTo(w)
+	return err
+}
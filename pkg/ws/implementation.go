@@ -0,0 +1,275 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+const (
+	// UserRoom is the kind of the rooms of a single user, only the user may subscribe to them
+	UserRoom = "user"
+
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = pongWait * 9 / 10
+	// Messages queued for a connection before it is dropped as too slow
+	sendBufferSize = 256
+	maxMessageSize = 4096
+)
+
+// Room returns the name of the room of the kind scoped to the entity with the ID.
+func Room(kind string, id uint) string {
+	return fmt.Sprintf("%s:%d", kind, id)
+}
+
+// RequirePermissions allows users that have all the permissions to subscribe.
+func RequirePermissions(perms ...*permission.Permission) Authorizer {
+	return func(_ context.Context, u user.User, _ string) bool {
+		for _, p := range perms {
+			if !u.Can(p) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// subscriptionDTO is the message a page sends to change its subscriptions.
+// htmx sends the values of the ws-send element along with the request headers.
+type subscriptionDTO struct {
+	Subscribe   string `json:"subscribe"`
+	Unsubscribe string `json:"unsubscribe"`
+}
+
+type connection struct {
+	conn    *websocket.Conn
+	user    user.User
+	session *session.Session
+	send    chan []byte
+	// rooms is only used by the reading goroutine of the connection
+	rooms map[string]struct{}
+}
+
+type hub struct {
+	upgrader    websocket.Upgrader
+	mu          sync.RWMutex
+	rooms       map[string]map[*connection]struct{}
+	authorizers map[string]Authorizer
+}
+
+// NewHub creates a hub that accepts connections from the origin of the application only.
+func NewHub() Hub {
+	h := &hub{
+		rooms:       make(map[string]map[*connection]struct{}),
+		authorizers: make(map[string]Authorizer),
+	}
+	h.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkOrigin,
+	}
+	h.RegisterRoom(UserRoom, func(_ context.Context, u user.User, id string) bool {
+		return id == strconv.FormatUint(uint64(u.ID()), 10)
+	})
+	return h
+}
+
+// checkOrigin accepts requests from the configured origin and from the host serving the request.
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	allowed, err := url.Parse(configuration.Use().Origin)
+	return err == nil && strings.EqualFold(u.Scheme, allowed.Scheme) && strings.EqualFold(u.Host, allowed.Host)
+}
+
+func (h *hub) RegisterRoom(kind string, authorize Authorizer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.authorizers[kind] = authorize
+}
+
+// authorized checks the authorizer registered for the kind of the room.
+func (h *hub) authorized(ctx context.Context, u user.User, room string) bool {
+	kind, id, _ := strings.Cut(room, ":")
+	h.mu.RLock()
+	authorize, ok := h.authorizers[kind]
+	h.mu.RUnlock()
+	return ok && authorize(ctx, u, id)
+}
+
+func (h *hub) subscribe(ctx context.Context, c *connection, room string) {
+	if _, ok := c.rooms[room]; ok {
+		return
+	}
+	if !h.authorized(ctx, c.user, room) {
+		log.Printf("User %d is not allowed to subscribe to %q", c.user.ID(), room)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.rooms[room] == nil {
+		h.rooms[room] = make(map[*connection]struct{})
+	}
+	h.rooms[room][c] = struct{}{}
+	c.rooms[room] = struct{}{}
+}
+
+func (h *hub) unsubscribe(c *connection, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(c.rooms, room)
+	delete(h.rooms[room], c)
+	if len(h.rooms[room]) == 0 {
+		delete(h.rooms, room)
+	}
+}
+
+// remove unsubscribes the connection from all its rooms and stops its writing goroutine.
+func (h *hub) remove(c *connection) {
+	for room := range c.rooms {
+		h.unsubscribe(c, room)
+	}
+	close(c.send)
+}
+
+func (h *hub) Broadcast(room string, message []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.rooms[room] {
+		select {
+		case c.send <- message:
+		default:
+			log.Printf("Dropping slow websocket connection of user %d", c.user.ID())
+			_ = c.conn.Close()
+		}
+	}
+}
+
+// ServeHTTP upgrades the request of an authenticated user and subscribes the connection to the
+// rooms in the "room" query parameters. Subscriptions made in the query are restored when htmx
+// reconnects, the ones sent as messages have to be sent again.
+func (h *hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u, err := composables.UseUser(r.Context())
+	if err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	sess, err := composables.UseSession(r.Context())
+	if err != nil || sess.IsExpired() {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Error upgrading connection: %v", err)
+		return
+	}
+	c := &connection{
+		conn:    conn,
+		user:    u,
+		session: sess,
+		send:    make(chan []byte, sendBufferSize),
+		rooms:   make(map[string]struct{}),
+	}
+	// The request context is cancelled once the handler returns, the values are still needed by
+	// the authorizers.
+	ctx := context.WithoutCancel(r.Context())
+	for _, room := range r.URL.Query()["room"] {
+		h.subscribe(ctx, c, room)
+	}
+	go h.writePump(c)
+	go h.readPump(ctx, c)
+}
+
+// readPump handles the subscription messages of the connection until it is closed.
+func (h *hub) readPump(ctx context.Context, c *connection) {
+	defer func() {
+		h.remove(c)
+		_ = c.conn.Close()
+	}()
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Printf("Error reading message: %v", err)
+			}
+			return
+		}
+		var dto subscriptionDTO
+		if err := json.Unmarshal(message, &dto); err != nil {
+			log.Printf("Error decoding websocket message: %v", err)
+			continue
+		}
+		if dto.Unsubscribe != "" {
+			h.unsubscribe(c, dto.Unsubscribe)
+		}
+		if dto.Subscribe != "" {
+			h.subscribe(ctx, c, dto.Subscribe)
+		}
+	}
+}
+
+// writePump writes the broadcast messages and pings the connection, which is closed when the
+// session of the user expires.
+func (h *hub) writePump(c *connection) {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+	for {
+		select {
+		case message, ok := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if c.session.IsExpired() {
+				_ = c.conn.WriteMessage(
+					websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "session expired"),
+				)
+				return
+			}
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package ws pushes real-time updates to the pages of authenticated users over websockets.
+// A page subscribes to rooms, like a chat, a user or any other entity, and receives the
+// HTML fragments broadcast to them, which htmx swaps in by id.
+package ws
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+)
+
+// Authorizer decides whether the user may receive the updates of the room with the given ID.
+// The ID is empty for rooms that are not scoped to an entity.
+type Authorizer func(ctx context.Context, u user.User, id string) bool
+
+// Hub serves the websocket connections of authenticated users. It expects the user and the
+// session in the request context, put there by middleware.Authorize and middleware.ProvideUser.
+type Hub interface {
+	http.Handler
+	// RegisterRoom allows subscriptions to the rooms of the kind, rooms of unregistered kinds are refused.
+	RegisterRoom(kind string, authorize Authorizer)
+	// Broadcast sends the message to every connection subscribed to the room.
+	Broadcast(room string, message []byte)
+}
@@ -0,0 +1,159 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var chatRead = &permission.Permission{
+	ID:       uuid.New(),
+	Name:     "Chat.Read",
+	Resource: "chat",
+	Action:   permission.ActionRead,
+	Modifier: permission.ModifierAll,
+}
+
+func newUser(t *testing.T, id uint, perms ...*permission.Permission) user.User {
+	t.Helper()
+	r, err := role.New("test", "", perms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	return user.NewWithID(id, "John", "Doe", "", "", "", nil, 0, "", user.UILanguageEN, []role.Role{r}, now, now, now, now)
+}
+
+// newServer serves the hub as the user, or as an anonymous visitor when the user is nil.
+func newServer(h Hub, u user.User) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if u != nil {
+			ctx = composables.WithUser(ctx, u)
+			ctx = composables.WithSession(ctx, &session.Session{UserID: u.ID(), ExpiresAt: time.Now().Add(time.Hour)})
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	}))
+}
+
+func dial(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+// waitSubscribers waits until the room has the number of subscribers.
+func waitSubscribers(t *testing.T, h *hub, room string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		h.mu.RLock()
+		count := len(h.rooms[room])
+		h.mu.RUnlock()
+		if count == n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d subscribers of %q", n, room)
+}
+
+func read(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(message)
+}
+
+func TestHub_Rooms(t *testing.T) {
+	h := NewHub().(*hub)
+	h.RegisterRoom("chat", RequirePermissions(chatRead))
+	server := newServer(h, newUser(t, 1, chatRead))
+	defer server.Close()
+
+	conn := dial(t, server, "?room=chat:1&room=user:2&room=order:1")
+	if err := conn.WriteJSON(map[string]string{"subscribe": Room(UserRoom, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	waitSubscribers(t, h, "chat:1", 1)
+	waitSubscribers(t, h, "user:1", 1)
+	waitSubscribers(t, h, "user:2", 0)
+	waitSubscribers(t, h, "order:1", 0)
+
+	h.Broadcast("chat:2", []byte("other chat"))
+	h.Broadcast("chat:1", []byte("chat"))
+	if got := read(t, conn); got != "chat" {
+		t.Errorf("expected %q, got %q", "chat", got)
+	}
+
+	if err := conn.WriteJSON(map[string]string{"unsubscribe": "chat:1"}); err != nil {
+		t.Fatal(err)
+	}
+	waitSubscribers(t, h, "chat:1", 0)
+
+	_ = conn.Close()
+	waitSubscribers(t, h, "user:1", 0)
+}
+
+func TestHub_RequirePermissions(t *testing.T) {
+	h := NewHub().(*hub)
+	h.RegisterRoom("chat", RequirePermissions(chatRead))
+	server := newServer(h, newUser(t, 1))
+	defer server.Close()
+
+	conn := dial(t, server, "?room=user:1")
+	if err := conn.WriteJSON(map[string]string{"subscribe": "chat:1"}); err != nil {
+		t.Fatal(err)
+	}
+	waitSubscribers(t, h, "user:1", 1)
+	waitSubscribers(t, h, "chat:1", 0)
+}
+
+func TestHub_Unauthenticated(t *testing.T) {
+	server := newServer(NewHub(), nil)
+	defer server.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err == nil {
+		t.Fatal("expected the connection to be refused")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %v", http.StatusUnauthorized, resp)
+	}
+}
+
+func TestHub_CheckOrigin(t *testing.T) {
+	server := newServer(NewHub(), newUser(t, 1))
+	defer server.Close()
+
+	header := http.Header{"Origin": []string{"https://attacker.example"}}
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+	if err == nil {
+		t.Fatal("expected the connection to be refused")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status %d, got %v", http.StatusForbidden, resp)
+	}
+}